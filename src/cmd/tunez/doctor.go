@@ -0,0 +1,346 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tunez/tunez/internal/artwork"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/scrobble"
+)
+
+// Check results in a doctor report.
+const (
+	checkOK      = "ok"
+	checkWarning = "warning" // an optional dependency is missing
+	checkError   = "error"
+)
+
+// doctorCheck is one line of the doctor report.
+type doctorCheck struct {
+	Group   string `json:"group"` // checks of a group are printed together
+	Name    string `json:"name"`
+	Result  string `json:"result"` // ok, warning or error
+	Status  string `json:"status"` // short text, e.g. "OK" or "NOT FOUND"
+	Version string `json:"version,omitempty"`
+	Detail  string `json:"detail,omitempty"`
+	// LatencyMs is how long the check took, for checks that talk to the
+	// provider or network.
+	LatencyMs int64 `json:"latency_ms,omitempty"`
+}
+
+// doctorReport is everything --doctor found, printed as text or, with
+// --json, as JSON.
+type doctorReport struct {
+	OK       bool          `json:"ok"` // every required check passed
+	Warnings int           `json:"warnings"`
+	Checks   []doctorCheck `json:"checks"`
+}
+
+func (r *doctorReport) add(c doctorCheck) {
+	switch c.Result {
+	case checkError:
+		r.OK = false
+	case checkWarning:
+		r.Warnings++
+	}
+	r.Checks = append(r.Checks, c)
+}
+
+func runDoctor(cfg *config.Config, logger *slog.Logger, jsonOut bool) {
+	report := buildDoctorReport(cfg)
+	if jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else {
+		printDoctorReport(report)
+	}
+	logger.Info("doctor complete", slog.Bool("ok", report.OK), slog.Int("warnings", report.Warnings))
+	if !report.OK {
+		os.Exit(1)
+	}
+}
+
+func buildDoctorReport(cfg *config.Config) doctorReport {
+	r := doctorReport{OK: true}
+
+	// Config file
+	r.add(doctorCheck{Group: "deps", Name: "Config file", Result: checkOK, Status: "OK"})
+
+	// Check mpv (required)
+	mpvPath, err := exec.LookPath(cfg.Player.MPVPath)
+	if err != nil {
+		r.add(doctorCheck{Group: "deps", Name: "mpv", Result: checkError, Status: "NOT FOUND", Detail: cfg.Player.MPVPath})
+	} else {
+		// Get mpv version
+		out, _ := exec.Command(mpvPath, "--version").Output()
+		version := ""
+		if len(out) > 0 {
+			lines := strings.Split(string(out), "\n")
+			if len(lines) > 0 {
+				version = strings.TrimSpace(lines[0])
+			}
+		}
+		r.add(doctorCheck{Group: "deps", Name: "mpv", Result: checkOK, Status: "OK", Version: version})
+	}
+
+	// Check ffprobe (optional)
+	ffprobePath, err := exec.LookPath("ffprobe")
+	if err != nil {
+		r.add(doctorCheck{Group: "deps", Name: "ffprobe", Result: checkWarning, Status: "NOT FOUND", Detail: "optional - for duration/codec detection"})
+	} else {
+		out, _ := exec.Command(ffprobePath, "-version").Output()
+		version := ""
+		if len(out) > 0 {
+			lines := strings.Split(string(out), "\n")
+			if len(lines) > 0 {
+				parts := strings.Fields(lines[0])
+				if len(parts) >= 3 {
+					version = parts[2]
+				}
+			}
+		}
+		r.add(doctorCheck{Group: "deps", Name: "ffprobe", Result: checkOK, Status: "OK", Version: version})
+	}
+
+	// Check ffmpeg (optional - for --analyze)
+	if _, err := exec.LookPath("ffmpeg"); err != nil {
+		r.add(doctorCheck{Group: "deps", Name: "ffmpeg", Result: checkWarning, Status: "NOT FOUND", Detail: "optional - for --analyze loudness measurement"})
+	} else {
+		r.add(doctorCheck{Group: "deps", Name: "ffmpeg", Result: checkOK, Status: "OK"})
+	}
+
+	// Check cava (optional - for visualizer)
+	cavaPath, err := exec.LookPath("cava")
+	if err != nil {
+		r.add(doctorCheck{Group: "deps", Name: "cava", Result: checkWarning, Status: "NOT FOUND", Detail: "optional - the visualizer uses its builtin backend"})
+	} else {
+		out, _ := exec.Command(cavaPath, "-v").CombinedOutput()
+		r.add(doctorCheck{Group: "deps", Name: "cava", Result: checkOK, Status: "OK", Version: strings.TrimSpace(string(out))})
+	}
+
+	// Check terminal graphics protocol
+	start := time.Now()
+	protocol := artwork.DetectProtocol()
+	var protocolDesc string
+	switch protocol {
+	case artwork.ProtocolKitty:
+		protocolDesc = "Kitty graphics protocol - pixel-perfect images"
+	case artwork.ProtocolSixel:
+		protocolDesc = "Sixel graphics - high-quality images"
+	case artwork.ProtocolText:
+		protocolDesc = "No images (NO_COLOR or dumb terminal) - initials tiles"
+	default:
+		protocolDesc = "ANSI half-blocks - universal fallback"
+	}
+	r.add(doctorCheck{Group: "graphics", Name: "Graphics", Result: checkOK, Status: string(protocol), Detail: protocolDesc, LatencyMs: since(start)})
+
+	// Check profile
+	var prov provider.Provider
+	profile, ok := cfg.ProfileByID(cfg.ActiveProfile)
+	if !ok {
+		r.add(doctorCheck{Group: "profile", Name: "Active profile", Result: checkError, Status: "NOT FOUND", Detail: cfg.ActiveProfile})
+	} else {
+		r.add(doctorCheck{Group: "profile", Name: "Active profile", Result: checkOK, Status: profile.Name, Detail: profile.Provider + " provider"})
+
+		// Check provider can be built
+		var err error
+		if prov, err = buildProvider(profile); err != nil {
+			r.add(doctorCheck{Group: "profile", Name: "Provider", Result: checkError, Status: "ERROR", Detail: err.Error()})
+		} else {
+			r.add(doctorCheck{Group: "profile", Name: "Provider", Result: checkOK, Status: "OK"})
+			if !checkProvider(&r, prov, profile) {
+				prov = nil
+			}
+		}
+	}
+
+	checkScrobblers(&r, cfg, prov)
+
+	// Check directories
+	stateDir, _ := os.UserConfigDir()
+	cacheDir, _ := os.UserCacheDir()
+	r.add(doctorCheck{Group: "dirs", Name: "Config dir", Result: checkOK, Status: "OK", Detail: filepath.Join(stateDir, "tunez")})
+	r.add(doctorCheck{Group: "dirs", Name: "Cache dir", Result: checkOK, Status: "OK", Detail: filepath.Join(cacheDir, "tunez")})
+
+	return r
+}
+
+// doctorTimeout bounds each provider and scrobbler call. Initializing may
+// scan a library that has never been indexed, so it gets longer.
+const (
+	doctorTimeout     = 15 * time.Second
+	doctorInitTimeout = 2 * time.Minute
+)
+
+// checkProvider initializes prov the way the player does, then times a
+// sample artist listing, a search and a stream lookup. It reports whether
+// the provider initialized.
+func checkProvider(r *doctorReport, prov provider.Provider, profile config.Profile) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), doctorInitTimeout)
+	start := time.Now()
+	err := prov.Initialize(ctx, profile.Settings)
+	cancel()
+	if err != nil {
+		r.add(doctorCheck{Group: "provider", Name: "Initialize", Result: checkError, Status: "ERROR", Detail: err.Error(), LatencyMs: since(start)})
+		return false
+	}
+	r.add(doctorCheck{Group: "provider", Name: "Initialize", Result: checkOK, Status: "OK", LatencyMs: since(start)})
+
+	ctx, cancel = context.WithTimeout(context.Background(), doctorTimeout)
+	defer cancel()
+
+	start = time.Now()
+	healthy, details := prov.Health(ctx)
+	if healthy {
+		r.add(doctorCheck{Group: "provider", Name: "Health", Result: checkOK, Status: "OK", Detail: details, LatencyMs: since(start)})
+	} else {
+		r.add(doctorCheck{Group: "provider", Name: "Health", Result: checkError, Status: "ERROR", Detail: details, LatencyMs: since(start)})
+	}
+
+	start = time.Now()
+	artists, err := prov.ListArtists(ctx, provider.ListReq{PageSize: 10})
+	if err != nil {
+		r.add(doctorCheck{Group: "provider", Name: "List artists", Result: checkError, Status: "ERROR", Detail: err.Error(), LatencyMs: since(start)})
+		return true
+	}
+	r.add(doctorCheck{Group: "provider", Name: "List artists", Result: checkOK, Status: "OK", Detail: fmt.Sprintf("%d returned", len(artists.Items)), LatencyMs: since(start)})
+	if len(artists.Items) == 0 {
+		r.add(doctorCheck{Group: "provider", Name: "Search", Result: checkWarning, Status: "SKIPPED", Detail: "library is empty"})
+		return true
+	}
+
+	// Searching for an artist the provider just listed should find tracks
+	artist := artists.Items[0]
+	start = time.Now()
+	results, err := prov.Search(ctx, artist.Name, provider.ListReq{PageSize: 10})
+	if err != nil {
+		r.add(doctorCheck{Group: "provider", Name: "Search", Result: checkError, Status: "ERROR", Detail: err.Error(), LatencyMs: since(start)})
+		return true
+	}
+	r.add(doctorCheck{Group: "provider", Name: "Search", Result: checkOK, Status: "OK", Detail: fmt.Sprintf("%q: %d tracks", artist.Name, len(results.Tracks.Items)), LatencyMs: since(start)})
+
+	track, ok := sampleTrack(ctx, prov, artist, results.Tracks.Items)
+	if !ok {
+		r.add(doctorCheck{Group: "provider", Name: "Stream URL", Result: checkWarning, Status: "SKIPPED", Detail: "no track found for " + artist.Name})
+		return true
+	}
+	start = time.Now()
+	stream, err := prov.GetStream(ctx, track.ID)
+	switch {
+	case err != nil:
+		r.add(doctorCheck{Group: "provider", Name: "Stream URL", Result: checkError, Status: "ERROR", Detail: err.Error(), LatencyMs: since(start)})
+	case stream.URL == "":
+		r.add(doctorCheck{Group: "provider", Name: "Stream URL", Result: checkError, Status: "ERROR", Detail: "empty URL for " + track.Title, LatencyMs: since(start)})
+	default:
+		r.add(doctorCheck{Group: "provider", Name: "Stream URL", Result: checkOK, Status: "OK", Detail: track.Title, LatencyMs: since(start)})
+	}
+	return true
+}
+
+// sampleTrack picks a track to resolve a stream for: the first search hit,
+// or else the first track of the artist's first album.
+func sampleTrack(ctx context.Context, prov provider.Provider, artist provider.Artist, hits []provider.Track) (provider.Track, bool) {
+	if len(hits) > 0 {
+		return hits[0], true
+	}
+	albums, err := prov.ListAlbums(ctx, artist.ID, provider.ListReq{PageSize: 1})
+	if err != nil || len(albums.Items) == 0 {
+		return provider.Track{}, false
+	}
+	tracks, err := prov.ListTracks(ctx, albums.Items[0].ID, "", "", provider.ListReq{PageSize: 1})
+	if err != nil || len(tracks.Items) == 0 {
+		return provider.Track{}, false
+	}
+	return tracks.Items[0], true
+}
+
+// checkScrobblers verifies each configured scrobbler's credentials. prov is
+// the initialized provider, if any, so Melodee scrobblers can reuse its
+// token.
+func checkScrobblers(r *doctorReport, cfg *config.Config, prov provider.Provider) {
+	mgr := buildScrobbleManager(cfg, prov, slog.New(slog.DiscardHandler))
+	if mgr == nil {
+		return
+	}
+	for _, s := range mgr.Scrobblers() {
+		name := s.Name() + " (" + s.ID() + ")"
+		checker, ok := s.(scrobble.Checker)
+		if !ok {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), doctorTimeout)
+		start := time.Now()
+		err := checker.Check(ctx)
+		cancel()
+		switch {
+		case errors.Is(err, scrobble.ErrNotConfigured):
+			r.add(doctorCheck{Group: "scrobblers", Name: name, Result: checkWarning, Status: "NOT CONFIGURED", Detail: "missing credentials"})
+		case err != nil:
+			r.add(doctorCheck{Group: "scrobblers", Name: name, Result: checkError, Status: "ERROR", Detail: err.Error(), LatencyMs: since(start)})
+		default:
+			r.add(doctorCheck{Group: "scrobblers", Name: name, Result: checkOK, Status: "OK", Detail: "authorized", LatencyMs: since(start)})
+		}
+	}
+}
+
+// since returns the milliseconds elapsed since start, at least 1 so the
+// latency of a check that ran is never omitted.
+func since(start time.Time) int64 {
+	return max(time.Since(start).Milliseconds(), 1)
+}
+
+func printDoctorReport(r doctorReport) {
+	fmt.Println("┌─────────────────────────────────────────┐")
+	fmt.Println("│           Tunez Doctor Report           │")
+	fmt.Println("└─────────────────────────────────────────┘")
+
+	group := ""
+	for _, c := range r.Checks {
+		if c.Group != group {
+			fmt.Println()
+			group = c.Group
+		}
+		detail := c.Detail
+		if c.Version != "" {
+			detail = c.Version
+		}
+		if c.LatencyMs > 0 {
+			detail = strings.TrimPrefix(detail+", ", ", ") + fmt.Sprintf("%dms", c.LatencyMs)
+		}
+		printCheck(c.Name, c.Status, c.Result == checkOK, detail)
+	}
+
+	// Summary
+	fmt.Println()
+	fmt.Println("─────────────────────────────────────────")
+	if r.OK && r.Warnings == 0 {
+		fmt.Println("✓ All checks passed!")
+	} else if r.OK {
+		fmt.Printf("✓ All required checks passed (%d optional warnings)\n", r.Warnings)
+	} else {
+		fmt.Println("✗ Some checks failed. Please resolve the issues above.")
+	}
+}
+
+func printCheck(name, status string, ok bool, detail string) {
+	icon := "✓"
+	if !ok {
+		icon = "✗"
+	}
+	if detail != "" {
+		fmt.Printf("  %s %-15s %s (%s)\n", icon, name+":", status, detail)
+	} else {
+		fmt.Printf("  %s %-15s %s\n", icon, name+":", status)
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/libexport"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// runExportLibrary writes every artist, album and track of the active
+// profile (or merged profiles) to stdout. Progress goes to stderr so the
+// output can be redirected to a file.
+func runExportLibrary(cfg *config.Config, logger *slog.Logger, format string) {
+	if !slices.Contains(libexport.Formats, format) {
+		fmt.Fprintf(os.Stderr, "tunez: --export-library: unknown format %q (use csv or json)\n", format)
+		os.Exit(2)
+	}
+	profile, _ := cfg.ProfileByID(cfg.ActiveProfile)
+	var prov provider.Provider
+	var err error
+	if cfg.Merged() {
+		prov, err = buildMergedProvider(cfg)
+	} else {
+		prov, err = buildProvider(profile)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "tunez: init provider: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	start := time.Now()
+	if err := prov.Initialize(ctx, profile.Settings); err != nil {
+		fmt.Fprintf(os.Stderr, "tunez: init provider: %v\n", err)
+		os.Exit(1)
+	}
+	lib, err := libexport.Collect(ctx, prov, libexport.Options{
+		PageSize: cfg.UI.PageSize,
+		Progress: func(artists, tracks int) {
+			fmt.Fprintf(os.Stderr, "\rExporting... %d artists, %d tracks", artists, tracks)
+		},
+	})
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		logger.Error("export library", slog.Any("err", err))
+		fmt.Fprintf(os.Stderr, "tunez: export library: %v\n", err)
+		if ctx.Err() != nil {
+			os.Exit(exitInterrupted)
+		}
+		os.Exit(1)
+	}
+	lib.Profile = cfg.ActiveProfile
+	if err := libexport.Write(os.Stdout, lib, format); err != nil {
+		fmt.Fprintf(os.Stderr, "tunez: export library: %v\n", err)
+		os.Exit(1)
+	}
+	logger.Info("library exported", slog.String("format", format), slog.Int("artists", len(lib.Artists)), slog.Duration("duration", time.Since(start)))
+}
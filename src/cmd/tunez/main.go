@@ -2,39 +2,63 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"log/slog"
 	"os"
-	"os/exec"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tunez/tunez/internal/app"
 	"github.com/tunez/tunez/internal/artwork"
 	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/control"
+	"github.com/tunez/tunez/internal/crash"
+	"github.com/tunez/tunez/internal/hooks"
 	"github.com/tunez/tunez/internal/logging"
+	"github.com/tunez/tunez/internal/metrics"
+	"github.com/tunez/tunez/internal/nowplaying"
+	"github.com/tunez/tunez/internal/platform"
 	"github.com/tunez/tunez/internal/player"
+	"github.com/tunez/tunez/internal/plugin"
 	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/provider/cache"
+	"github.com/tunez/tunez/internal/provider/composite"
 	"github.com/tunez/tunez/internal/providers/filesystem"
 	"github.com/tunez/tunez/internal/providers/melodee"
 	"github.com/tunez/tunez/internal/queue"
 	"github.com/tunez/tunez/internal/scrobble"
 	"github.com/tunez/tunez/internal/scrobble/lastfm"
 	scrobblemelodee "github.com/tunez/tunez/internal/scrobble/melodee"
+	"github.com/tunez/tunez/internal/secrets"
 	"github.com/tunez/tunez/internal/ui"
 )
 
 var version = "0.1.0"
 
 func main() {
+	// Set when the TUI crashed. Exiting is deferred so that cleanup (mpv,
+	// hooks, the control socket) still runs first.
+	var exitCode int
+	defer func() {
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+	}()
+
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, `Tunez - A terminal music player
 
 Usage: tunez [options]
+       tunez <command> [--json] [args]
 
 Options:
   -config string
@@ -43,12 +67,34 @@ Options:
         Print version and exit
   -config-init
         Create example config file
+  -lastfm-auth
+        Authorize Tunez with Last.fm and save the session key to config
+  -lastfm-import
+        Copy play counts and loved tracks from Last.fm into Tunez (run once
+        after -lastfm-auth; safe to repeat)
+  -secrets-migrate
+        Move passwords, tokens and API keys from config into the OS keyring
 
 Diagnostics:
   -doctor
-        Check configuration and dependencies (fast, no library scan)
+        Check configuration and dependencies, then initialize the provider
+        and time a sample listing, search, stream lookup and scrobbler login
   -scan
         Scan/rescan music library
+  -analyze
+        Measure the loudness of tracks without ReplayGain tags with ffmpeg,
+        so [player] normalize = "track" or "album" evens them out too
+  -organize
+        List where [library] organize_pattern would move local files
+        (default Artist/Album/NN - Title); add -apply to move them
+  -apply
+        Make the -organize moves instead of listing them
+  -json
+        Print -doctor, -scan, -analyze and -organize results as JSON
+  -export-library string
+        Write every artist, album and track to stdout as csv or json
+  -log-level string
+        Log level: debug, info, warn or error (overrides [logging] level)
 
 Playback:
   -artist string
@@ -57,20 +103,65 @@ Playback:
         Search for album and add matching tracks to queue
   -random
         Add random tracks to queue (uses ui.page_size from config)
+  -genre string
+        Only pick -random tracks of this genre
+  -years string
+        Only pick -random tracks from these years: 1984, 1970s, 1975-1985
+  -random-album
+        Add every track of a random album to queue
+  -random-artist
+        Add every album of a random artist to queue
   -play
-        Auto-play first track in queue (use with -artist, -album, or -random)
+        Auto-play first track in queue (use with -artist, -album or a -random flag)
+  -track string
+        Search for tracks by title (combine with -artist and -album)
+  -playlist string
+        Add the named playlist to the queue
   -clear-queue
         Clear the queue before adding new tracks
+  -shuffle
+        Shuffle the queue
+  -repeat string
+        Set the repeat mode: off, all or one
+  -enqueue
+        Send the playback flags to a running tunez instead of starting
+        another (starts normally if none is running)
+
+Commands (control a running tunez):
+  status              Show the playing track, position and queue
+  play, pause         Resume or pause playback
+  toggle              Toggle play/pause
+  next, prev          Skip forward or back in the queue
+  add "query"         Search and append matching tracks to the queue
+  nowplaying          Print one line for a status bar or prompt; --format
+                      takes {artist}, {title}, {album}, {year}, {status},
+                      {icon}, {position}, {duration}, {remaining}, {volume},
+                      {index}, {length}, {shuffle} and {repeat}
+  --json              Print the response as JSON
 
 Examples:
   tunez                                    # Start interactive TUI
   tunez --config-init                      # Create example config
   tunez --doctor                           # Check setup
   tunez --scan                             # Rescan music library
+  tunez --analyze                          # Measure loudness of untagged files
+  tunez --organize                         # Preview tidying file names
+  tunez --organize --apply                 # Move files to Artist/Album/...
+  tunez --doctor --json                    # Check setup, for scripts
+  tunez --export-library csv > library.csv # Dump the library for a spreadsheet
+  tunez --lastfm-auth                      # Connect Last.fm scrobbling
+  tunez --secrets-migrate                  # Move secrets to the OS keyring
   tunez --random --play                    # Play random tracks
+  tunez --random --genre Jazz --years 1970s # Play random 70s jazz
+  tunez --random-album --play              # Play a random album
   tunez --artist "Pink Floyd" --play       # Play artist
   tunez --artist "Queen" --album "News"    # Queue matching album
   tunez --clear-queue --artist "Beatles"   # Clear queue, then add Beatles
+  tunez --playlist "Chill" --shuffle --play # Shuffle a playlist
+  tunez --enqueue --track "Bohemian"       # Append to the running queue
+  tunez status --json                      # Query a running tunez
+  tunez add "Wish You Were Here"           # Queue tracks in a running tunez
+  tunez nowplaying --format "{icon} {title} {position}/{duration}"
 
 `)
 	}
@@ -78,13 +169,31 @@ Examples:
 	cfgPath := flag.String("config", "", "")
 	doctor := flag.Bool("doctor", false, "")
 	scan := flag.Bool("scan", false, "")
+	analyze := flag.Bool("analyze", false, "")
+	organize := flag.Bool("organize", false, "")
+	apply := flag.Bool("apply", false, "")
 	showVersion := flag.Bool("version", false, "")
 	configInit := flag.Bool("config-init", false, "")
+	lastfmAuth := flag.Bool("lastfm-auth", false, "")
+	lastfmImport := flag.Bool("lastfm-import", false, "")
+	secretsMigrate := flag.Bool("secrets-migrate", false, "")
 	searchArtist := flag.String("artist", "", "")
 	searchAlbum := flag.String("album", "", "")
 	autoPlay := flag.Bool("play", false, "")
 	randomPlay := flag.Bool("random", false, "")
+	randomGenre := flag.String("genre", "", "")
+	randomYears := flag.String("years", "", "")
+	randomAlbum := flag.Bool("random-album", false, "")
+	randomArtist := flag.Bool("random-artist", false, "")
 	clearQueue := flag.Bool("clear-queue", false, "")
+	searchTrack := flag.String("track", "", "")
+	playlist := flag.String("playlist", "", "")
+	shuffle := flag.Bool("shuffle", false, "")
+	repeat := flag.String("repeat", "", "")
+	enqueue := flag.Bool("enqueue", false, "")
+	jsonOut := flag.Bool("json", false, "")
+	logLevel := flag.String("log-level", "", "")
+	exportLibrary := flag.String("export-library", "", "")
 	flag.Parse()
 
 	if *showVersion {
@@ -92,6 +201,43 @@ Examples:
 		return
 	}
 
+	if flag.NArg() > 0 {
+		os.Exit(runControl(flag.Args(), *jsonOut))
+	}
+
+	if *repeat != "" {
+		if _, err := queue.ParseRepeatMode(*repeat); err != nil {
+			log.Fatalf("--repeat: %v", err)
+		}
+	}
+	years, err := provider.ParseYearRange(*randomYears)
+	if err != nil {
+		log.Fatalf("--years: %v", err)
+	}
+
+	playbackFlags := control.Enqueue{
+		Artist:       *searchArtist,
+		Album:        *searchAlbum,
+		Track:        *searchTrack,
+		Playlist:     *playlist,
+		Random:       *randomPlay,
+		Genre:        *randomGenre,
+		Years:        *randomYears,
+		RandomAlbum:  *randomAlbum,
+		RandomArtist: *randomArtist,
+		Play:         *autoPlay,
+		Clear:        *clearQueue,
+		Shuffle:      *shuffle,
+		Repeat:       *repeat,
+	}
+	if *enqueue {
+		if code, sent := forwardEnqueue(playbackFlags); sent {
+			exitCode = code
+			return
+		}
+		// Nothing to append to; start with the flags instead
+	}
+
 	if *configInit {
 		runConfigInit()
 		return
@@ -101,39 +247,141 @@ Examples:
 	if err != nil {
 		log.Fatalf("load config: %v", err)
 	}
-	logger, logFile, err := logging.Setup()
+	if *logLevel != "" {
+		cfg.Logging.Level = *logLevel
+	}
+	level, err := logging.ParseLevel(cfg.Logging.Level)
+	if err != nil {
+		log.Fatalf("--log-level: %v", err)
+	}
+	logger, logFile, err := logging.Setup(logging.Options{
+		Level:      level,
+		Path:       cfg.Logging.Path,
+		JSON:       cfg.Logging.Format == "json",
+		MaxSize:    int64(cfg.Logging.MaxSizeMB) << 20,
+		MaxBackups: cfg.Logging.MaxBackups,
+	})
 	if err != nil {
 		log.Fatalf("setup logging: %v", err)
 	}
 	defer logFile.Close()
 	logger.Info("starting tunez", slog.String("config", resolvedPath))
+	if m := cfg.Migration; m != nil {
+		logger.Info("config migrated", slog.Int("from", m.From), slog.Int("to", m.To), slog.Any("steps", m.Steps), slog.String("backup", m.Backup))
+		fmt.Fprintf(os.Stderr, "tunez: upgraded config to version %d (original saved as %s)\n", m.To, m.Backup)
+		if m.Reformatted {
+			fmt.Fprintln(os.Stderr, "tunez: the config was rewritten in full, so its comments are only in the backup")
+		}
+	}
+
+	if *secretsMigrate {
+		runSecretsMigrate(resolvedPath, logger)
+		return
+	}
+
+	if err := secrets.ResolveConfig(cfg, secrets.Keyring()); err != nil {
+		logger.Error("resolve secrets", slog.Any("err", err))
+		log.Fatalf("resolve secrets: %v", err)
+	}
 
 	if *doctor {
-		runDoctor(cfg, logger)
+		runDoctor(cfg, logger, *jsonOut)
 		return
 	}
 
 	if *scan {
-		runScan(cfg, logger)
+		runScan(cfg, logger, *jsonOut)
+		return
+	}
+
+	if *analyze {
+		runAnalyze(cfg, logger, *jsonOut)
+		return
+	}
+
+	if *organize {
+		runOrganize(cfg, logger, *apply, *jsonOut)
+		return
+	}
+
+	if *exportLibrary != "" {
+		runExportLibrary(cfg, logger, *exportLibrary)
+		return
+	}
+
+	if *lastfmAuth {
+		runLastfmAuth(cfg, logger)
 		return
 	}
 
+	if *lastfmImport {
+		runLastfmImport(cfg, logger)
+		return
+	}
+
+	// Control socket for "tunez status", "tunez next" and friends. Holding
+	// it also keeps Tunez to one instance, so two don't fight over mpv: a
+	// second launch hands its playback flags to the first and exits.
+	remote, err := control.Listen(control.SocketPath())
+	switch {
+	case errors.Is(err, control.ErrRunning) && !cfg.MultipleInstances:
+		logger.Info("another instance is running; forwarding flags")
+		exitCode = forwardToRunning(playbackFlags)
+		return
+	case err != nil:
+		logger.Warn("control socket unavailable", slog.Any("err", err))
+	default:
+		defer remote.Close()
+	}
+
 	profile, _ := cfg.ProfileByID(cfg.ActiveProfile)
-	prov, err := buildProvider(profile)
+	var prov provider.Provider
+	if cfg.Merged() {
+		prov, err = buildMergedProvider(cfg)
+	} else {
+		prov, err = buildProvider(profile)
+	}
 	if err != nil {
 		logger.Error("provider init", slog.Any("err", err))
 		log.Fatalf("init provider: %v", err)
 	}
+	// Profile switches add theirs; the app closes each outgoing provider,
+	// so this releases whichever is current when tunez exits
+	var providersMu sync.Mutex
+	providers := []provider.Provider{prov}
+	defer func() {
+		providersMu.Lock()
+		defer providersMu.Unlock()
+		for _, p := range providers {
+			if err := provider.Close(p); err != nil {
+				logger.Warn("close provider", slog.Any("err", err))
+			}
+		}
+	}()
 
+	// A second instance gets its own mpv socket rather than taking over
+	// the first one's
+	ipcPath := player.IPCPath(cfg.Player.IPC, cfg.MultipleInstances)
+	player.RemoveStaleSockets(player.IPCPath(cfg.Player.IPC, false))
 	ctrl := player.New(player.Options{
-		MPVPath: cfg.Player.MPVPath,
-		Logger:  logger,
+		MPVPath:            cfg.Player.MPVPath,
+		IPCPath:            ipcPath,
+		Logger:             logger,
+		AudioExclusive:     cfg.Player.AudioExclusive,
+		TrimSilence:        cfg.Player.TrimSilence,
+		SilenceThresholdDB: cfg.Player.SilenceThresholdDB,
+		Normalize:          cfg.Player.Normalize,
+		TargetLUFS:         cfg.Player.TargetLUFS,
+		Volume:             float64(cfg.Player.InitialVolume),
+		VolumeCurve:        cfg.Player.VolumeCurve,
+		Fade:               time.Duration(cfg.Player.FadeMs) * time.Millisecond,
 	})
 	if err := ctrl.Start(context.Background()); err != nil {
 		logger.Error("start player", slog.Any("err", err))
 		log.Fatalf("start player: %v", err)
 	}
 	defer ctrl.Stop()
+	defer app.RemovePrefetched()
 
 	// Initialize queue persistence store if enabled
 	var queueStore *queue.PersistenceStore
@@ -155,8 +403,26 @@ Examples:
 			if err := scrobbleMgr.LoadPending(); err != nil {
 				logger.Warn("failed to load pending scrobbles", slog.Any("err", err))
 			}
+			// Retry offline scrobbles in the background while the provider is reachable
+			flushCtx, stopFlush := context.WithCancel(context.Background())
+			scrobbleMgr.StartFlushLoop(flushCtx, scrobble.FlushOptions{
+				Online: func(ctx context.Context) bool {
+					ctx, cancel := context.WithTimeout(ctx, 5*time.Second)
+					defer cancel()
+					ok, _ := prov.Health(ctx)
+					return ok
+				},
+				OnFlush: func(id string, err error) {
+					if err != nil {
+						logger.Debug("scrobble flush failed", slog.String("scrobbler", id), slog.Any("err", err))
+					} else {
+						logger.Info("flushed pending scrobbles", slog.String("scrobbler", id))
+					}
+				},
+			})
 			// Save pending scrobbles on shutdown
 			defer func() {
+				stopFlush()
 				waitCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 				defer cancel()
 				if err := scrobbleMgr.Wait(waitCtx); err != nil {
@@ -170,34 +436,155 @@ Examples:
 	}
 
 	// NO_COLOR env var support per accessibility spec
-	noColor := os.Getenv("NO_COLOR") != "" || cfg.UI.NoEmoji
-	theme := ui.GetTheme(cfg.UI.Theme, noColor)
+	theme := ui.GetTheme(cfg.UI.Theme, cfg.NoColor())
 
 	// Initialize artwork cache if enabled
 	var artCache *artwork.Cache
 	if cfg.Artwork.Enabled {
-		artCache, err = artwork.NewCache("", cfg.Artwork.CacheDays, 0)
+		artCache, err = artwork.NewCache("", cfg.Artwork.CacheDays, cfg.Artwork.CacheMaxMB)
 		if err != nil {
 			logger.Warn("artwork cache unavailable", slog.Any("err", err))
 		}
 	}
 
+	// Load Lua plugins if enabled
+	var plugins *plugin.Manager
+	if cfg.Plugins.Enabled {
+		plugins, err = plugin.Load(cfg.PluginsDir(), plugin.Options{
+			Timeout: time.Duration(cfg.Plugins.TimeoutMs) * time.Millisecond,
+			Logger:  logger,
+		})
+		if err != nil {
+			logger.Warn("plugins unavailable", slog.Any("err", err))
+		} else {
+			defer plugins.Close()
+		}
+	}
+
+	// Shell hooks; app_stop runs after the TUI exits
+	hookRunner := hooks.New(cfg.Hooks, logger)
+	hookRunner.Fire(hooks.EventAppStart, provider.Track{})
+	defer func() {
+		hookRunner.Fire(hooks.EventAppStop, provider.Track{})
+		waitCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := hookRunner.Wait(waitCtx); err != nil {
+			logger.Warn("hooks still running at exit", slog.Any("err", err))
+		}
+	}()
+
+	if cfg.Metrics.Enabled {
+		srv, err := metrics.Serve(cfg.Metrics.Listen, metrics.Default, logger)
+		if err != nil {
+			logger.Warn("metrics endpoint unavailable", slog.Any("err", err))
+		} else {
+			logger.Info("serving metrics", slog.String("addr", "http://"+srv.Addr()+"/metrics"))
+			defer srv.Close()
+		}
+	}
+
 	// Build startup options from CLI flags
 	startupOpts := app.StartupOptions{
 		SearchArtist: *searchArtist,
 		SearchAlbum:  *searchAlbum,
+		SearchTrack:  *searchTrack,
+		Playlist:     *playlist,
 		AutoPlay:     *autoPlay,
 		RandomPlay:   *randomPlay,
+		RandomFilter: provider.RandomFilter{Genre: strings.TrimSpace(*randomGenre), Years: years},
+		RandomAlbum:  *randomAlbum,
+		RandomArtist: *randomArtist,
 		ClearQueue:   *clearQueue,
+		Shuffle:      *shuffle,
+		Repeat:       *repeat,
 	}
 
 	model := app.New(cfg, prov, func(p config.Profile) (provider.Provider, error) {
-		return buildProvider(p)
-	}, ctrl, profile.Settings, theme, startupOpts, queueStore, scrobbleMgr, artCache, logger)
-	if _, err := tea.NewProgram(model, tea.WithAltScreen()).Run(); err != nil {
+		built, err := buildProvider(p)
+		if err == nil {
+			providersMu.Lock()
+			providers = append(providers, built)
+			providersMu.Unlock()
+		}
+		return built, err
+	}, ctrl, profile.Settings, theme, startupOpts, queueStore, scrobbleMgr, artCache, plugins, hookRunner, remote, logger)
+	rec := &crash.Recorder{}
+	program := tea.NewProgram(crash.Guard(model, rec), tea.WithAltScreen())
+	rec.Attach(program)
+	// Return instead of exiting so the deferred cleanup still saves pending
+	// scrobbles, stops mpv and runs app_stop hooks. SIGTERM quits the
+	// program normally; SIGINT arrives as a key press unless stdin is not
+	// a terminal.
+	if _, err := program.Run(); errors.Is(err, tea.ErrInterrupted) {
+		logger.Info("interrupted")
+		exitCode = exitInterrupted
+		return
+	} else if err != nil {
 		logger.Error("run tui", slog.Any("err", err))
-		log.Fatalf("tui: %v", err)
+		fmt.Fprintf(os.Stderr, "tui: %v\n", err)
+		exitCode = 1
+		return
+	}
+	if c := rec.Crash(); c != nil {
+		reportCrash(cfg, c, logger)
+		exitCode = 2
+	}
+}
+
+// forwardEnqueue sends playback flags to a running instance to queue, and
+// returns the exit status. sent is false when no instance is running.
+func forwardEnqueue(e control.Enqueue) (code int, sent bool) {
+	resp, err := control.Send(control.SocketPath(), control.Request{Cmd: control.CmdEnqueue, Enqueue: &e})
+	switch {
+	case errors.Is(err, control.ErrNotRunning):
+		return 0, false
+	case err != nil:
+		fmt.Fprintln(os.Stderr, "tunez: enqueue:", err)
+		return 1, true
+	case !resp.OK:
+		fmt.Fprintln(os.Stderr, "tunez:", resp.Error)
+		return 1, true
+	}
+	fmt.Println(resp.Message)
+	return 0, true
+}
+
+// forwardToRunning hands a second launch's playback flags to the instance
+// already running. Without any it says where Tunez is running instead.
+func forwardToRunning(e control.Enqueue) int {
+	if e == (control.Enqueue{}) {
+		fmt.Fprintln(os.Stderr, "tunez: already running in another terminal. Control it with 'tunez status', 'tunez next' and friends, or set multiple_instances = true to start another.")
+		return 1
+	}
+	code, sent := forwardEnqueue(e)
+	if !sent {
+		// It quit in the meantime
+		fmt.Fprintln(os.Stderr, "tunez: the running instance exited; start tunez again")
+		return 1
 	}
+	return code
+}
+
+// reportCrash writes a crash report to the state dir and tells the user
+// where it is. The terminal has already been restored.
+func reportCrash(cfg *config.Config, c *crash.Crash, logger *slog.Logger) {
+	logger.Error("tui panic", slog.Any("panic", c.Value), slog.String("stack", string(c.Stack)))
+	fmt.Fprintf(os.Stderr, "tunez crashed: %v\n", c.Value)
+
+	summary, err := cfg.Redacted()
+	if err != nil {
+		summary = "(unavailable: " + err.Error() + ")"
+	}
+	dir, err := logging.StateDir()
+	if err == nil {
+		var path string
+		path, err = crash.WriteReport(dir, c, crash.Info{Version: version, Config: summary, Logs: logging.Recent()})
+		if err == nil {
+			fmt.Fprintf(os.Stderr, "A crash report was written to %s\nPlease attach it when reporting the bug.\n", path)
+			return
+		}
+	}
+	fmt.Fprintf(os.Stderr, "Could not write a crash report: %v\n%s", err, c.Stack)
 }
 
 func buildProvider(p config.Profile) (provider.Provider, error) {
@@ -205,12 +592,67 @@ func buildProvider(p config.Profile) (provider.Provider, error) {
 	case "filesystem":
 		return filesystem.New(), nil
 	case "melodee":
-		return melodee.New(), nil
+		return withCache(p, melodee.New()), nil
 	default:
 		return nil, fmt.Errorf("unknown provider %s", p.Provider)
 	}
 }
 
+// buildMergedProvider combines every profile in active_profiles into one
+// library.
+func buildMergedProvider(cfg *config.Config) (provider.Provider, error) {
+	var members []composite.Member
+	for _, id := range cfg.ActiveProfiles {
+		p, _ := cfg.ProfileByID(id)
+		prov, err := buildProvider(p)
+		if err != nil {
+			return nil, fmt.Errorf("profile %s: %w", id, err)
+		}
+		members = append(members, composite.Member{ProfileID: p.ID, Name: p.Name, Provider: prov, Settings: p.Settings})
+	}
+	return composite.New(members), nil
+}
+
+// melodeeProvider finds the Melodee provider with the given ID, looking
+// inside merged and cached providers.
+func melodeeProvider(prov provider.Provider, id string) (*melodee.Provider, bool) {
+	candidates := []provider.Provider{prov}
+	if c, ok := prov.(*composite.Provider); ok {
+		candidates = candidates[:0]
+		for _, mem := range c.Members() {
+			candidates = append(candidates, mem.Provider)
+		}
+	}
+	for _, p := range candidates {
+		if mp, ok := provider.Unwrap(p).(*melodee.Provider); ok && mp.ID() == id {
+			return mp, true
+		}
+	}
+	return nil, false
+}
+
+// withCache wraps a remote provider in the response cache unless the
+// profile sets cache = false. If the cache can't be opened the provider is
+// used uncached.
+func withCache(p config.Profile, prov provider.Provider) provider.Provider {
+	if enabled, ok := p.Settings["cache"].(bool); ok && !enabled {
+		return prov
+	}
+	opts := cache.Options{Namespace: p.ID}
+	if v, ok := p.Settings["cache_db"].(string); ok {
+		opts.Path = v
+	}
+	if v, ok := p.Settings["cache_ttl_minutes"].(int64); ok && v > 0 {
+		opts.ListTTL = time.Duration(v) * time.Minute
+	}
+	cached, err := cache.New(prov, opts)
+	if err != nil {
+		slog.Warn("provider cache disabled", slog.String("profile", p.ID), slog.Any("err", err))
+		return prov
+	}
+	return cached
+}
+
 // buildScrobbleManager creates and configures the scrobble manager based on config.
 func buildScrobbleManager(cfg *config.Config, prov provider.Provider, logger *slog.Logger) *scrobble.Manager {
 	if len(cfg.Scrobblers) == 0 {
@@ -244,8 +686,9 @@ func buildScrobbleManager(cfg *config.Config, prov provider.Provider, logger *sl
 			melCfg := scrobblemelodee.Config{}
 			// Check if we should reuse auth from a melodee provider
 			if provID, ok := entry.Settings["provider"].(string); ok && provID != "" {
+				melCfg.Provider = provID
 				// Try to get token from current provider if it's melodee
-				if mp, ok := prov.(*melodee.Provider); ok && prov.ID() == provID {
+				if mp, ok := melodeeProvider(prov, provID); ok {
 					melCfg.TokenProvider = mp
 					melCfg.BaseURL = mp.BaseURL()
 				}
@@ -281,134 +724,99 @@ func buildScrobbleManager(cfg *config.Config, prov provider.Provider, logger *sl
 	return mgr
 }
 
-func runDoctor(cfg *config.Config, logger *slog.Logger) {
-	fmt.Println("┌─────────────────────────────────────────┐")
-	fmt.Println("│           Tunez Doctor Report           │")
-	fmt.Println("└─────────────────────────────────────────┘")
-	fmt.Println()
-
-	allOK := true
-	warnings := 0
-
-	// Config file
-	printCheck("Config file", "OK", true, "")
-
-	// Check mpv (required)
-	mpvPath, err := exec.LookPath(cfg.Player.MPVPath)
-	if err != nil {
-		printCheck("mpv", "NOT FOUND", false, cfg.Player.MPVPath)
-		allOK = false
-	} else {
-		// Get mpv version
-		out, _ := exec.Command(mpvPath, "--version").Output()
-		version := ""
-		if len(out) > 0 {
-			lines := strings.Split(string(out), "\n")
-			if len(lines) > 0 {
-				version = strings.TrimSpace(lines[0])
-			}
+// runControl sends a subcommand to the running instance and prints the
+// answer. It returns the process exit code. A --json given before the
+// command name is the default for the one after it.
+func runControl(args []string, jsonDefault bool) int {
+	name := args[0]
+	if name == "nowplaying" {
+		return runNowPlaying(args[1:])
+	}
+	fs := flag.NewFlagSet(name, flag.ExitOnError)
+	jsonOut := fs.Bool("json", jsonDefault, "print the response as JSON")
+	fs.Parse(args[1:])
+
+	req := control.Request{Cmd: name}
+	switch name {
+	case control.CmdStatus, control.CmdPlay, control.CmdPause, control.CmdToggle, control.CmdNext, control.CmdPrev:
+		if fs.NArg() > 0 {
+			fmt.Fprintf(os.Stderr, "tunez %s takes no arguments\n", name)
+			return 2
 		}
-		printCheck("mpv", "OK", true, version)
+	case control.CmdAdd:
+		req.Arg = strings.Join(fs.Args(), " ")
+		if req.Arg == "" {
+			fmt.Fprintln(os.Stderr, `usage: tunez add [--json] "search query"`)
+			return 2
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "tunez: unknown command %q (see tunez --help)\n", name)
+		return 2
 	}
 
-	// Check ffprobe (optional)
-	ffprobePath, err := exec.LookPath("ffprobe")
+	resp, err := control.Send(control.SocketPath(), req)
 	if err != nil {
-		printCheck("ffprobe", "NOT FOUND", false, "optional - for duration/codec detection")
-		warnings++
-	} else {
-		out, _ := exec.Command(ffprobePath, "-version").Output()
-		version := ""
-		if len(out) > 0 {
-			lines := strings.Split(string(out), "\n")
-			if len(lines) > 0 {
-				parts := strings.Fields(lines[0])
-				if len(parts) >= 3 {
-					version = parts[2]
-				}
-			}
+		if *jsonOut {
+			resp = control.Response{Error: err.Error()}
+		} else {
+			fmt.Fprintln(os.Stderr, "tunez:", err)
+			return 1
 		}
-		printCheck("ffprobe", "OK", true, version)
 	}
-
-	// Check cava (optional - for visualizer)
-	cavaPath, err := exec.LookPath("cava")
-	if err != nil {
-		printCheck("cava", "NOT FOUND", false, "optional - for audio visualizer")
-		warnings++
+	if *jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(resp)
+	} else if !resp.OK {
+		fmt.Fprintln(os.Stderr, "tunez:", resp.Error)
+	} else if resp.Status != nil {
+		printStatus(resp.Status)
 	} else {
-		out, _ := exec.Command(cavaPath, "-v").CombinedOutput()
-		version := strings.TrimSpace(string(out))
-		printCheck("cava", "OK", true, version)
+		fmt.Println(resp.Message)
 	}
-
-	// Check terminal graphics protocol
-	fmt.Println()
-	protocol := artwork.DetectProtocol()
-	var protocolDesc string
-	switch protocol {
-	case artwork.ProtocolKitty:
-		protocolDesc = "Kitty graphics protocol - pixel-perfect images"
-		printCheck("Graphics", string(protocol), true, protocolDesc)
-	case artwork.ProtocolSixel:
-		protocolDesc = "Sixel graphics - high-quality images"
-		printCheck("Graphics", string(protocol), true, protocolDesc)
-	default:
-		protocolDesc = "ANSI half-blocks - universal fallback"
-		printCheck("Graphics", string(protocol), true, protocolDesc)
+	if !resp.OK {
+		return 1
 	}
+	return 0
+}
 
-	fmt.Println()
-
-	// Check profile
-	profile, ok := cfg.ProfileByID(cfg.ActiveProfile)
-	if !ok {
-		printCheck("Active profile", "NOT FOUND", false, cfg.ActiveProfile)
-		allOK = false
-	} else {
-		printCheck("Active profile", profile.Name, true, profile.Provider+" provider")
-
-		// Check provider can be built
-		_, err = buildProvider(profile)
-		if err != nil {
-			printCheck("Provider", "ERROR", false, err.Error())
-			allOK = false
-		} else {
-			printCheck("Provider", "OK", true, "")
-		}
+// runNowPlaying prints one line about the playing track for tmux status
+// lines and shell prompts. It asks the running instance, so it starts no
+// provider, and prints nothing, with status 1, when nothing is playing.
+func runNowPlaying(args []string) int {
+	fs := flag.NewFlagSet("nowplaying", flag.ExitOnError)
+	format := fs.String("format", control.DefaultFormat, "line to print, with {artist}, {title}, {position}, {duration} and other placeholders")
+	fs.Parse(args)
+	if fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, `usage: tunez nowplaying [--format "{artist} - {title}"]`)
+		return 2
 	}
 
-	// Check directories
-	fmt.Println()
-	stateDir, _ := os.UserConfigDir()
-	cacheDir, _ := os.UserCacheDir()
-	printCheck("Config dir", "OK", true, filepath.Join(stateDir, "tunez"))
-	printCheck("Cache dir", "OK", true, filepath.Join(cacheDir, "tunez"))
-
-	// Summary
-	fmt.Println()
-	fmt.Println("─────────────────────────────────────────")
-	if allOK && warnings == 0 {
-		fmt.Println("✓ All checks passed!")
-	} else if allOK {
-		fmt.Printf("✓ All required checks passed (%d optional warnings)\n", warnings)
-	} else {
-		fmt.Println("✗ Some checks failed. Please resolve the issues above.")
+	// Prompts redraw often; a busy instance is better skipped than waited on
+	resp, err := control.SendTimeout(control.SocketPath(), control.Request{Cmd: control.CmdStatus}, 2*time.Second)
+	if err != nil || !resp.OK || resp.Status == nil || resp.Status.Status == nowplaying.StatusStopped {
+		return 1
 	}
-
-	logger.Info("doctor complete")
+	fmt.Println(resp.Status.Format(*format))
+	return 0
 }
 
-func printCheck(name, status string, ok bool, detail string) {
-	icon := "✓"
-	if !ok {
-		icon = "✗"
-	}
-	if detail != "" {
-		fmt.Printf("  %s %-15s %s (%s)\n", icon, name+":", status, detail)
+func printStatus(st *control.Status) {
+	if st.Status == nowplaying.StatusStopped {
+		fmt.Println("Stopped")
 	} else {
-		fmt.Printf("  %s %-15s %s\n", icon, name+":", status)
+		pos := fmt.Sprintf("%d:%02d", st.PositionSec/60, st.PositionSec%60)
+		fmt.Printf("%-9s %s - %s\n", strings.ToUpper(st.Status[:1])+st.Status[1:], st.Artist, st.Title)
+		fmt.Printf("%-9s %s\n", "Album", st.Album)
+		fmt.Printf("%-9s %s / %s\n", "Position", pos, st.Duration)
+	}
+	onOff := map[bool]string{true: "on", false: "off"}
+	fmt.Printf("%-9s %d of %d (shuffle %s, repeat %s)\n", "Queue", st.QueueIndex+1, st.QueueLength, onOff[st.Shuffle], st.Repeat)
+	vol := fmt.Sprintf("%d", st.Volume)
+	if st.Muted {
+		vol += " (muted)"
 	}
+	fmt.Printf("%-9s %s\n", "Volume", vol)
 }
 
 func runConfigInit() {
@@ -440,11 +848,22 @@ func runConfigInit() {
 
 config_version = 1
 active_profile = "local"
+multiple_instances = false # true: allow more than one Tunez at a time
 
 [ui]
 theme = "rainbow"     # rainbow, mono, green, dracula, nord, synthwave, etc.
 page_size = 100
 no_emoji = false
+no_confirm = false    # Skip "are you sure?" prompts before clearing the queue and similar
+zen_idle_seconds = 0  # Enter zen mode after this long idle while playing; 0 = never
+now_playing_layout = "default"  # default, or lyrics for synced lyrics beside the artwork
+versions = "lossless"  # Version of a duplicated song to show: lossless, bitrate, compact or off
+track_columns = ["track", "artist", "title", "duration"]  # Also album, year, codec, bitrate, rating, plays; "album:24" sets a width
+quality_badges = false  # Mark track rows Hi-Res, Lossless or Lossy, and Mono
+language = "en"  # Interface language: en, de, es or ja
+clock = "24h"  # 24h or 12h
+dates = "relative"  # relative ("3 days ago") or absolute dates
+selection_markers = false  # Mark the selected row with >> instead of color alone
 
 [player]
 mpv_path = "mpv"
@@ -452,14 +871,34 @@ initial_volume = 70
 seek_small_seconds = 5
 seek_large_seconds = 30
 volume_step = 5
+trim_silence = false  # Trim silence between tracks (live albums, gappy rips)
+normalize = "off"     # Loudness normalization: off, track, album (ReplayGain) or loudnorm
+target_lufs = -18     # Loudness to normalize to
+stream_quality = "raw"  # Remote stream transcode: raw, mp3-320, opus-128, ...
+low_bandwidth_quality = "opus-64"  # Used instead in low-bandwidth mode (palette toggle)
 
 [queue]
 persist = true        # Remember queue across restarts
+play_from_here = false  # Enter on an album track queues the rest of the album too
+export_relative_paths = false  # Exported .m3u8/.xspf files use paths relative to the file
+max_size = 100000     # Most tracks the queue holds (negative: no limit)
+
+[queue.skip]
+shorter_than_seconds = 0  # Skip tracks shorter than this when advancing (0: off)
+title_patterns = []   # Skip titles matching these regexes, e.g. ["^intro$", "skit"]
+played_today = false  # Skip tracks already played today
+
+[queue.shuffle]
+mode = "random"  # random, or weighted by loves, plays and recency
+loved_weight = 2.0
+plays_weight = 0.5
+recency_weight = 1.0
 
 [artwork]
 enabled = true
 width = 40
 cache_days = 30
+cache_max_mb = 500
 
 [scrobble]
 enabled = false       # Set to true and configure scrobblers below
@@ -472,7 +911,40 @@ enabled = false       # Set to true and configure scrobblers below
 # [scrobblers.settings]
 # api_key = "YOUR_API_KEY"
 # api_secret = "YOUR_API_SECRET"
-# session_key = "YOUR_SESSION_KEY"
+# session_key = ""            # Filled in by: tunez --lastfm-auth
+
+[logging]
+level = "info"        # debug | info | warn | error
+format = "text"       # text | json
+max_size_mb = 10      # Rotate the log file past this size
+max_backups = 3
+
+[metrics]
+enabled = false       # Serve Prometheus metrics on http://<listen>/metrics
+listen = "127.0.0.1:9464"
+
+[visualizer]
+backend = "cava"      # cava | builtin | off; cava falls back to builtin if not installed
+style = "bars"        # bars | mirrored | waveform | vu; press v on Now Playing to cycle
+input = "auto"        # auto | pulse | pipewire | alsa | fifo
+source = ""           # Capture device or FIFO path; empty uses cava's default
+
+[about]
+enabled = false       # Fetch artist bios and album reviews for the About panel (I)
+source = ""           # lastfm | wikipedia; empty uses lastfm when a Last.fm api_key is set
+language = "en"       # Wikipedia edition
+cache_days = 30
+
+[audiobooks]
+folders = []          # Globs of audiobook folders, e.g. ["~/Audiobooks"]
+speed = 1.0           # e.g. 1.25; audiobooks also resume per chapter and skip scrobbling
+
+[library]
+organize_pattern = ""  # Where tunez --organize moves files; "" is Artist/Album/NN - Title
+allow_delete = false   # Delete File in the command palette moves the track to the OS trash
+
+[plugins]
+enabled = false       # Load Lua scripts from the plugins dir next to this file
 
 [keybindings]
 play_pause = "space"
@@ -486,7 +958,21 @@ mute = "m"
 shuffle = "s"
 repeat = "r"
 search = "/"
+love = "F"
+profile_switch = "ctrl+o"
+quick_open = "ctrl+k"
 help = "?"
+zen = "z"
+bookmark = "b"
+bookmarks = "B"
+next_chapter = "]"
+prev_chapter = "["
+chapters = "E"
+track_info = "i"
+go_to_album = "ctrl+a"
+go_to_artist = "ctrl+r"
+nav_back = "alt+left"
+nav_forward = "alt+right"
 quit = "q,ctrl+c"
 
 # Local filesystem profile
@@ -499,6 +985,7 @@ enabled = true
 [profiles.settings]
 roots = ["/home/` + os.Getenv("USER") + `/Music"]
 scan_on_start = false
+# drop_folder = "/home/` + os.Getenv("USER") + `/Downloads/music"  # Queue audio files as they land here
 
 # Melodee API profile (uncomment to enable)
 # [[profiles]]
@@ -526,20 +1013,79 @@ scan_on_start = false
 	fmt.Println("  3. Run 'tunez' to start playing!")
 }
 
-func runScan(cfg *config.Config, logger *slog.Logger) {
+// scanReport is the result of --scan, printed as text or, with --json, as
+// JSON.
+type scanReport struct {
+	OK         bool              `json:"ok"`
+	Profile    string            `json:"profile"`
+	Provider   string            `json:"provider"`
+	DurationMs int64             `json:"duration_ms"`
+	Stats      *filesystem.Stats `json:"stats,omitempty"`
+	Details    string            `json:"details,omitempty"` // provider health text
+	// Interrupted is set when SIGINT or SIGTERM stopped the scan. Tracks
+	// read up to then are kept, and the next scan picks up the rest.
+	Interrupted bool   `json:"interrupted,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// exitInterrupted is the conventional status for a command stopped by
+// Ctrl+C (128 + SIGINT).
+const exitInterrupted = 130
+
+// interruptContext is cancelled by SIGINT or SIGTERM, so CLI commands can
+// finish their writes and exit cleanly instead of dying mid-transaction.
+func interruptContext() (context.Context, context.CancelFunc) {
+	return signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+}
+
+func runScan(cfg *config.Config, logger *slog.Logger, jsonOut bool) {
+	ctx, stop := interruptContext()
+	report := scanLibrary(ctx, cfg, !jsonOut)
+	stop()
+	if jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(report)
+	} else if report.Error != "" {
+		fmt.Println(report.Error)
+	} else {
+		fmt.Printf("Scan complete in %s\n", (time.Duration(report.DurationMs) * time.Millisecond).Round(time.Millisecond))
+		if report.Stats != nil {
+			fmt.Printf("  %d artists, %d albums, %d tracks\n", report.Stats.Artists, report.Stats.Albums, report.Stats.Tracks)
+		} else {
+			fmt.Printf("  %s\n", report.Details)
+		}
+	}
+	logger.Info("scan complete", slog.Bool("ok", report.OK), slog.Bool("interrupted", report.Interrupted), slog.Duration("duration", time.Duration(report.DurationMs)*time.Millisecond))
+	if report.Interrupted {
+		os.Exit(exitInterrupted)
+	}
+	if !report.OK {
+		os.Exit(1)
+	}
+}
+
+// scanLibrary rescans the active profile's library, printing progress when
+// progress is set. Cancelling ctx stops the scan after committing what it
+// has read.
+func scanLibrary(ctx context.Context, cfg *config.Config, progress bool) scanReport {
+	r := scanReport{Profile: cfg.ActiveProfile}
 	profile, ok := cfg.ProfileByID(cfg.ActiveProfile)
 	if !ok {
-		fmt.Printf("Profile '%s' not found\n", cfg.ActiveProfile)
-		return
+		r.Error = fmt.Sprintf("Profile '%s' not found", cfg.ActiveProfile)
+		return r
 	}
+	r.Provider = profile.Provider
 
 	prov, err := buildProvider(profile)
 	if err != nil {
-		fmt.Printf("Provider error: %v\n", err)
-		return
+		r.Error = fmt.Sprintf("Provider error: %v", err)
+		return r
 	}
 
-	fmt.Printf("Scanning library for profile '%s' (%s)...\n", profile.Name, profile.Provider)
+	if progress {
+		fmt.Printf("Scanning library for profile '%s' (%s)...\n", profile.Name, profile.Provider)
+	}
 
 	// Force scan by setting scan_on_init in settings with progress callback
 	settings := make(map[string]any)
@@ -550,34 +1096,371 @@ func runScan(cfg *config.Config, logger *slog.Logger) {
 	}
 
 	settings["scan_on_init"] = true
-	// Add progress callback for CLI feedback
-	settings["scan_progress"] = func(count int, path string) {
-		// Truncate path for display
-		displayPath := path
-		if len(displayPath) > 60 {
-			displayPath = "..." + displayPath[len(displayPath)-57:]
+	if progress {
+		// Add progress callback for CLI feedback
+		settings["scan_progress"] = func(count int, path string) {
+			// Truncate path for display
+			displayPath := path
+			if len(displayPath) > 60 {
+				displayPath = "..." + displayPath[len(displayPath)-57:]
+			}
+			fmt.Printf("\r\033[K  Scanned %d tracks: %s", count, displayPath)
 		}
-		fmt.Printf("\r\033[K  Scanned %d tracks: %s", count, displayPath)
 	}
 
-	ctx := context.Background() // No timeout for scan
+	// No timeout for scan
 	start := time.Now()
-	if err := prov.Initialize(ctx, settings); err != nil {
-		fmt.Printf("\nScan error: %v\n", err)
-		return
+	err = prov.Initialize(ctx, settings)
+	r.DurationMs = time.Since(start).Milliseconds()
+	if progress {
+		// Clear progress line
+		fmt.Printf("\r\033[K")
+	}
+	if err != nil && ctx.Err() != nil {
+		r.Interrupted = true
+		r.Error = "Scan interrupted; tracks read so far were saved. Run --scan again to finish."
+		return r
+	}
+	if err != nil {
+		r.Error = fmt.Sprintf("Scan error: %v", err)
+		return r
 	}
-
-	// Clear progress line and show completion
-	fmt.Printf("\r\033[K")
 
 	// Get counts
 	healthy, details := prov.Health(ctx)
+	r.Details = details
 	if !healthy {
-		fmt.Printf("Health check failed: %s\n", details)
-		return
+		r.Error = fmt.Sprintf("Health check failed: %s", details)
+		return r
+	}
+	if fs, ok := provider.Unwrap(prov).(*filesystem.Provider); ok {
+		if stats, err := fs.Stats(ctx); err == nil {
+			r.Stats = &stats
+		}
+	}
+	r.OK = true
+	return r
+}
+
+// analyzeReport is the outcome of --analyze, printed with --json.
+type analyzeReport struct {
+	OK      bool   `json:"ok"`
+	Profile string `json:"profile"`
+	filesystem.AnalyzeResult
+	DurationMs  int64  `json:"duration_ms"`
+	Interrupted bool   `json:"interrupted,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// runAnalyze measures the loudness of the active library's untagged tracks.
+func runAnalyze(cfg *config.Config, logger *slog.Logger, jsonOut bool) {
+	ctx, stop := interruptContext()
+	r := analyzeLibrary(ctx, cfg, !jsonOut)
+	stop()
+	if jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(r)
+	} else if r.Error != "" {
+		fmt.Println(r.Error)
+	} else {
+		fmt.Printf("Analysis complete in %s\n", (time.Duration(r.DurationMs) * time.Millisecond).Round(time.Millisecond))
+		fmt.Printf("  %d of %d untagged tracks measured", r.Analyzed, r.Pending)
+		if r.Failed > 0 {
+			fmt.Printf(", %d could not be", r.Failed)
+		}
+		fmt.Println()
+	}
+	logger.Info("analysis complete", slog.Bool("ok", r.OK), slog.Int("analyzed", r.Analyzed), slog.Int("failed", r.Failed), slog.Bool("interrupted", r.Interrupted))
+	if r.Interrupted {
+		os.Exit(exitInterrupted)
 	}
+	if !r.OK {
+		os.Exit(1)
+	}
+}
 
-	fmt.Printf("Scan complete in %s\n", time.Since(start).Round(time.Millisecond))
-	fmt.Printf("  %s\n", details)
-	logger.Info("scan complete", slog.Duration("duration", time.Since(start)))
+// analyzeLibrary measures loudness in the active profile's library,
+// printing progress when progress is set. Cancelling ctx stops it,
+// keeping the measurements taken so far.
+func analyzeLibrary(ctx context.Context, cfg *config.Config, progress bool) analyzeReport {
+	r := analyzeReport{Profile: cfg.ActiveProfile}
+	profile, ok := cfg.ProfileByID(cfg.ActiveProfile)
+	if !ok {
+		r.Error = fmt.Sprintf("Profile '%s' not found", cfg.ActiveProfile)
+		return r
+	}
+	prov, err := buildProvider(profile)
+	if err != nil {
+		r.Error = fmt.Sprintf("Provider error: %v", err)
+		return r
+	}
+	fs, ok := provider.Unwrap(prov).(*filesystem.Provider)
+	if !ok {
+		r.Error = fmt.Sprintf("Loudness analysis works on filesystem libraries; profile '%s' uses %s", profile.ID, profile.Provider)
+		return r
+	}
+	if err := prov.Initialize(ctx, profile.Settings); err != nil {
+		r.Error = fmt.Sprintf("Provider error: %v", err)
+		return r
+	}
+
+	var opts filesystem.AnalyzeOptions
+	if progress {
+		fmt.Printf("Analyzing loudness for profile '%s'...\n", profile.Name)
+		opts.Progress = func(done, total int, path string) {
+			if len(path) > 50 {
+				path = "..." + path[len(path)-47:]
+			}
+			fmt.Printf("\r\033[K  %d/%d: %s", done, total, path)
+		}
+	}
+	start := time.Now()
+	r.AnalyzeResult, err = fs.Analyze(ctx, opts)
+	r.DurationMs = time.Since(start).Milliseconds()
+	if progress {
+		fmt.Printf("\r\033[K")
+	}
+	switch {
+	case err != nil && ctx.Err() != nil:
+		r.Interrupted = true
+		r.Error = "Analysis interrupted; measurements so far were saved. Run --analyze again to finish."
+	case err != nil:
+		r.Error = fmt.Sprintf("Analysis error: %v", err)
+	default:
+		r.OK = true
+	}
+	return r
+}
+
+// organizeReport is the outcome of --organize, printed with --json.
+type organizeReport struct {
+	OK      bool   `json:"ok"`
+	Profile string `json:"profile"`
+	DryRun  bool   `json:"dry_run"`
+	filesystem.OrganizeResult
+	DurationMs  int64  `json:"duration_ms"`
+	Interrupted bool   `json:"interrupted,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// runOrganize lists, or with apply makes, the moves that put the active
+// library's files where [library] organize_pattern says.
+func runOrganize(cfg *config.Config, logger *slog.Logger, apply, jsonOut bool) {
+	ctx, stop := interruptContext()
+	r := organizeLibrary(ctx, cfg, !apply, !jsonOut)
+	stop()
+	if jsonOut {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(r)
+	} else {
+		for _, mv := range r.Moves {
+			if mv.Skipped != "" {
+				fmt.Printf("  skip %s (%s)\n", mv.From, mv.Skipped)
+			} else {
+				fmt.Printf("  %s\n    → %s\n", mv.From, mv.To)
+			}
+		}
+		if r.Error != "" {
+			fmt.Println(r.Error)
+		} else if r.DryRun {
+			fmt.Printf("%d files to move, %d skipped, %d already in place. Run with --apply to move them.\n", r.Moved, r.Skipped, r.InPlace)
+		} else {
+			fmt.Printf("Moved %d files in %s, %d skipped, %d already in place\n", r.Moved, (time.Duration(r.DurationMs) * time.Millisecond).Round(time.Millisecond), r.Skipped, r.InPlace)
+		}
+	}
+	logger.Info("organize complete", slog.Bool("ok", r.OK), slog.Bool("dry_run", r.DryRun), slog.Int("moved", r.Moved), slog.Int("skipped", r.Skipped), slog.Bool("interrupted", r.Interrupted))
+	if r.Interrupted {
+		os.Exit(exitInterrupted)
+	}
+	if !r.OK {
+		os.Exit(1)
+	}
+}
+
+// organizeLibrary moves the active profile's files by their tags, or on a
+// dry run only lists the moves, printing progress when progress is set.
+// Cancelling ctx stops it between files; moves made so far stay.
+func organizeLibrary(ctx context.Context, cfg *config.Config, dryRun, progress bool) organizeReport {
+	r := organizeReport{Profile: cfg.ActiveProfile, DryRun: dryRun}
+	profile, ok := cfg.ProfileByID(cfg.ActiveProfile)
+	if !ok {
+		r.Error = fmt.Sprintf("Profile '%s' not found", cfg.ActiveProfile)
+		return r
+	}
+	prov, err := buildProvider(profile)
+	if err != nil {
+		r.Error = fmt.Sprintf("Provider error: %v", err)
+		return r
+	}
+	fs, ok := provider.Unwrap(prov).(*filesystem.Provider)
+	if !ok {
+		r.Error = fmt.Sprintf("Organizing works on filesystem libraries; profile '%s' uses %s", profile.ID, profile.Provider)
+		return r
+	}
+	if err := prov.Initialize(ctx, profile.Settings); err != nil {
+		r.Error = fmt.Sprintf("Provider error: %v", err)
+		return r
+	}
+
+	opts := filesystem.OrganizeOptions{Pattern: cfg.Library.OrganizePattern, DryRun: dryRun}
+	if progress && !dryRun {
+		fmt.Printf("Organizing files for profile '%s'...\n", profile.Name)
+		opts.Progress = func(done, total int, path string) {
+			if len(path) > 50 {
+				path = "..." + path[len(path)-47:]
+			}
+			fmt.Printf("\r\033[K  %d/%d: %s", done, total, path)
+		}
+	}
+	start := time.Now()
+	r.OrganizeResult, err = fs.Organize(ctx, opts)
+	r.DurationMs = time.Since(start).Milliseconds()
+	if opts.Progress != nil {
+		fmt.Printf("\r\033[K")
+	}
+	switch {
+	case err != nil && ctx.Err() != nil:
+		r.Interrupted = true
+		r.Error = "Organizing interrupted; files moved so far stay moved. Run --organize again to finish."
+	case err != nil:
+		r.Error = fmt.Sprintf("Organize error: %v", err)
+	default:
+		r.OK = true
+	}
+	return r
+}
+
+func runLastfmAuth(cfg *config.Config, logger *slog.Logger) {
+	entry, ok := cfg.LastfmScrobbler()
+	if !ok {
+		fmt.Println("No [[scrobblers]] entry with type = \"lastfm\" found in config.")
+		fmt.Println("Add one with your api_key and api_secret first (see docs/CONFIG.md).")
+		os.Exit(1)
+	}
+	apiKey, _ := entry.Settings["api_key"].(string)
+	apiSecret, _ := entry.Settings["api_secret"].(string)
+	if apiKey == "" || apiSecret == "" {
+		fmt.Printf("Scrobbler '%s' needs api_key and api_secret set before authorizing.\n", entry.ID)
+		fmt.Println("Create an API account at https://www.last.fm/api/account/create")
+		os.Exit(1)
+	}
+
+	auth := lastfm.NewAuth(apiKey, apiSecret)
+	ctx, stop := interruptContext()
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	defer cancel()
+
+	token, err := auth.GetToken(ctx)
+	if err != nil {
+		fmt.Printf("Failed to get token: %v\n", err)
+		os.Exit(1)
+	}
+
+	authURL := auth.AuthURL(token)
+	fmt.Println("Approve Tunez in your browser:")
+	fmt.Printf("  %s\n", authURL)
+	if err := platform.Open(authURL); err != nil {
+		logger.Debug("open browser", slog.Any("err", err))
+		fmt.Println("(Could not open a browser; open the link above manually.)")
+	}
+	fmt.Println()
+	fmt.Println("Waiting for approval (Ctrl+C to cancel)...")
+
+	sess, err := auth.WaitForSession(ctx, token, 3*time.Second)
+	if errors.Is(err, context.Canceled) {
+		fmt.Println("Cancelled.")
+		os.Exit(exitInterrupted)
+	}
+	if err != nil {
+		fmt.Printf("Authorization failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := secrets.SaveSetting(secrets.Keyring(), cfg.Path, config.SectionScrobblers, entry.ID, "session_key", sess.Key); err != nil {
+		fmt.Printf("Authorized, but failed to save session key: %v\n", err)
+		os.Exit(1)
+	}
+	logger.Info("lastfm authorized", slog.String("scrobbler", entry.ID), slog.String("user", sess.Name))
+	fmt.Printf("✓ Authorized as %s; session key saved to %s\n", sess.Name, cfg.Path)
+}
+
+// runLastfmImport copies the authorized user's play counts and loved tracks
+// into the local stats store, matched to library tracks by artist and title.
+func runLastfmImport(cfg *config.Config, logger *slog.Logger) {
+	entry, ok := cfg.LastfmScrobbler()
+	if !ok {
+		fmt.Println("No [[scrobblers]] entry with type = \"lastfm\" found in config.")
+		os.Exit(1)
+	}
+	apiKey, _ := entry.Settings["api_key"].(string)
+	apiSecret, _ := entry.Settings["api_secret"].(string)
+	sessionKey, _ := entry.Settings["session_key"].(string)
+	lfm := lastfm.New(entry.ID, lastfm.Config{APIKey: apiKey, APISecret: apiSecret, SessionKey: sessionKey})
+	if !lfm.IsEnabled() {
+		fmt.Println("Last.fm isn't authorized yet; run tunez --lastfm-auth first.")
+		os.Exit(1)
+	}
+
+	ctx, stop := interruptContext()
+	defer stop()
+	user, err := lfm.Username(ctx)
+	if err != nil {
+		fmt.Printf("Failed to look up the Last.fm user: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Importing from Last.fm user %s...\n", user)
+	top, err := lfm.TopTracks(ctx, user)
+	if err != nil {
+		fmt.Printf("Failed to fetch play counts: %v\n", err)
+		os.Exit(1)
+	}
+	loved, err := lfm.LovedTracks(ctx, user)
+	if err != nil {
+		fmt.Printf("Failed to fetch loved tracks: %v\n", err)
+		os.Exit(1)
+	}
+
+	stats := make([]queue.TrackStats, 0, len(top)+len(loved))
+	for _, t := range top {
+		stats = append(stats, queue.TrackStats{Artist: t.Artist, Title: t.Title, Plays: t.Plays})
+	}
+	for _, t := range loved {
+		stats = append(stats, queue.TrackStats{Artist: t.Artist, Title: t.Title, Loved: true})
+	}
+	store, err := queue.NewPersistenceStore("")
+	if err != nil {
+		fmt.Printf("Failed to open the state database: %v\n", err)
+		os.Exit(1)
+	}
+	defer store.Close()
+	if err := store.ImportStats(ctx, stats); err != nil {
+		fmt.Printf("Import failed: %v\n", err)
+		os.Exit(1)
+	}
+	logger.Info("lastfm import", slog.String("user", user), slog.Int("played", len(top)), slog.Int("loved", len(loved)))
+	fmt.Printf("✓ Imported play counts for %d tracks and %d loved tracks\n", len(top), len(loved))
+	if !cfg.Queue.Persist {
+		fmt.Println("  Set [queue] persist = true to see them in Tunez.")
+	}
+}
+
+func runSecretsMigrate(cfgPath string, logger *slog.Logger) {
+	moved, err := secrets.Migrate(secrets.Keyring(), cfgPath)
+	for _, m := range moved {
+		fmt.Printf("✓ %s.%s.%s → keyring:%s\n", m.Section, m.ID, m.Key, m.Name)
+	}
+	if err != nil {
+		logger.Error("migrate secrets", slog.Any("err", err))
+		fmt.Printf("Migration failed: %v\n", err)
+		os.Exit(1)
+	}
+	if len(moved) == 0 {
+		fmt.Println("No plaintext secrets found in config.")
+		return
+	}
+	logger.Info("migrated secrets to keyring", slog.Int("count", len(moved)))
+	fmt.Printf("Moved %d secret(s) to the OS keyring; %s now holds references only.\n", len(moved), cfgPath)
 }
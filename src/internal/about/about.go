@@ -0,0 +1,147 @@
+// Package about fetches artist biographies and album reviews from Last.fm
+// or Wikipedia for the About panel, caching them on disk, and similar
+// artists from Last.fm.
+package about
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a source has nothing on an artist or album.
+var ErrNotFound = errors.New("no information found")
+
+// userAgent identifies tunez to the public APIs, as Wikipedia requires.
+const userAgent = "tunez (https://github.com/sphildreth/tunez)"
+
+// Info is the text shown in the About panel.
+type Info struct {
+	Title  string `json:"title"`
+	Text   string `json:"text"`
+	Source string `json:"source"`
+	URL    string `json:"url,omitempty"`
+}
+
+// Source looks up artists and albums.
+type Source interface {
+	Name() string
+	Artist(ctx context.Context, name string) (Info, error)
+	Album(ctx context.Context, artist, album string) (Info, error)
+}
+
+// Cache wraps a Source, keeping what it finds, and what it doesn't, on disk
+// for a number of days.
+type Cache struct {
+	src  Source
+	dir  string
+	days int
+}
+
+// cacheEntry is one cached lookup; NotFound entries stop repeated misses
+// hitting the network.
+type cacheEntry struct {
+	Info     Info `json:"info"`
+	NotFound bool `json:"not_found,omitempty"`
+}
+
+// NewCache caches src under dir, or the user cache dir when dir is empty.
+func NewCache(src Source, dir string, days int) (*Cache, error) {
+	if dir == "" {
+		var err error
+		dir, err = defaultCacheDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolve cache dir: %w", err)
+		}
+	}
+	if days <= 0 {
+		days = 30
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	return &Cache{src: src, dir: dir, days: days}, nil
+}
+
+func defaultCacheDir() (string, error) {
+	switch runtime.GOOS {
+	case "darwin":
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, "Library", "Caches", "tunez", "about"), nil
+	case "windows":
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "Tunez", "about"), nil
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tunez", "about"), nil
+}
+
+func (c *Cache) Name() string { return c.src.Name() }
+
+// Artist returns the cached artist info, fetching it when missing or stale.
+func (c *Cache) Artist(ctx context.Context, name string) (Info, error) {
+	return c.lookup("artist", name, func() (Info, error) { return c.src.Artist(ctx, name) })
+}
+
+// Album returns the cached album info, fetching it when missing or stale.
+func (c *Cache) Album(ctx context.Context, artist, album string) (Info, error) {
+	return c.lookup("album", artist+"\x00"+album, func() (Info, error) { return c.src.Album(ctx, artist, album) })
+}
+
+func (c *Cache) lookup(kind, key string, fetch func() (Info, error)) (Info, error) {
+	sum := sha256.Sum256([]byte(c.src.Name() + "\x00" + kind + "\x00" + strings.ToLower(key)))
+	path := filepath.Join(c.dir, hex.EncodeToString(sum[:])[:16]+".json")
+
+	if st, err := os.Stat(path); err == nil && time.Since(st.ModTime()) < time.Duration(c.days)*24*time.Hour {
+		if data, err := os.ReadFile(path); err == nil {
+			var e cacheEntry
+			if json.Unmarshal(data, &e) == nil {
+				if e.NotFound {
+					return Info{}, ErrNotFound
+				}
+				return e.Info, nil
+			}
+		}
+	}
+
+	info, err := fetch()
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return info, err
+	}
+	// Failing to cache only costs a refetch next time
+	if data, merr := json.Marshal(cacheEntry{Info: info, NotFound: err != nil}); merr == nil {
+		_ = os.WriteFile(path, data, 0o644)
+	}
+	return info, err
+}
+
+var (
+	htmlTag    = regexp.MustCompile(`<[^>]*>`)
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// plainText strips HTML tags and entities and tidies blank lines.
+func plainText(s string) string {
+	s = html.UnescapeString(htmlTag.ReplaceAllString(s, ""))
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(s, "\n\n"))
+}
@@ -0,0 +1,144 @@
+package about
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLastfmArtist(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("api_key") != "key" || q.Get("format") != "json" {
+			t.Errorf("unexpected query %v", q)
+		}
+		if q.Get("artist") != "The Beatles" {
+			w.Write([]byte(`{"error":6,"message":"The artist you supplied could not be found"}`))
+			return
+		}
+		w.Write([]byte(`{"artist":{"name":"The Beatles","url":"https://www.last.fm/music/The+Beatles","bio":{"content":"The Beatles were an English rock band &amp; more.\n\n<a href=\"https://www.last.fm/music/The+Beatles\">Read more on Last.fm</a>. User-contributed text is available under the Creative Commons By-SA License; additional terms may apply."}}}`))
+	}))
+	defer srv.Close()
+	l := NewLastfm("key")
+	l.endpoint = srv.URL
+
+	info, err := l.Artist(context.Background(), "The Beatles")
+	if err != nil {
+		t.Fatalf("Artist: %v", err)
+	}
+	if info.Text != "The Beatles were an English rock band & more." || info.Source != "Last.fm" || info.URL == "" {
+		t.Errorf("unexpected info %+v", info)
+	}
+	if _, err := l.Artist(context.Background(), "Nobody"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestWikipediaAlbum(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch strings.TrimPrefix(r.URL.Path, "/") {
+		case "Abbey_Road_(album)":
+			w.Write([]byte(`{"type":"standard","title":"Abbey Road","extract":"Abbey Road is the eleventh studio album by the English rock band the Beatles.","content_urls":{"desktop":{"page":"https://en.wikipedia.org/wiki/Abbey_Road"}}}`))
+		case "Abbey_Road":
+			w.Write([]byte(`{"type":"standard","title":"Abbey Road","extract":"Abbey Road is a street in London."}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+	wp := NewWikipedia("en")
+	wp.endpoint = srv.URL + "/"
+
+	info, err := wp.Album(context.Background(), "The Beatles", "Abbey Road")
+	if err != nil {
+		t.Fatalf("Album: %v", err)
+	}
+	if !strings.HasPrefix(info.Text, "Abbey Road is the eleventh") {
+		t.Errorf("expected the album page, got %+v", info)
+	}
+	// The street page doesn't mention the artist
+	if _, err := wp.Album(context.Background(), "Someone Else", "Abbey Road"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+type countingSource struct {
+	calls int
+	err   error
+}
+
+func (s *countingSource) Name() string { return "test" }
+
+func (s *countingSource) Artist(ctx context.Context, name string) (Info, error) {
+	s.calls++
+	if s.err != nil {
+		return Info{}, s.err
+	}
+	return Info{Title: name, Text: "bio of " + name}, nil
+}
+
+func (s *countingSource) Album(ctx context.Context, artist, album string) (Info, error) {
+	s.calls++
+	return Info{}, ErrNotFound
+}
+
+func TestCache(t *testing.T) {
+	src := &countingSource{}
+	c, err := NewCache(src, t.TempDir(), 1)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	ctx := context.Background()
+	for range 2 {
+		if info, err := c.Artist(ctx, "Muse"); err != nil || info.Text != "bio of Muse" {
+			t.Fatalf("Artist: %+v %v", info, err)
+		}
+		if _, err := c.Album(ctx, "Muse", "Absolution"); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if src.calls != 2 {
+		t.Errorf("expected hits and misses cached, got %d fetches", src.calls)
+	}
+
+	// Network errors aren't cached
+	src.err = errors.New("offline")
+	if _, err := c.Artist(ctx, "Blur"); err == nil {
+		t.Fatal("expected the fetch error")
+	}
+	src.err = nil
+	if info, err := c.Artist(ctx, "Blur"); err != nil || info.Text != "bio of Blur" {
+		t.Errorf("expected a refetch after an error, got %+v %v", info, err)
+	}
+}
+
+func TestLastfmSimilarArtists(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("method") != "artist.getSimilar" || q.Get("limit") != "2" {
+			t.Errorf("unexpected query %v", q)
+		}
+		if q.Get("artist") == "Loner" {
+			w.Write([]byte(`{"similarartists":{"artist":{"name":"Only Friend","match":"0.5"}}}`))
+			return
+		}
+		w.Write([]byte(`{"similarartists":{"artist":[{"name":"The Rolling Stones","match":"1"},{"name":"The Kinks","match":"0.83"}]}}`))
+	}))
+	defer srv.Close()
+	l := NewLastfm("key")
+	l.endpoint = srv.URL
+
+	got, err := l.SimilarArtists(context.Background(), "The Beatles", 2)
+	if err != nil {
+		t.Fatalf("SimilarArtists: %v", err)
+	}
+	if len(got) != 2 || got[1].Name != "The Kinks" || got[1].Match != 0.83 {
+		t.Errorf("unexpected result %+v", got)
+	}
+	if got, err := l.SimilarArtists(context.Background(), "Loner", 2); err != nil || len(got) != 1 {
+		t.Errorf("expected a single artist object decoded, got %+v %v", got, err)
+	}
+}
@@ -0,0 +1,158 @@
+package about
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const lastfmURL = "https://ws.audioscrobbler.com/2.0/"
+
+// Lastfm reads artist bios and album wikis from Last.fm. It only needs an
+// API key, not a session.
+type Lastfm struct {
+	apiKey   string
+	endpoint string
+	client   *http.Client
+}
+
+// NewLastfm creates a Last.fm source for apiKey.
+func NewLastfm(apiKey string) *Lastfm {
+	return &Lastfm{apiKey: apiKey, endpoint: lastfmURL, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+func (l *Lastfm) Name() string { return "Last.fm" }
+
+// Artist returns an artist's biography (artist.getInfo).
+func (l *Lastfm) Artist(ctx context.Context, name string) (Info, error) {
+	var resp struct {
+		Artist struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+			Bio  struct {
+				Content string `json:"content"`
+			} `json:"bio"`
+		} `json:"artist"`
+	}
+	if err := l.get(ctx, url.Values{"method": {"artist.getInfo"}, "artist": {name}}, &resp); err != nil {
+		return Info{}, err
+	}
+	return l.info(resp.Artist.Name, resp.Artist.Bio.Content, resp.Artist.URL)
+}
+
+// Album returns an album's wiki text (album.getInfo).
+func (l *Lastfm) Album(ctx context.Context, artist, album string) (Info, error) {
+	var resp struct {
+		Album struct {
+			Name string `json:"name"`
+			URL  string `json:"url"`
+			Wiki struct {
+				Content string `json:"content"`
+			} `json:"wiki"`
+		} `json:"album"`
+	}
+	if err := l.get(ctx, url.Values{"method": {"album.getInfo"}, "artist": {artist}, "album": {album}}, &resp); err != nil {
+		return Info{}, err
+	}
+	return l.info(resp.Album.Name, resp.Album.Wiki.Content, resp.Album.URL)
+}
+
+// Similar is an artist like another, with Last.fm's match score from 0 to 1.
+type Similar struct {
+	Name  string
+	Match float64
+}
+
+// SimilarArtists returns up to limit artists similar to name, closest first
+// (artist.getSimilar).
+func (l *Lastfm) SimilarArtists(ctx context.Context, name string, limit int) ([]Similar, error) {
+	var resp struct {
+		Similar struct {
+			Artist json.RawMessage `json:"artist"`
+		} `json:"similarartists"`
+	}
+	params := url.Values{"method": {"artist.getSimilar"}, "artist": {name}, "limit": {strconv.Itoa(limit)}}
+	if err := l.get(ctx, params, &resp); err != nil {
+		return nil, err
+	}
+	type artist struct {
+		Name  string `json:"name"`
+		Match string `json:"match"`
+	}
+	var items []artist
+	raw := bytes.TrimSpace(resp.Similar.Artist)
+	switch {
+	case len(raw) == 0:
+	case raw[0] == '{':
+		var one artist
+		if err := json.Unmarshal(raw, &one); err != nil {
+			return nil, err
+		}
+		items = []artist{one}
+	default:
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return nil, err
+		}
+	}
+	out := make([]Similar, 0, len(items))
+	for _, a := range items {
+		match, _ := strconv.ParseFloat(a.Match, 64)
+		out = append(out, Similar{Name: a.Name, Match: match})
+	}
+	return out, nil
+}
+
+// lastfmFooter matches the "Read more on Last.fm" link and licence notice
+// that end every bio.
+var lastfmFooter = regexp.MustCompile(`(?s)\s*<a href="[^"]*">Read more on Last\.fm</a>.*$|\s*User-contributed text is available.*$`)
+
+func (l *Lastfm) info(title, content, link string) (Info, error) {
+	text := plainText(lastfmFooter.ReplaceAllString(content, ""))
+	if text == "" {
+		return Info{}, ErrNotFound
+	}
+	return Info{Title: title, Text: text, Source: l.Name(), URL: link}, nil
+}
+
+// get calls an unsigned read method. Error 6 is Last.fm's "not found".
+func (l *Lastfm) get(ctx context.Context, params url.Values, out any) error {
+	params.Set("api_key", l.apiKey)
+	params.Set("autocorrect", "1")
+	params.Set("format", "json")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, l.endpoint+"?"+params.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		Error   int    `json:"error"`
+		Message string `json:"message"`
+	}
+	raw := json.RawMessage{}
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		return fmt.Errorf("lastfm: %s: %w", resp.Status, err)
+	}
+	if json.Unmarshal(raw, &body) == nil && body.Error != 0 {
+		if body.Error == 6 {
+			return ErrNotFound
+		}
+		return fmt.Errorf("lastfm error %d: %s", body.Error, strings.TrimSpace(body.Message))
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("lastfm: %s", resp.Status)
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,98 @@
+package about
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Wikipedia reads page summaries from a Wikipedia edition's REST API.
+type Wikipedia struct {
+	endpoint string
+	client   *http.Client
+}
+
+// NewWikipedia creates a source for the Wikipedia in lang, e.g. "en".
+func NewWikipedia(lang string) *Wikipedia {
+	if lang == "" {
+		lang = "en"
+	}
+	return &Wikipedia{
+		endpoint: "https://" + lang + ".wikipedia.org/api/rest_v1/page/summary/",
+		client:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (w *Wikipedia) Name() string { return "Wikipedia" }
+
+// Artist tries the artist's own page, then the usual disambiguated titles.
+func (w *Wikipedia) Artist(ctx context.Context, name string) (Info, error) {
+	return w.first(ctx, "", name, name+" (band)", name+" (musician)", name+" (singer)")
+}
+
+// Album tries the album's disambiguated titles, then its plain title, and
+// only takes a page that mentions the artist.
+func (w *Wikipedia) Album(ctx context.Context, artist, album string) (Info, error) {
+	return w.first(ctx, artist, album+" ("+artist+" album)", album+" (album)", album)
+}
+
+// first returns the first title with a standard article, skipping
+// disambiguation pages and, when mention is set, pages that don't name it.
+func (w *Wikipedia) first(ctx context.Context, mention string, titles ...string) (Info, error) {
+	for _, title := range titles {
+		info, err := w.summary(ctx, title)
+		if err == ErrNotFound {
+			continue
+		}
+		if err != nil {
+			return Info{}, err
+		}
+		if mention != "" && !strings.Contains(strings.ToLower(info.Text), strings.ToLower(mention)) {
+			continue
+		}
+		return info, nil
+	}
+	return Info{}, ErrNotFound
+}
+
+func (w *Wikipedia) summary(ctx context.Context, title string) (Info, error) {
+	page := url.PathEscape(strings.ReplaceAll(title, " ", "_"))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, w.endpoint+page+"?redirect=true", nil)
+	if err != nil {
+		return Info{}, err
+	}
+	req.Header.Set("User-Agent", userAgent)
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return Info{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return Info{}, ErrNotFound
+	}
+	if resp.StatusCode >= 400 {
+		return Info{}, fmt.Errorf("wikipedia: %s", resp.Status)
+	}
+	var body struct {
+		Type        string `json:"type"`
+		Title       string `json:"title"`
+		Extract     string `json:"extract"`
+		ContentURLs struct {
+			Desktop struct {
+				Page string `json:"page"`
+			} `json:"desktop"`
+		} `json:"content_urls"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Info{}, fmt.Errorf("wikipedia: %w", err)
+	}
+	text := plainText(body.Extract)
+	if body.Type != "standard" || text == "" {
+		return Info{}, ErrNotFound
+	}
+	return Info{Title: body.Title, Text: text, Source: w.Name(), URL: body.ContentURLs.Desktop.Page}, nil
+}
@@ -0,0 +1,227 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/about"
+	"github.com/tunez/tunez/internal/config"
+)
+
+// aboutMsg carries the bio or review fetched for the About panel.
+type aboutMsg struct {
+	key  string
+	info about.Info
+	err  error
+}
+
+// aboutTarget is the artist or album the About panel describes.
+type aboutTarget struct {
+	artist string
+	album  string
+}
+
+func (t aboutTarget) key() string { return t.artist + "\x00" + t.album }
+
+// newAboutSource builds the cached bio source from [about], or nil when the
+// panel is off. Last.fm is used when a Last.fm api_key is configured.
+func newAboutSource(cfg *config.Config, logger *slog.Logger) about.Source {
+	if !cfg.About.Enabled {
+		return nil
+	}
+	var apiKey string
+	if entry, ok := cfg.LastfmScrobbler(); ok {
+		apiKey, _ = entry.Settings["api_key"].(string)
+	}
+	var src about.Source
+	switch {
+	case cfg.About.Source == "wikipedia", cfg.About.Source == "" && apiKey == "":
+		src = about.NewWikipedia(cfg.About.Language)
+	case apiKey == "":
+		logger.Warn("about panel needs a Last.fm api_key in [[scrobblers]]; using Wikipedia")
+		src = about.NewWikipedia(cfg.About.Language)
+	default:
+		src = about.NewLastfm(apiKey)
+	}
+	cache, err := about.NewCache(src, "", cfg.About.CacheDays)
+	if err != nil {
+		logger.Warn("about cache disabled", slog.Any("err", err))
+		return src
+	}
+	return cache
+}
+
+// aboutTargetAt returns the artist or album under the cursor: the selected
+// Library or Search artist or album, or the album whose tracks are listed.
+func (m Model) aboutTargetAt() (aboutTarget, bool) {
+	switch m.screen {
+	case screenLibrary:
+		switch m.libraryView() {
+		case "tracks":
+			t := m.tracks[0]
+			return aboutTarget{artist: t.ArtistName, album: t.AlbumTitle}, true
+		case "albums":
+			a := m.albums[clamp(m.selection, 0, len(m.albums)-1)]
+			return aboutTarget{artist: a.ArtistName, album: a.Title}, true
+		}
+		if len(m.artists) > 0 {
+			return aboutTarget{artist: m.artists[clamp(m.selection, 0, len(m.artists)-1)].Name}, true
+		}
+	case screenSearch:
+		switch {
+		case m.searchFilter == filterArtists && len(m.searchResults.Artists.Items) > 0:
+			items := m.searchResults.Artists.Items
+			return aboutTarget{artist: items[clamp(m.selection, 0, len(items)-1)].Name}, true
+		case m.searchFilter == filterAlbums && len(m.searchResults.Albums.Items) > 0:
+			a := m.searchResults.Albums.Items[clamp(m.selection, 0, len(m.searchResults.Albums.Items)-1)]
+			return aboutTarget{artist: a.ArtistName, album: a.Title}, true
+		}
+	}
+	return aboutTarget{}, false
+}
+
+// openAbout shows the About panel for the selected artist or album and
+// starts fetching its text.
+func (m Model) openAbout() (Model, tea.Cmd) {
+	if m.about == nil {
+		m.status = m.msgs.T("about.off")
+		return m, nil
+	}
+	target, ok := m.aboutTargetAt()
+	if !ok || target.artist == "" {
+		m.status = m.msgs.T("status.select_artist_album")
+		return m, nil
+	}
+	m.showAbout = true
+	m.aboutTarget = target
+	m.aboutInfo = about.Info{}
+	m.aboutErr = nil
+	m.aboutLoading = true
+	m.aboutScroll = 0
+	return m, m.fetchAboutCmd(target)
+}
+
+// fetchAboutCmd looks up an artist bio or album review.
+func (m Model) fetchAboutCmd(target aboutTarget) tea.Cmd {
+	src := m.about
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		var info about.Info
+		var err error
+		if target.album != "" {
+			info, err = src.Album(ctx, target.artist, target.album)
+		} else {
+			info, err = src.Artist(ctx, target.artist)
+		}
+		return aboutMsg{key: target.key(), info: info, err: err}
+	}
+}
+
+// handleAbout shows fetched text if the panel still wants it.
+func (m Model) handleAbout(msg aboutMsg) (Model, tea.Cmd) {
+	if !m.showAbout || msg.key != m.aboutTarget.key() {
+		return m, nil
+	}
+	m.aboutLoading = false
+	m.aboutInfo = msg.info
+	m.aboutErr = msg.err
+	if msg.err != nil && !errors.Is(msg.err, about.ErrNotFound) {
+		m.logger.Warn("about fetch failed", slog.String("artist", m.aboutTarget.artist), slog.String("album", m.aboutTarget.album), slog.Any("err", msg.err))
+	}
+	return m, nil
+}
+
+// handleAboutKey scrolls and closes the About panel.
+func (m Model) handleAboutKey(key string) (Model, tea.Cmd) {
+	page := m.aboutRows()
+	switch key {
+	case "esc", "q", "I":
+		m.showAbout = false
+	case "down", "j":
+		m.aboutScroll++
+	case "up", "k":
+		m.aboutScroll--
+	case "pgdown", " ", "ctrl+f":
+		m.aboutScroll += page
+	case "pgup", "ctrl+b":
+		m.aboutScroll -= page
+	case "g", "home":
+		m.aboutScroll = 0
+	case "G", "end":
+		m.aboutScroll = len(m.aboutLines())
+	}
+	m.aboutScroll = clamp(m.aboutScroll, 0, max(len(m.aboutLines())-page, 0))
+	return m, nil
+}
+
+// aboutWidth is the text width of the About panel.
+func (m Model) aboutWidth() int {
+	return clamp(m.width-12, 20, 80)
+}
+
+// aboutRows is how many lines of text the About panel shows at once.
+func (m Model) aboutRows() int {
+	return max(m.height-12, 3)
+}
+
+// aboutLines wraps the fetched text to the panel width.
+func (m Model) aboutLines() []string {
+	if m.aboutInfo.Text == "" {
+		return nil
+	}
+	wrapped := lipgloss.NewStyle().Width(m.aboutWidth()).Render(m.aboutInfo.Text)
+	return strings.Split(wrapped, "\n")
+}
+
+// renderAbout renders the About panel popup.
+func (m Model) renderAbout() string {
+	heading := m.aboutTarget.artist
+	if m.aboutTarget.album != "" {
+		heading = m.aboutTarget.album + " — " + m.aboutTarget.artist
+	}
+	fit := lipgloss.NewStyle().MaxWidth(m.aboutWidth())
+
+	var body []string
+	switch {
+	case m.aboutLoading:
+		body = []string{m.theme.Dim.Render("Loading…")}
+	case errors.Is(m.aboutErr, about.ErrNotFound):
+		body = []string{m.theme.Dim.Render("No information found on " + m.about.Name())}
+	case m.aboutErr != nil:
+		body = []string{m.theme.Error.Render("Failed to load: " + m.aboutErr.Error())}
+	default:
+		lines := m.aboutLines()
+		rows := m.aboutRows()
+		start := clamp(m.aboutScroll, 0, max(len(lines)-rows, 0))
+		for _, line := range lines[start:min(start+rows, len(lines))] {
+			body = append(body, m.theme.Text.Render(line))
+		}
+		if len(lines) > rows {
+			body = append(body, "", m.theme.Dim.Render(fmt.Sprintf("Lines %d-%d of %d", start+1, min(start+rows, len(lines)), len(lines))))
+		}
+	}
+
+	footer := "[j/k]Scroll  [Esc]Close"
+	if m.aboutInfo.Source != "" {
+		footer = "From " + m.aboutInfo.Source + "  " + footer
+	}
+	parts := []string{
+		m.theme.Title.Render("  ═══ About ═══  "),
+		m.theme.Accent.Render(fit.Render(heading)),
+		"",
+	}
+	parts = append(parts, body...)
+	if m.aboutInfo.URL != "" {
+		parts = append(parts, "", m.theme.Dim.Render(fit.Render(m.aboutInfo.URL)))
+	}
+	parts = append(parts, "", m.theme.Dim.Render(footer))
+	content := lipgloss.JoinVertical(lipgloss.Left, parts...)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
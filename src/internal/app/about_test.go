@@ -0,0 +1,73 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/about"
+)
+
+type fakeAbout struct{}
+
+func (fakeAbout) Name() string { return "Fake" }
+
+func (fakeAbout) Artist(ctx context.Context, name string) (about.Info, error) {
+	return about.Info{}, about.ErrNotFound
+}
+
+func (fakeAbout) Album(ctx context.Context, artist, album string) (about.Info, error) {
+	lines := make([]string, 40)
+	for i := range lines {
+		lines[i] = "Line about " + album
+	}
+	return about.Info{Title: album, Text: strings.Join(lines, "\n"), Source: "Fake"}, nil
+}
+
+func TestAboutPanel(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.screen = screenLibrary
+	key := func(k string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)} }
+
+	// Off unless enabled
+	m, _ = updateModel(m, key("I"))
+	if m.showAbout || !strings.Contains(m.status, "[about] enabled") {
+		t.Fatalf("expected a hint to enable the panel, got %q", m.status)
+	}
+
+	m.about = fakeAbout{}
+	m.albums = prov.albums
+	m, cmd := updateModel(m, key("I"))
+	if !m.showAbout || cmd == nil {
+		t.Fatal("expected the panel to open and fetch")
+	}
+	if !strings.Contains(m.View(), "Loading…") {
+		t.Error("expected a loading message")
+	}
+	m, _ = updateModel(m, cmd())
+	view := m.View()
+	if !strings.Contains(view, "Line about Abbey Road") || !strings.Contains(view, "From Fake") {
+		t.Errorf("expected the album text, got:\n%s", view)
+	}
+
+	m, _ = updateModel(m, key("G"))
+	if m.aboutScroll == 0 || !strings.Contains(m.View(), "of 40") {
+		t.Errorf("expected to scroll to the end, scroll=%d", m.aboutScroll)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showAbout {
+		t.Fatal("expected esc to close the panel")
+	}
+
+	// Artists without a bio
+	m.albums = nil
+	m.artists = prov.artists
+	m, cmd = updateModel(m, key("I"))
+	m, _ = updateModel(m, cmd())
+	if !strings.Contains(m.View(), "No information found on Fake") {
+		t.Error("expected a not found message")
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// albumGroups are the sections an album list is split into when the
+// provider reports release types, in order.
+var albumGroups = []string{"Albums", "Singles & EPs", "Compilations", "Live", "Other"}
+
+// albumGroup returns the index in albumGroups of a release type. Albums
+// without a type count as albums.
+func albumGroup(t string) int {
+	switch strings.ToLower(t) {
+	case "", "album":
+		return 0
+	case "single", "ep":
+		return 1
+	case "compilation":
+		return 2
+	case "live":
+		return 3
+	}
+	return 4
+}
+
+// albumsTyped reports whether the provider gave any album a release type.
+func albumsTyped(albums []provider.Album) bool {
+	return slices.ContainsFunc(albums, func(a provider.Album) bool { return a.Type != "" })
+}
+
+// groupAlbums moves albums into their albumGroups sections, keeping the
+// provider's order within each. Lists without release types are left as
+// they are.
+func groupAlbums(albums []provider.Album) []provider.Album {
+	if albumsTyped(albums) {
+		slices.SortStableFunc(albums, func(a, b provider.Album) int {
+			return albumGroup(a.Type) - albumGroup(b.Type)
+		})
+	}
+	return albums
+}
+
+// albumGroupHeader renders the heading above the first album of a section.
+func (m Model) albumGroupHeader(group int) string {
+	return m.theme.Dim.Render("  ── " + albumGroups[group] + " ──")
+}
@@ -0,0 +1,41 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestAlbumGroups(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenLibrary
+	albums := []provider.Album{
+		{ID: "a1", Title: "Hey Jude", Type: "single"},
+		{ID: "a2", Title: "Abbey Road", Type: "album"},
+		{ID: "a3", Title: "Magical Mystery Tour", Type: "EP"},
+		{ID: "a4", Title: "Let It Be"},
+	}
+	m, _ = updateModel(m, albumsMsg{page: provider.Page[provider.Album]{Items: albums}})
+
+	var got []string
+	for _, a := range m.albums {
+		got = append(got, a.ID)
+	}
+	if strings.Join(got, " ") != "a2 a4 a1 a3" {
+		t.Fatalf("expected albums before singles and EPs in the provider's order, got %v", got)
+	}
+	view := m.View()
+	albumsAt, singlesAt := strings.Index(view, "── Albums ──"), strings.Index(view, "── Singles & EPs ──")
+	if albumsAt < 0 || singlesAt < albumsAt || strings.Index(view, "Hey Jude") < singlesAt {
+		t.Errorf("expected an Albums section followed by Singles & EPs:\n%s", view)
+	}
+
+	// Without release types the list is left alone
+	untyped := []provider.Album{{ID: "b", Title: "B"}, {ID: "a", Title: "A"}}
+	m, _ = updateModel(m, albumsMsg{page: provider.Page[provider.Album]{Items: untyped}})
+	if m.albums[0].ID != "b" || strings.Contains(m.View(), "── Albums ──") {
+		t.Error("expected untyped albums ungrouped")
+	}
+}
@@ -2,18 +2,27 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"image"
 	"log/slog"
-	"math/rand"
+	"math"
 	"path/filepath"
 	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/about"
 	"github.com/tunez/tunez/internal/artwork"
 	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/control"
+	"github.com/tunez/tunez/internal/hooks"
+	"github.com/tunez/tunez/internal/i18n"
+	"github.com/tunez/tunez/internal/nowplaying"
+	"github.com/tunez/tunez/internal/platform"
 	"github.com/tunez/tunez/internal/player"
+	"github.com/tunez/tunez/internal/plugin"
 	"github.com/tunez/tunez/internal/provider"
 	"github.com/tunez/tunez/internal/queue"
 	"github.com/tunez/tunez/internal/scrobble"
@@ -31,6 +40,7 @@ const (
 	screenQueue
 	screenPlaylists
 	screenLyrics
+	screenScrobbles
 	screenConfig
 )
 
@@ -50,6 +60,7 @@ var (
 		"queue",
 		"playlists",
 		"lyrics",
+		"scrobbles",
 		"config",
 	}
 	paneNames = []string{
@@ -117,11 +128,18 @@ type ProviderFactory func(config.Profile) (provider.Provider, error)
 
 // StartupOptions contains CLI options for startup behavior
 type StartupOptions struct {
-	SearchArtist string // --artist flag
-	SearchAlbum  string // --album flag
-	AutoPlay     bool   // --play flag
-	RandomPlay   bool   // --random flag
-	ClearQueue   bool   // --clear-queue flag
+	SearchArtist string                // --artist flag
+	SearchAlbum  string                // --album flag
+	SearchTrack  string                // --track flag
+	Playlist     string                // --playlist flag
+	AutoPlay     bool                  // --play flag
+	RandomPlay   bool                  // --random flag
+	RandomFilter provider.RandomFilter // --genre and --years flags, narrowing --random
+	RandomAlbum  bool                  // --random-album flag
+	RandomArtist bool                  // --random-artist flag
+	ClearQueue   bool                  // --clear-queue flag
+	Shuffle      bool                  // --shuffle flag
+	Repeat       string                // --repeat flag: off, all or one; empty keeps the mode
 }
 
 type Model struct {
@@ -133,7 +151,12 @@ type Model struct {
 	queueStore   *queue.PersistenceStore
 	scrobbler    *scrobble.Manager
 	artworkCache *artwork.Cache
+	plugins      *plugin.Manager
+	hooks        *hooks.Runner
+	exporter     *nowplaying.Exporter
+	control      *control.Server
 	theme        ui.Theme
+	msgs         i18n.Catalog // interface text in [ui] language
 	logger       *slog.Logger
 
 	screen          screen
@@ -147,12 +170,16 @@ type Model struct {
 	albumsCursor    string
 	tracks          []provider.Track
 	tracksCursor    string
+	trackVersions   versionList // versions folded into tracks' rows
 	playlists       []provider.Playlist
 	playlistsCursor string
 	currentArtistID string
 	currentAlbumID  string
 	searchQ         string
 	searchResults   provider.SearchResults
+	searchFor       string          // query searchResults are for
+	searchDone      map[string]bool // kinds of result in for searchFor
+	searchVersions  versionList     // versions folded into the track results' rows
 	searchFilter    searchFilter
 	selection       int
 	width           int
@@ -160,6 +187,7 @@ type Model struct {
 	showHelp        bool
 	nowPlaying      provider.Track
 	paused          bool
+	playerPaused    bool // last pause state reported by mpv
 	timePos         float64
 	duration        float64
 	volume          float64
@@ -168,9 +196,23 @@ type Model struct {
 	noEmoji         bool
 	healthOK        bool
 	healthDetails   string
+	healthCheckedAt time.Time
+	healthFailedAt  time.Time
+	healthLatencies []time.Duration
 	startupOpts     StartupOptions
 	startupDone     bool // true after startup search/play is complete
 
+	// degraded is set while the provider is down and its response cache
+	// serves saved data
+	degraded   bool
+	showHealth bool
+
+	// lowBandwidth streams at [player] low_bandwidth_quality instead of
+	// stream_quality
+	lowBandwidth bool
+	prefetch     prefetchState
+	pageFetch    string // key of the list page being fetched ahead
+
 	// Lyrics state (Phase 2)
 	lyrics             string
 	lyricsLoading      bool
@@ -185,9 +227,19 @@ type Model struct {
 	artworkANSI    string // ANSI art for current track
 	artworkLoading bool
 	artworkTrackID string // track ID artwork was fetched for
+	artworkRef     string // artworkCacheRef of the artwork shown
+	// artworkLoader runs the conversions; copies of the model share it
+	artworkLoader *artworkLoader
 
 	// Visualizer state (Phase 2)
 	visualizer *visualizer.Visualizer
+	vizStyle   string
+
+	// Zen mode shows only artwork, title, progress and the visualizer
+	zen               bool
+	zenArtworkANSI    string
+	zenArtworkTrackID string
+	lastInput         time.Time
 
 	// Command palette state (Phase 3)
 	showPalette     bool
@@ -197,6 +249,134 @@ type Model struct {
 	// Diagnostics state (Phase 3)
 	showDiagnostics  bool
 	diagnosticsState *DiagnosticsState
+
+	// Track info popup state
+	showTrackInfo    bool
+	trackInfo        provider.Track
+	trackInfoLoading bool
+
+	// Tracks loved this session, by track ID
+	loved map[string]bool
+
+	// Library/Queue filter state
+	filter listFilter
+
+	// Set after ' until the initial to jump to is typed
+	jumpPending bool
+
+	// Profile quick-switch popup state
+	showProfileSwitcher bool
+	profileSwitcherSel  int
+
+	// Bookmarks of the playing track, and of the track in the info popup
+	bookmarks          []queue.Bookmark
+	showBookmarks      bool
+	bookmarkSel        int
+	trackInfoBookmarks []queue.Bookmark
+
+	// Chapters embedded in the playing file, as reported by mpv
+	chapters     []player.Chapter
+	showChapters bool
+	chapterSel   int
+
+	// About panel: bio or review of an artist or album
+	about        about.Source
+	showAbout    bool
+	aboutTarget  aboutTarget
+	aboutInfo    about.Info
+	aboutErr     error
+	aboutLoading bool
+	aboutScroll  int
+
+	// Similar Artists list of an artist
+	similarSource  similarSource
+	showSimilar    bool
+	similarFor     string
+	similar        []similarArtist
+	similarErr     error
+	similarLoading bool
+	similarSel     int
+
+	// Quick-open box jumping to an artist, album, playlist or command
+	showQuickOpen    bool
+	quickOpenInput   string
+	quickOpenResults provider.SearchResults
+	quickOpenItems   []quickOpenItem
+	quickOpenSel     int
+
+	// Release years the Library album and track lists are limited to
+	yearFilter provider.YearRange
+
+	// Classical mode lists composers, works and movements in the Library
+	classical bool
+
+	// Audiobook policy of the playing track: its speed in mpv and the
+	// resume position saved last
+	audiobook    bool
+	speed        float64
+	bookSavedPos float64
+
+	// Set while the Library tracks view lists an artist's top tracks
+	topTracksOf string
+	topPlays    map[string]int
+
+	// Set while the Library tracks view lists a playlist opened from
+	// Playlists
+	currentPlaylistID string
+
+	// Play count and loved mark of the playing track from the stats store
+	nowPlayingStats queue.TrackStats
+	playCounted     bool
+
+	// Destructive action waiting for confirmation
+	confirmPrompt *confirmPrompt
+
+	// OSC 52 sequence of a copy, sent with each frame until clipboardSentMsg
+	clipboard    string
+	clipboardSeq int
+
+	// Continue Listening state
+	playContext        queue.ListeningContext // album/playlist the current track is played from
+	playContextTracks  map[string]int         // track positions when playContext is a playlist
+	contextSavedPos    float64                // timePos when playContext was last recorded
+	continueItems      []queue.ListeningContext
+	pendingSeek        float64 // resume position, applied once pendingSeekTrackID loads
+	pendingSeekTrackID string
+
+	// Saved UI session being reopened at startup
+	restore *queue.Session
+
+	// Auto-pause: suspends reported by the system, and the audio outputs
+	// mpv saw last
+	sleep        <-chan struct{}
+	audioDevices []string
+
+	// Auto-skip: the [queue.skip] rules, and when tracks were last played
+	// for the played_today rule
+	skip      skipRules
+	lastPlays queue.LastPlays
+
+	// Radio station topping up the queue, nil when off, and whether a batch
+	// is on its way
+	radio        *radioStation
+	radioLoading bool
+
+	// Play counts, loved marks and last plays weighting the shuffle when
+	// [queue.shuffle] mode is weighted; nil otherwise
+	playHistory queue.PlayHistory
+
+	// Hidden artists, albums and tracks of the active profile, and
+	// whether the Config screen's list of them is open
+	hidden      hiddenSet
+	hiddenItems []queue.HiddenItem
+	hiddenOpen  bool
+
+	// The Config screen's list of caches, when open
+	caches    []cacheUsage
+	cacheOpen bool
+
+	// Views to go back and forward to
+	history navHistory
 }
 
 type searchFilter int
@@ -220,17 +400,25 @@ func (f searchFilter) String() string {
 	}
 }
 
-func New(cfg *config.Config, prov provider.Provider, factory ProviderFactory, player *player.Controller, settings any, theme ui.Theme, opts StartupOptions, queueStore *queue.PersistenceStore, scrobbleMgr *scrobble.Manager, artCache *artwork.Cache, logger *slog.Logger) Model {
+func New(cfg *config.Config, prov provider.Provider, factory ProviderFactory, player *player.Controller, settings any, theme ui.Theme, opts StartupOptions, queueStore *queue.PersistenceStore, scrobbleMgr *scrobble.Manager, artCache *artwork.Cache, plugins *plugin.Manager, hookRunner *hooks.Runner, remote *control.Server, logger *slog.Logger) Model {
 	if logger == nil {
 		logger = slog.Default()
 	}
 
-	// Initialize visualizer if available
+	// Initialize visualizer unless it is turned off
 	var viz *visualizer.Visualizer
-	if visualizer.Available() {
+	if backend := cfg.Visualizer.Backend; backend != visualizer.BackendOff {
+		if backend != visualizer.BackendBuiltin && !visualizer.Available() {
+			logger.Debug("cava not installed, using the builtin visualizer")
+			backend = visualizer.BackendBuiltin
+		}
 		viz = visualizer.New(visualizer.Config{
+			Backend:  backend,
+			MPVPath:  cfg.Player.MPVPath,
 			BarCount: 24, // Wider visualizer
 			MaxValue: 1000,
+			Input:    cfg.Visualizer.Input,
+			Source:   cfg.Visualizer.Source,
 		})
 	}
 
@@ -243,25 +431,57 @@ func New(cfg *config.Config, prov provider.Provider, factory ProviderFactory, pl
 		queueStore:      queueStore,
 		scrobbler:       scrobbleMgr,
 		artworkCache:    artCache,
+		artworkLoader:   newArtworkLoader(),
+		plugins:         plugins,
+		hooks:           hookRunner,
+		control:         remote,
 		theme:           theme,
+		msgs:            newCatalog(cfg.UI),
 		logger:          logger,
 		screen:          screenLoading,
-		status:          "Loading…",
 		profileSettings: settings,
 		noEmoji:         cfg.UI.NoEmoji,
 		volume:          float64(cfg.Player.InitialVolume),
+		speed:           1,
 		healthOK:        true,
 		healthDetails:   "OK",
 		startupOpts:     opts,
 		visualizer:      viz,
+		vizStyle:        cfg.Visualizer.Style,
+		lastInput:       time.Now(),
+		lowBandwidth:    cfg.Player.LowBandwidth,
+	}
+	m.status = m.msgs.T("status.starting")
+	m.queue.SetLimit(cfg.Queue.MaxSize)
+	m.skip = newSkipRules(cfg.Queue.Skip)
+	if m.weightedShuffle() || m.columnsShowHistory() {
+		m.playHistory = queue.PlayHistory{}
+	}
+	if m.weightedShuffle() {
+		m.setShuffleWeight()
+	}
+	if cfg.Player.AutoPause.Suspend {
+		m.sleep = platform.WatchSleep(context.Background())
 	}
 
+	exporter, err := nowplaying.New(cfg.Export)
+	if err != nil {
+		logger.Warn("now playing export disabled", slog.Any("err", err))
+	}
+	m.exporter = exporter
+	m.about = newAboutSource(cfg, logger)
+	m.similarSource = newSimilarSource(cfg)
+
 	// Initialize command palette (Phase 3)
 	m.commandRegistry = NewCommandRegistry(&m)
 	m.paletteState = NewPaletteState(m.commandRegistry)
 
 	// Initialize diagnostics (Phase 3)
 	m.diagnosticsState = NewDiagnosticsState()
+	m.provider = m.observed(prov)
+	if scrobbleMgr != nil {
+		scrobbleMgr.OnResult(m.diagnosticsState.RecordScrobble)
+	}
 
 	return m
 }
@@ -273,6 +493,7 @@ type initMsg struct {
 type healthMsg struct {
 	ok      bool
 	details string
+	latency time.Duration
 }
 
 // queueRestoredMsg signals that the queue was restored from persistence.
@@ -282,10 +503,10 @@ type queueRestoredMsg struct {
 }
 
 func (m Model) Init() tea.Cmd {
-	cmds := []tea.Cmd{m.initProviderCmd(), m.watchPlayerCmd(), m.healthCheckCmd()}
+	cmds := []tea.Cmd{m.initProviderCmd(), m.watchPlayerCmd(), m.healthCheckCmd(), m.waitControlCmd(), m.zenIdleCmd(), m.dropFolderCmd(), m.waitSleepCmd(), m.loadLastPlaysCmd(), m.loadHiddenCmd(), m.loadPlayHistoryCmd()}
 	// Restore queue if persistence is enabled
 	if m.cfg.Queue.Persist && m.queueStore != nil {
-		cmds = append(cmds, m.restoreQueueCmd())
+		cmds = append(cmds, m.restoreQueueCmd(), m.loadContinueListeningCmd(), m.loadSessionCmd())
 	}
 	return tea.Batch(cmds...)
 }
@@ -296,11 +517,15 @@ func (m Model) restoreQueueCmd() tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
 		result, err := m.queueStore.Load(ctx)
+		if err == nil && result.ProfileID != "" && result.ProfileID != m.cfg.ActiveProfile {
+			// Last run used another profile; use this profile's own queue
+			result, err = m.queueStore.LoadProfile(ctx, m.cfg.ActiveProfile)
+		}
 		return queueRestoredMsg{result: result, err: err}
 	}
 }
 
-// saveQueueCmd saves the queue to persistence storage.
+// saveQueueCmd saves the changes to the queue to persistence storage.
 func (m Model) saveQueueCmd() tea.Cmd {
 	return func() tea.Msg {
 		if m.queueStore == nil || !m.cfg.Queue.Persist {
@@ -308,20 +533,49 @@ func (m Model) saveQueueCmd() tea.Cmd {
 		}
 		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 		defer cancel()
-		_ = m.queueStore.Save(ctx, m.queue, m.provider.ID(), m.cfg.ActiveProfile)
+		if err := m.queueStore.Sync(ctx, m.queue, m.provider.ID(), m.cfg.ActiveProfile); err != nil {
+			m.logger.Debug("queue save failed", slog.Any("err", err))
+		}
 		return nil
 	}
 }
 
-func (m Model) healthCheckCmd() tea.Cmd {
-	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
-		ok, details := m.provider.Health(ctx)
-		return healthMsg{ok: ok, details: details}
+// queueFull reports the tracks left out of the queue when it reached
+// [queue] max_size, returning false when all wanted were added.
+func (m *Model) queueFull(added, wanted int) bool {
+	if added >= wanted {
+		return false
+	}
+	m.logger.Debug("queue full", slog.Int("limit", m.queue.Limit()), slog.Int("added", added), slog.Int("wanted", wanted))
+	m.status = m.msgs.T("status.queue_full", m.queue.Limit(), added, wanted)
+	return true
+}
+
+// clearQueue empties the queue once the user confirms it.
+func (m Model) clearQueue() (Model, tea.Cmd) {
+	if m.queue.Len() == 0 {
+		m.status = m.msgs.T("status.queue_empty")
+		return m, nil
+	}
+	return m.confirm(m.msgs.T("confirm.clear_queue"), m.msgs.T("confirm.clear_queue.detail", m.queue.Len()), func(m Model) (Model, tea.Cmd) {
+		m.queue.Clear()
+		m.radio, m.radioLoading = nil, false
+		m.selection = 0
+		m.logger.Debug("queue cleared")
+		m.status = m.msgs.T("status.queue_cleared")
+		return m, m.saveQueueCmd()
 	})
 }
 
+// shuffleRemaining shuffles the queue after the current track, leaving what
+// has already played in place.
+func (m Model) shuffleRemaining() (Model, tea.Cmd) {
+	m.queue.ShuffleRemaining()
+	m.logger.Debug("queue remaining shuffled", slog.Int("current_idx", m.queue.CurrentIndex()), slog.Int("queue_len", m.queue.Len()))
+	m.status = m.msgs.T("status.shuffled", max(m.queue.Len()-m.queue.CurrentIndex()-1, 0))
+	return m, m.saveQueueCmd()
+}
+
 func (m Model) initProviderCmd() tea.Cmd {
 	return func() tea.Msg {
 		m.logger.Debug("initializing provider")
@@ -334,7 +588,7 @@ func (m Model) initProviderCmd() tea.Cmd {
 		}
 		m.logger.Debug("provider initialized", slog.Duration("elapsed", time.Since(start)))
 		// Load initial data
-		page, err := m.provider.ListArtists(ctx, provider.ListReq{PageSize: m.cfg.UI.PageSize})
+		page, err := m.provider.ListArtists(ctx, provider.ListReq{PageSize: m.cfg.UI.PageSize, Sort: m.cfg.UI.Sort.Artists})
 		m.logger.Debug("artists loaded", slog.Int("count", len(page.Items)), slog.Any("err", err))
 		return artistsMsg{page: page, err: err}
 	}
@@ -344,7 +598,12 @@ func (m Model) loadArtistsCmd(cursor string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		page, err := m.provider.ListArtists(ctx, provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+		req := provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor, Sort: m.cfg.UI.Sort.Artists}
+		if cb := m.composerBrowser(); cb != nil {
+			page, err := cb.ListComposers(ctx, req)
+			return artistsMsg{page: page, err: err}
+		}
+		page, err := m.provider.ListArtists(ctx, req)
 		return artistsMsg{page: page, err: err}
 	}
 }
@@ -353,16 +612,26 @@ func (m Model) loadAlbumsCmd(artistID, cursor string) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		page, err := m.provider.ListAlbums(ctx, artistID, provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+		req := provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor, Sort: m.cfg.UI.Sort.Albums, Years: m.yearFilter}
+		if cb := m.composerBrowser(); cb != nil {
+			page, err := cb.ListWorks(ctx, artistID, req)
+			return albumsMsg{page: page, err: err}
+		}
+		page, err := m.provider.ListAlbums(ctx, artistID, req)
 		return albumsMsg{page: page, err: err}
 	}
 }
 
 func (m Model) loadTracksCmd(artistID, albumID, cursor string) tea.Cmd {
+	req := provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor, Sort: m.cfg.UI.Sort.Tracks}
+	if albumID == "" {
+		// An album's tracks always show in full
+		req.Years = m.yearFilter
+	}
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		page, err := m.provider.ListTracks(ctx, albumID, artistID, "", provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+		page, err := m.provider.ListTracks(ctx, albumID, artistID, "", req)
 		return tracksMsg{page: page, err: err}
 	}
 }
@@ -381,16 +650,38 @@ func (m Model) loadPlaylistTracksCmd(playlistID, cursor string) tea.Cmd {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		page, err := m.provider.ListTracks(ctx, "", "", playlistID, provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
-		return tracksMsg{page: page, err: err}
+		return tracksMsg{page: page, playlistID: playlistID, err: err}
 	}
 }
 
-func (m Model) searchCmd(q string) tea.Cmd {
+// moreTracksCmd loads the track list on screen from cursor: the playlist
+// it was opened from, or else the current album or artist.
+func (m Model) moreTracksCmd(cursor string) tea.Cmd {
+	if m.currentPlaylistID != "" {
+		return m.loadPlaylistTracksCmd(m.currentPlaylistID, cursor)
+	}
+	return m.loadTracksCmd(m.currentArtistID, m.currentAlbumID, cursor)
+}
+
+// volumeCmd sends the current volume to mpv.
+func (m Model) volumeCmd() tea.Cmd {
+	volume := m.volume
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-		defer cancel()
-		res, err := m.provider.Search(ctx, q, provider.ListReq{PageSize: m.cfg.UI.PageSize})
-		return searchMsg{res: res, err: err}
+		if err := m.player.SetVolume(volume); err != nil {
+			return playerMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// setNormalizationCmd applies [player] normalize and target_lufs to mpv.
+func (m Model) setNormalizationCmd() tea.Cmd {
+	mode, targetLUFS := m.cfg.Player.Normalize, m.cfg.Player.TargetLUFS
+	return func() tea.Msg {
+		if err := m.player.SetNormalization(mode, targetLUFS); err != nil {
+			return playerMsg{Err: err}
+		}
+		return nil
 	}
 }
 
@@ -404,6 +695,41 @@ func (m Model) watchPlayerCmd() tea.Cmd {
 	}
 }
 
+// playerRestartedMsg reports the outcome of restarting mpv after the IPC
+// connection was lost.
+type playerRestartedMsg struct {
+	err error
+}
+
+// restartPlayerCmd starts a new mpv and reloads the current track, if any,
+// in the state it was in.
+func (m Model) restartPlayerCmd() tea.Cmd {
+	track, paused, volume := m.nowPlaying, m.paused, m.volume
+	return func() tea.Msg {
+		_ = m.player.Stop()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := m.player.Start(ctx); err != nil {
+			return playerRestartedMsg{err: err}
+		}
+		_ = m.player.SetVolume(volume)
+		if track.ID == "" {
+			return playerRestartedMsg{}
+		}
+		stream, err := m.streamFor(ctx, track)
+		if err != nil {
+			return playerRestartedMsg{err: err}
+		}
+		if err := m.playStream(stream); err != nil {
+			return playerRestartedMsg{err: err}
+		}
+		if paused {
+			_ = m.player.TogglePause(true)
+		}
+		return playerRestartedMsg{}
+	}
+}
+
 type artistsMsg struct {
 	page provider.Page[provider.Artist]
 	err  error
@@ -415,8 +741,9 @@ type albumsMsg struct {
 }
 
 type tracksMsg struct {
-	page provider.Page[provider.Track]
-	err  error
+	page       provider.Page[provider.Track]
+	playlistID string // set when the tracks are a playlist's
+	err        error
 }
 
 type playlistsMsg struct {
@@ -424,16 +751,12 @@ type playlistsMsg struct {
 	err  error
 }
 
-type searchMsg struct {
-	res provider.SearchResults
-	err error
-}
-
 type playerMsg player.Event
 
 type playTrackMsg struct {
-	track provider.Track
-	err   error
+	track  provider.Track
+	stream provider.StreamInfo
+	err    error
 }
 
 // lyricsMsg is the result of fetching lyrics
@@ -456,98 +779,205 @@ func (m Model) fetchLyricsCmd(trackID string) tea.Cmd {
 // artworkMsg is the result of fetching artwork
 type artworkMsg struct {
 	trackID string
+	ref     string // artworkCacheRef of the track
 	ansi    string
+	zen     bool // drawn at the zen mode size
 	err     error
+
+	// preview marks a blurred stand-in, shown while img is converted
+	// at width x height
+	preview       bool
+	img           image.Image
+	width, height int
 }
 
-// fetchArtworkCmd fetches and converts artwork for a track
-func (m Model) fetchArtworkCmd(trackID, artworkRef string) tea.Cmd {
-	return func() tea.Msg {
-		if artworkRef == "" {
-			return artworkMsg{trackID: trackID, err: artwork.ErrNotFound}
-		}
+// fetchArtworkCmd fetches and converts artwork for a track. When the
+// terminal cannot draw the image, the track gets a tile of the album and
+// artist initials on the cover's average color instead of the logo.
+func (m Model) fetchArtworkCmd(track provider.Track) tea.Cmd {
+	width, height := m.artworkSize()
+	return m.renderArtworkCmd(track, width, height, false)
+}
 
-		width := m.cfg.Artwork.Width
-		if width <= 0 {
-			width = 40
-		}
-		height := m.cfg.Artwork.Height
-		if height <= 0 {
-			height = 20
-		}
+// artworkSize is the Now Playing artwork size in cells, from [artwork]
+// shrunk to fit the window and kept square.
+func (m Model) artworkSize() (width, height int) {
+	width = m.cfg.Artwork.Width
+	if width <= 0 {
+		width = 40
+	}
+	height = m.cfg.Artwork.Height
+	if height <= 0 {
+		height = 20
+	}
 
-		// Ensure artwork doesn't exceed available width
-		availableWidth := m.width - 20
-		if availableWidth > 0 && width > availableWidth {
-			width = availableWidth
-		}
+	// Ensure artwork doesn't exceed available width
+	availableWidth := m.width - 20
+	if availableWidth > 0 && width > availableWidth {
+		width = availableWidth
+	}
 
-		// Ensure artwork doesn't exceed available height
-		// Reserve space for: top bar, progress bar, visualizer, up next, hints, player bar
-		availableHeight := m.height - 22
-		if availableHeight < 8 {
-			availableHeight = 8
-		}
-		if height > availableHeight {
-			height = availableHeight
-		}
+	// Ensure artwork doesn't exceed available height
+	// Reserve space for: top bar, progress bar, visualizer, up next, hints, player bar
+	availableHeight := m.height - 22
+	if availableHeight < 8 {
+		availableHeight = 8
+	}
+	if height > availableHeight {
+		height = availableHeight
+	}
 
-		// Maintain square aspect ratio
-		expectedWidth := height * 2
-		expectedHeight := width / 2
-		if width > expectedWidth {
-			width = expectedWidth
-		} else if height > expectedHeight {
-			height = expectedHeight
-		}
+	// Maintain square aspect ratio
+	expectedWidth := height * 2
+	expectedHeight := width / 2
+	if width > expectedWidth {
+		width = expectedWidth
+	} else if height > expectedHeight {
+		height = expectedHeight
+	}
+	return width, height
+}
 
-		// Parse quality and scale mode
-		quality := artwork.QualityMedium
-		if m.cfg.Artwork.Quality != "" {
-			quality = artwork.QualityLevel(m.cfg.Artwork.Quality)
-		}
-		scaleMode := artwork.ScaleFit
-		if m.cfg.Artwork.ScaleMode != "" {
-			scaleMode = artwork.ScaleMode(m.cfg.Artwork.ScaleMode)
-		}
+// artworkCacheRef is what a track's converted artwork is cached under: its
+// album, so the album's tracks share one conversion even where each has a
+// ref of its own, such as a local file's path. Albums are kept apart by
+// profile, since two servers can hand out the same album IDs.
+func (m Model) artworkCacheRef(track provider.Track) string {
+	if track.AlbumID == "" {
+		return track.ArtworkRef
+	}
+	return "album:" + m.cfg.ActiveProfile + "/" + track.AlbumID
+}
 
-		// Check cache first
-		if m.artworkCache != nil {
-			if cached, ok := m.artworkCache.Get(artworkRef, width, height, quality, scaleMode); ok {
-				return artworkMsg{trackID: trackID, ansi: cached}
-			}
+// renderArtworkCmd fetches track's artwork and draws it in width x height
+// cells; zen marks artwork drawn for zen mode. A cover not in the cache
+// comes back first as a blurred preview, to be converted by
+// convertArtworkCmd. It goes through the artwork loader, which cancels the
+// work for any other cover.
+func (m Model) renderArtworkCmd(track provider.Track, width, height int, zen bool) tea.Cmd {
+	trackID, cacheRef := track.ID, m.artworkCacheRef(track)
+	if track.ArtworkRef == "" {
+		return func() tea.Msg {
+			return artworkMsg{trackID: trackID, ref: cacheRef, zen: zen, err: artwork.ErrNotFound}
 		}
+	}
+	key := artworkJobKey{ref: cacheRef, width: width, height: height, preview: true}
+	wait := m.artworkLoader.load(key, func(ctx context.Context) artworkMsg {
+		return m.renderArtwork(ctx, track, cacheRef, width, height)
+	})
+	return func() tea.Msg {
+		msg := wait()
+		msg.trackID, msg.zen = trackID, zen
+		return msg
+	}
+}
 
-		// Fetch artwork from provider
-		// Request larger image for higher quality conversion
-		requestSize := width * 10
-		if quality == artwork.QualityHigh {
-			requestSize = width * 20
-		} else if quality == artwork.QualityLow {
-			requestSize = width * 5
-		}
+// convertArtworkCmd converts the decoded cover a preview was drawn from,
+// to take the preview's place.
+func (m Model) convertArtworkCmd(track provider.Track, img image.Image, width, height int, zen bool) tea.Cmd {
+	trackID, cacheRef := track.ID, m.artworkCacheRef(track)
+	wait := m.artworkLoader.load(artworkJobKey{ref: cacheRef, width: width, height: height}, func(ctx context.Context) artworkMsg {
+		return m.convertArtwork(ctx, track, cacheRef, img, width, height)
+	})
+	return func() tea.Msg {
+		msg := wait()
+		msg.trackID, msg.zen = trackID, zen
+		return msg
+	}
+}
 
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
+// artworkStyle is the quality and scale mode from [artwork].
+func (m Model) artworkStyle() (artwork.QualityLevel, artwork.ScaleMode) {
+	quality := artwork.QualityMedium
+	if m.cfg.Artwork.Quality != "" {
+		quality = artwork.QualityLevel(m.cfg.Artwork.Quality)
+	}
+	scaleMode := artwork.ScaleFit
+	if m.cfg.Artwork.ScaleMode != "" {
+		scaleMode = artwork.ScaleMode(m.cfg.Artwork.ScaleMode)
+	}
+	return quality, scaleMode
+}
 
-		art, err := m.provider.GetArtwork(ctx, artworkRef, requestSize)
-		if err != nil {
-			return artworkMsg{trackID: trackID, err: err}
+// renderArtwork takes track's artwork from the cache under cacheRef, or
+// fetches it and draws a blurred preview of it. The cover is decoded once
+// here, and the preview carries the image on to convertArtwork.
+func (m Model) renderArtwork(ctx context.Context, track provider.Track, cacheRef string, width, height int) artworkMsg {
+	quality, scaleMode := m.artworkStyle()
+
+	// Tiles are cheap to draw and must not come from a cache filled on
+	// a terminal that could show images
+	textOnly := artwork.DetectProtocol() == artwork.ProtocolText
+
+	// Check cache first
+	if m.artworkCache != nil && !textOnly {
+		if cached, ok := m.artworkCache.Get(cacheRef, width, height, quality, scaleMode); ok {
+			m.diagnosticsState.RecordArtworkCacheHit()
+			return artworkMsg{ref: cacheRef, ansi: cached}
 		}
+		m.diagnosticsState.RecordArtworkCacheMiss()
+	}
+
+	// Fetch artwork from provider
+	// Request larger image for higher quality conversion
+	requestSize := width * 10
+	if quality == artwork.QualityHigh {
+		requestSize = width * 20
+	} else if quality == artwork.QualityLow {
+		requestSize = width * 5
+	}
+
+	art, err := m.provider.GetArtwork(ctx, track.ArtworkRef, requestSize)
+	if err != nil {
+		return artworkMsg{err: err}
+	}
 
-		// Convert using best available protocol (auto-detects kitty/sixel/ansi)
-		rendered, err := artwork.Render(ctx, art.Data, width, height, quality, scaleMode)
+	img, err := artwork.Decode(art.Data)
+	if err != nil {
+		return artworkMsg{err: err}
+	}
+
+	if textOnly {
+		bg, err := artwork.AverageColor(img)
 		if err != nil {
-			return artworkMsg{trackID: trackID, err: err}
+			return artworkMsg{err: err}
 		}
+		return artworkMsg{ansi: artwork.Tile(width, height, bg, track.AlbumTitle, track.ArtistName, false)}
+	}
+
+	blurred, err := artwork.Blurred(ctx, img, width, height, scaleMode)
+	if err != nil {
+		// Nothing to preview; the conversion falls back to a tile
+		return m.convertArtwork(ctx, track, cacheRef, img, width, height)
+	}
+	return artworkMsg{ref: cacheRef, ansi: blurred, preview: true, img: img, width: width, height: height}
+}
 
-		// Cache result
-		if m.artworkCache != nil {
-			_ = m.artworkCache.Set(artworkRef, width, height, quality, scaleMode, rendered)
+// convertArtwork converts a fetched, decoded cover and caches it under
+// cacheRef.
+func (m Model) convertArtwork(ctx context.Context, track provider.Track, cacheRef string, img image.Image, width, height int) artworkMsg {
+	quality, scaleMode := m.artworkStyle()
+
+	// Convert using best available protocol (auto-detects kitty/sixel/ansi)
+	rendered, err := artwork.RenderImage(ctx, img, width, height, quality, scaleMode)
+	if err != nil {
+		if ctx.Err() != nil {
+			return artworkMsg{err: ctx.Err()}
+		}
+		bg, avgErr := artwork.AverageColor(img)
+		if avgErr != nil {
+			return artworkMsg{err: err}
 		}
+		m.logger.Debug("artwork render failed, drawing a tile", slog.Any("err", err))
+		return artworkMsg{ansi: artwork.Tile(width, height, bg, track.AlbumTitle, track.ArtistName, true)}
+	}
 
-		return artworkMsg{trackID: trackID, ansi: rendered}
+	// Cache result
+	if m.artworkCache != nil {
+		_ = m.artworkCache.Set(cacheRef, width, height, quality, scaleMode, rendered)
 	}
+
+	return artworkMsg{ref: cacheRef, ansi: rendered}
 }
 
 // vizTickMsg triggers a visualizer refresh
@@ -576,64 +1006,76 @@ func (m Model) searchMoreCmd(q, cursor string) tea.Cmd {
 
 // startupSearchMsg is the result of a CLI-initiated search
 type startupSearchMsg struct {
+	opts   StartupOptions
+	call   *control.Call // set when the flags came from "tunez --enqueue"
 	tracks []provider.Track
 	err    error
 }
 
 // startupSearchCmd performs a search based on CLI flags and returns matching tracks
-func (m Model) startupSearchCmd() tea.Cmd {
+func (m Model) startupSearchCmd(opts StartupOptions, call *control.Call) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 		defer cancel()
-
-		// Build search query from artist and/or album
-		query := ""
-		if m.startupOpts.SearchArtist != "" {
-			query = m.startupOpts.SearchArtist
+		msg := startupSearchMsg{opts: opts, call: call}
+
+		// A playlist is queued whole, before any searched tracks
+		if opts.Playlist != "" {
+			p, err := m.findPlaylist(ctx, opts.Playlist)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			msg.tracks, msg.err = m.contextTracks(ctx, queue.ListeningContext{Kind: queue.ContextPlaylist, ID: p.ID, Title: p.Name})
+			if msg.err != nil || (opts.SearchArtist == "" && opts.SearchAlbum == "" && opts.SearchTrack == "") {
+				return msg
+			}
 		}
-		if m.startupOpts.SearchAlbum != "" {
-			if query != "" {
-				query += " "
+
+		// Build search query from artist, album and/or track
+		var terms []string
+		for _, t := range []string{opts.SearchArtist, opts.SearchAlbum, opts.SearchTrack} {
+			if t != "" {
+				terms = append(terms, t)
 			}
-			query += m.startupOpts.SearchAlbum
 		}
+		query := strings.Join(terms, " ")
 
 		// Search for tracks
 		res, err := m.provider.Search(ctx, query, provider.ListReq{PageSize: 100})
 		if err != nil {
-			return startupSearchMsg{err: err}
+			msg.err = err
+			return msg
 		}
 
-		// Filter results to match artist/album if specified
+		// Filter results to match artist/album/track if specified
 		var matchedTracks []provider.Track
 		for _, t := range res.Tracks.Items {
-			artistMatch := m.startupOpts.SearchArtist == "" ||
-				strings.Contains(strings.ToLower(t.ArtistName), strings.ToLower(m.startupOpts.SearchArtist))
-			albumMatch := m.startupOpts.SearchAlbum == "" ||
-				strings.Contains(strings.ToLower(t.AlbumTitle), strings.ToLower(m.startupOpts.SearchAlbum))
-			if artistMatch && albumMatch {
+			if opts.matches(t) {
 				matchedTracks = append(matchedTracks, t)
 			}
 		}
 
 		// If no tracks found via search, try browsing artists/albums
-		if len(matchedTracks) == 0 && m.startupOpts.SearchArtist != "" {
+		if len(matchedTracks) == 0 && opts.SearchArtist != "" {
 			// Try to find artist and get their tracks
 			artists, err := m.provider.ListArtists(ctx, provider.ListReq{PageSize: 100})
 			if err == nil {
 				for _, artist := range artists.Items {
-					if strings.Contains(strings.ToLower(artist.Name), strings.ToLower(m.startupOpts.SearchArtist)) {
+					if strings.Contains(strings.ToLower(artist.Name), strings.ToLower(opts.SearchArtist)) {
 						// Found artist, get albums
 						albums, err := m.provider.ListAlbums(ctx, artist.ID, provider.ListReq{PageSize: 100})
 						if err == nil {
 							for _, album := range albums.Items {
-								albumMatch := m.startupOpts.SearchAlbum == "" ||
-									strings.Contains(strings.ToLower(album.Title), strings.ToLower(m.startupOpts.SearchAlbum))
-								if albumMatch {
+								if flagMatches(album.Title, opts.SearchAlbum) {
 									// Get tracks from this album
 									tracks, err := m.provider.ListTracks(ctx, album.ID, artist.ID, "", provider.ListReq{PageSize: 100})
 									if err == nil {
-										matchedTracks = append(matchedTracks, tracks.Items...)
+										for _, t := range tracks.Items {
+											if flagMatches(t.Title, opts.SearchTrack) {
+												matchedTracks = append(matchedTracks, t)
+											}
+										}
 									}
 								}
 							}
@@ -644,73 +1086,94 @@ func (m Model) startupSearchCmd() tea.Cmd {
 			}
 		}
 
-		return startupSearchMsg{tracks: matchedTracks}
+		msg.tracks = append(msg.tracks, matchedTracks...)
+		return msg
 	}
 }
 
-// randomPlayMsg is the result of a random tracks request
+// randomPlayMsg is the result of a random tracks, album or artist request
 type randomPlayMsg struct {
+	opts   StartupOptions
+	call   *control.Call
 	tracks []provider.Track
+	what   string // e.g. "tracks from Abbey Road"; "random tracks" when empty
 	err    error
 }
 
-// randomPlayCmd fetches random tracks and queues them for playback
-func (m Model) randomPlayCmd() tea.Cmd {
-	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
-		defer cancel()
-
-		pageSize := m.cfg.UI.PageSize
-		if pageSize <= 0 {
-			pageSize = 50
-		}
-
-		// Get all tracks with a large page size, then shuffle
-		allTracks, err := m.provider.ListTracks(ctx, "", "", "", provider.ListReq{PageSize: pageSize * 10})
-		if err != nil {
-			return randomPlayMsg{err: err}
-		}
-
-		tracks := allTracks.Items
-		if len(tracks) == 0 {
-			return randomPlayMsg{err: fmt.Errorf("no tracks found")}
-		}
-
-		// Shuffle using rand.Shuffle
-		rand.Shuffle(len(tracks), func(i, j int) {
-			tracks[i], tracks[j] = tracks[j], tracks[i]
-		})
-
-		// Take only pageSize tracks
-		if len(tracks) > pageSize {
-			tracks = tracks[:pageSize]
-		}
-
-		return randomPlayMsg{tracks: tracks}
-	}
-}
-
 type profileSwitchedMsg struct {
 	provider provider.Provider
 	profile  config.Profile
+	queue    queue.LoadResult // the new profile's saved queue
 }
 
 func (m Model) switchProfileCmd(profile config.Profile) tea.Cmd {
+	// The queue keeps changing in Update while this runs
+	outgoing := m.queue.Clone()
 	return func() tea.Msg {
 		newProv, err := m.factory(profile)
 		if err != nil {
 			return initMsg{err: err}
 		}
+		restored := queue.LoadResult{CurrentIndex: -1}
+		if m.queueStore != nil && m.cfg.Queue.Persist {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			// Keep the outgoing profile's queue for when we switch back
+			if err := m.queueStore.Save(ctx, outgoing, m.provider.ID(), m.cfg.ActiveProfile); err != nil {
+				m.logger.Warn("save queue before profile switch", slog.Any("err", err))
+			}
+			if restored, err = m.queueStore.LoadProfile(ctx, profile.ID); err != nil {
+				m.logger.Warn("load profile queue", slog.String("profile", profile.ID), slog.Any("err", err))
+			}
+			cancel()
+		}
 		_ = m.player.Stop()
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
 		if err := m.player.Start(ctx); err != nil {
 			return initMsg{err: err}
 		}
-		return profileSwitchedMsg{provider: newProv, profile: profile}
+		return profileSwitchedMsg{provider: m.observed(newProv), profile: profile, queue: restored}
+	}
+}
+
+// closeProviderCmd releases an outgoing provider's resources, such as its
+// response cache.
+func (m Model) closeProviderCmd(p provider.Provider) tea.Cmd {
+	if p == nil {
+		return nil
+	}
+	logger := m.logger
+	return func() tea.Msg {
+		if err := provider.Close(p); err != nil {
+			logger.Warn("close provider", slog.Any("err", err))
+		}
+		return nil
 	}
 }
 
+// applyRestoredQueue loads a persisted queue into the (empty) queue.
+func (m *Model) applyRestoredQueue(result queue.LoadResult) {
+	if len(result.Tracks) == 0 {
+		return
+	}
+	added := m.queue.Add(result.Tracks...)
+	if result.CurrentIndex >= 0 && result.CurrentIndex < added {
+		_ = m.queue.SetCurrent(result.CurrentIndex)
+	}
+	// Restore shuffle/repeat state
+	// The saved tracks are already in shuffled order
+	m.queue.SetShuffled(result.Shuffled)
+	for m.queue.RepeatMode() != result.Repeat {
+		m.queue.CycleRepeat()
+	}
+	m.status = m.msgs.T("status.restored", added)
+	m.queueFull(added, len(result.Tracks))
+	m.logger.Debug("queue restored",
+		slog.String("profile", result.ProfileID),
+		slog.Int("tracks", len(result.Tracks)),
+		slog.Int("current_idx", result.CurrentIndex))
+}
+
 // matchKey returns true if the key matches the binding.
 // Handles both single keys and aliases (e.g., "space" matches " ").
 func matchKey(key, binding string) bool {
@@ -737,7 +1200,7 @@ func (m Model) clearErrorCmd() tea.Cmd {
 }
 
 func (m Model) setError(err error) (Model, tea.Cmd) {
-	m.errorMsg = err.Error()
+	m.errorMsg = actionableError(err)
 	return m, m.clearErrorCmd()
 }
 
@@ -787,6 +1250,21 @@ func (m Model) seekCmd(delta float64) tea.Cmd {
 	}
 }
 
+// selectionMark replaces a row's selection glyph when ui.selection_markers
+// is on.
+const selectionMark = ">>"
+
+// selectedRow returns the prefix and style of a selected row: prefix in
+// the highlight color, or with ui.selection_markers on, ">>" padded to the
+// same width in bold so the selection doesn't rely on color.
+func (m Model) selectedRow(prefix string) (string, lipgloss.Style) {
+	if !m.cfg.UI.SelectionMarkers {
+		return prefix, selectedStyle
+	}
+	pad := lipgloss.Width(prefix) - len(selectionMark)
+	return selectionMark + strings.Repeat(" ", max(pad, 1)), m.theme.Text.Bold(true)
+}
+
 func (m Model) selectedTrack() (provider.Track, bool) {
 	if m.screen == screenLibrary && len(m.tracks) > 0 {
 		idx := clamp(m.selection, 0, len(m.tracks)-1)
@@ -802,9 +1280,40 @@ func (m Model) selectedTrack() (provider.Track, bool) {
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case healthMsg:
-		m.healthOK = msg.ok
-		m.healthDetails = msg.details
-		return m, m.healthCheckCmd() // Schedule next check
+		return m.handleHealth(msg)
+	case prefetchMsg:
+		return m.handlePrefetch(msg)
+	case pageMsg:
+		return m.handlePage(msg)
+	case sessionMsg:
+		return m.handleSession(msg)
+	case hiddenMsg:
+		return m.handleHidden(msg)
+	case trackDeletedMsg:
+		return m.handleTrackDeleted(msg)
+	case trackLocationMsg:
+		return m.handleTrackLocation(msg)
+	case artworkSavedMsg:
+		return m.handleArtworkSaved(msg)
+	case cacheUsageMsg:
+		return m.handleCacheUsage(msg)
+	case cacheClearedMsg:
+		return m.handleCacheCleared(msg)
+	case clipboardSentMsg:
+		if msg.seq == m.clipboardSeq {
+			m.clipboard = ""
+		}
+		return m, nil
+	case lastPlaysMsg:
+		return m.handleLastPlays(msg)
+	case playHistoryMsg:
+		return m.handlePlayHistory(msg)
+	case radioMsg:
+		return m.handleRadio(msg)
+	case goToMsg:
+		return m.handleGoTo(msg)
+	case sleepMsg:
+		return m.handleSleep()
 	case queueRestoredMsg:
 		if msg.err != nil {
 			m.logger.Debug("queue restore failed", slog.Any("err", msg.err))
@@ -817,23 +1326,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				slog.String("active_profile", m.cfg.ActiveProfile))
 			return m, nil
 		}
-		if len(msg.result.Tracks) > 0 {
-			m.queue.Add(msg.result.Tracks...)
-			if msg.result.CurrentIndex >= 0 && msg.result.CurrentIndex < len(msg.result.Tracks) {
-				_ = m.queue.SetCurrent(msg.result.CurrentIndex)
-			}
-			// Restore shuffle/repeat state
-			if msg.result.Shuffled && !m.queue.IsShuffled() {
-				m.queue.ToggleShuffle()
-			}
-			for m.queue.RepeatMode() != msg.result.Repeat {
-				m.queue.CycleRepeat()
-			}
-			m.status = fmt.Sprintf("Restored %d tracks", len(msg.result.Tracks))
-			m.logger.Debug("queue restored",
-				slog.Int("tracks", len(msg.result.Tracks)),
-				slog.Int("current_idx", msg.result.CurrentIndex))
-		}
+		m.applyRestoredQueue(msg.result)
 		return m, nil
 	case seekMsg:
 		if msg.err != nil {
@@ -841,48 +1334,151 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 	case addTrackMsg:
-		m.queue.Add(msg.track)
-		m.status = "Added to queue: " + msg.track.Title
+		if m.queueFull(m.queue.Add(msg.track), 1) {
+			return m, nil
+		}
+		m.status = m.msgs.T("status.added", msg.track.Title)
 		return m, m.saveQueueCmd()
 	case addNextTrackMsg:
-		m.queue.AddNext(msg.track)
-		m.status = "Playing next: " + msg.track.Title
+		if m.queueFull(m.queue.AddNext(msg.track), 1) {
+			return m, nil
+		}
+		m.status = m.msgs.T("status.next", msg.track.Title)
 		return m, m.saveQueueCmd()
+	case playFromHereMsg:
+		return m.handlePlayFromHere(msg)
+	case playNextMsg:
+		return m.handlePlayNext(msg)
+	case playlistExportMsg:
+		return m.handlePlaylistExport(msg)
+	case trackPositionMsg:
+		return m.handleTrackPosition(msg)
+	case playlistImportMsg:
+		return m.handlePlaylistImport(msg)
+	case trackStatsMsg:
+		return m.handleTrackStats(msg)
+	case aboutMsg:
+		return m.handleAbout(msg)
+	case topTracksMsg:
+		return m.handleTopTracks(msg)
+	case similarArtistsMsg:
+		return m.handleSimilarArtists(msg)
+	case quickOpenTickMsg:
+		return m.handleQuickOpenTick(msg)
+	case quickOpenResultsMsg:
+		return m.handleQuickOpenResults(msg)
+	case artistTracksMsg:
+		return m.handleArtistTracks(msg)
 	case addAndPlayTrackMsg:
 		// Add to queue and play - used for library/search selections
 		m.logger.Debug("add and play track", slog.String("track_id", msg.track.ID), slog.String("title", msg.track.Title), slog.Int("queue_len_before", m.queue.Len()))
-		m.queue.Add(msg.track)
+		if m.queueFull(m.queue.Add(msg.track), 1) {
+			return m, nil
+		}
 		m.logger.Debug("track added to queue", slog.Int("queue_len_after", m.queue.Len()), slog.Int("current_idx", m.queue.CurrentIndex()))
 		return m, tea.Batch(m.playTrackCmd(msg.track), m.saveQueueCmd())
+	case playerRestartedMsg:
+		if msg.err != nil {
+			m.logger.Error("restart mpv", slog.Any("err", msg.err))
+			return m.setError(fmt.Errorf("restart mpv: %w", msg.err))
+		}
+		m.diagnosticsState.RecordMPVReconnect()
+		m.status = m.msgs.T("status.mpv_restarted")
+		if m.nowPlaying.ID != "" {
+			// Pick up where the old process stopped once the duration is known
+			m.pendingSeek, m.pendingSeekTrackID = m.timePos, m.nowPlaying.ID
+			m.timePos = 0
+		}
+		return m, m.watchPlayerCmd()
 	case profileSwitchedMsg:
+		closeCmd := m.closeProviderCmd(m.provider)
 		m.provider = msg.provider
 		m.cfg.ActiveProfile = msg.profile.ID
 		m.profileSettings = msg.profile.Settings
+		// The new profile may override page size, theme, keybindings,
+		// loudness normalization and the starting volume
+		normalize, targetLUFS, volume := m.cfg.Player.Normalize, m.cfg.Player.TargetLUFS, m.cfg.Player.InitialVolume
+		m.cfg.ApplyProfile(msg.profile.ID)
+		var normalizeCmd tea.Cmd
+		if m.cfg.Player.Normalize != normalize || m.cfg.Player.TargetLUFS != targetLUFS {
+			normalizeCmd = m.setNormalizationCmd()
+		}
+		if m.cfg.Player.InitialVolume != volume && m.cfg.Player.InitialVolume > 0 {
+			m.volume = float64(m.cfg.Player.InitialVolume)
+			normalizeCmd = tea.Batch(normalizeCmd, m.volumeCmd())
+		}
+		m.theme = ui.GetTheme(m.cfg.UI.Theme, m.cfg.NoColor())
+		m.commandRegistry = NewCommandRegistry(&m)
+		m.paletteState = NewPaletteState(m.commandRegistry)
 		m.queue.Clear()
+		m.radio, m.radioLoading = nil, false
 		m.tracks = nil
 		m.albums = nil
 		m.artists = nil
 		m.playlists = nil
 		m.searchResults = provider.SearchResults{}
-		m.status = "Profile switched"
 		m.healthOK = true
 		m.healthDetails = "OK"
-		// Clear saved queue on profile switch
-		if m.queueStore != nil {
-			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-			_ = m.queueStore.Clear(ctx)
-			cancel()
-		}
-		return m, tea.Batch(m.initProviderCmd(), m.watchPlayerCmd(), m.healthCheckCmd())
+		m.healthCheckedAt = time.Time{}
+		m.healthFailedAt = time.Time{}
+		m.healthLatencies = nil
+		m.degraded = false
+		m.prefetch.startedFor, m.prefetch.trackID = "", ""
+		m.playContext = queue.ListeningContext{}
+		m.playContextTracks = nil
+		m.continueItems = nil
+		m.hidden, m.hiddenItems, m.hiddenOpen = nil, nil, false
+		m.caches, m.cacheOpen = nil, false
+		m.applyRestoredQueue(msg.queue)
+		m.status = m.msgs.T("status.switched", msg.profile.Name)
+		return m, tea.Batch(m.initProviderCmd(), m.watchPlayerCmd(), m.healthCheckCmd(), m.saveQueueCmd(), m.loadContinueListeningCmd(), m.loadHiddenCmd(), normalizeCmd, closeCmd)
 	case clearErrorMsg:
 		m.errorMsg = ""
 		return m, nil
+	case continueListeningMsg:
+		if msg.err != nil {
+			m.logger.Debug("continue listening update failed", slog.Any("err", msg.err))
+			return m, nil
+		}
+		m.continueItems = msg.items
+		return m, nil
+	case contextTracksMsg:
+		return m.handleContextTracks(msg)
+	case trackInfoMsg:
+		m.trackInfoLoading = false
+		if msg.err != nil {
+			// Keep showing the list metadata we already have
+			m.logger.Debug("track info fetch failed", slog.String("track_id", m.trackInfo.ID), slog.Any("err", msg.err))
+			return m, nil
+		}
+		if msg.track.ID == m.trackInfo.ID {
+			m.trackInfo = msg.track
+		}
+		return m, nil
+	case loveMsg:
+		return m.handleLoveMsg(msg)
+	case scrobblesSavedMsg:
+		if msg.err != nil {
+			return m.setError(fmt.Errorf("save pending scrobbles: %w", msg.err))
+		}
+		return m, nil
+	case lastfmTokenMsg:
+		if msg.err != nil {
+			return m.setError(fmt.Errorf("last.fm auth: %w", msg.err))
+		}
+		m.status = m.msgs.T("status.approve", msg.url)
+		return m, m.lastfmSessionCmd(msg)
+	case lastfmSessionMsg:
+		if msg.err != nil {
+			return m.setError(fmt.Errorf("last.fm auth: %w", msg.err))
+		}
+		return m.applyLastfmSession(msg)
 	case initMsg:
 		if msg.err != nil {
 			m.fatalErr = msg.err
-			m.status = "Init failed"
+			m.status = m.msgs.T("status.init_failed")
 		} else {
-			m.status = "Ready"
+			m.status = m.msgs.T("status.ready")
 		}
 	case tea.KeyMsg:
 		key := msg.String()
@@ -893,17 +1489,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			slog.Int("focused_pane", int(m.focusedPane)),
 			slog.Int("selection", m.selection))
 
+		m.lastInput = time.Now()
+		// The user has taken over from a session still being restored
+		m.restore = nil
+		if m.zen {
+			// Any key leaves zen mode and does nothing else
+			m.zen = false
+			return m, nil
+		}
+
+		// A confirmation prompt takes every key until it is answered
+		if m.confirmPrompt != nil {
+			return m.handleConfirmKey(key)
+		}
+
 		// Handle command palette input when visible
 		if m.showPalette {
 			switch key {
 			case "esc":
 				m.logger.Debug("command palette: escape pressed")
+				if m.paletteState.ArgCommand() != nil {
+					// Back to the command list
+					m.paletteState.Reset()
+					return m, nil
+				}
 				m.showPalette = false
 				m.paletteState.Reset()
 				return m, nil
 			case "enter":
 				m.logger.Debug("command palette: enter pressed")
+				if cmd := m.paletteState.ArgCommand(); cmd != nil {
+					arg := strings.TrimSpace(m.paletteState.Input())
+					m.logger.Debug("command palette: running argument command", slog.String("command_id", cmd.ID), slog.String("arg", arg))
+					m.showPalette = false
+					m.paletteState.Reset()
+					return cmd.RunArg(&m, arg)
+				}
 				if cmd := m.paletteState.SelectedCommand(); cmd != nil {
+					if cmd.RunArg != nil {
+						m.logger.Debug("command palette: prompting for argument", slog.String("command_id", cmd.ID))
+						m.paletteState.PromptArg(cmd)
+						return m, nil
+					}
 					m.logger.Debug("command palette: executing command", slog.String("command_id", cmd.ID), slog.String("command_name", cmd.Name))
 					m.showPalette = false
 					m.paletteState.Reset()
@@ -948,6 +1575,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Quick-open box takes typed text like the palette
+		if m.showQuickOpen {
+			return m.handleQuickOpenKey(msg)
+		}
+
+		// List filter prompt takes typed text before any other binding
+		if m.filter.typing && m.filterShown() {
+			if nm, cmd, ok := m.handleFilterKey(msg); ok {
+				return nm, cmd
+			}
+		}
+
+		// The key after ' picks the initial to jump to
+		if m.jumpPending {
+			m.jumpPending = false
+			return m.jumpToInitial(key)
+		}
+
 		// Open command palette with : or ctrl+p
 		if key == ":" || key == "ctrl+p" {
 			m.logger.Debug("opening command palette", slog.String("trigger_key", key))
@@ -956,6 +1601,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		if matchKey(key, m.cfg.Keybindings.QuickOpen) {
+			m.logger.Debug("opening quick open", slog.String("trigger_key", key))
+			return m.openQuickOpen()
+		}
+
 		// Toggle diagnostics overlay with ctrl+d
 		if key == "ctrl+d" {
 			m.logger.Debug("toggling diagnostics overlay", slog.Bool("show_diagnostics", !m.showDiagnostics))
@@ -963,6 +1613,57 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Track info popup: its key toggles, esc closes, other keys are ignored while open
+		if m.showTrackInfo {
+			if matchKey(key, m.cfg.Keybindings.TrackInfo) || key == "esc" {
+				m.logger.Debug("closing track info popup", slog.String("key", key))
+				m.showTrackInfo = false
+			}
+			return m, nil
+		}
+		// Profile quick switcher
+		if m.showProfileSwitcher {
+			return m.handleProfileSwitcherKey(key)
+		}
+		if matchKey(key, m.cfg.Keybindings.ProfileSwitch) {
+			return m.openProfileSwitcher()
+		}
+
+		// About panel of an artist or album
+		if m.showAbout {
+			return m.handleAboutKey(key)
+		}
+		if key == "I" && m.screen == screenLibrary {
+			return m.openAbout()
+		}
+
+		// Similar artists of an artist
+		if m.showSimilar {
+			return m.handleSimilarKey(key)
+		}
+		if key == "R" && m.screen == screenLibrary {
+			return m.openSimilar()
+		}
+		if key == "T" && m.screen == screenLibrary && m.libraryView() != "tracks" {
+			return m.openTopTracks()
+		}
+
+		// Provider Health panel closes on esc and ignores other keys
+		if m.showHealth {
+			if key == "esc" || key == "q" {
+				m.showHealth = false
+			}
+			return m, nil
+		}
+
+		// Bookmark list and chapter picker of the playing track
+		if m.showBookmarks {
+			return m.handleBookmarksKey(key)
+		}
+		if m.showChapters {
+			return m.handleChaptersKey(key)
+		}
+
 		// ESC closes help overlay or goes back
 		if key == "esc" {
 			m.logger.Debug("esc key pressed",
@@ -979,24 +1680,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.showHelp = false
 				return m, nil
 			}
+			if m.filterShown() {
+				m.logger.Debug("clearing list filter", slog.String("query", m.filter.query))
+				return m.clearFilter()
+			}
+			if m.screen == screenConfig && m.hiddenOpen {
+				m.hiddenOpen = false
+				m.selection = configHiddenSection
+				return m, nil
+			}
+			if m.screen == screenConfig && m.cacheOpen {
+				m.cacheOpen = false
+				m.selection = configCacheSection
+				return m, nil
+			}
 			// ESC can also go back in library navigation
 			if m.screen == screenLibrary {
-				if len(m.tracks) > 0 {
-					m.logger.Debug("library navigation: going back from tracks to albums")
-					m.tracks = nil
-					m.tracksCursor = ""
-					m.currentAlbumID = ""
-					m.selection = 0
-					return m, nil
-				}
-				if len(m.albums) > 0 {
-					m.logger.Debug("library navigation: going back from albums to artists")
-					m.albums = nil
-					m.albumsCursor = ""
-					m.currentArtistID = ""
-					m.selection = 0
-					return m, nil
-				}
+				return m.libraryBack()
 			}
 			m.logger.Debug("esc key: no action taken")
 			return m, nil
@@ -1005,7 +1705,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle configurable keybindings first (player controls)
 		if matchKey(key, m.cfg.Keybindings.Quit) {
 			m.logger.Debug("quit key pressed", slog.String("key", key))
-			return m, tea.Quit
+			return m.quit()
 		}
 		if matchKey(key, m.cfg.Keybindings.Help) {
 			m.logger.Debug("help toggle key pressed", slog.String("key", key), slog.Bool("show_help", !m.showHelp))
@@ -1037,7 +1737,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.paused = !m.paused
 			m.logger.Debug("play/pause toggled", slog.Bool("paused", m.paused), slog.String("now_playing", m.nowPlaying.Title))
 			return m, func() tea.Msg {
-				if err := m.player.TogglePause(m.paused); err != nil {
+				if err := m.player.FadePause(m.paused); err != nil {
 					return playerMsg{Err: err}
 				}
 				return nil
@@ -1045,9 +1745,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if matchKey(key, m.cfg.Keybindings.NextTrack) {
 			m.logger.Debug("next track pressed", slog.Int("queue_len", m.queue.Len()), slog.Int("current_idx", m.queue.CurrentIndex()))
-			if t, err := m.queue.Next(); err == nil {
+			var t provider.Track
+			var err error
+			if m, t, err = m.nextTrack(); err == nil {
 				m.logger.Debug("next track", slog.String("track_id", t.ID), slog.String("title", t.Title), slog.Int("new_idx", m.queue.CurrentIndex()))
-				return m, m.playTrackCmd(t)
+				return m, m.skipToCmd(t)
 			} else {
 				m.logger.Debug("next track failed", slog.Any("err", err))
 			}
@@ -1057,7 +1759,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.logger.Debug("prev track pressed", slog.Int("queue_len", m.queue.Len()), slog.Int("current_idx", m.queue.CurrentIndex()))
 			if t, err := m.queue.Prev(); err == nil {
 				m.logger.Debug("prev track", slog.String("track_id", t.ID), slog.String("title", t.Title), slog.Int("new_idx", m.queue.CurrentIndex()))
-				return m, m.playTrackCmd(t)
+				return m, m.skipToCmd(t)
 			} else {
 				m.logger.Debug("prev track failed", slog.Any("err", err))
 			}
@@ -1091,12 +1793,54 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return nil
 			}
 		}
+		if matchKey(key, m.cfg.Keybindings.Love) && m.screen != screenSearch {
+			m.logger.Debug("love key pressed", slog.String("key", key), slog.String("track_id", m.nowPlaying.ID))
+			return m.toggleLove()
+		}
+		if matchKey(key, m.cfg.Keybindings.Zen) && m.screen != screenSearch {
+			m.logger.Debug("zen key pressed", slog.String("key", key))
+			return m.enterZen()
+		}
+		if matchKey(key, m.cfg.Keybindings.Bookmark) && m.screen != screenSearch {
+			return m.addBookmark()
+		}
+		if matchKey(key, m.cfg.Keybindings.Bookmarks) && m.screen != screenSearch {
+			return m.openBookmarks()
+		}
+		if matchKey(key, m.cfg.Keybindings.NextChapter) && m.screen != screenSearch {
+			return m.nextChapter()
+		}
+		if matchKey(key, m.cfg.Keybindings.PrevChapter) && m.screen != screenSearch {
+			return m.prevChapter()
+		}
+		if matchKey(key, m.cfg.Keybindings.Chapters) && m.screen != screenSearch {
+			return m.openChapters()
+		}
+		if matchKey(key, m.cfg.Keybindings.TrackInfo) && m.screen != screenSearch {
+			m.logger.Debug("track info key pressed", slog.String("screen", screenNames[m.screen]), slog.Int("selection", m.selection))
+			return m.openTrackInfo()
+		}
+		if matchKey(key, m.cfg.Keybindings.GoToAlbum) {
+			return m.goTo(true)
+		}
+		if matchKey(key, m.cfg.Keybindings.GoToArtist) {
+			return m.goTo(false)
+		}
+		if matchKey(key, m.cfg.Keybindings.NavBack) {
+			return m.navBack()
+		}
+		if matchKey(key, m.cfg.Keybindings.NavForward) {
+			return m.navForward()
+		}
+		if matchKey(key, m.cfg.Keybindings.Search) && (m.screen == screenLibrary || m.screen == screenQueue) {
+			return m.startFilter()
+		}
 		if matchKey(key, m.cfg.Keybindings.Search) {
 			m.logger.Debug("search key pressed", slog.String("key", key), slog.String("old_screen", screenNames[m.screen]))
 			m.screen = screenSearch
 			m.searchQ = ""
 			m.searchResults = provider.SearchResults{}
-			m.status = "Enter search query"
+			m.status = m.msgs.T("status.enter_query")
 			return m, nil
 		}
 
@@ -1130,6 +1874,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if t, ok := m.selectedTrack(); ok {
 				m.logger.Debug("add track next to queue key pressed", slog.String("key", key), slog.String("track_title", t.Title), slog.String("track_id", t.ID))
 				return m, m.addNextTrackCmd(t)
+			} else if lc, ok := m.playNextTarget(); ok {
+				m.status = m.msgs.T("status.loading", lc.Title)
+				return m, m.playNextCmd(lc)
 			} else {
 				m.logger.Debug("add track next to queue key pressed but no track selected", slog.String("key", key))
 			}
@@ -1137,6 +1884,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if t, ok := m.selectedTrack(); ok {
 				m.logger.Debug("play track next key pressed", slog.String("key", key), slog.String("track_title", t.Title), slog.String("track_id", t.ID))
 				return m, m.addNextTrackCmd(t)
+			} else if lc, ok := m.playNextTarget(); ok {
+				m.status = m.msgs.T("status.loading", lc.Title)
+				return m, m.playNextCmd(lc)
 			} else {
 				m.logger.Debug("play track next key pressed but no track selected", slog.String("key", key))
 			}
@@ -1157,34 +1907,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						m.lyricsScrollOffset++
 					}
 				}
+			} else if m.filterActive() {
+				// Only visit rows that pass the filter
+				m.selection = m.nextFilteredRow(m.selection, 1)
+				return m.prefetchPage()
 			} else {
-				// Navigate within list content
+				// Navigate within list content, fetching the next page
+				// before the end is reached
 				if m.selection < m.currentListLen()-1 {
 					m.selection++
-				} else if m.screen == screenSearch {
-					var nextCursor string
-					switch m.searchFilter {
-					case filterTracks:
-						nextCursor = m.searchResults.Tracks.NextCursor
-					case filterAlbums:
-						nextCursor = m.searchResults.Albums.NextCursor
-					case filterArtists:
-						nextCursor = m.searchResults.Artists.NextCursor
-					}
-					if nextCursor != "" {
-						return m, m.searchMoreCmd(m.searchQ, nextCursor)
-					}
-				} else if m.screen == screenLibrary {
-					if len(m.tracks) > 0 && m.tracksCursor != "" {
-						return m, m.loadTracksCmd(m.currentArtistID, m.currentAlbumID, m.tracksCursor)
-					}
-					if len(m.albums) > 0 && m.albumsCursor != "" {
-						return m, m.loadAlbumsCmd(m.currentArtistID, m.albumsCursor)
-					}
-					if len(m.artists) > 0 && m.artistsCursor != "" {
-						return m, m.loadArtistsCmd(m.artistsCursor)
-					}
 				}
+				return m.prefetchPage()
 			}
 			return m, nil
 		case "up", "k":
@@ -1201,6 +1934,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				if m.lyricsScrollOffset > 0 {
 					m.lyricsScrollOffset--
 				}
+			} else if m.filterActive() {
+				m.selection = m.nextFilteredRow(m.selection, -1)
 			} else {
 				// Navigate within list content
 				if m.selection > 0 {
@@ -1210,25 +1945,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		case "h", "left", "backspace":
 			m.logger.Debug("navigation left/back key pressed", slog.String("key", key), slog.String("screen", screenNames[m.screen]))
-			if m.screen == screenLibrary {
-				if len(m.tracks) > 0 {
-					m.logger.Debug("library navigation: going back from tracks to albums")
-					m.tracks = nil
-					m.tracksCursor = ""
-					m.currentAlbumID = ""
-					m.selection = 0
-					m.status = "Albums"
-					return m, nil
-				}
-				if len(m.albums) > 0 {
-					m.logger.Debug("library navigation: going back from albums to artists")
-					m.albums = nil
-					m.albumsCursor = ""
-					m.currentArtistID = ""
-					m.selection = 0
-					m.status = "Artists"
-					return m, nil
-				}
+			if m.screen == screenLibrary && (len(m.history.back) > 0 || m.libraryView() != "artists") {
+				return m.libraryBack()
 			}
 			// Seeking for other screens
 			m.logger.Debug("seeking backward small", slog.Int("seek_small", m.cfg.Player.SeekSmall))
@@ -1238,6 +1956,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.screen == screenLibrary {
 				return m.handleEnter()
 			}
+			if m.screen == screenPlaylists && len(m.playlists) > 0 {
+				return m.openPlaylist()
+			}
 			m.logger.Debug("seeking forward small", slog.Int("seek_small", m.cfg.Player.SeekSmall))
 			return m, m.seekCmd(float64(m.cfg.Player.SeekSmall))
 		case "f":
@@ -1271,6 +1992,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, m.saveQueueCmd()
 			}
+			if m.screen == screenScrobbles {
+				return m.removePendingScrobble()
+			}
 		case "d":
 			if m.screen == screenQueue {
 				m.logger.Debug("queue move down key pressed", slog.String("key", key), slog.Int("selection", m.selection), slog.Int("queue_len", m.queue.Len()))
@@ -1319,21 +2043,50 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				return m, m.saveQueueCmd()
 			}
-		case "c":
+		case "S":
 			if m.screen == screenQueue {
-				m.logger.Debug("queue clear key pressed", slog.String("key", key), slog.Int("queue_len", m.queue.Len()))
-				m.queue.Clear()
-				m.selection = 0
-				m.logger.Debug("queue cleared")
-				return m, m.saveQueueCmd()
+				return m.shuffleRemaining()
+			}
+		case "o":
+			if m.screen == screenLibrary {
+				return m.cycleSort()
 			}
-		case "C":
+		case "y", "Y":
+			if m.screen == screenLibrary {
+				if key == "Y" {
+					return m.cycleYears(-1)
+				}
+				return m.cycleYears(1)
+			}
+		case "'":
+			if m.screen == screenLibrary && m.libraryView() == "artists" && !m.classical {
+				m.jumpPending = true
+				m.status = m.msgs.T("status.jump")
+				return m, nil
+			}
+		case "v":
+			if m.screen == screenNowPlaying && m.visualizer != nil {
+				return m.cycleVisualizerStyle()
+			}
+			if m, ok := m.toggleVersions(); ok {
+				return m, nil
+			}
+		case "1", "2", "3", "4", "5":
+			if m.screen == screenNowPlaying {
+				items := m.continueListening()
+				if idx := int(key[0] - '1'); idx < len(items) {
+					m.logger.Debug("continue listening key pressed", slog.String("key", key), slog.String("context", items[idx].ID))
+					m.status = m.msgs.T("status.loading", items[idx].Title)
+					return m, m.playContextCmd(items[idx])
+				}
+			}
+		case "c", "C":
 			if m.screen == screenQueue {
-				m.logger.Debug("queue clear (alt) key pressed", slog.String("key", key), slog.Int("queue_len", m.queue.Len()))
-				m.queue.Clear()
-				m.selection = 0
-				m.logger.Debug("queue cleared")
-				return m, m.saveQueueCmd()
+				m.logger.Debug("queue clear key pressed", slog.String("key", key), slog.Int("queue_len", m.queue.Len()))
+				return m.clearQueue()
+			}
+			if m.screen == screenLibrary && key == "C" {
+				return m.toggleClassical()
 			}
 		case "g":
 			// Go to top (lyrics screen)
@@ -1365,28 +2118,46 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.logger.Debug("unhandled key in switch", slog.String("key", key), slog.String("screen", screenNames[m.screen]))
 			}
 		}
+	case artistJumpMsg:
+		return m.handleArtistJump(msg)
+	case bookmarksMsg:
+		return m.handleBookmarks(msg)
+	case findArtistMsg:
+		return m.handleFindArtist(msg)
+	case macroStepMsg:
+		return m.runMacroStep(msg)
+	case pluginCommandMsg:
+		return m.handlePluginCommand(msg)
+	case controlMsg:
+		return m.handleControl(msg.call)
+	case controlAddMsg:
+		return m.handleControlAdd(msg)
 	case artistsMsg:
 		if msg.err != nil {
 			return m.setError(msg.err)
 		} else {
+			items := visible(msg.page.Items, m.hidden.artist)
 			if m.artistsCursor == "" {
-				m.artists = msg.page.Items
+				m.artists = items
 			} else {
-				m.artists = append(m.artists, msg.page.Items...)
+				m.artists = append(m.artists, items...)
 			}
 			m.artistsCursor = msg.page.NextCursor
-			m.status = fmt.Sprintf("Artists loaded (%d)", len(m.artists))
+			m.status = m.msgs.T("status.artists_loaded", len(m.artists))
 			if m.screen == screenLoading {
 				m.screen = screenNowPlaying
 				// Handle startup options if CLI flags were provided
 				if !m.startupDone {
-					if m.startupOpts.RandomPlay {
-						m.startupDone = true
-						return m, m.randomPlayCmd()
+					m.startupDone = true
+					if cmd := m.randomCmd(m.startupOpts, nil); cmd != nil {
+						return m, cmd
 					}
-					if m.startupOpts.SearchArtist != "" || m.startupOpts.SearchAlbum != "" {
-						m.startupDone = true
-						return m, m.startupSearchCmd()
+					if m.startupOpts.searches() {
+						return m, m.startupSearchCmd(m.startupOpts, nil)
+					}
+					m.applyQueueModes(m.startupOpts)
+					if m.restore != nil {
+						return m.restoreSession()
 					}
 				}
 			}
@@ -1395,27 +2166,34 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.err != nil {
 			return m.setError(msg.err)
 		} else {
+			items := visible(msg.page.Items, m.hidden.album)
 			if m.albumsCursor == "" {
-				m.albums = msg.page.Items
+				m.albums = items
+				m.selection = 0
 			} else {
-				m.albums = append(m.albums, msg.page.Items...)
+				m.albums = append(m.albums, items...)
 			}
+			m.albums = groupAlbums(m.albums)
 			m.albumsCursor = msg.page.NextCursor
 			m.tracks = nil
-			m.selection = 0
-			m.status = fmt.Sprintf("Albums loaded (%d)", len(m.albums))
+			m.status = m.msgs.T("status.albums_loaded", len(m.albums))
+			return m.continueRestore(screenLibrary, true)
 		}
 	case tracksMsg:
 		if msg.err != nil {
 			return m.setError(msg.err)
 		} else {
+			items := visible(msg.page.Items, m.hidden.track)
 			if m.tracksCursor == "" {
-				m.tracks = msg.page.Items
-			} else {
-				m.tracks = append(m.tracks, msg.page.Items...)
+				m.tracks, m.trackVersions = nil, versionList{}
 			}
+			m.tracks = m.trackVersions.add(m.tracks, items, m.cfg.UI.Versions)
+			m.tracks = m.bookOrder(m.tracks)
 			m.tracksCursor = msg.page.NextCursor
-			m.status = fmt.Sprintf("Tracks loaded (%d)", len(m.tracks))
+			m.currentPlaylistID = msg.playlistID
+			m.topTracksOf = ""
+			m.status = m.msgs.T("status.tracks_loaded", len(m.tracks))
+			return m.continueRestore(screenLibrary, false)
 		}
 	case playlistsMsg:
 		if msg.err != nil {
@@ -1427,22 +2205,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.playlists = append(m.playlists, msg.page.Items...)
 			}
 			m.playlistsCursor = msg.page.NextCursor
-			m.status = fmt.Sprintf("Playlists loaded (%d)", len(m.playlists))
+			m.status = m.msgs.T("status.playlists_loaded", len(m.playlists))
+			return m.continueRestore(screenPlaylists, false)
 		}
 	case searchMsg:
-		if msg.err != nil {
-			return m.setError(msg.err)
-		} else {
-			m.searchResults = msg.res
-			count := len(msg.res.Tracks.Items) + len(msg.res.Albums.Items) + len(msg.res.Artists.Items)
-			m.status = fmt.Sprintf("Found %d results", count)
-		}
+		return m.handleSearch(msg)
 	case searchMoreMsg:
 		if msg.err != nil {
 			return m.setError(msg.err)
 		} else {
+			msg.res = m.visibleResults(msg.res)
 			if len(msg.res.Tracks.Items) > 0 {
-				m.searchResults.Tracks.Items = append(m.searchResults.Tracks.Items, msg.res.Tracks.Items...)
+				m.searchResults.Tracks.Items = m.searchVersions.add(m.searchResults.Tracks.Items, msg.res.Tracks.Items, m.cfg.UI.Versions)
 				m.searchResults.Tracks.NextCursor = msg.res.Tracks.NextCursor
 			}
 			if len(msg.res.Albums.Items) > 0 {
@@ -1453,93 +2227,80 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.searchResults.Artists.Items = append(m.searchResults.Artists.Items, msg.res.Artists.Items...)
 				m.searchResults.Artists.NextCursor = msg.res.Artists.NextCursor
 			}
-			m.status = "Loaded more results"
+			m.status = m.msgs.T("status.more_results")
 		}
 	case startupSearchMsg:
 		m.logger.Debug("startup search result", slog.Int("track_count", len(msg.tracks)), slog.Any("err", msg.err))
-		if msg.err != nil {
-			return m.setError(msg.err)
-		}
-		if len(msg.tracks) == 0 {
-			m.status = "No tracks found for startup search"
-			return m, nil
-		}
-		// Clear queue first if requested
-		if m.startupOpts.ClearQueue {
-			m.logger.Debug("clearing queue before adding tracks")
-			m.queue.Clear()
-		}
-		// Add all tracks to queue
-		for i, t := range msg.tracks {
-			m.logger.Debug("adding startup track to queue", slog.Int("index", i), slog.String("track_id", t.ID), slog.String("title", t.Title))
-			m.queue.Add(t)
-		}
-		m.logger.Debug("startup tracks added", slog.Int("queue_len", m.queue.Len()), slog.Int("current_idx", m.queue.CurrentIndex()))
-		m.status = fmt.Sprintf("Added %d tracks to queue", len(msg.tracks))
-		// If autoplay is enabled, play the first track and show Now Playing
-		if m.startupOpts.AutoPlay {
-			m.logger.Debug("auto-playing first track")
-			m.screen = screenNowPlaying
-			m.focusedPane = paneContent
-			return m, m.playQueueTrackCmd(0)
-		}
-		// Otherwise just show the queue
-		m.screen = screenQueue
-		m.focusedPane = paneContent
-		return m, nil
+		return m.queueStartupTracks(msg.opts, msg.call, msg.tracks, msg.err, "")
 	case randomPlayMsg:
-		if msg.err != nil {
-			return m.setError(msg.err)
-		}
-		if len(msg.tracks) == 0 {
-			m.status = "No tracks found for random play"
-			return m, nil
+		what := msg.what
+		if what == "" {
+			what = m.msgs.T("random.what")
 		}
-		// Clear queue first if requested
-		if m.startupOpts.ClearQueue {
-			m.logger.Debug("clearing queue before adding random tracks")
-			m.queue.Clear()
-		}
-		// Add all tracks to queue
-		for _, t := range msg.tracks {
-			m.queue.Add(t)
-		}
-		m.status = fmt.Sprintf("Added %d random tracks to queue", len(msg.tracks))
-		// Only auto-play if --play was also given
-		if m.startupOpts.AutoPlay {
-			m.screen = screenNowPlaying
-			m.focusedPane = paneContent
-			return m, m.playQueueTrackCmd(0)
-		}
-		// Otherwise just show the queue
-		m.screen = screenQueue
-		m.focusedPane = paneContent
-		return m, nil
+		return m.queueStartupTracks(msg.opts, msg.call, msg.tracks, msg.err, what)
 	case playTrackMsg:
 		if msg.err != nil {
 			m.logger.Error("play track failed", slog.Any("err", msg.err))
 			return m.setError(msg.err)
 		} else {
 			m.logger.Debug("play track success", slog.String("track_id", msg.track.ID), slog.String("title", msg.track.Title), slog.Int("queue_idx", m.queue.CurrentIndex()))
+			policyCmd := m.startTrackPolicy(msg.track)
 			m.nowPlaying = msg.track
 			m.paused = false
-			m.status = "Playing " + msg.track.Title
+			m.status = m.msgs.T("status.playing", msg.track.Title)
 			m.scrobbled = false // Reset scrobble state for new track
+			m.hooks.Fire(hooks.EventTrackChange, msg.track)
+			m.diagnosticsState.RecordTrackPlayed()
+
+			// Remember where we are in the album/playlist for Continue Listening
+			m.playContext = m.contextFor(msg.track)
+			if m.playContext.Kind != queue.ContextPlaylist {
+				m.playContextTracks = nil
+			}
+			m.contextSavedPos = 0
 
-			// Notify scrobblers of now playing
-			if m.scrobbler != nil && m.cfg.Scrobble.Enabled {
+			// Notify scrobblers of now playing; audiobooks are never scrobbled
+			if m.scrobbler != nil && m.cfg.Scrobble.Enabled && !m.audiobook {
+				providerID, localID := m.trackOrigin(msg.track.ID)
 				m.scrobbler.NowPlaying(context.Background(), scrobble.Track{
 					Title:      msg.track.Title,
 					Artist:     msg.track.ArtistName,
 					Album:      msg.track.AlbumTitle,
 					DurationMs: msg.track.DurationMs,
 					StartedAt:  time.Now(),
-					ProviderID: msg.track.ID,
+					Provider:   providerID,
+					ProviderID: localID,
 				})
 			}
 
 			// Build commands for async fetches
 			var cmds []tea.Cmd
+			if policyCmd != nil {
+				cmds = append(cmds, policyCmd)
+			}
+			if cmd := m.recordContextCmd(); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			if cmd := m.pluginEventCmd(plugin.EventTrackStart, msg.track); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			if cmd := m.exportCmd(nowplaying.StatusPlaying); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			m.bookmarks = nil
+			m.showBookmarks = false
+			if cmd := m.loadBookmarksCmd(msg.track.ID); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			m.nowPlayingStats = queue.TrackStats{}
+			m.playCounted = false
+			if cmd := m.loadTrackStatsCmd(msg.track); cmd != nil {
+				cmds = append(cmds, cmd)
+			}
+			var radioCmd tea.Cmd
+			if m, radioCmd = m.refillRadio(); radioCmd != nil {
+				cmds = append(cmds, radioCmd)
+			}
 			caps := m.provider.Capabilities()
 
 			// Fetch lyrics for new track if provider supports it
@@ -1560,19 +2321,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				slog.String("artwork_ref", msg.track.ArtworkRef),
 				slog.Bool("cache_available", m.artworkCache != nil),
 			)
+			if m.artworkANSI != "" && m.artworkRef == m.artworkCacheRef(msg.track) {
+				// Same album: the cover on screen is already this track's
+				m.artworkTrackID = msg.track.ID
+			}
 			if m.cfg.Artwork.Enabled && caps[provider.CapArtwork] && msg.track.ID != m.artworkTrackID && msg.track.ArtworkRef != "" {
 				m.logger.Debug("fetching artwork", slog.String("track_id", msg.track.ID), slog.String("artwork_ref", msg.track.ArtworkRef))
-				m.artworkANSI = ""
+				m.artworkANSI, m.artworkRef = "", ""
 				m.artworkLoading = true
-				cmds = append(cmds, m.fetchArtworkCmd(msg.track.ID, msg.track.ArtworkRef))
+				cmds = append(cmds, m.fetchArtworkCmd(msg.track))
+				if m.zen {
+					width, height := m.zenArtworkSize()
+					cmds = append(cmds, m.renderArtworkCmd(msg.track, width, height, true))
+				}
 			} else if m.cfg.Artwork.Enabled && msg.track.ArtworkRef == "" {
 				m.logger.Debug("no artwork ref for track", slog.String("track_id", msg.track.ID))
 			}
 
 			// Start visualizer if available and not already running
+			if m.visualizer != nil {
+				m.visualizer.Follow(msg.stream.URL, msg.stream.Headers)
+			}
 			if m.visualizer != nil && !m.visualizer.Running() {
 				if err := m.visualizer.Start(context.Background()); err != nil {
-					m.logger.Debug("visualizer start failed", slog.Any("err", err))
+					m.logger.Warn("visualizer start failed", slog.String("backend", m.visualizer.Backend()), slog.Any("err", err))
 				} else {
 					cmds = append(cmds, vizTickCmd())
 				}
@@ -1604,81 +2376,181 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			slog.Bool("has_error", msg.err != nil),
 			slog.Int("ansi_len", len(msg.ansi)),
 		)
+		if msg.preview {
+			// Show the blurred cover while the real one is converted
+			if msg.trackID != m.nowPlaying.ID {
+				return m, nil
+			}
+			if msg.zen {
+				m.zenArtworkTrackID, m.zenArtworkANSI = msg.trackID, msg.ansi
+			} else {
+				m.artworkTrackID = msg.trackID
+				m.artworkANSI, m.artworkRef = msg.ansi, ""
+			}
+			return m, m.convertArtworkCmd(m.nowPlaying, msg.img, msg.width, msg.height, msg.zen)
+		}
+		if msg.zen {
+			if msg.trackID == m.nowPlaying.ID && msg.err == nil {
+				m.zenArtworkTrackID = msg.trackID
+				m.zenArtworkANSI = msg.ansi
+			}
+			return m, nil
+		}
 		if msg.trackID == m.nowPlaying.ID {
 			m.artworkTrackID = msg.trackID
 			m.artworkLoading = false
 			if msg.err != nil {
 				m.logger.Debug("artwork fetch failed", slog.Any("err", msg.err))
-				m.artworkANSI = ""
+				m.artworkANSI, m.artworkRef = "", ""
 			} else {
 				m.logger.Debug("artwork fetch success", slog.Int("ansi_len", len(msg.ansi)))
-				m.artworkANSI = msg.ansi
+				m.artworkANSI, m.artworkRef = msg.ansi, msg.ref
 			}
 		}
 		return m, nil
+	case zenIdleMsg:
+		return m.handleZenIdle()
+	case dropTickMsg:
+		return m.handleDropTick()
+	case droppedMsg:
+		return m.handleDropped(msg)
 	case vizTickMsg:
 		// Update visualizer diagnostics
 		if m.diagnosticsState != nil && m.visualizer != nil {
-			m.diagnosticsState.VisualizerRunning = m.visualizer.Running()
-			m.diagnosticsState.VisualizerFPS = 30 // ~30fps target
+			m.diagnosticsState.SetVisualizer(m.visualizer.Running(), 30) // ~30fps target
 		}
 		// Continue ticking only if visualizer is running and we're playing
 		if m.visualizer != nil && m.visualizer.Running() && !m.paused && m.nowPlaying.ID != "" {
 			return m, vizTickCmd()
 		}
+		if m.visualizer != nil && m.visualizer.Error() != nil {
+			m.logger.Warn("visualizer stopped", slog.String("backend", m.visualizer.Backend()), slog.Any("err", m.visualizer.Error()))
+		}
 		return m, nil
 	case playerMsg:
+		// Side effects of this update, returned with whatever it does next
+		var scrobbleHook, exportCmd tea.Cmd
 		if msg.TimePos != nil {
 			m.timePos = *msg.TimePos
+			if m.visualizer != nil {
+				m.visualizer.Sync(m.timePos)
+			}
 		}
 		if msg.Duration != nil {
 			m.duration = *msg.Duration
 		}
+		if msg.Chapters != nil {
+			m.chapters = *msg.Chapters
+			if len(m.chapters) == 0 {
+				m.showChapters = false
+			}
+		}
 		if msg.Volume != nil {
 			m.volume = *msg.Volume
 		}
 		if msg.Paused != nil {
+			// m.paused flips as soon as a key is pressed, so compare with
+			// what mpv reported last
+			if *msg.Paused != m.playerPaused && m.nowPlaying.ID != "" {
+				if *msg.Paused {
+					m.hooks.Fire(hooks.EventPause, m.nowPlaying)
+					exportCmd = m.exportCmd(nowplaying.StatusPaused)
+				} else {
+					m.hooks.Fire(hooks.EventResume, m.nowPlaying)
+					exportCmd = m.exportCmd(nowplaying.StatusPlaying)
+				}
+			}
 			m.paused = *msg.Paused
+			m.playerPaused = *msg.Paused
+			if m.visualizer != nil {
+				m.visualizer.SetPaused(*msg.Paused)
+			}
 		}
 		if msg.Muted != nil {
 			m.muted = *msg.Muted
 		}
+		if msg.AudioDevices != nil {
+			var pauseCmd tea.Cmd
+			m, pauseCmd = m.audioDevicesChanged(*msg.AudioDevices)
+			scrobbleHook = tea.Batch(scrobbleHook, pauseCmd)
+		}
 
 		// Update scrobbler position and check if we should scrobble
-		if m.scrobbler != nil && m.cfg.Scrobble.Enabled && m.nowPlaying.ID != "" {
+		if m.scrobbler != nil && m.cfg.Scrobble.Enabled && m.nowPlaying.ID != "" && !m.audiobook {
 			m.scrobbler.UpdatePosition(time.Duration(m.timePos*float64(time.Second)), m.paused)
 
 			// Scrobble if threshold met and not already scrobbled
 			if !m.scrobbled && m.scrobbler.ShouldScrobble() {
 				m.scrobbled = true
+				providerID, localID := m.trackOrigin(m.nowPlaying.ID)
 				m.scrobbler.Scrobble(context.Background(), scrobble.Track{
 					Title:      m.nowPlaying.Title,
 					Artist:     m.nowPlaying.ArtistName,
 					Album:      m.nowPlaying.AlbumTitle,
 					DurationMs: m.nowPlaying.DurationMs,
 					StartedAt:  time.Now().Add(-time.Duration(m.timePos * float64(time.Second))),
-					ProviderID: m.nowPlaying.ID,
+					Provider:   providerID,
+					ProviderID: localID,
 				})
 				m.logger.Debug("scrobbled track", slog.String("title", m.nowPlaying.Title))
+				scrobbleHook = m.pluginEventCmd(plugin.EventScrobble, m.nowPlaying)
 			}
 		}
+		if msg.TimePos != nil {
+			var playCmd, prefetchCmd tea.Cmd
+			m, playCmd = m.countPlay()
+			m, prefetchCmd = m.maybePrefetch()
+			scrobbleHook = tea.Batch(scrobbleHook, playCmd, prefetchCmd)
+		}
 
 		if msg.Err != nil {
-			return m.setError(msg.Err)
+			m.diagnosticsState.RecordMPVError(msg.Err.Error())
+			if errors.Is(msg.Err, player.ErrDisconnected) {
+				m.logger.Warn("mpv disconnected, restarting", slog.Any("err", msg.Err))
+				m.status = m.msgs.T("status.mpv_restarting")
+				return m, tea.Batch(m.restartPlayerCmd(), scrobbleHook, exportCmd)
+			}
+			m, cmd := m.setError(msg.Err)
+			return m, tea.Batch(cmd, scrobbleHook, exportCmd)
 		}
 		if msg.EndReason != "" {
 			m.logger.Debug("end-file event", slog.String("reason", msg.EndReason), slog.Bool("ended", msg.Ended))
 		}
 		if msg.Ended {
 			m.logger.Debug("track ended naturally (eof), advancing to next")
-			if t, err := m.queue.Next(); err == nil {
+			m.playContext.Position = m.timePos
+			m.playContext.Finished = m.contextFinished()
+			recordCmd := m.recordContextCmd()
+			if m.audiobook {
+				// Finished chapters start over next time
+				recordCmd = tea.Batch(recordCmd, m.saveBookPositionCmd(m.nowPlaying.ID, 0))
+				m.timePos = 0
+			}
+			endHook := m.pluginEventCmd(plugin.EventTrackEnd, m.nowPlaying)
+			var t provider.Track
+			var err error
+			if m, t, err = m.nextTrack(); err == nil {
 				m.logger.Debug("auto-advancing to next track", slog.String("track_id", t.ID), slog.String("title", t.Title))
-				return m, m.playTrackCmd(t)
+				return m, tea.Batch(m.playTrackCmd(t), recordCmd, scrobbleHook, endHook)
 			} else {
 				m.logger.Debug("no more tracks in queue", slog.Any("err", err))
+				m.hooks.Fire(hooks.EventQueueEmpty, m.nowPlaying)
+				exportCmd = m.exportCmd(nowplaying.StatusStopped)
 			}
+			return m, tea.Batch(m.watchPlayerCmd(), recordCmd, scrobbleHook, endHook, exportCmd)
 		}
-		return m, m.watchPlayerCmd()
+
+		cmds := []tea.Cmd{m.watchPlayerCmd(), m.applyPendingSeek(), scrobbleHook, exportCmd}
+		if msg.TimePos != nil || msg.Paused != nil {
+			cmds = append(cmds, m.trackBookPosition(msg.Paused != nil && *msg.Paused))
+		}
+		if m.playContext.ID != "" && m.playContext.TrackID == m.nowPlaying.ID &&
+			(math.Abs(m.timePos-m.contextSavedPos) >= contextSaveInterval || (msg.Paused != nil && *msg.Paused)) {
+			m.playContext.Position = m.timePos
+			m.contextSavedPos = m.timePos
+			cmds = append(cmds, m.recordContextCmd())
+		}
+		return m, tea.Batch(cmds...)
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
@@ -1691,12 +2563,16 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	case screenLibrary:
 		if len(m.tracks) > 0 {
 			idx := clamp(m.selection, 0, len(m.tracks)-1)
+			if m.cfg.Queue.PlayFromHere {
+				return m, m.playFromHereCmd(idx)
+			}
 			track := m.tracks[idx]
 			return m, m.addAndPlayTrackCmd(track)
 		}
 		if len(m.albums) > 0 {
 			idx := clamp(m.selection, 0, len(m.albums)-1)
 			album := m.albums[idx]
+			m = m.pushHistory()
 			m.currentAlbumID = album.ID
 			m.currentArtistID = album.ArtistID
 			return m, m.loadTracksCmd(album.ArtistID, album.ID, "")
@@ -1704,6 +2580,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 		if len(m.artists) > 0 {
 			idx := clamp(m.selection, 0, len(m.artists)-1)
 			artist := m.artists[idx]
+			m = m.pushHistory()
 			m.currentArtistID = artist.ID
 			return m, m.loadAlbumsCmd(artist.ID, "")
 		}
@@ -1719,6 +2596,7 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			if len(m.searchResults.Albums.Items) > 0 {
 				idx := clamp(m.selection, 0, len(m.searchResults.Albums.Items)-1)
 				album := m.searchResults.Albums.Items[idx]
+				m = m.pushHistory()
 				m.screen = screenLibrary
 				m.currentAlbumID = album.ID
 				m.currentArtistID = album.ArtistID
@@ -1728,11 +2606,19 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			if len(m.searchResults.Artists.Items) > 0 {
 				idx := clamp(m.selection, 0, len(m.searchResults.Artists.Items)-1)
 				artist := m.searchResults.Artists.Items[idx]
+				m = m.pushHistory()
 				m.screen = screenLibrary
 				m.currentArtistID = artist.ID
 				return m, m.loadAlbumsCmd(artist.ID, "")
 			}
 		}
+	case screenPlaylists:
+		if len(m.playlists) > 0 {
+			p := m.playlists[clamp(m.selection, 0, len(m.playlists)-1)]
+			m.logger.Debug("play playlist", slog.String("playlist_id", p.ID))
+			m.status = m.msgs.T("status.loading", p.Name)
+			return m, m.playContextCmd(queue.ListeningContext{Kind: queue.ContextPlaylist, ID: p.ID, Title: p.Name})
+		}
 	case screenQueue:
 		if m.queue.Len() > 0 {
 			if err := m.queue.SetCurrent(m.selection); err == nil {
@@ -1742,11 +2628,25 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 			}
 		}
 	case screenConfig:
+		if m.hiddenOpen {
+			return m.unhideSelected()
+		}
+		if m.cacheOpen {
+			return m.selectCache()
+		}
+		if m.selection == configHiddenSection {
+			m.hiddenOpen = len(m.hiddenItems) > 0
+			m.selection = 0
+			return m, nil
+		}
+		if m.selection == configCacheSection {
+			return m.openCaches()
+		}
 		if len(m.cfg.Profiles) > 0 {
 			idx := clamp(m.selection, 0, len(m.cfg.Profiles)-1)
 			profile := m.cfg.Profiles[idx]
 			if profile.ID != m.cfg.ActiveProfile && profile.Enabled {
-				m.status = "Switching profile..."
+				m.status = m.msgs.T("status.switching")
 				return m, m.switchProfileCmd(profile)
 			}
 		}
@@ -1754,18 +2654,70 @@ func (m Model) handleEnter() (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// openPlaylist lists the selected playlist's tracks in the Library.
+func (m Model) openPlaylist() (Model, tea.Cmd) {
+	p := m.playlists[clamp(m.selection, 0, len(m.playlists)-1)]
+	m.logger.Debug("open playlist", slog.String("playlist_id", p.ID))
+	m = m.pushHistory()
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.currentArtistID, m.currentAlbumID = "", ""
+	m.tracksCursor = ""
+	m.selection = 0
+	m.status = m.msgs.T("status.loading", p.Name)
+	return m, m.loadPlaylistTracksCmd(p.ID, "")
+}
+
+// playStream loads a stream into mpv with its provider's network settings
+// and, for files measured by --analyze, their gain.
+func (m Model) playStream(stream provider.StreamInfo) error {
+	if err := m.player.SetNetwork(player.NetworkOptions{
+		Timeout:            stream.Timeout,
+		CAFile:             stream.CAFile,
+		InsecureSkipVerify: stream.InsecureSkipVerify,
+	}); err != nil {
+		return err
+	}
+	var gain float64
+	if g := stream.Gain; g != nil {
+		switch m.cfg.Player.Normalize {
+		case "track":
+			gain = g.TrackDB
+		case "album":
+			gain = g.AlbumDB
+		}
+	}
+	if err := m.player.SetGainFallback(gain); err != nil {
+		return err
+	}
+	return m.player.Play(stream.URL, stream.Headers)
+}
+
 func (m Model) playTrackCmd(track provider.Track) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		stream, err := m.provider.GetStream(ctx, track.ID)
+		stream, err := m.streamFor(ctx, track)
 		if err != nil {
 			return playTrackMsg{err: err}
 		}
-		if err := m.player.Play(stream.URL, stream.Headers); err != nil {
+		if err := m.playStream(stream); err != nil {
 			return playTrackMsg{err: err}
 		}
-		return playTrackMsg{track: track}
+		return playTrackMsg{track: track, stream: stream}
+	}
+}
+
+// skipToCmd plays track in place of the one playing, fading the playing
+// one out first when [player] fade_ms is set.
+func (m Model) skipToCmd(track provider.Track) tea.Cmd {
+	playing := m.nowPlaying.ID != "" && !m.paused
+	play := m.playTrackCmd(track)
+	return func() tea.Msg {
+		if playing {
+			m.player.FadeOut()
+		}
+		return play()
 	}
 }
 
@@ -1780,27 +2732,63 @@ func (m Model) playQueueTrackCmd(index int) tea.Cmd {
 		_ = m.queue.SetCurrent(index)
 		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 		defer cancel()
-		stream, err := m.provider.GetStream(ctx, track.ID)
+		stream, err := m.streamFor(ctx, track)
 		if err != nil {
 			return playTrackMsg{err: err}
 		}
-		if err := m.player.Play(stream.URL, stream.Headers); err != nil {
+		if err := m.playStream(stream); err != nil {
 			return playTrackMsg{err: err}
 		}
-		return playTrackMsg{track: track}
+		return playTrackMsg{track: track, stream: stream}
 	}
 }
 
 func (m Model) View() string {
+	// A copy to the clipboard goes out with the frame, whatever is showing
+	return m.clipboard + m.render()
+}
+
+// render draws the screen, or the overlay on top of it.
+func (m Model) render() string {
 	if m.fatalErr != nil {
 		return m.renderFatalError()
 	}
+	if m.zen {
+		return m.renderZen()
+	}
+	if m.confirmPrompt != nil {
+		return m.renderConfirm()
+	}
 	if m.showHelp {
 		return m.renderHelpOverlay()
 	}
+	if m.showTrackInfo {
+		return m.renderTrackInfoOverlay()
+	}
+	if m.showProfileSwitcher {
+		return m.renderProfileSwitcher()
+	}
+	if m.showAbout {
+		return m.renderAbout()
+	}
+	if m.showSimilar {
+		return m.renderSimilar()
+	}
+	if m.showBookmarks {
+		return m.renderBookmarks()
+	}
+	if m.showChapters {
+		return m.renderChapters()
+	}
 	if m.showPalette {
 		return m.paletteState.Render(&m)
 	}
+	if m.showQuickOpen {
+		return m.renderQuickOpen()
+	}
+	if m.showHealth {
+		return m.renderHealth()
+	}
 
 	// Calculate dimensions
 	// Ensure width is strictly less than terminal width to prevent auto-wrapping
@@ -1885,6 +2873,8 @@ func (m Model) View() string {
 		mainContent = m.renderPlaylists()
 	case screenLyrics:
 		mainContent = m.renderLyrics(contentHeight)
+	case screenScrobbles:
+		mainContent = m.renderScrobbles(mainWidth, contentHeight)
 	case screenConfig:
 		mainContent = m.renderConfig()
 	}
@@ -1938,10 +2928,19 @@ func (m Model) renderTopBar(width int) string {
 	// Provider info
 	profile, _ := m.cfg.ProfileByID(m.cfg.ActiveProfile)
 	providerInfo := fmt.Sprintf("Provider: %s (%s)", profile.Provider, profile.Name)
+	if _, ok := provider.As[originProvider](m.provider); ok {
+		providerInfo = fmt.Sprintf("Provider: merged (%s)", m.provider.Name())
+	}
 
 	// Health status - use actual health check result
 	var health string
-	if m.healthOK {
+	if m.degraded {
+		if m.noEmoji {
+			health = m.theme.Warning.Render("[CACHED]")
+		} else {
+			health = m.theme.Warning.Render("● Offline (cached)")
+		}
+	} else if m.healthOK {
 		if m.noEmoji {
 			health = m.theme.Success.Render("[OK]")
 		} else {
@@ -1954,6 +2953,9 @@ func (m Model) renderTopBar(width int) string {
 			health = m.theme.Error.Render("● " + m.healthDetails)
 		}
 	}
+	if m.lowBandwidth {
+		health = m.theme.Warning.Render("LOW BW") + "  " + health
+	}
 
 	// Queue count
 	queueInfo := fmt.Sprintf("Queue: %d", m.queue.Len())
@@ -1976,8 +2978,8 @@ func (m Model) renderTopBar(width int) string {
 	if availableForLeft > titleLen+10 {
 		// Enough room for title + some provider info
 		maxProviderLen := availableForLeft - titleLen - 4
-		if maxProviderLen > 0 && len(providerInfo) > maxProviderLen {
-			providerInfo = providerInfo[:maxProviderLen-1] + "…"
+		if maxProviderLen > 0 {
+			providerInfo = ui.Truncate(providerInfo, maxProviderLen)
 		}
 		left = title + "  " + m.theme.Dim.Render(providerInfo)
 	} else if availableForLeft > titleLen {
@@ -2005,10 +3007,10 @@ func (m Model) renderNavigation(width, height int) string {
 		label  string
 		icon   string
 	}{
-		{screenNowPlaying, "Now Playing", "♪"},
-		{screenSearch, "Search", "⌕"},
-		{screenLibrary, "Library", "≡"},
-		{screenQueue, "Queue", "☰"},
+		{screenNowPlaying, m.screenLabel(screenNowPlaying), "♪"},
+		{screenSearch, m.screenLabel(screenSearch), "⌕"},
+		{screenLibrary, m.screenLabel(screenLibrary), "≡"},
+		{screenQueue, m.screenLabel(screenQueue), "☰"},
 	}
 
 	// Add capability-gated items
@@ -2018,20 +3020,27 @@ func (m Model) renderNavigation(width, height int) string {
 			screen screen
 			label  string
 			icon   string
-		}{screenPlaylists, "Playlists", "♫"})
+		}{screenPlaylists, m.screenLabel(screenPlaylists), "♫"})
 	}
 	if caps[provider.CapLyrics] {
 		items = append(items, struct {
 			screen screen
 			label  string
 			icon   string
-		}{screenLyrics, "Lyrics", "¶"})
+		}{screenLyrics, m.screenLabel(screenLyrics), "¶"})
+	}
+	if m.scrobbler != nil {
+		items = append(items, struct {
+			screen screen
+			label  string
+			icon   string
+		}{screenScrobbles, m.screenLabel(screenScrobbles), "↑"})
 	}
 	items = append(items, struct {
 		screen screen
 		label  string
 		icon   string
-	}{screenConfig, "Config", "⚙"})
+	}{screenConfig, m.screenLabel(screenConfig), "⚙"})
 
 	// Debug items
 	var itemLabels []string
@@ -2056,7 +3065,9 @@ func (m Model) renderNavigation(width, height int) string {
 		}
 		label := fmt.Sprintf("%s %s", icon, item.label)
 
-		if item.screen == m.screen {
+		if item.screen == m.screen && m.cfg.UI.SelectionMarkers {
+			lines = append(lines, navItemStyle.Bold(true).Render(selectionMark+" "+item.label))
+		} else if item.screen == m.screen {
 			lines = append(lines, navSelectedStyle.Render(label))
 		} else {
 			lines = append(lines, navItemStyle.Render(label))
@@ -2150,7 +3161,7 @@ func (m Model) renderNowPlaying() string {
 		b.WriteString(boxStyle.Render(
 			lipgloss.JoinVertical(lipgloss.Center,
 				"",
-				m.theme.Dim.Render("♪ Nothing playing"),
+				m.theme.Dim.Render("♪ "+m.msgs.T("status.nothing_playing")),
 				"",
 				m.theme.Dim.Render("Select a track from Library or Search"),
 				"",
@@ -2159,16 +3170,46 @@ func (m Model) renderNowPlaying() string {
 	} else {
 		// Track info with optional artwork
 		trackInfo := lipgloss.JoinVertical(lipgloss.Left,
-			m.theme.Dim.Render("Track: ")+m.theme.Accent.Render(m.nowPlaying.Title),
+			m.theme.Dim.Render("Track: ")+m.theme.Accent.Render(m.nowPlaying.MovementTitle())+m.theme.Error.Render(m.loveIndicator(m.nowPlaying.ID)),
 			m.theme.Dim.Render("Artist: ")+m.theme.Text.Render(m.nowPlaying.ArtistName),
 			m.theme.Dim.Render("Album: ")+m.theme.Text.Render(m.nowPlaying.AlbumTitle),
 		)
+		if m.nowPlaying.Composer != "" {
+			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
+				trackInfo,
+				m.theme.Dim.Render("Composer: ")+m.theme.Text.Render(m.nowPlaying.Composer),
+			)
+		}
+		if m.nowPlaying.Work != "" && m.nowPlaying.Work != m.nowPlaying.Title {
+			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
+				trackInfo,
+				m.theme.Dim.Render("Work: ")+m.theme.Text.Render(m.nowPlaying.Work),
+			)
+		}
 		if m.nowPlaying.Year > 0 {
 			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
 				trackInfo,
 				m.theme.Dim.Render("Year: ")+m.theme.Text.Render(fmt.Sprintf("%d", m.nowPlaying.Year)),
 			)
 		}
+		if m.audiobook {
+			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
+				trackInfo,
+				m.theme.Dim.Render("Audiobook: ")+m.theme.Text.Render(fmt.Sprintf("%gx, resumes where left", m.speed)),
+			)
+		}
+		if plays := m.nowPlayingStats.Plays; plays > 0 {
+			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
+				trackInfo,
+				m.theme.Dim.Render("Plays: ")+m.theme.Text.Render(fmt.Sprintf("%d", plays)),
+			)
+		}
+		if current := m.currentChapter(); current >= 0 {
+			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
+				trackInfo,
+				m.theme.Dim.Render(fmt.Sprintf("Chapter %d/%d: ", current+1, len(m.chapters)))+m.theme.Text.Render(m.chapterTitle(current)),
+			)
+		}
 		if m.nowPlaying.StreamURL != "" && !strings.HasPrefix(m.nowPlaying.StreamURL, "http://") && !strings.HasPrefix(m.nowPlaying.StreamURL, "https://") {
 			// Extract just the filename from the path (only for local files, not streams)
 			fileName := filepath.Base(m.nowPlaying.StreamURL)
@@ -2183,44 +3224,19 @@ func (m Model) renderNowPlaying() string {
 				m.theme.Dim.Render(fmt.Sprintf("Codec: %s  |  Bitrate: %dkbps", m.nowPlaying.Codec, m.nowPlaying.BitrateKbps)),
 			)
 		}
+		if format := formatAudioFormat(m.nowPlaying); format != "" {
+			if m.cfg.Player.AudioExclusive {
+				format += "  |  Exclusive"
+			}
+			trackInfo = lipgloss.JoinVertical(lipgloss.Left,
+				trackInfo,
+				m.theme.Dim.Render("Format: "+format),
+			)
+		}
 
 		// Render artwork alongside track info if available
 		if m.cfg.Artwork.Enabled {
-			artWidth := m.cfg.Artwork.Width
-			if artWidth <= 0 {
-				artWidth = 40
-			}
-			artHeight := m.cfg.Artwork.Height
-			if artHeight <= 0 {
-				artHeight = 20
-			}
-
-			// Ensure artwork doesn't exceed available width (leave some margin)
-			// Main content width is roughly: totalWidth - navWidth - borders - padding
-			availableWidth := m.width - 20 // Conservative estimate for borders/padding
-			if availableWidth > 0 && artWidth > availableWidth {
-				artWidth = availableWidth
-			}
-
-			// Ensure artwork doesn't exceed available height
-			// Reserve space for: top bar (2), progress bar (3), visualizer (5), up next (7), hints (2), player bar (3) = ~22 lines
-			availableHeight := m.height - 22
-			if availableHeight < 8 {
-				availableHeight = 8 // Minimum artwork height
-			}
-			if artHeight > availableHeight {
-				artHeight = availableHeight
-			}
-
-			// Maintain square aspect ratio (width should be ~2x height for square look)
-			// If we constrained one dimension, adjust the other to maintain aspect
-			expectedWidth := artHeight * 2
-			expectedHeight := artWidth / 2
-			if artWidth > expectedWidth {
-				artWidth = expectedWidth
-			} else if artHeight > expectedHeight {
-				artHeight = expectedHeight
-			}
+			artWidth, artHeight := m.artworkSize()
 
 			var artworkDisplay string
 			if m.artworkANSI != "" {
@@ -2233,9 +3249,16 @@ func (m Model) renderNowPlaying() string {
 			// Join artwork and track info horizontally
 			// Style the info box to match artwork height for proper alignment
 			artworkLines := strings.Count(artworkDisplay, "\n") + 1
-			infoBox := boxStyle.Height(artworkLines).Render(trackInfo)
-			combined := lipgloss.JoinHorizontal(lipgloss.Top, artworkDisplay, "  ", infoBox)
-			b.WriteString(combined)
+			if m.cfg.UI.NowPlayingLayout == "lyrics" {
+				b.WriteString(m.withLyrics(artworkDisplay, artWidth, artworkLines))
+			} else {
+				infoBox := boxStyle.Height(artworkLines).Render(trackInfo)
+				combined := lipgloss.JoinHorizontal(lipgloss.Top, artworkDisplay, "  ", infoBox)
+				b.WriteString(combined)
+			}
+		} else if m.cfg.UI.NowPlayingLayout == "lyrics" {
+			info := boxStyle.Render(trackInfo)
+			b.WriteString(m.withLyrics(info, lipgloss.Width(info), max(lipgloss.Height(info)-2, 12)))
 		} else {
 			b.WriteString(boxStyle.Render(trackInfo))
 		}
@@ -2243,33 +3266,18 @@ func (m Model) renderNowPlaying() string {
 
 		// Visual progress bar
 		barWidth := 50
-		pct := 0.0
-		if m.duration > 0 {
-			pct = m.timePos / m.duration
-		}
-		filled := int(float64(barWidth) * pct)
-		empty := barWidth - filled
-		if filled < 0 {
-			filled = 0
-		}
-		if empty < 0 {
-			empty = 0
-		}
-
-		progressBar := m.theme.Highlight.Render(strings.Repeat("▓", filled)) +
-			m.theme.Dim.Render(strings.Repeat("░", empty))
+		progressBar := m.progressBar(barWidth)
 
-		tPos := fmt.Sprintf("%d:%02d", int(m.timePos)/60, int(m.timePos)%60)
-		dur := fmt.Sprintf("%d:%02d", int(m.duration)/60, int(m.duration)%60)
+		tPos := formatPosition(m.timePos)
+		dur := formatPosition(m.duration)
 		timeStr := fmt.Sprintf("%s / %s", tPos, dur)
 
 		b.WriteString("  " + progressBar + "  " + m.theme.Dim.Render(timeStr) + "\n\n")
 
 		// Visualizer - match progress bar width
 		if m.visualizer != nil && m.visualizer.Running() {
-			// Use rainbow colors for rainbow theme, plain for others
-			useRainbow := m.cfg.UI.Theme == "" || m.cfg.UI.Theme == "rainbow"
-			vizBars := m.visualizer.RenderSized(barWidth, 0, useRainbow) // 0 height = auto
+			// Colors come from the theme's gradient for the style
+			vizBars := m.visualizer.RenderStyle(m.vizStyle, barWidth, 0, m.theme.VisualizerGradient(m.vizStyle)) // 0 height = auto
 			// Indent each line
 			for i, line := range strings.Split(vizBars, "\n") {
 				if i > 0 {
@@ -2278,10 +3286,14 @@ func (m Model) renderNowPlaying() string {
 				b.WriteString("  " + line)
 			}
 			b.WriteString("\n\n")
-		} else if visualizer.Available() {
+		} else if m.visualizer != nil && m.visualizer.Error() != nil {
+			b.WriteString(m.theme.Error.Render("  Visualizer: "+m.visualizer.Error().Error()) + "\n")
+			if m.visualizer.Backend() == visualizer.BackendCava {
+				b.WriteString(m.theme.Dim.Render("  Set [visualizer] input (pulse, pipewire, alsa, fifo) in your config") + "\n")
+			}
+			b.WriteString("\n")
+		} else if m.visualizer != nil {
 			b.WriteString(m.theme.Dim.Render("  Visualizer: (starting...)") + "\n\n")
-		} else {
-			b.WriteString(m.theme.Dim.Render("  Visualizer: (cava not installed)") + "\n\n")
 		}
 	}
 
@@ -2303,6 +3315,7 @@ func (m Model) renderNowPlaying() string {
 	if upNextCount == 0 {
 		b.WriteString(m.theme.Dim.Render("  (End of queue)") + "\n")
 	}
+	b.WriteString(m.renderContinueListening())
 
 	return b.String()
 }
@@ -2311,9 +3324,10 @@ func (m Model) renderLibrary(width, height int) string {
 	var b strings.Builder
 
 	// Determine what we're viewing
-	var title string
 	var items []string
+	count := len(m.artists)
 	var start, end int
+	selPos := 0 // position of the selected row among the visible items
 
 	// Calculate visible Rows
 	// Overhead: Header(1) + \n\n(2) + BoxBorder(2) + \n(1) + Hints(1) = 7 lines
@@ -2322,78 +3336,105 @@ func (m Model) renderLibrary(width, height int) string {
 		visibleRows = 1
 	}
 
-	// Max content width: width - padding(2) - borders(2) = width - 4
-	maxWidth := width - 4
+	// Max content width: width - pane padding(2) - box padding(2) - borders(2)
+	maxWidth := width - 6
 	if maxWidth < 10 {
 		maxWidth = 10
 	}
 
 	if len(m.tracks) > 0 {
-		title = fmt.Sprintf("Tracks (%d)", len(m.tracks))
+		count = len(m.tracks)
+		badges := func(t provider.Track) string {
+			b := m.qualityBadges(t) + m.providerBadge(t.ID) + m.versionBadge(m.trackVersions, t)
+			if n := m.topPlays[t.ID]; m.topTracksOf != "" && n > 0 {
+				b += m.theme.Dim.Render(fmt.Sprintf("  %d plays", n))
+			}
+			return b
+		}
+		tab := m.trackTable(m.tracks, maxWidth-3, m.currentAlbumID != "" && m.topTracksOf == "", badges)
 		for i, t := range m.tracks {
+			if !m.rowMatches(i) {
+				continue
+			}
+			if i == m.selection {
+				selPos = len(items)
+			}
 			prefix := "   "
 			style := m.theme.Text
 			if i == m.selection {
-				prefix = " ▶ "
-				style = selectedStyle
+				prefix, style = m.selectedRow(" ▶ ")
 			}
-			dur := "—:——"
-			if t.DurationMs > 0 {
-				dur = fmt.Sprintf("%d:%02d", t.DurationMs/60000, (t.DurationMs/1000)%60)
-			}
-			// Truncate title/artist if needed
-			// Format: "   01  Artist — Title  3:00"
-			// Fixed parts: prefix(3) + index(2) + spacing(2) + duration(6) = ~13 chars
-			// We construct line then truncate? Or truncate components?
-			// Construct line first, then truncate allows flexible spacing
-			line := fmt.Sprintf("%s%02d  %s — %s  %s", prefix, i+1, t.ArtistName, t.Title, m.theme.Dim.Render(dur))
-			if len(line) > maxWidth {
-				line = line[:maxWidth-1] + "…"
+			// The columns are fitted to the width; movements and expanded
+			// versions are cut to it
+			line := prefix + m.trackRow(tab, i, t) + badges(t)
+			if m.classical {
+				dur := "—:——"
+				if t.DurationMs > 0 {
+					dur = formatMs(t.DurationMs)
+				}
+				line = fmt.Sprintf("%s%02d  %s  %s%s", prefix, i+1, t.MovementTitle(), m.theme.Dim.Render(dur), m.providerBadge(t.ID))
+			} else if m.trackVersions.count(t) == 0 {
+				line = m.versionRow(prefix, t)
 			}
-			items = append(items, style.Render(line))
+			line = ui.Truncate(line, maxWidth)
+			items = append(items, m.renderFilteredRow(line, style))
 		}
 	} else if len(m.albums) > 0 {
-		title = fmt.Sprintf("Albums (%d)", len(m.albums))
+		count = len(m.albums)
+		grouped := !m.classical && albumsTyped(m.albums)
+		lastGroup := -1
 		for i, a := range m.albums {
+			if !m.rowMatches(i) {
+				continue
+			}
+			if g := albumGroup(a.Type); grouped && g != lastGroup {
+				items = append(items, m.albumGroupHeader(g))
+				lastGroup = g
+			}
+			if i == m.selection {
+				selPos = len(items)
+			}
 			prefix := " ▢ "
 			style := m.theme.Text
 			if i == m.selection {
-				prefix = " ▣ "
-				style = selectedStyle
+				prefix, style = m.selectedRow(" ▣ ")
 			}
-			line := fmt.Sprintf("%s%s — %s (%d)", prefix, a.Title, a.ArtistName, a.Year)
-			if len(line) > maxWidth {
-				line = line[:maxWidth-1] + "…"
+			line := fmt.Sprintf("%s%s — %s (%d)%s", prefix, a.Title, a.ArtistName, a.Year, m.providerBadge(a.ID))
+			if m.classical {
+				line = fmt.Sprintf("%s%s  (%s)%s", prefix, a.Title, m.msgs.N("count.movement", a.TrackCount), m.providerBadge(a.ID))
 			}
-			items = append(items, style.Render(line))
+			line = ui.Truncate(line, maxWidth)
+			items = append(items, m.renderFilteredRow(line, style))
 		}
 	} else {
-		title = fmt.Sprintf("Artists (%d)", len(m.artists))
 		for i, a := range m.artists {
+			if !m.rowMatches(i) {
+				continue
+			}
+			if i == m.selection {
+				selPos = len(items)
+			}
 			prefix := " ▢ "
 			style := m.theme.Text
 			if i == m.selection {
-				prefix = " ▣ "
-				style = selectedStyle
-			}
-			albumText := "albums"
-			if a.AlbumCount == 1 {
-				albumText = "album"
+				prefix, style = m.selectedRow(" ▣ ")
 			}
-			line := fmt.Sprintf("%s%s  (%d %s)", prefix, a.Name, a.AlbumCount, albumText)
-			if len(line) > maxWidth {
-				line = line[:maxWidth-1] + "…"
+			noun := "count.album"
+			if m.classical {
+				noun = "count.work"
 			}
-			items = append(items, style.Render(line))
+			line := fmt.Sprintf("%s%s  (%s)%s", prefix, a.Name, m.msgs.N(noun, a.AlbumCount), m.providerBadge(a.ID))
+			line = ui.Truncate(line, maxWidth)
+			items = append(items, m.renderFilteredRow(line, style))
 		}
 	}
 
 	// Header with view mode and pagination
-	header := title
-	b.WriteString(m.theme.Title.Render(header) + "\n")
+	info := m.theme.Dim.Render("  "+m.msgs.T("library.sort", sortLabel(m.librarySort()))) + m.yearsHeader() + m.staleHeader() + m.filterHeader(len(items))
+	b.WriteString(m.breadcrumbs(count, width-2-lipgloss.Width(info)) + info + "\n")
 
 	// Calculate visible window (show ~20 items centered on selection)
-	start = m.selection - visibleRows/2
+	start = selPos - visibleRows/2
 	if start < 0 {
 		start = 0
 	}
@@ -2411,12 +3452,15 @@ func (m Model) renderLibrary(width, height int) string {
 	for i := start; i < end; i++ {
 		listContent.WriteString(items[i] + "\n")
 	}
+	if len(items) == 0 && m.filterActive() {
+		listContent.WriteString(m.theme.Dim.Render("  No matches") + "\n")
+	}
 
 	b.WriteString(boxStyle.Render(listContent.String()))
 	b.WriteString("\n")
 
 	// Details panel for selected item
-	if len(items) > 0 && m.selection < len(items) {
+	if len(items) > 0 {
 		// Note: adding details here effectively reduces the available height for the list above if we want to stay within bounds.
 		// A proper fix would be to subtract details height from visibleRows calculation.
 		// For MVP, we'll let it be.
@@ -2434,7 +3478,7 @@ func (m Model) renderLibrary(width, height int) string {
 	}
 
 	// Action hints
-	b.WriteString("\n" + m.theme.Dim.Render("[Enter]Open/Play  [a]Add to Queue  [A]Play Next  [Backspace]Back"))
+	b.WriteString("\n" + m.theme.Dim.Render("[Enter]Open/Play  [a]Add to Queue  [A]Play Next  [/]Filter  [o]Sort  [Backspace]Back"))
 
 	return b.String()
 }
@@ -2491,8 +3535,8 @@ func (m Model) renderSearch(width, height int) string {
 		visibleRows = 1
 	}
 
-	// Max content width
-	maxWidth := width - 4
+	// Max content width: width - pane padding(2) - box padding(2) - borders(2)
+	maxWidth := width - 6
 	if maxWidth < 10 {
 		maxWidth = 10
 	}
@@ -2501,9 +3545,12 @@ func (m Model) renderSearch(width, height int) string {
 	var listContent strings.Builder
 
 	if m.searchQ == "" || itemCount == 0 {
-		if m.searchQ == "" {
+		switch {
+		case m.searchQ == "":
 			listContent.WriteString(m.theme.Dim.Render("  Enter a search query to find music"))
-		} else {
+		case m.searchPending(m.searchFilter):
+			listContent.WriteString(m.theme.Dim.Render("  Searching..."))
+		default:
 			listContent.WriteString(m.theme.Dim.Render("  No results found"))
 		}
 	} else {
@@ -2511,21 +3558,21 @@ func (m Model) renderSearch(width, height int) string {
 		var items []string
 		switch m.searchFilter {
 		case filterTracks:
+			badges := func(t provider.Track) string {
+				return m.qualityBadges(t) + m.providerBadge(t.ID) + m.versionBadge(m.searchVersions, t)
+			}
+			tab := m.trackTable(m.searchResults.Tracks.Items, maxWidth-3, false, badges)
 			for i, t := range m.searchResults.Tracks.Items {
 				prefix := "   "
 				style := m.theme.Text
 				if i == m.selection {
-					prefix = " ▶ "
-					style = selectedStyle
-				}
-				dur := "—:——"
-				if t.DurationMs > 0 {
-					dur = fmt.Sprintf("%d:%02d", t.DurationMs/60000, (t.DurationMs/1000)%60)
+					prefix, style = m.selectedRow(" ▶ ")
 				}
-				line := fmt.Sprintf("%s%02d  %s — %s  %s", prefix, i+1, t.ArtistName, t.Title, m.theme.Dim.Render(dur))
-				if len(line) > maxWidth {
-					line = line[:maxWidth-1] + "…"
+				line := prefix + m.trackRow(tab, i, t) + badges(t)
+				if m.searchVersions.count(t) == 0 {
+					line = m.versionRow(prefix, t)
 				}
+				line = ui.Truncate(line, maxWidth)
 				items = append(items, style.Render(line))
 			}
 		case filterAlbums:
@@ -2533,13 +3580,10 @@ func (m Model) renderSearch(width, height int) string {
 				prefix := " ▢ "
 				style := m.theme.Text
 				if i == m.selection {
-					prefix = " ▣ "
-					style = selectedStyle
-				}
-				line := fmt.Sprintf("%s%s — %s (%d)", prefix, a.Title, a.ArtistName, a.Year)
-				if len(line) > maxWidth {
-					line = line[:maxWidth-1] + "…"
+					prefix, style = m.selectedRow(" ▣ ")
 				}
+				line := fmt.Sprintf("%s%s — %s (%d)%s", prefix, a.Title, a.ArtistName, a.Year, m.providerBadge(a.ID))
+				line = ui.Truncate(line, maxWidth)
 				items = append(items, style.Render(line))
 			}
 		case filterArtists:
@@ -2547,13 +3591,10 @@ func (m Model) renderSearch(width, height int) string {
 				prefix := " ▢ "
 				style := m.theme.Text
 				if i == m.selection {
-					prefix = " ▣ "
-					style = selectedStyle
-				}
-				line := fmt.Sprintf("%s%s", prefix, a.Name)
-				if len(line) > maxWidth {
-					line = line[:maxWidth-1] + "…"
+					prefix, style = m.selectedRow(" ▣ ")
 				}
+				line := fmt.Sprintf("%s%s%s", prefix, a.Name, m.providerBadge(a.ID))
+				line = ui.Truncate(line, maxWidth)
 				items = append(items, style.Render(line))
 			}
 		}
@@ -2580,7 +3621,7 @@ func (m Model) renderSearch(width, height int) string {
 	b.WriteString("\n")
 
 	// Action hints
-	b.WriteString(m.theme.Dim.Render("[/]Search  [f]Cycle Filter  [Enter]Play  [a]Add to Queue  [A]Play Next"))
+	b.WriteString(m.theme.Dim.Render("[/]Search  [f]Cycle Filter  [Enter]Play  [a]Add to Queue  [A]Play Next  [v]Versions"))
 
 	return b.String()
 }
@@ -2617,7 +3658,10 @@ func (m Model) renderQueue(width, height int) string {
 	}
 
 	header += fmt.Sprintf("   Mode: %s   Shuffle: %s   Repeat: %s", modeStr, shuffleStr, repeatStr)
-	b.WriteString(m.theme.Title.Render(header) + "\n\n")
+	if m.radio != nil {
+		header += "   Radio: " + m.radio.name
+	}
+	b.WriteString(m.theme.Title.Render(header) + m.filterHeader(m.queueMatches()) + "\n\n")
 
 	// Max content width
 	// Width - 2 (MainPane Padding) - 2 (Box Border) - 2 (Box Padding) = Width - 6
@@ -2634,33 +3678,40 @@ func (m Model) renderQueue(width, height int) string {
 		listContent.WriteString(m.theme.Dim.Render("  Queue is empty. Add tracks from Library or Search."))
 	} else {
 		// Build rendered items for viewport
+		badges := func(t provider.Track) string { return m.qualityBadges(t) + m.providerBadge(t.ID) }
+		tab := m.trackTable(items, maxWidth-4, false, badges)
 		var renderedItems []string
+		selPos := 0 // position of the selected row among the rendered items
 		for i, t := range items {
+			if !m.rowMatches(i) {
+				continue
+			}
+			if i == m.selection {
+				selPos = len(renderedItems)
+			}
 			prefix := "    "
 			style := m.theme.Text
 			isPlaying := i == currentIdx
 			isSelected := i == m.selection
 
 			if isPlaying && isSelected {
-				prefix = "▶▣  " // 4 chars
-				style = selectedStyle
+				prefix, style = m.selectedRow("▶▣  ") // 4 chars
+				if m.cfg.UI.SelectionMarkers {
+					prefix = selectionMark + "▶ "
+				}
 			} else if isPlaying {
 				prefix = "▶   " // 4 chars
 				style = m.theme.Accent
 			} else if isSelected {
-				prefix = " ▣  " // 4 chars
-				style = selectedStyle
+				prefix, style = m.selectedRow(" ▣  ") // 4 chars
 			}
 
-			dur := "—:——"
-			if t.DurationMs > 0 {
-				dur = fmt.Sprintf("%d:%02d", t.DurationMs/60000, (t.DurationMs/1000)%60)
-			}
-			line := fmt.Sprintf("%s%02d  %s — %s  %s", prefix, i+1, t.ArtistName, t.Title, m.theme.Dim.Render(dur))
-			if len(line) > maxWidth {
-				line = line[:maxWidth-1] + "…"
-			}
-			renderedItems = append(renderedItems, style.Render(line))
+			line := prefix + m.trackRow(tab, i, t) + badges(t)
+			line = ui.Truncate(line, maxWidth)
+			renderedItems = append(renderedItems, m.renderFilteredRow(line, style))
+		}
+		if len(renderedItems) == 0 {
+			renderedItems = append(renderedItems, m.theme.Dim.Render("  No matches"))
 		}
 
 		// Calculate visible window based on available height
@@ -2670,7 +3721,7 @@ func (m Model) renderQueue(width, height int) string {
 			visibleRows = 1
 		}
 
-		start := m.selection - visibleRows/2
+		start := selPos - visibleRows/2
 		if start < 0 {
 			start = 0
 		}
@@ -2699,7 +3750,7 @@ func (m Model) renderQueue(width, height int) string {
 	b.WriteString("\n")
 
 	// Action hints
-	b.WriteString(m.theme.Dim.Render("[Enter]Play  [x]Remove  [C]Clear  [u/d]Move Up/Down  [P]Play Next"))
+	b.WriteString(m.theme.Dim.Render("[Enter]Play  [x]Remove  [C]Clear  [u/d]Move Up/Down  [P]Play Next  [/]Filter"))
 
 	return b.String()
 }
@@ -2712,7 +3763,7 @@ func (m Model) renderPlaylists() string {
 	if len(m.playlists) > 0 {
 		header += fmt.Sprintf("  %d/%d", m.selection+1, len(m.playlists))
 	}
-	b.WriteString(m.theme.Title.Render(header) + "\n\n")
+	b.WriteString(m.theme.Title.Render(header) + m.staleHeader() + "\n\n")
 
 	// Playlists list in a box
 	var listContent strings.Builder
@@ -2724,14 +3775,13 @@ func (m Model) renderPlaylists() string {
 			prefix := " ▢ "
 			style := m.theme.Text
 			if i == m.selection {
-				prefix = " ▣ "
-				style = selectedStyle
+				prefix, style = m.selectedRow(" ▣ ")
 			}
 			trackText := "tracks"
 			if p.TrackCount == 1 {
 				trackText = "track"
 			}
-			line := fmt.Sprintf("%s%s  (%d %s)", prefix, p.Name, p.TrackCount, trackText)
+			line := fmt.Sprintf("%s%s  (%d %s)%s", prefix, p.Name, p.TrackCount, trackText, m.providerBadge(p.ID))
 			listContent.WriteString(style.Render(line) + "\n")
 		}
 	}
@@ -2750,7 +3800,7 @@ func (m Model) renderPlaylists() string {
 	b.WriteString("\n")
 
 	// Action hints
-	b.WriteString(m.theme.Dim.Render("[Enter]Open  [A]Add All to Queue  [p]Play Playlist"))
+	b.WriteString(m.theme.Dim.Render("[Enter]Play  [→]Open  [A]Add All to Queue"))
 
 	return b.String()
 }
@@ -2831,99 +3881,127 @@ func (m Model) renderLyrics(height int) string {
 }
 
 func (m Model) renderConfig() string {
+	t := m.msgs.T
 	var b strings.Builder
-	b.WriteString(m.theme.Title.Render("Config") + "\n\n")
+	b.WriteString(m.theme.Title.Render(t("screen.config")) + "\n\n")
 
 	// Config sections list
-	sections := []struct {
-		name string
-		desc string
-	}{
-		{"Providers & Profiles", "Manage provider connections and profiles"},
-		{"Theme & ANSI", "Appearance settings"},
-		{"Keybindings", "View keybindings (view only)"},
-		{"Cache / Offline", "Cache settings and status"},
-		{"Logging & Diagnostics", "Log settings and debug info"},
+	sections := []string{
+		t("config.section.providers"),
+		t("config.section.theme"),
+		t("config.section.keys"),
+		t("config.section.cache"),
+		t("config.section.logging"),
+		t("config.section.hidden"),
+	}
+	section := m.selection
+	if m.hiddenOpen {
+		section = configHiddenSection
+	}
+	if m.cacheOpen {
+		section = configCacheSection
 	}
 
-	b.WriteString(m.theme.Accent.Render("Sections") + "\n")
+	b.WriteString(m.theme.Accent.Render(t("config.sections")) + "\n")
 	var sectionsContent strings.Builder
-	for i, s := range sections {
+	for i, name := range sections {
 		prefix := " ▢ "
 		style := m.theme.Text
-		if i == m.selection {
-			prefix = " ▣ "
-			style = selectedStyle
+		if i == section {
+			prefix, style = m.selectedRow(" ▣ ")
 		}
-		sectionsContent.WriteString(style.Render(prefix+s.name) + "\n")
+		sectionsContent.WriteString(style.Render(prefix+name) + "\n")
 	}
 	b.WriteString(boxStyle.Render(sectionsContent.String()))
 	b.WriteString("\n\n")
 
 	// Details panel based on selection
-	b.WriteString(m.theme.Accent.Render("Details") + "\n")
+	b.WriteString(m.theme.Accent.Render(t("config.details")) + "\n")
 	var detailsContent strings.Builder
 
-	switch m.selection {
+	switch section {
 	case 0: // Providers & Profiles
 		activeProfile := m.cfg.ActiveProfile
 		for _, p := range m.cfg.Profiles {
 			if p.ID == activeProfile {
-				detailsContent.WriteString(fmt.Sprintf("Active Provider: %s\n", p.Provider))
-				detailsContent.WriteString(fmt.Sprintf("Profile: %s\n", p.Name))
+				detailsContent.WriteString(t("config.active_provider", p.Provider) + "\n")
+				detailsContent.WriteString(t("config.profile", p.Name) + "\n")
 			}
 		}
-		detailsContent.WriteString(fmt.Sprintf("Total Profiles: %d\n", len(m.cfg.Profiles)))
+		detailsContent.WriteString(t("config.profiles", len(m.cfg.Profiles)) + "\n")
 
 		// Provider capabilities
 		caps := m.provider.Capabilities()
 		capList := []string{}
 		if caps[provider.CapPlaylists] {
-			capList = append(capList, "Playlists")
+			capList = append(capList, t("screen.playlists"))
 		}
 		if caps[provider.CapLyrics] {
-			capList = append(capList, "Lyrics")
+			capList = append(capList, t("screen.lyrics"))
 		}
 		if caps[provider.CapArtwork] {
-			capList = append(capList, "Artwork")
+			capList = append(capList, t("config.artwork"))
 		}
 		if len(capList) > 0 {
-			detailsContent.WriteString(fmt.Sprintf("Capabilities: %s", strings.Join(capList, ", ")))
+			detailsContent.WriteString(t("config.capabilities", strings.Join(capList, ", ")))
 		}
 
 	case 1: // Theme & ANSI
-		detailsContent.WriteString(fmt.Sprintf("Theme: %s\n", m.cfg.UI.Theme))
-		noEmoji := "No"
+		detailsContent.WriteString(t("config.theme", m.cfg.UI.Theme) + "\n")
+		noEmoji := t("config.no")
 		if m.cfg.UI.NoEmoji {
-			noEmoji = "Yes"
+			noEmoji = t("config.yes")
 		}
-		detailsContent.WriteString(fmt.Sprintf("No Emoji: %s\n", noEmoji))
-		detailsContent.WriteString(fmt.Sprintf("Page Size: %d", m.cfg.UI.PageSize))
+		detailsContent.WriteString(t("config.no_emoji", noEmoji) + "\n")
+		detailsContent.WriteString(t("config.page_size", m.cfg.UI.PageSize))
 
 	case 2: // Keybindings
-		detailsContent.WriteString("Navigation: j/k, Tab/Shift+Tab\n")
-		detailsContent.WriteString("Player: Space, n/p, h/l, +/-\n")
-		detailsContent.WriteString("Queue: x, C, u/d, P\n")
-		detailsContent.WriteString("Help: ?")
+		detailsContent.WriteString(t("help.navigation") + ": j/k, Tab/Shift+Tab\n")
+		detailsContent.WriteString(t("help.player") + ": Space, n/p, h/l, +/-\n")
+		detailsContent.WriteString(t("help.queue") + ": x, C, u/d, P\n")
+		detailsContent.WriteString(t("config.keys.help") + ": ?")
 
-	case 3: // Cache / Offline
-		detailsContent.WriteString("Cache: Not configured\n")
-		detailsContent.WriteString("(MVP: view only)")
+	case configCacheSection:
+		detailsContent.WriteString(m.renderCaches())
 
 	case 4: // Logging & Diagnostics
-		detailsContent.WriteString(fmt.Sprintf("MPV Path: %s\n", m.cfg.Player.MPVPath))
-		detailsContent.WriteString(fmt.Sprintf("Seek Small: %ds\n", m.cfg.Player.SeekSmall))
-		detailsContent.WriteString(fmt.Sprintf("Seek Large: %ds\n", m.cfg.Player.SeekLarge))
-		detailsContent.WriteString(fmt.Sprintf("Volume Step: %d%%", m.cfg.Player.VolumeStep))
+		detailsContent.WriteString(t("config.mpv_path", m.cfg.Player.MPVPath) + "\n")
+		detailsContent.WriteString(t("config.seek_small", m.cfg.Player.SeekSmall) + "\n")
+		detailsContent.WriteString(t("config.seek_large", m.cfg.Player.SeekLarge) + "\n")
+		detailsContent.WriteString(t("config.volume_step", m.cfg.Player.VolumeStep) + "\n")
+		exclusive := t("config.off")
+		if m.cfg.Player.AudioExclusive {
+			exclusive = t("config.on")
+		}
+		detailsContent.WriteString(t("config.exclusive", exclusive) + "\n")
+		trim := t("config.off")
+		if m.cfg.Player.TrimSilence {
+			trim = t("config.trim_below", m.cfg.Player.SilenceThresholdDB)
+		}
+		detailsContent.WriteString(t("config.trim_silence", trim) + "\n")
+		normalize := t("config.off")
+		if m.cfg.Player.Normalize != "" && m.cfg.Player.Normalize != "off" {
+			normalize = fmt.Sprintf("%s (%g LUFS)", m.cfg.Player.Normalize, m.cfg.Player.TargetLUFS)
+		}
+		detailsContent.WriteString(t("config.normalize", normalize))
+
+	case configHiddenSection:
+		detailsContent.WriteString(m.renderHiddenItems())
 	}
 
 	b.WriteString(boxStyle.Render(detailsContent.String()))
 	b.WriteString("\n\n")
 
 	// Footer hint
-	b.WriteString(m.theme.Dim.Render("Config file: ~/.config/tunez/config.toml"))
+	b.WriteString(m.theme.Dim.Render(t("config.file", "~/.config/tunez/config.toml")))
 	b.WriteString("\n")
-	b.WriteString(m.theme.Dim.Render("[Enter]Open Section  [Esc]Back"))
+	if m.hiddenOpen {
+		b.WriteString(m.theme.Dim.Render(t("config.hint.hidden")))
+	} else if m.cacheOpen {
+		b.WriteString(m.theme.Dim.Render(t("config.hint.cache")))
+	} else {
+		b.WriteString(m.theme.Dim.Render(t("config.hint")))
+	}
 
 	return b.String()
 }
@@ -2931,47 +4009,74 @@ func (m Model) renderConfig() string {
 func (m Model) renderHelpOverlay() string {
 	// Use configured keybindings instead of hardcoded values
 	kb := m.cfg.Keybindings
+	t := m.msgs.T
+	row := func(keys, desc string) string {
+		return "  " + ui.PadRight(keys, 13) + " : " + desc
+	}
+	seekSmall := t("help.seek", m.cfg.Player.SeekSmall, m.cfg.Player.SeekSmall)
+	seekLarge := t("help.seek", m.cfg.Player.SeekLarge, m.cfg.Player.SeekLarge)
 
 	lines := []string{
-		m.theme.Accent.Render("Global"),
-		fmt.Sprintf("  %-13s : Switch pane (nav ↔ content)", "tab"),
-		fmt.Sprintf("  %-13s : Toggle help", kb.Help),
-		fmt.Sprintf("  %-13s : Quit", kb.Quit),
+		m.theme.Accent.Render(t("help.global")),
+		row("tab", t("help.switch_pane")),
+		row(kb.Help, t("help.toggle_help")),
+		row(kb.ProfileSwitch, t("help.switch_profile")),
+		row(kb.QuickOpen, t("help.quick_open")),
+		row(kb.Quit, t("help.quit")),
 		"",
-		m.theme.Accent.Render("Player"),
-		fmt.Sprintf("  %-13s : Play/Pause", kb.PlayPause),
-		fmt.Sprintf("  %-13s : Next / Previous track", kb.NextTrack+" / "+kb.PrevTrack),
-		fmt.Sprintf("  %-13s : Seek -%ds / +%ds", kb.SeekBackward+" / "+kb.SeekForward, m.cfg.Player.SeekSmall, m.cfg.Player.SeekSmall),
-		fmt.Sprintf("  %-13s : Seek -%ds / +%ds", "H / L", m.cfg.Player.SeekLarge, m.cfg.Player.SeekLarge),
-		fmt.Sprintf("  %-13s : Volume Down / Up", kb.VolumeDown+" / "+kb.VolumeUp),
-		fmt.Sprintf("  %-13s : Mute", kb.Mute),
-		fmt.Sprintf("  %-13s : Toggle Shuffle", kb.Shuffle),
-		fmt.Sprintf("  %-13s : Cycle Repeat (off/all/one)", kb.Repeat),
+		m.theme.Accent.Render(t("help.player")),
+		row(kb.PlayPause, t("help.play_pause")),
+		row(kb.NextTrack+" / "+kb.PrevTrack, t("help.next_prev")),
+		row(kb.SeekBackward+" / "+kb.SeekForward, seekSmall),
+		row("H / L", seekLarge),
+		row(kb.VolumeDown+" / "+kb.VolumeUp, t("help.volume")),
+		row(kb.Mute, t("help.mute")),
+		row(kb.Shuffle, t("help.shuffle")),
+		row(kb.Repeat, t("help.repeat")),
+		row(kb.Love, t("help.love")),
+		row(kb.Zen, t("help.zen")),
+		row(kb.Bookmark+" / "+kb.Bookmarks, t("help.bookmark")),
+		row(kb.PrevChapter+" / "+kb.NextChapter, t("help.chapter")),
+		row(kb.Chapters, t("help.chapters")),
+		row(kb.GoToAlbum+"/"+kb.GoToArtist, t("help.go_to")),
+		row("v", t("help.visualizer")),
 		"",
-		m.theme.Accent.Render("Navigation"),
-		"  ↑/↓ or j/k    : Move up/down (context-aware)",
-		"  enter         : Select / Play / Drill down",
-		"  backspace/esc : Go back (Library)",
+		m.theme.Accent.Render(t("help.navigation")),
+		row(t("help.key.move"), t("help.move")),
+		row("enter", t("help.select")),
+		row("backspace/esc", t("help.back")),
+		row(kb.NavBack, t("help.nav_back")),
+		row(kb.NavForward, t("help.nav_forward")),
 		"",
-		m.theme.Accent.Render("Search"),
-		fmt.Sprintf("  %-13s : Enter search mode", kb.Search),
-		"  f             : Cycle filter (Tracks/Albums/Artists)",
+		m.theme.Accent.Render(t("help.search")),
+		row(kb.Search, t("help.search_key")),
+		row("f", t("help.filter")),
+		row("v", t("help.versions")),
 		"",
-		m.theme.Accent.Render("Queue"),
-		"  x             : Remove item",
-		"  u / d         : Move item up / down",
-		"  C             : Clear queue",
-		"  P             : Play next (add after current)",
+		m.theme.Accent.Render(t("help.queue")),
+		row("x", t("help.remove")),
+		row("u / d", t("help.move_item")),
+		row("C", t("help.clear_queue")),
+		row("S", t("help.shuffle_remaining")),
+		row("P", t("help.play_next")),
 		"",
-		m.theme.Accent.Render("Library"),
-		"  a             : Add to queue",
-		"  A             : Add to queue (play next)",
+		m.theme.Accent.Render(t("help.library")),
+		row("a", t("help.add")),
+		row("A", t("help.play_next_all")),
+		row("o", t("help.sort")),
+		row("y / Y", t("help.decade")),
+		row("C", t("help.classical")),
+		row(t("help.key.jump"), t("help.jump")),
+		row(kb.TrackInfo, t("help.track_info")),
+		row("I", t("help.about")),
+		row("R", t("help.similar")),
+		row("T", t("help.top_tracks")),
 		"",
-		m.theme.Dim.Render("Press ? or Esc to close"),
+		m.theme.Dim.Render(t("help.close")),
 	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
-		m.theme.Title.Render("  ═══ Help / Keybindings ═══  "),
+		m.theme.Title.Render("  ═══ "+t("help.title")+" ═══  "),
 		"",
 		strings.Join(lines, "\n"),
 	)
@@ -3004,8 +4109,8 @@ func (m Model) renderPlayerBar() string {
 	// Time and visual progress bar
 	var timeAndProgress string
 	if m.duration > 0 {
-		tPos := fmt.Sprintf("%d:%02d", int(m.timePos)/60, int(m.timePos)%60)
-		dur := fmt.Sprintf("%d:%02d", int(m.duration)/60, int(m.duration)%60)
+		tPos := formatPosition(m.timePos)
+		dur := formatPosition(m.duration)
 
 		// Visual progress bar
 		barWidth := 20
@@ -3067,26 +4172,24 @@ func (m Model) renderPlayerBar() string {
 }
 
 func (m Model) screenTitle() string {
-	switch m.screen {
-	case screenLoading:
-		return "Loading"
-	case screenNowPlaying:
-		return "Now Playing"
-	case screenLibrary:
-		return "Library"
-	case screenSearch:
-		return "Search"
-	case screenQueue:
-		return "Queue"
-	case screenPlaylists:
-		return "Playlists"
-	case screenLyrics:
-		return "Lyrics"
-	case screenConfig:
-		return "Config"
-	default:
+	if m.screen < 0 || int(m.screen) >= len(screenNames) {
 		return ""
 	}
+	return m.screenLabel(m.screen)
+}
+
+// newCatalog returns the interface text for the [ui] language, formatting
+// times with its clock and dates settings.
+func newCatalog(c config.UIConfig) i18n.Catalog {
+	msgs := i18n.New(c.Language)
+	msgs.Clock12 = c.Clock == "12h"
+	msgs.AbsoluteDates = c.Dates == "absolute"
+	return msgs
+}
+
+// screenLabel names screen s in the interface's language.
+func (m Model) screenLabel(s screen) string {
+	return m.msgs.T("screen." + screenNames[s])
 }
 
 func (m Model) currentListLen() int {
@@ -3113,8 +4216,19 @@ func (m Model) currentListLen() int {
 		return m.queue.Len()
 	case screenPlaylists:
 		return len(m.playlists)
+	case screenScrobbles:
+		if m.scrobbler == nil {
+			return 0
+		}
+		return len(m.scrobbler.PendingItems())
 	case screenConfig:
-		return 5 // Number of config sections
+		if m.hiddenOpen {
+			return len(m.hiddenItems)
+		}
+		if m.cacheOpen {
+			return len(m.caches) + 2 // and the two artwork limits
+		}
+		return configHiddenSection + 1 // Number of config sections
 	default:
 		return 0
 	}
@@ -3137,6 +4251,10 @@ func (m Model) nextScreen() screen {
 	if next == screenLyrics && !caps[provider.CapLyrics] {
 		next++
 	}
+	// Skip scrobbles when scrobbling is off
+	if next == screenScrobbles && m.scrobbler == nil {
+		next++
+	}
 	// Wrap around
 	if next > screenConfig {
 		next = screenNowPlaying
@@ -3154,6 +4272,10 @@ func (m Model) prevScreen() screen {
 	if prev <= screenLoading {
 		prev = screenConfig
 	}
+	// Skip scrobbles when scrobbling is off
+	if prev == screenScrobbles && m.scrobbler == nil {
+		prev--
+	}
 	// Skip lyrics if not supported
 	if prev == screenLyrics && !caps[provider.CapLyrics] {
 		prev--
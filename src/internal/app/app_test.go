@@ -81,7 +81,7 @@ func TestNavigation(t *testing.T) {
 
 	m := New(cfg, prov, func(p config.Profile) (provider.Provider, error) {
 		return prov, nil
-	}, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil)
+	}, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil, nil, nil, nil)
 
 	// 1. Initial State
 	if m.screen != screenLoading {
@@ -184,7 +184,7 @@ func TestNextPrevDoesNotAddToQueue(t *testing.T) {
 
 	m := New(cfg, prov, func(p config.Profile) (provider.Provider, error) {
 		return prov, nil
-	}, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil)
+	}, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil, nil, nil, nil)
 
 	// Initialize model
 	m, _ = updateModel(m, initMsg{err: nil})
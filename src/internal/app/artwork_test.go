@@ -0,0 +1,160 @@
+package app
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/artwork"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+type coverProvider struct {
+	*testProvider
+	cover []byte
+}
+
+func (p coverProvider) GetArtwork(ctx context.Context, ref string, sizePx int) (provider.Artwork, error) {
+	return provider.Artwork{Data: p.cover}, nil
+}
+
+func TestArtworkTileWithoutGraphics(t *testing.T) {
+	artwork.ForceProtocol(artwork.ProtocolText)
+	defer artwork.ResetProtocolDetection()
+
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+	for i := range img.Pix {
+		img.Pix[i] = 255 // white cover
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	m := createTestModel(t)
+	m.width, m.height = 120, 40
+	m.provider = coverProvider{newTestProvider(), buf.Bytes()}
+	track := provider.Track{ID: "t1", Title: "Come Together", AlbumTitle: "Abbey Road", ArtistName: "The Beatles", ArtworkRef: "cover"}
+
+	msg := m.fetchArtworkCmd(track)().(artworkMsg)
+	if msg.err != nil {
+		t.Fatal(msg.err)
+	}
+	if strings.Contains(msg.ansi, "\x1b[") {
+		t.Error("expected no escape codes on a terminal without graphics")
+	}
+	if !strings.Contains(msg.ansi, " AR ") || !strings.Contains(msg.ansi, "▓") {
+		t.Errorf("expected a light tile with the album initials, got:\n%s", msg.ansi)
+	}
+}
+
+// countingCoverProvider serves one cover and counts the fetches.
+type countingCoverProvider struct {
+	*testProvider
+	cover []byte
+	calls *int
+}
+
+func (p countingCoverProvider) GetArtwork(ctx context.Context, ref string, sizePx int) (provider.Artwork, error) {
+	*p.calls++
+	return provider.Artwork{Data: p.cover}, nil
+}
+
+func TestArtworkSharedByAlbum(t *testing.T) {
+	artwork.ForceProtocol(artwork.ProtocolANSI)
+	defer artwork.ResetProtocolDetection()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 8, 8))); err != nil {
+		t.Fatal(err)
+	}
+	var calls int
+	m := createTestModel(t)
+	m.cfg.ActiveProfile = "home"
+	m.cfg.Artwork.Enabled = true
+	m.provider = countingCoverProvider{newTestProvider(), buf.Bytes(), &calls}
+	cache, err := artwork.NewCache(t.TempDir(), 30, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.artworkCache = cache
+
+	// Each local track has its own ref, but the album's cover is converted
+	// and stored once
+	first := provider.Track{ID: "t1", AlbumID: "a1", ArtworkRef: "/music/Abbey Road/01.flac"}
+	second := provider.Track{ID: "t2", AlbumID: "a1", ArtworkRef: "/music/Abbey Road/02.flac"}
+	msg1 := m.renderArtworkCmd(first, 10, 5, false)().(artworkMsg)
+	if msg1.preview {
+		msg1 = m.convertArtworkCmd(first, msg1.img, 10, 5, false)().(artworkMsg)
+	}
+	msg2 := m.renderArtworkCmd(second, 10, 5, false)().(artworkMsg)
+	if msg1.err != nil || msg2.err != nil || msg2.ansi != msg1.ansi || calls != 1 {
+		t.Fatalf("expected the second track served from the album's entry, got %d fetches, %v %v", calls, msg1.err, msg2.err)
+	}
+	if msg2.ref != "album:home/a1" {
+		t.Errorf("unexpected cache ref %q", msg2.ref)
+	}
+	cache.Prune() // waits for the startup prune, then counts
+	if st := cache.Stats(); st.Entries != 1 {
+		t.Errorf("expected one cached conversion, got %+v", st)
+	}
+
+	// Moving on to the next track of the album keeps the cover on screen
+	m.nowPlaying = first
+	m, _ = updateModel(m, msg1)
+	m, _ = updateModel(m, playTrackMsg{track: second})
+	if m.artworkTrackID != "t2" || m.artworkANSI != msg1.ansi || m.artworkLoading {
+		t.Errorf("expected the album's cover kept, got track %q loading=%v", m.artworkTrackID, m.artworkLoading)
+	}
+}
+
+func TestArtworkPreviewThenFinal(t *testing.T) {
+	artwork.ForceProtocol(artwork.ProtocolANSI)
+	defer artwork.ResetProtocolDetection()
+
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7) // enough detail to blur
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	var calls int
+	m := createTestModel(t)
+	m.width, m.height = 120, 40
+	m.provider = countingCoverProvider{newTestProvider(), buf.Bytes(), &calls}
+	track := provider.Track{ID: "t1", AlbumID: "a1", ArtworkRef: "cover"}
+	m.nowPlaying = track
+	m.artworkLoading = true
+
+	// The blurred cover shows at once and its conversion follows
+	preview := m.fetchArtworkCmd(track)().(artworkMsg)
+	if !preview.preview || preview.ansi == "" {
+		t.Fatalf("expected a blurred preview first, got %+v", preview)
+	}
+	m, cmd := updateModel(m, preview)
+	if m.artworkANSI != preview.ansi || !m.artworkLoading || cmd == nil {
+		t.Fatalf("expected the preview shown while loading, got loading=%v", m.artworkLoading)
+	}
+	final := cmd().(artworkMsg)
+	if final.preview || final.err != nil || final.ansi == preview.ansi {
+		t.Fatalf("expected the full conversion, got %+v", final)
+	}
+	m, _ = updateModel(m, final)
+	if m.artworkANSI != final.ansi || m.artworkLoading || calls != 1 {
+		t.Errorf("expected the final cover swapped in from one fetch, got loading=%v after %d fetches", m.artworkLoading, calls)
+	}
+	if strings.Count(preview.ansi, "\n") != strings.Count(final.ansi, "\n") {
+		t.Error("expected the preview the same height as the cover it stands in for")
+	}
+
+	// A preview for a track no longer playing is dropped
+	m.nowPlaying = provider.Track{ID: "t2"}
+	if _, cmd := updateModel(m, preview); cmd != nil {
+		t.Error("expected no conversion for a track skipped past")
+	}
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// artworkWorkers is how many artwork fetches and conversions run at once.
+const artworkWorkers = 2
+
+// artworkJobKey identifies one conversion: a cache ref drawn at a size,
+// either as the quick preview or in full.
+type artworkJobKey struct {
+	ref           string
+	width, height int
+	preview       bool
+}
+
+// artworkJob is a conversion in flight, shared by everyone waiting on it.
+type artworkJob struct {
+	done   chan struct{}
+	msg    artworkMsg
+	cancel context.CancelFunc
+}
+
+// artworkLoader runs the artwork fetches and conversions: at most
+// artworkWorkers at once, one per ref and size however many tracks ask for
+// it, and none for a ref no longer wanted. Skipping quickly through tracks
+// cancels the covers skipped past instead of leaving each to run out its
+// timeout.
+type artworkLoader struct {
+	slots chan struct{}
+
+	mu   sync.Mutex
+	jobs map[artworkJobKey]*artworkJob
+}
+
+func newArtworkLoader() *artworkLoader {
+	return &artworkLoader{
+		slots: make(chan struct{}, artworkWorkers),
+		jobs:  make(map[artworkJobKey]*artworkJob),
+	}
+}
+
+// load starts the conversion for key, or joins the one already running,
+// and cancels those for other refs. It returns a wait for the result. It
+// is called from Update, so a new track cancels the old one's cover at
+// once, before the returned wait is run as a command.
+func (l *artworkLoader) load(key artworkJobKey, render func(context.Context) artworkMsg) func() artworkMsg {
+	if l == nil {
+		return func() artworkMsg {
+			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+			defer cancel()
+			return render(ctx)
+		}
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for k, job := range l.jobs {
+		if k.ref != key.ref {
+			job.cancel()
+			delete(l.jobs, k)
+		}
+	}
+	job, ok := l.jobs[key]
+	if !ok {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		job = &artworkJob{done: make(chan struct{}), cancel: cancel}
+		l.jobs[key] = job
+		go l.run(ctx, key, job, render)
+	}
+	return func() artworkMsg {
+		<-job.done
+		return job.msg
+	}
+}
+
+// run waits for a free slot, then renders.
+func (l *artworkLoader) run(ctx context.Context, key artworkJobKey, job *artworkJob, render func(context.Context) artworkMsg) {
+	defer close(job.done)
+	defer job.cancel()
+	defer func() {
+		l.mu.Lock()
+		if l.jobs[key] == job {
+			delete(l.jobs, key)
+		}
+		l.mu.Unlock()
+	}()
+	select {
+	case l.slots <- struct{}{}:
+		defer func() { <-l.slots }()
+	case <-ctx.Done():
+		job.msg = artworkMsg{ref: key.ref, err: ctx.Err()}
+		return
+	}
+	job.msg = render(ctx)
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestArtworkLoaderSharesOneConversion(t *testing.T) {
+	l := newArtworkLoader()
+	var calls atomic.Int32
+	release := make(chan struct{})
+	render := func(context.Context) artworkMsg {
+		calls.Add(1)
+		<-release
+		return artworkMsg{ansi: "cover"}
+	}
+
+	key := artworkJobKey{ref: "album:home/a1", width: 20, height: 10}
+	first, second := l.load(key, render), l.load(key, render)
+	close(release)
+	if a, b := first(), second(); a.ansi != "cover" || b.ansi != "cover" || calls.Load() != 1 {
+		t.Errorf("expected one conversion for both, got %d and %+v %+v", calls.Load(), a, b)
+	}
+}
+
+func TestArtworkLoaderCancelsOtherRefs(t *testing.T) {
+	l := newArtworkLoader()
+	started := make(chan struct{})
+	skipped := l.load(artworkJobKey{ref: "album:home/a1", width: 20, height: 10}, func(ctx context.Context) artworkMsg {
+		close(started)
+		<-ctx.Done()
+		return artworkMsg{err: ctx.Err()}
+	})
+	<-started
+
+	// The next track's cover cancels the one skipped past
+	next := l.load(artworkJobKey{ref: "album:home/a2", width: 20, height: 10}, func(context.Context) artworkMsg {
+		return artworkMsg{ansi: "next"}
+	})
+	if msg := skipped(); !errors.Is(msg.err, context.Canceled) {
+		t.Errorf("expected the skipped cover cancelled, got %+v", msg)
+	}
+	if msg := next(); msg.ansi != "next" {
+		t.Errorf("expected the next cover drawn, got %+v", msg)
+	}
+}
+
+func TestArtworkLoaderBoundsConcurrency(t *testing.T) {
+	l := newArtworkLoader()
+	var mu sync.Mutex
+	running, most := 0, 0
+	release := make(chan struct{})
+	render := func(context.Context) artworkMsg {
+		mu.Lock()
+		running++
+		most = max(most, running)
+		mu.Unlock()
+		<-release
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return artworkMsg{ansi: "cover"}
+	}
+
+	// One cover at several sizes, as Now Playing and zen mode draw it
+	var waits []func() artworkMsg
+	for size := 10; size <= 40; size += 10 {
+		waits = append(waits, l.load(artworkJobKey{ref: "album:home/a1", width: size, height: size / 2}, render))
+	}
+	for {
+		mu.Lock()
+		full := running == artworkWorkers
+		mu.Unlock()
+		if full {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	close(release)
+	for _, wait := range waits {
+		if msg := wait(); msg.ansi != "cover" {
+			t.Errorf("expected every size drawn, got %+v", msg)
+		}
+	}
+	if most > artworkWorkers {
+		t.Errorf("expected at most %d conversions at once, got %d", artworkWorkers, most)
+	}
+}
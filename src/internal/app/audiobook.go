@@ -0,0 +1,213 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"math"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// bookEndSeconds is how close to its end an audiobook track counts as
+// finished, so it starts over rather than resuming in the last seconds.
+const bookEndSeconds = 5.0
+
+// trackPositionMsg carries the saved position of an audiobook track.
+type trackPositionMsg struct {
+	trackID  string
+	position float64
+	err      error
+}
+
+// isAudiobook reports whether t is an audiobook track, by its genre or a
+// folder its file is in.
+func (m Model) isAudiobook(t provider.Track) bool {
+	cfg := m.cfg.Audiobooks
+	if t.Genre != "" {
+		for _, g := range cfg.Genres {
+			if strings.EqualFold(strings.TrimSpace(t.Genre), g) {
+				return true
+			}
+		}
+	}
+	if t.FilePath == "" || len(cfg.Folders) == 0 {
+		return false
+	}
+	for dir := filepath.Dir(t.FilePath); ; dir = filepath.Dir(dir) {
+		for _, pattern := range cfg.Folders {
+			if ok, _ := filepath.Match(filepath.Clean(expandHome(pattern)), dir); ok {
+				return true
+			}
+		}
+		if parent := filepath.Dir(dir); parent == dir {
+			return false
+		}
+	}
+}
+
+// playbackSpeed returns the speed t plays at: the audiobook speed for
+// audiobooks, normal otherwise.
+func (m Model) playbackSpeed(t provider.Track) float64 {
+	if sp := m.cfg.Audiobooks.Speed; sp > 0 && m.isAudiobook(t) {
+		return sp
+	}
+	return 1
+}
+
+// setSpeedCmd changes the playback speed in mpv.
+func (m Model) setSpeedCmd(speed float64) tea.Cmd {
+	if m.player == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := m.player.SetSpeed(speed); err != nil {
+			return playerMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// bookPosition returns where to resume the playing audiobook track, or 0
+// once it has been played to the end.
+func (m Model) bookPosition() float64 {
+	if m.duration > 0 && m.timePos >= m.duration-bookEndSeconds {
+		return 0
+	}
+	return m.timePos
+}
+
+// saveBookPositionCmd records the position in an audiobook track.
+func (m Model) saveBookPositionCmd(trackID string, position float64) tea.Cmd {
+	if m.queueStore == nil || trackID == "" {
+		return nil
+	}
+	store, profileID := m.queueStore, m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := store.SaveTrackPosition(ctx, profileID, trackID, position); err != nil {
+			m.logger.Warn("save audiobook position", slog.String("track_id", trackID), slog.Any("err", err))
+		}
+		return nil
+	}
+}
+
+// loadBookPositionCmd loads where an audiobook track was left.
+func (m Model) loadBookPositionCmd(trackID string) tea.Cmd {
+	if m.queueStore == nil || trackID == "" {
+		return nil
+	}
+	store, profileID := m.queueStore, m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		pos, err := store.TrackPosition(ctx, profileID, trackID)
+		return trackPositionMsg{trackID: trackID, position: pos, err: err}
+	}
+}
+
+// handleTrackPosition resumes the playing audiobook track where it was
+// left, once it has loaded, unless a Continue Listening resume already
+// picked a position.
+func (m Model) handleTrackPosition(msg trackPositionMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("load audiobook position", slog.String("track_id", msg.trackID), slog.Any("err", msg.err))
+		return m, nil
+	}
+	if msg.trackID != m.nowPlaying.ID || msg.position <= 0 || m.pendingSeekTrackID != "" {
+		return m, nil
+	}
+	m.pendingSeek = msg.position
+	m.pendingSeekTrackID = msg.trackID
+	m.bookSavedPos = msg.position
+	m.status = m.msgs.T("audiobook.resuming", formatPosition(msg.position))
+	return m, nil
+}
+
+// startTrackPolicy applies the audiobook rules when t starts playing: it
+// saves where the audiobook track before it was left, sets the speed and
+// looks up where t was left. It runs before m.nowPlaying becomes t.
+func (m *Model) startTrackPolicy(t provider.Track) tea.Cmd {
+	var cmds []tea.Cmd
+	if m.audiobook && m.nowPlaying.ID != "" && m.nowPlaying.ID != t.ID {
+		cmds = append(cmds, m.saveBookPositionCmd(m.nowPlaying.ID, m.bookPosition()))
+	}
+	m.audiobook = m.isAudiobook(t)
+	m.bookSavedPos = 0
+	if sp := m.playbackSpeed(t); sp != m.speed {
+		m.speed = sp
+		cmds = append(cmds, m.setSpeedCmd(sp))
+	}
+	if m.audiobook {
+		cmds = append(cmds, m.loadBookPositionCmd(t.ID))
+	}
+	return tea.Batch(cmds...)
+}
+
+// trackBookPosition saves the position in the playing audiobook track as
+// playback moves on or pauses.
+func (m *Model) trackBookPosition(paused bool) tea.Cmd {
+	if !m.audiobook || m.nowPlaying.ID == "" || m.pendingSeekTrackID != "" {
+		return nil
+	}
+	if math.Abs(m.timePos-m.bookSavedPos) < contextSaveInterval && !paused {
+		return nil
+	}
+	m.bookSavedPos = m.timePos
+	return m.saveBookPositionCmd(m.nowPlaying.ID, m.bookPosition())
+}
+
+// bookOrder puts the tracks of an audiobook in the order of their files,
+// chapter 2 before chapter 10, since audiobook files often carry no or
+// conflicting track numbers. Other track lists are returned as they are.
+func (m Model) bookOrder(tracks []provider.Track) []provider.Track {
+	if len(tracks) == 0 {
+		return tracks
+	}
+	for _, t := range tracks {
+		if t.FilePath == "" || !m.isAudiobook(t) {
+			return tracks
+		}
+	}
+	slices.SortStableFunc(tracks, func(a, b provider.Track) int {
+		return naturalCompare(a.FilePath, b.FilePath)
+	})
+	return tracks
+}
+
+// naturalCompare compares strings with runs of digits ordered by value.
+func naturalCompare(a, b string) int {
+	for a != "" && b != "" {
+		da, db := digitPrefix(a), digitPrefix(b)
+		if da != "" && db != "" {
+			na, nb := strings.TrimLeft(da, "0"), strings.TrimLeft(db, "0")
+			if c := len(na) - len(nb); c != 0 {
+				return c
+			}
+			if c := strings.Compare(na, nb); c != 0 {
+				return c
+			}
+			a, b = a[len(da):], b[len(db):]
+			continue
+		}
+		if a[0] != b[0] {
+			return int(a[0]) - int(b[0])
+		}
+		a, b = a[1:], b[1:]
+	}
+	return len(a) - len(b)
+}
+
+// digitPrefix returns the leading digits of s.
+func digitPrefix(s string) string {
+	i := 0
+	for i < len(s) && s[i] >= '0' && s[i] <= '9' {
+		i++
+	}
+	return s[:i]
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestAudiobookDetection(t *testing.T) {
+	m := createTestModel(t)
+	m.cfg.Audiobooks = config.AudiobooksConfig{Folders: []string{"/media/*/Audiobooks"}, Genres: []string{"Audiobook"}, Speed: 1.25}
+
+	tests := []struct {
+		track provider.Track
+		want  bool
+	}{
+		{provider.Track{Genre: "audiobook"}, true},
+		{provider.Track{Genre: "Rock", FilePath: "/media/usb/Audiobooks/Dune/01.mp3"}, true},
+		{provider.Track{FilePath: "/media/usb/Audiobooks/01.mp3"}, true},
+		{provider.Track{Genre: "Rock", FilePath: "/media/usb/Music/Dune/01.mp3"}, false},
+		{provider.Track{}, false},
+	}
+	for _, tt := range tests {
+		if got := m.isAudiobook(tt.track); got != tt.want {
+			t.Errorf("isAudiobook(%+v) = %v, want %v", tt.track, got, tt.want)
+		}
+	}
+	if sp := m.playbackSpeed(provider.Track{Genre: "Audiobook"}); sp != 1.25 {
+		t.Errorf("expected audiobooks at 1.25x, got %v", sp)
+	}
+	if sp := m.playbackSpeed(provider.Track{Genre: "Rock"}); sp != 1 {
+		t.Errorf("expected music at normal speed, got %v", sp)
+	}
+
+	// Chapter files keep their order whatever their track numbers
+	book := []provider.Track{
+		{ID: "c10", TrackNo: 1, Genre: "Audiobook", FilePath: "/b/Chapter 10.mp3"},
+		{ID: "c2", TrackNo: 1, Genre: "Audiobook", FilePath: "/b/Chapter 2.mp3"},
+		{ID: "c1", TrackNo: 1, Genre: "Audiobook", FilePath: "/b/Chapter 01.mp3"},
+	}
+	var ids []string
+	for _, tr := range m.bookOrder(book) {
+		ids = append(ids, tr.ID)
+	}
+	if strings.Join(ids, ",") != "c1,c2,c10" {
+		t.Errorf("expected chapters in file order, got %v", ids)
+	}
+}
+
+func TestAudiobookResume(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.cfg.Audiobooks = config.AudiobooksConfig{Genres: []string{"Audiobook"}, Speed: 1.25}
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+	if err := store.SaveTrackPosition(context.Background(), m.cfg.ActiveProfile, "ch1", 754); err != nil {
+		t.Fatal(err)
+	}
+
+	chapter := provider.Track{ID: "ch1", Title: "Chapter 1", Genre: "Audiobook", DurationMs: 1800000}
+	m, _ = updateModel(m, playTrackMsg{track: chapter})
+	if !m.audiobook || m.speed != 1.25 {
+		t.Fatalf("expected the audiobook policy, got audiobook=%v speed=%v", m.audiobook, m.speed)
+	}
+	if !strings.Contains(m.renderNowPlaying(), "Audiobook: 1.25x") {
+		t.Error("expected the audiobook line on Now Playing")
+	}
+
+	m, _ = updateModel(m, m.loadBookPositionCmd("ch1")())
+	if m.pendingSeekTrackID != "ch1" || m.pendingSeek != 754 {
+		t.Fatalf("expected a resume at 754s, got %q %v", m.pendingSeekTrackID, m.pendingSeek)
+	}
+
+	// Moving on saves where the chapter was left, and music plays at 1x
+	m.pendingSeekTrackID = ""
+	m.timePos, m.duration = 900, 1800
+	next := m
+	cmd := next.startTrackPolicy(provider.Track{ID: "100", Title: "Come Together"})
+	if next.audiobook || next.speed != 1 || cmd == nil {
+		t.Fatalf("expected the music policy, got audiobook=%v speed=%v", next.audiobook, next.speed)
+	}
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok || len(batch) != 2 {
+		t.Fatalf("expected a save and a speed change, got %#v", batch)
+	}
+	batch[0]()
+	if pos, _ := store.TrackPosition(context.Background(), m.cfg.ActiveProfile, "ch1"); pos != 900 {
+		t.Errorf("expected the position saved, got %v", pos)
+	}
+
+	// A chapter played to its end starts over
+	m.timePos = 1798
+	if pos := m.bookPosition(); pos != 0 {
+		t.Errorf("expected a finished chapter to start over, got %v", pos)
+	}
+}
@@ -0,0 +1,58 @@
+package app
+
+import (
+	"log/slog"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sleepMsg is sent when the system is going to sleep.
+type sleepMsg struct{}
+
+// waitSleepCmd waits for the next suspend, when [player.auto_pause]
+// suspend is on.
+func (m Model) waitSleepCmd() tea.Cmd {
+	if m.sleep == nil {
+		return nil
+	}
+	sleep := m.sleep
+	return func() tea.Msg {
+		if _, ok := <-sleep; !ok {
+			return nil
+		}
+		return sleepMsg{}
+	}
+}
+
+// handleSleep pauses playback before the system suspends, so it doesn't
+// pick up again on wake-up.
+func (m Model) handleSleep() (Model, tea.Cmd) {
+	if m.nowPlaying.ID == "" || m.paused {
+		return m, m.waitSleepCmd()
+	}
+	m.logger.Info("system going to sleep, pausing")
+	m, cmd := m.pause()
+	m.status = m.msgs.T("autopause.sleep")
+	return m, tea.Batch(cmd, m.waitSleepCmd())
+}
+
+// audioDevicesChanged pauses playback when [player.auto_pause]
+// device_removed is on and an audio output mpv could see is gone, such as
+// headphones being unplugged.
+func (m Model) audioDevicesChanged(devices []string) (Model, tea.Cmd) {
+	prev := m.audioDevices
+	m.audioDevices = devices
+	if !m.cfg.Player.AutoPause.DeviceRemoved || prev == nil || m.nowPlaying.ID == "" || m.paused {
+		return m, nil
+	}
+	for _, d := range prev {
+		if !slices.Contains(devices, d) {
+			m.logger.Info("audio device removed, pausing", slog.String("device", d))
+			m, cmd := m.pause()
+			m.status = m.msgs.T("autopause.device", d)
+			return m, cmd
+		}
+	}
+	return m, nil
+}
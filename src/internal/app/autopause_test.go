@@ -0,0 +1,51 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/tunez/tunez/internal/player"
+)
+
+func TestAutoPauseOnDeviceRemoved(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.cfg.Player.AutoPause.DeviceRemoved = true
+	m.nowPlaying = prov.tracks[0]
+
+	devices := []string{"auto", "pulse/speakers", "pulse/headphones"}
+	m, _ = updateModel(m, playerMsg(player.Event{AudioDevices: &devices}))
+	if m.paused {
+		t.Fatal("expected the first device list not to pause")
+	}
+	// A device appearing doesn't pause either
+	devices = append(devices, "pulse/hdmi")
+	m, _ = updateModel(m, playerMsg(player.Event{AudioDevices: &devices}))
+	if m.paused {
+		t.Fatal("expected a new device not to pause")
+	}
+
+	unplugged := []string{"auto", "pulse/speakers", "pulse/hdmi"}
+	m, cmd := updateModel(m, playerMsg(player.Event{AudioDevices: &unplugged}))
+	if !m.paused || cmd == nil || m.status != "Paused: audio device removed (pulse/headphones)" {
+		t.Errorf("expected playback paused, got paused=%v status %q", m.paused, m.status)
+	}
+}
+
+func TestAutoPauseOff(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.nowPlaying = prov.tracks[0]
+
+	devices := []string{"auto", "pulse/headphones"}
+	m, _ = updateModel(m, playerMsg(player.Event{AudioDevices: &devices}))
+	devices = devices[:1]
+	m, _ = updateModel(m, playerMsg(player.Event{AudioDevices: &devices}))
+	if m.paused {
+		t.Error("expected no auto-pause unless device_removed is set")
+	}
+
+	m, _ = updateModel(m, sleepMsg{})
+	if !m.paused || m.status != "Paused: system went to sleep" {
+		t.Errorf("expected a suspend to pause, got paused=%v status %q", m.paused, m.status)
+	}
+}
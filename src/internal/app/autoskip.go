@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// skipRules are the [queue.skip] rules, ready to match tracks against.
+type skipRules struct {
+	shorterThan time.Duration
+	titles      []titlePattern
+	playedToday bool
+}
+
+type titlePattern struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+func newSkipRules(cfg config.SkipConfig) skipRules {
+	r := skipRules{
+		shorterThan: time.Duration(cfg.ShorterThanSeconds) * time.Second,
+		playedToday: cfg.PlayedToday,
+	}
+	for _, p := range cfg.TitlePatterns {
+		// Patterns are checked by config.Validate
+		if re, err := regexp.Compile("(?i)" + p); err == nil {
+			r.titles = append(r.titles, titlePattern{pattern: p, re: re})
+		}
+	}
+	return r
+}
+
+// lastPlaysMsg carries the tracks played today, for the played_today rule.
+type lastPlaysMsg struct {
+	plays queue.LastPlays
+	err   error
+}
+
+// loadLastPlaysCmd loads the tracks played since midnight when the
+// played_today rule is on.
+func (m Model) loadLastPlaysCmd() tea.Cmd {
+	if !m.skip.playedToday || m.queueStore == nil {
+		return nil
+	}
+	store := m.queueStore
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		plays, err := store.LastPlays(ctx, startOfDay(time.Now()))
+		return lastPlaysMsg{plays: plays, err: err}
+	}
+}
+
+func (m Model) handleLastPlays(msg lastPlaysMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("load last plays", slog.Any("err", msg.err))
+		return m, nil
+	}
+	// Keep plays counted while the load was running
+	for k, at := range m.lastPlays {
+		msg.plays[k] = at
+	}
+	m.lastPlays = msg.plays
+	return m, nil
+}
+
+// startOfDay is local midnight on t's day.
+func startOfDay(t time.Time) time.Time {
+	y, mo, d := t.Date()
+	return time.Date(y, mo, d, 0, 0, 0, 0, t.Location())
+}
+
+// skipReason returns why the [queue.skip] rules pass over t, or "" to
+// play it.
+func (m Model) skipReason(t provider.Track) string {
+	if d := time.Duration(t.DurationMs) * time.Millisecond; d > 0 && d < m.skip.shorterThan {
+		return fmt.Sprintf("shorter than %s", m.skip.shorterThan)
+	}
+	for _, tp := range m.skip.titles {
+		if tp.re.MatchString(t.Title) {
+			return fmt.Sprintf("title matches %q", tp.pattern)
+		}
+	}
+	if m.skip.playedToday && m.lastPlays.PlayedSince(t.ArtistName, t.Title, startOfDay(time.Now())) {
+		return "played today"
+	}
+	return ""
+}
+
+// nextTrack advances the queue to the next track the [queue.skip] rules
+// let through. Skipped tracks are noted in the status line. Repeat-one
+// plays the track again regardless.
+func (m Model) nextTrack() (Model, provider.Track, error) {
+	t, err := m.queue.Next()
+	if err != nil || m.queue.RepeatMode() == queue.RepeatOne {
+		return m, t, err
+	}
+	skipped := 0
+	for range m.queue.Len() {
+		reason := m.skipReason(t)
+		if reason == "" {
+			break
+		}
+		m.logger.Debug("auto-skipping track", slog.String("track_id", t.ID), slog.String("title", t.Title), slog.String("reason", reason))
+		if skipped++; skipped == 1 {
+			m.status = m.msgs.T("autoskip.skipped", t.Title, reason)
+		} else {
+			m.status = m.msgs.T("autoskip.skipped_many", skipped)
+		}
+		if t, err = m.queue.Next(); err != nil {
+			return m, t, err
+		}
+	}
+	if skipped >= m.queue.Len() {
+		// Every track in a repeating queue is skipped
+		return m, provider.Track{}, errors.New("every track in the queue is skipped")
+	}
+	return m, t, nil
+}
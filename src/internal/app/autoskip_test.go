@@ -0,0 +1,59 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestAutoSkip(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.skip = newSkipRules(config.SkipConfig{ShorterThanSeconds: 30, TitlePatterns: []string{`^intro\b`, "skit"}, PlayedToday: true})
+	m.lastPlays = queue.LastPlays{}
+	m.lastPlays.Add("Abba", "Waterloo", time.Now())
+	m.lastPlays.Add("Abba", "Fernando", time.Now().AddDate(0, 0, -1))
+
+	m.queue.Clear()
+	m.queue.Add(
+		provider.Track{ID: "1", Title: "Opener", ArtistName: "Abba", DurationMs: 200000},
+		provider.Track{ID: "2", Title: "Intro (Reprise)", ArtistName: "Abba", DurationMs: 90000},
+		provider.Track{ID: "3", Title: "Hidden", ArtistName: "Abba", DurationMs: 12000},
+		provider.Track{ID: "4", Title: "Waterloo", ArtistName: "ABBA", DurationMs: 170000},
+		provider.Track{ID: "5", Title: "Fernando", ArtistName: "Abba", DurationMs: 250000},
+		provider.Track{ID: "6", Title: "The Skit", ArtistName: "Abba"},
+	)
+
+	m, next, err := m.nextTrack()
+	if err != nil || next.ID != "5" || m.status != "Skipped 3 tracks" {
+		t.Fatalf("expected tracks 2-4 skipped for Fernando, got %q %v, status %q", next.ID, err, m.status)
+	}
+	m, next, err = m.nextTrack()
+	if err == nil || m.status != `Skipped "The Skit": title matches "skit"` {
+		t.Errorf("expected the skit skipped to the end of the queue, got %q %v, status %q", next.ID, err, m.status)
+	}
+
+	// Repeat-one plays the track again whatever the rules say
+	m.queue.SetRepeat(queue.RepeatOne)
+	if _, next, _ = m.nextTrack(); next.ID != "6" {
+		t.Errorf("expected repeat-one to replay the skit, got %q", next.ID)
+	}
+	// A repeating queue where every track is skipped stops
+	m.queue.Clear()
+	m.queue.Add(provider.Track{ID: "2", Title: "Intro"}, provider.Track{ID: "6", Title: "Skit"})
+	m.queue.SetRepeat(queue.RepeatAll)
+	if _, next, err = m.nextTrack(); err == nil {
+		t.Errorf("expected an error when every track is skipped, got %q", next.ID)
+	}
+}
+
+func TestAutoSkipOff(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.queue.Clear()
+	m.queue.Add(provider.Track{ID: "1", Title: "Opener"}, provider.Track{ID: "2", Title: "Intro", DurationMs: 5000})
+	if _, next, err := m.nextTrack(); err != nil || next.ID != "2" {
+		t.Errorf("expected no tracks skipped without rules, got %q %v", next.ID, err)
+	}
+}
@@ -0,0 +1,184 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/i18n"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// bookmarksMsg carries the bookmarks of a track after loading or changing
+// them.
+type bookmarksMsg struct {
+	trackID string
+	items   []queue.Bookmark
+	err     error
+}
+
+// loadBookmarksCmd loads the bookmarks of a track from the local store.
+func (m Model) loadBookmarksCmd(trackID string) tea.Cmd {
+	if m.queueStore == nil || trackID == "" {
+		return nil
+	}
+	store, profileID := m.queueStore, m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		items, err := store.Bookmarks(ctx, profileID, trackID)
+		return bookmarksMsg{trackID: trackID, items: items, err: err}
+	}
+}
+
+// handleBookmarks stores loaded bookmarks for the playing track and the
+// track info popup.
+func (m Model) handleBookmarks(msg bookmarksMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if msg.trackID == m.nowPlaying.ID {
+		m.bookmarks = msg.items
+		m.bookmarkSel = clamp(m.bookmarkSel, 0, max(len(m.bookmarks)-1, 0))
+		if len(m.bookmarks) == 0 {
+			m.showBookmarks = false
+		}
+	}
+	if msg.trackID == m.trackInfo.ID {
+		m.trackInfoBookmarks = msg.items
+	}
+	return m, nil
+}
+
+// addBookmark saves the current position in the playing track.
+func (m Model) addBookmark() (Model, tea.Cmd) {
+	if m.nowPlaying.ID == "" {
+		m.status = m.msgs.T("status.nothing_playing")
+		return m, nil
+	}
+	if m.queueStore == nil {
+		m.status = m.msgs.T("bookmarks.needs_persist")
+		return m, nil
+	}
+	b := queue.Bookmark{ProfileID: m.cfg.ActiveProfile, TrackID: m.nowPlaying.ID, Position: math.Floor(m.timePos)}
+	m.logger.Debug("adding bookmark", slog.String("track_id", b.TrackID), slog.Float64("position", b.Position))
+	m.status = m.msgs.T("bookmarks.added", formatPosition(b.Position))
+	store := m.queueStore
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := store.AddBookmark(ctx, b); err != nil {
+			return bookmarksMsg{trackID: b.TrackID, err: err}
+		}
+		items, err := store.Bookmarks(ctx, b.ProfileID, b.TrackID)
+		return bookmarksMsg{trackID: b.TrackID, items: items, err: err}
+	}
+}
+
+// openBookmarks shows the bookmarks of the playing track to jump to.
+func (m Model) openBookmarks() (Model, tea.Cmd) {
+	if m.nowPlaying.ID == "" {
+		m.status = m.msgs.T("status.nothing_playing")
+		return m, nil
+	}
+	if len(m.bookmarks) == 0 {
+		m.status = m.msgs.T("bookmarks.none", m.cfg.Keybindings.Bookmark)
+		return m, nil
+	}
+	m.showBookmarks = true
+	// Start from the last bookmark before the current position
+	m.bookmarkSel = 0
+	for i, b := range m.bookmarks {
+		if b.Position <= m.timePos {
+			m.bookmarkSel = i
+		}
+	}
+	return m, nil
+}
+
+// handleBookmarksKey handles keys while the bookmark list is open.
+func (m Model) handleBookmarksKey(key string) (Model, tea.Cmd) {
+	switch {
+	case key == "esc" || key == "q" || matchKey(key, m.cfg.Keybindings.Bookmarks):
+		m.showBookmarks = false
+	case key == "up" || key == "k":
+		if m.bookmarkSel > 0 {
+			m.bookmarkSel--
+		}
+	case key == "down" || key == "j":
+		if m.bookmarkSel < len(m.bookmarks)-1 {
+			m.bookmarkSel++
+		}
+	case key == "enter":
+		b := m.bookmarks[clamp(m.bookmarkSel, 0, len(m.bookmarks)-1)]
+		m.showBookmarks = false
+		m.logger.Debug("jumping to bookmark", slog.Float64("position", b.Position), slog.Float64("from", m.timePos))
+		m.status = m.msgs.T("bookmarks.jumping", formatPosition(b.Position))
+		return m, m.seekCmd(b.Position - m.timePos)
+	case key == "d" || key == "x":
+		b := m.bookmarks[clamp(m.bookmarkSel, 0, len(m.bookmarks)-1)]
+		m.status = m.msgs.T("bookmarks.removed", formatPosition(b.Position))
+		store := m.queueStore
+		return m, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			if err := store.DeleteBookmark(ctx, b.ProfileID, b.TrackID, b.Position); err != nil {
+				return bookmarksMsg{trackID: b.TrackID, err: err}
+			}
+			items, err := store.Bookmarks(ctx, b.ProfileID, b.TrackID)
+			return bookmarksMsg{trackID: b.TrackID, items: items, err: err}
+		}
+	}
+	return m, nil
+}
+
+// renderBookmarks renders the bookmark list popup.
+func (m Model) renderBookmarks() string {
+	var lines []string
+	for i, b := range m.bookmarks {
+		prefix := "   "
+		style := m.theme.Text
+		if i == m.bookmarkSel {
+			prefix, style = m.selectedRow(" ▶ ")
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s%s", prefix, formatPosition(b.Position))))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Bookmarks ═══  "),
+		m.theme.Dim.Render("  "+m.nowPlaying.Title),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.theme.Dim.Render("[Enter]Jump  [d]Delete  [Esc]Close"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
+
+// bookmarkTicks returns the progress bar cells barWidth wide that fall on a
+// bookmark of the playing track.
+func (m Model) bookmarkTicks(barWidth int) map[int]bool {
+	if m.duration <= 0 || len(m.bookmarks) == 0 {
+		return nil
+	}
+	ticks := make(map[int]bool, len(m.bookmarks))
+	for _, b := range m.bookmarks {
+		ticks[min(int(b.Position/m.duration*float64(barWidth)), barWidth-1)] = true
+	}
+	return ticks
+}
+
+// formatPosition formats seconds as m:ss, or h:mm:ss from an hour.
+func formatPosition(secs float64) string {
+	return i18n.Duration(time.Duration(secs * float64(time.Second)))
+}
+
+// formatMs formats a track length in milliseconds like formatPosition.
+func formatMs(ms int) string {
+	return i18n.Duration(time.Duration(ms) * time.Millisecond)
+}
@@ -0,0 +1,86 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestBookmarks(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+	m.nowPlaying = provider.Track{ID: "mix", Title: "Essential Mix"}
+	m.duration = 7200
+
+	key := func(k string) tea.KeyMsg {
+		if k == "enter" {
+			return tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+	bookmarkAt := func(pos float64) {
+		t.Helper()
+		m.timePos = pos
+		var cmd tea.Cmd
+		m, cmd = updateModel(m, key("b"))
+		if cmd == nil {
+			t.Fatal("expected a command saving the bookmark")
+		}
+		m, _ = updateModel(m, cmd())
+	}
+	bookmarkAt(3725.6)
+	bookmarkAt(600)
+	if len(m.bookmarks) != 2 || m.bookmarks[0].Position != 600 || m.bookmarks[1].Position != 3725 {
+		t.Fatalf("expected two bookmarks in order, got %+v", m.bookmarks)
+	}
+	if bar := m.progressBar(50); strings.Count(bar, "┃") != 2 {
+		t.Errorf("expected two ticks on the progress bar, got %q", bar)
+	}
+
+	// The list opens on the last bookmark before the position
+	m.timePos = 4000
+	m, _ = updateModel(m, key("B"))
+	if !m.showBookmarks || m.bookmarkSel != 1 {
+		t.Fatalf("expected the list open on the second bookmark, got open=%v sel=%d", m.showBookmarks, m.bookmarkSel)
+	}
+	if view := m.View(); !strings.Contains(view, "▶ 1:02:05") || !strings.Contains(view, "10:00") {
+		t.Errorf("expected both bookmarks listed, got:\n%s", view)
+	}
+	m, _ = updateModel(m, key("k"))
+	m, cmd := updateModel(m, key("enter"))
+	if m.showBookmarks || cmd == nil || !strings.Contains(m.status, "10:00") {
+		t.Errorf("expected enter to jump to 10:00, got open=%v status=%q", m.showBookmarks, m.status)
+	}
+
+	// Deleting is kept in the store
+	m, _ = updateModel(m, key("B"))
+	m, cmd = updateModel(m, key("d"))
+	m, _ = updateModel(m, cmd())
+	saved, err := store.Bookmarks(t.Context(), m.cfg.ActiveProfile, "mix")
+	if err != nil || len(saved) != 1 || len(m.bookmarks) != 1 {
+		t.Fatalf("expected one bookmark left, got %+v in the store, %+v shown (%v)", saved, m.bookmarks, err)
+	}
+
+	// The info popup lists them too
+	m.showBookmarks = false
+	m.screen = screenNowPlaying
+	m, cmd = updateModel(m, key("i"))
+	for _, msg := range cmd().(tea.BatchMsg) {
+		if msg != nil {
+			m, _ = updateModel(m, msg())
+		}
+	}
+	if view := m.View(); !strings.Contains(view, "Bookmarks") {
+		t.Errorf("expected bookmarks in the track info popup, got:\n%s", view)
+	}
+}
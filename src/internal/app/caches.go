@@ -0,0 +1,271 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/platform"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// configCacheSection is the Config section listing the caches.
+const configCacheSection = 3
+
+// cacheKind is a store on disk the Config screen can measure and clear.
+type cacheKind int
+
+const (
+	cacheArtwork   cacheKind = iota // converted ANSI artwork
+	cacheResponses                  // the provider response cache
+	cacheAudio                      // prefetched next tracks
+	cacheState                      // queue.db: queue, history, loves, hidden items
+)
+
+// cacheKeys names each cache in logs and in its "cache." messages.
+var cacheKeys = map[cacheKind]string{
+	cacheArtwork:   "artwork",
+	cacheResponses: "responses",
+	cacheAudio:     "audio",
+	cacheState:     "state",
+}
+
+// Sizes and ages the artwork limit rows step through.
+var (
+	artworkSizeLimits = []int{100, 250, 500, 1000, 2000}
+	artworkDayLimits  = []int{7, 30, 90, 365}
+)
+
+// cacheUsage is how much a cache takes on disk; size is -1 until measured.
+type cacheUsage struct {
+	kind cacheKind
+	size int64
+	err  error
+}
+
+// cacheUsageMsg carries the measured caches.
+type cacheUsageMsg struct {
+	usage []cacheUsage
+}
+
+// cacheClearedMsg reports clearing a cache, or compacting the state
+// database.
+type cacheClearedMsg struct {
+	kind cacheKind
+	err  error
+}
+
+// responseCache returns the provider's response cache, if it has one.
+func (m Model) responseCache() (provider.ResponseCache, bool) {
+	return provider.As[provider.ResponseCache](m.provider)
+}
+
+// openCaches lists the caches in use, then measures them.
+func (m Model) openCaches() (Model, tea.Cmd) {
+	var kinds []cacheKind
+	if m.artworkCache != nil {
+		kinds = append(kinds, cacheArtwork)
+	}
+	if _, ok := m.responseCache(); ok {
+		kinds = append(kinds, cacheResponses)
+	}
+	kinds = append(kinds, cacheAudio)
+	if m.queueStore != nil {
+		kinds = append(kinds, cacheState)
+	}
+	m.caches = make([]cacheUsage, len(kinds))
+	for i, kind := range kinds {
+		m.caches[i] = cacheUsage{kind: kind, size: -1}
+	}
+	m.cacheOpen = true
+	m.selection = 0
+	return m, m.cacheUsageCmd()
+}
+
+// cacheUsageCmd measures the listed caches.
+func (m Model) cacheUsageCmd() tea.Cmd {
+	caches := slices.Clone(m.caches)
+	art, store := m.artworkCache, m.queueStore
+	responses, _ := m.responseCache()
+	return func() tea.Msg {
+		for i, c := range caches {
+			switch c.kind {
+			case cacheArtwork:
+				caches[i].size, caches[i].err = art.Size()
+			case cacheResponses:
+				caches[i].size, caches[i].err = responses.Size()
+			case cacheAudio:
+				caches[i].size, caches[i].err = platform.DiskUsage(prefetchDir())
+			case cacheState:
+				caches[i].size, caches[i].err = store.Size()
+			}
+		}
+		return cacheUsageMsg{usage: caches}
+	}
+}
+
+// handleCacheUsage shows measured sizes while the list is open.
+func (m Model) handleCacheUsage(msg cacheUsageMsg) (Model, tea.Cmd) {
+	if m.cacheOpen && len(msg.usage) == len(m.caches) {
+		m.caches = msg.usage
+	}
+	return m, nil
+}
+
+// selectCache clears the selected cache, after asking, or steps the
+// selected artwork limit to its next size.
+func (m Model) selectCache() (Model, tea.Cmd) {
+	switch i := m.selection; {
+	case i < len(m.caches):
+		return m.clearCache(m.caches[i].kind)
+	case i == len(m.caches):
+		m.cfg.Artwork.CacheMaxMB = nextLimit(artworkSizeLimits, m.cfg.Artwork.CacheMaxMB)
+		m.status = m.msgs.T("cache.limit_set", m.cfg.Artwork.CacheMaxMB)
+		return m.applyArtworkLimits("cache_max_mb", m.cfg.Artwork.CacheMaxMB)
+	default:
+		m.cfg.Artwork.CacheDays = nextLimit(artworkDayLimits, m.cfg.Artwork.CacheDays)
+		m.status = m.msgs.T("cache.days_set", m.cfg.Artwork.CacheDays)
+		return m.applyArtworkLimits("cache_days", m.cfg.Artwork.CacheDays)
+	}
+}
+
+// nextLimit returns the preset after current, wrapping to the first.
+func nextLimit(presets []int, current int) int {
+	for _, p := range presets {
+		if p > current {
+			return p
+		}
+	}
+	return presets[0]
+}
+
+// applyArtworkLimits puts the [artwork] limits into effect and saves the
+// one changed to the config file.
+func (m Model) applyArtworkLimits(key string, value int) (Model, tea.Cmd) {
+	if m.artworkCache != nil {
+		m.artworkCache.SetLimits(m.cfg.Artwork.CacheDays, m.cfg.Artwork.CacheMaxMB)
+	}
+	m.logger.Debug("artwork cache limit changed", slog.String("key", key), slog.Int("value", value))
+	path := m.cfg.Path
+	if path == "" {
+		return m, m.cacheUsageCmd()
+	}
+	return m, tea.Batch(m.cacheUsageCmd(), func() tea.Msg {
+		if err := config.SetInt(path, "artwork", key, value); err != nil {
+			m.logger.Warn("save artwork cache limit", slog.String("key", key), slog.Any("err", err))
+		}
+		return nil
+	})
+}
+
+// clearCache empties a cache once confirmed. The state database holds
+// history, loves and hidden items, so it is only compacted, which keeps
+// everything and needs no asking.
+func (m Model) clearCache(kind cacheKind) (Model, tea.Cmd) {
+	key := cacheKeys[kind]
+	if kind == cacheState {
+		m.status = m.msgs.T("cache.clearing.state")
+		return m, m.clearCacheCmd(kind)
+	}
+	return m.confirm(m.msgs.T("cache.clear", m.msgs.T("cache."+key)), m.msgs.T("cache.detail."+key), func(m Model) (Model, tea.Cmd) {
+		m.status = m.msgs.T("cache.clearing." + key)
+		return m, m.clearCacheCmd(kind)
+	})
+}
+
+// clearCacheCmd clears a cache, or compacts the state database.
+func (m Model) clearCacheCmd(kind cacheKind) tea.Cmd {
+	art, store := m.artworkCache, m.queueStore
+	responses, _ := m.responseCache()
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		var err error
+		switch kind {
+		case cacheArtwork:
+			err = art.Clear()
+		case cacheResponses:
+			err = responses.Clear(ctx)
+		case cacheAudio:
+			err = os.RemoveAll(prefetchDir())
+		case cacheState:
+			err = store.Compact(ctx)
+		}
+		return cacheClearedMsg{kind: kind, err: err}
+	}
+}
+
+// handleCacheCleared reports a cleared cache and measures the caches
+// again.
+func (m Model) handleCacheCleared(msg cacheClearedMsg) (Model, tea.Cmd) {
+	key := cacheKeys[msg.kind]
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("clear %s cache: %w", key, msg.err))
+	}
+	m.logger.Info("cleared cache", slog.String("cache", key))
+	if msg.kind == cacheAudio {
+		// The download is gone; play the next track from its stream
+		m.prefetch = prefetchState{startedFor: m.prefetch.startedFor}
+	}
+	m.status = m.msgs.T("cache.cleared." + key)
+	if !m.cacheOpen {
+		return m, nil
+	}
+	return m, m.cacheUsageCmd()
+}
+
+// renderCaches shows the caches in the Config screen details: their limits,
+// or once opened, what each takes on disk.
+func (m Model) renderCaches() string {
+	if !m.cacheOpen {
+		var b strings.Builder
+		b.WriteString(m.msgs.T("cache.artwork_limits", m.cfg.Artwork.CacheMaxMB, m.cfg.Artwork.CacheDays) + "\n")
+		responses := m.msgs.T("config.off")
+		if _, ok := m.responseCache(); ok {
+			responses = m.msgs.T("config.on")
+		}
+		b.WriteString(m.msgs.T("cache.responses_status", responses) + "\n")
+		prefetch := m.msgs.T("config.off")
+		if secs := m.cfg.Player.PrefetchSeconds; secs > 0 {
+			prefetch = m.msgs.T("cache.prefetch", secs)
+		}
+		b.WriteString(m.msgs.T("cache.audio_status", prefetch) + "\n")
+		b.WriteString(m.msgs.T("cache.open"))
+		return b.String()
+	}
+
+	rows := make([]string, 0, len(m.caches)+2)
+	for _, c := range m.caches {
+		size := m.msgs.T("cache.measuring")
+		switch {
+		case c.err != nil:
+			size = m.msgs.T("cache.unknown")
+		case c.size >= 0:
+			size = formatBytes(uint64(c.size))
+		}
+		rows = append(rows, cacheRow(m.msgs.T("cache."+cacheKeys[c.kind]), size))
+	}
+	rows = append(rows,
+		cacheRow(m.msgs.T("cache.size_limit"), m.msgs.T("cache.mb", m.cfg.Artwork.CacheMaxMB)),
+		cacheRow(m.msgs.T("cache.kept_for"), m.msgs.T("cache.days", m.cfg.Artwork.CacheDays)))
+	for i, row := range rows {
+		prefix, style := "  ", m.theme.Text
+		if i == m.selection {
+			prefix, style = m.selectedRow("▸ ")
+		}
+		rows[i] = style.Render(prefix + row)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// cacheRow lines a cache's name up with its size, by terminal cells.
+func cacheRow(name, size string) string {
+	return ui.PadRight(name, 20) + " " + ui.PadLeft(size, 12)
+}
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/artwork"
+	"github.com/tunez/tunez/internal/provider/cache"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestCacheManagement(t *testing.T) {
+	dir := t.TempDir()
+	m := createTestModel(t)
+	cached, err := cache.New(newTestProvider(), cache.Options{Path: filepath.Join(dir, "provider_cache.db")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cached.Close()
+	m.provider = cached
+	m.artworkCache, err = artwork.NewCache(filepath.Join(dir, "artwork"), 30, 500)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.artworkCache.Set("cover", 20, 10, artwork.QualityMedium, artwork.ScaleFit, strings.Repeat("▀", 100))
+	m.queueStore, err = queue.NewPersistenceStore(filepath.Join(dir, "queue.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.queueStore.Close()
+	m.cfg.Path = filepath.Join(dir, "config.toml")
+	if err := os.WriteFile(m.cfg.Path, []byte("[artwork]\ncache_days = 30\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m.cfg.Artwork.CacheDays, m.cfg.Artwork.CacheMaxMB = 30, 500
+
+	// Open the list and measure the caches
+	m.screen = screenConfig
+	m.selection = configCacheSection
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.cacheOpen || m.currentListLen() != 6 || cmd == nil {
+		t.Fatalf("expected the cache list open with four caches and two limits, got open=%v len=%d", m.cacheOpen, m.currentListLen())
+	}
+	m, _ = updateModel(m, cmd())
+	if m.caches[0].kind != cacheArtwork || m.caches[0].size < 300 || m.caches[1].size <= 0 || m.caches[3].size <= 0 {
+		t.Fatalf("expected the caches measured, got %+v", m.caches)
+	}
+
+	// Clearing the artwork asks first
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirmPrompt == nil || cmd != nil {
+		t.Fatal("expected to be asked before clearing the artwork")
+	}
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m, cmd = updateModel(m, cmd())
+	if m.status != "Cleared artwork" || cmd == nil {
+		t.Fatalf("expected the artwork cleared and the caches measured again, got %q", m.status)
+	}
+	m, _ = updateModel(m, cmd())
+	if m.caches[0].size != 0 {
+		t.Errorf("expected the artwork cache empty, got %d bytes", m.caches[0].size)
+	}
+
+	// The state database is only compacted, without asking
+	m.selection = 3
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirmPrompt != nil || cmd == nil {
+		t.Fatal("expected the state database compacted straight away")
+	}
+	if m, _ = updateModel(m, cmd()); m.status != "Compacted the state database" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+
+	// The size limit steps up, takes effect and is saved
+	m.selection = 4
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.cfg.Artwork.CacheMaxMB != 1000 || m.status != "Artwork cache limit: 1000 MB" {
+		t.Fatalf("expected the limit raised to 1000 MB, got %d, status %q", m.cfg.Artwork.CacheMaxMB, m.status)
+	}
+	if _, size := m.artworkCache.Limits(); size != 1000<<20 {
+		t.Errorf("expected the cache to use the new limit, got %d bytes", size)
+	}
+	for _, c := range cmd().(tea.BatchMsg) {
+		c()
+	}
+	if data, _ := os.ReadFile(m.cfg.Path); !strings.Contains(string(data), "cache_max_mb = 1000") {
+		t.Errorf("expected the limit saved, got:\n%s", data)
+	}
+	m.selection = 5
+	if m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter}); m.cfg.Artwork.CacheDays != 90 {
+		t.Errorf("expected artwork kept for 90 days, got %d", m.cfg.Artwork.CacheDays)
+	}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.cacheOpen || m.selection != configCacheSection {
+		t.Errorf("expected Esc back to the sections, got open=%v selection=%d", m.cacheOpen, m.selection)
+	}
+}
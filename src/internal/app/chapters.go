@@ -0,0 +1,133 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// chapterRestartSeconds is how far into a chapter the previous-chapter key
+// restarts it rather than going back one, as with tracks.
+const chapterRestartSeconds = 3.0
+
+// currentChapter returns the index of the chapter playing, or -1 before
+// the first one or when the track has none.
+func (m Model) currentChapter() int {
+	current := -1
+	for i, c := range m.chapters {
+		if c.Start <= m.timePos {
+			current = i
+		}
+	}
+	return current
+}
+
+// chapterTitle returns the title of chapter i, numbering untitled ones.
+func (m Model) chapterTitle(i int) string {
+	if title := strings.TrimSpace(m.chapters[i].Title); title != "" {
+		return title
+	}
+	return fmt.Sprintf("Chapter %d", i+1)
+}
+
+// seekChapter jumps to the start of chapter i.
+func (m Model) seekChapter(i int) (Model, tea.Cmd) {
+	c := m.chapters[i]
+	m.logger.Debug("seeking to chapter", slog.Int("chapter", i), slog.Float64("start", c.Start), slog.Float64("from", m.timePos))
+	m.status = m.msgs.T("chapters.chapter", i+1, len(m.chapters), m.chapterTitle(i))
+	return m, m.seekCmd(c.Start - m.timePos)
+}
+
+// nextChapter jumps to the start of the next chapter.
+func (m Model) nextChapter() (Model, tea.Cmd) {
+	if len(m.chapters) == 0 {
+		m.status = m.msgs.T("chapters.none")
+		return m, nil
+	}
+	next := m.currentChapter() + 1
+	if next >= len(m.chapters) {
+		m.status = m.msgs.T("chapters.last")
+		return m, nil
+	}
+	return m.seekChapter(next)
+}
+
+// prevChapter restarts the current chapter, or goes back one when it has
+// only just started.
+func (m Model) prevChapter() (Model, tea.Cmd) {
+	if len(m.chapters) == 0 {
+		m.status = m.msgs.T("chapters.none")
+		return m, nil
+	}
+	current := m.currentChapter()
+	if current > 0 && m.timePos-m.chapters[current].Start < chapterRestartSeconds {
+		current--
+	}
+	return m.seekChapter(max(current, 0))
+}
+
+// openChapters shows the chapter picker with the playing chapter selected.
+func (m Model) openChapters() (Model, tea.Cmd) {
+	if len(m.chapters) == 0 {
+		m.status = m.msgs.T("chapters.none")
+		return m, nil
+	}
+	m.showChapters = true
+	m.chapterSel = max(m.currentChapter(), 0)
+	return m, nil
+}
+
+// handleChaptersKey handles keys while the chapter picker is open.
+func (m Model) handleChaptersKey(key string) (Model, tea.Cmd) {
+	switch {
+	case key == "esc" || key == "q" || matchKey(key, m.cfg.Keybindings.Chapters):
+		m.showChapters = false
+	case key == "up" || key == "k":
+		if m.chapterSel > 0 {
+			m.chapterSel--
+		}
+	case key == "down" || key == "j":
+		if m.chapterSel < len(m.chapters)-1 {
+			m.chapterSel++
+		}
+	case key == "enter":
+		m.showChapters = false
+		return m.seekChapter(clamp(m.chapterSel, 0, len(m.chapters)-1))
+	}
+	return m, nil
+}
+
+// renderChapters renders the chapter picker popup, scrolled to keep the
+// selection in view.
+func (m Model) renderChapters() string {
+	rows := max(m.height-10, 3)
+	start := clamp(m.chapterSel-rows/2, 0, max(len(m.chapters)-rows, 0))
+	current := m.currentChapter()
+	var lines []string
+	for i := start; i < min(start+rows, len(m.chapters)); i++ {
+		prefix := "   "
+		style := m.theme.Text
+		if i == m.chapterSel {
+			prefix, style = m.selectedRow(" ▶ ")
+		}
+		line := style.Render(fmt.Sprintf("%s%3d  %s", prefix, i+1, m.chapterTitle(i))) +
+			m.theme.Dim.Render("  "+formatPosition(m.chapters[i].Start))
+		if i == current {
+			line += m.theme.Accent.Render("  ♪")
+		}
+		lines = append(lines, line)
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Chapters ═══  "),
+		m.theme.Dim.Render("  "+m.nowPlaying.Title),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.theme.Dim.Render("[Enter]Jump  [Esc]Close"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
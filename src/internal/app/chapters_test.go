@@ -0,0 +1,74 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/player"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestChapters(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenNowPlaying
+	m.nowPlaying = provider.Track{ID: "book", Title: "The Hobbit"}
+	m.duration = 36000
+
+	key := func(k string) tea.KeyMsg {
+		if k == "enter" {
+			return tea.KeyMsg{Type: tea.KeyEnter}
+		}
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+
+	m, _ = updateModel(m, key("]"))
+	if m.status != "This track has no chapters" {
+		t.Errorf("expected a note about missing chapters, got %q", m.status)
+	}
+
+	chapters := []player.Chapter{{Title: "An Unexpected Party", Start: 0}, {Title: "Roast Mutton", Start: 2400}, {Start: 4100}}
+	m, _ = updateModel(m, playerMsg{Chapters: &chapters})
+	pos := 2500.0
+	m, _ = updateModel(m, playerMsg{TimePos: &pos})
+	if view := m.renderNowPlaying(); !strings.Contains(view, "Chapter 2/3: Roast Mutton") {
+		t.Errorf("expected the current chapter on Now Playing, got:\n%s", view)
+	}
+
+	m, cmd := updateModel(m, key("]"))
+	if cmd == nil || m.status != "Chapter 3/3: Chapter 3" {
+		t.Errorf("expected a jump to the untitled third chapter, got %q", m.status)
+	}
+	// Well into a chapter, previous restarts it; at its start, it goes back one
+	m, _ = updateModel(m, key("["))
+	if m.status != "Chapter 2/3: Roast Mutton" {
+		t.Errorf("expected the current chapter restarted, got %q", m.status)
+	}
+	pos = 2401
+	m, _ = updateModel(m, playerMsg{TimePos: &pos})
+	m, _ = updateModel(m, key("["))
+	if m.status != "Chapter 1/3: An Unexpected Party" {
+		t.Errorf("expected the previous chapter, got %q", m.status)
+	}
+
+	m, _ = updateModel(m, key("E"))
+	if !m.showChapters || m.chapterSel != 1 {
+		t.Fatalf("expected the picker open on the playing chapter, got open=%v sel=%d", m.showChapters, m.chapterSel)
+	}
+	if view := m.View(); !strings.Contains(view, "Roast Mutton") || !strings.Contains(view, "1:08:20") {
+		t.Errorf("expected chapter titles and start times, got:\n%s", view)
+	}
+	m, _ = updateModel(m, key("j"))
+	m, cmd = updateModel(m, key("enter"))
+	if m.showChapters || cmd == nil || m.status != "Chapter 3/3: Chapter 3" {
+		t.Errorf("expected enter to jump to the selected chapter, got open=%v status=%q", m.showChapters, m.status)
+	}
+
+	// The next file without chapters clears them
+	none := []player.Chapter{}
+	m, _ = updateModel(m, playerMsg{Chapters: &none})
+	if m.currentChapter() != -1 {
+		t.Error("expected no chapters after loading a file without them")
+	}
+}
@@ -0,0 +1,42 @@
+package app
+
+import (
+	"errors"
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// composerBrowser returns the provider's composer index while the Library
+// is in Classical mode, or nil.
+func (m Model) composerBrowser() provider.ComposerBrowser {
+	if !m.classical {
+		return nil
+	}
+	cb, _ := provider.As[provider.ComposerBrowser](m.provider)
+	return cb
+}
+
+// toggleClassical switches the Library between artists, albums and tracks
+// and composers, works and movements, starting over from the top list.
+func (m Model) toggleClassical() (Model, tea.Cmd) {
+	if !m.classical {
+		if _, ok := provider.As[provider.ComposerBrowser](m.provider); !ok {
+			return m.setError(errors.New("classical mode needs a provider that indexes composer tags"))
+		}
+	}
+	m.classical = !m.classical
+	m.screen = screenLibrary
+	m.albums, m.tracks = nil, nil
+	m.currentArtistID, m.currentAlbumID = "", ""
+	m.topTracksOf = ""
+	m.selection = 0
+	m.artistsCursor = ""
+	m.status = m.msgs.T("classical.off")
+	if m.classical {
+		m.status = m.msgs.T("classical.on")
+	}
+	m.logger.Debug("classical mode toggled", slog.Bool("classical", m.classical))
+	return m, m.loadArtistsCmd("")
+}
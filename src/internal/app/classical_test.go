@@ -0,0 +1,77 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// classicalProvider indexes one composer with one work in two movements.
+type classicalProvider struct {
+	*testProvider
+}
+
+func (p *classicalProvider) ListComposers(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	return provider.Page[provider.Artist]{Items: []provider.Artist{{ID: "c1", Name: "Ludwig van Beethoven", AlbumCount: 1}}}, nil
+}
+
+func (p *classicalProvider) ListWorks(ctx context.Context, composerID string, req provider.ListReq) (provider.Page[provider.Album], error) {
+	return provider.Page[provider.Album]{Items: []provider.Album{{ID: "w1", Title: "Symphony No. 5", ArtistID: "c1", ArtistName: "Ludwig van Beethoven", TrackCount: 2}}}, nil
+}
+
+func (p *classicalProvider) ListTracks(ctx context.Context, albumID, artistID, playlistID string, req provider.ListReq) (provider.Page[provider.Track], error) {
+	return provider.Page[provider.Track]{Items: []provider.Track{
+		{ID: "m1", Title: "Symphony No. 5: I. Allegro con brio", ArtistName: "Unknown Artist", Composer: "Ludwig van Beethoven", Work: "Symphony No. 5", Movement: "Allegro con brio", MovementNo: 1, DurationMs: 420000},
+		{ID: "m2", Title: "Symphony No. 5: II. Andante con moto", ArtistName: "Unknown Artist", Composer: "Ludwig van Beethoven", Work: "Symphony No. 5", Movement: "Andante con moto", MovementNo: 2, DurationMs: 600000},
+	}}, nil
+}
+
+func TestClassicalMode(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+
+	// Providers without a composer index can't switch
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if m.classical || m.errorMsg == "" {
+		t.Fatalf("expected an error without a composer index, got classical=%v", m.classical)
+	}
+
+	m.errorMsg = ""
+	m.provider = &classicalProvider{testProvider: newTestProvider()}
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if !m.classical || cmd == nil {
+		t.Fatal("expected classical mode and a reload")
+	}
+	m, _ = updateModel(m, cmd())
+	if view := m.renderLibrary(80, 20); !strings.Contains(view, "Composers (1)") || !strings.Contains(view, "Ludwig van Beethoven  (1 work)") {
+		t.Fatalf("expected the composer list, got:\n%s", view)
+	}
+
+	m, _ = updateModel(m, m.loadAlbumsCmd("c1", "")())
+	if view := m.renderLibrary(80, 20); !strings.Contains(view, "Works (1)") || !strings.Contains(view, "Symphony No. 5  (2 movements)") {
+		t.Fatalf("expected the work list, got:\n%s", view)
+	}
+
+	m, _ = updateModel(m, m.loadTracksCmd("c1", "w1", "")())
+	view := m.renderLibrary(80, 20)
+	if !strings.Contains(view, "Movements (2)") || !strings.Contains(view, "01  I. Allegro con brio") {
+		t.Fatalf("expected the movements, got:\n%s", view)
+	}
+	if strings.Contains(view, "Unknown Artist") {
+		t.Errorf("expected movements without the performer, got:\n%s", view)
+	}
+
+	// Switching back lists artists again
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'C'}})
+	if m.classical || len(m.tracks) != 0 {
+		t.Fatalf("expected the artist list, got classical=%v tracks=%d", m.classical, len(m.tracks))
+	}
+	if msg, ok := cmd().(artistsMsg); !ok || len(msg.page.Items) != 5 {
+		t.Errorf("expected the library artists, got %+v", msg)
+	}
+}
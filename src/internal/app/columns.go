@@ -0,0 +1,190 @@
+package app
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// columnGap separates the columns of a track row.
+const columnGap = "  "
+
+// minFlexWidth is the narrowest a title, artist or album column sharing
+// the room left gets before columns are dropped to make more.
+const minFlexWidth = 8
+
+// columnWidths are the widths of the columns sized to fit their values.
+var columnWidths = map[string]int{"year": 4, "duration": 5, "codec": 4, "bitrate": 5, "rating": 1, "plays": 4}
+
+// columnDropOrder is the order columns give way in when a list is too
+// narrow for all of them. The title always stays.
+var columnDropOrder = []string{"plays", "rating", "bitrate", "codec", "year", "album", "track", "artist", "duration"}
+
+// trackTable is the [ui] track_columns fitted to the width of a track list.
+type trackTable struct {
+	cols []config.TrackColumn
+	// albumOrder numbers the rows by their track number on the album
+	// rather than their position in the list
+	albumOrder bool
+}
+
+// flexible reports whether a column shares the room the others leave.
+func flexible(c config.TrackColumn) bool {
+	return c.Width == 0 && (c.Name == "title" || c.Name == "artist" || c.Name == "album")
+}
+
+// trackTable fits the configured columns to width for tracks, leaving room
+// after each row for the widest of its badges. Columns are dropped in
+// columnDropOrder while the shared ones would be narrower than
+// minFlexWidth.
+func (m Model) trackTable(tracks []provider.Track, width int, albumOrder bool, badges func(provider.Track) string) trackTable {
+	cols, err := config.ParseTrackColumns(m.cfg.UI.TrackColumns)
+	if err != nil || len(cols) == 0 {
+		cols = []config.TrackColumn{{Name: "track"}, {Name: "artist"}, {Name: "title"}, {Name: "duration"}}
+	}
+	room := 0
+	for _, t := range tracks {
+		room = max(room, ui.Width(badges(t)))
+	}
+	width -= room
+
+	numbers, length := len(strconv.Itoa(len(tracks))), columnWidths["duration"]
+	for _, t := range tracks {
+		if albumOrder {
+			numbers = max(numbers, len(strconv.Itoa(t.TrackNo)))
+		}
+		// Tracks of an hour or more take h:mm:ss
+		length = max(length, len(formatMs(t.DurationMs)))
+	}
+	for i, c := range cols {
+		switch {
+		case c.Width > 0:
+		case c.Name == "track":
+			cols[i].Width = max(numbers, 2)
+		case c.Name == "duration":
+			cols[i].Width = length
+		case !flexible(c):
+			cols[i].Width = columnWidths[c.Name]
+		}
+	}
+
+	// Shared columns need no more than their longest value
+	natural := map[string]int{}
+	for _, t := range tracks {
+		for name, v := range map[string]string{"title": t.Title, "artist": t.ArtistName, "album": t.AlbumTitle} {
+			natural[name] = max(natural[name], ui.Width(v))
+		}
+	}
+	for {
+		fixed, least := len(columnGap)*(len(cols)-1), 0
+		var shared []int
+		for j, c := range cols {
+			if flexible(c) {
+				shared = append(shared, j)
+				least += min(natural[c.Name], minFlexWidth)
+			} else {
+				fixed += c.Width
+			}
+		}
+		i := -1
+		if fixed+least > width {
+			for _, name := range columnDropOrder {
+				if i = slices.IndexFunc(cols, func(c config.TrackColumn) bool { return c.Name == name }); i >= 0 {
+					break
+				}
+			}
+		}
+		if i < 0 {
+			// Share the room left: columns narrower than an even share keep
+			// their width and the rest split what they leave
+			slices.SortStableFunc(shared, func(a, b int) int { return natural[cols[a].Name] - natural[cols[b].Name] })
+			rest := width - fixed
+			for k, j := range shared {
+				w := max(min(natural[cols[j].Name], rest/(len(shared)-k)), 1)
+				cols[j].Width = w
+				rest -= w
+			}
+			return trackTable{cols: cols, albumOrder: albumOrder}
+		}
+		cols = slices.Delete(cols, i, i+1)
+	}
+}
+
+// columnsShowHistory reports whether the track lists show plays or loved
+// marks from the play history.
+func (m Model) columnsShowHistory() bool {
+	return slices.ContainsFunc(m.cfg.UI.TrackColumns, func(spec string) bool {
+		name, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(spec)), ":")
+		return name == "plays" || name == "rating"
+	})
+}
+
+// trackRow renders the columns of track t, the i-th of its list.
+func (m Model) trackRow(tab trackTable, i int, t provider.Track) string {
+	cells := make([]string, len(tab.cols))
+	for j, c := range tab.cols {
+		text, right := m.columnValue(tab, c.Name, i, t)
+		if right {
+			text = ui.PadLeft(text, c.Width)
+		} else {
+			text = ui.PadRight(text, c.Width)
+		}
+		if c.Name == "duration" {
+			text = m.theme.Dim.Render(text)
+		}
+		cells[j] = text
+	}
+	return strings.TrimRight(strings.Join(cells, columnGap), " ")
+}
+
+// columnValue returns a track's value in a column, and whether it aligns
+// right.
+func (m Model) columnValue(tab trackTable, name string, i int, t provider.Track) (string, bool) {
+	switch name {
+	case "track":
+		n := i + 1
+		if tab.albumOrder && t.TrackNo > 0 {
+			n = t.TrackNo
+		}
+		return fmt.Sprintf("%02d", n), false
+	case "title":
+		return t.Title, false
+	case "artist":
+		return t.ArtistName, false
+	case "album":
+		return t.AlbumTitle, false
+	case "year":
+		if t.Year > 0 {
+			return strconv.Itoa(t.Year), false
+		}
+	case "duration":
+		if t.DurationMs <= 0 {
+			return "—:——", true
+		}
+		return formatMs(t.DurationMs), true
+	case "codec":
+		return strings.ToUpper(t.Codec), false
+	case "bitrate":
+		if t.BitrateKbps > 0 {
+			return fmt.Sprintf("%dk", t.BitrateKbps), true
+		}
+	case "rating":
+		if m.isLoved(t.ID) || m.playHistory.Get(t.ArtistName, t.Title).Loved {
+			return "♥", false
+		}
+	case "plays":
+		n, ok := m.topPlays[t.ID]
+		if !ok {
+			n = m.playHistory.Get(t.ArtistName, t.Title).Plays
+		}
+		if n > 0 {
+			return strconv.Itoa(n), true
+		}
+	}
+	return "", false
+}
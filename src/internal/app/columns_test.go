@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+func TestTrackColumns(t *testing.T) {
+	m := createTestModel(t)
+	m.cfg.UI.TrackColumns = []string{"track", "title", "album:12", "year", "codec", "bitrate", "rating", "plays", "duration"}
+	m.playHistory = queue.PlayHistory{}
+	m.playHistory.AddPlay("Pink Floyd", "Time", m.lastInput)
+	m.playHistory.SetLoved("Pink Floyd", "Time", true)
+	tracks := []provider.Track{
+		{ID: "1", Title: "Time", ArtistName: "Pink Floyd", AlbumTitle: "The Dark Side of the Moon", Year: 1973, TrackNo: 4, DurationMs: 413000, Codec: "flac", BitrateKbps: 1021},
+		{ID: "2", Title: "Us and Them", ArtistName: "Pink Floyd", AlbumTitle: "The Dark Side of the Moon", Year: 1973, TrackNo: 7, Codec: "mp3"},
+	}
+	none := func(provider.Track) string { return "" }
+
+	tab := m.trackTable(tracks, 80, true, none)
+	rows := []string{m.trackRow(tab, 0, tracks[0]), m.trackRow(tab, 1, tracks[1])}
+	want := []string{
+		"04  Time         The Dark Si…  1973  FLAC  1021k  ♥     1   6:53",
+		"07  Us and Them  The Dark Si…  1973  MP3                    —:——",
+	}
+	if strings.Join(rows, "\n") != strings.Join(want, "\n") {
+		t.Errorf("expected\n%s\ngot\n%s", strings.Join(want, "\n"), strings.Join(rows, "\n"))
+	}
+
+	// Narrow lists drop the least useful columns first, and number rows by
+	// position outside an album
+	tab = m.trackTable(tracks, 30, false, none)
+	var names []string
+	for _, c := range tab.cols {
+		names = append(names, c.Name)
+	}
+	if strings.Join(names, ",") != "track,title,duration" {
+		t.Errorf("expected the columns dropped, got %v", names)
+	}
+	if row := m.trackRow(tab, 1, tracks[1]); row != "02  Us and Them   —:——" {
+		t.Errorf("expected the row's position, got %q", row)
+	}
+
+	// Tracks of an hour or more widen the duration column
+	long := append(tracks[:1:1], provider.Track{ID: "5", Title: "Echoes", DurationMs: 3753000})
+	tab = m.trackTable(long, 80, true, none)
+	if row := m.trackRow(tab, 0, long[0]); !strings.HasSuffix(row, "   6:53") {
+		t.Errorf("expected the duration aligned with h:mm:ss, got %q", row)
+	}
+	if row := m.trackRow(tab, 1, long[1]); !strings.HasSuffix(row, "1:02:33") {
+		t.Errorf("expected h:mm:ss, got %q", row)
+	}
+
+	// Wide characters count two cells, so their rows stay aligned
+	m.cfg.UI.TrackColumns = []string{"title:10", "artist:6", "duration"}
+	wide := []provider.Track{
+		{ID: "3", Title: "夜に駆ける夜に駆ける", ArtistName: "YOASOBI", DurationMs: 261000},
+		{ID: "4", Title: "Time", ArtistName: "Pink Floyd", DurationMs: 413000},
+	}
+	tab = m.trackTable(wide, 80, false, none)
+	for i, want := range []string{"夜に駆け…   YOASO…   4:21", "Time        Pink…    6:53"} {
+		row := m.trackRow(tab, i, wide[i])
+		if row != want || ui.Width(row) != 25 {
+			t.Errorf("expected %q, got %q (%d cells)", want, row, ui.Width(row))
+		}
+	}
+}
@@ -1,6 +1,13 @@
 package app
 
-import tea "github.com/charmbracelet/bubbletea"
+import (
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
 
 // Command represents an action that can be invoked via the command palette.
 type Command struct {
@@ -10,6 +17,12 @@ type Command struct {
 	Category    string
 	Keybinding  string
 	Handler     func(m *Model) (Model, tea.Cmd)
+
+	// Arg names what an argument command prompts for (e.g. "mm:ss").
+	// Selecting such a command opens a prompt and runs RunArg with the
+	// text typed into it instead of calling Handler.
+	Arg    string
+	RunArg func(m *Model, arg string) (Model, tea.Cmd)
 }
 
 // CommandRegistry holds all available commands.
@@ -63,6 +76,64 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 			return *m, nil
 		},
 	})
+	r.register(Command{
+		ID:          "nav.search_for",
+		Name:        "Search For",
+		Description: "Search the library for a query",
+		Category:    "Navigation",
+		Arg:         "query",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			m.screen = screenSearch
+			m.searchQ = arg
+			m.selection = 0
+			return *m, m.searchCmd(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "nav.go_to_artist",
+		Name:        "Go to Artist",
+		Description: "Open an artist's albums by name, or with no name the selected or playing track's artist",
+		Category:    "Navigation",
+		Keybinding:  m.cfg.Keybindings.GoToArtist,
+		Arg:         "name",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			if arg == "" {
+				return m.goTo(false)
+			}
+			m.status = m.msgs.T("status.looking_up", arg)
+			return *m, m.findArtistCmd(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "nav.go_to_album",
+		Name:        "Go to Album",
+		Description: "Open the selected or playing track's album in the Library",
+		Category:    "Navigation",
+		Keybinding:  m.cfg.Keybindings.GoToAlbum,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.goTo(true)
+		},
+	})
+	r.register(Command{
+		ID:          "nav.back",
+		Name:        "Back",
+		Description: "Return to the previous view and selection",
+		Category:    "Navigation",
+		Keybinding:  m.cfg.Keybindings.NavBack,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.navBack()
+		},
+	})
+	r.register(Command{
+		ID:          "nav.forward",
+		Name:        "Forward",
+		Description: "Return to the view left by going back",
+		Category:    "Navigation",
+		Keybinding:  m.cfg.Keybindings.NavForward,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.navForward()
+		},
+	})
 	r.register(Command{
 		ID:          "nav.lyrics",
 		Name:        "Go to Lyrics",
@@ -73,6 +144,21 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 			return *m, nil
 		},
 	})
+	r.register(Command{
+		ID:          "nav.scrobbles",
+		Name:        "Go to Scrobbles",
+		Description: "Review recent and pending scrobbles",
+		Category:    "Navigation",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			if m.scrobbler == nil {
+				m.status = m.msgs.T("scrobble.off")
+				return *m, nil
+			}
+			m.screen = screenScrobbles
+			m.selection = 0
+			return *m, nil
+		},
+	})
 	r.register(Command{
 		ID:          "nav.config",
 		Name:        "Go to Config",
@@ -84,6 +170,27 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 		},
 	})
 
+	r.register(Command{
+		ID:          "profile.switch",
+		Name:        "Switch Profile",
+		Description: "Pick another profile; each keeps its own queue",
+		Category:    "Navigation",
+		Keybinding:  m.cfg.Keybindings.ProfileSwitch,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openProfileSwitcher()
+		},
+	})
+	r.register(Command{
+		ID:          "nav.quick_open",
+		Name:        "Quick Open",
+		Description: "Jump to an artist, album, playlist or command by name",
+		Category:    "Navigation",
+		Keybinding:  m.cfg.Keybindings.QuickOpen,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openQuickOpen()
+		},
+	})
+
 	// Playback commands
 	r.register(Command{
 		ID:          "playback.play_pause",
@@ -94,13 +201,22 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 		Handler: func(m *Model) (Model, tea.Cmd) {
 			m.paused = !m.paused
 			return *m, func() tea.Msg {
-				if err := m.player.TogglePause(m.paused); err != nil {
+				if err := m.player.FadePause(m.paused); err != nil {
 					return playerMsg{Err: err}
 				}
 				return nil
 			}
 		},
 	})
+	r.register(Command{
+		ID:          "playback.play",
+		Name:        "Play",
+		Description: "Resume playback, or start the queue if nothing is playing",
+		Category:    "Playback",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.play()
+		},
+	})
 	r.register(Command{
 		ID:          "playback.next",
 		Name:        "Next Track",
@@ -108,11 +224,11 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 		Category:    "Playback",
 		Keybinding:  m.cfg.Keybindings.NextTrack,
 		Handler: func(m *Model) (Model, tea.Cmd) {
-			next, err := m.queue.Next()
+			mm, next, err := m.nextTrack()
 			if err != nil {
-				return *m, nil
+				return mm, nil
 			}
-			return *m, m.playTrackCmd(next)
+			return mm, mm.skipToCmd(next)
 		},
 	})
 	r.register(Command{
@@ -126,7 +242,7 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 			if err != nil {
 				return *m, nil
 			}
-			return *m, m.playTrackCmd(prev)
+			return *m, m.skipToCmd(prev)
 		},
 	})
 	r.register(Command{
@@ -140,6 +256,36 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 			return *m, nil
 		},
 	})
+	r.register(Command{
+		ID:          "playback.seek_to",
+		Name:        "Seek To",
+		Description: "Jump to a position in the playing track",
+		Category:    "Playback",
+		Arg:         "mm:ss",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			return m.seekTo(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "playback.set_volume",
+		Name:        "Set Volume",
+		Description: "Set the volume to a level from 0 to 100",
+		Category:    "Playback",
+		Arg:         "0-100",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			return m.setVolume(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "playback.set_shuffle",
+		Name:        "Set Shuffle",
+		Description: "Turn shuffle on or off",
+		Category:    "Playback",
+		Arg:         "on|off",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			return m.setShuffle(arg)
+		},
+	})
 	r.register(Command{
 		ID:          "playback.repeat",
 		Name:        "Cycle Repeat",
@@ -205,6 +351,71 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 			}
 		},
 	})
+	r.register(Command{
+		ID:          "playback.audio_exclusive",
+		Name:        "Toggle Exclusive Output",
+		Description: "Bit-perfect output with exclusive access to the audio device",
+		Category:    "Playback",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			m.cfg.Player.AudioExclusive = !m.cfg.Player.AudioExclusive
+			exclusive := m.cfg.Player.AudioExclusive
+			if exclusive {
+				m.status = m.msgs.T("player.exclusive_on")
+			} else {
+				m.status = m.msgs.T("player.exclusive_off")
+			}
+			return *m, func() tea.Msg {
+				if err := m.player.SetAudioExclusive(exclusive); err != nil {
+					return playerMsg{Err: err}
+				}
+				return nil
+			}
+		},
+	})
+	r.register(Command{
+		ID:          "playback.trim_silence",
+		Name:        "Toggle Silence Trimming",
+		Description: "Trim silence between tracks so they play seamlessly",
+		Category:    "Playback",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			m.cfg.Player.TrimSilence = !m.cfg.Player.TrimSilence
+			trim := m.cfg.Player.TrimSilence
+			if trim {
+				m.status = m.msgs.T("player.trim_on")
+			} else {
+				m.status = m.msgs.T("player.trim_off")
+			}
+			return *m, func() tea.Msg {
+				if err := m.player.SetTrimSilence(trim); err != nil {
+					return playerMsg{Err: err}
+				}
+				return nil
+			}
+		},
+	})
+
+	r.register(Command{
+		ID:          "playback.normalize",
+		Name:        "Cycle Loudness Normalization",
+		Description: "Switch between off, ReplayGain track/album and loudnorm",
+		Category:    "Playback",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			modes := config.NormalizeModes
+			m.cfg.Player.Normalize = modes[(slices.Index(modes, m.cfg.Player.Normalize)+1)%len(modes)]
+			m.status = m.msgs.T("player.normalization", m.cfg.Player.Normalize)
+			return *m, m.setNormalizationCmd()
+		},
+	})
+
+	r.register(Command{
+		ID:          "playback.low_bandwidth",
+		Name:        "Toggle Low-Bandwidth Streaming",
+		Description: "Stream at low_bandwidth_quality on metered connections",
+		Category:    "Playback",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.toggleLowBandwidth(), nil
+		},
+	})
 
 	// Queue commands
 	r.register(Command{
@@ -213,10 +424,185 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 		Description: "Remove all tracks from the queue",
 		Category:    "Queue",
 		Handler: func(m *Model) (Model, tea.Cmd) {
-			m.queue.Clear()
+			return m.clearQueue()
+		},
+	})
+	r.register(Command{
+		ID:          "queue.play_from_here",
+		Name:        "Play From Here",
+		Description: "Play the selected album track and queue the rest of the album after it",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			if m.screen != screenLibrary || len(m.tracks) == 0 {
+				m.status = m.msgs.T("status.select_album_track")
+				return *m, nil
+			}
+			return *m, m.playFromHereCmd(clamp(m.selection, 0, len(m.tracks)-1))
+		},
+	})
+	r.register(Command{
+		ID:          "queue.play_next",
+		Name:        "Play Next",
+		Description: "Queue the selected track, album or playlist right after the current track",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			if t, ok := m.selectedTrack(); ok {
+				return *m, m.addNextTrackCmd(t)
+			}
+			if lc, ok := m.playNextTarget(); ok {
+				m.status = m.msgs.T("status.loading", lc.Title)
+				return *m, m.playNextCmd(lc)
+			}
+			m.status = m.msgs.T("status.select_track_album_playlist")
 			return *m, nil
 		},
 	})
+	r.register(Command{
+		ID:          "library.hide",
+		Name:        "Hide",
+		Description: "Hide the selected artist, album or track from the Library, Search and random play; unhide under Config",
+		Category:    "Library",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.hideSelected()
+		},
+	})
+	r.register(Command{
+		ID:          "library.delete",
+		Name:        "Delete File",
+		Description: "Move the selected local track's file to the trash and drop it from the library and queue ([library] allow_delete)",
+		Category:    "Library",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.deleteSelected()
+		},
+	})
+	r.register(Command{
+		ID:          "queue.radio",
+		Name:        "Start Radio",
+		Description: "Replace the queue with an endless station of tracks like the selected track or artist",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.startRadio()
+		},
+	})
+	r.register(Command{
+		ID:          "queue.stop_radio",
+		Name:        "Stop Radio",
+		Description: "Stop adding radio tracks to the queue",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.stopRadio()
+		},
+	})
+	r.register(Command{
+		ID:          "queue.random_tracks",
+		Name:        "Play Random Tracks",
+		Description: "Queue a page of random tracks and play them, optionally of a genre and years (e.g. jazz 1970s)",
+		Category:    "Queue",
+		Arg:         "genre years",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			m.status = m.msgs.T("random.tracks")
+			return *m, m.randomPlayCmd(StartupOptions{AutoPlay: true, RandomFilter: provider.ParseRandomFilter(arg)}, nil)
+		},
+	})
+	r.register(Command{
+		ID:          "queue.random_album",
+		Name:        "Play Random Album",
+		Description: "Queue every track of a random album and play it",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			m.status = m.msgs.T("random.album")
+			return *m, m.randomAlbumCmd(StartupOptions{AutoPlay: true}, nil)
+		},
+	})
+	r.register(Command{
+		ID:          "queue.random_artist",
+		Name:        "Play Random Artist",
+		Description: "Queue every album of a random artist and play it",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			m.status = m.msgs.T("random.artist")
+			return *m, m.randomArtistCmd(StartupOptions{AutoPlay: true}, nil)
+		},
+	})
+	r.register(Command{
+		ID:          "queue.play_playlist",
+		Name:        "Play Playlist",
+		Description: "Replace the queue with a playlist, found by name, and play it",
+		Category:    "Queue",
+		Arg:         "name",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			m.status = m.msgs.T("status.loading", arg)
+			return *m, m.playPlaylistByNameCmd(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "queue.export",
+		Name:        "Export Queue",
+		Description: "Save the queue to an .m3u8 or .xspf playlist file",
+		Category:    "Queue",
+		Arg:         "file",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			return *m, m.exportQueueCmd(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "queue.import",
+		Name:        "Import Playlist File",
+		Description: "Add the tracks of an .m3u, .m3u8 or .xspf file to the queue",
+		Category:    "Queue",
+		Arg:         "file",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			m.status = m.msgs.T("playlistfile.importing", arg)
+			return *m, m.importPlaylistCmd(arg)
+		},
+	})
+	r.register(Command{
+		ID:          "playlist.export",
+		Name:        "Export Playlist",
+		Description: "Save the selected playlist to an .m3u8 or .xspf file",
+		Category:    "Queue",
+		Arg:         "file",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			if m.screen != screenPlaylists || len(m.playlists) == 0 {
+				m.status = m.msgs.T("status.select_playlist")
+				return *m, nil
+			}
+			p := m.playlists[clamp(m.selection, 0, len(m.playlists)-1)]
+			m.status = m.msgs.T("playlistfile.exporting", p.Name)
+			return *m, m.exportPlaylistCmd(queue.ListeningContext{Kind: queue.ContextPlaylist, ID: p.ID, Title: p.Name}, arg)
+		},
+	})
+	r.register(Command{
+		ID:          "queue.shuffle_remaining",
+		Name:        "Shuffle Remaining",
+		Description: "Shuffle the tracks after the current one; played tracks stay put",
+		Category:    "Queue",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.shuffleRemaining()
+		},
+	})
+
+	// Scrobbling commands
+	r.register(Command{
+		ID:          "scrobble.lastfm_connect",
+		Name:        "Connect Last.fm",
+		Description: "Authorize Tunez with Last.fm and save the session key",
+		Category:    "Scrobbling",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.startLastfmAuth()
+		},
+	})
+
+	r.register(Command{
+		ID:          "scrobble.love",
+		Name:        "Love/Unlove Track",
+		Description: "Love the playing track on scrobblers that support it",
+		Category:    "Scrobbling",
+		Keybinding:  m.cfg.Keybindings.Love,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.toggleLove()
+		},
+	})
 
 	// UI commands
 	r.register(Command{
@@ -230,6 +616,133 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 			return *m, nil
 		},
 	})
+	r.register(Command{
+		ID:          "ui.track_info",
+		Name:        "Track Info",
+		Description: "Show full metadata for the selected or playing track",
+		Category:    "UI",
+		Keybinding:  m.cfg.Keybindings.TrackInfo,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openTrackInfo()
+		},
+	})
+	r.register(Command{
+		ID:          "library.copy_path",
+		Name:        "Copy Path",
+		Description: "Copy the selected or playing track's file path, or its stream URL, to the clipboard",
+		Category:    "Library",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.copyTrackLocation()
+		},
+	})
+	r.register(Command{
+		ID:          "library.open_folder",
+		Name:        "Open Containing Folder",
+		Description: "Open the folder of the selected or playing track's file in the file manager",
+		Category:    "Library",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openTrackFolder()
+		},
+	})
+	r.register(Command{
+		ID:          "library.save_artwork",
+		Name:        "Save Artwork",
+		Description: "Save the playing track's full-size artwork to [artwork] export_dir and run the artwork_saved hook",
+		Category:    "Library",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.saveArtwork()
+		},
+	})
+	r.register(Command{
+		ID:          "ui.about",
+		Name:        "About Artist/Album",
+		Description: "Show the bio or review of the selected artist or album",
+		Category:    "UI",
+		Keybinding:  "I",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openAbout()
+		},
+	})
+	r.register(Command{
+		ID:          "nav.top_tracks",
+		Name:        "Top Tracks",
+		Description: "List the selected artist's most played tracks",
+		Category:    "Navigation",
+		Keybinding:  "T",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openTopTracks()
+		},
+	})
+	r.register(Command{
+		ID:          "nav.classical_mode",
+		Name:        "Classical Mode",
+		Description: "Browse the Library by composer, work and movement",
+		Category:    "Navigation",
+		Keybinding:  "C",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.toggleClassical()
+		},
+	})
+	r.register(Command{
+		ID:          "nav.filter_years",
+		Name:        "Filter by Years",
+		Description: "Limit Library albums and tracks to a decade or years (1970s, 1975-1985, all)",
+		Category:    "Navigation",
+		Keybinding:  "y",
+		Arg:         "years",
+		RunArg: func(m *Model, arg string) (Model, tea.Cmd) {
+			r, err := provider.ParseYearRange(arg)
+			if err != nil {
+				return m.setError(err)
+			}
+			m.screen = screenLibrary
+			return m.setYearFilter(r)
+		},
+	})
+	r.register(Command{
+		ID:          "ui.similar_artists",
+		Name:        "Similar Artists",
+		Description: "List artists like the selected one, those in your library first",
+		Category:    "UI",
+		Keybinding:  "R",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.openSimilar()
+		},
+	})
+	r.register(Command{
+		ID:          "ui.provider_health",
+		Name:        "Provider Health",
+		Description: "Show each capability's status, latency trend and last error",
+		Category:    "UI",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			m.showHealth = true
+			return *m, nil
+		},
+	})
+	r.register(Command{
+		ID:          "ui.zen",
+		Name:        "Zen Mode",
+		Description: "Show only the artwork, track, progress and visualizer until a key is pressed",
+		Category:    "UI",
+		Keybinding:  m.cfg.Keybindings.Zen,
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			return m.enterZen()
+		},
+	})
+	r.register(Command{
+		ID:          "ui.visualizer_style",
+		Name:        "Cycle Visualizer Style",
+		Description: "Switch the Now Playing visualizer between bars, mirrored, waveform and VU meters",
+		Category:    "UI",
+		Keybinding:  "v",
+		Handler: func(m *Model) (Model, tea.Cmd) {
+			if m.visualizer == nil {
+				m.status = m.msgs.T("viz.off")
+				return *m, nil
+			}
+			return m.cycleVisualizerStyle()
+		},
+	})
 	r.register(Command{
 		ID:          "ui.quit",
 		Name:        "Quit",
@@ -237,10 +750,13 @@ func NewCommandRegistry(m *Model) *CommandRegistry {
 		Category:    "UI",
 		Keybinding:  m.cfg.Keybindings.Quit,
 		Handler: func(m *Model) (Model, tea.Cmd) {
-			return *m, tea.Quit
+			return m.quit()
 		},
 	})
 
+	r.registerPluginCommands(m)
+	r.registerUserCommands(m)
+
 	return r
 }
 
@@ -253,6 +769,16 @@ func (r *CommandRegistry) Commands() []Command {
 	return r.commands
 }
 
+// Command returns the command with the given ID.
+func (r *CommandRegistry) Command(id string) (Command, bool) {
+	for _, cmd := range r.commands {
+		if cmd.ID == id {
+			return cmd, true
+		}
+	}
+	return Command{}, false
+}
+
 // SearchableNames returns command names for fuzzy matching.
 func (r *CommandRegistry) SearchableNames() []string {
 	names := make([]string, len(r.commands))
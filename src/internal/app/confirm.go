@@ -0,0 +1,58 @@
+package app
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// confirmPrompt is a destructive action waiting for a yes or no.
+type confirmPrompt struct {
+	title  string
+	detail string
+	run    func(Model) (Model, tea.Cmd)
+	// strict takes only y as a yes, for prompts a stray Enter mustn't pass
+	strict bool
+}
+
+// confirm asks before running a destructive action, or runs it straight
+// away when ui.no_confirm is on.
+func (m Model) confirm(title, detail string, run func(Model) (Model, tea.Cmd)) (Model, tea.Cmd) {
+	if m.cfg.UI.NoConfirm {
+		return run(m)
+	}
+	m.logger.Debug("asking for confirmation", slog.String("title", title))
+	m.confirmPrompt = &confirmPrompt{title: title, detail: detail, run: run}
+	return m, nil
+}
+
+// handleConfirmKey runs the pending action on y or enter and drops it on
+// n, esc or q; other keys are ignored while the prompt is open.
+func (m Model) handleConfirmKey(key string) (Model, tea.Cmd) {
+	p := m.confirmPrompt
+	switch key {
+	case "y", "Y", "enter":
+		if key == "enter" && p.strict {
+			return m, nil
+		}
+		m.confirmPrompt = nil
+		m.logger.Debug("confirmed", slog.String("title", p.title))
+		return p.run(m)
+	case "n", "N", "esc", "q":
+		m.confirmPrompt = nil
+		m.status = m.msgs.T("confirm.cancelled")
+	}
+	return m, nil
+}
+
+// renderConfirm renders the confirmation popup.
+func (m Model) renderConfirm() string {
+	lines := []string{m.theme.Title.Render("  ═══ " + m.confirmPrompt.title + " ═══  ")}
+	if m.confirmPrompt.detail != "" {
+		lines = append(lines, "", m.theme.Text.Render(m.confirmPrompt.detail))
+	}
+	lines = append(lines, "", m.theme.Dim.Render(m.msgs.T("confirm.keys")))
+	content := lipgloss.JoinVertical(lipgloss.Left, lines...)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
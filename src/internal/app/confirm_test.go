@@ -0,0 +1,67 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestConfirmClearQueue(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.screen = screenQueue
+	m.queue.Add(prov.tracks...)
+
+	key := func(k string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+
+	m, _ = updateModel(m, key("C"))
+	if m.confirmPrompt == nil || m.queue.Len() != 3 {
+		t.Fatalf("expected a prompt before clearing, got prompt=%v len=%d", m.confirmPrompt != nil, m.queue.Len())
+	}
+	if view := m.View(); !strings.Contains(view, "Remove all 3 tracks") {
+		t.Errorf("expected the prompt on screen, got:\n%s", view)
+	}
+	// Other keys are swallowed while the prompt is open
+	m, _ = updateModel(m, key("x"))
+	if m.confirmPrompt == nil || m.queue.Len() != 3 {
+		t.Fatal("expected unrelated keys to leave the prompt open")
+	}
+	m, _ = updateModel(m, key("n"))
+	if m.confirmPrompt != nil || m.queue.Len() != 3 || m.status != "Cancelled" {
+		t.Fatalf("expected n to cancel, got prompt=%v len=%d status=%q", m.confirmPrompt != nil, m.queue.Len(), m.status)
+	}
+
+	m, _ = updateModel(m, key("C"))
+	m, _ = updateModel(m, key("y"))
+	if m.confirmPrompt != nil || m.queue.Len() != 0 {
+		t.Fatalf("expected y to clear the queue, got len=%d", m.queue.Len())
+	}
+
+	// ui.no_confirm skips the prompt
+	m.queue.Add(prov.tracks...)
+	m.cfg.UI.NoConfirm = true
+	m, _ = updateModel(m, key("C"))
+	if m.confirmPrompt != nil || m.queue.Len() != 0 {
+		t.Fatalf("expected the queue cleared without asking, got len=%d", m.queue.Len())
+	}
+}
+
+func TestPaletteClearQueue(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.screen = screenQueue
+	m.queue.Add(prov.tracks...)
+	m.selection = 2
+
+	command, _ := m.commandRegistry.Command("queue.clear")
+	m, _ = command.Handler(&m)
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m.queue.Len() != 0 || m.selection != 0 || m.status != "Queue cleared" || cmd == nil {
+		t.Errorf("expected the palette to clear like the queue key, got len=%d selection=%d status=%q", m.queue.Len(), m.selection, m.status)
+	}
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// continueListeningLimit is how many contexts the Continue Listening strip
+// shows, each resumable with its number key.
+const continueListeningLimit = 5
+
+// contextSaveInterval is how far playback moves before the position in the
+// current album/playlist is recorded again.
+const contextSaveInterval = 10.0
+
+type continueListeningMsg struct {
+	items []queue.ListeningContext
+	err   error
+}
+
+type contextTracksMsg struct {
+	context queue.ListeningContext
+	tracks  []provider.Track
+	err     error
+}
+
+// contextFor returns the album or playlist t is being played from, or a zero
+// context when t has no album.
+func (m Model) contextFor(t provider.Track) queue.ListeningContext {
+	if m.playContext.Kind == queue.ContextPlaylist {
+		if idx, ok := m.playContextTracks[t.ID]; ok {
+			lc := m.playContext
+			lc.TrackID = t.ID
+			lc.TrackIndex = idx
+			lc.Position = 0
+			lc.Finished = false
+			return lc
+		}
+	}
+	if t.AlbumID == "" {
+		return queue.ListeningContext{}
+	}
+	return queue.ListeningContext{
+		Kind:       queue.ContextAlbum,
+		ID:         t.AlbumID,
+		Title:      t.AlbumTitle,
+		Subtitle:   t.ArtistName,
+		TrackID:    t.ID,
+		TrackIndex: max(t.TrackNo-1, 0),
+	}
+}
+
+// contextFinished reports whether the track that just ended was the last
+// one of the current context.
+func (m Model) contextFinished() bool {
+	if m.playContext.Kind == queue.ContextPlaylist {
+		return m.playContext.TrackIndex >= len(m.playContextTracks)-1
+	}
+	items := m.queue.Items()
+	next := m.queue.CurrentIndex() + 1
+	return next >= len(items) || items[next].AlbumID != m.playContext.ID
+}
+
+// recordContextCmd stores the current position in the playing album or
+// playlist and refreshes the Continue Listening strip.
+func (m Model) recordContextCmd() tea.Cmd {
+	if m.queueStore == nil || !m.cfg.Queue.Persist || m.playContext.ID == "" {
+		return nil
+	}
+	lc := m.playContext
+	lc.ProfileID = m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := m.queueStore.RecordContext(ctx, lc); err != nil {
+			return continueListeningMsg{err: err}
+		}
+		items, err := m.queueStore.RecentContexts(ctx, lc.ProfileID, continueListeningLimit+1)
+		return continueListeningMsg{items: items, err: err}
+	}
+}
+
+// loadContinueListeningCmd loads the Continue Listening strip of the active
+// profile.
+func (m Model) loadContinueListeningCmd() tea.Cmd {
+	if m.queueStore == nil || !m.cfg.Queue.Persist {
+		return nil
+	}
+	profileID := m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		items, err := m.queueStore.RecentContexts(ctx, profileID, continueListeningLimit+1)
+		return continueListeningMsg{items: items, err: err}
+	}
+}
+
+// continueListening returns the contexts shown in the strip, leaving out the
+// one playing now.
+func (m Model) continueListening() []queue.ListeningContext {
+	var out []queue.ListeningContext
+	for _, lc := range m.continueItems {
+		if lc.Kind == m.playContext.Kind && lc.ID == m.playContext.ID && m.nowPlaying.ID != "" {
+			continue
+		}
+		out = append(out, lc)
+		if len(out) == continueListeningLimit {
+			break
+		}
+	}
+	return out
+}
+
+// playContextCmd loads every track of an album or playlist so it can replace
+// the queue, starting from lc's track.
+func (m Model) playContextCmd(lc queue.ListeningContext) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		tracks, err := m.contextTracks(ctx, lc)
+		return contextTracksMsg{context: lc, tracks: tracks, err: err}
+	}
+}
+
+// contextTracks lists every track of an album or playlist.
+func (m Model) contextTracks(ctx context.Context, lc queue.ListeningContext) ([]provider.Track, error) {
+	var tracks []provider.Track
+	cursor := ""
+	for {
+		var page provider.Page[provider.Track]
+		var err error
+		if lc.Kind == queue.ContextPlaylist {
+			page, err = m.provider.ListTracks(ctx, "", "", lc.ID, provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+		} else {
+			page, err = m.provider.ListTracks(ctx, lc.ID, "", "", provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+		}
+		if err != nil {
+			return nil, err
+		}
+		tracks = append(tracks, page.Items...)
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return m.bookOrder(tracks), nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// handleContextTracks replaces the queue with a loaded album or playlist and
+// plays it from the saved track and position.
+func (m Model) handleContextTracks(msg contextTracksMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if len(msg.tracks) == 0 {
+		m.status = m.msgs.T("status.nothing_in", msg.context.Title)
+		return m, nil
+	}
+
+	start := clamp(msg.context.TrackIndex, 0, len(msg.tracks)-1)
+	for i, t := range msg.tracks {
+		if t.ID == msg.context.TrackID {
+			start = i
+			break
+		}
+	}
+
+	m.queue.Clear()
+	m.radio, m.radioLoading = nil, false
+	added := m.queue.Add(msg.tracks...)
+	start = min(start, added-1)
+	m.playContext = msg.context
+	m.playContextTracks = nil
+	if msg.context.Kind == queue.ContextPlaylist {
+		m.playContextTracks = make(map[string]int, len(msg.tracks))
+		for i, t := range msg.tracks {
+			m.playContextTracks[t.ID] = i
+		}
+	}
+	m.pendingSeek = 0
+	m.pendingSeekTrackID = ""
+	if msg.tracks[start].ID == msg.context.TrackID && msg.context.Position > 0 {
+		m.pendingSeek = msg.context.Position
+		m.pendingSeekTrackID = msg.context.TrackID
+	}
+	m.logger.Debug("playing context",
+		slog.String("kind", string(msg.context.Kind)),
+		slog.String("id", msg.context.ID),
+		slog.Int("start", start),
+		slog.Float64("position", m.pendingSeek))
+	m.screen = screenNowPlaying
+	m.status = m.msgs.T("continue.resuming", msg.context.Title)
+	m.queueFull(added, len(msg.tracks))
+	return m, tea.Batch(m.playQueueTrackCmd(start), m.saveQueueCmd())
+}
+
+// applyPendingSeek jumps to the resume position once the resumed track has
+// loaded.
+func (m *Model) applyPendingSeek() tea.Cmd {
+	if m.pendingSeekTrackID == "" || m.nowPlaying.ID != m.pendingSeekTrackID || m.duration <= 0 {
+		return nil
+	}
+	delta := math.Min(m.pendingSeek, m.duration-1) - m.timePos
+	m.pendingSeek = 0
+	m.pendingSeekTrackID = ""
+	if delta <= 0 {
+		return nil
+	}
+	return m.seekCmd(delta)
+}
+
+// renderContinueListening renders the Continue Listening strip on Now
+// Playing, or nothing when there is nothing to resume.
+func (m Model) renderContinueListening() string {
+	items := m.continueListening()
+	if len(items) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteString("\n" + m.theme.Title.Render("Continue Listening") + "\n")
+	for i, lc := range items {
+		icon := "◉"
+		if lc.Kind == queue.ContextPlaylist {
+			icon = "♫"
+		}
+		line := fmt.Sprintf("  %d %s %s", i+1, icon, lc.Title)
+		if lc.Subtitle != "" {
+			line += " - " + lc.Subtitle
+		}
+		where := fmt.Sprintf("  track %d", lc.TrackIndex+1)
+		if lc.Position > 0 {
+			where += " @ " + formatPosition(lc.Position)
+		}
+		b.WriteString(m.theme.Text.Render(line) + m.theme.Dim.Render(where) + "\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,92 @@
+package app
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestContinueListening(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+	m.cfg.Queue.Persist = true
+
+	// Playing a track records its album
+	track := prov.tracks[1]
+	track.AlbumTitle = "Abbey Road"
+	track.TrackNo = 2
+	m, _ = updateModel(m, playTrackMsg{track: track})
+	if m.playContext.Kind != queue.ContextAlbum || m.playContext.ID != "10" {
+		t.Fatalf("expected album context, got %+v", m.playContext)
+	}
+	pos := 95.0
+	m, _ = updateModel(m, playerMsg{TimePos: &pos})
+	if m.playContext.Position != 95 {
+		t.Fatalf("expected position to be tracked, got %v", m.playContext.Position)
+	}
+	m, _ = updateModel(m, m.recordContextCmd()())
+	// The album playing now isn't offered
+	if len(m.continueListening()) != 0 {
+		t.Fatalf("expected the playing album to be hidden, got %+v", m.continueListening())
+	}
+
+	items, err := store.RecentContexts(t.Context(), m.cfg.ActiveProfile, 5)
+	if err != nil || len(items) != 1 || items[0].TrackID != "101" || items[0].Position != 95 {
+		t.Fatalf("expected album position to be recorded, got %+v %v", items, err)
+	}
+
+	m.nowPlaying.ID = ""
+	m.screen = screenNowPlaying
+	m, _ = updateModel(m, continueListeningMsg{items: items})
+	if view := m.renderNowPlaying(); !strings.Contains(view, "Continue Listening") || !strings.Contains(view, "Abbey Road - The Beatles  track 2 @ 1:35") {
+		t.Errorf("expected Continue Listening strip, got:\n%s", view)
+	}
+
+	// One key resumes at the saved track and position
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'1'}})
+	if cmd == nil {
+		t.Fatal("expected resume command")
+	}
+	msg, ok := cmd().(contextTracksMsg)
+	if !ok || len(msg.tracks) != 3 {
+		t.Fatalf("expected album tracks, got %#v", msg)
+	}
+	m, _ = updateModel(m, msg)
+	if m.queue.Len() != 3 || m.pendingSeekTrackID != "101" || m.pendingSeek != 95 {
+		t.Errorf("expected queue of 3 resuming 101 at 95s, got len=%d seek=%q@%v", m.queue.Len(), m.pendingSeekTrackID, m.pendingSeek)
+	}
+}
+
+func TestPlaylistEnterPlaysContext(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenPlaylists
+	m.playlists = []provider.Playlist{{ID: "p1", Name: "Mix"}, {ID: "p2", Name: "Road Trip"}}
+	m.selection = 1
+
+	// "p" is PrevTrack elsewhere, so Enter plays the playlist
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("expected the playlist to load")
+	}
+	msg, ok := cmd().(contextTracksMsg)
+	if !ok || msg.context.Kind != queue.ContextPlaylist || msg.context.ID != "p2" {
+		t.Fatalf("expected the selected playlist's tracks, got %#v", msg)
+	}
+	m, _ = updateModel(m, msg)
+	if m.playContext.Kind != queue.ContextPlaylist || m.playContext.ID != "p2" || m.queue.Len() != len(msg.tracks) {
+		t.Errorf("expected the playlist playing as the context, got %+v with %d queued", m.playContext, m.queue.Len())
+	}
+}
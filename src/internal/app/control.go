@@ -0,0 +1,160 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/control"
+	"github.com/tunez/tunez/internal/nowplaying"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// controlMsg is a request from "tunez status", "tunez next" and friends.
+type controlMsg struct {
+	call *control.Call
+}
+
+// controlAddMsg carries the search results for a "tunez add" request.
+type controlAddMsg struct {
+	call   *control.Call
+	tracks []provider.Track
+	err    error
+}
+
+// waitControlCmd waits for the next control request.
+func (m Model) waitControlCmd() tea.Cmd {
+	if m.control == nil {
+		return nil
+	}
+	calls := m.control.Calls()
+	return func() tea.Msg {
+		call, ok := <-calls
+		if !ok {
+			return nil
+		}
+		return controlMsg{call: call}
+	}
+}
+
+// handleControl answers a control request, running the same commands as
+// the palette.
+func (m Model) handleControl(call *control.Call) (Model, tea.Cmd) {
+	cmds := []tea.Cmd{m.waitControlCmd()}
+	run := func(id, message string) {
+		c, _ := m.commandRegistry.Command(id)
+		var cmd tea.Cmd
+		m, cmd = c.Handler(&m)
+		cmds = append(cmds, cmd)
+		call.Reply(control.Response{OK: true, Message: message})
+	}
+
+	switch call.Cmd {
+	case control.CmdStatus:
+		call.Reply(control.Response{OK: true, Status: m.controlStatus()})
+	case control.CmdPlay:
+		run("playback.play", "Playing")
+	case control.CmdPause:
+		var cmd tea.Cmd
+		m, cmd = m.pause()
+		cmds = append(cmds, cmd)
+		call.Reply(control.Response{OK: true, Message: "Paused"})
+	case control.CmdToggle:
+		if m.nowPlaying.ID == "" {
+			run("playback.play", "Playing")
+		} else if m.paused {
+			run("playback.play_pause", "Playing")
+		} else {
+			run("playback.play_pause", "Paused")
+		}
+	case control.CmdNext:
+		if _, err := m.queue.PeekNext(); err != nil && m.queue.RepeatMode() == queue.RepeatOff {
+			call.Reply(control.Response{Error: "no next track"})
+			break
+		}
+		run("playback.next", "Skipped to next track")
+	case control.CmdPrev:
+		if m.queue.Len() == 0 {
+			call.Reply(control.Response{Error: "queue is empty"})
+			break
+		}
+		run("playback.prev", "Back to previous track")
+	case control.CmdEnqueue:
+		var cmd tea.Cmd
+		m, cmd = m.handleEnqueue(call)
+		cmds = append(cmds, cmd)
+	case control.CmdAdd:
+		if call.Arg == "" {
+			call.Reply(control.Response{Error: "add needs a search query"})
+			break
+		}
+		cmds = append(cmds, m.controlAddCmd(call))
+	default:
+		call.Reply(control.Response{Error: fmt.Sprintf("unknown command %q", call.Cmd)})
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// controlAddCmd searches for the tracks a "tunez add" request names.
+func (m Model) controlAddCmd(call *control.Call) tea.Cmd {
+	prov, pageSize := m.provider, m.cfg.UI.PageSize
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		res, err := prov.Search(ctx, call.Arg, provider.ListReq{PageSize: pageSize})
+		return controlAddMsg{call: call, tracks: res.Tracks.Items, err: err}
+	}
+}
+
+func (m Model) handleControlAdd(msg controlAddMsg) (Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		msg.call.Reply(control.Response{Error: msg.err.Error()})
+		return m, nil
+	case len(msg.tracks) == 0:
+		msg.call.Reply(control.Response{Error: fmt.Sprintf("no tracks match %q", msg.call.Arg)})
+		return m, nil
+	}
+	added := m.queue.Add(msg.tracks...)
+	m.status = m.msgs.T("status.added_tracks", added)
+	m.queueFull(added, len(msg.tracks))
+	msg.call.Reply(control.Response{OK: true, Message: m.status})
+	return m, m.saveQueueCmd()
+}
+
+// pause pauses playback if it is playing.
+func (m Model) pause() (Model, tea.Cmd) {
+	if m.nowPlaying.ID == "" || m.paused {
+		return m, nil
+	}
+	m.paused = true
+	return m, func() tea.Msg {
+		if err := m.player.FadePause(true); err != nil {
+			return playerMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// controlStatus describes the player for "tunez status".
+func (m Model) controlStatus() *control.Status {
+	status := nowplaying.StatusStopped
+	if m.nowPlaying.ID != "" {
+		status = nowplaying.StatusPlaying
+		if m.paused {
+			status = nowplaying.StatusPaused
+		}
+	}
+	return &control.Status{
+		Info:        nowplaying.FromTrack(m.nowPlaying, status),
+		PositionSec: int(m.timePos),
+		Volume:      int(m.volume),
+		Muted:       m.muted,
+		Shuffle:     m.queue.IsShuffled(),
+		Repeat:      m.queue.RepeatMode().String(),
+		QueueIndex:  m.queue.CurrentIndex(),
+		QueueLength: m.queue.Len(),
+	}
+}
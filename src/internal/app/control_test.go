@@ -0,0 +1,67 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/control"
+)
+
+func TestControlRequests(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tunez.sock")
+	srv, err := control.Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.provider = prov
+	m.control = srv
+	m.queue.Add(prov.tracks...)
+	m.queue.SetCurrent(1)
+	m.nowPlaying = prov.tracks[1]
+	m.timePos = 65
+
+	send := func(req control.Request) control.Response {
+		t.Helper()
+		done := make(chan control.Response)
+		go func() {
+			resp, err := control.Send(path, req)
+			if err != nil {
+				t.Error(err)
+			}
+			done <- resp
+		}()
+		msg := m.waitControlCmd()()
+		m, _ = updateModel(m, msg)
+		// add searches in the background before answering
+		if c, ok := msg.(controlMsg); ok && c.call.Cmd == control.CmdAdd {
+			m, _ = updateModel(m, m.controlAddCmd(c.call)())
+		}
+		return <-done
+	}
+
+	resp := send(control.Request{Cmd: control.CmdStatus})
+	st := resp.Status
+	if !resp.OK || st == nil || st.Status != "playing" || st.Title != prov.tracks[1].Title || st.PositionSec != 65 || st.QueueIndex != 1 || st.QueueLength != 3 {
+		t.Fatalf("unexpected status %+v", st)
+	}
+
+	resp = send(control.Request{Cmd: control.CmdPause})
+	if !resp.OK || !m.paused {
+		t.Errorf("expected pause, got %+v paused=%v", resp, m.paused)
+	}
+
+	resp = send(control.Request{Cmd: control.CmdAdd, Arg: "Something"})
+	if !resp.OK || m.queue.Len() == 3 {
+		t.Errorf("expected tracks added, got %+v len=%d", resp, m.queue.Len())
+	}
+
+	resp = send(control.Request{Cmd: "dance"})
+	if resp.OK || resp.Error == "" {
+		t.Errorf("expected unknown command error, got %+v", resp)
+	}
+}
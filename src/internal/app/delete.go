@@ -0,0 +1,119 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// trackDeletedMsg reports a Delete File.
+type trackDeletedMsg struct {
+	track provider.Track
+	err   error
+}
+
+// fileDeleter finds the provider that can delete a track's file, looking
+// through merged libraries, and the track's ID there.
+func (m Model) fileDeleter(id string) (provider.FileDeleter, string, bool) {
+	if op, ok := provider.As[originProvider](m.provider); ok {
+		mem, local, ok := op.Origin(id)
+		if !ok {
+			return nil, "", false
+		}
+		fd, ok := provider.As[provider.FileDeleter](mem.Provider)
+		return fd, local, ok
+	}
+	fd, ok := provider.As[provider.FileDeleter](m.provider)
+	return fd, id, ok
+}
+
+// deleteSelected deletes the file of the track selected in the Library or
+// the Search results, once library.allow_delete is on. It asks twice, even
+// with ui.no_confirm, and the second time only y goes ahead, so a repeated
+// Enter can't delete anything.
+func (m Model) deleteSelected() (Model, tea.Cmd) {
+	if !m.cfg.Library.AllowDelete {
+		m.status = m.msgs.T("delete.off")
+		return m, nil
+	}
+	t, ok := m.selectedTrack()
+	if !ok {
+		m.status = m.msgs.T("status.select_track")
+		return m, nil
+	}
+	if _, _, ok := m.fileDeleter(t.ID); !ok {
+		m.status = m.msgs.T("delete.not_local")
+		return m, nil
+	}
+	if t.ID == m.nowPlaying.ID {
+		m.status = m.msgs.T("delete.playing")
+		return m, nil
+	}
+
+	first, detail := m.msgs.T("delete.confirm.trash", t.Title, t.ArtistName), m.msgs.T("delete.restorable")
+	if m.cfg.Library.DeletePermanently {
+		first, detail = m.msgs.T("delete.confirm.permanent", t.Title, t.ArtistName), m.msgs.T("delete.final")
+	}
+	if t.FilePath != "" {
+		first += "\n" + t.FilePath
+	}
+	m.logger.Debug("asking to delete file", slog.String("track_id", t.ID))
+	m.confirmPrompt = &confirmPrompt{title: m.msgs.T("delete.title"), detail: first, run: func(m Model) (Model, tea.Cmd) {
+		m.confirmPrompt = &confirmPrompt{
+			title:  m.msgs.T("delete.really"),
+			detail: m.msgs.T("delete.really.detail", t.Title, detail),
+			strict: true,
+			run: func(m Model) (Model, tea.Cmd) {
+				m.status = m.msgs.T("delete.deleting", t.Title)
+				return m, m.deleteTrackCmd(t)
+			},
+		}
+		return m, nil
+	}}
+	return m, nil
+}
+
+// deleteTrackCmd deletes a track's file and drops it from the index.
+func (m Model) deleteTrackCmd(t provider.Track) tea.Cmd {
+	fd, id, ok := m.fileDeleter(t.ID)
+	if !ok {
+		return nil
+	}
+	permanent := m.cfg.Library.DeletePermanently
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		return trackDeletedMsg{track: t, err: fd.DeleteTrack(ctx, id, permanent)}
+	}
+}
+
+// handleTrackDeleted takes a deleted track out of the lists and the queue.
+func (m Model) handleTrackDeleted(msg trackDeletedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("delete %s: %w", msg.track.Title, msg.err))
+	}
+	m.logger.Info("deleted file", slog.String("track_id", msg.track.ID), slog.String("path", msg.track.FilePath), slog.Bool("permanent", m.cfg.Library.DeletePermanently))
+	deleted := func(t provider.Track) bool { return t.ID == msg.track.ID }
+	m.tracks = slices.DeleteFunc(slices.Clone(m.tracks), deleted)
+	m.searchResults.Tracks.Items = slices.DeleteFunc(slices.Clone(m.searchResults.Tracks.Items), deleted)
+	items := m.queue.Items()
+	for i := len(items) - 1; i >= 0; i-- {
+		if deleted(items[i]) {
+			_ = m.queue.Remove(i)
+		}
+	}
+	if n := m.currentListLen(); m.selection >= n {
+		m.selection = max(n-1, 0)
+	}
+	if m.cfg.Library.DeletePermanently {
+		m.status = m.msgs.T("delete.deleted", msg.track.Title)
+	} else {
+		m.status = m.msgs.T("delete.trashed", msg.track.Title)
+	}
+	return m, m.saveQueueCmd()
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/i18n"
+)
+
+// deletingProvider records the tracks whose files it was asked to delete.
+type deletingProvider struct {
+	*testProvider
+	deleted []string
+}
+
+func (p *deletingProvider) DeleteTrack(_ context.Context, id string, permanent bool) error {
+	p.deleted = append(p.deleted, id)
+	return nil
+}
+
+func TestDeleteFile(t *testing.T) {
+	prov := &deletingProvider{testProvider: newTestProvider()}
+	m := initializeModel(createTestModel(t), prov.testProvider)
+	m.provider = prov
+	m.cfg.UI.NoConfirm = true // deleting asks regardless
+	m.screen = screenLibrary
+	m.tracks = prov.tracks
+	m.selection = 1
+	m.queue.Add(prov.tracks...)
+	key := func(k string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+
+	// Off unless library.allow_delete is set
+	if m, _ := m.deleteSelected(); m.confirmPrompt != nil {
+		t.Fatal("expected no prompt with deleting turned off")
+	}
+	m.cfg.Library.AllowDelete = true
+
+	m, _ = m.deleteSelected()
+	if m.confirmPrompt == nil || m.confirmPrompt.title != "Delete File" {
+		t.Fatalf("expected a first prompt, got %+v", m.confirmPrompt)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirmPrompt == nil || m.confirmPrompt.title != "Really Delete?" {
+		t.Fatalf("expected a second prompt, got %+v", m.confirmPrompt)
+	}
+	// Enter doesn't pass the second prompt
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirmPrompt == nil || cmd != nil {
+		t.Fatal("expected Enter to leave the second prompt open")
+	}
+	m, cmd = updateModel(m, key("y"))
+	if m.confirmPrompt != nil || cmd == nil {
+		t.Fatal("expected y to delete")
+	}
+	m, _ = updateModel(m, cmd())
+	if len(prov.deleted) != 1 || prov.deleted[0] != "101" {
+		t.Fatalf("expected Something deleted, got %v", prov.deleted)
+	}
+	if len(m.tracks) != 2 || m.queue.Len() != 2 || m.status != "Moved to the trash: Something" {
+		t.Errorf("expected the track out of the lists, got %d tracks, %d queued, status %q", len(m.tracks), m.queue.Len(), m.status)
+	}
+	if len(prov.tracks) != 3 {
+		t.Error("expected the provider's own list left alone")
+	}
+
+	// n at either prompt keeps the file
+	m, _ = m.deleteSelected()
+	m, _ = updateModel(m, key("y"))
+	m, _ = updateModel(m, key("n"))
+	if m.confirmPrompt != nil || len(prov.deleted) != 1 {
+		t.Errorf("expected n to cancel, got %v", prov.deleted)
+	}
+
+	// The playing track stays
+	m.nowPlaying = m.tracks[m.selection]
+	if m, _ = m.deleteSelected(); m.confirmPrompt != nil {
+		t.Error("expected no prompt for the playing track")
+	}
+
+	// Providers without files can't delete
+	m.provider = prov.testProvider
+	m.nowPlaying.ID = ""
+	if m, _ = m.deleteSelected(); m.confirmPrompt != nil {
+		t.Error("expected no prompt without a local library")
+	}
+}
+
+func TestDeleteFileLanguage(t *testing.T) {
+	prov := &deletingProvider{testProvider: newTestProvider()}
+	m := initializeModel(createTestModel(t), prov.testProvider)
+	m.provider = prov
+	m.msgs = i18n.New("de")
+	m.cfg.Library.AllowDelete = true
+	m.screen = screenLibrary
+	m.tracks = prov.tracks
+	m.selection = 1
+
+	m, _ = m.deleteSelected()
+	if p := m.confirmPrompt; p == nil || p.title != "Datei löschen" || !strings.HasPrefix(p.detail, "Something von The Beatles in den Papierkorb") {
+		t.Fatalf("expected the German prompt, got %+v", p)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.confirmPrompt == nil || m.confirmPrompt.title != "Wirklich löschen?" {
+		t.Fatalf("expected the German second prompt, got %+v", m.confirmPrompt)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.status != "Abgebrochen" {
+		t.Errorf("expected a German status, got %q", m.status)
+	}
+}
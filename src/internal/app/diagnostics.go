@@ -4,16 +4,25 @@ import (
 	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/metrics"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/scrobble"
 )
 
 // DiagnosticsState holds diagnostic metrics for the debug overlay.
+// Provider requests and artwork lookups are recorded from command
+// goroutines, so every method locks.
 type DiagnosticsState struct {
+	mu sync.Mutex
+
 	// Request timing
 	LastRequestLatency time.Duration
 	RequestCount       int
+	RequestErrors      int
 	TotalRequestTime   time.Duration
 
 	// Cache stats
@@ -30,30 +39,72 @@ type DiagnosticsState struct {
 	VisualizerRunning bool
 	VisualizerFPS     int
 
+	// Playback
+	TracksPlayed int
+
 	// App stats
 	StartTime      time.Time
 	LastUpdate     time.Time
 	MemoryUsage    uint64
 	GoroutineCount int
+
+	// areas holds each capability's calls for the Provider Health panel
+	areas map[string]*areaHealth
+
+	// metrics receives every recorded event, for the /metrics endpoint
+	metrics *metrics.Registry
 }
 
-// NewDiagnosticsState creates a new diagnostics state.
+// NewDiagnosticsState creates a new diagnostics state that also records
+// into metrics.Default.
 func NewDiagnosticsState() *DiagnosticsState {
 	return &DiagnosticsState{
 		StartTime:    time.Now(),
 		MPVConnected: true,
+		metrics:      metrics.Default,
 	}
 }
 
 // RecordRequest records a provider request latency.
 func (d *DiagnosticsState) RecordRequest(latency time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.LastRequestLatency = latency
 	d.RequestCount++
 	d.TotalRequestTime += latency
+	d.metrics.Histogram("tunez_provider_request_duration_seconds", "Provider request latency.", nil).Observe(latency)
+}
+
+// ObserveProvider is a provider.Observer that records each call.
+func (d *DiagnosticsState) ObserveProvider(op string, latency time.Duration, err error) {
+	d.RecordRequest(latency)
+	d.mu.Lock()
+	d.recordArea(op, latency, err)
+	if err == nil {
+		d.mu.Unlock()
+		return
+	}
+	d.RequestErrors++
+	d.mu.Unlock()
+	d.metrics.Counter("tunez_provider_errors_total", "Failed provider requests by method.", "method", op).Inc()
+}
+
+// observed wraps p so its requests show up in the overlay.
+func (m Model) observed(p provider.Provider) provider.Provider {
+	if p == nil {
+		return nil
+	}
+	return provider.Observe(p, m.diagnosticsState.ObserveProvider)
 }
 
 // AverageLatency returns the average request latency.
 func (d *DiagnosticsState) AverageLatency() time.Duration {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.averageLatency()
+}
+
+func (d *DiagnosticsState) averageLatency() time.Duration {
 	if d.RequestCount == 0 {
 		return 0
 	}
@@ -62,16 +113,28 @@ func (d *DiagnosticsState) AverageLatency() time.Duration {
 
 // RecordArtworkCacheHit records an artwork cache hit.
 func (d *DiagnosticsState) RecordArtworkCacheHit() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.ArtworkCacheHits++
+	d.metrics.Counter("tunez_artwork_cache_requests_total", "Artwork cache lookups by result.", "result", "hit").Inc()
 }
 
 // RecordArtworkCacheMiss records an artwork cache miss.
 func (d *DiagnosticsState) RecordArtworkCacheMiss() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.ArtworkCacheMisses++
+	d.metrics.Counter("tunez_artwork_cache_requests_total", "Artwork cache lookups by result.", "result", "miss").Inc()
 }
 
 // ArtworkCacheHitRate returns the cache hit rate as a percentage.
 func (d *DiagnosticsState) ArtworkCacheHitRate() float64 {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.artworkCacheHitRate()
+}
+
+func (d *DiagnosticsState) artworkCacheHitRate() float64 {
 	total := d.ArtworkCacheHits + d.ArtworkCacheMisses
 	if total == 0 {
 		return 0
@@ -81,19 +144,53 @@ func (d *DiagnosticsState) ArtworkCacheHitRate() float64 {
 
 // RecordMPVError records an mpv error.
 func (d *DiagnosticsState) RecordMPVError(err string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.LastMPVError = err
 	d.LastMPVErrorAt = time.Now()
 	d.MPVConnected = false
+	d.metrics.Counter("tunez_mpv_errors_total", "mpv connection errors.").Inc()
 }
 
 // RecordMPVReconnect records an mpv reconnection.
 func (d *DiagnosticsState) RecordMPVReconnect() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
 	d.MPVReconnects++
 	d.MPVConnected = true
+	d.metrics.Counter("tunez_mpv_reconnects_total", "mpv reconnections after an error.").Inc()
+}
+
+// RecordTrackPlayed counts a track that started playing.
+func (d *DiagnosticsState) RecordTrackPlayed() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.TracksPlayed++
+	d.metrics.Counter("tunez_tracks_played_total", "Tracks started.").Inc()
+}
+
+// RecordScrobble counts the outcome of a scrobble on one backend. It is
+// called from scrobbler goroutines, so it only touches the metrics.
+func (d *DiagnosticsState) RecordScrobble(scrobblerID string, status scrobble.SubmitStatus) {
+	d.metrics.Counter("tunez_scrobbles_total", "Scrobbles by backend and result.", "scrobbler", scrobblerID, "result", string(status)).Inc()
+}
+
+// SetVisualizer records whether the visualizer is running.
+func (d *DiagnosticsState) SetVisualizer(running bool, fps int) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.VisualizerRunning = running
+	d.VisualizerFPS = fps
 }
 
 // Update refreshes runtime stats.
 func (d *DiagnosticsState) Update() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.update()
+}
+
+func (d *DiagnosticsState) update() {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 	d.MemoryUsage = m.Alloc
@@ -108,7 +205,9 @@ func (d *DiagnosticsState) Uptime() time.Duration {
 
 // Render renders the diagnostics overlay.
 func (d *DiagnosticsState) Render(m *Model) string {
-	d.Update()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.update()
 
 	var b strings.Builder
 
@@ -135,7 +234,11 @@ func (d *DiagnosticsState) Render(m *Model) string {
 	b.WriteString(fmt.Sprintf("  Requests: %d\n", d.RequestCount))
 	if d.RequestCount > 0 {
 		b.WriteString(fmt.Sprintf("  Last latency: %s\n", d.LastRequestLatency.Round(time.Millisecond)))
-		b.WriteString(fmt.Sprintf("  Avg latency: %s\n", d.AverageLatency().Round(time.Millisecond)))
+		b.WriteString(fmt.Sprintf("  Avg latency: %s\n", d.averageLatency().Round(time.Millisecond)))
+	}
+	if d.RequestErrors > 0 {
+		b.WriteString(m.theme.Error.Render(fmt.Sprintf("  Errors: %d", d.RequestErrors)))
+		b.WriteString("\n")
 	}
 	b.WriteString("\n")
 
@@ -145,10 +248,17 @@ func (d *DiagnosticsState) Render(m *Model) string {
 	total := d.ArtworkCacheHits + d.ArtworkCacheMisses
 	if total > 0 {
 		b.WriteString(fmt.Sprintf("  Hits: %d / Misses: %d\n", d.ArtworkCacheHits, d.ArtworkCacheMisses))
-		b.WriteString(fmt.Sprintf("  Hit rate: %.1f%%\n", d.ArtworkCacheHitRate()))
+		b.WriteString(fmt.Sprintf("  Hit rate: %.1f%%\n", d.artworkCacheHitRate()))
 	} else {
 		b.WriteString("  No requests yet\n")
 	}
+	if m.artworkCache != nil {
+		st := m.artworkCache.Stats()
+		b.WriteString(fmt.Sprintf("  Entries: %d (%s of %s)\n", st.Entries, formatBytes(uint64(st.Bytes)), formatBytes(uint64(st.MaxBytes))))
+		if st.Evicted > 0 {
+			b.WriteString(fmt.Sprintf("  Evicted: %d\n", st.Evicted))
+		}
+	}
 	b.WriteString("\n")
 
 	// mpv status
@@ -173,17 +283,46 @@ func (d *DiagnosticsState) Render(m *Model) string {
 	b.WriteString("\n")
 	if m.visualizer != nil && d.VisualizerRunning {
 		b.WriteString(m.theme.Success.Render("  ● Running"))
-		b.WriteString(fmt.Sprintf(" (~%d fps)\n", d.VisualizerFPS))
+		b.WriteString(fmt.Sprintf(" (%s, ~%d fps)\n", m.visualizer.Backend(), d.VisualizerFPS))
 	} else if m.visualizer != nil {
-		b.WriteString(m.theme.Dim.Render("  ○ Stopped\n"))
+		b.WriteString(m.theme.Dim.Render(fmt.Sprintf("  ○ Stopped (%s)\n", m.visualizer.Backend())))
 	} else {
-		b.WriteString(m.theme.Dim.Render("  ○ Not available (cava not installed)\n"))
+		b.WriteString(m.theme.Dim.Render("  ○ Off\n"))
 	}
 	b.WriteString("\n")
 
+	// Scrobbling
+	if m.scrobbler != nil {
+		b.WriteString(m.theme.Accent.Render("Scrobbling"))
+		b.WriteString("\n")
+		if m.scrobbler.Offline() {
+			b.WriteString(m.theme.Error.Render("  ○ Offline, retries paused"))
+			b.WriteString("\n")
+		}
+		for _, st := range m.scrobbler.PendingStatus() {
+			b.WriteString(fmt.Sprintf("  %s: %d pending", st.Name, st.Pending))
+			if !st.Enabled {
+				b.WriteString(", disabled")
+			} else if !st.NextRetry.IsZero() && st.Pending > 0 {
+				wait := time.Until(st.NextRetry).Round(time.Second)
+				if wait < 0 {
+					wait = 0
+				}
+				b.WriteString(fmt.Sprintf(" (retry %s)", wait))
+			}
+			b.WriteString("\n")
+			if st.LastError != "" {
+				b.WriteString(m.theme.Error.Render(fmt.Sprintf("    %s", st.LastError)))
+				b.WriteString("\n")
+			}
+		}
+		b.WriteString("\n")
+	}
+
 	// Playback
 	b.WriteString(m.theme.Accent.Render("Playback"))
 	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("  Tracks played: %d\n", d.TracksPlayed))
 	if m.nowPlaying.Title != "" {
 		state := "Playing"
 		if m.paused {
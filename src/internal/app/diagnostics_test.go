@@ -1,8 +1,17 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/tunez/tunez/internal/artwork"
+	"github.com/tunez/tunez/internal/player"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/scrobble"
+	"github.com/tunez/tunez/internal/scrobble/lastfm"
 )
 
 func TestDiagnosticsState(t *testing.T) {
@@ -95,6 +104,97 @@ func TestDiagnosticsState(t *testing.T) {
 	})
 }
 
+func TestDiagnosticsRenderScrobbling(t *testing.T) {
+	m := createTestModel(t)
+	m.width, m.height = 120, 60
+
+	mgr := scrobble.NewManager()
+	lfm := lastfm.New("lastfm", lastfm.Config{}) // not configured, queues offline
+	mgr.Register(lfm)
+	_ = lfm.Scrobble(nil, scrobble.Track{Title: "Song", StartedAt: time.Now()})
+	m.scrobbler = mgr
+
+	view := NewDiagnosticsState().Render(&m)
+	for _, want := range []string{"Scrobbling", "Last.fm: 1 pending, disabled"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected diagnostics to contain %q", want)
+		}
+	}
+}
+
+func TestDiagnosticsRenderArtworkCache(t *testing.T) {
+	m := createTestModel(t)
+	m.width, m.height = 120, 60
+	cache, err := artwork.NewCache(t.TempDir(), 30, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	m.artworkCache = cache
+	cache.Set("cover", 20, 10, artwork.QualityMedium, artwork.ScaleFit, strings.Repeat("x", 2048))
+	// Waits for the startup prune, then counts the write
+	cache.Prune()
+
+	d := NewDiagnosticsState()
+	d.RecordArtworkCacheHit()
+	view := d.Render(&m)
+	for _, want := range []string{"Hit rate: 100.0%", "Entries: 1 (2.0 KB of 1.0 MB)"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected diagnostics to contain %q", want)
+		}
+	}
+}
+
+type missingArtistProvider struct{ *testProvider }
+
+func (missingArtistProvider) GetArtist(ctx context.Context, id string) (provider.Artist, error) {
+	return provider.Artist{}, provider.ErrNotFound
+}
+
+func TestDiagnosticsObservesProvider(t *testing.T) {
+	m := createTestModel(t)
+	m.width, m.height = 120, 60
+	m.diagnosticsState = NewDiagnosticsState()
+	prov := m.observed(missingArtistProvider{newTestProvider()})
+
+	if _, err := prov.ListArtists(context.Background(), provider.ListReq{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := prov.GetArtist(context.Background(), "missing"); err == nil {
+		t.Fatal("expected an error for an unknown artist")
+	}
+	if d := m.diagnosticsState; d.RequestCount != 2 || d.RequestErrors != 1 {
+		t.Errorf("expected 2 requests with 1 error, got %d and %d", d.RequestCount, d.RequestErrors)
+	}
+	view := m.diagnosticsState.Render(&m)
+	for _, want := range []string{"Requests: 2", "Errors: 1", "Avg latency"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected diagnostics to contain %q", want)
+		}
+	}
+}
+
+func TestPlayerDisconnectRestarts(t *testing.T) {
+	m := createTestModel(t)
+	m.nowPlaying = provider.Track{ID: "101", Title: "Song"}
+	m.timePos = 95
+
+	m, cmd := updateModel(m, playerMsg{Err: fmt.Errorf("%w: EOF", player.ErrDisconnected)})
+	if cmd == nil || !strings.Contains(m.status, "restarting") {
+		t.Fatalf("expected a restart, got status %q", m.status)
+	}
+	if m.diagnosticsState.MPVConnected {
+		t.Error("expected mpv to show as disconnected")
+	}
+
+	m, _ = updateModel(m, playerRestartedMsg{})
+	if d := m.diagnosticsState; !d.MPVConnected || d.MPVReconnects != 1 {
+		t.Errorf("expected 1 reconnect, got %d (connected=%v)", d.MPVReconnects, d.MPVConnected)
+	}
+	if m.pendingSeekTrackID != "101" || m.pendingSeek != 95 {
+		t.Errorf("expected to resume 101 at 95s, got %q@%v", m.pendingSeekTrackID, m.pendingSeek)
+	}
+}
+
 func TestFormatBytes(t *testing.T) {
 	tests := []struct {
 		bytes    uint64
@@ -0,0 +1,63 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// dropFolderPoll is how often the drop folder is checked for new files.
+const dropFolderPoll = 2 * time.Second
+
+// dropTickMsg is the time to check the drop folder.
+type dropTickMsg struct{}
+
+// droppedMsg carries the tracks that arrived in the drop folder.
+type droppedMsg struct {
+	tracks []provider.Track
+	err    error
+}
+
+// dropFolderCmd schedules the next drop folder check. Checks keep running
+// without one, in case a profile switch brings a provider that has one.
+func (m Model) dropFolderCmd() tea.Cmd {
+	return tea.Tick(dropFolderPoll, func(time.Time) tea.Msg {
+		return dropTickMsg{}
+	})
+}
+
+// handleDropTick looks for files that finished arriving in the drop folder.
+func (m Model) handleDropTick() (Model, tea.Cmd) {
+	w, ok := provider.As[provider.DropFolderWatcher](m.provider)
+	if !ok || w.DropFolder() == "" {
+		return m, m.dropFolderCmd()
+	}
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		tracks, err := w.TakeDropped(ctx)
+		return droppedMsg{tracks: tracks, err: err}
+	}
+}
+
+// handleDropped appends tracks from the drop folder to the queue.
+func (m Model) handleDropped(msg droppedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("drop folder", slog.Any("err", msg.err))
+	}
+	if len(msg.tracks) == 0 {
+		return m, m.dropFolderCmd()
+	}
+	added := m.queue.Add(msg.tracks...)
+	m.logger.Debug("queued from drop folder", slog.Int("tracks", added))
+	if added == 1 {
+		m.status = m.msgs.T("dropfolder.added", msg.tracks[0].Title)
+	} else {
+		m.status = m.msgs.T("dropfolder.added_many", added)
+	}
+	m.queueFull(added, len(msg.tracks))
+	return m, tea.Batch(m.saveQueueCmd(), m.dropFolderCmd())
+}
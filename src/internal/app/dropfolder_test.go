@@ -0,0 +1,26 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestHandleDropped(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+
+	m, cmd := m.handleDropped(droppedMsg{})
+	if cmd == nil || m.queue.Len() != 0 {
+		t.Fatal("expected nothing queued and the next check scheduled")
+	}
+
+	m, cmd = m.handleDropped(droppedMsg{tracks: []provider.Track{prov.tracks[0], prov.tracks[1]}})
+	if m.queue.Len() != 2 || cmd == nil {
+		t.Fatalf("expected both tracks queued, got %d", m.queue.Len())
+	}
+	if m.status != "Added 2 tracks from drop folder" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+}
@@ -0,0 +1,23 @@
+package app
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/nowplaying"
+)
+
+// exportCmd writes the playing track to the [export] files.
+func (m Model) exportCmd(status string) tea.Cmd {
+	if m.exporter == nil {
+		return nil
+	}
+	exporter, logger := m.exporter, m.logger
+	info := nowplaying.FromTrack(m.nowPlaying, status)
+	return func() tea.Msg {
+		if err := exporter.Write(info); err != nil {
+			logger.Warn("now playing export failed", slog.Any("err", err))
+		}
+		return nil
+	}
+}
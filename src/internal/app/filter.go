@@ -0,0 +1,228 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// listFilter narrows the rows of the Library or Queue list by typed text,
+// without asking the provider.
+type listFilter struct {
+	query  string
+	typing bool   // true while the query is being edited
+	list   string // listKey of the list the filter belongs to
+}
+
+// listKey identifies the list currently on screen, so a filter set on one
+// list doesn't apply after drilling into another.
+func (m Model) listKey() string {
+	switch m.screen {
+	case screenLibrary:
+		if len(m.tracks) > 0 {
+			return "tracks:" + m.currentArtistID + "/" + m.currentAlbumID
+		}
+		if len(m.albums) > 0 {
+			return "albums:" + m.currentArtistID
+		}
+		return "artists"
+	case screenQueue:
+		return "queue"
+	}
+	return ""
+}
+
+// filterShown reports whether the filter prompt belongs to the current list.
+func (m Model) filterShown() bool {
+	return (m.filter.typing || m.filter.query != "") && m.filter.list == m.listKey()
+}
+
+// filterActive reports whether rows of the current list are being narrowed.
+func (m Model) filterActive() bool {
+	return m.filter.query != "" && m.filter.list == m.listKey()
+}
+
+// rowText is the text a row of the current list is matched against.
+func (m Model) rowText(i int) string {
+	switch m.screen {
+	case screenLibrary:
+		if len(m.tracks) > 0 {
+			t := m.tracks[i]
+			return t.ArtistName + " " + t.Title + " " + t.AlbumTitle
+		}
+		if len(m.albums) > 0 {
+			a := m.albums[i]
+			return a.Title + " " + a.ArtistName
+		}
+		return m.artists[i].Name
+	case screenQueue:
+		t := m.queue.At(i)
+		return t.ArtistName + " " + t.Title + " " + t.AlbumTitle
+	}
+	return ""
+}
+
+// rowMatches reports whether row i of the current list passes the filter.
+func (m Model) rowMatches(i int) bool {
+	if !m.filterActive() {
+		return true
+	}
+	return strings.Contains(strings.ToLower(m.rowText(i)), strings.ToLower(m.filter.query))
+}
+
+// nextFilteredRow returns the nearest matching row after (dir > 0) or before
+// (dir < 0) from, or from itself when there is none.
+func (m Model) nextFilteredRow(from, dir int) int {
+	n := m.currentListLen()
+	for i := from + dir; i >= 0 && i < n; i += dir {
+		if m.rowMatches(i) {
+			return i
+		}
+	}
+	return from
+}
+
+// snapSelectionToFilter moves the selection to the first match when the
+// selected row no longer matches.
+func (m *Model) snapSelectionToFilter() {
+	if m.selection < m.currentListLen() && m.rowMatches(m.selection) {
+		return
+	}
+	if i := m.nextFilteredRow(-1, 1); i >= 0 {
+		m.selection = i
+	}
+}
+
+// startFilter opens the filter prompt on the current list, keeping the
+// query if one is already applied to it.
+func (m Model) startFilter() (Model, tea.Cmd) {
+	key := m.listKey()
+	if m.filter.list != key {
+		m.filter = listFilter{list: key}
+	}
+	m.filter.typing = true
+	m.focusedPane = paneContent
+	m.logger.Debug("list filter opened", slog.String("list", key), slog.String("query", m.filter.query))
+	return m, nil
+}
+
+// clearFilter drops the filter and shows every row again.
+func (m Model) clearFilter() (Model, tea.Cmd) {
+	m.filter = listFilter{}
+	m.status = ""
+	return m, nil
+}
+
+// handleFilterKey edits the filter query while the prompt is open. Keys it
+// doesn't use are reported as unhandled so global keys like ctrl+c still
+// work.
+func (m Model) handleFilterKey(msg tea.KeyMsg) (Model, tea.Cmd, bool) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m, cmd := m.clearFilter()
+		return m, cmd, true
+	case tea.KeyEnter:
+		m.filter.typing = false
+		if m.filter.query == "" {
+			m.filter = listFilter{}
+		}
+		return m, nil, true
+	case tea.KeyBackspace:
+		if r := []rune(m.filter.query); len(r) > 0 {
+			m.filter.query = string(r[:len(r)-1])
+		}
+		m.snapSelectionToFilter()
+		return m, nil, true
+	case tea.KeyUp:
+		m.selection = m.nextFilteredRow(m.selection, -1)
+		return m, nil, true
+	case tea.KeyDown:
+		m.selection = m.nextFilteredRow(m.selection, 1)
+		return m, nil, true
+	case tea.KeySpace:
+		m.filter.query += " "
+		return m, nil, true
+	case tea.KeyRunes:
+		m.filter.query += string(msg.Runes)
+		m.snapSelectionToFilter()
+		return m, nil, true
+	}
+	return m, nil, false
+}
+
+// filterHeader is appended to a list title while the filter is shown.
+func (m Model) filterHeader(matches int) string {
+	if !m.filterShown() {
+		return ""
+	}
+	s := "  /" + m.filter.query
+	if m.filter.typing {
+		s += "▏"
+	}
+	if m.filter.query != "" {
+		s += m.theme.Dim.Render(fmt.Sprintf("  (%d of %d)", matches, m.currentListLen()))
+	}
+	return s
+}
+
+// renderFilteredRow renders a list row, highlighting the filter match.
+func (m Model) renderFilteredRow(line string, style lipgloss.Style) string {
+	if !m.filterActive() {
+		return style.Render(line)
+	}
+	start, end, ok := findVisible(line, m.filter.query)
+	if !ok {
+		return style.Render(line)
+	}
+	out := style.Render(line[:start]) + m.theme.Highlight.Render(line[start:end])
+	if end < len(line) {
+		out += style.Render(line[end:])
+	}
+	return out
+}
+
+// findVisible finds q in s case-insensitively, skipping ANSI escape
+// sequences, and returns the byte range of the match in s.
+func findVisible(s, q string) (int, int, bool) {
+	var plain strings.Builder
+	var offsets []int // byte offset in s of each byte in plain
+	for i := 0; i < len(s); i++ {
+		if s[i] == 0x1b && i+1 < len(s) && s[i+1] == '[' {
+			i += 2
+			for i < len(s) && (s[i] < 0x40 || s[i] > 0x7e) {
+				i++
+			}
+			continue
+		}
+		plain.WriteByte(s[i])
+		offsets = append(offsets, i)
+	}
+	lower := strings.ToLower(plain.String())
+	if len(lower) != len(offsets) {
+		return 0, 0, false // case folding changed byte widths
+	}
+	lq := strings.ToLower(q)
+	idx := strings.Index(lower, lq)
+	if idx < 0 || lq == "" {
+		return 0, 0, false
+	}
+	last := idx + len(lq) - 1
+	if last >= len(offsets) {
+		return 0, 0, false
+	}
+	return offsets[idx], offsets[last] + 1, true
+}
+
+// queueMatches counts the queue items passing the filter.
+func (m Model) queueMatches() int {
+	n := 0
+	for i := range m.queue.Len() {
+		if m.rowMatches(i) {
+			n++
+		}
+	}
+	return n
+}
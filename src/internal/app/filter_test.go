@@ -0,0 +1,94 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func typeKeys(m Model, s string) Model {
+	for _, r := range s {
+		m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	return m
+}
+
+func TestLibraryFilter(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+
+	// "/" filters the list instead of opening Search
+	m = typeKeys(m, "/")
+	if m.screen != screenLibrary || !m.filter.typing {
+		t.Fatalf("expected filter prompt on library, got screen=%v typing=%v", m.screen, m.filter.typing)
+	}
+	// Letters go to the query rather than triggering bindings
+	m = typeKeys(m, "qUe")
+	if m.filter.query != "qUe" {
+		t.Fatalf("expected query qUe, got %q", m.filter.query)
+	}
+	if m.selection != 3 {
+		t.Errorf("expected selection to snap to Queue, got %d", m.selection)
+	}
+	view := m.renderLibrary(80, 20)
+	if strings.Contains(view, "Pink Floyd") || !strings.Contains(view, "Queen") || !strings.Contains(view, "/qUe") {
+		t.Errorf("expected only Queen listed, got:\n%s", view)
+	}
+
+	// Enter keeps the filter and returns keys to navigation
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.filter.typing || !m.filterActive() {
+		t.Fatal("expected filter to stay applied after enter")
+	}
+
+	// Esc clears the filter before navigating back
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.filterShown() {
+		t.Error("expected esc to clear the filter")
+	}
+	if view := m.renderLibrary(80, 20); !strings.Contains(view, "Pink Floyd") {
+		t.Errorf("expected all artists after clearing, got:\n%s", view)
+	}
+}
+
+func TestQueueFilterNavigation(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.queue.Add(prov.tracks...)
+	m.screen = screenQueue
+	m.focusedPane = paneContent
+
+	m = typeKeys(m, "/so")
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	// "Something" is the only match, so j/k stay on it
+	if m.selection != 1 {
+		t.Fatalf("expected selection on Something, got %d", m.selection)
+	}
+	m = typeKeys(m, "j")
+	if m.selection != 1 {
+		t.Errorf("expected j to skip hidden rows, got %d", m.selection)
+	}
+
+	// The filter belongs to the list it was set on
+	m.screen = screenLibrary
+	if m.filterActive() {
+		t.Error("expected queue filter not to apply to the library")
+	}
+}
+
+func TestFindVisible(t *testing.T) {
+	s := "\x1b[2mAbba\x1b[0m Queen"
+	start, end, ok := findVisible(s, "m q")
+	if ok {
+		t.Errorf("expected no match across an escape code's letters, got %q", s[start:end])
+	}
+	start, end, ok = findVisible(s, "QUE")
+	if !ok || s[start:end] != "Que" {
+		t.Errorf("expected to find Que, got %v %q", ok, s[start:end])
+	}
+}
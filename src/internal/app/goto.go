@@ -0,0 +1,181 @@
+package app
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// goToMsg carries what Go to Album or Go to Artist opens in the Library:
+// the artist's albums, and with album set, the album's tracks.
+type goToMsg struct {
+	track   provider.Track // what was gone from, to select
+	artist  provider.Artist
+	album   provider.Album
+	albums  provider.Page[provider.Album]
+	tracks  provider.Page[provider.Track]
+	missing string // the album or artist that couldn't be found
+	err     error
+}
+
+// goToTrack returns the track Go to Album and Go to Artist start from: the
+// selected Search, Library or Queue row, else the playing track. A
+// selected album stands in as a track of it.
+func (m Model) goToTrack() (provider.Track, bool) {
+	if t, ok := m.selectedTrack(); ok {
+		return t, true
+	}
+	switch {
+	case m.screen == screenQueue && m.queue.Len() > 0:
+		return m.queue.At(clamp(m.selection, 0, m.queue.Len()-1)), true
+	case m.screen == screenSearch && m.searchFilter == filterAlbums && len(m.searchResults.Albums.Items) > 0:
+		a := m.searchResults.Albums.Items[clamp(m.selection, 0, len(m.searchResults.Albums.Items)-1)]
+		return provider.Track{AlbumID: a.ID, AlbumTitle: a.Title, ArtistID: a.ArtistID, ArtistName: a.ArtistName}, true
+	}
+	return m.nowPlaying, m.nowPlaying.ID != ""
+}
+
+// goTo opens the album, or with album unset the artist, of the selected or
+// playing track in the Library.
+func (m Model) goTo(album bool) (Model, tea.Cmd) {
+	t, ok := m.goToTrack()
+	if !ok {
+		m.status = m.msgs.T("status.select_track")
+		return m, nil
+	}
+	name := t.ArtistName
+	if album {
+		name = t.AlbumTitle
+	}
+	m.logger.Debug("go to", slog.Bool("album", album), slog.String("track_id", t.ID), slog.String("name", name))
+	m.status = m.msgs.T("status.loading", name)
+	return m, m.goToCmd(t, album)
+}
+
+// goToCmd resolves the track's album and artist and loads the artist's
+// albums, and the album's tracks when going to the album.
+func (m Model) goToCmd(t provider.Track, album bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		msg := goToMsg{track: t}
+		msg.artist, msg.album, msg.missing, msg.err = m.resolveAlbum(ctx, t, album)
+		if msg.err != nil {
+			return msg
+		}
+		msg.albums, msg.err = m.provider.ListAlbums(ctx, msg.artist.ID, provider.ListReq{PageSize: m.cfg.UI.PageSize, Sort: m.cfg.UI.Sort.Albums})
+		if msg.err != nil || !album {
+			return msg
+		}
+		msg.tracks, msg.err = m.provider.ListTracks(ctx, msg.album.ID, msg.artist.ID, "", provider.ListReq{PageSize: m.cfg.UI.PageSize, Sort: m.cfg.UI.Sort.Tracks})
+		return msg
+	}
+}
+
+// resolveAlbum finds the IDs of a track's artist and, when album is set,
+// its album. Tracks that don't carry them are looked up by ID, then
+// searched for by name; missing names the one that wasn't found.
+func (m Model) resolveAlbum(ctx context.Context, t provider.Track, album bool) (artist provider.Artist, al provider.Album, missing string, err error) {
+	if (t.ArtistID == "" || album && t.AlbumID == "") && t.ID != "" {
+		if full, err := m.provider.GetTrack(ctx, t.ID); err == nil {
+			t.ArtistID, t.AlbumID = cmp.Or(t.ArtistID, full.ArtistID), cmp.Or(t.AlbumID, full.AlbumID)
+		}
+	}
+	artist = provider.Artist{ID: t.ArtistID, Name: t.ArtistName}
+	if album {
+		al = provider.Album{ID: t.AlbumID, Title: t.AlbumTitle, ArtistID: t.ArtistID, ArtistName: t.ArtistName}
+	}
+	if album && al.ID == "" {
+		res, err := m.provider.Search(ctx, fmt.Sprintf(`album:"%s" artist:"%s"`, t.AlbumTitle, t.ArtistName), provider.ListReq{PageSize: m.cfg.UI.PageSize, SearchKind: provider.SearchAlbums})
+		if err != nil {
+			return artist, al, "", err
+		}
+		found, ok := byName(res.Albums.Items, t.AlbumTitle, func(a provider.Album) string { return a.Title })
+		if !ok {
+			return artist, al, t.AlbumTitle, provider.ErrNotFound
+		}
+		al = found
+		artist.ID = cmp.Or(artist.ID, al.ArtistID)
+	}
+	if artist.ID == "" {
+		res, err := m.provider.Search(ctx, fmt.Sprintf(`artist:"%s"`, t.ArtistName), provider.ListReq{PageSize: m.cfg.UI.PageSize, SearchKind: provider.SearchArtists})
+		if err != nil {
+			return artist, al, "", err
+		}
+		found, ok := byName(res.Artists.Items, t.ArtistName, func(a provider.Artist) string { return a.Name })
+		if !ok {
+			return artist, al, t.ArtistName, provider.ErrNotFound
+		}
+		artist = found
+	}
+	return artist, al, "", nil
+}
+
+// byName returns the item called name, ignoring case, or else the first.
+func byName[T any](items []T, name string, nameOf func(T) string) (T, bool) {
+	var zero T
+	if len(items) == 0 || strings.TrimSpace(name) == "" {
+		return zero, false
+	}
+	if i := slices.IndexFunc(items, func(item T) bool { return strings.EqualFold(nameOf(item), name) }); i >= 0 {
+		return items[i], true
+	}
+	return items[0], true
+}
+
+// handleGoTo opens the Library at the artist's albums, or the album's
+// tracks, selecting what was gone from.
+func (m Model) handleGoTo(msg goToMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		if errors.Is(msg.err, provider.ErrNotFound) {
+			m.status = m.msgs.T("goto.missing_it")
+			if msg.missing != "" {
+				m.status = m.msgs.T("goto.missing", msg.missing)
+			}
+			return m, nil
+		}
+		return m.setError(msg.err)
+	}
+	m = m.pushHistory()
+	var cmd tea.Cmd
+	if m.classical {
+		// The albums are an artist's, not a composer's works
+		m.classical, m.artists, m.artistsCursor = false, nil, ""
+		cmd = m.loadArtistsCmd("")
+	}
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.topTracksOf = ""
+	m.currentArtistID = msg.artist.ID
+	m.albums = groupAlbums(visible(msg.albums.Items, m.hidden.album))
+	m.albumsCursor = msg.albums.NextCursor
+	m.tracks, m.trackVersions, m.tracksCursor, m.currentAlbumID = nil, versionList{}, "", ""
+	m.currentPlaylistID = ""
+	m.selection = 0
+
+	if msg.album.ID == "" {
+		if i := slices.IndexFunc(m.albums, func(a provider.Album) bool { return a.ID == msg.track.AlbumID }); i >= 0 {
+			m.selection = i
+		}
+		m.status = m.msgs.T("goto.artist", cmp.Or(msg.artist.Name, msg.track.ArtistName))
+		return m, cmd
+	}
+	m.currentAlbumID = msg.album.ID
+	m.tracks = m.trackVersions.add(nil, visible(msg.tracks.Items, m.hidden.track), m.cfg.UI.Versions)
+	m.tracksCursor = msg.tracks.NextCursor
+	if i := slices.IndexFunc(m.tracks, func(t provider.Track) bool {
+		return t.ID == msg.track.ID || versionKey(t) == versionKey(msg.track)
+	}); i >= 0 {
+		m.selection = i
+	}
+	m.status = m.msgs.T("goto.album", cmp.Or(msg.album.Title, msg.track.AlbumTitle))
+	return m, cmd
+}
@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestGoToAlbum(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.provider = prov
+	m.screen = screenNowPlaying
+	m.nowPlaying = prov.tracks[1]
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlA})
+	if cmd == nil {
+		t.Fatal("expected the album to load")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.screen != screenLibrary || m.currentAlbumID != "10" || m.currentArtistID != "1" {
+		t.Fatalf("expected Abbey Road in the Library, got screen %v album %q artist %q", m.screen, m.currentAlbumID, m.currentArtistID)
+	}
+	if len(m.tracks) != 3 || m.selection != 1 {
+		t.Errorf("expected the playing track selected, got selection %d of %d", m.selection, len(m.tracks))
+	}
+
+	// Going up a level shows the artist's albums
+	m.history = navHistory{}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if len(m.albums) != 2 || m.libraryView() != "albums" {
+		t.Errorf("expected the artist's albums, got %+v", m.albums)
+	}
+}
+
+func TestGoToArtistFromQueue(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.provider = prov
+	m.queue.Add(prov.tracks...)
+	m.screen = screenQueue
+	m.selection = 2
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, _ = updateModel(m, cmd())
+	if m.screen != screenLibrary || m.currentArtistID != "1" || len(m.tracks) != 0 {
+		t.Fatalf("expected The Beatles' albums, got screen %v artist %q", m.screen, m.currentArtistID)
+	}
+	if m.selection != 0 || m.albums[0].ID != "10" || m.status != "Artist: The Beatles" {
+		t.Errorf("expected the track's album selected, got %d, status %q", m.selection, m.status)
+	}
+}
+
+// idlessProvider lists tracks that don't carry their album's or artist's
+// IDs, so Go to has to search for them.
+type idlessProvider struct {
+	*testProvider
+}
+
+func (p idlessProvider) Search(_ context.Context, q string, req provider.ListReq) (provider.SearchResults, error) {
+	if req.SearchKind == provider.SearchArtists && q == `artist:"Queen"` {
+		return provider.SearchResults{Artists: provider.Page[provider.Artist]{Items: p.artists[3:4]}}, nil
+	}
+	return provider.SearchResults{}, nil
+}
+
+func TestGoToResolvesByName(t *testing.T) {
+	prov := idlessProvider{newTestProvider()}
+	m := initializeModel(createTestModel(t), prov.testProvider)
+	m.provider = prov
+	m.screen = screenNowPlaying
+	m.nowPlaying = provider.Track{ID: "stream-1", Title: "Innuendo", ArtistName: "Queen", AlbumTitle: "Innuendo"}
+
+	m, cmd := m.goTo(false)
+	m, _ = updateModel(m, cmd())
+	if m.currentArtistID != "4" {
+		t.Errorf("expected Queen found by name, got %q", m.currentArtistID)
+	}
+
+	m, cmd = m.goTo(true)
+	m, _ = updateModel(m, cmd())
+	if m.status != "Couldn't find Innuendo in the library" || m.screen != screenLibrary {
+		t.Errorf("expected the album reported missing, got %q", m.status)
+	}
+}
@@ -0,0 +1,244 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// healthTrend is how many recent latencies are kept per capability and for
+// the health check, for the trend line on the Provider Health panel.
+const healthTrend = 20
+
+// healthAreas are the capabilities the Provider Health panel lists, in
+// order.
+var healthAreas = []string{"Library", "Search", "Playlists", "Streaming", "Lyrics", "Artwork"}
+
+// healthArea returns the capability a provider method belongs to, or "" for
+// the health check itself.
+func healthArea(op string) string {
+	switch op {
+	case "Search":
+		return "Search"
+	case "ListPlaylists", "GetPlaylist":
+		return "Playlists"
+	case "GetStream":
+		return "Streaming"
+	case "GetLyrics":
+		return "Lyrics"
+	case "GetArtwork":
+		return "Artwork"
+	case "Health":
+		return ""
+	}
+	return "Library"
+}
+
+// areaHealth is what the panel shows for one capability.
+type areaHealth struct {
+	Calls     int
+	Errors    int
+	LastOK    time.Time
+	LastErr   string
+	LastErrAt time.Time
+	Latencies []time.Duration
+}
+
+// failing reports whether the latest call of the capability failed.
+func (a areaHealth) failing() bool {
+	return !a.LastErrAt.IsZero() && a.LastErrAt.After(a.LastOK)
+}
+
+// recordArea records a provider call against its capability. The caller
+// holds d.mu.
+func (d *DiagnosticsState) recordArea(op string, latency time.Duration, err error) {
+	name := healthArea(op)
+	if name == "" {
+		return
+	}
+	if d.areas == nil {
+		d.areas = make(map[string]*areaHealth)
+	}
+	a := d.areas[name]
+	if a == nil {
+		a = &areaHealth{}
+		d.areas[name] = a
+	}
+	a.Calls++
+	a.Latencies = appendTrend(a.Latencies, latency)
+	if err != nil {
+		a.Errors++
+		a.LastErr = err.Error()
+		a.LastErrAt = time.Now()
+	} else {
+		a.LastOK = time.Now()
+	}
+}
+
+// AreaHealth returns a copy of what has been recorded for a capability.
+func (d *DiagnosticsState) AreaHealth(name string) areaHealth {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	a, ok := d.areas[name]
+	if !ok {
+		return areaHealth{}
+	}
+	out := *a
+	out.Latencies = append([]time.Duration(nil), a.Latencies...)
+	return out
+}
+
+// appendTrend appends d, dropping the oldest entry past healthTrend.
+func appendTrend(trend []time.Duration, d time.Duration) []time.Duration {
+	trend = append(trend, d)
+	if len(trend) > healthTrend {
+		trend = trend[len(trend)-healthTrend:]
+	}
+	return trend
+}
+
+// sparkline draws durations as a row of block characters scaled to the
+// largest.
+func sparkline(trend []time.Duration) string {
+	const blocks = "▁▂▃▄▅▆▇█"
+	steps := []rune(blocks)
+	var top time.Duration
+	for _, d := range trend {
+		top = max(top, d)
+	}
+	var b strings.Builder
+	for _, d := range trend {
+		i := 0
+		if top > 0 {
+			i = int(int64(d) * int64(len(steps)-1) / int64(top))
+		}
+		b.WriteRune(steps[i])
+	}
+	return b.String()
+}
+
+// healthCheckCmd checks the provider every 30 seconds.
+func (m Model) healthCheckCmd() tea.Cmd {
+	return tea.Tick(30*time.Second, func(t time.Time) tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		start := time.Now()
+		ok, details := m.provider.Health(ctx)
+		return healthMsg{ok: ok, details: details, latency: time.Since(start)}
+	})
+}
+
+// handleHealth records a health check and, when the provider has a response
+// cache, switches it to serving saved data while the provider is down and
+// back once it recovers.
+func (m Model) handleHealth(msg healthMsg) (Model, tea.Cmd) {
+	m.healthOK = msg.ok
+	m.healthDetails = msg.details
+	m.healthLatencies = appendTrend(m.healthLatencies, msg.latency)
+	m.healthCheckedAt = time.Now()
+	if msg.ok {
+		m.healthFailedAt = time.Time{}
+	} else if m.healthFailedAt.IsZero() {
+		m.healthFailedAt = time.Now()
+	}
+
+	if offline, ok := provider.As[provider.OfflineServer](m.provider); ok && offline.Offline() == msg.ok {
+		offline.SetOffline(!msg.ok)
+		m.degraded = !msg.ok
+		if m.degraded {
+			m.logger.Warn("provider unreachable, serving cached data", slog.String("details", msg.details))
+			m.status = m.msgs.T("health.offline")
+		} else {
+			m.logger.Info("provider reachable again")
+			m.status = m.msgs.T("health.online")
+		}
+	}
+	return m, m.healthCheckCmd() // Schedule next check
+}
+
+// actionableError turns the provider's rate-limit, server-trouble and
+// offline errors into a message saying what to do about them, and returns
+// any other error's text as is.
+func actionableError(err error) string {
+	wait := "in a moment"
+	if d := provider.RetryAfter(err); d > 0 {
+		wait = "in " + d.Round(time.Second).String()
+	}
+	switch {
+	case provider.IsRateLimited(err):
+		return "Server is rate limiting requests; try again " + wait
+	case provider.IsTemporary(err):
+		return "Server is having trouble; try again " + wait + " (cached data is shown where available)"
+	case provider.IsOffline(err):
+		return "Can't reach the server; check the connection (cached data is shown where available)"
+	}
+	return err.Error()
+}
+
+// staleHeader marks a list header while the lists come from the cache.
+func (m Model) staleHeader() string {
+	if !m.degraded {
+		return ""
+	}
+	return m.theme.Warning.Render("  ⚠ cached")
+}
+
+// renderHealth renders the Provider Health panel: the health check with its
+// latency trend, then each capability's calls, latency trend and last error.
+func (m Model) renderHealth() string {
+	var lines []string
+	status := m.theme.Success.Render("● Reachable")
+	switch {
+	case m.healthCheckedAt.IsZero():
+		status = m.theme.Dim.Render("● Not checked yet")
+	case !m.healthOK:
+		status = m.theme.Error.Render("● Unreachable since " + m.msgs.Clock(m.healthFailedAt) + ": " + m.healthDetails)
+	}
+	// Pad by terminal cells: "●" and non-Latin names take fewer than their bytes
+	label := func(s string) string { return "  " + ui.PadRight(s, max(11, ui.Width(s)+1)) }
+	lines = append(lines, label(m.provider.Name())+status)
+	if m.degraded {
+		lines = append(lines, m.theme.Warning.Render("  Showing cached data; lists marked ⚠ cached may be out of date"))
+	}
+	if n := len(m.healthLatencies); n > 0 {
+		lines = append(lines, m.theme.Dim.Render(fmt.Sprintf("%s%s  last %s", label("Check"), sparkline(m.healthLatencies), m.healthLatencies[n-1].Round(time.Millisecond))))
+	}
+	lines = append(lines, "")
+
+	for _, name := range healthAreas {
+		a := m.diagnosticsState.AreaHealth(name)
+		var line string
+		switch {
+		case a.Calls == 0:
+			line = m.theme.Dim.Render(ui.PadRight("● unused", 9))
+		case a.failing():
+			line = m.theme.Error.Render(ui.PadRight("● failing", 9))
+		default:
+			line = m.theme.Success.Render(ui.PadRight("● OK", 9))
+		}
+		if a.Calls > 0 {
+			line += m.theme.Dim.Render(fmt.Sprintf("  %4d calls  %s", a.Calls, sparkline(a.Latencies)))
+		}
+		lines = append(lines, label(name)+line)
+		if a.LastErr != "" {
+			lastErr := lipgloss.NewStyle().MaxWidth(50).Render(a.LastErr)
+			lines = append(lines, m.theme.Dim.Render(fmt.Sprintf("%s%s %s", label(""), m.msgs.Clock(a.LastErrAt), lastErr)))
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Provider Health ═══  "),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.theme.Dim.Render("[Esc]Close"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
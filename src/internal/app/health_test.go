@@ -0,0 +1,131 @@
+package app
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// offlineProvider stands in for the response cache above a provider.
+type offlineProvider struct {
+	*testProvider
+	offline bool
+}
+
+func (p *offlineProvider) Unwrap() provider.Provider { return p.testProvider }
+func (p *offlineProvider) SetOffline(offline bool)   { p.offline = offline }
+func (p *offlineProvider) Offline() bool             { return p.offline }
+
+func TestHealthDegradedMode(t *testing.T) {
+	prov := &offlineProvider{testProvider: newTestProvider()}
+	m := createTestModel(t)
+	m = initializeModel(m, prov.testProvider)
+	m.provider = m.observed(prov)
+	m.screen = screenLibrary
+	m.artists = prov.artists
+
+	m, cmd := updateModel(m, healthMsg{ok: false, details: "connection refused", latency: 5 * time.Second})
+	if !prov.offline || !m.degraded || cmd == nil {
+		t.Fatalf("expected the cache switched offline and the next check scheduled, got offline=%v degraded=%v", prov.offline, m.degraded)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Offline (cached)") || !strings.Contains(view, "⚠ cached") {
+		t.Errorf("expected the top bar and list header to mark cached data:\n%s", view)
+	}
+
+	m.diagnosticsState.ObserveProvider("ListAlbums", 20*time.Millisecond, nil)
+	m.diagnosticsState.ObserveProvider("GetStream", 30*time.Millisecond, errors.New("stream: 502 bad gateway"))
+	m.showHealth = true
+	panel := m.View()
+	for _, want := range []string{"Provider Health", "Unreachable since", "connection refused", "failing", "502 bad gateway", "● unused"} {
+		if !strings.Contains(panel, want) {
+			t.Errorf("expected the health panel to contain %q:\n%s", want, panel)
+		}
+	}
+	if lib := m.diagnosticsState.AreaHealth("Library"); lib.Calls != 1 || lib.failing() {
+		t.Errorf("expected one good Library call, got %+v", lib)
+	}
+
+	m, _ = updateModel(m, healthMsg{ok: true, details: "OK", latency: 40 * time.Millisecond})
+	if prov.offline || m.degraded || m.status != "Provider back online" {
+		t.Errorf("expected the cache back online, got offline=%v status %q", prov.offline, m.status)
+	}
+	if len(m.healthLatencies) != 2 {
+		t.Errorf("expected both checks in the latency trend, got %v", m.healthLatencies)
+	}
+}
+
+func TestHealthWithoutCache(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m, _ = updateModel(m, healthMsg{ok: false, details: "down"})
+	if m.degraded || m.healthOK {
+		t.Errorf("expected an error without degraded mode, got degraded=%v ok=%v", m.degraded, m.healthOK)
+	}
+}
+
+// namedProvider renames the test provider.
+type namedProvider struct {
+	*testProvider
+	name string
+}
+
+func (p namedProvider) Name() string { return p.name }
+
+func TestHealthColumnsAlign(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.provider = namedProvider{prov, "音楽"}
+	m.diagnosticsState.ObserveProvider("ListAlbums", 20*time.Millisecond, nil)
+	m, _ = updateModel(m, healthMsg{ok: true, details: "OK", latency: 40 * time.Millisecond})
+
+	// Every status dot sits in the same terminal column, whatever the
+	// byte length of the label before it
+	col := -1
+	for _, line := range strings.Split(ansi.Strip(m.renderHealth()), "\n") {
+		before, _, ok := strings.Cut(line, "●")
+		if !ok {
+			continue
+		}
+		if w := ui.Width(before); col < 0 {
+			col = w
+		} else if w != col {
+			t.Errorf("expected the dot at column %d, got %d in %q", col, w, line)
+		}
+	}
+	if col < 0 {
+		t.Fatal("expected status dots in the health panel")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]time.Duration{0, 50, 100}); got != "▁▄█" {
+		t.Errorf("sparkline = %q", got)
+	}
+	if got := sparkline([]time.Duration{0, 0}); got != "▁▁" {
+		t.Errorf("sparkline of zeros = %q", got)
+	}
+}
+
+func TestActionableError(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m, _ = m.setError(&provider.RetryError{Err: provider.ErrRateLimited, After: 42 * time.Second})
+	if m.errorMsg != "Server is rate limiting requests; try again in 42s" {
+		t.Errorf("rate limit message = %q", m.errorMsg)
+	}
+	m, _ = m.setError(provider.ErrTemporary)
+	if !strings.Contains(m.errorMsg, "try again in a moment") {
+		t.Errorf("temporary message = %q", m.errorMsg)
+	}
+	m, _ = m.setError(errors.New("boom"))
+	if m.errorMsg != "boom" {
+		t.Errorf("other errors should pass through, got %q", m.errorMsg)
+	}
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// configHiddenSection is the Config section listing hidden items.
+const configHiddenSection = 5
+
+type hiddenKey struct {
+	kind queue.HiddenKind
+	id   string
+}
+
+// hiddenSet is the active profile's hidden items. It is replaced whole
+// rather than changed, so commands already running can keep reading it.
+type hiddenSet map[hiddenKey]bool
+
+func newHiddenSet(items []queue.HiddenItem) hiddenSet {
+	h := make(hiddenSet, len(items))
+	for _, item := range items {
+		h[hiddenKey{item.Kind, item.ID}] = true
+	}
+	return h
+}
+
+func (h hiddenSet) artist(a provider.Artist) bool {
+	return h[hiddenKey{queue.HiddenArtist, a.ID}]
+}
+
+// album reports whether an album is hidden, itself or by its artist.
+func (h hiddenSet) album(a provider.Album) bool {
+	return h[hiddenKey{queue.HiddenAlbum, a.ID}] || h[hiddenKey{queue.HiddenArtist, a.ArtistID}]
+}
+
+// track reports whether a track is hidden, itself or by its album or
+// artist.
+func (h hiddenSet) track(t provider.Track) bool {
+	return h[hiddenKey{queue.HiddenTrack, t.ID}] || h[hiddenKey{queue.HiddenAlbum, t.AlbumID}] ||
+		h[hiddenKey{queue.HiddenArtist, t.ArtistID}]
+}
+
+// visible returns a list without its hidden items. The list passed in is
+// left as it was; providers may hand out slices they keep.
+func visible[T any](items []T, hidden func(T) bool) []T {
+	if !slices.ContainsFunc(items, hidden) {
+		return items
+	}
+	out := make([]T, 0, len(items))
+	for _, item := range items {
+		if !hidden(item) {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// hiddenMsg carries the active profile's hidden items after loading them
+// or hiding or unhiding one.
+type hiddenMsg struct {
+	profileID string
+	items     []queue.HiddenItem
+	status    string
+	unhidden  bool
+	err       error
+}
+
+// loadHiddenCmd loads the active profile's hidden items.
+func (m Model) loadHiddenCmd() tea.Cmd {
+	return m.hiddenCmd(func(context.Context, *queue.PersistenceStore, string) (string, error) {
+		return "", nil
+	}, false)
+}
+
+// hiddenCmd runs a change to the hidden items, then reloads them.
+func (m Model) hiddenCmd(change func(context.Context, *queue.PersistenceStore, string) (string, error), unhidden bool) tea.Cmd {
+	if m.queueStore == nil {
+		return nil
+	}
+	store, profileID := m.queueStore, m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		status, err := change(ctx, store, profileID)
+		if err != nil {
+			return hiddenMsg{profileID: profileID, err: err}
+		}
+		items, err := store.HiddenItems(ctx, profileID)
+		return hiddenMsg{profileID: profileID, items: items, status: status, unhidden: unhidden, err: err}
+	}
+}
+
+// hideTarget returns the artist, album or track selected in the Library or
+// the Search results.
+func (m Model) hideTarget() (queue.HiddenItem, bool) {
+	if t, ok := m.selectedTrack(); ok {
+		return queue.HiddenItem{Kind: queue.HiddenTrack, ID: t.ID, Name: t.Title + " — " + t.ArtistName}, true
+	}
+	var album provider.Album
+	switch {
+	case m.screen == screenLibrary && m.libraryView() == "albums":
+		album = m.albums[clamp(m.selection, 0, len(m.albums)-1)]
+	case m.screen == screenLibrary && len(m.artists) > 0:
+		a := m.artists[clamp(m.selection, 0, len(m.artists)-1)]
+		return queue.HiddenItem{Kind: queue.HiddenArtist, ID: a.ID, Name: a.Name}, true
+	case m.screen == screenSearch && m.searchFilter == filterAlbums && len(m.searchResults.Albums.Items) > 0:
+		album = m.searchResults.Albums.Items[clamp(m.selection, 0, len(m.searchResults.Albums.Items)-1)]
+	case m.screen == screenSearch && m.searchFilter == filterArtists && len(m.searchResults.Artists.Items) > 0:
+		a := m.searchResults.Artists.Items[clamp(m.selection, 0, len(m.searchResults.Artists.Items)-1)]
+		return queue.HiddenItem{Kind: queue.HiddenArtist, ID: a.ID, Name: a.Name}, true
+	default:
+		return queue.HiddenItem{}, false
+	}
+	return queue.HiddenItem{Kind: queue.HiddenAlbum, ID: album.ID, Name: album.Title + " — " + album.ArtistName}, true
+}
+
+// hideSelected hides the selected artist, album or track.
+func (m Model) hideSelected() (Model, tea.Cmd) {
+	item, ok := m.hideTarget()
+	if !ok {
+		m.status = m.msgs.T("hidden.select")
+		return m, nil
+	}
+	if m.queueStore == nil {
+		m.status = m.msgs.T("hidden.needs_store")
+		return m, nil
+	}
+	m.logger.Debug("hiding item", slog.String("kind", string(item.Kind)), slog.String("id", item.ID))
+	status := m.msgs.T("hidden.hidden", item.Name)
+	return m, m.hiddenCmd(func(ctx context.Context, store *queue.PersistenceStore, profileID string) (string, error) {
+		return status, store.Hide(ctx, profileID, item)
+	}, false)
+}
+
+// unhideSelected shows the item selected in the Config hidden list again.
+func (m Model) unhideSelected() (Model, tea.Cmd) {
+	if len(m.hiddenItems) == 0 {
+		return m, nil
+	}
+	item := m.hiddenItems[clamp(m.selection, 0, len(m.hiddenItems)-1)]
+	m.logger.Debug("unhiding item", slog.String("kind", string(item.Kind)), slog.String("id", item.ID))
+	status := m.msgs.T("hidden.unhidden", item.Name)
+	return m, m.hiddenCmd(func(ctx context.Context, store *queue.PersistenceStore, profileID string) (string, error) {
+		return status, store.Unhide(ctx, profileID, item.Kind, item.ID)
+	}, true)
+}
+
+// handleHidden takes in the hidden items and drops newly hidden ones from
+// the lists on screen. Unhidden items come back as the Library reloads,
+// from the artist list.
+func (m Model) handleHidden(msg hiddenMsg) (Model, tea.Cmd) {
+	if msg.profileID != m.cfg.ActiveProfile {
+		return m, nil
+	}
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("hidden items: %w", msg.err))
+	}
+	m.hiddenItems = msg.items
+	m.hidden = newHiddenSet(msg.items)
+	if msg.status != "" {
+		m.status = msg.status
+	}
+	if m.screen == screenConfig && m.hiddenOpen {
+		m.selection = clamp(m.selection, 0, max(len(m.hiddenItems)-1, 0))
+	}
+	if msg.unhidden {
+		m.albums, m.tracks = nil, nil
+		m.albumsCursor, m.tracksCursor = "", ""
+		m.currentArtistID, m.currentAlbumID = "", ""
+		m.artistsCursor = ""
+		return m, m.loadArtistsCmd("")
+	}
+	m.artists = visible(m.artists, m.hidden.artist)
+	m.albums = visible(m.albums, m.hidden.album)
+	m.tracks = visible(m.tracks, m.hidden.track)
+	m.searchResults.Artists.Items = visible(m.searchResults.Artists.Items, m.hidden.artist)
+	m.searchResults.Albums.Items = visible(m.searchResults.Albums.Items, m.hidden.album)
+	m.searchResults.Tracks.Items = visible(m.searchResults.Tracks.Items, m.hidden.track)
+	if n := m.currentListLen(); m.selection >= n {
+		m.selection = max(n-1, 0)
+	}
+	return m, nil
+}
+
+// renderHiddenItems lists the hidden items in the Config screen details.
+func (m Model) renderHiddenItems() string {
+	if len(m.hiddenItems) == 0 {
+		return m.msgs.T("hidden.none")
+	}
+	if !m.hiddenOpen {
+		return m.msgs.T("hidden.count", len(m.hiddenItems))
+	}
+	// Kinds are padded to the longest in the interface's language
+	kindWidth := 0
+	for _, kind := range []queue.HiddenKind{queue.HiddenArtist, queue.HiddenAlbum, queue.HiddenTrack} {
+		kindWidth = max(kindWidth, ui.Width(m.msgs.T("hidden.kind."+string(kind))))
+	}
+	lines := make([]string, 0, len(m.hiddenItems))
+	for i, item := range m.hiddenItems {
+		prefix, style := "  ", m.theme.Text
+		if i == m.selection {
+			prefix, style = m.selectedRow("▸ ")
+		}
+		lines = append(lines, style.Render(prefix+ui.PadRight(m.msgs.T("hidden.kind."+string(item.Kind)), kindWidth)+" "+item.Name))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// visibleResults drops the hidden items from search results.
+func (m Model) visibleResults(res provider.SearchResults) provider.SearchResults {
+	res.Artists.Items = visible(res.Artists.Items, m.hidden.artist)
+	res.Albums.Items = visible(res.Albums.Items, m.hidden.album)
+	res.Tracks.Items = visible(res.Tracks.Items, m.hidden.track)
+	return res
+}
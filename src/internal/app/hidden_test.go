@@ -0,0 +1,76 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestHideAndUnhide(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+
+	// Hide Pink Floyd from the artist list
+	m.screen = screenLibrary
+	m.selection = 1
+	m, cmd := m.hideSelected()
+	if cmd == nil {
+		t.Fatal("expected a command saving the hidden artist")
+	}
+	m, _ = updateModel(m, cmd())
+	if len(m.artists) != 4 || m.artists[1].Name != "Led Zeppelin" || m.status != "Hidden: Pink Floyd" {
+		t.Fatalf("expected Pink Floyd dropped, got %v, status %q", m.artists, m.status)
+	}
+	if len(prov.artists) != 5 {
+		t.Error("expected the provider's own list left alone")
+	}
+
+	// Hide an album; its tracks go from search results and random play too
+	m, _ = updateModel(m, albumsMsg{page: provider.Page[provider.Album]{Items: prov.albums}})
+	m, cmd = m.hideSelected()
+	m, _ = updateModel(m, cmd())
+	if len(m.albums) != 1 || m.albums[0].Title != "Let It Be" {
+		t.Fatalf("expected Abbey Road dropped, got %v", m.albums)
+	}
+	m, _ = updateModel(m, searchMsg{res: provider.SearchResults{
+		Artists: provider.Page[provider.Artist]{Items: prov.artists},
+		Tracks:  provider.Page[provider.Track]{Items: append(prov.tracks, provider.Track{ID: "200", Title: "Dig It", AlbumID: "11"})},
+	}})
+	if len(m.searchResults.Artists.Items) != 4 || len(m.searchResults.Tracks.Items) != 1 || m.status != "Found 5 results" {
+		t.Errorf("expected hidden results dropped, got %+v, status %q", m.searchResults, m.status)
+	}
+	if msg, ok := m.randomPlayCmd(StartupOptions{}, nil)().(randomPlayMsg); !ok || msg.err == nil {
+		t.Errorf("expected random play to find only hidden tracks, got %+v", msg)
+	}
+
+	// Unhide the artist from the Config list
+	m.screen = screenConfig
+	m.selection = configHiddenSection
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.hiddenOpen || m.currentListLen() != 2 {
+		t.Fatalf("expected the hidden list open with two items, got open=%v len=%d", m.hiddenOpen, m.currentListLen())
+	}
+	m.selection = 1
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = updateModel(m, cmd())
+	if len(m.hiddenItems) != 1 || m.status != "Unhidden: Pink Floyd" || cmd == nil {
+		t.Fatalf("expected Pink Floyd unhidden and the artists reloaded, got %+v, status %q", m.hiddenItems, m.status)
+	}
+	m, _ = updateModel(m, cmd())
+	if len(m.artists) != 5 {
+		t.Errorf("expected Pink Floyd back in the artist list, got %v", m.artists)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.hiddenOpen || m.selection != configHiddenSection {
+		t.Errorf("expected Esc back to the sections, got open=%v selection=%d", m.hiddenOpen, m.selection)
+	}
+}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// maxHistory is how many views back navigation remembers.
+const maxHistory = 50
+
+// navView is a view the navigation history returns to: the screen, the
+// Library list and Search results as they were, and the row selected.
+type navView struct {
+	screen      screen
+	focusedPane pane
+	selection   int
+
+	artists         []provider.Artist
+	artistsCursor   string
+	albums          []provider.Album
+	albumsCursor    string
+	tracks          []provider.Track
+	tracksCursor    string
+	trackVersions   versionList
+	currentArtistID string
+	currentAlbumID  string
+	topTracksOf     string
+	topPlays        map[string]int
+	playlistID      string
+	yearFilter      provider.YearRange
+	classical       bool
+
+	searchQ        string
+	searchResults  provider.SearchResults
+	searchFor      string
+	searchVersions versionList
+	searchFilter   searchFilter
+}
+
+// navHistory holds the views behind and ahead of the one on screen, most
+// recent last.
+type navHistory struct {
+	back    []navView
+	forward []navView
+}
+
+// currentView records the view on screen.
+func (m Model) currentView() navView {
+	return navView{
+		screen:          m.screen,
+		focusedPane:     m.focusedPane,
+		selection:       m.selection,
+		artists:         m.artists,
+		artistsCursor:   m.artistsCursor,
+		albums:          m.albums,
+		albumsCursor:    m.albumsCursor,
+		tracks:          m.tracks,
+		tracksCursor:    m.tracksCursor,
+		trackVersions:   m.trackVersions.clone(),
+		currentArtistID: m.currentArtistID,
+		currentAlbumID:  m.currentAlbumID,
+		topTracksOf:     m.topTracksOf,
+		topPlays:        m.topPlays,
+		playlistID:      m.currentPlaylistID,
+		yearFilter:      m.yearFilter,
+		classical:       m.classical,
+		searchQ:         m.searchQ,
+		searchResults:   m.searchResults,
+		searchFor:       m.searchFor,
+		searchVersions:  m.searchVersions.clone(),
+		searchFilter:    m.searchFilter,
+	}
+}
+
+// showView puts a recorded view back on screen.
+func (m Model) showView(v navView) Model {
+	m.screen, m.focusedPane, m.selection = v.screen, v.focusedPane, v.selection
+	m.artists, m.artistsCursor = v.artists, v.artistsCursor
+	m.albums, m.albumsCursor = v.albums, v.albumsCursor
+	m.tracks, m.tracksCursor, m.trackVersions = v.tracks, v.tracksCursor, v.trackVersions
+	m.currentArtistID, m.currentAlbumID = v.currentArtistID, v.currentAlbumID
+	m.topTracksOf, m.topPlays = v.topTracksOf, v.topPlays
+	m.currentPlaylistID = v.playlistID
+	m.yearFilter, m.classical = v.yearFilter, v.classical
+	m.searchQ, m.searchResults, m.searchFor = v.searchQ, v.searchResults, v.searchFor
+	m.searchVersions, m.searchFilter = v.searchVersions, v.searchFilter
+	m.filter = listFilter{}
+	return m
+}
+
+// pushHistory records the view on screen before another is opened in its
+// place, and forgets the views ahead of it.
+func (m Model) pushHistory() Model {
+	m.history.back = append(m.history.back, m.currentView())
+	if len(m.history.back) > maxHistory {
+		m.history.back = m.history.back[len(m.history.back)-maxHistory:]
+	}
+	m.history.forward = nil
+	return m
+}
+
+// navBack returns to the previous view, keeping the one on screen to go
+// forward to.
+func (m Model) navBack() (Model, tea.Cmd) {
+	n := len(m.history.back)
+	if n == 0 {
+		m.status = m.msgs.T("nav.no_back")
+		return m, nil
+	}
+	v := m.history.back[n-1]
+	m.history.back = m.history.back[:n-1]
+	m.history.forward = append(m.history.forward, m.currentView())
+	m = m.showView(v)
+	m.logger.Debug("navigated back", slog.String("screen", screenNames[m.screen]), slog.Int("selection", m.selection))
+	m.status = m.msgs.T("nav.back", m.viewLabel())
+	return m, nil
+}
+
+// navForward returns to the view navBack left.
+func (m Model) navForward() (Model, tea.Cmd) {
+	n := len(m.history.forward)
+	if n == 0 {
+		m.status = m.msgs.T("nav.no_forward")
+		return m, nil
+	}
+	v := m.history.forward[n-1]
+	m.history.forward = m.history.forward[:n-1]
+	m.history.back = append(m.history.back, m.currentView())
+	m = m.showView(v)
+	m.logger.Debug("navigated forward", slog.String("screen", screenNames[m.screen]), slog.Int("selection", m.selection))
+	m.status = m.msgs.T("nav.forward", m.viewLabel())
+	return m, nil
+}
+
+// libraryBack goes back from the Library list on screen: to the view it was
+// opened from, or without one up a level, from tracks to albums to artists.
+func (m Model) libraryBack() (Model, tea.Cmd) {
+	if len(m.history.back) > 0 {
+		return m.navBack()
+	}
+	if len(m.tracks) > 0 {
+		m.logger.Debug("library navigation: going back from tracks to albums")
+		m.tracks = nil
+		m.tracksCursor = ""
+		m.currentAlbumID = ""
+		m.topTracksOf = ""
+		m.selection = 0
+		m.status = m.msgs.T("library.albums")
+		return m, nil
+	}
+	if len(m.albums) > 0 {
+		m.logger.Debug("library navigation: going back from albums to artists")
+		m.albums = nil
+		m.albumsCursor = ""
+		m.currentArtistID = ""
+		m.selection = 0
+		m.status = m.msgs.T("library.artists")
+		return m, nil
+	}
+	return m, nil
+}
+
+// viewLabel names the view on screen in the status line.
+func (m Model) viewLabel() string {
+	switch m.screen {
+	case screenLibrary:
+		return strings.Join(m.libraryCrumbs(), " / ")
+	case screenSearch:
+		if m.searchQ != "" {
+			return m.msgs.T("nav.search", m.searchQ)
+		}
+	}
+	return m.screenTitle()
+}
+
+// libraryCrumbs returns the path to the Library list on screen, as in
+// Artists / Pink Floyd / The Wall.
+func (m Model) libraryCrumbs() []string {
+	crumbs := []string{m.msgs.T("library.artists")}
+	if m.classical {
+		crumbs[0] = m.msgs.T("library.composers")
+	}
+	view := m.libraryView()
+	if view == "artists" {
+		return crumbs
+	}
+	name := m.currentArtistName()
+	if name != "" {
+		crumbs = append(crumbs, name)
+	}
+	switch {
+	case view == "albums" && name == "" && m.classical:
+		crumbs = append(crumbs, m.msgs.T("library.works"))
+	case view == "albums" && name == "":
+		// Albums of every artist, such as those of a decade
+		crumbs = append(crumbs, m.msgs.T("library.albums"))
+	case view == "albums":
+	case m.topTracksOf != "":
+		crumbs = append(crumbs, m.msgs.T("library.top_tracks"))
+	case m.currentAlbumID != "":
+		crumbs = append(crumbs, m.currentAlbumTitle())
+	case m.classical:
+		crumbs = append(crumbs, m.msgs.T("library.movements"))
+	default:
+		crumbs = append(crumbs, m.msgs.T("library.tracks"))
+	}
+	return crumbs
+}
+
+// currentArtistName returns the name of the artist the Library list is of,
+// from whichever list carries it.
+func (m Model) currentArtistName() string {
+	id := m.currentArtistID
+	if m.topTracksOf != "" {
+		return m.topTracksOf
+	}
+	if id == "" {
+		return ""
+	}
+	for _, a := range m.artists {
+		if a.ID == id {
+			return a.Name
+		}
+	}
+	for _, a := range m.albums {
+		if a.ArtistID == id && a.ArtistName != "" {
+			return a.ArtistName
+		}
+	}
+	for _, t := range m.tracks {
+		if t.ArtistID == id && t.ArtistName != "" {
+			return t.ArtistName
+		}
+	}
+	return ""
+}
+
+// currentAlbumTitle returns the title of the album the Library tracks are
+// of.
+func (m Model) currentAlbumTitle() string {
+	for _, a := range m.albums {
+		if a.ID == m.currentAlbumID {
+			return a.Title
+		}
+	}
+	for _, t := range m.tracks {
+		if t.AlbumID == m.currentAlbumID && t.AlbumTitle != "" {
+			return t.AlbumTitle
+		}
+	}
+	return m.msgs.T("library.tracks")
+}
+
+// breadcrumbs renders the Library path with the count of the list on
+// screen, shortening the names above it to fit width.
+func (m Model) breadcrumbs(count, width int) string {
+	crumbs := m.libraryCrumbs()
+	name, trail := crumbs[len(crumbs)-1], crumbs[:len(crumbs)-1]
+	suffix := fmt.Sprintf(" (%d)", count)
+	length := func() int {
+		n := ui.Width(name) + len(suffix)
+		for _, c := range trail {
+			n += ui.Width(c) + len(" / ")
+		}
+		return n
+	}
+	// Names above the list give way first, then the root, then the list's
+	// own name
+	for i := len(trail) - 1; i >= 1 && length() > width; i-- {
+		trail[i] = ui.Truncate(trail[i], 12)
+	}
+	if len(trail) > 1 && length() > width {
+		trail = trail[1:]
+	}
+	if over := length() - width; over > 0 {
+		name = ui.Truncate(name, max(ui.Width(name)-over, 1))
+	}
+	var b strings.Builder
+	for _, c := range trail {
+		b.WriteString(m.theme.Dim.Render(c + " / "))
+	}
+	return b.String() + m.theme.Title.Render(name+suffix)
+}
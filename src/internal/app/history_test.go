@@ -0,0 +1,156 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tunez/tunez/internal/i18n"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestNavigationHistory(t *testing.T) {
+	prov := newTestProvider()
+	for i := range prov.tracks {
+		prov.tracks[i].AlbumTitle = "Abbey Road"
+	}
+	m := initializeModel(createTestModel(t), prov)
+	m.provider = prov
+	m.screen = screenSearch
+	m.focusedPane = paneContent
+	m.searchQ = "abbey"
+	m.searchFilter = filterAlbums
+	m.searchResults = provider.SearchResults{Albums: provider.Page[provider.Album]{Items: prov.albums}}
+
+	// Search result → album → its artist
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, _ = updateModel(m, cmd())
+	m.selection = 2
+	if view := m.renderLibrary(120, 20); !strings.Contains(view, "Artists / The Beatles / Abbey Road (3)") {
+		t.Fatalf("expected the album's breadcrumbs, got:\n%s", view)
+	}
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, _ = updateModel(m, cmd())
+	if m.libraryView() != "albums" || strings.Join(m.libraryCrumbs(), " / ") != "Artists / The Beatles" {
+		t.Fatalf("expected the artist's albums, got %v", m.libraryCrumbs())
+	}
+
+	back := tea.KeyMsg{Type: tea.KeyLeft, Alt: true}
+	m, _ = updateModel(m, back)
+	if m.screen != screenLibrary || m.libraryView() != "tracks" || m.selection != 2 {
+		t.Fatalf("expected the album with its track selected, got %s, selection %d", m.libraryView(), m.selection)
+	}
+	m, _ = updateModel(m, back)
+	if m.screen != screenSearch || m.searchQ != "abbey" || m.searchFilter != filterAlbums || len(m.searchResults.Albums.Items) != 2 {
+		t.Fatalf("expected the search results, got screen %v %q", m.screen, m.searchQ)
+	}
+	if m, _ = updateModel(m, back); m.status != "Nothing to go back to" {
+		t.Errorf("expected the start of the history, got %q", m.status)
+	}
+
+	forward := tea.KeyMsg{Type: tea.KeyRight, Alt: true}
+	m, _ = updateModel(m, forward)
+	m, _ = updateModel(m, forward)
+	if m.screen != screenLibrary || m.libraryView() != "albums" || m.status != "Forward: Artists / The Beatles" {
+		t.Fatalf("expected forward to the artist again, got %s, %q", m.libraryView(), m.status)
+	}
+
+	// Esc goes back too, and opening a view drops the ones ahead
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.libraryView() != "tracks" || len(m.history.forward) != 1 {
+		t.Fatalf("expected esc to go back to the album, got %s", m.libraryView())
+	}
+	m.selection = 0
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlR})
+	m, _ = updateModel(m, cmd())
+	if len(m.history.forward) != 0 || len(m.history.back) != 2 {
+		t.Errorf("expected a new branch of the history, got %d back, %d forward", len(m.history.back), len(m.history.forward))
+	}
+}
+
+func TestBreadcrumbsShorten(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.currentArtistID, m.currentAlbumID = "1", "10"
+	m.albums = []provider.Album{{ID: "10", Title: "The Dark Side of the Moon", ArtistID: "1", ArtistName: "The Beatles"}}
+	m.tracks = []provider.Track{{ID: "1", AlbumID: "10", ArtistID: "1"}}
+
+	for width, want := range map[int]string{
+		80: "Artists / The Beatles / The Dark Side of the Moon (1)",
+		40: "The Beatles / The Dark Side of the… (1)",
+		20: "The Beatles / T… (1)",
+	} {
+		if got := m.breadcrumbs(1, width); got != want {
+			t.Errorf("width %d: expected %q, got %q", width, want, got)
+		}
+	}
+}
+
+func TestBreadcrumbsLanguage(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.msgs = i18n.New("de")
+	m.currentArtistID = "1"
+	m.albums = []provider.Album{{ID: "10", Title: "Abbey Road", ArtistID: "1", ArtistName: "The Beatles", TrackCount: 1}}
+
+	if got := m.breadcrumbs(1, 80); got != "Interpreten / The Beatles (1)" {
+		t.Errorf("expected German breadcrumbs, got %q", got)
+	}
+	m.classical = true
+	if view := m.renderLibrary(120, 20); !strings.Contains(view, "Abbey Road  (1 Satz)") {
+		t.Errorf("expected the movements counted in German, got:\n%s", view)
+	}
+}
+
+func TestHelpAndConfigLanguage(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.msgs = i18n.New("es")
+	m.width, m.height = 120, 80
+
+	help := ansi.Strip(m.renderHelpOverlay())
+	for _, want := range []string{"Ayuda / Atajos", "Reproductor", "Añadir a la cola", "Saltar -"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("expected %q in the Spanish help, got:\n%s", want, help)
+		}
+	}
+	m.screen = screenConfig
+	if config := ansi.Strip(m.renderConfig()); !strings.Contains(config, "Proveedores y perfiles") || !strings.Contains(config, "[Enter]Abrir sección") {
+		t.Errorf("expected the Spanish Config screen, got:\n%s", config)
+	}
+	if m, _ = m.clearQueue(); m.status != "La cola ya está vacía" {
+		t.Errorf("expected a Spanish status, got %q", m.status)
+	}
+}
+
+func TestMessagesInCatalog(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := regexp.MustCompile(`msgs\.([TN])\("([a-z0-9_.]+)"[,)]`)
+	literal := regexp.MustCompile(`m\.status = (fmt\.Sprintf\()?"[^"]`)
+	for _, f := range files {
+		if strings.HasSuffix(f, "_test.go") {
+			continue
+		}
+		src, err := os.ReadFile(f)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, match := range lookup.FindAllStringSubmatch(string(src), -1) {
+			key := match[2]
+			if match[1] == "N" {
+				key += ".other"
+			}
+			if _, ok := i18n.English[key]; !ok {
+				t.Errorf("%s: %q has no English text", f, key)
+			}
+		}
+		// Statuses go through the catalog, so they follow ui.language
+		for _, line := range literal.FindAllString(string(src), -1) {
+			t.Errorf("%s: untranslated status %s", f, line)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/hooks"
+)
+
+func TestPauseHook(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.hooks = hooks.New(config.HooksConfig{
+		Pause:  `echo "pause $TUNEZ_TITLE" >> ` + out,
+		Resume: `echo "resume $TUNEZ_TITLE" >> ` + out,
+	}, nil)
+	m.nowPlaying = prov.tracks[0]
+
+	// The key press flips m.paused before mpv confirms it
+	m.paused = true
+	paused := true
+	m, _ = updateModel(m, playerMsg{Paused: &paused})
+	m, _ = updateModel(m, playerMsg{Paused: &paused})
+	if err := m.hooks.Wait(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	m.paused = false
+	resumed := false
+	m, _ = updateModel(m, playerMsg{Paused: &resumed})
+	if err := m.hooks.Wait(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := os.ReadFile(out)
+	want := "pause " + prov.tracks[0].Title + "\nresume " + prov.tracks[0].Title + "\n"
+	if string(got) != want {
+		t.Errorf("expected one pause and one resume hook, got %q", got)
+	}
+}
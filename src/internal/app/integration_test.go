@@ -66,18 +66,33 @@ func createTestModel(t *testing.T) Model {
 		},
 		Queue: config.QueueConfig{Persist: false},
 		Keybindings: config.KeybindConfig{
-			PlayPause:    "space",
-			NextTrack:    "n",
-			PrevTrack:    "N",
-			SeekForward:  "l",
-			SeekBackward: "h",
-			VolumeUp:     "+",
-			VolumeDown:   "-",
-			Mute:         "m",
-			Shuffle:      "S",
-			Repeat:       "r",
-			Help:         "?",
-			Quit:         "q",
+			PlayPause:     "space",
+			NextTrack:     "n",
+			PrevTrack:     "N",
+			SeekForward:   "l",
+			SeekBackward:  "h",
+			VolumeUp:      "+",
+			VolumeDown:    "-",
+			Mute:          "m",
+			Shuffle:       "S",
+			Repeat:        "r",
+			Love:          "F",
+			ProfileSwitch: "ctrl+o",
+			QuickOpen:     "ctrl+k",
+			Search:        "/",
+			Help:          "?",
+			Zen:           "z",
+			Bookmark:      "b",
+			Bookmarks:     "B",
+			NextChapter:   "]",
+			PrevChapter:   "[",
+			Chapters:      "E",
+			TrackInfo:     "i",
+			GoToAlbum:     "ctrl+a",
+			GoToArtist:    "ctrl+r",
+			NavBack:       "alt+left",
+			NavForward:    "alt+right",
+			Quit:          "q",
 		},
 	}
 	prov := newTestProvider()
@@ -86,7 +101,7 @@ func createTestModel(t *testing.T) Model {
 
 	m := New(cfg, prov, func(p config.Profile) (provider.Provider, error) {
 		return prov, nil
-	}, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil)
+	}, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil, nil, nil, nil)
 	m.width = 80
 	m.height = 24
 
@@ -153,6 +168,20 @@ func TestScreensGolden(t *testing.T) {
 				return m
 			},
 		},
+		{
+			name: "library_tracks_markers",
+			setup: func(m Model) Model {
+				m.cfg.UI.SelectionMarkers = true
+				m, _ = updateModel(m, initMsg{err: nil})
+				m, _ = updateModel(m, artistsMsg{page: provider.Page[provider.Artist]{Items: prov.artists}})
+				m.screen = screenLibrary
+				m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+				m, _ = updateModel(m, albumsMsg{page: provider.Page[provider.Album]{Items: prov.albums}})
+				m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+				m, _ = updateModel(m, tracksMsg{page: provider.Page[provider.Track]{Items: prov.tracks}})
+				return m
+			},
+		},
 		{
 			name: "queue_empty",
 			setup: func(m Model) Model {
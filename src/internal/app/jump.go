@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// artistJumpMsg carries the artists loaded to reach a jump-to initial.
+type artistJumpMsg struct {
+	initial string
+	page    provider.Page[provider.Artist]
+	err     error
+}
+
+// artistInitial returns the lowercased first letter of name, or "#" when it
+// doesn't start with a letter.
+func artistInitial(name string) string {
+	r, _ := utf8.DecodeRuneInString(name)
+	if !unicode.IsLetter(r) {
+		return "#"
+	}
+	return string(unicode.ToLower(r))
+}
+
+// artistSortKey is the key the artist list is ordered by in name order.
+func artistSortKey(a provider.Artist) string {
+	if a.SortName != "" {
+		return strings.ToLower(a.SortName)
+	}
+	return strings.ToLower(a.Name)
+}
+
+// artistsByName reports whether the artist list is in name order, so an
+// initial's position can be predicted.
+func (m Model) artistsByName() bool {
+	return m.cfg.UI.Sort.Artists == "" || m.cfg.UI.Sort.Artists == provider.SortName
+}
+
+// findArtistInitial returns the index of the first loaded artist under
+// initial, or -1.
+func (m Model) findArtistInitial(initial string) int {
+	for i, a := range m.artists {
+		if artistInitial(a.Name) == initial {
+			return i
+		}
+	}
+	return -1
+}
+
+// jumpToInitial selects the first artist under the typed initial, loading
+// further pages when it lies beyond the ones loaded so far.
+func (m Model) jumpToInitial(key string) (Model, tea.Cmd) {
+	r, size := utf8.DecodeRuneInString(key)
+	if size != len(key) || (r != '#' && !unicode.IsLetter(r)) {
+		m.status = ""
+		return m, nil
+	}
+	initial := string(unicode.ToLower(r))
+	m.filter = listFilter{}
+	if i := m.findArtistInitial(initial); i >= 0 {
+		m.selection = i
+		m.status = ""
+		return m, nil
+	}
+
+	// In name order, artists under a later initial mean this one is empty
+	pastIt := len(m.artists) > 0 && artistSortKey(m.artists[len(m.artists)-1]) > initial
+	if m.artistsCursor == "" || !m.artistsByName() || initial == "#" || pastIt {
+		m.status = m.msgs.T("jump.none", strings.ToUpper(initial))
+		return m, nil
+	}
+	m.status = m.msgs.T("jump.jumping", strings.ToUpper(initial))
+	return m, m.loadArtistsThroughCmd(initial)
+}
+
+// loadArtistsThroughCmd loads the artists after the ones already loaded, up
+// to the first under initial. Providers with an index load them in a single
+// request; others are paged through until the initial turns up.
+func (m Model) loadArtistsThroughCmd(initial string) tea.Cmd {
+	loaded := len(m.artists)
+	cursor := m.artistsCursor
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		req := provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor, Sort: m.cfg.UI.Sort.Artists}
+
+		if ix, ok := provider.As[provider.ArtistIndexer](m.provider); ok {
+			offset, err := ix.ArtistOffset(ctx, initial)
+			if err != nil {
+				return artistJumpMsg{initial: initial, err: err}
+			}
+			req.PageSize += max(offset-loaded, 0)
+			page, err := m.provider.ListArtists(ctx, req)
+			m.logger.Debug("artist jump via index", slog.String("initial", initial), slog.Int("offset", offset), slog.Int("count", len(page.Items)))
+			return artistJumpMsg{initial: initial, page: page, err: err}
+		}
+
+		var out provider.Page[provider.Artist]
+		for {
+			page, err := m.provider.ListArtists(ctx, req)
+			if err != nil {
+				return artistJumpMsg{initial: initial, err: err}
+			}
+			out.Items = append(out.Items, page.Items...)
+			out.NextCursor = page.NextCursor
+			if n := len(page.Items); n > 0 && artistSortKey(page.Items[n-1]) >= initial {
+				break
+			}
+			if page.NextCursor == "" || page.NextCursor == req.Cursor {
+				break
+			}
+			req.Cursor = page.NextCursor
+		}
+		m.logger.Debug("artist jump via paging", slog.String("initial", initial), slog.Int("count", len(out.Items)))
+		return artistJumpMsg{initial: initial, page: out}
+	}
+}
+
+// handleArtistJump appends the artists loaded for a jump and selects the
+// first one under the initial.
+func (m Model) handleArtistJump(msg artistJumpMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	m.artists = append(m.artists, visible(msg.page.Items, m.hidden.artist)...)
+	m.artistsCursor = msg.page.NextCursor
+	if i := m.findArtistInitial(msg.initial); i >= 0 {
+		m.selection = i
+		m.status = m.msgs.T("status.artists_loaded", len(m.artists))
+	} else {
+		m.status = m.msgs.T("jump.none", strings.ToUpper(msg.initial))
+	}
+	return m, nil
+}
@@ -0,0 +1,126 @@
+package app
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// pagedArtists serves its artists a page at a time, like a large library.
+type pagedArtists struct {
+	*testProvider
+	requests int
+}
+
+func (p *pagedArtists) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	p.requests++
+	offset, _ := strconv.Atoi(req.Cursor)
+	end := min(offset+req.PageSize, len(p.artists))
+	page := provider.Page[provider.Artist]{Items: p.artists[offset:end]}
+	if end < len(p.artists) {
+		page.NextCursor = strconv.Itoa(end)
+	}
+	return page, nil
+}
+
+// indexedArtists also knows where each initial starts.
+type indexedArtists struct {
+	pagedArtists
+}
+
+func (p *indexedArtists) ArtistOffset(ctx context.Context, prefix string) (int, error) {
+	n := 0
+	for _, a := range p.artists {
+		if strings.ToLower(a.Name) < prefix {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func jumpTestModel(t *testing.T, prov provider.Provider) Model {
+	t.Helper()
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.provider = prov
+	m.cfg.UI.PageSize = 2
+	m.artists = nil
+	m.artistsCursor = ""
+	msg := m.loadArtistsCmd("")()
+	m, _ = updateModel(m, msg)
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	return m
+}
+
+func jumpArtists() []provider.Artist {
+	var out []provider.Artist
+	for _, name := range []string{"Abba", "Air", "Beck", "Blur", "Cake", "Muse", "Queen", "Yes"} {
+		out = append(out, provider.Artist{ID: name, Name: name})
+	}
+	return out
+}
+
+func TestArtistJumpLoaded(t *testing.T) {
+	prov := &pagedArtists{testProvider: newTestProvider()}
+	prov.artists = jumpArtists()
+	m := jumpTestModel(t, prov)
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'\''}})
+	if !m.jumpPending {
+		t.Fatal("expected ' to wait for an initial")
+	}
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'A'}})
+	if cmd != nil || m.selection != 0 || m.jumpPending {
+		t.Fatalf("expected jump within loaded artists, got sel=%d cmd=%v", m.selection, cmd != nil)
+	}
+	// A key that isn't an initial just cancels
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'\''}})
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.jumpPending || m.screen != screenLibrary {
+		t.Error("expected enter to cancel the jump")
+	}
+}
+
+func TestArtistJumpPaging(t *testing.T) {
+	prov := &pagedArtists{testProvider: newTestProvider()}
+	prov.artists = jumpArtists()
+	m := jumpTestModel(t, prov)
+	prov.requests = 0
+
+	m = typeKeys(m, "'m")
+	m, _ = updateModel(m, m.loadArtistsThroughCmd("m")())
+	if m.artists[m.selection].Name != "Muse" {
+		t.Fatalf("expected Muse selected, got %q", m.artists[m.selection].Name)
+	}
+	if prov.requests != 2 {
+		t.Errorf("expected to page until Muse (2 requests), got %d", prov.requests)
+	}
+}
+
+func TestArtistJumpIndexed(t *testing.T) {
+	prov := &indexedArtists{pagedArtists{testProvider: newTestProvider()}}
+	prov.artists = jumpArtists()
+	m := jumpTestModel(t, prov)
+	prov.requests = 0
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'\''}})
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Q'}})
+	if cmd == nil {
+		t.Fatal("expected load command for an unloaded initial")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.artists[m.selection].Name != "Queen" || prov.requests != 1 {
+		t.Errorf("expected Queen in a single request, got %q after %d", m.artists[m.selection].Name, prov.requests)
+	}
+
+	// Initials with no artists say so
+	m = typeKeys(m, "'d")
+	if !strings.Contains(m.status, "No artists under D") {
+		t.Errorf("expected no-artists status, got %q", m.status)
+	}
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/platform"
+	"github.com/tunez/tunez/internal/scrobble/lastfm"
+	"github.com/tunez/tunez/internal/secrets"
+)
+
+// lastfmTokenMsg carries a fresh request token for the Last.fm wizard.
+type lastfmTokenMsg struct {
+	scrobblerID string
+	auth        *lastfm.Auth
+	token       string
+	url         string
+	err         error
+}
+
+// lastfmSessionMsg is the outcome of waiting for the user to approve the token.
+type lastfmSessionMsg struct {
+	scrobblerID string
+	session     lastfm.Session
+	saveErr     error
+	err         error
+}
+
+// startLastfmAuth kicks off the in-TUI Last.fm authorization wizard.
+func (m Model) startLastfmAuth() (Model, tea.Cmd) {
+	entry, ok := m.cfg.LastfmScrobbler()
+	if !ok {
+		return m.setError(errors.New("no lastfm scrobbler configured"))
+	}
+	apiKey, _ := entry.Settings["api_key"].(string)
+	apiSecret, _ := entry.Settings["api_secret"].(string)
+	if apiKey == "" || apiSecret == "" {
+		return m.setError(fmt.Errorf("scrobbler %s needs api_key and api_secret", entry.ID))
+	}
+	m.status = m.msgs.T("lastfm.requesting")
+	return m, m.lastfmTokenCmd(entry.ID, lastfm.NewAuth(apiKey, apiSecret))
+}
+
+// lastfmTokenCmd requests a token and opens the approval page in the browser.
+func (m Model) lastfmTokenCmd(scrobblerID string, auth *lastfm.Auth) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		token, err := auth.GetToken(ctx)
+		if err != nil {
+			return lastfmTokenMsg{scrobblerID: scrobblerID, err: err}
+		}
+		url := auth.AuthURL(token)
+		if err := platform.Open(url); err != nil {
+			m.logger.Debug("open browser", slog.Any("err", err))
+		}
+		return lastfmTokenMsg{scrobblerID: scrobblerID, auth: auth, token: token, url: url}
+	}
+}
+
+// lastfmSessionCmd polls until the token is approved, then saves the session
+// key to the config file.
+func (m Model) lastfmSessionCmd(msg lastfmTokenMsg) tea.Cmd {
+	cfgPath := m.cfg.Path
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+		sess, err := msg.auth.WaitForSession(ctx, msg.token, 3*time.Second)
+		if err != nil {
+			return lastfmSessionMsg{scrobblerID: msg.scrobblerID, err: err}
+		}
+		result := lastfmSessionMsg{scrobblerID: msg.scrobblerID, session: sess}
+		if cfgPath == "" {
+			result.saveErr = errors.New("config path unknown")
+		} else {
+			result.saveErr = secrets.SaveSetting(secrets.Keyring(), cfgPath, config.SectionScrobblers, msg.scrobblerID, "session_key", sess.Key)
+		}
+		return result
+	}
+}
+
+// applyLastfmSession hands a new session key to the running scrobbler.
+func (m Model) applyLastfmSession(msg lastfmSessionMsg) (Model, tea.Cmd) {
+	applied := false
+	if m.scrobbler != nil {
+		for _, s := range m.scrobbler.Scrobblers() {
+			if lfm, ok := s.(*lastfm.Scrobbler); ok && lfm.ID() == msg.scrobblerID {
+				lfm.SetSessionKey(msg.session.Key)
+				applied = true
+			}
+		}
+	}
+	m.logger.Info("lastfm authorized",
+		slog.String("scrobbler", msg.scrobblerID),
+		slog.String("user", msg.session.Name),
+		slog.Bool("live", applied),
+		slog.Any("save_err", msg.saveErr))
+
+	if msg.saveErr != nil {
+		return m.setError(fmt.Errorf("last.fm connected but session key not saved: %w", msg.saveErr))
+	}
+	if applied {
+		m.status = m.msgs.T("lastfm.connected", msg.session.Name)
+	} else {
+		m.status = m.msgs.T("lastfm.connected_restart", msg.session.Name)
+	}
+	return m, nil
+}
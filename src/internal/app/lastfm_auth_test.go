@@ -0,0 +1,55 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/scrobble"
+	"github.com/tunez/tunez/internal/scrobble/lastfm"
+)
+
+func TestLastfmAuthRequiresScrobbler(t *testing.T) {
+	m := createTestModel(t)
+	m, cmd := m.startLastfmAuth()
+	if m.errorMsg == "" {
+		t.Error("expected an error without a lastfm scrobbler")
+	}
+	if cmd == nil {
+		t.Error("expected clear-error command")
+	}
+
+	m.cfg.Scrobblers = []config.ScrobblerEntry{{ID: "lastfm", Type: "lastfm", Settings: map[string]any{"api_key": "k"}}}
+	m.errorMsg = ""
+	m, _ = m.startLastfmAuth()
+	if !strings.Contains(m.errorMsg, "api_secret") {
+		t.Errorf("expected missing api_secret error, got %q", m.errorMsg)
+	}
+}
+
+func TestLastfmSessionAppliesToScrobbler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	doc := "[[scrobblers]]\nid = \"lastfm\"\ntype = \"lastfm\"\n[scrobblers.settings]\napi_key = \"k\"\napi_secret = \"s\"\n"
+	if err := os.WriteFile(path, []byte(doc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m := createTestModel(t)
+	m.cfg.Path = path
+	lfm := lastfm.New("lastfm", lastfm.Config{APIKey: "k", APISecret: "s"})
+	m.scrobbler = scrobble.NewManager()
+	m.scrobbler.Register(lfm)
+	if lfm.IsEnabled() {
+		t.Fatal("expected scrobbler to be disabled without a session key")
+	}
+
+	m, _ = updateModel(m, lastfmSessionMsg{scrobblerID: "lastfm", session: lastfm.Session{Name: "rj", Key: "sess"}})
+	if !lfm.IsEnabled() {
+		t.Error("expected live scrobbler to receive the session key")
+	}
+	if !strings.Contains(m.status, "rj") {
+		t.Errorf("expected status to name the user, got %q", m.status)
+	}
+}
@@ -45,7 +45,7 @@ func TestViewLayoutLimits(t *testing.T) {
 				})
 			}
 
-			m := New(cfg, &mockProvider{}, nil, nil, nil, theme, StartupOptions{}, nil, nil, nil, nil)
+			m := New(cfg, &mockProvider{}, nil, nil, nil, theme, StartupOptions{}, nil, nil, nil, nil, nil, nil, nil)
 			m.queue = q
 			m.screen = screenQueue
 			m.startupDone = true
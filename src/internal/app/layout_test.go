@@ -47,7 +47,7 @@ func newTestModelForLayout(width, height int) Model {
 	prov := &mockProvider{}
 	pl := player.New(player.Options{DisableProcess: true})
 
-	m := New(cfg, prov, nil, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil)
+	m := New(cfg, prov, nil, pl, nil, theme, StartupOptions{}, nil, nil, nil, nil, nil, nil, nil)
 	m.width = width
 	m.height = height
 	m.screen = screenNowPlaying
@@ -0,0 +1,101 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tunez/tunez/internal/platform"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// clipboardHold is how long a copy rides along with the rendered frames,
+// long enough for at least one of them to reach the terminal.
+const clipboardHold = 250 * time.Millisecond
+
+// trackLocationMsg carries where a track's file or stream is, for Copy
+// Path, or the outcome of opening its folder.
+type trackLocationMsg struct {
+	track    provider.Track
+	location string
+	opened   bool
+	err      error
+}
+
+// clipboardSentMsg ends the copy numbered seq.
+type clipboardSentMsg struct {
+	seq int
+}
+
+// trackLocation returns a track's file path, or for a streamed track the
+// URL it plays from.
+func trackLocation(ctx context.Context, prov provider.Provider, t provider.Track) (string, error) {
+	if t.FilePath != "" {
+		return t.FilePath, nil
+	}
+	if t.StreamURL != "" {
+		return t.StreamURL, nil
+	}
+	info, err := prov.GetStream(ctx, t.ID)
+	return info.URL, err
+}
+
+// copyTrackLocation copies the selected or playing track's file path, or
+// its stream URL, to the clipboard.
+func (m Model) copyTrackLocation() (Model, tea.Cmd) {
+	t, ok := m.infoTarget()
+	if !ok {
+		m.status = m.msgs.T("status.no_track_selected")
+		return m, nil
+	}
+	prov := m.provider
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		location, err := trackLocation(ctx, prov, t)
+		return trackLocationMsg{track: t, location: location, err: err}
+	}
+}
+
+// openTrackFolder opens the folder of the selected or playing track's file
+// in the file manager.
+func (m Model) openTrackFolder() (Model, tea.Cmd) {
+	t, ok := m.infoTarget()
+	if !ok {
+		m.status = m.msgs.T("status.no_track_selected")
+		return m, nil
+	}
+	if t.FilePath == "" {
+		m.status = m.msgs.T("location.not_local")
+		return m, nil
+	}
+	dir := filepath.Dir(t.FilePath)
+	return m, func() tea.Msg {
+		return trackLocationMsg{track: t, location: dir, opened: true, err: platform.Open(dir)}
+	}
+}
+
+// handleTrackLocation copies a track's location with OSC 52, which
+// terminals pass to the system clipboard, or reports the opened folder.
+func (m Model) handleTrackLocation(msg trackLocationMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if msg.opened {
+		m.status = m.msgs.T("location.opened", msg.location)
+		return m, nil
+	}
+	if msg.location == "" {
+		m.status = m.msgs.T("location.none", msg.track.Title)
+		return m, nil
+	}
+	m.logger.Debug("copying track location", slog.String("track_id", msg.track.ID))
+	m.clipboardSeq++
+	m.clipboard = ansi.SetSystemClipboard(msg.location)
+	m.status = m.msgs.T("location.copied", msg.location)
+	seq := m.clipboardSeq
+	return m, tea.Tick(clipboardHold, func(time.Time) tea.Msg { return clipboardSentMsg{seq: seq} })
+}
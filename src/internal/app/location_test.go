@@ -0,0 +1,44 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestCopyTrackLocation(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.screen = screenLibrary
+	m.tracks = []provider.Track{{ID: "100", Title: "Come Together", FilePath: "/music/Beatles/01 Come Together.flac"}}
+
+	m, cmd := m.copyTrackLocation()
+	m, tick := updateModel(m, cmd())
+	seq := ansi.SetSystemClipboard("/music/Beatles/01 Come Together.flac")
+	if m.clipboard != seq || tick == nil || !strings.HasPrefix(m.View(), seq) {
+		t.Fatalf("expected the path sent to the clipboard with the frame, got %q", m.clipboard)
+	}
+	if m.status != "Copied /music/Beatles/01 Come Together.flac" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+
+	// A later copy outlives the earlier one's timer
+	m.tracks[0].FilePath, m.tracks[0].StreamURL = "", "https://example.com/stream/100"
+	m, cmd = m.copyTrackLocation()
+	m, _ = updateModel(m, cmd())
+	m, _ = updateModel(m, clipboardSentMsg{seq: m.clipboardSeq - 1})
+	if m.clipboard != ansi.SetSystemClipboard("https://example.com/stream/100") {
+		t.Fatalf("expected the stream URL still being copied, got %q", m.clipboard)
+	}
+	m, _ = updateModel(m, clipboardSentMsg{seq: m.clipboardSeq})
+	if m.clipboard != "" || strings.Contains(m.View(), "\x1b]52") {
+		t.Error("expected the copy to stop once sent")
+	}
+
+	// Streamed tracks have no folder to open
+	if m, cmd = m.openTrackFolder(); cmd != nil || m.status != "Only local files have a folder to open" {
+		t.Errorf("expected no folder for a stream, got status %q", m.status)
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/scrobble"
+)
+
+// loveMsg is the result of loving or unloving a track on the scrobblers.
+type loveMsg struct {
+	track    provider.Track
+	love     bool
+	accepted int // backends that applied the change
+	err      error
+}
+
+// isLoved reports whether the track is loved, as far as this session knows.
+func (m Model) isLoved(trackID string) bool {
+	return m.loved[trackID]
+}
+
+// setLoved notes whether the track is loved.
+func (m *Model) setLoved(t provider.Track, loved bool) {
+	if m.loved == nil {
+		m.loved = make(map[string]bool)
+	}
+	m.loved[t.ID] = loved
+	if t.ID == m.nowPlaying.ID {
+		m.nowPlayingStats.Loved = loved
+	}
+}
+
+// toggleLove loves the playing track, or unloves it if it already is.
+func (m Model) toggleLove() (Model, tea.Cmd) {
+	t := m.nowPlaying
+	if t.ID == "" {
+		m.status = m.msgs.T("status.nothing_playing")
+		return m, nil
+	}
+	if m.scrobbler == nil || !m.scrobbler.CanLove() {
+		m.status = m.msgs.T("love.unsupported")
+		return m, nil
+	}
+
+	mgr, store := m.scrobbler, m.queueStore
+	providerID, localID := m.trackOrigin(t.ID)
+	track := scrobble.Track{
+		Title:      t.Title,
+		Artist:     t.ArtistName,
+		Album:      t.AlbumTitle,
+		DurationMs: t.DurationMs,
+		Provider:   providerID,
+		ProviderID: localID,
+	}
+	loved, known := m.loved[t.ID]
+	if known || store == nil {
+		love := !loved
+		// Show the heart right away; loveMsg reverts it if every backend fails
+		m.setLoved(t, love)
+		m.logger.Debug("love track", slog.String("track_id", t.ID), slog.Bool("love", love))
+		return m, func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			n, err := mgr.Love(ctx, track, love)
+			return loveMsg{track: t, love: love, accepted: n, err: err}
+		}
+	}
+
+	// Not yet known this session, e.g. pressed before the track's stats
+	// loaded: the stored mark decides between love and unlove
+	logger := m.logger
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		st, err := store.TrackStats(ctx, t.ArtistName, t.Title)
+		if err != nil {
+			logger.Warn("load loved mark", slog.String("track_id", t.ID), slog.Any("err", err))
+		}
+		love := !st.Loved
+		logger.Debug("love track", slog.String("track_id", t.ID), slog.Bool("love", love))
+		n, err := mgr.Love(ctx, track, love)
+		return loveMsg{track: t, love: love, accepted: n, err: err}
+	}
+}
+
+// handleLoveMsg reports the outcome of a love/unlove request.
+func (m Model) handleLoveMsg(msg loveMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("love track failed", slog.String("track_id", msg.track.ID), slog.Any("err", msg.err))
+		if msg.accepted == 0 {
+			m.setLoved(msg.track, !msg.love)
+			return m.setError(msg.err)
+		}
+		m.setLoved(msg.track, msg.love)
+		m, cmd := m.setError(msg.err)
+		return m, tea.Batch(cmd, m.saveLovedCmd(msg.track, msg.love))
+	}
+	m.setLoved(msg.track, msg.love)
+	if msg.love {
+		m.status = m.msgs.T("love.loved")
+	} else {
+		m.status = m.msgs.T("love.unloved")
+	}
+	return m, m.saveLovedCmd(msg.track, msg.love)
+}
+
+// loveIndicator returns the heart shown next to loved tracks.
+func (m Model) loveIndicator(trackID string) string {
+	if !m.isLoved(trackID) {
+		return ""
+	}
+	if m.noEmoji {
+		return " <3"
+	}
+	return " ♥"
+}
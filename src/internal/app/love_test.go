@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+	"github.com/tunez/tunez/internal/scrobble"
+)
+
+// fakeLover is a scrobbler that supports loving tracks.
+type fakeLover struct {
+	err   error
+	loved []bool // each love (true) and unlove sent
+}
+
+func (f *fakeLover) ID() string                                       { return "fake" }
+func (f *fakeLover) Name() string                                     { return "Fake" }
+func (f *fakeLover) IsEnabled() bool                                  { return true }
+func (f *fakeLover) NowPlaying(context.Context, scrobble.Track) error { return nil }
+func (f *fakeLover) Scrobble(context.Context, scrobble.Track) error   { return nil }
+func (f *fakeLover) UpdatePosition(time.Duration, bool)               {}
+func (f *fakeLover) ShouldScrobble() bool                             { return false }
+func (f *fakeLover) SavePending() error                               { return nil }
+func (f *fakeLover) LoadPending() error                               { return nil }
+func (f *fakeLover) PendingCount() int                                { return 0 }
+func (f *fakeLover) FlushPending(context.Context) error               { return nil }
+func (f *fakeLover) Love(_ context.Context, _ scrobble.Track, love bool) error {
+	f.loved = append(f.loved, love)
+	return f.err
+}
+
+func TestLoveTrack(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.width, m.height = 120, 40
+	m.screen = screenNowPlaying
+	m.nowPlaying = provider.Track{ID: "np", Title: "Playing", ArtistName: "Band"}
+	m.scrobbler = scrobble.NewManager()
+	m.scrobbler.Register(&fakeLover{})
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	if !m.isLoved("np") {
+		t.Fatal("expected track to be marked loved")
+	}
+	if cmd == nil {
+		t.Fatal("expected love command")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.status != "Loved ♥" {
+		t.Errorf("expected loved status, got %q", m.status)
+	}
+	if !strings.Contains(m.View(), "Playing ♥") {
+		t.Error("expected heart next to the playing track")
+	}
+
+	// Pressing again unloves
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'F'}})
+	m, _ = updateModel(m, cmd())
+	if m.isLoved("np") {
+		t.Error("expected track to be unloved")
+	}
+}
+
+func TestLoveTrackRevertsOnFailure(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.nowPlaying = provider.Track{ID: "np", Title: "Playing"}
+	m.scrobbler = scrobble.NewManager()
+	m.scrobbler.Register(&fakeLover{err: errors.New("offline")})
+
+	m, cmd := m.toggleLove()
+	m, _ = updateModel(m, cmd())
+	if m.isLoved("np") {
+		t.Error("expected love to be reverted when no backend accepted it")
+	}
+	if !strings.Contains(m.errorMsg, "offline") {
+		t.Errorf("expected error to be shown, got %q", m.errorMsg)
+	}
+}
+
+func TestLoveWithoutSupport(t *testing.T) {
+	m := createTestModel(t)
+	m.nowPlaying = provider.Track{ID: "np", Title: "Playing"}
+	m, cmd := m.toggleLove()
+	if cmd != nil || m.isLoved("np") {
+		t.Error("expected no-op without a scrobbler that supports loving")
+	}
+	if m.status != "No scrobbler supports loving tracks" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+}
+
+func TestLoveTrackLovedLastSession(t *testing.T) {
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer store.Close()
+	if err := store.SetLoved(t.Context(), "Band", "Playing", true); err != nil {
+		t.Fatal(err)
+	}
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.queueStore = store
+	m.nowPlaying = provider.Track{ID: "np", Title: "Playing", ArtistName: "Band"}
+	lover := &fakeLover{}
+	m.scrobbler = scrobble.NewManager()
+	m.scrobbler.Register(lover)
+
+	// Pressed before the track's stats loaded, the stored mark still
+	// makes it an unlove
+	m, cmd := m.toggleLove()
+	m, _ = updateModel(m, cmd())
+	if len(lover.loved) != 1 || lover.loved[0] || m.isLoved("np") || m.status != "Unloved" {
+		t.Errorf("expected the loved track unloved, sent %v, status %q", lover.loved, m.status)
+	}
+
+	// Once known, pressing again loves it
+	m, cmd = m.toggleLove()
+	if !m.isLoved("np") {
+		t.Error("expected the heart shown right away")
+	}
+	if m, _ = updateModel(m, cmd()); len(lover.loved) != 2 || !lover.loved[1] {
+		t.Errorf("expected a love sent, got %v", lover.loved)
+	}
+}
@@ -0,0 +1,122 @@
+package app
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// lyricLine is one line of lyrics, sung at At seconds when synced.
+type lyricLine struct {
+	At   float64
+	Text string
+}
+
+// lrcTime matches an LRC timestamp such as [01:23.45] or [01:23].
+var lrcTime = regexp.MustCompile(`^\[(\d+):(\d{1,2}(?:[.:]\d+)?)\]`)
+
+// lrcTag matches the ID tags at the top of an LRC file, such as [ar:...].
+var lrcTag = regexp.MustCompile(`^\[[a-zA-Z#]+:[^\]]*\]$`)
+
+// parseLRC splits lyrics into lines. When any line carries LRC
+// timestamps, only timestamped lines are kept, one per timestamp, sorted
+// by time, and synced is true.
+func parseLRC(text string) (lines []lyricLine, synced bool) {
+	var plain []lyricLine
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		raw = strings.TrimSpace(raw)
+		var times []float64
+		for {
+			match := lrcTime.FindStringSubmatch(raw)
+			if match == nil {
+				break
+			}
+			mins, _ := strconv.Atoi(match[1])
+			secs, _ := strconv.ParseFloat(strings.Replace(match[2], ":", ".", 1), 64)
+			times = append(times, float64(mins)*60+secs)
+			raw = strings.TrimSpace(raw[len(match[0]):])
+		}
+		if len(times) == 0 {
+			if !lrcTag.MatchString(raw) {
+				plain = append(plain, lyricLine{Text: raw})
+			}
+			continue
+		}
+		for _, at := range times {
+			lines = append(lines, lyricLine{At: at, Text: raw})
+		}
+	}
+	if len(lines) == 0 {
+		return plain, false
+	}
+	sort.SliceStable(lines, func(i, j int) bool { return lines[i].At < lines[j].At })
+	return lines, true
+}
+
+// currentLyric returns the index of the synced line being sung at pos, or
+// -1 before the first.
+func currentLyric(lines []lyricLine, pos float64) int {
+	return sort.Search(len(lines), func(i int) bool { return lines[i].At > pos }) - 1
+}
+
+// renderLyricsPane draws the lyrics beside the artwork for the "lyrics"
+// Now Playing layout. Synced lyrics keep the current line highlighted a
+// third of the way down; plain lyrics scroll through with the track.
+func (m Model) renderLyricsPane(width, height int) string {
+	header := m.theme.Accent.Render(m.nowPlaying.Title) + m.theme.Error.Render(m.loveIndicator(m.nowPlaying.ID))
+	sub := m.theme.Text.Render(m.nowPlaying.ArtistName) + m.theme.Dim.Render(" — "+m.nowPlaying.AlbumTitle)
+	rows := max(height-3, 1)
+	fit := lipgloss.NewStyle().MaxWidth(width)
+
+	var body []string
+	switch {
+	case !m.provider.Capabilities()[provider.CapLyrics]:
+		body = []string{m.theme.Dim.Render("Lyrics not supported by this provider")}
+	case m.lyricsLoading || m.lyricsTrackID != m.nowPlaying.ID:
+		body = []string{m.theme.Dim.Render("Loading lyrics...")}
+	case m.lyricsError != nil || strings.TrimSpace(m.lyrics) == "":
+		body = []string{m.theme.Dim.Render("No lyrics available for this track")}
+	default:
+		lines, synced := parseLRC(m.lyrics)
+		current, start := -1, 0
+		if synced {
+			current = currentLyric(lines, m.timePos)
+			start = current - rows/3
+		} else if m.duration > 0 && len(lines) > rows {
+			start = int(float64(len(lines)-rows) * m.timePos / m.duration)
+		}
+		start = max(min(start, len(lines)-rows), 0)
+		for i := start; i < min(start+rows, len(lines)); i++ {
+			switch {
+			case i == current:
+				body = append(body, m.theme.Highlight.Render(fit.Render("▶ "+lines[i].Text)))
+			case synced:
+				body = append(body, m.theme.Dim.Render(fit.Render("  "+lines[i].Text)))
+			default:
+				body = append(body, m.theme.Text.Render(fit.Render(lines[i].Text)))
+			}
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left, append([]string{fit.Render(header), fit.Render(sub), ""}, body...)...)
+	return lipgloss.NewStyle().Width(width).Height(height).MaxHeight(height).Render(content)
+}
+
+// minLyricsWidth is the narrowest lyrics pane worth drawing beside the
+// artwork; narrower terminals put it underneath.
+const minLyricsWidth = 24
+
+// withLyrics puts the boxed lyrics pane, height rows tall, beside left
+// (leftWidth cells wide), or under it when the main pane is too narrow.
+func (m Model) withLyrics(left string, leftWidth, height int) string {
+	// Main pane less its padding, and the box's border and padding
+	inner := m.width - 2 - 20 - 12 - 2 - 4
+	if width := inner - leftWidth - 2; width >= minLyricsWidth {
+		return lipgloss.JoinHorizontal(lipgloss.Top, left, "  ", boxStyle.Render(m.renderLyricsPane(width, height)))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, left, boxStyle.Render(m.renderLyricsPane(max(inner, minLyricsWidth), height)))
+}
@@ -0,0 +1,75 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestParseLRC(t *testing.T) {
+	text := "[ar:Pink Floyd]\n[ti:Time]\n[00:10.50]Ticking away\n[01:02][00:20.00]Chorus\n[00:15.25]The moments\n"
+	lines, synced := parseLRC(text)
+	if !synced {
+		t.Fatal("expected timestamped lyrics to be synced")
+	}
+	want := []lyricLine{{10.5, "Ticking away"}, {15.25, "The moments"}, {20, "Chorus"}, {62, "Chorus"}}
+	if len(lines) != len(want) {
+		t.Fatalf("expected %d lines, got %+v", len(want), lines)
+	}
+	for i := range want {
+		if lines[i] != want[i] {
+			t.Errorf("line %d: expected %+v, got %+v", i, want[i], lines[i])
+		}
+	}
+
+	for pos, idx := range map[float64]int{0: -1, 10.5: 0, 16: 1, 100: 3} {
+		if got := currentLyric(lines, pos); got != idx {
+			t.Errorf("currentLyric(%v) = %d, want %d", pos, got, idx)
+		}
+	}
+
+	plain, synced := parseLRC("First line\n\nSecond line")
+	if synced || len(plain) != 3 || plain[2].Text != "Second line" {
+		t.Errorf("expected plain lyrics kept as they are, got %+v (synced %v)", plain, synced)
+	}
+}
+
+type lyricsProvider struct{ *testProvider }
+
+func (lyricsProvider) Capabilities() provider.Capabilities {
+	return provider.Capabilities{provider.CapLyrics: true}
+}
+
+func TestNowPlayingLyricsLayout(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.provider = lyricsProvider{newTestProvider()}
+	m.cfg.UI.NowPlayingLayout = "lyrics"
+	m.screen = screenNowPlaying
+	m.nowPlaying = provider.Track{ID: "t1", Title: "Time", ArtistName: "Pink Floyd", AlbumTitle: "The Dark Side of the Moon"}
+	m.lyricsTrackID = "t1"
+	m.lyrics = "[00:10.00]Ticking away\n[00:15.00]The moments that make up\n[00:20.00]A dull day"
+	m.duration = 413
+	m.timePos = 16
+	m.width, m.height = 140, 40
+
+	view := m.View()
+	if !strings.Contains(view, "▶ The moments that make up") {
+		t.Errorf("expected the current line marked, got:\n%s", view)
+	}
+	if !strings.Contains(view, "Ticking away") || !strings.Contains(view, "A dull day") {
+		t.Errorf("expected the surrounding lines, got:\n%s", view)
+	}
+
+	// Too narrow to sit beside the track info, the pane goes underneath
+	m.width = 80
+	if view := m.View(); !strings.Contains(view, "▶ The moments that make up") {
+		t.Errorf("expected the lyrics under the track info, got:\n%s", view)
+	}
+
+	m.cfg.UI.NowPlayingLayout = "default"
+	if view := m.View(); strings.Contains(view, "The moments") {
+		t.Errorf("expected no lyrics on the default layout, got:\n%s", view)
+	}
+}
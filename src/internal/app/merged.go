@@ -0,0 +1,38 @@
+package app
+
+import (
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/provider/composite"
+)
+
+// originProvider is implemented by providers that merge several profiles
+// into one library (see composite.Provider).
+type originProvider interface {
+	Origin(id string) (composite.Member, string, bool)
+}
+
+// providerBadge labels an item with the profile it came from when browsing
+// a merged library. It is empty for a single provider.
+func (m Model) providerBadge(id string) string {
+	op, ok := provider.As[originProvider](m.provider)
+	if !ok {
+		return ""
+	}
+	mem, _, ok := op.Origin(id)
+	if !ok {
+		return ""
+	}
+	return "  [" + mem.Name + "]"
+}
+
+// trackOrigin returns the ID of the provider a track was played from and
+// its ID there, looking through merged libraries. Scrobblers that submit
+// provider IDs need the original pair.
+func (m Model) trackOrigin(id string) (providerID, localID string) {
+	if op, ok := provider.As[originProvider](m.provider); ok {
+		if mem, local, ok := op.Origin(id); ok {
+			return mem.Provider.ID(), local
+		}
+	}
+	return m.provider.ID(), id
+}
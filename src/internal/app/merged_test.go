@@ -0,0 +1,27 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider/composite"
+)
+
+func TestMergedTrackOrigin(t *testing.T) {
+	m := createTestModel(t)
+	inner := newTestProvider()
+	merged := composite.New([]composite.Member{{ProfileID: "home", Name: "Home", Provider: inner}})
+	m = initializeModel(m, inner)
+	m.provider = m.observed(merged)
+
+	providerID, localID := m.trackOrigin("home:t1")
+	if providerID != inner.ID() || localID != "t1" {
+		t.Errorf("expected (%s, t1), got (%s, %s)", inner.ID(), providerID, localID)
+	}
+	if badge := m.providerBadge("home:t1"); badge != "  [Home]" {
+		t.Errorf("unexpected badge %q", badge)
+	}
+	m.provider = inner
+	if badge := m.providerBadge("t1"); badge != "" {
+		t.Errorf("expected no badge for a single provider, got %q", badge)
+	}
+}
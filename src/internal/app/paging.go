@@ -0,0 +1,85 @@
+package app
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// pagePrefetchPercent is how far down the loaded list the selection has to
+// be before the next page is fetched, so it is usually in before the
+// selection reaches the end.
+const pagePrefetchPercent = 80
+
+// pageMsg carries a page fetched ahead for the list key names.
+type pageMsg struct {
+	key string
+	msg tea.Msg
+}
+
+// nextPage returns the command loading the next page of the list on
+// screen and a key naming the list and page, or a nil command when the
+// list is complete.
+func (m Model) nextPage() (string, tea.Cmd) {
+	switch m.screen {
+	case screenSearch:
+		var cursor string
+		switch m.searchFilter {
+		case filterTracks:
+			cursor = m.searchResults.Tracks.NextCursor
+		case filterAlbums:
+			cursor = m.searchResults.Albums.NextCursor
+		case filterArtists:
+			cursor = m.searchResults.Artists.NextCursor
+		}
+		if cursor != "" {
+			return "search:" + m.searchQ + ":" + m.searchFilter.String() + ":" + cursor, m.searchMoreCmd(m.searchQ, cursor)
+		}
+	case screenLibrary:
+		switch {
+		case len(m.tracks) > 0:
+			if m.tracksCursor != "" {
+				return "tracks:" + m.currentArtistID + ":" + m.currentAlbumID + ":" + m.currentPlaylistID + ":" + m.tracksCursor, m.moreTracksCmd(m.tracksCursor)
+			}
+		case len(m.albums) > 0:
+			if m.albumsCursor != "" {
+				return "albums:" + m.currentArtistID + ":" + m.albumsCursor, m.loadAlbumsCmd(m.currentArtistID, m.albumsCursor)
+			}
+		case len(m.artists) > 0:
+			if m.artistsCursor != "" {
+				return "artists:" + m.artistsCursor, m.loadArtistsCmd(m.artistsCursor)
+			}
+		}
+	}
+	return "", nil
+}
+
+// prefetchPage fetches the next page in the background once the selection
+// is pagePrefetchPercent of the way down the loaded list. Each page is
+// requested once.
+func (m Model) prefetchPage() (Model, tea.Cmd) {
+	n := m.currentListLen()
+	if n == 0 || (m.selection+1)*100 < n*pagePrefetchPercent {
+		return m, nil
+	}
+	key, cmd := m.nextPage()
+	if cmd == nil || key == m.pageFetch {
+		return m, nil
+	}
+	m.logger.Debug("prefetching next page", slog.String("page", key), slog.Int("selection", m.selection), slog.Int("loaded", n))
+	m.pageFetch = key
+	return m, func() tea.Msg { return pageMsg{key: key, msg: cmd()} }
+}
+
+// handlePage applies a fetched page unless the user has moved to another
+// list since it was requested; the page is fetched again if they come back.
+func (m Model) handlePage(msg pageMsg) (tea.Model, tea.Cmd) {
+	if msg.key == m.pageFetch {
+		m.pageFetch = ""
+	}
+	if key, _ := m.nextPage(); msg.key != key {
+		m.logger.Debug("dropping page for a list no longer shown", slog.String("page", msg.key))
+		return m, nil
+	}
+	return m.Update(msg.msg)
+}
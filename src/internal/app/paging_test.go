@@ -0,0 +1,47 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestPagePrefetch(t *testing.T) {
+	prov := &pagedArtists{testProvider: newTestProvider()}
+	prov.artists = jumpArtists()
+	m := jumpTestModel(t, prov)
+	m.cfg.UI.PageSize = 5
+	m.artists = nil
+	m.artistsCursor = ""
+	m, _ = updateModel(m, m.loadArtistsCmd("")())
+
+	down := tea.KeyMsg{Type: tea.KeyDown}
+	var cmd tea.Cmd
+	for range 2 {
+		m, cmd = updateModel(m, down)
+		if cmd != nil {
+			t.Fatalf("expected no fetch at row %d of %d", m.selection, len(m.artists))
+		}
+	}
+	m, cmd = updateModel(m, down)
+	if cmd == nil {
+		t.Fatal("expected the next page fetched at 80% of the list")
+	}
+	if _, again := updateModel(m, down); again != nil {
+		t.Error("expected the page fetched once")
+	}
+
+	page := cmd()
+	m, _ = updateModel(m, page)
+	if len(m.artists) != 8 || m.selection != 3 || m.artistsCursor != "" {
+		t.Fatalf("expected the page appended under the selection, got %d artists, sel=%d", len(m.artists), m.selection)
+	}
+
+	// A page for a list that has since been replaced is dropped
+	m.artists = m.artists[:5]
+	m.artistsCursor = "9"
+	m, _ = updateModel(m, page)
+	if len(m.artists) != 5 {
+		t.Errorf("expected a stale page dropped, got %d artists", len(m.artists))
+	}
+}
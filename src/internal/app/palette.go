@@ -15,6 +15,7 @@ type PaletteState struct {
 	matches  []fuzzy.Match
 	selected int
 	registry *CommandRegistry
+	argCmd   *Command // argument command being prompted for, if any
 }
 
 // NewPaletteState creates a new palette state.
@@ -31,6 +32,19 @@ func (p *PaletteState) Reset() {
 	p.cursor = 0
 	p.matches = nil
 	p.selected = 0
+	p.argCmd = nil
+}
+
+// PromptArg switches the palette to prompting for cmd's argument.
+func (p *PaletteState) PromptArg(cmd *Command) {
+	p.Reset()
+	p.argCmd = cmd
+}
+
+// ArgCommand returns the command whose argument is being typed, or nil
+// while commands are being searched.
+func (p *PaletteState) ArgCommand() *Command {
+	return p.argCmd
 }
 
 // SetInput sets the search input and updates matches.
@@ -119,7 +133,7 @@ func (p *PaletteState) SelectedCommand() *Command {
 }
 
 func (p *PaletteState) updateMatches() {
-	if p.input == "" {
+	if p.input == "" || p.argCmd != nil {
 		p.matches = nil
 		p.selected = 0
 		return
@@ -138,6 +152,11 @@ func (p *PaletteState) Render(m *Model) string {
 	b.WriteString(m.theme.Title.Render("  ═══ Command Palette ═══  "))
 	b.WriteString("\n\n")
 
+	if p.argCmd != nil {
+		b.WriteString(m.theme.Accent.Render("  "+p.argCmd.Name) + m.theme.Dim.Render(" <"+p.argCmd.Arg+">"))
+		b.WriteString("\n")
+	}
+
 	// Input field
 	inputStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
@@ -154,6 +173,13 @@ func (p *PaletteState) Render(m *Model) string {
 	b.WriteString(inputStyle.Render(inputDisplay))
 	b.WriteString("\n\n")
 
+	if p.argCmd != nil {
+		b.WriteString(m.theme.Dim.Render("  " + p.argCmd.Description))
+		b.WriteString("\n\n")
+		b.WriteString(m.theme.Dim.Render("  Enter run  Esc back"))
+		return p.box(m, b.String())
+	}
+
 	// Results list
 	var items []Command
 	var matchIndices [][]int
@@ -199,7 +225,8 @@ func (p *PaletteState) Render(m *Model) string {
 		// Highlight selected item
 		prefix := "   "
 		if i == p.selected {
-			prefix = m.theme.Highlight.Render(" ▸ ")
+			mark, _ := m.selectedRow(" ▸ ")
+			prefix = m.theme.Highlight.Render(mark)
 		}
 
 		// Command name with fuzzy match highlighting
@@ -208,7 +235,10 @@ func (p *PaletteState) Render(m *Model) string {
 			name = highlightMatches(cmd.Name, matchIndices[i-startIdx], m.theme.Accent)
 		}
 
-		// Keybinding hint
+		// Argument placeholder and keybinding hint
+		if cmd.Arg != "" {
+			name += m.theme.Dim.Render(" <" + cmd.Arg + ">")
+		}
 		keyHint := ""
 		if cmd.Keybinding != "" {
 			keyHint = m.theme.Dim.Render(fmt.Sprintf(" [%s]", cmd.Keybinding))
@@ -226,8 +256,11 @@ func (p *PaletteState) Render(m *Model) string {
 	b.WriteString("\n")
 	b.WriteString(m.theme.Dim.Render("  ↑↓ navigate  Enter select  Esc close"))
 
-	// Wrap in a box
-	content := b.String()
+	return p.box(m, b.String())
+}
+
+// box wraps the palette content in a border centered on screen.
+func (p *PaletteState) box(m *Model, content string) string {
 	paletteBox := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
 		Padding(1, 2).
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// findArtistMsg carries the artist picked for a "Go to Artist" name.
+type findArtistMsg struct {
+	query  string
+	artist provider.Artist
+	found  bool
+	err    error
+}
+
+// parseTimestamp reads a position given as seconds, mm:ss or h:mm:ss.
+func parseTimestamp(s string) (float64, error) {
+	parts := strings.Split(strings.TrimSpace(s), ":")
+	if len(parts) > 3 {
+		return 0, fmt.Errorf("invalid position %q", s)
+	}
+	total := 0
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil || n < 0 || (i > 0 && n > 59) {
+			return 0, fmt.Errorf("invalid position %q", s)
+		}
+		total = total*60 + n
+	}
+	return float64(total), nil
+}
+
+// seekTo jumps to an absolute position in the playing track.
+func (m Model) seekTo(arg string) (Model, tea.Cmd) {
+	if m.nowPlaying.ID == "" {
+		m.status = m.msgs.T("status.nothing_playing")
+		return m, nil
+	}
+	pos, err := parseTimestamp(arg)
+	if err != nil {
+		return m.setError(err)
+	}
+	if m.duration > 0 {
+		pos = min(pos, m.duration-1)
+	}
+	m.logger.Debug("seek to", slog.Float64("position", pos), slog.Float64("from", m.timePos))
+	m.status = m.msgs.T("status.seeking", formatPosition(pos))
+	return m, m.seekCmd(pos - m.timePos)
+}
+
+// setVolume sets the volume to a level typed at the palette.
+func (m Model) setVolume(arg string) (Model, tea.Cmd) {
+	n, err := strconv.Atoi(strings.TrimSuffix(strings.TrimSpace(arg), "%"))
+	if err != nil {
+		return m.setError(fmt.Errorf("invalid volume %q", arg))
+	}
+	m.volume = float64(clamp(n, 0, 100))
+	m.status = m.msgs.T("status.volume", int(m.volume))
+	return m, func() tea.Msg {
+		if err := m.player.SetVolume(m.volume); err != nil {
+			return playerMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// findArtistCmd searches for an artist by name, preferring an exact match.
+func (m Model) findArtistCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		if strings.TrimSpace(name) == "" {
+			return findArtistMsg{query: name, err: errors.New("no artist name given")}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		res, err := m.provider.Search(ctx, name, provider.ListReq{PageSize: m.cfg.UI.PageSize})
+		if err != nil {
+			return findArtistMsg{query: name, err: err}
+		}
+		artist, ok := bestArtistMatch(res.Artists.Items, name)
+		return findArtistMsg{query: name, artist: artist, found: ok}
+	}
+}
+
+// bestArtistMatch picks the artist whose name equals name, then one whose
+// name contains it, then the provider's top result.
+func bestArtistMatch(artists []provider.Artist, name string) (provider.Artist, bool) {
+	if len(artists) == 0 {
+		return provider.Artist{}, false
+	}
+	q := strings.ToLower(strings.TrimSpace(name))
+	for _, a := range artists {
+		if strings.ToLower(a.Name) == q {
+			return a, true
+		}
+	}
+	for _, a := range artists {
+		if strings.Contains(strings.ToLower(a.Name), q) {
+			return a, true
+		}
+	}
+	return artists[0], true
+}
+
+// handleFindArtist opens the albums of the artist found for a name.
+func (m Model) handleFindArtist(msg findArtistMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if !msg.found {
+		m.status = m.msgs.T("status.no_artist", msg.query)
+		return m, nil
+	}
+	m.logger.Debug("go to artist", slog.String("query", msg.query), slog.String("artist_id", msg.artist.ID))
+	m = m.pushHistory()
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.currentArtistID = msg.artist.ID
+	m.albumsCursor = ""
+	m.status = m.msgs.T("status.loading", msg.artist.Name)
+	return m, m.loadAlbumsCmd(msg.artist.ID, "")
+}
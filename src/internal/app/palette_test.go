@@ -1,8 +1,10 @@
 package app
 
 import (
+	"strings"
 	"testing"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/tunez/tunez/internal/config"
 	"github.com/tunez/tunez/internal/queue"
 )
@@ -139,3 +141,70 @@ func TestPaletteState(t *testing.T) {
 		}
 	})
 }
+
+func TestPaletteArgumentCommand(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+
+	m = typeKeys(m, ":set vol")
+	if cmd := m.paletteState.SelectedCommand(); cmd == nil || cmd.ID != "playback.set_volume" {
+		t.Fatalf("expected Set Volume to rank first, got %+v", cmd)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.showPalette || m.paletteState.ArgCommand() == nil {
+		t.Fatal("expected the palette to prompt for the volume")
+	}
+	if view := m.paletteState.Render(&m); !strings.Contains(view, "Set Volume <0-100>") {
+		t.Errorf("expected argument prompt, got:\n%s", view)
+	}
+
+	// Esc returns to the command list rather than closing
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if !m.showPalette || m.paletteState.ArgCommand() != nil {
+		t.Fatal("expected esc to go back to the command list")
+	}
+
+	m = typeKeys(m, "set vol")
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = typeKeys(m, "140")
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showPalette || m.volume != 100 || cmd == nil {
+		t.Errorf("expected volume clamped to 100 and palette closed, got volume=%v palette=%v", m.volume, m.showPalette)
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	for in, want := range map[string]float64{"90": 90, "1:30": 90, "01:02:03": 3723, " 0:05 ": 5} {
+		if got, err := parseTimestamp(in); err != nil || got != want {
+			t.Errorf("parseTimestamp(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	for _, in := range []string{"", "1:75", "a:bc", "1:2:3:4", "-5"} {
+		if _, err := parseTimestamp(in); err == nil {
+			t.Errorf("parseTimestamp(%q): expected error", in)
+		}
+	}
+}
+
+func TestGoToArtist(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.provider = prov
+
+	var goTo Command
+	for _, cmd := range m.commandRegistry.Commands() {
+		if cmd.ID == "nav.go_to_artist" {
+			goTo = cmd
+		}
+	}
+	m, c := goTo.RunArg(&m, "queen")
+	msg, ok := c().(findArtistMsg)
+	if !ok || msg.artist.Name != "Queen" {
+		t.Fatalf("expected Queen, got %#v", msg)
+	}
+	m, c = updateModel(m, msg)
+	if m.screen != screenLibrary || m.currentArtistID != "4" || c == nil {
+		t.Errorf("expected Queen's albums to load, got screen=%v artist=%q", m.screen, m.currentArtistID)
+	}
+}
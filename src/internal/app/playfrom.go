@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// playFromHereMsg carries a listed track and the tracks listed after it.
+type playFromHereMsg struct {
+	tracks []provider.Track
+	err    error
+}
+
+// playFromHereCmd collects the track at idx in the Library track list and
+// every track after it. The pages not fetched yet are loaded from where the
+// list came from, the playlist it was opened from or else its album or
+// artist; lists shown whole, like an artist's top tracks, use the rows on
+// screen. Later tracks are played in their preferred version.
+func (m Model) playFromHereCmd(idx int) tea.Cmd {
+	first, rest := m.tracks[idx], m.trackVersions.rows(m.tracks[idx+1:])
+	cursor := m.tracksCursor
+	artistID, albumID, playlistID := m.currentArtistID, m.currentAlbumID, m.currentPlaylistID
+	req := provider.ListReq{PageSize: m.cfg.UI.PageSize, Sort: m.cfg.UI.Sort.Tracks}
+	switch {
+	case playlistID != "":
+		// Playlists keep their own order (see loadPlaylistTracksCmd)
+		artistID, albumID, req.Sort = "", "", ""
+	case albumID == "":
+		req.Years = m.yearFilter // as loadTracksCmd lists an artist's tracks
+	}
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		var folded versionList
+		for cursor != "" {
+			req.Cursor = cursor
+			page, err := m.provider.ListTracks(ctx, albumID, artistID, playlistID, req)
+			if err != nil {
+				return playFromHereMsg{err: err}
+			}
+			rest = folded.add(rest, page.Items, m.cfg.UI.Versions)
+			if page.NextCursor == cursor {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		return playFromHereMsg{tracks: append([]provider.Track{first}, rest...)}
+	}
+}
+
+// handlePlayFromHere queues the tracks after the current one, in list
+// order, and plays the first.
+func (m Model) handlePlayFromHere(msg playFromHereMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if len(msg.tracks) == 0 {
+		return m, nil
+	}
+	start, added := 0, 0
+	if m.queue.Len() == 0 {
+		added = m.queue.Add(msg.tracks...)
+	} else {
+		start = m.queue.CurrentIndex() + 1
+		added = m.queue.AddNext(msg.tracks...)
+	}
+	if added == 0 {
+		m.queueFull(added, len(msg.tracks))
+		return m, nil
+	}
+	m.logger.Debug("play from here", slog.String("track_id", msg.tracks[0].ID), slog.Int("queued", added), slog.Int("start", start))
+	m.status = m.msgs.T("playfrom.playing", msg.tracks[0].Title, added-1)
+	m.queueFull(added, len(msg.tracks))
+	return m, tea.Batch(m.playQueueTrackCmd(start), m.saveQueueCmd())
+}
@@ -0,0 +1,94 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestPlayFromHere(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.tracks = prov.tracks
+	m.tracksCursor = ""
+	m.queue.Add(provider.Track{ID: "900", Title: "Already Playing"}, provider.Track{ID: "901", Title: "Queued Later"})
+
+	// Enter only plays the track unless play_from_here is on
+	m.selection = 1
+	_, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if _, ok := cmd().(addAndPlayTrackMsg); !ok {
+		t.Fatal("expected enter to play just the track by default")
+	}
+
+	m.cfg.Queue.PlayFromHere = true
+	_, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(playFromHereMsg)
+	if !ok || len(msg.tracks) != 2 || msg.tracks[0].ID != "101" {
+		t.Fatalf("expected the selected track and the rest of the album, got %#v", msg)
+	}
+	m, cmd = updateModel(m, msg)
+	if cmd == nil {
+		t.Fatal("expected play command")
+	}
+	want := []string{"900", "101", "102", "901"}
+	items := m.queue.Items()
+	if len(items) != len(want) {
+		t.Fatalf("expected %d queued, got %d", len(want), len(items))
+	}
+	for i, id := range want {
+		if items[i].ID != id {
+			t.Errorf("queue[%d] = %s, want %s", i, items[i].ID, id)
+		}
+	}
+}
+
+// pagedPlaylistProvider serves a playlist in two pages and records what it was
+// asked for.
+type pagedPlaylistProvider struct {
+	*testProvider
+	asked []string // album/artist/playlist of each ListTracks call
+}
+
+func (p *pagedPlaylistProvider) ListTracks(ctx context.Context, albumID, artistID, playlistID string, req provider.ListReq) (provider.Page[provider.Track], error) {
+	p.asked = append(p.asked, albumID+"/"+artistID+"/"+playlistID)
+	if playlistID != "pl1" {
+		return p.testProvider.ListTracks(ctx, albumID, artistID, playlistID, req)
+	}
+	if req.Cursor == "" {
+		return provider.Page[provider.Track]{Items: []provider.Track{{ID: "p1", Title: "One"}, {ID: "p2", Title: "Two"}}, NextCursor: "2"}, nil
+	}
+	return provider.Page[provider.Track]{Items: []provider.Track{{ID: "p3", Title: "Three"}}}, nil
+}
+
+func TestPlayFromHerePlaylist(t *testing.T) {
+	m := createTestModel(t)
+	prov := &pagedPlaylistProvider{testProvider: newTestProvider()}
+	m = initializeModel(m, prov.testProvider)
+	m.provider = prov
+	m.cfg.Queue.PlayFromHere = true
+	m.screen = screenPlaylists
+	m.playlists = []provider.Playlist{{ID: "pl1", Name: "Mix"}}
+	// Left over from browsing an album before
+	m.currentArtistID, m.currentAlbumID = "1", "10"
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRight})
+	m, _ = updateModel(m, cmd())
+	if m.screen != screenLibrary || len(m.tracks) != 2 || m.currentPlaylistID != "pl1" {
+		t.Fatalf("expected the playlist's first page in the Library, got %d tracks of %q", len(m.tracks), m.currentPlaylistID)
+	}
+
+	m.selection = 1
+	_, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	msg, ok := cmd().(playFromHereMsg)
+	if !ok || len(msg.tracks) != 2 || msg.tracks[0].ID != "p2" || msg.tracks[1].ID != "p3" {
+		t.Fatalf("expected the rest of the playlist, got %#v", msg)
+	}
+	if last := prov.asked[len(prov.asked)-1]; last != "//pl1" {
+		t.Errorf("expected the playlist's next page, asked for %q", last)
+	}
+}
@@ -0,0 +1,155 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/playlistfile"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// playlistExportMsg reports a queue or playlist written to a file.
+type playlistExportMsg struct {
+	path    string
+	written int
+	total   int
+	err     error
+}
+
+// playlistImportMsg carries the tracks matched from a playlist file.
+type playlistImportMsg struct {
+	path   string
+	tracks []provider.Track
+	total  int
+	err    error
+}
+
+// expandHome replaces a leading ~ with the home directory.
+func expandHome(path string) string {
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+	return path
+}
+
+// playlistEntries converts tracks to playlist file entries. Only tracks
+// with a local file have a location.
+func playlistEntries(tracks []provider.Track) []playlistfile.Entry {
+	entries := make([]playlistfile.Entry, 0, len(tracks))
+	for _, t := range tracks {
+		entries = append(entries, playlistfile.Entry{Location: t.FilePath, Title: t.Title, Artist: t.ArtistName, Album: t.AlbumTitle, DurationMs: t.DurationMs})
+	}
+	return entries
+}
+
+// exportQueueCmd writes the queue to a playlist file.
+func (m Model) exportQueueCmd(path string) tea.Cmd {
+	path = expandHome(path)
+	tracks := m.queue.Items()
+	relative := m.cfg.Queue.ExportRelativePaths
+	return func() tea.Msg {
+		n, err := playlistfile.Write(path, "Tunez queue", playlistEntries(tracks), relative)
+		return playlistExportMsg{path: path, written: n, total: len(tracks), err: err}
+	}
+}
+
+// exportPlaylistCmd writes every track of a playlist to a playlist file.
+func (m Model) exportPlaylistCmd(lc queue.ListeningContext, path string) tea.Cmd {
+	path = expandHome(path)
+	relative := m.cfg.Queue.ExportRelativePaths
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		tracks, err := m.contextTracks(ctx, lc)
+		if err != nil {
+			return playlistExportMsg{path: path, err: err}
+		}
+		n, err := playlistfile.Write(path, lc.Title, playlistEntries(tracks), relative)
+		return playlistExportMsg{path: path, written: n, total: len(tracks), err: err}
+	}
+}
+
+// importPlaylistCmd reads a playlist file and matches its entries to
+// library tracks.
+func (m Model) importPlaylistCmd(path string) tea.Cmd {
+	path = expandHome(path)
+	return func() tea.Msg {
+		entries, err := playlistfile.Read(path)
+		if err != nil {
+			return playlistImportMsg{path: path, err: err}
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		var tracks []provider.Track
+		for _, e := range entries {
+			t, err := m.resolveEntry(ctx, e)
+			if err != nil {
+				if provider.IsNotFound(err) {
+					m.logger.Debug("playlist entry not found", slog.String("location", e.Location), slog.String("title", e.Title))
+					continue
+				}
+				return playlistImportMsg{path: path, err: err}
+			}
+			tracks = append(tracks, t)
+		}
+		return playlistImportMsg{path: path, tracks: tracks, total: len(entries)}
+	}
+}
+
+// resolveEntry finds the library track for a playlist file entry: by path
+// or tags through the local index when the provider has one, otherwise by
+// searching for its artist and title.
+func (m Model) resolveEntry(ctx context.Context, e playlistfile.Entry) (provider.Track, error) {
+	if r, ok := provider.As[provider.TrackResolver](m.provider); ok {
+		return r.ResolveTrack(ctx, e.Location, e.Title, e.Artist)
+	}
+	if e.Title == "" {
+		return provider.Track{}, provider.ErrNotFound
+	}
+	res, err := m.provider.Search(ctx, strings.TrimSpace(e.Artist+" "+e.Title), provider.ListReq{PageSize: 20})
+	if err != nil {
+		return provider.Track{}, err
+	}
+	for _, t := range res.Tracks.Items {
+		if strings.EqualFold(t.Title, e.Title) && (e.Artist == "" || strings.EqualFold(t.ArtistName, e.Artist)) {
+			return t, nil
+		}
+	}
+	return provider.Track{}, provider.ErrNotFound
+}
+
+// handlePlaylistExport reports an export.
+func (m Model) handlePlaylistExport(msg playlistExportMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("export playlist: %w", msg.err))
+	}
+	m.status = m.msgs.T("playlistfile.exported", msg.written, msg.path)
+	if skipped := msg.total - msg.written; skipped > 0 {
+		m.status += fmt.Sprintf(" (%d without a local file left out)", skipped)
+	}
+	return m, nil
+}
+
+// handlePlaylistImport appends the matched tracks to the queue.
+func (m Model) handlePlaylistImport(msg playlistImportMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("import playlist: %w", msg.err))
+	}
+	if len(msg.tracks) == 0 {
+		return m.setError(errors.New("import playlist: no entries matched the library"))
+	}
+	added := m.queue.Add(msg.tracks...)
+	m.status = m.msgs.T("playlistfile.imported", added, msg.total, filepath.Base(msg.path))
+	m.queueFull(added, len(msg.tracks))
+	return m, m.saveQueueCmd()
+}
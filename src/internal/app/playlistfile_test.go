@@ -0,0 +1,53 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestExportImportQueue(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	dir := t.TempDir()
+
+	local := prov.tracks[0]
+	local.FilePath = filepath.Join(dir, "music", "come_together.flac")
+	m.queue.Add(local, prov.tracks[1])
+
+	path := filepath.Join(dir, "queue.m3u8")
+	m, _ = updateModel(m, m.exportQueueCmd(path)())
+	if m.status != "Exported 1 tracks to "+path+" (1 without a local file left out)" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "#EXTINF:259,The Beatles - Come Together") {
+		t.Fatalf("expected an extended M3U entry, got %q %v", data, err)
+	}
+
+	m.cfg.Queue.ExportRelativePaths = true
+	m, _ = updateModel(m, m.exportQueueCmd(path)())
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "\nmusic/come_together.flac\n") {
+		t.Errorf("expected a relative path, got %q", data)
+	}
+
+	// Providers without a file index match entries by artist and title
+	xspf := filepath.Join(dir, "queue.xspf")
+	m, _ = updateModel(m, m.exportQueueCmd(xspf)())
+	m.queue.Clear()
+	m, _ = updateModel(m, m.importPlaylistCmd(xspf)())
+	items := m.queue.Items()
+	if len(items) != 2 || items[0].ID != "100" || items[1].ID != "101" {
+		t.Fatalf("expected both tracks imported in order, got %+v", items)
+	}
+	if m.status != "Imported 2 of 2 tracks from queue.xspf" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+
+	m, _ = updateModel(m, m.importPlaylistCmd(filepath.Join(dir, "missing.m3u"))())
+	if m.errorMsg == "" {
+		t.Error("expected an error importing a missing file")
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// playNextMsg carries every track of an album or playlist to insert after
+// the current track.
+type playNextMsg struct {
+	context queue.ListeningContext
+	tracks  []provider.Track
+	err     error
+}
+
+// playNextTarget returns the album or playlist selected in the Library
+// albums list, the Search album results or the Playlists screen.
+func (m Model) playNextTarget() (queue.ListeningContext, bool) {
+	var album provider.Album
+	switch {
+	case m.screen == screenLibrary && m.libraryView() == "albums":
+		album = m.albums[clamp(m.selection, 0, len(m.albums)-1)]
+	case m.screen == screenSearch && m.searchFilter == filterAlbums && len(m.searchResults.Albums.Items) > 0:
+		album = m.searchResults.Albums.Items[clamp(m.selection, 0, len(m.searchResults.Albums.Items)-1)]
+	case m.screen == screenPlaylists && len(m.playlists) > 0:
+		p := m.playlists[clamp(m.selection, 0, len(m.playlists)-1)]
+		return queue.ListeningContext{Kind: queue.ContextPlaylist, ID: p.ID, Title: p.Name}, true
+	default:
+		return queue.ListeningContext{}, false
+	}
+	return queue.ListeningContext{Kind: queue.ContextAlbum, ID: album.ID, Title: album.Title, Subtitle: album.ArtistName}, true
+}
+
+// playNextCmd loads every track of an album or playlist to play next.
+func (m Model) playNextCmd(lc queue.ListeningContext) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		tracks, err := m.contextTracks(ctx, lc)
+		return playNextMsg{context: lc, tracks: tracks, err: err}
+	}
+}
+
+// handlePlayNext inserts a loaded album or playlist after the current
+// track, in its own order.
+func (m Model) handlePlayNext(msg playNextMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if len(msg.tracks) == 0 {
+		m.status = m.msgs.T("status.nothing_in", msg.context.Title)
+		return m, nil
+	}
+	added := m.queue.AddNext(msg.tracks...)
+	m.logger.Debug("play next", slog.String("kind", string(msg.context.Kind)), slog.String("id", msg.context.ID), slog.Int("tracks", added))
+	m.status = m.msgs.T("status.playing_next", msg.context.Title, m.msgs.N("count.track", added))
+	m.queueFull(added, len(msg.tracks))
+	return m, m.saveQueueCmd()
+}
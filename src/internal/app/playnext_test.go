@@ -0,0 +1,64 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestPlayNextAlbum(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.queue.Add(provider.Track{ID: "1", Title: "Playing"}, provider.Track{ID: "2", Title: "Later"})
+	m.queue.SetCurrent(0)
+
+	m.screen = screenLibrary
+	m.albums = prov.albums
+	m.selection = 0
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("P")})
+	if cmd == nil {
+		t.Fatal("expected a command loading the album")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.status != "Playing next: Abbey Road (3 tracks)" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+
+	want := []string{"1", "100", "101", "102", "2"}
+	items := m.queue.Items()
+	if len(items) != len(want) {
+		t.Fatalf("expected %d queued tracks, got %d", len(want), len(items))
+	}
+	for i, id := range want {
+		if items[i].ID != id {
+			t.Errorf("item %d: expected %s, got %s", i, id, items[i].ID)
+		}
+	}
+}
+
+func TestPlayNextTarget(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+
+	m.screen = screenPlaylists
+	m.playlists = []provider.Playlist{{ID: "pl1", Name: "Road Trip"}}
+	if lc, ok := m.playNextTarget(); !ok || lc.ID != "pl1" || lc.Title != "Road Trip" {
+		t.Errorf("expected the selected playlist, got %+v (%v)", lc, ok)
+	}
+
+	m.screen = screenSearch
+	m.searchFilter = filterAlbums
+	m.searchResults = provider.SearchResults{Albums: provider.Page[provider.Album]{Items: prov.albums}}
+	m.selection = 1
+	if lc, ok := m.playNextTarget(); !ok || lc.ID != "11" {
+		t.Errorf("expected the selected search album, got %+v (%v)", lc, ok)
+	}
+
+	m.searchFilter = filterTracks
+	if _, ok := m.playNextTarget(); ok {
+		t.Error("expected no album or playlist target when searching tracks")
+	}
+}
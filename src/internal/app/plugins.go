@@ -0,0 +1,64 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/plugin"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// pluginCommandMsg carries the result of a plugin's palette command.
+type pluginCommandMsg struct {
+	name   string
+	status string
+	err    error
+}
+
+// registerPluginCommands adds the commands registered by Lua plugins to the
+// palette. They come before user-defined commands so those can use them as
+// steps.
+func (r *CommandRegistry) registerPluginCommands(m *Model) {
+	for _, c := range m.plugins.Commands() {
+		r.register(Command{
+			ID:          "plugin." + c.Plugin + "." + strings.ReplaceAll(strings.ToLower(strings.TrimSpace(c.Name)), " ", "_"),
+			Name:        c.Name,
+			Description: "From plugin " + c.Plugin,
+			Category:    "Plugins",
+			Handler: func(m *Model) (Model, tea.Cmd) {
+				return *m, m.pluginCommandCmd(c)
+			},
+		})
+	}
+}
+
+func (m Model) pluginCommandCmd(c plugin.Command) tea.Cmd {
+	plugins := m.plugins
+	return func() tea.Msg {
+		status, err := plugins.Run(c)
+		return pluginCommandMsg{name: c.Name, status: status, err: err}
+	}
+}
+
+func (m Model) handlePluginCommand(msg pluginCommandMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	m.status = msg.status
+	if m.status == "" {
+		m.status = m.msgs.T("status.ran", msg.name)
+	}
+	return m, nil
+}
+
+// pluginEventCmd calls the plugin hooks for event off the UI goroutine.
+func (m Model) pluginEventCmd(event string, t provider.Track) tea.Cmd {
+	if m.plugins.Len() == 0 {
+		return nil
+	}
+	plugins := m.plugins
+	return func() tea.Msg {
+		plugins.Emit(event, t)
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/plugin"
+)
+
+func TestPluginCommands(t *testing.T) {
+	dir := t.TempDir()
+	src := `
+local playing = "nothing"
+tunez.on("track_start", function(track) playing = track.title end)
+tunez.command("Say track", function() return "Now: " .. playing end)
+`
+	if err := os.WriteFile(filepath.Join(dir, "say.lua"), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	plugins, err := plugin.Load(dir, plugin.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer plugins.Close()
+
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.plugins = plugins
+	// User commands can run plugin commands as steps
+	m.cfg.Commands = []config.UserCommand{{Name: "Announce", Steps: []string{"plugin.say.say_track"}}}
+	registry := NewCommandRegistry(&m)
+
+	var say *Command
+	announce := false
+	for _, cmd := range registry.Commands() {
+		switch cmd.Name {
+		case "Say track":
+			say = &cmd
+		case "Announce":
+			announce = true
+		}
+	}
+	if say == nil || say.ID != "plugin.say.say_track" || say.Category != "Plugins" {
+		t.Fatalf("expected plugin command to be registered, got %+v", say)
+	}
+	if !announce {
+		t.Error("expected a user command using the plugin command to be registered")
+	}
+
+	// Hook state is visible to the script's commands
+	m.pluginEventCmd(plugin.EventTrackStart, prov.tracks[1])()
+
+	m, cmd := say.Handler(&m)
+	if cmd == nil {
+		t.Fatal("expected plugin command to run asynchronously")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.status != "Now: "+prov.tracks[1].Title {
+		t.Errorf("expected plugin status, got %q", m.status)
+	}
+}
@@ -0,0 +1,214 @@
+package app
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// prefetchMaxBytes caps a prefetched download. Longer streams, such as
+// audiobooks, are played from the server as usual.
+const prefetchMaxBytes = 256 << 20
+
+// prefetchState is the next queue item, resolved and downloaded ahead of
+// time so it starts without waiting on the server.
+type prefetchState struct {
+	startedFor string              // now-playing track the prefetch was started during
+	trackID    string              // track the prefetch is for
+	stream     provider.StreamInfo // the local copy, or the remote stream if it wasn't downloaded
+	path       string              // downloaded copy, or ""
+	old        string              // previous download, removed once the next one lands
+}
+
+// prefetchMsg is the result of prefetching a track.
+type prefetchMsg struct {
+	trackID string
+	stream  provider.StreamInfo
+	path    string
+	err     error
+}
+
+// prefetchDir holds this process's prefetched downloads.
+func prefetchDir() string {
+	return filepath.Join(os.TempDir(), fmt.Sprintf("tunez-prefetch-%d", os.Getpid()))
+}
+
+// RemovePrefetched deletes the downloads prefetched by this process. It is
+// called on exit.
+func RemovePrefetched() {
+	_ = os.RemoveAll(prefetchDir())
+}
+
+// maybePrefetch starts prefetching the next queue item once the current
+// track is within [player] prefetch_seconds of its end.
+func (m Model) maybePrefetch() (Model, tea.Cmd) {
+	secs := float64(m.cfg.Player.PrefetchSeconds)
+	if secs <= 0 || m.duration <= 0 || m.nowPlaying.ID == "" || m.prefetch.startedFor == m.nowPlaying.ID {
+		return m, nil
+	}
+	if m.duration-m.timePos > secs {
+		return m, nil
+	}
+	next, err := m.queue.PeekNext()
+	if err != nil || next.ID == m.prefetch.trackID {
+		return m, nil
+	}
+	m.prefetch.startedFor = m.nowPlaying.ID
+	m.logger.Debug("prefetching next track", slog.String("track_id", next.ID), slog.String("title", next.Title))
+	return m, m.prefetchCmd(next)
+}
+
+// prefetchCmd resolves track's stream and, for a remote stream, downloads
+// it to prefetchDir.
+func (m Model) prefetchCmd(track provider.Track) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+		stream, err := m.provider.GetStream(m.streamContext(ctx), track.ID)
+		if err != nil {
+			return prefetchMsg{trackID: track.ID, err: err}
+		}
+		if !remoteStream(stream.URL) {
+			return prefetchMsg{trackID: track.ID, stream: stream}
+		}
+		path, err := downloadStream(ctx, stream)
+		if err != nil {
+			// Still saves the lookup; mpv streams it as usual
+			m.logger.Debug("prefetch download failed", slog.String("track_id", track.ID), slog.Any("err", err))
+			return prefetchMsg{trackID: track.ID, stream: stream}
+		}
+		return prefetchMsg{trackID: track.ID, stream: provider.StreamInfo{URL: path}, path: path}
+	}
+}
+
+// handlePrefetch keeps a finished prefetch for when the track comes up.
+func (m Model) handlePrefetch(msg prefetchMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Debug("prefetch failed", slog.String("track_id", msg.trackID), slog.Any("err", msg.err))
+		return m, nil
+	}
+	// The download before last has finished playing by now
+	if m.prefetch.old != "" {
+		_ = os.Remove(m.prefetch.old)
+	}
+	m.prefetch.old = m.prefetch.path
+	m.prefetch.trackID = msg.trackID
+	m.prefetch.stream = msg.stream
+	m.prefetch.path = msg.path
+	return m, nil
+}
+
+// streamFor returns the stream to play track from: its prefetched copy
+// when there is one, otherwise the provider's stream.
+func (m Model) streamFor(ctx context.Context, track provider.Track) (provider.StreamInfo, error) {
+	if m.prefetch.trackID != "" && m.prefetch.trackID == track.ID {
+		return m.prefetch.stream, nil
+	}
+	return m.provider.GetStream(m.streamContext(ctx), track.ID)
+}
+
+// remoteStream reports whether a stream URL is fetched over the network.
+func remoteStream(u string) bool {
+	return strings.HasPrefix(u, "http://") || strings.HasPrefix(u, "https://")
+}
+
+// downloadStream saves a remote stream to prefetchDir, with the stream's
+// headers and TLS settings, and returns the file's path.
+func downloadStream(ctx context.Context, stream provider.StreamInfo) (string, error) {
+	client, err := streamClient(stream)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, stream.URL, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range stream.Headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("prefetch: %s", resp.Status)
+	}
+	if resp.ContentLength > prefetchMaxBytes {
+		return "", errors.New("prefetch: stream too large")
+	}
+
+	if err := os.MkdirAll(prefetchDir(), 0o700); err != nil {
+		return "", err
+	}
+	f, err := os.CreateTemp(prefetchDir(), "track-*"+streamExt(stream.URL))
+	if err != nil {
+		return "", err
+	}
+	n, err := io.Copy(f, io.LimitReader(resp.Body, prefetchMaxBytes+1))
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil && n > prefetchMaxBytes {
+		err = errors.New("prefetch: stream too large")
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// streamClient returns an HTTP client honoring the stream's TLS settings.
+func streamClient(stream provider.StreamInfo) (*http.Client, error) {
+	if stream.CAFile == "" && !stream.InsecureSkipVerify {
+		return http.DefaultClient, nil
+	}
+	tlsCfg := &tls.Config{MinVersion: tls.VersionTLS12, InsecureSkipVerify: stream.InsecureSkipVerify}
+	if stream.CAFile != "" {
+		pem, err := os.ReadFile(stream.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		roots.AppendCertsFromPEM(pem)
+		tlsCfg.RootCAs = roots
+	}
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = tlsCfg
+	return &http.Client{Transport: tr}, nil
+}
+
+// streamExt guesses a file extension from a stream URL, so mpv can probe
+// the download as it would the stream.
+func streamExt(stream string) string {
+	u, err := url.Parse(stream)
+	if err != nil {
+		return ""
+	}
+	if f := u.Query().Get("format"); slices.Contains(provider.StreamFormats, f) {
+		return "." + f
+	}
+	ext := filepath.Ext(u.Path)
+	if len(ext) > 6 {
+		return ""
+	}
+	return ext
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// remoteStreamProvider serves every track's stream from an HTTP server.
+type remoteStreamProvider struct {
+	*testProvider
+	url     string
+	streams int
+}
+
+func (p *remoteStreamProvider) GetStream(ctx context.Context, trackID string) (provider.StreamInfo, error) {
+	p.streams++
+	return provider.StreamInfo{URL: p.url + "/stream/" + trackID + ".mp3", Headers: map[string]string{"Authorization": "Bearer t"}}, nil
+}
+
+func TestPrefetchNextTrack(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte("audio:" + r.URL.Path))
+	}))
+	defer srv.Close()
+	t.Cleanup(RemovePrefetched)
+
+	prov := &remoteStreamProvider{testProvider: newTestProvider(), url: srv.URL}
+	m := createTestModel(t)
+	m = initializeModel(m, prov.testProvider)
+	m.provider = prov
+	m.cfg.Player.PrefetchSeconds = 15
+	m.queue.Add(prov.tracks[0], prov.tracks[1])
+	_ = m.queue.SetCurrent(0)
+	m.nowPlaying = prov.tracks[0]
+	m.duration = 200
+
+	m.timePos = 100
+	if _, cmd := m.maybePrefetch(); cmd != nil {
+		t.Fatal("expected no prefetch far from the end")
+	}
+	m.timePos = 190
+	m, cmd := m.maybePrefetch()
+	if cmd == nil {
+		t.Fatal("expected the next track prefetched near the end")
+	}
+	if _, again := m.maybePrefetch(); again != nil {
+		t.Error("expected one prefetch per track")
+	}
+	m, _ = updateModel(m, cmd())
+
+	stream, err := m.streamFor(context.Background(), prov.tracks[1])
+	if err != nil || stream.URL != m.prefetch.path || stream.URL == "" {
+		t.Fatalf("expected the downloaded copy, got %+v %v", stream, err)
+	}
+	data, err := os.ReadFile(stream.URL)
+	if err != nil || string(data) != "audio:/stream/101.mp3" {
+		t.Errorf("unexpected download %q %v", data, err)
+	}
+	if prov.streams != 1 {
+		t.Errorf("expected the stream looked up once, got %d", prov.streams)
+	}
+
+	// Other tracks still go to the provider
+	if _, err := m.streamFor(context.Background(), prov.tracks[2]); err != nil || prov.streams != 2 {
+		t.Errorf("expected a fresh lookup for another track, got %d lookups", prov.streams)
+	}
+}
@@ -0,0 +1,103 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/config"
+)
+
+// switchableProfiles returns the profiles offered by the quick switcher.
+func (m Model) switchableProfiles() []config.Profile {
+	var out []config.Profile
+	for _, p := range m.cfg.Profiles {
+		if p.Enabled {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// openProfileSwitcher shows the quick-switch popup with the active profile
+// selected.
+func (m Model) openProfileSwitcher() (Model, tea.Cmd) {
+	profiles := m.switchableProfiles()
+	if len(profiles) < 2 {
+		m.status = m.msgs.T("profiles.none")
+		return m, nil
+	}
+	m.showProfileSwitcher = true
+	m.profileSwitcherSel = 0
+	for i, p := range profiles {
+		if p.ID == m.cfg.ActiveProfile {
+			m.profileSwitcherSel = i
+		}
+	}
+	return m, nil
+}
+
+// handleProfileSwitcherKey handles keys while the quick switcher is open.
+// Number keys pick a profile directly.
+func (m Model) handleProfileSwitcherKey(key string) (Model, tea.Cmd) {
+	profiles := m.switchableProfiles()
+	switch key {
+	case "esc", "q":
+		m.showProfileSwitcher = false
+		return m, nil
+	case "up", "k":
+		if m.profileSwitcherSel > 0 {
+			m.profileSwitcherSel--
+		}
+		return m, nil
+	case "down", "j":
+		if m.profileSwitcherSel < len(profiles)-1 {
+			m.profileSwitcherSel++
+		}
+		return m, nil
+	case "enter":
+	default:
+		if len(key) != 1 || key[0] < '1' || key[0] > '9' || int(key[0]-'1') >= len(profiles) {
+			return m, nil
+		}
+		m.profileSwitcherSel = int(key[0] - '1')
+	}
+
+	m.showProfileSwitcher = false
+	profile := profiles[clamp(m.profileSwitcherSel, 0, len(profiles)-1)]
+	if profile.ID == m.cfg.ActiveProfile {
+		return m, nil
+	}
+	m.logger.Debug("quick switching profile", slog.String("from", m.cfg.ActiveProfile), slog.String("to", profile.ID))
+	m.status = m.msgs.T("status.switching")
+	return m, m.switchProfileCmd(profile)
+}
+
+// renderProfileSwitcher renders the quick-switch popup.
+func (m Model) renderProfileSwitcher() string {
+	var lines []string
+	for i, p := range m.switchableProfiles() {
+		prefix := "   "
+		style := m.theme.Text
+		if i == m.profileSwitcherSel {
+			prefix, style = m.selectedRow(" ▶ ")
+		}
+		line := fmt.Sprintf("%s%d  %s", prefix, i+1, p.Name)
+		if p.ID == m.cfg.ActiveProfile {
+			line += "  (active)"
+		}
+		lines = append(lines, style.Render(line)+m.theme.Dim.Render("  "+p.Provider))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Switch Profile ═══  "),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.theme.Dim.Render("[1-9/Enter]Switch  [Esc]Close"),
+		m.theme.Dim.Render("Each profile keeps its own queue"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestProfileSwitcher(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+
+	// A single profile has nothing to switch to
+	m.cfg.ActiveProfile = "home"
+	m.cfg.Profiles = []config.Profile{{ID: "home", Name: "Home", Provider: "filesystem", Enabled: true}}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	if m.showProfileSwitcher {
+		t.Fatal("expected switcher to stay closed with one profile")
+	}
+
+	m.cfg.Profiles = append(m.cfg.Profiles,
+		config.Profile{ID: "old", Name: "Old", Provider: "filesystem", Enabled: false},
+		config.Profile{ID: "server", Name: "Server", Provider: "melodee", Enabled: true},
+	)
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	if !m.showProfileSwitcher || m.profileSwitcherSel != 0 {
+		t.Fatalf("expected switcher open on the active profile, got open=%v sel=%d", m.showProfileSwitcher, m.profileSwitcherSel)
+	}
+
+	// Disabled profiles are not listed, so "2" is the server
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'2'}})
+	if m.showProfileSwitcher {
+		t.Error("expected switcher to close after picking")
+	}
+	if cmd == nil || m.status != "Switching profile..." {
+		t.Fatalf("expected a switch to start, got status %q", m.status)
+	}
+	if m.profileSwitcherSel != 1 {
+		t.Errorf("expected server selected, got %d", m.profileSwitcherSel)
+	}
+
+	// Picking the active profile is a no-op
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlO})
+	m.profileSwitcherSel = 0
+	if _, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("expected no switch for the active profile")
+	}
+}
+
+func TestProfileSwitchRestoresQueue(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.queue.Add(prov.tracks[0])
+
+	saved := queue.LoadResult{
+		Tracks:       []provider.Track{prov.tracks[1], prov.tracks[2]},
+		CurrentIndex: 1,
+	}
+	m, _ = updateModel(m, profileSwitchedMsg{
+		provider: prov,
+		profile:  config.Profile{ID: "server", Name: "Server"},
+		queue:    saved,
+	})
+
+	if m.cfg.ActiveProfile != "server" {
+		t.Errorf("expected active profile server, got %q", m.cfg.ActiveProfile)
+	}
+	items := m.queue.Items()
+	if len(items) != 2 || items[0].ID != prov.tracks[1].ID {
+		t.Fatalf("expected the server queue to be restored, got %+v", items)
+	}
+	if m.queue.CurrentIndex() != 1 {
+		t.Errorf("expected current index 1, got %d", m.queue.CurrentIndex())
+	}
+}
+
+func TestProfileSwitchAppliesOverrides(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.cfg.Profiles = []config.Profile{
+		{ID: "home", Name: "Home", Enabled: true},
+		{ID: "server", Name: "Server", Enabled: true,
+			UI:          config.ProfileUIConfig{PageSize: 20, Theme: "nord"},
+			Keybindings: config.KeybindConfig{NextTrack: "N"}},
+	}
+	pageSize := m.cfg.UI.PageSize
+
+	m, _ = updateModel(m, profileSwitchedMsg{provider: prov, profile: m.cfg.Profiles[1]})
+	if m.cfg.UI.PageSize != 20 || m.theme.Name != "nord" || m.cfg.Keybindings.NextTrack != "N" {
+		t.Fatalf("expected server overrides, got page_size=%d theme=%q next=%q", m.cfg.UI.PageSize, m.theme.Name, m.cfg.Keybindings.NextTrack)
+	}
+	if c, ok := m.commandRegistry.Command("playback.next"); !ok || c.Keybinding != "N" {
+		t.Errorf("expected the palette to show the new binding, got %+v", c)
+	}
+
+	m, _ = updateModel(m, profileSwitchedMsg{provider: prov, profile: m.cfg.Profiles[0]})
+	if m.cfg.UI.PageSize != pageSize || m.cfg.Keybindings.NextTrack == "N" {
+		t.Errorf("expected top-level settings back, got page_size=%d next=%q", m.cfg.UI.PageSize, m.cfg.Keybindings.NextTrack)
+	}
+}
@@ -0,0 +1,44 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// hiResHz is the sample rate above which lossless audio counts as hi-res.
+const hiResHz = 48000
+
+// qualityBadges marks the quality of a track in list rows when [ui]
+// quality_badges is on: Hi-Res or Lossless for lossless files, Lossy for
+// the rest, and Mono. Tracks of an unknown codec get no Lossless or Lossy
+// badge. The badges are words so they read without color too, and are
+// bracketed when there is none.
+func (m Model) qualityBadges(t provider.Track) string {
+	if !m.cfg.UI.QualityBadges {
+		return ""
+	}
+	var badges []string
+	add := func(label string, style func(...string) string) {
+		if m.cfg.NoColor() {
+			label = "[" + label + "]"
+		}
+		badges = append(badges, style(label))
+	}
+	switch {
+	case t.Codec == "":
+	case isLossless(t) && t.SampleRateHz > hiResHz:
+		add("Hi-Res", m.theme.Accent.Render)
+	case isLossless(t):
+		add("Lossless", m.theme.Success.Render)
+	default:
+		add("Lossy", m.theme.Dim.Render)
+	}
+	if t.Channels == 1 {
+		add("Mono", m.theme.Warning.Render)
+	}
+	if len(badges) == 0 {
+		return ""
+	}
+	return "  " + strings.Join(badges, " ")
+}
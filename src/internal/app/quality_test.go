@@ -0,0 +1,44 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestQualityBadges(t *testing.T) {
+	m := createTestModel(t)
+	if got := m.qualityBadges(provider.Track{Codec: "flac"}); got != "" {
+		t.Errorf("expected no badges unless turned on, got %q", got)
+	}
+	m.cfg.UI.QualityBadges = true
+	m.cfg.UI.NoEmoji = true // draws without color
+
+	for _, tc := range []struct {
+		track provider.Track
+		want  string
+	}{
+		{provider.Track{Codec: "flac", SampleRateHz: 96000, Channels: 2}, "  [Hi-Res]"},
+		{provider.Track{Codec: "flac", SampleRateHz: 44100}, "  [Lossless]"},
+		{provider.Track{Codec: "mp3", SampleRateHz: 96000, Channels: 1}, "  [Lossy] [Mono]"},
+		{provider.Track{Channels: 1}, "  [Mono]"},
+		{provider.Track{}, ""},
+	} {
+		if got := m.qualityBadges(tc.track); got != tc.want {
+			t.Errorf("%s %d Hz %d ch: expected %q, got %q", tc.track.Codec, tc.track.SampleRateHz, tc.track.Channels, tc.want, got)
+		}
+	}
+
+	// The badges follow the rows of the track lists
+	prov := newTestProvider()
+	for i := range prov.tracks {
+		prov.tracks[i].Codec = "flac"
+	}
+	m = initializeModel(m, prov)
+	m.screen = screenQueue
+	m.queue.Add(prov.tracks...)
+	if view := m.renderQueue(120, 20); strings.Count(view, "[Lossless]") != len(prov.tracks) {
+		t.Errorf("expected a badge on every queue row, got:\n%s", view)
+	}
+}
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// quickOpenDelay is how long typing has to pause before the library is
+// searched, so a fast typist sends one query rather than one per key.
+const quickOpenDelay = 150 * time.Millisecond
+
+// quickOpenRows is how many matches the quick-open box lists.
+const quickOpenRows = 12
+
+// quickOpenItem is one match in the quick-open box: an artist, album,
+// playlist or palette command.
+type quickOpenItem struct {
+	kind     string
+	label    string
+	matched  []int
+	artist   provider.Artist
+	album    provider.Album
+	playlist provider.Playlist
+	command  *Command
+}
+
+// quickOpenTickMsg fires once typing pauses on query.
+type quickOpenTickMsg struct {
+	query string
+}
+
+// quickOpenResultsMsg carries the library matches for a quick-open query.
+type quickOpenResultsMsg struct {
+	query   string
+	results provider.SearchResults
+	err     error
+}
+
+// openQuickOpen shows the quick-open box, loading the playlists to match
+// against when the provider has some and none are loaded yet.
+func (m Model) openQuickOpen() (Model, tea.Cmd) {
+	m.showQuickOpen = true
+	m.quickOpenInput = ""
+	m.quickOpenResults = provider.SearchResults{}
+	m.quickOpenItems = nil
+	m.quickOpenSel = 0
+	if m.provider.Capabilities()[provider.CapPlaylists] && len(m.playlists) == 0 {
+		return m, m.loadPlaylistsCmd("")
+	}
+	return m, nil
+}
+
+// handleQuickOpenKey edits the query, moves through the matches and opens
+// the selected one.
+func (m Model) handleQuickOpenKey(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.showQuickOpen = false
+		return m, nil
+	case tea.KeyEnter:
+		if len(m.quickOpenItems) == 0 {
+			return m, nil
+		}
+		m.showQuickOpen = false
+		return m.openQuickOpenItem(m.quickOpenItems[clamp(m.quickOpenSel, 0, len(m.quickOpenItems)-1)])
+	case tea.KeyUp, tea.KeyCtrlP:
+		if m.quickOpenSel > 0 {
+			m.quickOpenSel--
+		}
+		return m, nil
+	case tea.KeyDown, tea.KeyCtrlN:
+		if m.quickOpenSel < len(m.quickOpenItems)-1 {
+			m.quickOpenSel++
+		}
+		return m, nil
+	case tea.KeyBackspace:
+		r := []rune(m.quickOpenInput)
+		if len(r) == 0 {
+			return m, nil
+		}
+		m.quickOpenInput = string(r[:len(r)-1])
+	case tea.KeySpace:
+		m.quickOpenInput += " "
+	case tea.KeyRunes:
+		m.quickOpenInput += string(msg.Runes)
+	default:
+		return m, nil
+	}
+	m.quickOpenItems = m.quickOpenMatches()
+	m.quickOpenSel = 0
+	query := strings.TrimSpace(m.quickOpenInput)
+	if query == "" {
+		return m, nil
+	}
+	return m, tea.Tick(quickOpenDelay, func(time.Time) tea.Msg {
+		return quickOpenTickMsg{query: query}
+	})
+}
+
+// handleQuickOpenTick searches the library once typing has paused.
+func (m Model) handleQuickOpenTick(msg quickOpenTickMsg) (Model, tea.Cmd) {
+	if !m.showQuickOpen || msg.query != strings.TrimSpace(m.quickOpenInput) {
+		return m, nil
+	}
+	return m, m.quickOpenSearchCmd(msg.query)
+}
+
+// quickOpenSearchCmd asks the provider for artists and albums matching
+// query.
+func (m Model) quickOpenSearchCmd(query string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		res, err := m.provider.Search(ctx, query, provider.ListReq{PageSize: m.cfg.UI.PageSize})
+		return quickOpenResultsMsg{query: query, results: res, err: err}
+	}
+}
+
+// handleQuickOpenResults ranks the library matches in with the playlists
+// and commands, unless the query has changed since.
+func (m Model) handleQuickOpenResults(msg quickOpenResultsMsg) (Model, tea.Cmd) {
+	if !m.showQuickOpen || msg.query != strings.TrimSpace(m.quickOpenInput) {
+		return m, nil
+	}
+	if msg.err != nil {
+		m.logger.Warn("quick open search failed", slog.String("query", msg.query), slog.Any("err", msg.err))
+		if provider.IsRateLimited(msg.err) || provider.IsTemporary(msg.err) {
+			return m.setError(msg.err)
+		}
+		return m, nil
+	}
+	m.quickOpenResults = msg.results
+	m.quickOpenItems = m.quickOpenMatches()
+	m.quickOpenSel = 0
+	return m, nil
+}
+
+// quickOpenCandidates lists everything the query is matched against: the
+// artists and albums the last search found, the playlists and the palette
+// commands.
+func (m Model) quickOpenCandidates() []quickOpenItem {
+	var out []quickOpenItem
+	for _, a := range m.quickOpenResults.Artists.Items {
+		out = append(out, quickOpenItem{kind: "Artist", label: a.Name, artist: a})
+	}
+	for _, a := range m.quickOpenResults.Albums.Items {
+		out = append(out, quickOpenItem{kind: "Album", label: a.Title + " — " + a.ArtistName, album: a})
+	}
+	seen := make(map[string]bool)
+	for _, p := range append(m.quickOpenResults.Playlists.Items, m.playlists...) {
+		if !seen[p.ID] {
+			seen[p.ID] = true
+			out = append(out, quickOpenItem{kind: "Playlist", label: p.Name, playlist: p})
+		}
+	}
+	for _, c := range m.commandRegistry.Commands() {
+		out = append(out, quickOpenItem{kind: "Command", label: c.Name, command: &c})
+	}
+	return out
+}
+
+// quickOpenMatches fuzzy-matches the query against every candidate, best
+// match first.
+func (m Model) quickOpenMatches() []quickOpenItem {
+	query := strings.TrimSpace(m.quickOpenInput)
+	if query == "" {
+		return nil
+	}
+	candidates := m.quickOpenCandidates()
+	labels := make([]string, len(candidates))
+	for i, c := range candidates {
+		labels[i] = c.label
+	}
+	matches := fuzzy.Find(query, labels)
+	out := make([]quickOpenItem, 0, min(len(matches), quickOpenRows))
+	for _, match := range matches[:min(len(matches), quickOpenRows)] {
+		item := candidates[match.Index]
+		item.matched = match.MatchedIndexes
+		out = append(out, item)
+	}
+	return out
+}
+
+// openQuickOpenItem jumps to an artist's albums, an album's tracks or a
+// playlist in its list, or runs a command.
+func (m Model) openQuickOpenItem(item quickOpenItem) (Model, tea.Cmd) {
+	m.logger.Debug("quick open", slog.String("kind", item.kind), slog.String("label", item.label))
+	switch {
+	case item.command != nil:
+		if item.command.RunArg != nil {
+			m.showPalette = true
+			m.paletteState.PromptArg(item.command)
+			return m, nil
+		}
+		return item.command.Handler(&m)
+	case item.kind == "Artist":
+		return m.handleFindArtist(findArtistMsg{query: item.label, artist: item.artist, found: true})
+	case item.kind == "Album":
+		m = m.pushHistory()
+		m.screen = screenLibrary
+		m.focusedPane = paneContent
+		m.currentAlbumID = item.album.ID
+		m.currentArtistID = item.album.ArtistID
+		m.status = m.msgs.T("status.loading", item.album.Title)
+		return m, m.loadTracksCmd(item.album.ArtistID, item.album.ID, "")
+	case item.kind == "Playlist":
+		m.screen = screenPlaylists
+		m.focusedPane = paneContent
+		m.selection = 0
+		for i, p := range m.playlists {
+			if p.ID == item.playlist.ID {
+				m.selection = i
+			}
+		}
+	}
+	return m, nil
+}
+
+// renderQuickOpen renders the quick-open box.
+func (m Model) renderQuickOpen() string {
+	input := lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).Padding(0, 1).Width(50).Render(m.quickOpenInput + "│")
+
+	var lines []string
+	switch {
+	case strings.TrimSpace(m.quickOpenInput) == "":
+		lines = []string{m.theme.Dim.Render("  Type to find an artist, album, playlist or command")}
+	case len(m.quickOpenItems) == 0:
+		lines = []string{m.theme.Dim.Render("  No matches")}
+	default:
+		for i, item := range m.quickOpenItems {
+			prefix := "   "
+			style := m.theme.Text
+			if i == m.quickOpenSel {
+				prefix, style = m.selectedRow(" ▶ ")
+			}
+			kind := m.theme.Dim.Render(fmt.Sprintf("%-9s", item.kind))
+			lines = append(lines, style.Render(prefix)+kind+style.Render(highlightMatches(item.label, item.matched, m.theme.Accent)))
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Quick Open ═══  "),
+		"",
+		input,
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.theme.Dim.Render("[Enter]Open  [↑/↓]Select  [Esc]Close"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
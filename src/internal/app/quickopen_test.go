@@ -0,0 +1,83 @@
+package app
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestQuickOpen(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.playlists = []provider.Playlist{{ID: "pl1", Name: "Road Trip"}, {ID: "pl2", Name: "Abbey Favourites"}}
+	typeText := func(m Model, s string) (Model, tea.Cmd) {
+		var cmd tea.Cmd
+		for _, r := range s {
+			m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		}
+		return m, cmd
+	}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlK})
+	if !m.showQuickOpen || !strings.Contains(m.View(), "Quick Open") {
+		t.Fatal("expected ctrl+k to open the quick-open box")
+	}
+
+	// Letters are typed, not taken as bindings; playlists match at once
+	m, cmd := typeText(m, "abbey")
+	if m.quickOpenInput != "abbey" || len(m.quickOpenItems) != 1 || m.quickOpenItems[0].kind != "Playlist" {
+		t.Fatalf("expected only the playlist before the search returns, got %q %+v", m.quickOpenInput, m.quickOpenItems)
+	}
+
+	// A stale tick is dropped; the current one searches the library
+	if _, stale := m.handleQuickOpenTick(quickOpenTickMsg{query: "abb"}); stale != nil {
+		t.Error("expected a tick for an old query to be ignored")
+	}
+	m, cmd = updateModel(m, cmd())
+	if cmd == nil {
+		t.Fatal("expected the paused query to be searched")
+	}
+	m, _ = updateModel(m, cmd())
+	var kinds []string
+	for _, item := range m.quickOpenItems {
+		kinds = append(kinds, item.kind+":"+item.label)
+	}
+	if len(kinds) != 2 || !slices.Contains(kinds, "Album:Abbey Road — The Beatles") {
+		t.Fatalf("expected the album and playlist, got %v", kinds)
+	}
+
+	// Enter on an album opens its tracks
+	for m.quickOpenItems[m.quickOpenSel].kind != "Album" {
+		m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showQuickOpen || m.screen != screenLibrary || m.currentAlbumID != "10" || cmd == nil {
+		t.Fatalf("expected the album's tracks to load, got screen %v album %q", m.screen, m.currentAlbumID)
+	}
+
+	// A playlist is selected in the Playlists list
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlK})
+	m, _ = typeText(m, "favourites")
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.screen != screenPlaylists || m.selection != 1 {
+		t.Fatalf("expected the Abbey Favourites playlist selected, got screen %v selection %d", m.screen, m.selection)
+	}
+
+	// Commands run as from the palette
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlK})
+	m, _ = typeText(m, "go to queue")
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.screen != screenQueue {
+		t.Errorf("expected the Go to Queue command to run, got screen %v", m.screen)
+	}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlK})
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showQuickOpen {
+		t.Error("expected esc to close the box")
+	}
+}
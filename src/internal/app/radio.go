@@ -0,0 +1,238 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"math/rand"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// Radio queues radioBatch tracks at a time, topping up once fewer than
+// radioLowWater are left after the playing one.
+const (
+	radioBatch    = 10
+	radioLowWater = 3
+)
+
+// radioArtists is how many of the station's artists a batch draws from, and
+// radioPerArtist how many tracks it takes from any one of them while others
+// have tracks to give.
+const (
+	radioArtists   = 3
+	radioPerArtist = 3
+)
+
+// radioStation is a never-ending queue seeded by a track or an artist. It
+// is replaced whole rather than changed, so commands already running can
+// keep reading it.
+type radioStation struct {
+	name    string            // what the station was started from
+	genre   string            // the seed's genre, or ""
+	artists []provider.Artist // the seed artist, then similar artists in the library
+	seen    map[string]bool   // tracks the station has queued
+}
+
+// radioMsg carries a batch of tracks for a station: its first when start is
+// set, with the station itself and the seed track, if any.
+type radioMsg struct {
+	station *radioStation
+	seed    provider.Track
+	tracks  []provider.Track
+	start   bool
+	reset   bool // every track was heard; the batch starts over
+	err     error
+}
+
+// radioTarget returns what a station would start from: the selected track,
+// else the selected artist, else the playing track.
+func (m Model) radioTarget() (provider.Track, provider.Artist, bool) {
+	if t, ok := m.selectedTrack(); ok {
+		return t, provider.Artist{ID: t.ArtistID, Name: t.ArtistName}, true
+	}
+	if a, ok := m.artistTarget(); ok {
+		return provider.Track{}, a, true
+	}
+	if t := m.nowPlaying; t.ID != "" {
+		return t, provider.Artist{ID: t.ArtistID, Name: t.ArtistName}, true
+	}
+	return provider.Track{}, provider.Artist{}, false
+}
+
+// startRadio starts a station from the selected track or artist.
+func (m Model) startRadio() (Model, tea.Cmd) {
+	seed, artist, ok := m.radioTarget()
+	if !ok {
+		m.status = m.msgs.T("radio.select")
+		return m, nil
+	}
+	name := artist.Name
+	if seed.ID != "" {
+		name = seed.Title + " — " + seed.ArtistName
+	}
+	m.logger.Debug("starting radio", slog.String("seed", name))
+	m.status = m.msgs.T("radio.tuning", name)
+	return m, m.startRadioCmd(name, seed, artist)
+}
+
+// startRadioCmd builds a station from the seed's artist, the artists
+// similar to it and its genre, and picks its first batch.
+func (m Model) startRadioCmd(name string, seed provider.Track, artist provider.Artist) tea.Cmd {
+	prov, src, pageSize := m.provider, m.similarSource, m.cfg.UI.PageSize
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		st := &radioStation{name: name, genre: seed.Genre, artists: []provider.Artist{artist}, seen: map[string]bool{}}
+		if seed.ID != "" {
+			st.seen[seed.ID] = true
+		}
+		// Without similar artists the station keeps to the artist and genre
+		similar, err := findSimilarArtists(ctx, prov, src, artist, pageSize)
+		if err != nil {
+			m.logger.Debug("radio: no similar artists", slog.String("artist", artist.Name), slog.Any("err", err))
+		}
+		for _, s := range similar {
+			if s.inLibrary() && s.Artist.ID != artist.ID && !m.hidden.artist(s.Artist) {
+				st.artists = append(st.artists, s.Artist)
+			}
+		}
+		if st.genre == "" && artist.ID != "" {
+			page, err := prov.ListTracks(ctx, "", artist.ID, "", provider.ListReq{PageSize: pageSize})
+			if err != nil {
+				return radioMsg{station: st, start: true, err: err}
+			}
+			for _, t := range page.Items {
+				if t.Genre != "" {
+					st.genre = t.Genre
+					break
+				}
+			}
+		}
+		tracks, reset, err := m.radioTracks(ctx, st)
+		return radioMsg{station: st, seed: seed, tracks: tracks, start: true, reset: reset, err: err}
+	}
+}
+
+// radioTracks picks a batch for a station: tracks by a few of its artists
+// and of its genre that it hasn't queued yet, no more than radioPerArtist
+// by any one artist while there are others. Once everything has been heard
+// it starts over, and reset is set.
+func (m Model) radioTracks(ctx context.Context, st *radioStation) (tracks []provider.Track, reset bool, err error) {
+	var pool []provider.Track
+	for _, i := range rand.Perm(len(st.artists))[:min(radioArtists, len(st.artists))] {
+		page, err := m.provider.ListTracks(ctx, "", st.artists[i].ID, "", provider.ListReq{PageSize: m.randomPageSize()})
+		if err != nil {
+			return nil, false, err
+		}
+		pool = append(pool, page.Items...)
+	}
+	if st.genre != "" {
+		genre, err := provider.RandomTracks(ctx, m.provider, radioBatch, provider.RandomFilter{Genre: st.genre})
+		if err != nil {
+			return nil, false, err
+		}
+		pool = append(pool, genre...)
+	}
+	pool = visible(pool, m.hidden.track)
+	fresh := visible(pool, func(t provider.Track) bool { return st.seen[t.ID] })
+	if len(fresh) == 0 {
+		fresh, reset = pool, true
+	}
+	rand.Shuffle(len(fresh), func(i, j int) { fresh[i], fresh[j] = fresh[j], fresh[i] })
+
+	// Spread the batch over the artists, then fill it up with what's left
+	picked := map[string]bool{}
+	perArtist := map[string]int{}
+	for _, capped := range []bool{true, false} {
+		for _, t := range fresh {
+			if len(tracks) == radioBatch {
+				return tracks, reset, nil
+			}
+			if picked[t.ID] || capped && perArtist[t.ArtistName] >= radioPerArtist {
+				continue
+			}
+			picked[t.ID] = true
+			perArtist[t.ArtistName]++
+			tracks = append(tracks, t)
+		}
+	}
+	return tracks, reset, nil
+}
+
+// refillRadio tops up the queue from the station once it runs low.
+func (m Model) refillRadio() (Model, tea.Cmd) {
+	if m.radio == nil || m.radioLoading || m.queue.Len()-m.queue.CurrentIndex()-1 >= radioLowWater {
+		return m, nil
+	}
+	m.radioLoading = true
+	st := m.radio
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		tracks, reset, err := m.radioTracks(ctx, st)
+		return radioMsg{station: st, tracks: tracks, reset: reset, err: err}
+	}
+}
+
+// handleRadio queues a station's batch. The first replaces the queue and
+// starts playing; later ones are added to the end, unless the station has
+// been stopped or replaced since.
+func (m Model) handleRadio(msg radioMsg) (Model, tea.Cmd) {
+	if !msg.start && msg.station != m.radio {
+		return m, nil
+	}
+	m.radioLoading = false
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("radio: %w", msg.err))
+	}
+	tracks := msg.tracks
+	if msg.start && msg.seed.ID != "" {
+		tracks = append([]provider.Track{msg.seed}, tracks...)
+	}
+	if len(tracks) == 0 {
+		m.status = m.msgs.T("radio.empty", msg.station.name)
+		return m, nil
+	}
+
+	st := *msg.station
+	st.seen = maps.Clone(st.seen)
+	if msg.reset {
+		clear(st.seen)
+	}
+	for _, t := range tracks {
+		st.seen[t.ID] = true
+	}
+	m.radio = &st
+
+	if !msg.start {
+		added := m.queue.Add(tracks...)
+		m.logger.Debug("radio refilled", slog.String("station", st.name), slog.Int("added", added))
+		m.queueFull(added, len(tracks))
+		return m, m.saveQueueCmd()
+	}
+	m.queue.Clear()
+	added := m.queue.Add(tracks...)
+	if m.queueFull(added, len(tracks)) && added == 0 {
+		m.radio = nil
+		return m, nil
+	}
+	m.status = m.msgs.T("radio.station", st.name)
+	m.screen = screenNowPlaying
+	m.focusedPane = paneContent
+	return m, tea.Batch(m.saveQueueCmd(), m.playQueueTrackCmd(0))
+}
+
+// stopRadio stops topping up the queue; what's queued stays.
+func (m Model) stopRadio() (Model, tea.Cmd) {
+	if m.radio == nil {
+		m.status = m.msgs.T("radio.off")
+		return m, nil
+	}
+	m.status = m.msgs.T("radio.stopped")
+	m.radio, m.radioLoading = nil, false
+	return m, nil
+}
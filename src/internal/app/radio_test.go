@@ -0,0 +1,54 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestRadio(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.provider = prov
+	m.queue.Add(prov.tracks[2])
+	m.hidden = newHiddenSet([]queue.HiddenItem{{Kind: queue.HiddenTrack, ID: "102"}})
+	m.screen = screenLibrary
+	m.tracks = prov.tracks
+	m.selection = 0
+
+	m, cmd := m.startRadio()
+	if cmd == nil || m.status != "Tuning in to Come Together — The Beatles..." {
+		t.Fatalf("expected the station to start from the selected track, got %q", m.status)
+	}
+	m, cmd = updateModel(m, cmd())
+	// The seed plays first; the hidden track never comes up
+	items := m.queue.Items()
+	if len(items) != 2 || items[0].ID != "100" || items[1].ID != "101" || cmd == nil {
+		t.Fatalf("expected the seed and the other visible track queued, got %v", items)
+	}
+	if m.radio == nil || m.status != "Radio: Come Together — The Beatles" || m.screen != screenNowPlaying {
+		t.Errorf("expected the radio on and Now Playing shown, got %q", m.status)
+	}
+
+	// Running low tops the queue up, starting over once all was heard
+	m, cmd = m.refillRadio()
+	if cmd == nil || !m.radioLoading {
+		t.Fatal("expected a refill with one track left")
+	}
+	if m2, again := m.refillRadio(); again != nil || m2.radio != m.radio {
+		t.Error("expected one refill at a time")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.queue.Len() != 4 || m.radioLoading {
+		t.Errorf("expected two more tracks queued, got %v", m.queue.Items())
+	}
+
+	// A refill landing after the radio stopped is dropped
+	m.queue.SetCurrent(3)
+	m, cmd = m.refillRadio()
+	m, _ = m.stopRadio()
+	m, _ = updateModel(m, cmd())
+	if m.radio != nil || m.queue.Len() != 4 || m.status != "Radio stopped" {
+		t.Errorf("expected the stale refill dropped, got %d tracks, status %q", m.queue.Len(), m.status)
+	}
+}
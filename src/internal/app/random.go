@@ -0,0 +1,168 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/control"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// randomPickTries is how many picks are made before giving up on finding
+// an album or artist that isn't hidden.
+const randomPickTries = 10
+
+// errAllHidden is returned when every random pick was hidden.
+var errAllHidden = errors.New("no random pick that isn't hidden")
+
+// randomPlayCmd queues a page of random tracks, sampled by the provider and
+// narrowed by --genre and --years.
+func (m Model) randomPlayCmd(opts StartupOptions, call *control.Call) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		msg := randomPlayMsg{opts: opts, call: call}
+		filter := opts.RandomFilter
+		n := m.randomCount()
+		// Ask for a few extra to make up for hidden tracks
+		tracks, err := provider.RandomTracks(ctx, m.provider, n+n/4, filter)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		tracks = visible(tracks, m.hidden.track)
+		if len(tracks) == 0 {
+			msg.err = errors.New("no tracks found")
+			return msg
+		}
+		msg.tracks = tracks[:min(n, len(tracks))]
+		if !filter.IsZero() {
+			msg.what = m.msgs.T("random.what.filtered", filter)
+		}
+		return msg
+	}
+}
+
+// randomAlbumCmd queues every track of a random album.
+func (m Model) randomAlbumCmd(opts StartupOptions, call *control.Call) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		msg := randomPlayMsg{opts: opts, call: call}
+		album, err := m.pickRandomAlbum(ctx)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		tracks, err := m.contextTracks(ctx, queue.ListeningContext{Kind: queue.ContextAlbum, ID: album.ID})
+		msg.tracks, msg.err = visible(tracks, m.hidden.track), err
+		msg.what = m.msgs.T("random.what.album", album.Title)
+		return msg
+	}
+}
+
+// randomArtistCmd queues every album of a random artist, album by album.
+func (m Model) randomArtistCmd(opts StartupOptions, call *control.Call) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		msg := randomPlayMsg{opts: opts, call: call}
+		artist, err := m.pickRandomArtist(ctx)
+		if err != nil {
+			msg.err = err
+			return msg
+		}
+		msg.tracks, msg.err = m.artistTracks(ctx, artist.ID)
+		msg.what = m.msgs.T("random.what.artist", artist.Name)
+		return msg
+	}
+}
+
+// artistTracks loads the tracks of each of an artist's visible albums, in
+// the Library's album order.
+func (m Model) artistTracks(ctx context.Context, artistID string) ([]provider.Track, error) {
+	var tracks []provider.Track
+	cursor := ""
+	for {
+		page, err := m.provider.ListAlbums(ctx, artistID, provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor, Sort: m.cfg.UI.Sort.Albums})
+		if err != nil {
+			return nil, err
+		}
+		for _, album := range visible(page.Items, m.hidden.album) {
+			albumTracks, err := m.contextTracks(ctx, queue.ListeningContext{Kind: queue.ContextAlbum, ID: album.ID})
+			if err != nil {
+				return nil, err
+			}
+			tracks = append(tracks, visible(albumTracks, m.hidden.track)...)
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return tracks, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// pickRandomAlbum picks a random album that isn't hidden, by the
+// provider's own index when it has one and otherwise from a large page of
+// the album list.
+func (m Model) pickRandomAlbum(ctx context.Context) (provider.Album, error) {
+	if rp, ok := provider.As[provider.RandomPicker](m.provider); ok {
+		for range randomPickTries {
+			album, err := rp.RandomAlbum(ctx)
+			if err != nil || !m.hidden.album(album) {
+				return album, err
+			}
+		}
+		return provider.Album{}, errAllHidden
+	}
+	page, err := m.provider.ListAlbums(ctx, "", provider.ListReq{PageSize: m.randomPageSize()})
+	if err != nil {
+		return provider.Album{}, err
+	}
+	albums := visible(page.Items, m.hidden.album)
+	if len(albums) == 0 {
+		return provider.Album{}, provider.ErrNotFound
+	}
+	return albums[rand.Intn(len(albums))], nil
+}
+
+// pickRandomArtist picks a random artist that isn't hidden, the same way
+// as pickRandomAlbum.
+func (m Model) pickRandomArtist(ctx context.Context) (provider.Artist, error) {
+	if rp, ok := provider.As[provider.RandomPicker](m.provider); ok {
+		for range randomPickTries {
+			artist, err := rp.RandomArtist(ctx)
+			if err != nil || !m.hidden.artist(artist) {
+				return artist, err
+			}
+		}
+		return provider.Artist{}, errAllHidden
+	}
+	page, err := m.provider.ListArtists(ctx, provider.ListReq{PageSize: m.randomPageSize()})
+	if err != nil {
+		return provider.Artist{}, err
+	}
+	artists := visible(page.Items, m.hidden.artist)
+	if len(artists) == 0 {
+		return provider.Artist{}, provider.ErrNotFound
+	}
+	return artists[rand.Intn(len(artists))], nil
+}
+
+// randomCount is how many tracks random play queues: a page of them.
+func (m Model) randomCount() int {
+	if m.cfg.UI.PageSize <= 0 {
+		return 50
+	}
+	return m.cfg.UI.PageSize
+}
+
+// randomPageSize is how much of a list random play draws from when the
+// provider can't pick at random itself.
+func (m Model) randomPageSize() int {
+	return m.randomCount() * 10
+}
@@ -0,0 +1,111 @@
+package app
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// pickingProvider picks its albums and artists in turn, as a provider with
+// a RandomPicker index would at random.
+type pickingProvider struct {
+	*testProvider
+	picks int
+}
+
+func (p *pickingProvider) RandomAlbum(context.Context) (provider.Album, error) {
+	p.picks++
+	return p.albums[p.picks%len(p.albums)], nil
+}
+
+func (p *pickingProvider) RandomArtist(context.Context) (provider.Artist, error) {
+	p.picks++
+	return p.artists[p.picks%len(p.artists)], nil
+}
+
+func TestRandomAlbum(t *testing.T) {
+	prov := &pickingProvider{testProvider: newTestProvider()}
+	m := initializeModel(createTestModel(t), prov.testProvider)
+	m.provider = prov
+	// Let It Be comes up first, and is hidden
+	m.hidden = newHiddenSet([]queue.HiddenItem{{Kind: queue.HiddenAlbum, ID: "11"}})
+
+	msg := m.randomAlbumCmd(StartupOptions{AutoPlay: true}, nil)().(randomPlayMsg)
+	if msg.err != nil || prov.picks != 2 || msg.what != "tracks from Abbey Road" {
+		t.Fatalf("expected a second pick past the hidden album, got %d picks, %q %v", prov.picks, msg.what, msg.err)
+	}
+	m, cmd := updateModel(m, msg)
+	if m.queue.Len() != 3 || m.status != "Added 3 tracks from Abbey Road to queue" || m.screen != screenNowPlaying || cmd == nil {
+		t.Errorf("expected the album queued and playing, got %d tracks, status %q", m.queue.Len(), m.status)
+	}
+}
+
+func TestRandomArtistFallback(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	// Without a RandomPicker the pick comes from the artist list; only
+	// The Beatles are left to pick
+	var hidden []queue.HiddenItem
+	for _, a := range prov.artists {
+		if a.Name != "The Beatles" {
+			hidden = append(hidden, queue.HiddenItem{Kind: queue.HiddenArtist, ID: a.ID})
+		}
+	}
+	m.hidden = newHiddenSet(hidden)
+
+	msg := m.randomArtistCmd(StartupOptions{}, nil)().(randomPlayMsg)
+	// The test provider lists the same two albums for every artist
+	if msg.err != nil || msg.what != "tracks by The Beatles" || len(msg.tracks) != 6 {
+		t.Errorf("expected both albums' tracks by The Beatles, got %q, %d tracks, %v", msg.what, len(msg.tracks), msg.err)
+	}
+}
+
+// samplingProvider samples random tracks itself, always the same ones.
+type samplingProvider struct {
+	*testProvider
+	filter provider.RandomFilter
+}
+
+func (p *samplingProvider) ListRandomTracks(_ context.Context, n int, filter provider.RandomFilter) ([]provider.Track, error) {
+	p.filter = filter
+	return p.tracks[:min(n, len(p.tracks))], nil
+}
+
+func TestRandomTracks(t *testing.T) {
+	prov := &samplingProvider{testProvider: newTestProvider()}
+	m := initializeModel(createTestModel(t), prov.testProvider)
+	m.provider = prov
+	m.hidden = newHiddenSet([]queue.HiddenItem{{Kind: queue.HiddenTrack, ID: "101"}})
+
+	filter := provider.ParseRandomFilter("1960s rock")
+	msg := m.randomPlayCmd(StartupOptions{RandomFilter: filter}, nil)().(randomPlayMsg)
+	if prov.filter != (provider.RandomFilter{Genre: "rock", Years: provider.Decade(1960)}) {
+		t.Errorf("expected the filter passed to the provider, got %+v", prov.filter)
+	}
+	if msg.err != nil || len(msg.tracks) != 2 || msg.what != "random tracks (rock, 1960s)" {
+		t.Errorf("expected the sampled tracks without the hidden one, got %q, %+v, %v", msg.what, msg.tracks, msg.err)
+	}
+}
+
+func TestRandomTracksFallback(t *testing.T) {
+	prov := newTestProvider()
+	prov.tracks[0].Genre, prov.tracks[1].Genre = "Rock", "Pop"
+	m := initializeModel(createTestModel(t), prov)
+	m.provider = prov
+
+	// Without a RandomTrackLister a page of the track list is filtered and
+	// shuffled, leaving the provider's own list as it was
+	msg := m.randomPlayCmd(StartupOptions{RandomFilter: provider.RandomFilter{Genre: "rock"}}, nil)().(randomPlayMsg)
+	if msg.err != nil || len(msg.tracks) != 1 || msg.tracks[0].ID != "100" {
+		t.Errorf("expected only the rock track, got %+v, %v", msg.tracks, msg.err)
+	}
+	msg = m.randomPlayCmd(StartupOptions{}, nil)().(randomPlayMsg)
+	if msg.err != nil || len(msg.tracks) != 3 || msg.what != "" {
+		t.Errorf("expected every track, got %q, %+v, %v", msg.what, msg.tracks, msg.err)
+	}
+	if prov.tracks[0].ID != "100" || prov.tracks[2].ID != "102" {
+		t.Errorf("expected the provider's tracks left in order, got %+v", prov.tracks)
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/hooks"
+	"github.com/tunez/tunez/internal/platform"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// artworkSavedMsg reports a Save Artwork.
+type artworkSavedMsg struct {
+	track provider.Track
+	path  string
+	err   error
+}
+
+// artworkExtensions name image files by their type.
+var artworkExtensions = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+	"image/bmp":  ".bmp",
+}
+
+// artworkExportDir is where Save Artwork writes: [artwork] export_dir, or
+// ~/Pictures.
+func (m Model) artworkExportDir() string {
+	if dir := m.cfg.Artwork.ExportDir; dir != "" {
+		return expandHome(dir)
+	}
+	return expandHome("~/Pictures")
+}
+
+// saveArtwork writes the playing track's artwork at full size to the
+// export folder, as "Artist - Album.jpg".
+func (m Model) saveArtwork() (Model, tea.Cmd) {
+	t := m.nowPlaying
+	if t.ID == "" {
+		m.status = m.msgs.T("status.nothing_playing")
+		return m, nil
+	}
+	if t.ArtworkRef == "" {
+		m.status = m.msgs.T("artwork.none", t.Title)
+		return m, nil
+	}
+	m.status = m.msgs.T("artwork.saving")
+	return m, m.saveArtworkCmd(t, m.artworkExportDir())
+}
+
+// saveArtworkCmd fetches a track's artwork from the provider as it comes,
+// not the resized copy drawn on screen, and writes it to dir.
+func (m Model) saveArtworkCmd(t provider.Track, dir string) tea.Cmd {
+	prov := m.provider
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+		// A size of 0 asks for the original
+		art, err := prov.GetArtwork(ctx, t.ArtworkRef, 0)
+		if err != nil {
+			return artworkSavedMsg{track: t, err: err}
+		}
+		if len(art.Data) == 0 {
+			return artworkSavedMsg{track: t, err: errors.New("empty image")}
+		}
+		mimeType := art.MimeType
+		if _, ok := artworkExtensions[mimeType]; !ok {
+			mimeType = http.DetectContentType(art.Data)
+		}
+		ext, ok := artworkExtensions[mimeType]
+		if !ok {
+			return artworkSavedMsg{track: t, err: fmt.Errorf("unknown image type %s", mimeType)}
+		}
+		name := platform.FileName(t.ArtistName + " - " + t.AlbumTitle)
+		if t.AlbumTitle == "" {
+			name = platform.FileName(t.ArtistName + " - " + t.Title)
+		}
+		if name == "-" || name == "" {
+			name = "artwork"
+		}
+		path := filepath.Join(dir, name+ext)
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return artworkSavedMsg{track: t, err: err}
+		}
+		return artworkSavedMsg{track: t, path: path, err: os.WriteFile(path, art.Data, 0o644)}
+	}
+}
+
+// handleArtworkSaved reports the saved image and runs the artwork_saved
+// hook with its path.
+func (m Model) handleArtworkSaved(msg artworkSavedMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(fmt.Errorf("save artwork: %w", msg.err))
+	}
+	m.logger.Debug("saved artwork", slog.String("track_id", msg.track.ID), slog.String("path", msg.path))
+	m.hooks.Fire(hooks.EventArtworkSaved, msg.track, "TUNEZ_ARTWORK_PATH="+msg.path)
+	m.status = m.msgs.T("artwork.saved", msg.path)
+	return m, nil
+}
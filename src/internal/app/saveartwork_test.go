@@ -0,0 +1,46 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/hooks"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestSaveArtwork(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "hook")
+	cover := []byte("\x89PNG\r\n\x1a\nfull-size cover")
+	m := createTestModel(t)
+	m.provider = coverProvider{newTestProvider(), cover}
+	m.cfg.Artwork.ExportDir = filepath.Join(dir, "art")
+	m.hooks = hooks.New(config.HooksConfig{ArtworkSaved: `printf '%s' "$TUNEZ_ARTWORK_PATH" > ` + out}, nil)
+
+	if m, cmd := m.saveArtwork(); cmd != nil || m.status != "Nothing playing" {
+		t.Fatalf("expected nothing to save, got %q", m.status)
+	}
+
+	m.nowPlaying = provider.Track{ID: "t1", Title: "Come Together", AlbumTitle: "Abbey Road", ArtistName: "AC/DC", ArtworkRef: "cover"}
+	m, cmd := m.saveArtwork()
+	m, _ = updateModel(m, cmd())
+	want := filepath.Join(dir, "art", "AC_DC - Abbey Road.png")
+	if got, err := os.ReadFile(want); err != nil || string(got) != string(cover) {
+		t.Fatalf("expected the provider's image at %s, got %q %v (status %q)", want, got, err, m.status)
+	}
+	if m.status != "Saved artwork to "+want {
+		t.Errorf("unexpected status %q", m.status)
+	}
+	if err := m.hooks.Wait(t.Context()); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(out); string(got) != want {
+		t.Errorf("expected the hook to get the path, got %q", got)
+	}
+}
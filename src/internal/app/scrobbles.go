@@ -0,0 +1,152 @@
+package app
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/scrobble"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// scrobblesSavedMsg reports the result of persisting edited pending queues.
+type scrobblesSavedMsg struct {
+	err error
+}
+
+// removePendingScrobble deletes the selected pending scrobble so it is
+// never sent, then persists the edited queue.
+func (m Model) removePendingScrobble() (Model, tea.Cmd) {
+	if m.scrobbler == nil {
+		return m, nil
+	}
+	items := m.scrobbler.PendingItems()
+	if len(items) == 0 {
+		return m, nil
+	}
+	item := items[clamp(m.selection, 0, len(items)-1)]
+	if !m.scrobbler.RemovePending(item.ScrobblerID, item.Track) {
+		// Flushed in the meantime
+		m.status = m.msgs.T("scrobbles.sent")
+		return m, nil
+	}
+	m.logger.Debug("removed pending scrobble",
+		slog.String("scrobbler", item.ScrobblerID),
+		slog.String("title", item.Track.Title))
+	m.status = m.msgs.T("scrobbles.removed", item.Track.Title, item.ScrobblerName)
+	if m.selection >= len(items)-1 && m.selection > 0 {
+		m.selection--
+	}
+
+	mgr := m.scrobbler
+	return m, func() tea.Msg {
+		return scrobblesSavedMsg{err: mgr.SavePending()}
+	}
+}
+
+// renderScrobbles renders pending scrobbles (editable) and this session's
+// submission history with per-backend status.
+func (m Model) renderScrobbles(width, height int) string {
+	var b strings.Builder
+	pending := m.scrobbler.PendingItems()
+	history := m.scrobbler.History()
+
+	b.WriteString(m.theme.Title.Render(fmt.Sprintf("Scrobbles  Pending: %d  Sent this session: %d", len(pending), len(history))) + "\n\n")
+
+	maxWidth := width - 10
+	if maxWidth < 10 {
+		maxWidth = 10
+	}
+
+	// Pending queue gets at most half the rows so history stays visible
+	// Header(1) + \n\n(2) + 2 section titles + blank(1) + hints(1) = 7 lines overhead
+	rows := height - 7
+	if rows < 2 {
+		rows = 2
+	}
+	pendingRows := rows / 2
+
+	b.WriteString(m.theme.Accent.Render("Pending") + "\n")
+	if len(pending) == 0 {
+		b.WriteString(m.theme.Dim.Render("  Nothing waiting to be sent") + "\n")
+	} else {
+		start := 0
+		if m.selection >= pendingRows {
+			start = m.selection - pendingRows + 1
+		}
+		end := start + pendingRows
+		if end > len(pending) {
+			end = len(pending)
+		}
+		for i := start; i < end; i++ {
+			p := pending[i]
+			prefix := "    "
+			style := m.theme.Text
+			if i == m.selection {
+				prefix, style = m.selectedRow(" ▣  ")
+			}
+			line := fmt.Sprintf("%s%s — %s  [%s]  %s", prefix, p.Track.Artist, p.Track.Title, p.ScrobblerName, m.scrobbleTime(p.Track.StartedAt))
+			b.WriteString(style.Render(ui.Truncate(line, maxWidth)) + "\n")
+		}
+	}
+
+	b.WriteString(m.theme.Accent.Render("Recent") + "\n")
+	if len(history) == 0 {
+		b.WriteString(m.theme.Dim.Render("  No scrobbles yet this session") + "\n")
+	}
+	historyRows := rows - pendingRows
+	for i, h := range history {
+		if i >= historyRows {
+			break
+		}
+		var results []string
+		for _, r := range h.Results {
+			results = append(results, r.Name+" "+m.scrobbleStatusIcon(r.Status))
+		}
+		line := fmt.Sprintf("    %s  %s — %s  %s", m.scrobbleTime(h.At), h.Track.Artist, h.Track.Title, strings.Join(results, "  "))
+		style := m.theme.Text
+		for _, r := range h.Results {
+			if r.Status == scrobble.StatusFailed {
+				style = m.theme.Error
+				line += "  " + r.Error
+				break
+			}
+		}
+		b.WriteString(style.Render(ui.Truncate(line, maxWidth)) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(m.theme.Dim.Render("[x]Delete pending  [j/k]Select  Ctrl+D shows retry status"))
+	return b.String()
+}
+
+// scrobbleStatusIcon renders a backend status compactly.
+func (m Model) scrobbleStatusIcon(s scrobble.SubmitStatus) string {
+	if m.noEmoji {
+		return "[" + string(s) + "]"
+	}
+	switch s {
+	case scrobble.StatusSent:
+		return "✓"
+	case scrobble.StatusFailed:
+		return "✗"
+	case scrobble.StatusQueued:
+		return "⏳"
+	case scrobble.StatusRemoved:
+		return "⌫"
+	case scrobble.StatusSkipped:
+		return "–"
+	default:
+		return "…"
+	}
+}
+
+// scrobbleTime shows a clock time for today and a date otherwise.
+func (m Model) scrobbleTime(t time.Time) string {
+	if t.IsZero() {
+		return "--:--"
+	}
+	return m.msgs.Stamp(t, time.Now())
+}
@@ -0,0 +1,118 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/scrobble"
+	"github.com/tunez/tunez/internal/scrobble/lastfm"
+	scrobblemelodee "github.com/tunez/tunez/internal/scrobble/melodee"
+)
+
+func TestScrobblesScreen(t *testing.T) {
+	// Pending queues are saved under the user config dir
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.width, m.height = 140, 40
+
+	mgr := scrobble.NewManager()
+	mgr.Register(lastfm.New("lastfm", lastfm.Config{})) // not configured, queues offline
+	m.scrobbler = mgr
+	mgr.Scrobble(context.Background(), scrobble.Track{Title: "Wrong Song", Artist: "Band", StartedAt: time.Now()})
+	mgr.Scrobble(context.Background(), scrobble.Track{Title: "Right Song", Artist: "Band", StartedAt: time.Now()})
+	if err := mgr.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	m.screen = screenScrobbles
+	m.focusedPane = paneContent
+	view := m.View()
+	for _, want := range []string{"Scrobbles", "Pending: 2", "Wrong Song", "Recent"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected view to contain %q", want)
+		}
+	}
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}})
+	if cmd == nil {
+		t.Fatal("expected save command after delete")
+	}
+	if msg, ok := cmd().(scrobblesSavedMsg); !ok || msg.err != nil {
+		t.Errorf("expected pending queue to save, got %+v", msg)
+	}
+	items := mgr.PendingItems()
+	if len(items) != 1 || items[0].Track.Title != "Right Song" {
+		t.Errorf("expected only Right Song to remain pending, got %+v", items)
+	}
+	if !strings.Contains(m.status, "Wrong Song") {
+		t.Errorf("expected status to name removed track, got %q", m.status)
+	}
+}
+
+func TestScrobblesScreenHiddenWithoutScrobbler(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenLyrics
+	if next := m.nextScreen(); next == screenScrobbles {
+		t.Error("expected scrobbles screen to be skipped when scrobbling is off")
+	}
+	m.screen = screenConfig
+	if prev := m.prevScreen(); prev == screenScrobbles {
+		t.Error("expected scrobbles screen to be skipped when scrobbling is off")
+	}
+}
+
+func TestScrobbleCarriesProviderTrackID(t *testing.T) {
+	var mu sync.Mutex
+	var songIDs []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			SongID string `json:"songId"`
+		}
+		json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		songIDs = append(songIDs, body.SongID)
+		mu.Unlock()
+	}))
+	defer srv.Close()
+
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.cfg.Scrobble.Enabled = true
+	mgr := scrobble.NewManager()
+	mgr.Register(scrobblemelodee.New("melodee", scrobblemelodee.Config{
+		BaseURL:  srv.URL,
+		Token:    "tok",
+		Provider: prov.ID(),
+	}))
+	m.scrobbler = mgr
+
+	track := provider.Track{ID: "song-42", Title: "Song", ArtistName: "Band", DurationMs: 60000}
+	m, _ = updateModel(m, playTrackMsg{track: track})
+	if err := mgr.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	pos := 45.0
+	m, _ = updateModel(m, playerMsg{TimePos: &pos})
+	if err := mgr.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(songIDs) != 2 || songIDs[0] != "song-42" || songIDs[1] != "song-42" {
+		t.Errorf("expected now playing and scrobble for song-42, got %v", songIDs)
+	}
+}
@@ -0,0 +1,114 @@
+package app
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// searchKinds are searched separately, so each kind of result shows as
+// soon as it comes in rather than once the slowest has.
+var searchKinds = []string{provider.SearchTracks, provider.SearchAlbums, provider.SearchArtists}
+
+// searchMsg carries one kind of search results for query, or every kind
+// when kind is "".
+type searchMsg struct {
+	query string
+	kind  string
+	res   provider.SearchResults
+	err   error
+	// invalid is set when query didn't parse, such as a half-typed
+	// year:19; err says why and no search was made.
+	invalid bool
+}
+
+// searchCmd searches for q, one kind of result at a time. A query that
+// doesn't parse isn't sent to the provider.
+func (m Model) searchCmd(q string) tea.Cmd {
+	if _, err := provider.ParseQuery(q); err != nil {
+		return func() tea.Msg { return searchMsg{query: q, err: err, invalid: true} }
+	}
+	cmds := make([]tea.Cmd, len(searchKinds))
+	for i, kind := range searchKinds {
+		cmds[i] = func() tea.Msg {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			res, err := m.provider.Search(ctx, q, provider.ListReq{PageSize: m.cfg.UI.PageSize, SearchKind: kind})
+			return searchMsg{query: q, kind: kind, res: res, err: err}
+		}
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleSearch shows search results as they come in. Results for a query
+// typed over since are dropped; the first results for a new query replace
+// the old ones.
+func (m Model) handleSearch(msg searchMsg) (Model, tea.Cmd) {
+	if msg.query != m.searchQ {
+		return m, nil
+	}
+	if msg.query != m.searchFor || m.searchDone == nil || len(m.searchDone) == len(searchKinds) {
+		m.searchResults, m.searchVersions = provider.SearchResults{}, versionList{}
+		m.searchFor = msg.query
+		m.searchDone = map[string]bool{}
+	}
+	res := m.visibleResults(msg.res)
+	res.Tracks.Items = m.searchVersions.add(nil, res.Tracks.Items, m.cfg.UI.Versions)
+	switch msg.kind {
+	case provider.SearchTracks:
+		m.searchResults.Tracks = res.Tracks
+	case provider.SearchAlbums:
+		m.searchResults.Albums = res.Albums
+	case provider.SearchArtists:
+		m.searchResults.Artists = res.Artists
+	default:
+		m.searchResults = res
+		for _, kind := range searchKinds {
+			m.searchDone[kind] = true
+		}
+	}
+	m.searchDone[msg.kind] = true
+	if msg.invalid {
+		m.status = m.msgs.T("search.failed", msg.err.Error())
+		return m, nil
+	}
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	count := len(m.searchResults.Tracks.Items) + len(m.searchResults.Albums.Items) + len(m.searchResults.Artists.Items)
+	if !m.searchComplete() {
+		m.status = m.msgs.T("search.searching", count)
+		return m, nil
+	}
+	m.status = m.msgs.T("search.found", count)
+	return m.continueRestore(screenSearch, false)
+}
+
+// searchComplete reports whether every kind of result is in for the
+// search box's query.
+func (m Model) searchComplete() bool {
+	if m.searchFor != m.searchQ {
+		return false
+	}
+	for _, kind := range searchKinds {
+		if !m.searchDone[kind] {
+			return false
+		}
+	}
+	return true
+}
+
+// searchPending reports whether the results shown under filter are still
+// on their way.
+func (m Model) searchPending(filter searchFilter) bool {
+	kind := provider.SearchTracks
+	switch filter {
+	case filterAlbums:
+		kind = provider.SearchAlbums
+	case filterArtists:
+		kind = provider.SearchArtists
+	}
+	return m.searchQ != "" && (m.searchFor != m.searchQ || !m.searchDone[kind])
+}
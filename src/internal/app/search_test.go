@@ -0,0 +1,70 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestProgressiveSearch(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m.screen = screenSearch
+	m.searchQ = "beat"
+	if cmd := m.searchCmd("beat"); cmd == nil {
+		t.Fatal("expected search commands")
+	}
+	if !strings.Contains(m.renderSearch(80, 20), "Searching...") {
+		t.Error("expected Searching... before any results")
+	}
+
+	// Artists come in first and show while tracks are still on their way
+	m, _ = updateModel(m, searchMsg{query: "beat", kind: provider.SearchArtists, res: provider.SearchResults{
+		Artists: provider.Page[provider.Artist]{Items: prov.artists[:1]},
+	}})
+	if len(m.searchResults.Artists.Items) != 1 || m.status != "Searching... 1 results so far" {
+		t.Errorf("expected the artists shown, got %+v, status %q", m.searchResults.Artists, m.status)
+	}
+	if !m.searchPending(filterTracks) || m.searchPending(filterArtists) {
+		t.Error("expected tracks pending and artists in")
+	}
+
+	// Results for an earlier query are dropped
+	m, _ = updateModel(m, searchMsg{query: "bea", kind: provider.SearchTracks, res: provider.SearchResults{
+		Tracks: provider.Page[provider.Track]{Items: prov.tracks},
+	}})
+	if len(m.searchResults.Tracks.Items) != 0 {
+		t.Errorf("expected stale tracks dropped, got %v", m.searchResults.Tracks.Items)
+	}
+
+	m, _ = updateModel(m, searchMsg{query: "beat", kind: provider.SearchTracks, res: provider.SearchResults{
+		Tracks: provider.Page[provider.Track]{Items: prov.tracks},
+	}})
+	m, _ = updateModel(m, searchMsg{query: "beat", kind: provider.SearchAlbums})
+	if len(m.searchResults.Tracks.Items) != 3 || len(m.searchResults.Artists.Items) != 1 || m.status != "Found 4 results" {
+		t.Errorf("expected every kind in, got %+v, status %q", m.searchResults, m.status)
+	}
+
+	// The first results of a new query replace the old ones
+	m.searchQ = "queen"
+	m, _ = updateModel(m, searchMsg{query: "queen", kind: provider.SearchArtists, res: provider.SearchResults{
+		Artists: provider.Page[provider.Artist]{Items: prov.artists[3:4]},
+	}})
+	if len(m.searchResults.Tracks.Items) != 0 || len(m.searchResults.Artists.Items) != 1 {
+		t.Errorf("expected only the new artists, got %+v", m.searchResults)
+	}
+}
+
+func TestSearchInvalidQuery(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.screen = screenSearch
+	m.searchQ = "year:19"
+	m, _ = updateModel(m, m.searchCmd(m.searchQ)())
+	if !strings.HasPrefix(m.status, "Search: year:19: invalid year") {
+		t.Errorf("expected the parse error as status, got %q", m.status)
+	}
+	if m.errorMsg != "" || m.searchPending(filterTracks) {
+		t.Errorf("expected no error and nothing pending, got %q", m.errorMsg)
+	}
+}
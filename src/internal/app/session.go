@@ -0,0 +1,161 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// sessionScreens are the screens a saved session reopens. Popups and the
+// Config and Scrobbles screens start fresh.
+var sessionScreens = []screen{screenNowPlaying, screenSearch, screenLibrary, screenQueue, screenPlaylists, screenLyrics}
+
+// sessionMsg carries the UI session saved at the last quit.
+type sessionMsg struct {
+	session queue.Session
+	ok      bool
+	err     error
+}
+
+// loadSessionCmd reads the active profile's saved UI session.
+func (m Model) loadSessionCmd() tea.Cmd {
+	store, profileID := m.queueStore, m.cfg.ActiveProfile
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		sess, ok, err := store.Session(ctx, profileID)
+		return sessionMsg{session: sess, ok: ok, err: err}
+	}
+}
+
+// session captures where the UI is: the screen, the Library artist and
+// album being browsed, the search and the selected row.
+func (m Model) session() queue.Session {
+	s := queue.Session{
+		Screen:       screenNames[m.screen],
+		Pane:         paneNames[m.focusedPane],
+		SearchQuery:  m.searchQ,
+		SearchFilter: int(m.searchFilter),
+		Selection:    m.selection,
+		LyricsScroll: m.lyricsScrollOffset,
+		Volume:       m.volume,
+	}
+	if len(m.albums) > 0 || len(m.tracks) > 0 {
+		s.ArtistID = m.currentArtistID
+	}
+	if len(m.tracks) > 0 {
+		s.AlbumID = m.currentAlbumID
+	}
+	return s
+}
+
+// quit saves the UI session, when the queue is persisted, and exits.
+func (m Model) quit() (Model, tea.Cmd) {
+	if m.queueStore == nil || !m.cfg.Queue.Persist || m.screen == screenLoading {
+		return m, tea.Quit
+	}
+	store, profileID, sess, logger := m.queueStore, m.cfg.ActiveProfile, m.session(), m.logger
+	save := func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := store.SaveSession(ctx, profileID, sess); err != nil {
+			logger.Debug("session save failed", slog.Any("err", err))
+		}
+		return nil
+	}
+	return m, tea.Sequence(save, tea.Quit)
+}
+
+// handleSession reopens the saved session once the library has loaded.
+// Startup flags that pick what to play take precedence.
+func (m Model) handleSession(msg sessionMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Debug("session restore failed", slog.Any("err", msg.err))
+		return m, nil
+	}
+	if !msg.ok {
+		return m, nil
+	}
+	var volumeCmd tea.Cmd
+	if m.cfg.Player.RestoreVolume && msg.session.Volume > 0 {
+		m.volume = min(msg.session.Volume, 100)
+		volumeCmd = m.volumeCmd()
+	}
+	if m.startupOpts.RandomPlay || m.startupOpts.searches() {
+		return m, volumeCmd
+	}
+	m.restore = &msg.session
+	if m.screen == screenLoading {
+		return m, volumeCmd
+	}
+	m, cmd := m.restoreSession()
+	return m, tea.Batch(volumeCmd, cmd)
+}
+
+// restoreSession moves to the saved screen and reloads the lists it
+// showed. The saved row is selected once the last of them arrives.
+func (m Model) restoreSession() (Model, tea.Cmd) {
+	s := m.restore
+	scr := screen(slices.Index(screenNames, s.Screen))
+	if !slices.Contains(sessionScreens, scr) {
+		m.restore = nil
+		return m, nil
+	}
+	m.logger.Debug("restoring session", slog.String("screen", s.Screen), slog.String("artist_id", s.ArtistID), slog.String("album_id", s.AlbumID))
+	m.screen = scr
+	if s.Pane == paneNames[paneContent] {
+		m.focusedPane = paneContent
+	}
+	m.searchFilter = searchFilter(clamp(s.SearchFilter, int(filterTracks), int(filterArtists)))
+	m.lyricsScrollOffset = max(s.LyricsScroll, 0)
+
+	var cmds []tea.Cmd
+	if s.SearchQuery != "" {
+		m.searchQ = s.SearchQuery
+		cmds = append(cmds, m.searchCmd(s.SearchQuery))
+	}
+	waiting := false
+	switch {
+	case scr == screenLibrary && s.ArtistID != "":
+		m.currentArtistID = s.ArtistID
+		cmds = append(cmds, m.loadAlbumsCmd(s.ArtistID, ""))
+		waiting = true
+	case scr == screenPlaylists:
+		cmds = append(cmds, m.loadPlaylistsCmd(""))
+		waiting = true
+	case scr == screenSearch && s.SearchQuery != "":
+		waiting = true
+	}
+	if !waiting {
+		m = m.finishRestore()
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// continueRestore takes the next step of a session restore after a list
+// for screen s loaded: the saved album's tracks after its artist's albums,
+// then the saved selection.
+func (m Model) continueRestore(s screen, albums bool) (Model, tea.Cmd) {
+	if m.restore == nil || m.screen != s {
+		return m, nil
+	}
+	if albums && m.restore.AlbumID != "" {
+		m.currentAlbumID = m.restore.AlbumID
+		return m, m.loadTracksCmd(m.currentArtistID, m.restore.AlbumID, "")
+	}
+	return m.finishRestore(), nil
+}
+
+// finishRestore selects the saved row, within the list as loaded.
+func (m Model) finishRestore() Model {
+	m.selection = max(m.restore.Selection, 0)
+	if n := m.currentListLen(); n > 0 {
+		m.selection = min(m.selection, n-1)
+	}
+	m.restore = nil
+	return m
+}
@@ -0,0 +1,98 @@
+package app
+
+import (
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestSessionRestore(t *testing.T) {
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	prov := newTestProvider()
+
+	// Browsing an album's tracks with a search typed
+	m := initializeModel(createTestModel(t), prov)
+	m.queueStore = store
+	m.cfg.Queue.Persist = true
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.searchQ = "beatles"
+	m.searchFilter = filterAlbums
+	m.currentArtistID, m.currentAlbumID = "1", "10"
+	m.albums, m.tracks = prov.albums, prov.tracks
+	m.selection = 2
+	if _, cmd := m.quit(); cmd == nil {
+		t.Fatal("expected quit command")
+	}
+	if err := store.SaveSession(t.Context(), m.cfg.ActiveProfile, m.session()); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+
+	// The next launch reopens it once the library loads
+	m = createTestModel(t)
+	m.queueStore = store
+	m.cfg.Queue.Persist = true
+	m, _ = updateModel(m, m.loadSessionCmd()())
+	if m.restore == nil {
+		t.Fatal("expected the session to wait for the library")
+	}
+	m, _ = updateModel(m, initMsg{})
+	m, cmd := updateModel(m, artistsMsg{page: provider.Page[provider.Artist]{Items: prov.artists}})
+	if cmd == nil || m.screen != screenLibrary || m.focusedPane != paneContent || m.currentArtistID != "1" || m.searchQ != "beatles" || m.searchFilter != filterAlbums {
+		t.Fatalf("expected the Library reopened at the artist, got screen %s artist %q search %q", screenNames[m.screen], m.currentArtistID, m.searchQ)
+	}
+	m, cmd = updateModel(m, albumsMsg{page: provider.Page[provider.Album]{Items: prov.albums}})
+	if cmd == nil || m.currentAlbumID != "10" {
+		t.Fatalf("expected the album's tracks to be loaded, got album %q", m.currentAlbumID)
+	}
+	m, _ = updateModel(m, tracksMsg{page: provider.Page[provider.Track]{Items: prov.tracks}})
+	if m.restore != nil || m.selection != 2 || m.libraryView() != "tracks" {
+		t.Errorf("expected the saved track selected, got selection %d in %s", m.selection, m.libraryView())
+	}
+}
+
+func TestSessionRestoreYieldsToInput(t *testing.T) {
+	prov := newTestProvider()
+	m := initializeModel(createTestModel(t), prov)
+	m, cmd := updateModel(m, sessionMsg{session: queue.Session{Screen: "playlists", Selection: 3}, ok: true})
+	if cmd == nil || m.screen != screenPlaylists {
+		t.Fatalf("expected a late session to reopen Playlists, got %s", screenNames[m.screen])
+	}
+
+	// Moving before the playlists arrive keeps the user's place
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = updateModel(m, playlistsMsg{page: provider.Page[provider.Playlist]{Items: make([]provider.Playlist, 5)}})
+	if m.restore != nil || m.selection == 3 {
+		t.Errorf("expected the restore dropped after input, got selection %d", m.selection)
+	}
+
+	// Startup flags choose where to start instead
+	m = createTestModel(t)
+	m.startupOpts.Playlist = "Chill"
+	m, _ = updateModel(m, sessionMsg{session: queue.Session{Screen: "queue"}, ok: true})
+	if m.restore != nil {
+		t.Error("expected the session ignored with startup flags")
+	}
+}
+
+func TestSessionRestoresVolume(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.volume = 70
+	m, _ = updateModel(m, sessionMsg{session: queue.Session{Screen: "queue", Volume: 35}, ok: true})
+	if m.volume != 70 {
+		t.Fatalf("expected initial_volume kept without restore_volume, got %v", m.volume)
+	}
+
+	m.cfg.Player.RestoreVolume = true
+	m, cmd := updateModel(m, sessionMsg{session: queue.Session{Screen: "queue", Volume: 35}, ok: true})
+	if m.volume != 35 || cmd == nil {
+		t.Errorf("expected the volume at quit restored, got %v", m.volume)
+	}
+}
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// weightedShuffle reports whether [queue.shuffle] mode is weighted.
+func (m Model) weightedShuffle() bool {
+	return m.cfg.Queue.Shuffle.Mode == "weighted"
+}
+
+// setShuffleWeight weights the queue's shuffling by m.playHistory.
+func (m Model) setShuffleWeight() {
+	sh := m.cfg.Queue.Shuffle
+	weights := queue.ShuffleWeights{Loved: sh.LovedWeight, Plays: sh.PlaysWeight, Recency: sh.RecencyWeight}
+	history := m.playHistory
+	m.queue.SetShuffleWeight(func(t provider.Track) float64 {
+		return weights.Weight(history.Get(t.ArtistName, t.Title), time.Now())
+	})
+}
+
+// playHistoryMsg carries the play counts, loved marks and last plays that
+// weight shuffling.
+type playHistoryMsg struct {
+	history queue.PlayHistory
+	err     error
+}
+
+// loadPlayHistoryCmd loads the play history when shuffling is weighted or
+// the track lists show plays or loved marks.
+func (m Model) loadPlayHistoryCmd() tea.Cmd {
+	if !m.weightedShuffle() && !m.columnsShowHistory() || m.queueStore == nil {
+		return nil
+	}
+	store := m.queueStore
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		history, err := store.PlayHistory(ctx)
+		return playHistoryMsg{history: history, err: err}
+	}
+}
+
+func (m Model) handlePlayHistory(msg playHistoryMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("load play history", slog.Any("err", msg.err))
+		return m, nil
+	}
+	m.playHistory = msg.history
+	if m.weightedShuffle() {
+		m.setShuffleWeight()
+	}
+	return m, nil
+}
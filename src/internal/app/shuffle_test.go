@@ -0,0 +1,47 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestWeightedShuffle(t *testing.T) {
+	m := createTestModel(t)
+	m.cfg.Queue.Shuffle = config.ShuffleConfig{Mode: "weighted", LovedWeight: 1000}
+	prov := newTestProvider()
+	m.queue.Add(prov.tracks...)
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+	if err := store.SetLoved(context.Background(), "The Beatles", "Here Comes the Sun", true); err != nil {
+		t.Fatal(err)
+	}
+
+	m, _ = updateModel(m, m.loadPlayHistoryCmd()())
+	for range 20 {
+		m.queue.ShuffleRemaining()
+		if items := m.queue.Items(); items[1].Title != "Here Comes the Sun" {
+			t.Fatalf("expected the loved track next, got %v", items)
+		}
+	}
+
+	// Plays counted and loves made from then on weigh in too
+	m.nowPlaying = prov.tracks[0]
+	m.duration, m.timePos = 259, 200
+	m, _ = m.countPlay()
+	if h := m.playHistory.Get("The Beatles", "Come Together"); h.Plays != 1 || time.Since(h.LastPlayed) > time.Minute {
+		t.Errorf("expected the play in the history, got %+v", h)
+	}
+	m.saveLovedCmd(prov.tracks[1], true)
+	if !m.playHistory.Get("The Beatles", "Something").Loved {
+		t.Error("expected the love in the history")
+	}
+}
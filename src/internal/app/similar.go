@@ -0,0 +1,307 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/about"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// similarLimit is how many similar artists are asked for.
+const similarLimit = 30
+
+// similarLookups is how many library searches run at once while matching
+// Last.fm's suggestions.
+const similarLookups = 4
+
+// similarArtist is one entry of the Similar Artists list. Artist is set
+// when the artist is in the library.
+type similarArtist struct {
+	Name   string
+	Match  float64
+	Artist provider.Artist
+}
+
+func (s similarArtist) inLibrary() bool { return s.Artist.ID != "" }
+
+// similarArtistsMsg carries the similar artists of an artist.
+type similarArtistsMsg struct {
+	artist  string
+	artists []similarArtist
+	err     error
+}
+
+// artistTracksMsg carries every track of an artist to add to the queue.
+type artistTracksMsg struct {
+	artist string
+	tracks []provider.Track
+	next   bool
+	err    error
+}
+
+// similarSource suggests artists like another by name; about.Lastfm is one.
+type similarSource interface {
+	SimilarArtists(ctx context.Context, name string, limit int) ([]about.Similar, error)
+}
+
+// newSimilarSource returns the Last.fm client used for similar artists, or
+// nil without a Last.fm api_key.
+func newSimilarSource(cfg *config.Config) similarSource {
+	entry, ok := cfg.LastfmScrobbler()
+	if !ok {
+		return nil
+	}
+	apiKey, _ := entry.Settings["api_key"].(string)
+	if apiKey == "" {
+		return nil
+	}
+	return about.NewLastfm(apiKey)
+}
+
+// artistTarget returns the artist under the cursor: the selected Library
+// or Search artist, or the artist whose albums are listed.
+func (m Model) artistTarget() (provider.Artist, bool) {
+	switch {
+	case m.screen == screenLibrary && m.libraryView() == "artists" && len(m.artists) > 0:
+		return m.artists[clamp(m.selection, 0, len(m.artists)-1)], true
+	case m.screen == screenLibrary && m.libraryView() == "albums":
+		a := m.albums[clamp(m.selection, 0, len(m.albums)-1)]
+		return provider.Artist{ID: m.currentArtistID, Name: a.ArtistName}, a.ArtistName != ""
+	case m.screen == screenSearch && m.searchFilter == filterArtists && len(m.searchResults.Artists.Items) > 0:
+		items := m.searchResults.Artists.Items
+		return items[clamp(m.selection, 0, len(items)-1)], true
+	}
+	return provider.Artist{}, false
+}
+
+// openSimilar shows the Similar Artists list for the selected artist and
+// starts looking them up.
+func (m Model) openSimilar() (Model, tea.Cmd) {
+	artist, ok := m.artistTarget()
+	if !ok {
+		m.status = m.msgs.T("status.select_artist")
+		return m, nil
+	}
+	_, native := provider.As[provider.SimilarArtistFinder](m.provider)
+	if !native && m.similarSource == nil {
+		m.status = m.msgs.T("similar.needs_key")
+		return m, nil
+	}
+	m.showSimilar = true
+	m.similarFor = artist.Name
+	m.similar = nil
+	m.similarErr = nil
+	m.similarLoading = true
+	m.similarSel = 0
+	return m, m.similarArtistsCmd(artist)
+}
+
+// similarArtistsCmd looks up the artists similar to artist.
+func (m Model) similarArtistsCmd(artist provider.Artist) tea.Cmd {
+	prov, src, pageSize := m.provider, m.similarSource, m.cfg.UI.PageSize
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		found, err := findSimilarArtists(ctx, prov, src, artist, pageSize)
+		return similarArtistsMsg{artist: artist.Name, artists: found, err: err}
+	}
+}
+
+// findSimilarArtists asks the provider for similar artists when it can,
+// otherwise Last.fm, whose suggestions are then looked up in the library.
+func findSimilarArtists(ctx context.Context, prov provider.Provider, src similarSource, artist provider.Artist, pageSize int) ([]similarArtist, error) {
+	if f, ok := provider.As[provider.SimilarArtistFinder](prov); ok && artist.ID != "" {
+		found, err := f.SimilarArtists(ctx, artist.ID, similarLimit)
+		if err == nil || src == nil {
+			out := make([]similarArtist, len(found))
+			for i, a := range found {
+				out[i] = similarArtist{Name: a.Name, Artist: a}
+			}
+			return out, err
+		}
+	}
+	if src == nil {
+		return nil, about.ErrNotFound
+	}
+	suggested, err := src.SimilarArtists(ctx, artist.Name, similarLimit)
+	if err != nil {
+		return nil, err
+	}
+	return matchLibraryArtists(ctx, prov, suggested, pageSize), nil
+}
+
+// matchLibraryArtists searches the library for each suggested artist by
+// name and lists those found first, each group closest first.
+func matchLibraryArtists(ctx context.Context, prov provider.Provider, suggested []about.Similar, pageSize int) []similarArtist {
+	out := make([]similarArtist, len(suggested))
+	sem := make(chan struct{}, similarLookups)
+	var wg sync.WaitGroup
+	for i, s := range suggested {
+		out[i] = similarArtist{Name: s.Name, Match: s.Match}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			res, err := prov.Search(ctx, s.Name, provider.ListReq{PageSize: pageSize})
+			if err != nil {
+				return
+			}
+			for _, a := range res.Artists.Items {
+				if strings.EqualFold(strings.TrimSpace(a.Name), strings.TrimSpace(s.Name)) {
+					out[i].Artist = a
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	sort.SliceStable(out, func(i, j int) bool { return out[i].inLibrary() && !out[j].inLibrary() })
+	return out
+}
+
+// handleSimilarArtists fills in the list if it is still open.
+func (m Model) handleSimilarArtists(msg similarArtistsMsg) (Model, tea.Cmd) {
+	if !m.showSimilar || msg.artist != m.similarFor {
+		return m, nil
+	}
+	m.similarLoading = false
+	m.similar = msg.artists
+	m.similarErr = msg.err
+	if msg.err != nil && !errors.Is(msg.err, about.ErrNotFound) {
+		m.logger.Warn("similar artists lookup failed", slog.String("artist", msg.artist), slog.Any("err", msg.err))
+	}
+	return m, nil
+}
+
+// handleSimilarKey moves through the list, opens an artist in the Library
+// or queues all of its tracks.
+func (m Model) handleSimilarKey(key string) (Model, tea.Cmd) {
+	switch key {
+	case "esc", "q", "R":
+		m.showSimilar = false
+	case "up", "k":
+		if m.similarSel > 0 {
+			m.similarSel--
+		}
+	case "down", "j":
+		if m.similarSel < len(m.similar)-1 {
+			m.similarSel++
+		}
+	case "enter", "a", "A", "P":
+		if len(m.similar) == 0 {
+			return m, nil
+		}
+		s := m.similar[clamp(m.similarSel, 0, len(m.similar)-1)]
+		if !s.inLibrary() {
+			m.status = m.msgs.T("similar.not_in_library", s.Name)
+			return m, nil
+		}
+		if key == "enter" {
+			m.showSimilar = false
+			return m.handleFindArtist(findArtistMsg{query: s.Name, artist: s.Artist, found: true})
+		}
+		m.status = m.msgs.T("status.loading", s.Name)
+		return m, m.artistTracksCmd(s.Artist, key != "a")
+	}
+	return m, nil
+}
+
+// artistTracksCmd loads every track of an artist, to add to the end of the
+// queue or play next.
+func (m Model) artistTracksCmd(artist provider.Artist, next bool) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		var tracks []provider.Track
+		cursor := ""
+		for {
+			page, err := m.provider.ListTracks(ctx, "", artist.ID, "", provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+			if err != nil {
+				return artistTracksMsg{artist: artist.Name, err: err}
+			}
+			tracks = append(tracks, page.Items...)
+			if page.NextCursor == "" || page.NextCursor == cursor {
+				return artistTracksMsg{artist: artist.Name, tracks: tracks, next: next}
+			}
+			cursor = page.NextCursor
+		}
+	}
+}
+
+// handleArtistTracks queues an artist's tracks.
+func (m Model) handleArtistTracks(msg artistTracksMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	if len(msg.tracks) == 0 {
+		m.status = m.msgs.T("status.no_tracks_by", msg.artist)
+		return m, nil
+	}
+	if msg.next {
+		added := m.queue.AddNext(msg.tracks...)
+		m.status = m.msgs.T("status.playing_next", msg.artist, m.msgs.N("count.track", added))
+		m.queueFull(added, len(msg.tracks))
+	} else {
+		added := m.queue.Add(msg.tracks...)
+		m.status = m.msgs.T("status.queued", msg.artist, m.msgs.N("count.track", added))
+		m.queueFull(added, len(msg.tracks))
+	}
+	return m, m.saveQueueCmd()
+}
+
+// renderSimilar renders the Similar Artists popup. Artists in the library
+// come first; the rest are dimmed.
+func (m Model) renderSimilar() string {
+	var lines []string
+	switch {
+	case m.similarLoading:
+		lines = []string{m.theme.Dim.Render("  Looking up similar artists…")}
+	case m.similarErr != nil && !errors.Is(m.similarErr, about.ErrNotFound):
+		lines = []string{m.theme.Error.Render("  Failed to load: " + m.similarErr.Error())}
+	case len(m.similar) == 0:
+		lines = []string{m.theme.Dim.Render("  No similar artists found")}
+	default:
+		rows := max(m.height-10, 3)
+		start := clamp(m.similarSel-rows/2, 0, max(len(m.similar)-rows, 0))
+		for i := start; i < min(start+rows, len(m.similar)); i++ {
+			s := m.similar[i]
+			prefix := "   "
+			style := m.theme.Text
+			if !s.inLibrary() {
+				style = m.theme.Dim
+			}
+			if i == m.similarSel {
+				prefix, style = m.selectedRow(" ▶ ")
+			}
+			line := style.Render(prefix + s.Name)
+			if s.Match > 0 {
+				line += m.theme.Dim.Render(fmt.Sprintf("  %d%%", int(s.Match*100+0.5)))
+			}
+			if s.inLibrary() {
+				line += m.theme.Accent.Render("  ♪")
+			}
+			lines = append(lines, line)
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Similar Artists ═══  "),
+		m.theme.Dim.Render("  Like "+m.similarFor+"  (♪ in your library)"),
+		"",
+		strings.Join(lines, "\n"),
+		"",
+		m.theme.Dim.Render("[Enter]Open  [a]Queue  [P]Play next  [Esc]Close"),
+	)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, boxStyle.Render(content))
+}
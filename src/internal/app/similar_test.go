@@ -0,0 +1,78 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/about"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+type fakeSimilar []about.Similar
+
+func (f fakeSimilar) SimilarArtists(ctx context.Context, name string, limit int) ([]about.Similar, error) {
+	return f, nil
+}
+
+func TestSimilarArtists(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.screen = screenLibrary
+	m.artists = prov.artists
+	m.selection = 0
+	key := func(k string) tea.KeyMsg { return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)} }
+
+	m.similarSource = nil
+	m, _ = updateModel(m, key("R"))
+	if m.showSimilar || !strings.Contains(m.status, "api_key") {
+		t.Fatalf("expected a hint to configure Last.fm, got %q", m.status)
+	}
+
+	m.similarSource = fakeSimilar{{Name: "The Kinks", Match: 0.9}, {Name: "Queen", Match: 0.4}, {Name: "pink floyd", Match: 0.3}}
+	m, cmd := updateModel(m, key("R"))
+	if !m.showSimilar || cmd == nil {
+		t.Fatal("expected the list to open and load")
+	}
+	m, _ = updateModel(m, cmd())
+	var names []string
+	for _, s := range m.similar {
+		names = append(names, s.Name)
+	}
+	if strings.Join(names, ",") != "Queen,pink floyd,The Kinks" {
+		t.Fatalf("expected library artists first, got %v", names)
+	}
+	if m.similar[1].Artist.ID != "2" || m.similar[2].inLibrary() {
+		t.Errorf("unexpected matches %+v", m.similar)
+	}
+	if !strings.Contains(m.View(), "Like The Beatles") {
+		t.Error("expected the popup to name the artist")
+	}
+
+	// Queue every track of a library artist
+	m.queue.Add(provider.Track{ID: "1", Title: "Playing"})
+	m, cmd = updateModel(m, key("a"))
+	if cmd == nil {
+		t.Fatal("expected a command loading the artist's tracks")
+	}
+	m, _ = updateModel(m, cmd())
+	if m.queue.Len() != 4 || m.status != "Added Queen to queue (3 tracks)" {
+		t.Errorf("expected 3 tracks queued, got %d %q", m.queue.Len(), m.status)
+	}
+
+	// Artists outside the library can't be opened
+	m, _ = updateModel(m, key("j"))
+	m, _ = updateModel(m, key("j"))
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if !m.showSimilar || m.status != "The Kinks is not in your library" {
+		t.Errorf("unexpected status %q", m.status)
+	}
+
+	m, _ = updateModel(m, key("k"))
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyEnter})
+	if m.showSimilar || m.currentArtistID != "2" || cmd == nil {
+		t.Errorf("expected Pink Floyd opened in the Library, got artist %q", m.currentArtistID)
+	}
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// sortLabels names each sort mode in the Library header.
+var sortLabels = map[string]string{
+	"":                      "Default",
+	provider.SortName:       "Name",
+	provider.SortAlbumCount: "Album Count",
+	provider.SortYear:       "Year",
+	provider.SortTitle:      "Title",
+	provider.SortRecent:     "Recently Added",
+	provider.SortTrackNo:    "Track #",
+	provider.SortDuration:   "Duration",
+}
+
+func sortLabel(mode string) string {
+	if l, ok := sortLabels[mode]; ok {
+		return l
+	}
+	return mode
+}
+
+// libraryView names the Library list on screen, matching the keys of the
+// [ui.sort] config table.
+func (m Model) libraryView() string {
+	switch {
+	case len(m.tracks) > 0:
+		return "tracks"
+	case len(m.albums) > 0:
+		return "albums"
+	}
+	return "artists"
+}
+
+// librarySort returns the sort mode of the Library list on screen.
+func (m Model) librarySort() string {
+	switch m.libraryView() {
+	case "tracks":
+		return m.cfg.UI.Sort.Tracks
+	case "albums":
+		return m.cfg.UI.Sort.Albums
+	}
+	return m.cfg.UI.Sort.Artists
+}
+
+// cycleSort moves the Library list on screen to its next sort mode, reloads
+// it and saves the choice to the config file.
+func (m Model) cycleSort() (Model, tea.Cmd) {
+	view := m.libraryView()
+	var modes []string
+	var target *string
+	switch view {
+	case "tracks":
+		modes, target = provider.TrackSorts, &m.cfg.UI.Sort.Tracks
+	case "albums":
+		modes, target = provider.AlbumSorts, &m.cfg.UI.Sort.Albums
+	default:
+		modes, target = provider.ArtistSorts, &m.cfg.UI.Sort.Artists
+	}
+
+	next := modes[0]
+	for i, mode := range modes {
+		if mode == *target {
+			next = modes[(i+1)%len(modes)]
+		}
+	}
+	m.cfg.SetSort(view, next)
+	m.selection = 0
+	m.status = m.msgs.T("sort.sorted", m.msgs.T("library."+view), sortLabel(next))
+	m.logger.Debug("library sort changed", slog.String("view", view), slog.String("sort", next))
+
+	var reload tea.Cmd
+	switch view {
+	case "tracks":
+		m.tracksCursor = ""
+		reload = m.moreTracksCmd("")
+	case "albums":
+		m.albumsCursor = ""
+		reload = m.loadAlbumsCmd(m.currentArtistID, "")
+	default:
+		m.artistsCursor = ""
+		reload = m.loadArtistsCmd("")
+	}
+	return m, tea.Batch(reload, m.saveSortCmd(view, next))
+}
+
+// saveSortCmd writes a Library sort mode to the [ui.sort] config table.
+func (m Model) saveSortCmd(view, mode string) tea.Cmd {
+	path := m.cfg.Path
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := config.SetValue(path, "ui.sort", view, mode); err != nil {
+			m.logger.Warn("save sort order", slog.String("view", view), slog.Any("err", err))
+		}
+		return nil
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestLibrarySortCycle(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.cfg.Path = filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(m.cfg.Path, []byte("[ui]\npage_size = 100\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m.selection = 2
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.cfg.UI.Sort.Artists != provider.SortName || m.selection != 0 {
+		t.Fatalf("expected artists sorted by name from the top, got %q sel=%d", m.cfg.UI.Sort.Artists, m.selection)
+	}
+	if cmd == nil {
+		t.Fatal("expected reload command")
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.cfg.UI.Sort.Artists != provider.SortAlbumCount {
+		t.Fatalf("expected album count sort, got %q", m.cfg.UI.Sort.Artists)
+	}
+	if view := m.renderLibrary(80, 20); !strings.Contains(view, "Sort: Album Count") {
+		t.Errorf("expected sort label in header, got:\n%s", view)
+	}
+
+	// The choice is written to [ui.sort]
+	m.saveSortCmd("artists", provider.SortAlbumCount)()
+	data, err := os.ReadFile(m.cfg.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "[ui.sort]\nartists = \"album_count\"") {
+		t.Errorf("expected sort saved to config, got:\n%s", data)
+	}
+
+	// Albums cycle separately
+	m.albums = prov.albums
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+	if m.cfg.UI.Sort.Albums != provider.SortYear || m.cfg.UI.Sort.Artists != provider.SortAlbumCount {
+		t.Errorf("expected only the album sort to change, got %+v", m.cfg.UI.Sort)
+	}
+}
@@ -0,0 +1,163 @@
+package app
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/control"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// searches reports whether the options name tracks to look up.
+func (o StartupOptions) searches() bool {
+	return o.SearchArtist != "" || o.SearchAlbum != "" || o.SearchTrack != "" || o.Playlist != ""
+}
+
+// matches reports whether t passes the --artist, --album and --track
+// flags.
+func (o StartupOptions) matches(t provider.Track) bool {
+	return flagMatches(t.ArtistName, o.SearchArtist) && flagMatches(t.AlbumTitle, o.SearchAlbum) && flagMatches(t.Title, o.SearchTrack)
+}
+
+// flagMatches reports whether got contains the flag value want, ignoring
+// case. An unset flag matches anything.
+func flagMatches(got, want string) bool {
+	return want == "" || strings.Contains(strings.ToLower(got), strings.ToLower(want))
+}
+
+// randomCmd runs the random pick the options ask for, or returns nil when
+// they ask for none.
+func (m Model) randomCmd(opts StartupOptions, call *control.Call) tea.Cmd {
+	switch {
+	case opts.RandomAlbum:
+		return m.randomAlbumCmd(opts, call)
+	case opts.RandomArtist:
+		return m.randomArtistCmd(opts, call)
+	case opts.RandomPlay:
+		return m.randomPlayCmd(opts, call)
+	}
+	return nil
+}
+
+// applyQueueModes applies --shuffle and --repeat to the queue.
+func (m *Model) applyQueueModes(opts StartupOptions) {
+	if opts.Shuffle && !m.queue.IsShuffled() {
+		m.queue.ShuffleRemaining()
+	}
+	if mode, err := queue.ParseRepeatMode(opts.Repeat); err == nil && opts.Repeat != "" {
+		m.queue.SetRepeat(mode)
+	}
+}
+
+// queueStartupTracks adds the tracks found for CLI flags to the queue and
+// starts playing them if --play was given. call is set when the flags were
+// forwarded by "tunez --enqueue", and gets the outcome as its answer. what
+// describes random picks for the status line, and is empty for tracks the
+// search flags found.
+func (m Model) queueStartupTracks(opts StartupOptions, call *control.Call, tracks []provider.Track, err error, what string) (Model, tea.Cmd) {
+	reply := func(ok bool, text string) {
+		if call == nil {
+			return
+		}
+		if ok {
+			call.Reply(control.Response{OK: true, Message: text})
+		} else {
+			call.Reply(control.Response{Error: text})
+		}
+	}
+	if err != nil {
+		reply(false, err.Error())
+		return m.setError(err)
+	}
+	if len(tracks) == 0 {
+		m.status = m.msgs.T("startup.no_search")
+		if what != "" {
+			m.status = m.msgs.T("startup.no_random")
+		}
+		reply(false, m.status)
+		return m, nil
+	}
+	// Clear queue first if requested
+	if opts.ClearQueue {
+		m.queue.Clear()
+		m.radio, m.radioLoading = nil, false
+	}
+	first := m.queue.Len()
+	added := m.queue.Add(tracks...)
+	if m.queueFull(added, len(tracks)) && added == 0 {
+		reply(false, m.status)
+		return m, nil
+	}
+	m.applyQueueModes(opts)
+	if opts.Shuffle {
+		first = m.queue.CurrentIndex() + 1
+	}
+	if what == "" {
+		what = m.msgs.T("startup.what")
+	}
+	m.status = m.msgs.T("startup.added", added, what)
+	m.queueFull(added, len(tracks))
+	reply(true, m.status)
+	cmds := []tea.Cmd{m.saveQueueCmd()}
+	// If autoplay is enabled, play the first new track and show Now Playing
+	if opts.AutoPlay {
+		m.screen = screenNowPlaying
+		m.focusedPane = paneContent
+		return m, tea.Batch(append(cmds, m.playQueueTrackCmd(first))...)
+	}
+	// Otherwise show the queue, unless the tracks came from another terminal
+	if call == nil {
+		m.screen = screenQueue
+		m.focusedPane = paneContent
+	}
+	return m, tea.Batch(cmds...)
+}
+
+// handleEnqueue runs the queueing flags forwarded by "tunez --enqueue".
+func (m Model) handleEnqueue(call *control.Call) (Model, tea.Cmd) {
+	e := call.Enqueue
+	if e == nil {
+		call.Reply(control.Response{Error: "enqueue needs options"})
+		return m, nil
+	}
+	filter, err := enqueueRandomFilter(e)
+	if err != nil {
+		call.Reply(control.Response{Error: err.Error()})
+		return m, nil
+	}
+	opts := StartupOptions{
+		SearchArtist: e.Artist,
+		SearchAlbum:  e.Album,
+		SearchTrack:  e.Track,
+		Playlist:     e.Playlist,
+		AutoPlay:     e.Play,
+		RandomPlay:   e.Random,
+		RandomFilter: filter,
+		RandomAlbum:  e.RandomAlbum,
+		RandomArtist: e.RandomArtist,
+		ClearQueue:   e.Clear,
+		Shuffle:      e.Shuffle,
+		Repeat:       e.Repeat,
+	}
+	if cmd := m.randomCmd(opts, call); cmd != nil {
+		return m, cmd
+	}
+	switch {
+	case opts.searches():
+		return m, m.startupSearchCmd(opts, call)
+	}
+	m.applyQueueModes(opts)
+	call.Reply(control.Response{OK: true, Message: "Queue updated"})
+	return m, m.saveQueueCmd()
+}
+
+// enqueueRandomFilter reads the forwarded --genre and --years flags.
+func enqueueRandomFilter(e *control.Enqueue) (provider.RandomFilter, error) {
+	years, err := provider.ParseYearRange(e.Years)
+	if err != nil {
+		return provider.RandomFilter{}, fmt.Errorf("--years: %w", err)
+	}
+	return provider.RandomFilter{Genre: strings.TrimSpace(e.Genre), Years: years}, nil
+}
@@ -0,0 +1,93 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/control"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// playlistProvider adds one playlist holding all the test tracks.
+type playlistProvider struct {
+	*testProvider
+}
+
+func (p playlistProvider) ListPlaylists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Playlist], error) {
+	return provider.Page[provider.Playlist]{Items: []provider.Playlist{{ID: "p1", Name: "Sunday Chill"}}}, nil
+}
+
+func TestStartupTrackFlag(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.provider = prov
+
+	opts := StartupOptions{SearchArtist: "beatles", SearchTrack: "something", Repeat: "all"}
+	msg := m.startupSearchCmd(opts, nil)().(startupSearchMsg)
+	if len(msg.tracks) != 1 || msg.tracks[0].ID != "101" {
+		t.Fatalf("expected only Something, got %+v", msg.tracks)
+	}
+	m, _ = updateModel(m, msg)
+	if m.queue.Len() != 1 || m.queue.RepeatMode() != queue.RepeatAll || m.screen != screenQueue {
+		t.Errorf("expected one queued track with repeat all, got len=%d repeat=%v screen=%v", m.queue.Len(), m.queue.RepeatMode(), m.screen)
+	}
+}
+
+func TestStartupPlaylistFlag(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.provider = playlistProvider{prov}
+	m.queue.Add(provider.Track{ID: "old"})
+
+	opts := StartupOptions{Playlist: "chill", Shuffle: true, ClearQueue: true}
+	m, _ = updateModel(m, m.startupSearchCmd(opts, nil)())
+	if m.queue.Len() != 3 || !m.queue.IsShuffled() {
+		t.Errorf("expected the shuffled playlist to replace the queue, got len=%d shuffled=%v", m.queue.Len(), m.queue.IsShuffled())
+	}
+
+	opts.Playlist = "workout"
+	msg := m.startupSearchCmd(opts, nil)().(startupSearchMsg)
+	if msg.err == nil {
+		t.Error("expected an error for a missing playlist")
+	}
+}
+
+func TestEnqueueForwarded(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tunez.sock")
+	srv, err := control.Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer srv.Close()
+
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.provider = prov
+	m.control = srv
+	m.queue.Add(prov.tracks[0])
+	m.screen = screenLibrary
+
+	done := make(chan control.Response)
+	go func() {
+		resp, _ := control.Send(path, control.Request{Cmd: control.CmdEnqueue, Enqueue: &control.Enqueue{Track: "here comes"}})
+		done <- resp
+	}()
+	call := m.waitControlCmd()().(controlMsg).call
+	m, cmd := m.handleEnqueue(call)
+	if cmd == nil {
+		t.Fatal("expected a search command")
+	}
+	m, _ = updateModel(m, cmd())
+	resp := <-done
+	if !resp.OK || resp.Message != "Added 1 tracks to queue" {
+		t.Errorf("unexpected reply %+v", resp)
+	}
+	if m.queue.Len() != 2 || m.screen != screenLibrary {
+		t.Errorf("expected the track appended without leaving the library, got len=%d screen=%v", m.queue.Len(), m.screen)
+	}
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// streamQuality is what remote providers are asked to transcode to: the
+// profile's stream_quality, or low_bandwidth_quality in low-bandwidth mode.
+func (m Model) streamQuality() provider.StreamQuality {
+	s := m.cfg.Player.StreamQuality
+	if m.lowBandwidth {
+		s = m.cfg.Player.LowBandwidthQuality
+	}
+	q, err := provider.ParseStreamQuality(s)
+	if err != nil {
+		m.logger.Warn("invalid stream quality, streaming raw", slog.String("quality", s), slog.Any("err", err))
+	}
+	return q
+}
+
+// streamContext attaches the stream quality to ctx for GetStream.
+func (m Model) streamContext(ctx context.Context) context.Context {
+	return provider.WithStreamQuality(ctx, m.streamQuality())
+}
+
+// toggleLowBandwidth switches low-bandwidth streaming, for metered
+// connections. It applies from the next track.
+func (m Model) toggleLowBandwidth() Model {
+	m.lowBandwidth = !m.lowBandwidth
+	m.logger.Info("low-bandwidth streaming", slog.Bool("on", m.lowBandwidth), slog.String("quality", m.streamQuality().String()))
+	if m.lowBandwidth {
+		m.status = m.msgs.T("quality.low_on", m.streamQuality())
+	} else {
+		m.status = m.msgs.T("quality.low_off", m.streamQuality())
+	}
+	return m
+}
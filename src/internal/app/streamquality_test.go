@@ -0,0 +1,29 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLowBandwidthToggle(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.cfg.Player.StreamQuality = "mp3-320"
+	m.cfg.Player.LowBandwidthQuality = "opus-64"
+	if q := m.streamQuality().String(); q != "mp3-320" {
+		t.Fatalf("expected the profile's quality, got %s", q)
+	}
+
+	m = m.toggleLowBandwidth()
+	if q := m.streamQuality().String(); !m.lowBandwidth || q != "opus-64" {
+		t.Fatalf("expected low-bandwidth quality, got %s", q)
+	}
+	if !strings.Contains(m.View(), "LOW BW") {
+		t.Error("expected the top bar to show low-bandwidth mode")
+	}
+
+	m = m.toggleLowBandwidth()
+	if m.lowBandwidth || m.streamQuality().String() != "mp3-320" {
+		t.Error("expected the normal quality back")
+	}
+}
@@ -0,0 +1,117 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// topTracksLimit is how many tracks the Top Tracks view lists.
+const topTracksLimit = 25
+
+// topTracksScan caps how many of an artist's tracks are ranked.
+const topTracksScan = 2000
+
+// topTracksMsg carries an artist's most played tracks.
+type topTracksMsg struct {
+	artist provider.Artist
+	tracks []provider.Track
+	plays  map[string]int // by track ID
+	err    error
+}
+
+// openTopTracks starts loading the Top Tracks view of the selected artist.
+func (m Model) openTopTracks() (Model, tea.Cmd) {
+	artist, ok := m.artistTarget()
+	if !ok || artist.ID == "" {
+		m.status = m.msgs.T("status.select_artist")
+		return m, nil
+	}
+	m.status = m.msgs.T("toptracks.loading", artist.Name)
+	return m, m.topTracksCmd(artist)
+}
+
+// topTracksCmd ranks an artist's tracks by local play count, which includes
+// plays imported from Last.fm. Unplayed tracks keep the provider's order, so
+// an artist with no plays lists its first tracks.
+func (m Model) topTracksCmd(artist provider.Artist) tea.Cmd {
+	prov, store, pageSize := m.provider, m.queueStore, m.cfg.UI.PageSize
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		var tracks []provider.Track
+		cursor := ""
+		for len(tracks) < topTracksScan {
+			page, err := prov.ListTracks(ctx, "", artist.ID, "", provider.ListReq{PageSize: pageSize, Cursor: cursor})
+			if err != nil {
+				return topTracksMsg{artist: artist, err: err}
+			}
+			tracks = append(tracks, page.Items...)
+			if page.NextCursor == "" || page.NextCursor == cursor {
+				break
+			}
+			cursor = page.NextCursor
+		}
+
+		var counts queue.ArtistPlays
+		if store != nil {
+			var err error
+			if counts, err = store.ArtistPlays(ctx, artist.Name); err != nil {
+				return topTracksMsg{artist: artist, err: err}
+			}
+		}
+		plays := make(map[string]int, len(tracks))
+		seen := make(map[string]bool, len(tracks))
+		ranked := tracks[:0:0]
+		for _, t := range tracks {
+			// The same song on several albums is listed once
+			key := strings.ToLower(strings.TrimSpace(t.Title))
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			plays[t.ID] = counts.Plays(t.Title)
+			ranked = append(ranked, t)
+		}
+		sort.SliceStable(ranked, func(i, j int) bool { return plays[ranked[i].ID] > plays[ranked[j].ID] })
+		if len(ranked) > topTracksLimit {
+			ranked = ranked[:topTracksLimit]
+		}
+		return topTracksMsg{artist: artist, tracks: ranked, plays: plays}
+	}
+}
+
+// handleTopTracks shows the ranked tracks in the Library tracks view.
+func (m Model) handleTopTracks(msg topTracksMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		return m.setError(msg.err)
+	}
+	msg.tracks = visible(msg.tracks, m.hidden.track)
+	if len(msg.tracks) == 0 {
+		m.status = m.msgs.T("status.no_tracks_by", msg.artist.Name)
+		return m, nil
+	}
+	m.logger.Debug("top tracks", slog.String("artist_id", msg.artist.ID), slog.Int("tracks", len(msg.tracks)))
+	m = m.pushHistory()
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+	m.currentArtistID = msg.artist.ID
+	m.currentAlbumID, m.currentPlaylistID = "", ""
+	m.tracks, m.trackVersions = msg.tracks, versionList{}
+	m.tracksCursor = ""
+	m.selection = 0
+	m.topTracksOf = msg.artist.Name
+	m.topPlays = msg.plays
+	if msg.plays[msg.tracks[0].ID] > 0 {
+		m.status = m.msgs.T("toptracks.by_plays", msg.artist.Name)
+	} else {
+		m.status = m.msgs.T("toptracks.no_plays", msg.artist.Name)
+	}
+	return m, nil
+}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestTopTracks(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	m.width, m.height = 120, 40
+	m.screen = screenLibrary
+	m.artists = prov.artists
+	m.selection = 0
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("T")}
+
+	// Without a stats store the first tracks are listed
+	m, cmd := updateModel(m, key)
+	if cmd == nil {
+		t.Fatal("expected a command loading the top tracks")
+	}
+	m, _ = updateModel(m, cmd())
+	if len(m.tracks) != 3 || m.tracks[0].ID != "100" || !strings.Contains(m.status, "showing the first tracks") {
+		t.Fatalf("expected the first tracks, got %d %q", len(m.tracks), m.status)
+	}
+
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+	ctx := context.Background()
+	for _, title := range []string{"Something", "Here Comes the Sun", "Something", "something"} {
+		if err := store.RecordPlay(ctx, "The Beatles", title); err != nil {
+			t.Fatalf("RecordPlay: %v", err)
+		}
+	}
+
+	m.tracks = nil
+	m, cmd = updateModel(m, key)
+	m, _ = updateModel(m, cmd())
+	var ids []string
+	for _, tr := range m.tracks {
+		ids = append(ids, tr.ID)
+	}
+	if strings.Join(ids, ",") != "101,102,100" {
+		t.Fatalf("expected tracks ranked by plays, got %v", ids)
+	}
+	view := m.View()
+	if !strings.Contains(view, "Artists / The Beatles / Top Tracks (3)") || !strings.Contains(view, "3 plays") {
+		t.Errorf("expected the top tracks view, got:\n%s", view)
+	}
+
+	// Back goes to the artist list
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.libraryView() != "artists" {
+		t.Errorf("expected to return to artists, got %s", m.libraryView())
+	}
+}
+
+func TestCountPlay(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+	m.nowPlaying = prov.tracks[0]
+
+	pos, dur := 100.0, 259.0
+	m, _ = updateModel(m, playerMsg{TimePos: &pos, Duration: &dur})
+	if m.playCounted {
+		t.Fatal("expected no play before half the track")
+	}
+	m.timePos = 130
+	m, cmd := m.countPlay()
+	if cmd == nil || !m.playCounted || m.nowPlayingStats.Plays != 1 {
+		t.Fatalf("expected a play counted, got %+v", m.nowPlayingStats)
+	}
+	cmd()
+	if _, cmd := m.countPlay(); cmd != nil {
+		t.Error("expected a track to count once")
+	}
+	if st, _ := store.TrackStats(context.Background(), "The Beatles", "Come Together"); st.Plays != 1 {
+		t.Errorf("expected one play stored, got %d", st.Plays)
+	}
+}
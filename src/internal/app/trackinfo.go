@@ -0,0 +1,223 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// trackInfoMsg is the result of fetching full metadata for the info popup.
+type trackInfoMsg struct {
+	track provider.Track
+	err   error
+}
+
+// fetchTrackInfoCmd asks the provider for the full metadata of a track.
+func (m Model) fetchTrackInfoCmd(trackID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		track, err := m.provider.GetTrack(ctx, trackID)
+		return trackInfoMsg{track: track, err: err}
+	}
+}
+
+// infoTarget returns the track the info popup should describe: the selected
+// track in Library/Search/Queue, falling back to the playing track.
+func (m Model) infoTarget() (provider.Track, bool) {
+	if t, ok := m.selectedTrack(); ok {
+		return t, true
+	}
+	if m.screen == screenQueue {
+		items := m.queue.Items()
+		if len(items) > 0 {
+			return items[clamp(m.selection, 0, len(items)-1)], true
+		}
+	}
+	if m.nowPlaying.ID != "" {
+		return m.nowPlaying, true
+	}
+	return provider.Track{}, false
+}
+
+// openTrackInfo shows the info popup for the current target and starts
+// fetching its full metadata.
+func (m Model) openTrackInfo() (Model, tea.Cmd) {
+	t, ok := m.infoTarget()
+	if !ok {
+		m.status = m.msgs.T("status.no_track_selected")
+		return m, nil
+	}
+	m.showTrackInfo = true
+	m.trackInfo = t
+	m.trackInfoLoading = true
+	m.trackInfoBookmarks = nil
+	return m, tea.Batch(m.fetchTrackInfoCmd(t.ID), m.loadBookmarksCmd(t.ID))
+}
+
+// renderTrackInfoOverlay renders the metadata inspector popup.
+func (m Model) renderTrackInfoOverlay() string {
+	t := m.trackInfo
+	label := func(name string) string {
+		return m.theme.Dim.Render(fmt.Sprintf("  %-14s ", name+":"))
+	}
+	var lines []string
+	add := func(name, value string) {
+		if value == "" {
+			return
+		}
+		lines = append(lines, label(name)+m.theme.Text.Render(value))
+	}
+
+	lines = append(lines, m.theme.Accent.Render("Track"))
+	add("Title", t.Title)
+	add("Artist", t.ArtistName)
+	add("Album", t.AlbumTitle)
+	if t.Year > 0 {
+		add("Year", fmt.Sprintf("%d", t.Year))
+	}
+	if t.TrackNo > 0 {
+		add("Track", fmt.Sprintf("%d", t.TrackNo))
+	}
+	if t.DiscNo > 0 {
+		add("Disc", fmt.Sprintf("%d", t.DiscNo))
+	}
+	if t.DurationMs > 0 {
+		add("Duration", formatMs(t.DurationMs))
+	}
+	add("ID", t.ID)
+
+	lines = append(lines, "", m.theme.Accent.Render("Audio"))
+	add("Codec", t.Codec)
+	if t.BitrateKbps > 0 {
+		add("Bitrate", fmt.Sprintf("%d kbps", t.BitrateKbps))
+	}
+	if t.SampleRateHz > 0 {
+		add("Sample rate", formatSampleRate(t.SampleRateHz))
+	}
+	if t.BitDepth > 0 {
+		add("Bit depth", fmt.Sprintf("%d-bit", t.BitDepth))
+	}
+	if t.Channels > 0 {
+		add("Channels", formatChannels(t.Channels))
+	}
+	add("Track gain", t.ReplayGainTrack)
+	add("Album gain", t.ReplayGainAlbum)
+
+	if t.FilePath != "" || t.FileSize > 0 || !t.ModifiedAt.IsZero() || !t.IndexedAt.IsZero() {
+		lines = append(lines, "", m.theme.Accent.Render("File"))
+		add("Path", t.FilePath)
+		if t.FileSize > 0 {
+			add("Size", formatBytes(uint64(t.FileSize)))
+		}
+		if !t.ModifiedAt.IsZero() {
+			add("Modified", m.msgs.Since(t.ModifiedAt, time.Now()))
+		}
+		if !t.IndexedAt.IsZero() {
+			add("Added", m.msgs.Since(t.IndexedAt, time.Now()))
+		}
+	}
+
+	if len(t.Tags) > 0 {
+		lines = append(lines, "", m.theme.Accent.Render("Tags"))
+		keys := make([]string, 0, len(t.Tags))
+		for k := range t.Tags {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			add(k, t.Tags[k])
+		}
+	}
+
+	if len(m.trackInfoBookmarks) > 0 {
+		positions := make([]string, len(m.trackInfoBookmarks))
+		for i, b := range m.trackInfoBookmarks {
+			positions[i] = formatPosition(b.Position)
+		}
+		lines = append(lines, "", m.theme.Accent.Render("Bookmarks"), "  "+m.theme.Text.Render(strings.Join(positions, "  ")))
+	}
+
+	if m.trackInfoLoading {
+		lines = append(lines, "", m.theme.Dim.Render("Loading full metadata…"))
+	}
+	lines = append(lines, "", m.theme.Dim.Render("Press i or Esc to close"))
+
+	// Keep long values (paths, comments) inside the box
+	maxWidth := m.width - 8
+	if maxWidth > 100 {
+		maxWidth = 100
+	}
+	if maxWidth > 20 {
+		for i, line := range lines {
+			lines[i] = ui.Truncate(line, maxWidth)
+		}
+	}
+	// Tag-heavy files can be taller than the terminal
+	if maxLines := m.height - 8; maxLines > 10 && len(lines) > maxLines {
+		hidden := len(lines) - maxLines + 1
+		lines = append(lines[:maxLines-1], m.theme.Dim.Render(fmt.Sprintf("  … %d more", hidden)))
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Left,
+		m.theme.Title.Render("  ═══ Track Info ═══  "),
+		"",
+		strings.Join(lines, "\n"),
+	)
+
+	infoBox := boxStyle.Render(content)
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, infoBox)
+}
+
+// formatChannels describes a channel count in listener terms.
+func formatChannels(n int) string {
+	switch n {
+	case 1:
+		return "1 (mono)"
+	case 2:
+		return "2 (stereo)"
+	case 6:
+		return "6 (5.1)"
+	case 8:
+		return "8 (7.1)"
+	default:
+		return fmt.Sprintf("%d", n)
+	}
+}
+
+// formatSampleRate renders a sample rate in kHz, dropping a trailing ".0".
+func formatSampleRate(hz int) string {
+	if hz%1000 == 0 {
+		return fmt.Sprintf("%d kHz", hz/1000)
+	}
+	return fmt.Sprintf("%.1f kHz", float64(hz)/1000)
+}
+
+// formatAudioFormat summarizes sample rate, bit depth and channels, e.g.
+// "96 kHz / 24-bit / stereo". Returns "" when nothing is known.
+func formatAudioFormat(t provider.Track) string {
+	var parts []string
+	if t.SampleRateHz > 0 {
+		parts = append(parts, formatSampleRate(t.SampleRateHz))
+	}
+	if t.BitDepth > 0 {
+		parts = append(parts, fmt.Sprintf("%d-bit", t.BitDepth))
+	}
+	switch t.Channels {
+	case 0:
+	case 1:
+		parts = append(parts, "mono")
+	case 2:
+		parts = append(parts, "stereo")
+	default:
+		parts = append(parts, fmt.Sprintf("%dch", t.Channels))
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,133 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestTrackInfoPopup(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.width, m.height = 120, 40
+	m.screen = screenLibrary
+	m.tracks = prov.tracks
+	m.selection = 1
+
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if !m.showTrackInfo {
+		t.Fatal("expected track info popup to open")
+	}
+	if m.trackInfo.ID != "101" {
+		t.Errorf("expected selected track 101, got %s", m.trackInfo.ID)
+	}
+	if cmd == nil {
+		t.Error("expected a fetch command")
+	}
+
+	// Full metadata arrives from the provider
+	full := prov.tracks[1]
+	full.FilePath = "/music/The Beatles/Abbey Road/02 - Something.flac"
+	full.SampleRateHz = 44100
+	full.Channels = 2
+	full.ReplayGainTrack = "-6.20 dB"
+	full.IndexedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	full.ModifiedAt = time.Now().Add(-3 * 24 * time.Hour)
+	full.Tags = map[string]string{"GENRE": "Rock"}
+	m, _ = updateModel(m, trackInfoMsg{track: full})
+	if m.trackInfoLoading {
+		t.Error("expected loading to finish")
+	}
+
+	view := m.View()
+	for _, want := range []string{"Track Info", "Something", "44.1 kHz", "2 (stereo)", "-6.20 dB", "GENRE", "Rock", "2025-01-02", "3 days ago"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected view to contain %q", want)
+		}
+	}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showTrackInfo {
+		t.Error("expected esc to close track info popup")
+	}
+}
+
+func TestTrackInfoFallsBackToNowPlaying(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenNowPlaying
+	m.nowPlaying = provider.Track{ID: "np", Title: "Playing"}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if !m.showTrackInfo || m.trackInfo.ID != "np" {
+		t.Errorf("expected popup for now playing track, got show=%v id=%q", m.showTrackInfo, m.trackInfo.ID)
+	}
+
+	// A failed fetch keeps the metadata we already have
+	m, _ = updateModel(m, trackInfoMsg{err: provider.ErrNotFound})
+	if m.trackInfo.Title != "Playing" {
+		t.Errorf("expected existing metadata to be kept, got %q", m.trackInfo.Title)
+	}
+}
+
+func TestTrackInfoKeyTypesInSearch(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenSearch
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if m.showTrackInfo {
+		t.Error("expected i to type into the search query")
+	}
+	if m.searchQ != "i" {
+		t.Errorf("expected search query %q, got %q", "i", m.searchQ)
+	}
+}
+
+func TestFormatAudioFormat(t *testing.T) {
+	tests := []struct {
+		name  string
+		track provider.Track
+		want  string
+	}{
+		{"empty", provider.Track{}, ""},
+		{"cd quality", provider.Track{SampleRateHz: 44100, BitDepth: 16, Channels: 2}, "44.1 kHz / 16-bit / stereo"},
+		{"hi-res", provider.Track{SampleRateHz: 96000, BitDepth: 24, Channels: 2}, "96 kHz / 24-bit / stereo"},
+		{"lossy mono", provider.Track{SampleRateHz: 48000, Channels: 1}, "48 kHz / mono"},
+		{"surround", provider.Track{SampleRateHz: 48000, Channels: 6}, "48 kHz / 6ch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatAudioFormat(tt.track); got != tt.want {
+				t.Errorf("formatAudioFormat() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackInfoKeyLeftToOverlays(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenNowPlaying
+	m.nowPlaying = provider.Track{ID: "np", Title: "Playing"}
+	m.showProfileSwitcher = true
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}})
+	if m.showTrackInfo {
+		t.Error("expected i not to open track info over the profile switcher")
+	}
+
+	// Rebound, the old key does nothing and the new one opens it
+	m.showProfileSwitcher = false
+	m.cfg.Keybindings.TrackInfo = "ctrl+t"
+	if m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'i'}}); m.showTrackInfo {
+		t.Error("expected i unbound")
+	}
+	if m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyCtrlT}); !m.showTrackInfo {
+		t.Error("expected the configured key to open track info")
+	}
+}
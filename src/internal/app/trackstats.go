@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// trackStatsMsg carries the play count and loved mark of a track from the
+// local stats store.
+type trackStatsMsg struct {
+	trackID string
+	stats   queue.TrackStats
+	err     error
+}
+
+// loadTrackStatsCmd looks up a track's stats by artist and title.
+func (m Model) loadTrackStatsCmd(t provider.Track) tea.Cmd {
+	if m.queueStore == nil || t.ID == "" {
+		return nil
+	}
+	store := m.queueStore
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		st, err := store.TrackStats(ctx, t.ArtistName, t.Title)
+		return trackStatsMsg{trackID: t.ID, stats: st, err: err}
+	}
+}
+
+// handleTrackStats shows the playing track's play count and its heart when
+// it was loved before, e.g. on Last.fm.
+func (m Model) handleTrackStats(msg trackStatsMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.logger.Warn("load track stats", slog.String("track_id", msg.trackID), slog.Any("err", msg.err))
+		return m, nil
+	}
+	if msg.trackID != m.nowPlaying.ID {
+		return m, nil
+	}
+	if loved, set := m.loved[msg.trackID]; set {
+		// Loved or unloved before the stats came in
+		msg.stats.Loved = loved
+	}
+	m.nowPlayingStats = msg.stats
+	m.setLoved(m.nowPlaying, msg.stats.Loved)
+	return m, nil
+}
+
+// saveLovedCmd keeps a love or unlove in the local stats store, and in
+// the play history weighting the shuffle.
+func (m Model) saveLovedCmd(t provider.Track, loved bool) tea.Cmd {
+	if m.playHistory != nil {
+		m.playHistory.SetLoved(t.ArtistName, t.Title, loved)
+	}
+	if m.queueStore == nil {
+		return nil
+	}
+	store, logger := m.queueStore, m.logger
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := store.SetLoved(ctx, t.ArtistName, t.Title, loved); err != nil {
+			logger.Warn("save loved mark", slog.String("track_id", t.ID), slog.Any("err", err))
+		}
+		return nil
+	}
+}
+
+// playCountSeconds and playCountFraction set when a play counts, as for a
+// Last.fm scrobble: half the track or four minutes, whichever comes first.
+// Tracks under playCountMinSeconds never count.
+const (
+	playCountSeconds    = 240
+	playCountFraction   = 0.5
+	playCountMinSeconds = 30
+)
+
+// countPlay records a play of the playing track once it has played long
+// enough.
+func (m Model) countPlay() (Model, tea.Cmd) {
+	if m.queueStore == nil || m.playCounted || m.nowPlaying.ID == "" || m.duration < playCountMinSeconds {
+		return m, nil
+	}
+	if m.timePos < min(m.duration*playCountFraction, playCountSeconds) {
+		return m, nil
+	}
+	m.playCounted = true
+	m.nowPlayingStats.Plays++
+	if m.skip.playedToday {
+		if m.lastPlays == nil {
+			m.lastPlays = queue.LastPlays{}
+		}
+		m.lastPlays.Add(m.nowPlaying.ArtistName, m.nowPlaying.Title, time.Now())
+	}
+	if m.playHistory != nil {
+		m.playHistory.AddPlay(m.nowPlaying.ArtistName, m.nowPlaying.Title, time.Now())
+	}
+	store, logger, t := m.queueStore, m.logger, m.nowPlaying
+	return m, func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := store.RecordPlay(ctx, t.ArtistName, t.Title); err != nil {
+			logger.Warn("record play", slog.String("track_id", t.ID), slog.Any("err", err))
+		}
+		return nil
+	}
+}
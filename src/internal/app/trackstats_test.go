@@ -0,0 +1,54 @@
+package app
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/queue"
+)
+
+func TestTrackStatsSeedHeart(t *testing.T) {
+	prov := newTestProvider()
+	m := createTestModel(t)
+	m = initializeModel(m, prov)
+	store, err := queue.NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	m.queueStore = store
+
+	// Imported from Last.fm with different casing
+	if err := store.ImportStats(context.Background(), []queue.TrackStats{
+		{Artist: "the beatles", Title: "COME TOGETHER", Plays: 42, Loved: true},
+	}); err != nil {
+		t.Fatalf("ImportStats: %v", err)
+	}
+
+	track := prov.tracks[0]
+	m.nowPlaying = track
+	m, _ = updateModel(m, m.loadTrackStatsCmd(track)())
+	if m.nowPlayingStats.Plays != 42 || !m.loved[track.ID] {
+		t.Fatalf("expected 42 plays and a heart, got %+v loved=%v", m.nowPlayingStats, m.loved[track.ID])
+	}
+
+	// Unloving in tunez is kept locally
+	m, cmd := updateModel(m, loveMsg{track: track, love: false, accepted: 1})
+	if cmd == nil {
+		t.Fatal("expected a command saving the loved mark")
+	}
+	cmd()
+	st, err := store.TrackStats(context.Background(), track.ArtistName, track.Title)
+	if err != nil || st.Loved || st.Plays != 42 {
+		t.Errorf("expected unloved with plays kept, got %+v %v", st, err)
+	}
+
+	// Stats for a track that is no longer playing are dropped
+	m.nowPlaying = prov.tracks[1]
+	m.nowPlayingStats = queue.TrackStats{}
+	m, _ = updateModel(m, trackStatsMsg{trackID: track.ID, stats: st})
+	if m.nowPlayingStats.Plays != 0 {
+		t.Error("expected stale stats to be ignored")
+	}
+}
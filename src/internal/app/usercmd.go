@@ -0,0 +1,229 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/queue"
+)
+
+// macroStep is one built-in command run by a user-defined command.
+type macroStep struct {
+	cmd Command
+	arg string
+}
+
+// macroStepMsg runs the next step of a user-defined command once the
+// previous step's command has delivered its result.
+type macroStepMsg struct {
+	name  string
+	steps []macroStep
+	next  int
+}
+
+// registerUserCommands adds the [[commands]] of the config to the palette.
+// A command with an unknown or malformed step is left out and logged.
+func (r *CommandRegistry) registerUserCommands(m *Model) {
+	for _, def := range m.cfg.Commands {
+		steps, err := r.parseSteps(def.Steps)
+		if err != nil {
+			if m.logger != nil {
+				m.logger.Warn("skipping user command", slog.String("name", def.Name), slog.Any("err", err))
+			}
+			continue
+		}
+		name := def.Name
+		desc := def.Description
+		if desc == "" {
+			desc = fmt.Sprintf("Runs %d commands", len(steps))
+		}
+		r.register(Command{
+			ID:          "user." + strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), " ", "_"),
+			Name:        name,
+			Description: desc,
+			Category:    "Custom",
+			Handler: func(m *Model) (Model, tea.Cmd) {
+				return m.runMacroStep(macroStepMsg{name: name, steps: steps})
+			},
+		})
+	}
+}
+
+// parseSteps resolves "<command id> [argument]" steps against the commands
+// registered so far.
+func (r *CommandRegistry) parseSteps(defs []string) ([]macroStep, error) {
+	steps := make([]macroStep, 0, len(defs))
+	for _, def := range defs {
+		id, arg, _ := strings.Cut(strings.TrimSpace(def), " ")
+		arg = strings.TrimSpace(arg)
+		found, ok := r.Command(id)
+		switch {
+		case !ok:
+			return nil, fmt.Errorf("unknown command %q", id)
+		case found.RunArg != nil && arg == "":
+			return nil, fmt.Errorf("%s needs an argument <%s>", id, found.Arg)
+		case found.RunArg == nil && arg != "":
+			return nil, fmt.Errorf("%s takes no argument", id)
+		}
+		steps = append(steps, macroStep{cmd: found, arg: arg})
+	}
+	return steps, nil
+}
+
+// runMacroStep runs steps of a user-defined command in order. A step that
+// returns a command is run to completion, and its result handled, before
+// the next step starts; a step that fails stops the rest.
+func (m Model) runMacroStep(msg macroStepMsg) (Model, tea.Cmd) {
+	if msg.next == 0 {
+		m.errorMsg = ""
+	}
+	for {
+		// An error left by the previous step's result stops the rest
+		if msg.next > 0 && m.errorMsg != "" {
+			m.status = m.msgs.T("commands.stopped", msg.name, msg.steps[msg.next-1].cmd.Name)
+			return m, nil
+		}
+		if msg.next == len(msg.steps) {
+			m.status = m.msgs.T("status.ran", msg.name)
+			return m, nil
+		}
+		step := msg.steps[msg.next]
+		msg.next++
+		m.logger.Debug("user command step", slog.String("command", msg.name), slog.String("step", step.cmd.ID), slog.String("arg", step.arg))
+
+		var cmd tea.Cmd
+		if step.cmd.RunArg != nil {
+			m, cmd = step.cmd.RunArg(&m, step.arg)
+		} else {
+			m, cmd = step.cmd.Handler(&m)
+		}
+		var waiting bool
+		if m, cmd, waiting = m.settleMacroPrompt(cmd, msg); waiting {
+			return m, cmd
+		}
+		switch {
+		case m.errorMsg != "":
+			m.status = m.msgs.T("commands.stopped", msg.name, step.cmd.Name)
+			return m, cmd
+		case cmd != nil:
+			next := msg
+			return m, tea.Sequence(cmd, func() tea.Msg { return next })
+		}
+	}
+}
+
+// settleMacroPrompt deals with a prompt a step of a user-defined command
+// raised. With ui.no_confirm on it is answered yes, unless it is strict;
+// otherwise the command waits at it, reporting true, and goes on with next
+// once the user says yes.
+func (m Model) settleMacroPrompt(cmd tea.Cmd, next macroStepMsg) (Model, tea.Cmd, bool) {
+	for m.confirmPrompt != nil {
+		p := m.confirmPrompt
+		if !m.cfg.UI.NoConfirm || p.strict {
+			m.logger.Debug("user command waiting for confirmation", slog.String("command", next.name), slog.String("title", p.title))
+			resume := *p
+			resume.run = func(m Model) (Model, tea.Cmd) {
+				m, cmd := p.run(m)
+				m, cmd, waiting := m.settleMacroPrompt(cmd, next)
+				switch {
+				case waiting:
+					return m, cmd
+				case cmd != nil:
+					return m, tea.Sequence(cmd, func() tea.Msg { return next })
+				}
+				return m.runMacroStep(next)
+			}
+			m.confirmPrompt = &resume
+			return m, cmd, true
+		}
+		m.confirmPrompt = nil
+		m, cmd = p.run(m)
+	}
+	return m, cmd, false
+}
+
+// play resumes playback, or starts the queue when nothing is playing.
+func (m Model) play() (Model, tea.Cmd) {
+	if m.nowPlaying.ID == "" {
+		if m.queue.Len() == 0 {
+			m.status = m.msgs.T("status.queue_is_empty")
+			return m, nil
+		}
+		return m, m.playQueueTrackCmd(max(m.queue.CurrentIndex(), 0))
+	}
+	if !m.paused {
+		return m, nil
+	}
+	m.paused = false
+	return m, func() tea.Msg {
+		if err := m.player.FadePause(false); err != nil {
+			return playerMsg{Err: err}
+		}
+		return nil
+	}
+}
+
+// setShuffle turns shuffle on or off regardless of its current state.
+func (m Model) setShuffle(arg string) (Model, tea.Cmd) {
+	var on bool
+	switch strings.ToLower(arg) {
+	case "on", "true", "yes":
+		on = true
+	case "off", "false", "no":
+	default:
+		return m.setError(fmt.Errorf("shuffle must be on or off, not %q", arg))
+	}
+	if on != m.queue.IsShuffled() {
+		m.queue.ToggleShuffle()
+	}
+	return m, m.saveQueueCmd()
+}
+
+// playPlaylistByNameCmd finds a playlist by name, preferring an exact match,
+// and loads its tracks to replace the queue.
+func (m Model) playPlaylistByNameCmd(name string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		p, err := m.findPlaylist(ctx, name)
+		if err != nil {
+			return contextTracksMsg{err: err}
+		}
+		return m.playContextCmd(queue.ListeningContext{Kind: queue.ContextPlaylist, ID: p.ID, Title: p.Name})()
+	}
+}
+
+// findPlaylist returns the playlist called name, ignoring case, or else the
+// first one whose name contains it.
+func (m Model) findPlaylist(ctx context.Context, name string) (provider.Playlist, error) {
+	q := strings.ToLower(strings.TrimSpace(name))
+	var partial *provider.Playlist
+	cursor := ""
+	for {
+		page, err := m.provider.ListPlaylists(ctx, provider.ListReq{PageSize: m.cfg.UI.PageSize, Cursor: cursor})
+		if err != nil {
+			return provider.Playlist{}, err
+		}
+		for _, p := range page.Items {
+			if strings.ToLower(p.Name) == q {
+				return p, nil
+			}
+			if partial == nil && strings.Contains(strings.ToLower(p.Name), q) {
+				partial = &p
+			}
+		}
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			break
+		}
+		cursor = page.NextCursor
+	}
+	if partial == nil {
+		return provider.Playlist{}, fmt.Errorf("no playlist named %q", name)
+	}
+	return *partial, nil
+}
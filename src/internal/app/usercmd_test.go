@@ -0,0 +1,136 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+)
+
+func TestUserCommands(t *testing.T) {
+	m := createTestModel(t)
+	prov := newTestProvider()
+	m = initializeModel(m, prov)
+	m.cfg.Commands = []config.UserCommand{
+		{Name: "Evening chill", Steps: []string{"queue.clear", "playback.set_shuffle on", "playback.set_volume 40"}},
+		{Name: "Broken", Steps: []string{"playback.set_volume"}},
+		{Name: "Typo", Steps: []string{"queue.clearr"}},
+	}
+	registry := NewCommandRegistry(&m)
+
+	var chill *Command
+	for _, cmd := range registry.Commands() {
+		switch cmd.Name {
+		case "Evening chill":
+			chill = &cmd
+		case "Broken", "Typo":
+			t.Errorf("expected invalid command %q to be skipped", cmd.Name)
+		}
+	}
+	if chill == nil || chill.Category != "Custom" || chill.ID != "user.evening_chill" {
+		t.Fatalf("expected Evening chill to be registered, got %+v", chill)
+	}
+
+	m.queue.Add(prov.tracks...)
+	m, cmd := chill.Handler(&m)
+	// Clearing asks first, and the command waits for the answer
+	if m.confirmPrompt == nil || m.queue.Len() != 3 || cmd != nil {
+		t.Fatalf("expected the command waiting at the clear prompt, got %+v", m.confirmPrompt)
+	}
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	// Clearing saves the queue, so the shuffle step waits for that to finish
+	if m.queue.Len() != 0 || m.queue.IsShuffled() || cmd == nil {
+		t.Fatalf("expected queue cleared and shuffle waiting, got len=%d shuffled=%v", m.queue.Len(), m.queue.IsShuffled())
+	}
+	steps, _ := registry.parseSteps(m.cfg.Commands[0].Steps)
+	m, _ = updateModel(m, macroStepMsg{name: "Evening chill", steps: steps, next: 1})
+	if !m.queue.IsShuffled() {
+		t.Fatal("expected shuffle on")
+	}
+	if m.volume == 40 {
+		t.Fatal("expected the volume step to wait for the previous command")
+	}
+	m, _ = updateModel(m, macroStepMsg{name: "Evening chill", steps: steps, next: 2})
+	if m.volume != 40 {
+		t.Errorf("expected volume 40, got %v", m.volume)
+	}
+	m, _ = updateModel(m, macroStepMsg{name: "Evening chill", steps: steps, next: 3})
+	if m.status != "Ran Evening chill" {
+		t.Errorf("expected completion status, got %q", m.status)
+	}
+}
+
+func TestUserCommandStopsOnError(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	registry := NewCommandRegistry(&m)
+	steps, err := registry.parseSteps([]string{"playback.set_shuffle sideways", "playback.set_volume 10"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ = m.runMacroStep(macroStepMsg{name: "Bad", steps: steps})
+	if m.volume == 10 || !strings.Contains(m.status, "Bad stopped at Set Shuffle") {
+		t.Errorf("expected the command to stop at the failing step, got volume=%v status=%q", m.volume, m.status)
+	}
+}
+
+func TestUserCommandWaitsForDelete(t *testing.T) {
+	prov := &deletingProvider{testProvider: newTestProvider()}
+	m := initializeModel(createTestModel(t), prov.testProvider)
+	m.provider = prov
+	m.cfg.Library.AllowDelete = true
+	m.screen = screenLibrary
+	m.tracks = prov.tracks
+	m.selection = 1
+	registry := NewCommandRegistry(&m)
+	steps, err := registry.parseSteps([]string{"library.delete", "playback.set_volume 30"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := func(k string) tea.KeyMsg {
+		return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+	}
+
+	for _, noConfirm := range []bool{false, true} {
+		m.cfg.UI.NoConfirm = noConfirm
+		m.volume = 100
+		m, _ = m.runMacroStep(macroStepMsg{name: "Tidy", steps: steps})
+		if !noConfirm {
+			// Without ui.no_confirm the first prompt is asked too
+			if m.confirmPrompt == nil || m.confirmPrompt.title != "Delete File" || m.volume == 30 {
+				t.Fatalf("expected the command waiting at the first prompt, got %+v", m.confirmPrompt)
+			}
+			m, _ = updateModel(m, key("y"))
+		}
+		// The strict prompt is always asked, and the rest waits for it
+		if m.confirmPrompt == nil || m.confirmPrompt.title != "Really Delete?" || m.volume == 30 {
+			t.Fatalf("no_confirm=%v: expected the command waiting at the second prompt, got %+v", noConfirm, m.confirmPrompt)
+		}
+		if len(prov.deleted) != 0 {
+			t.Fatal("expected nothing deleted before the answer")
+		}
+		m, _ = updateModel(m, key("n"))
+		if m.confirmPrompt != nil || m.volume == 30 {
+			t.Fatalf("no_confirm=%v: expected n to stop the command", noConfirm)
+		}
+	}
+
+	// A yes deletes the file, then runs the rest once it's done
+	m, _ = m.runMacroStep(macroStepMsg{name: "Tidy", steps: steps})
+	target := m.tracks[m.selection]
+	m, cmd := updateModel(m, key("y"))
+	if cmd == nil || m.volume == 30 {
+		t.Fatal("expected the next step to wait for the delete")
+	}
+	// The sequence deletes the file before it sends the next step
+	m, _ = updateModel(m, m.deleteTrackCmd(target)())
+	if len(prov.deleted) != 1 {
+		t.Fatalf("expected the file deleted, got %v", prov.deleted)
+	}
+	m, _ = updateModel(m, macroStepMsg{name: "Tidy", steps: steps, next: 1})
+	m, _ = updateModel(m, macroStepMsg{name: "Tidy", steps: steps, next: 2})
+	if m.volume != 30 || m.status != "Ran Tidy" {
+		t.Errorf("expected the command finished, got volume %v status %q", m.volume, m.status)
+	}
+}
@@ -0,0 +1,237 @@
+package app
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// versionLengthSlack is how far apart two tracks' lengths can be and still
+// be versions of the same song; remasters often gain or lose a few
+// seconds of silence.
+const versionLengthSlack = 10_000 // ms
+
+// versionSuffix matches the part of a title naming a remaster, such as
+// " (2011 Remaster)" or " - Remastered 2009", which versions differ by.
+var versionSuffix = regexp.MustCompile(`(?i)\s*(\([^)]*(remaster|explicit)[^)]*\)|\[[^\]]*(remaster|explicit)[^\]]*\]|-\s*[^-]*remaster.*)$`)
+
+// versionKey is what versions of a song have in common: its artist and
+// its title without a remaster suffix, ignoring case.
+func versionKey(t provider.Track) string {
+	title := versionSuffix.ReplaceAllString(t.Title, "")
+	return strings.ToLower(strings.TrimSpace(t.ArtistName)) + "\x00" + strings.ToLower(strings.TrimSpace(title))
+}
+
+// sameLength reports whether two tracks are close enough in length to be
+// versions of the same song. Unknown lengths match anything.
+func sameLength(a, b provider.Track) bool {
+	return a.DurationMs == 0 || b.DurationMs == 0 || abs(a.DurationMs-b.DurationMs) <= versionLengthSlack
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// losslessCodecs are the codecs ranked above any lossy one.
+var losslessCodecs = []string{"flac", "alac", "wav", "aiff", "ape", "wavpack", "wv", "tta", "dsd", "dsf"}
+
+func isLossless(t provider.Track) bool {
+	codec := strings.ToLower(t.Codec)
+	return slices.Contains(losslessCodecs, codec) || strings.HasPrefix(codec, "pcm")
+}
+
+// compareVersions orders versions of a song, preferred first, by the
+// [ui] versions preference: "lossless" puts lossless files first, then
+// higher bit depths, sample rates and bitrates; "bitrate" puts the highest
+// bitrate first; "compact" the lowest known bitrate.
+func compareVersions(prefer string) func(a, b provider.Track) int {
+	lossless := func(t provider.Track) int {
+		if isLossless(t) {
+			return 1
+		}
+		return 0
+	}
+	switch prefer {
+	case "bitrate":
+		return func(a, b provider.Track) int {
+			return cmp.Or(cmp.Compare(b.BitrateKbps, a.BitrateKbps), cmp.Compare(lossless(b), lossless(a)))
+		}
+	case "compact":
+		return func(a, b provider.Track) int {
+			known := func(t provider.Track) int {
+				if t.BitrateKbps > 0 {
+					return 1
+				}
+				return 0
+			}
+			return cmp.Or(cmp.Compare(known(b), known(a)), cmp.Compare(a.BitrateKbps, b.BitrateKbps))
+		}
+	}
+	return func(a, b provider.Track) int {
+		return cmp.Or(cmp.Compare(lossless(b), lossless(a)), cmp.Compare(b.BitDepth, a.BitDepth),
+			cmp.Compare(b.SampleRateHz, a.SampleRateHz), cmp.Compare(b.BitrateKbps, a.BitrateKbps))
+	}
+}
+
+// versionList folds the versions of each song in a track list, from any
+// provider, into one row showing the preferred version. The other versions
+// can be expanded beneath it.
+type versionList struct {
+	others map[string][]provider.Track // a row's other versions, preferred first
+	parent map[string]string           // an expanded version's ID → its row's
+}
+
+// add appends tracks to list, folding each into the row of another
+// version of it already there. With "off" nothing is folded.
+func (v *versionList) add(list, tracks []provider.Track, prefer string) []provider.Track {
+	if prefer == "off" {
+		return append(list, tracks...)
+	}
+	if v.others == nil {
+		v.others, v.parent = map[string][]provider.Track{}, map[string]string{}
+	}
+	// Rows with their versions expanded aren't folded into
+	rows := map[string][]int{}
+	for i, t := range list {
+		if v.parent[t.ID] == "" && !v.expanded(t) {
+			rows[versionKey(t)] = append(rows[versionKey(t)], i)
+		}
+	}
+	out := slices.Clone(list)
+	for _, t := range tracks {
+		key := versionKey(t)
+		i := slices.IndexFunc(rows[key], func(i int) bool { return sameLength(out[i], t) })
+		if i < 0 {
+			rows[key] = append(rows[key], len(out))
+			out = append(out, t)
+			continue
+		}
+		i = rows[key][i]
+		row := out[i]
+		group := append([]provider.Track{row}, v.others[row.ID]...)
+		group = append(group, t)
+		slices.SortStableFunc(group, compareVersions(prefer))
+		delete(v.others, row.ID)
+		v.others[group[0].ID] = group[1:]
+		out[i] = group[0]
+	}
+	return out
+}
+
+// clone copies v, so toggling versions in one copy leaves the other be.
+func (v versionList) clone() versionList {
+	return versionList{others: maps.Clone(v.others), parent: maps.Clone(v.parent)}
+}
+
+// count returns how many versions a row stands for, or 0 when it is an
+// expanded version itself.
+func (v versionList) count(t provider.Track) int {
+	if v.parent[t.ID] != "" {
+		return 0
+	}
+	return len(v.others[t.ID]) + 1
+}
+
+// expanded reports whether a row's versions are listed beneath it.
+func (v versionList) expanded(t provider.Track) bool {
+	others := v.others[t.ID]
+	return len(others) > 0 && v.parent[others[0].ID] == t.ID
+}
+
+// toggle expands the versions of list[i], or collapses them when they, or
+// list[i] as one of them, are shown. It returns the list and the index of
+// the song's row.
+func (v versionList) toggle(list []provider.Track, i int) ([]provider.Track, int, bool) {
+	if parent := v.parent[list[i].ID]; parent != "" {
+		i = slices.IndexFunc(list, func(t provider.Track) bool { return t.ID == parent })
+		if i < 0 {
+			return list, 0, false
+		}
+	}
+	row := list[i]
+	others := v.others[row.ID]
+	if len(others) == 0 {
+		return list, i, false
+	}
+	if v.expanded(row) {
+		for _, t := range others {
+			delete(v.parent, t.ID)
+		}
+		return slices.Delete(slices.Clone(list), i+1, i+1+len(others)), i, true
+	}
+	for _, t := range others {
+		v.parent[t.ID] = row.ID
+	}
+	return slices.Insert(slices.Clone(list), i+1, others...), i, true
+}
+
+// rows drops expanded versions from list, leaving one row per song.
+func (v versionList) rows(list []provider.Track) []provider.Track {
+	return visible(list, func(t provider.Track) bool { return v.parent[t.ID] != "" })
+}
+
+// versionLabel tells versions of a song apart: their format and quality,
+// and the album they're from.
+func versionLabel(t provider.Track) string {
+	format := strings.ToUpper(t.Codec)
+	if format == "" {
+		format = "?"
+	}
+	switch {
+	case isLossless(t) && t.BitDepth > 0 && t.SampleRateHz > 0:
+		format += fmt.Sprintf(" %d/%g", t.BitDepth, float64(t.SampleRateHz)/1000)
+	case t.BitrateKbps > 0:
+		format += fmt.Sprintf(" %dk", t.BitrateKbps)
+	}
+	album := t.AlbumTitle
+	if t.Year > 0 {
+		album += fmt.Sprintf(" (%d)", t.Year)
+	}
+	return format + " · " + album
+}
+
+// versionRow renders an expanded version beneath its song's row.
+func (m Model) versionRow(prefix string, t provider.Track) string {
+	return fmt.Sprintf("%s      ↳ %s%s", prefix, versionLabel(t), m.providerBadge(t.ID))
+}
+
+// versionBadge marks a row standing for several versions of a song.
+func (m Model) versionBadge(v versionList, t provider.Track) string {
+	n := v.count(t)
+	if n < 2 {
+		return ""
+	}
+	mark := "▸"
+	if v.expanded(t) {
+		mark = "▾"
+	}
+	return m.theme.Dim.Render(fmt.Sprintf("  %s %d versions", mark, n))
+}
+
+// toggleVersions expands or collapses the versions of the selected Search
+// or Library track.
+func (m Model) toggleVersions() (Model, bool) {
+	var ok bool
+	switch {
+	case m.screen == screenSearch && m.searchFilter == filterTracks && len(m.searchResults.Tracks.Items) > 0:
+		i := clamp(m.selection, 0, len(m.searchResults.Tracks.Items)-1)
+		m.searchResults.Tracks.Items, m.selection, ok = m.searchVersions.toggle(m.searchResults.Tracks.Items, i)
+	case m.screen == screenLibrary && len(m.tracks) > 0:
+		i := clamp(m.selection, 0, len(m.tracks)-1)
+		m.tracks, m.selection, ok = m.trackVersions.toggle(m.tracks, i)
+	default:
+		return m, false
+	}
+	if !ok {
+		m.status = m.msgs.T("versions.none")
+	}
+	return m, true
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestVersionsFolded(t *testing.T) {
+	m := initializeModel(createTestModel(t), newTestProvider())
+	m.screen = screenSearch
+	m.searchQ = "numb"
+	versions := []provider.Track{
+		{ID: "mp3", Title: "Comfortably Numb", ArtistName: "Pink Floyd", AlbumTitle: "The Wall", DurationMs: 382000, Codec: "mp3", BitrateKbps: 320},
+		{ID: "live", Title: "Comfortably Numb (Live)", ArtistName: "Pink Floyd", AlbumTitle: "Pulse", DurationMs: 563000, Codec: "flac"},
+		{ID: "flac", Title: "Comfortably Numb (2011 Remaster)", ArtistName: "pink floyd", AlbumTitle: "The Wall", DurationMs: 384000, Codec: "flac", BitDepth: 24, SampleRateHz: 96000},
+		{ID: "other", Title: "Comfortably Numb", ArtistName: "Scissor Sisters", DurationMs: 265000, Codec: "mp3"},
+		{ID: "home:aac", Title: "Comfortably Numb", ArtistName: "Pink Floyd", AlbumTitle: "Echoes", DurationMs: 380000, Codec: "aac", BitrateKbps: 256},
+	}
+	m, _ = updateModel(m, searchMsg{query: "numb", kind: provider.SearchTracks, res: provider.SearchResults{
+		Tracks: provider.Page[provider.Track]{Items: versions},
+	}})
+
+	// The 24-bit remaster stands for the studio versions; the live take and
+	// the cover are songs of their own
+	var ids []string
+	for _, tr := range m.searchResults.Tracks.Items {
+		ids = append(ids, tr.ID)
+	}
+	if strings.Join(ids, ",") != "flac,live,other" {
+		t.Fatalf("expected the versions folded, got %v", ids)
+	}
+	if !strings.Contains(m.renderSearch(100, 20), "▸ 3 versions") {
+		t.Error("expected the folded row marked")
+	}
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	ids = ids[:0]
+	for _, tr := range m.searchResults.Tracks.Items {
+		ids = append(ids, tr.ID)
+	}
+	if strings.Join(ids, ",") != "flac,mp3,home:aac,live,other" {
+		t.Fatalf("expected the other versions beneath the row, best first, got %v", ids)
+	}
+	view := m.renderSearch(100, 20)
+	if !strings.Contains(view, "▾ 3 versions") || !strings.Contains(view, "↳ MP3 320k · The Wall") {
+		t.Errorf("expected the versions listed, got\n%s", view)
+	}
+
+	// Collapsing from a version goes back to the song's row
+	m.selection = 2
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if len(m.searchResults.Tracks.Items) != 3 || m.selection != 0 {
+		t.Errorf("expected the versions collapsed, got %d rows, selection %d", len(m.searchResults.Tracks.Items), m.selection)
+	}
+
+	// A later page folds into the rows already there
+	m, _ = updateModel(m, searchMoreMsg{res: provider.SearchResults{Tracks: provider.Page[provider.Track]{Items: []provider.Track{
+		{ID: "hires", Title: "Comfortably Numb", ArtistName: "Pink Floyd", DurationMs: 383000, Codec: "flac", BitDepth: 24, SampleRateHz: 192000},
+	}}}})
+	if items := m.searchResults.Tracks.Items; len(items) != 3 || items[0].ID != "hires" || m.searchVersions.count(items[0]) != 4 {
+		t.Errorf("expected the better version to take the row, got %+v", items)
+	}
+}
+
+func TestVersionPreferences(t *testing.T) {
+	tracks := []provider.Track{
+		{ID: "flac", Title: "Song", Codec: "FLAC", BitrateKbps: 900},
+		{ID: "mp3", Title: "Song", Codec: "mp3", BitrateKbps: 320},
+		{ID: "opus", Title: "Song", Codec: "opus", BitrateKbps: 96},
+		{ID: "unknown", Title: "Song", Codec: "mp3"},
+	}
+	for prefer, want := range map[string]string{"lossless": "flac", "bitrate": "flac", "compact": "opus"} {
+		var v versionList
+		if got := v.add(nil, tracks, prefer); len(got) != 1 || got[0].ID != want {
+			t.Errorf("%s: expected %s, got %+v", prefer, want, got)
+		}
+	}
+	var v versionList
+	if got := v.add(nil, tracks, "off"); len(got) != 4 {
+		t.Errorf("expected every version listed with off, got %d", len(got))
+	}
+}
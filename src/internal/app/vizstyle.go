@@ -0,0 +1,32 @@
+package app
+
+import (
+	"log/slog"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/config"
+)
+
+// cycleVisualizerStyle moves the Now Playing visualizer to its next style
+// and saves the choice to the config file.
+func (m Model) cycleVisualizerStyle() (Model, tea.Cmd) {
+	m.vizStyle = m.visualizer.NextStyle(m.vizStyle)
+	m.cfg.Visualizer.Style = m.vizStyle
+	m.status = m.msgs.T("viz.style", m.vizStyle)
+	m.logger.Debug("visualizer style changed", slog.String("style", m.vizStyle))
+	return m, m.saveVisualizerStyleCmd(m.vizStyle)
+}
+
+// saveVisualizerStyleCmd writes style to the [visualizer] config table.
+func (m Model) saveVisualizerStyleCmd(style string) tea.Cmd {
+	path := m.cfg.Path
+	if path == "" {
+		return nil
+	}
+	return func() tea.Msg {
+		if err := config.SetValue(path, "visualizer", "style", style); err != nil {
+			m.logger.Warn("save visualizer style", slog.String("style", style), slog.Any("err", err))
+		}
+		return nil
+	}
+}
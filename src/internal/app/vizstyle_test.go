@@ -0,0 +1,53 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/visualizer"
+)
+
+func TestVisualizerStyleCycle(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.visualizer = visualizer.New(visualizer.Config{Backend: visualizer.BackendCava})
+	m.vizStyle = visualizer.StyleBars
+	m.screen = screenNowPlaying
+	m.cfg.Path = filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(m.cfg.Path, []byte("[ui]\npage_size = 100\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	v := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'v'}}
+	m, cmd := updateModel(m, v)
+	if m.vizStyle != visualizer.StyleMirrored || m.status != "Visualizer: mirrored" {
+		t.Fatalf("expected mirrored, got %q (%q)", m.vizStyle, m.status)
+	}
+	if cmd == nil {
+		t.Fatal("expected the style to be saved")
+	}
+	cmd()
+	data, err := os.ReadFile(m.cfg.Path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "[visualizer]\nstyle = \"mirrored\"") {
+		t.Errorf("expected style saved to config, got:\n%s", data)
+	}
+
+	// cava has no samples for a waveform, so it is skipped
+	m, _ = updateModel(m, v)
+	if m.vizStyle != visualizer.StyleVU {
+		t.Errorf("expected vu after mirrored, got %q", m.vizStyle)
+	}
+
+	// The key only cycles on Now Playing
+	m.screen = screenQueue
+	m, _ = updateModel(m, v)
+	if m.vizStyle != visualizer.StyleVU {
+		t.Errorf("expected the style unchanged off Now Playing, got %q", m.vizStyle)
+	}
+}
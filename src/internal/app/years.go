@@ -0,0 +1,69 @@
+package app
+
+import (
+	"log/slog"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// firstDecadeChip is the oldest decade offered by the Library year chips.
+const firstDecadeChip = 1950
+
+// yearChips lists the Library year filters y and Y step through: every
+// decade from the 1950s to the current one, then back to all years.
+func yearChips() []provider.YearRange {
+	chips := []provider.YearRange{{}}
+	for d := firstDecadeChip; d <= time.Now().Year(); d += 10 {
+		chips = append(chips, provider.Decade(d))
+	}
+	return chips
+}
+
+// cycleYears moves the Library year filter dir chips forward or back.
+func (m Model) cycleYears(dir int) (Model, tea.Cmd) {
+	chips := yearChips()
+	next := 0
+	for i, c := range chips {
+		if c == m.yearFilter {
+			next = (i + dir + len(chips)) % len(chips)
+		}
+	}
+	return m.setYearFilter(chips[next])
+}
+
+// setYearFilter limits album and track lists to r and reloads the Library
+// list on screen. From the artists list it opens every album in the range.
+func (m Model) setYearFilter(r provider.YearRange) (Model, tea.Cmd) {
+	m.yearFilter = r
+	m.selection = 0
+	m.status = m.msgs.T("years.showing", r)
+	m.logger.Debug("library years changed", slog.String("years", r.String()))
+
+	switch m.libraryView() {
+	case "tracks":
+		if m.currentAlbumID != "" || m.topTracksOf != "" || m.currentPlaylistID != "" {
+			return m, nil
+		}
+		m.tracksCursor = ""
+		return m, m.loadTracksCmd(m.currentArtistID, "", "")
+	case "albums":
+		m.albumsCursor = ""
+		return m, m.loadAlbumsCmd(m.currentArtistID, "")
+	}
+	if r.IsZero() {
+		return m, nil
+	}
+	m.currentArtistID = ""
+	m.albumsCursor = ""
+	return m, m.loadAlbumsCmd("", "")
+}
+
+// yearsHeader shows the active year filter chip in the Library header.
+func (m Model) yearsHeader() string {
+	if m.yearFilter.IsZero() {
+		return ""
+	}
+	return m.theme.Dim.Render("  Years: ") + m.theme.Accent.Render(m.yearFilter.String())
+}
@@ -0,0 +1,79 @@
+package app
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// yearsProvider filters albums by year the way the real providers do.
+type yearsProvider struct {
+	*testProvider
+	albumsReq provider.ListReq
+}
+
+func (p *yearsProvider) ListAlbums(ctx context.Context, artistID string, req provider.ListReq) (provider.Page[provider.Album], error) {
+	p.albumsReq = req
+	return provider.Page[provider.Album]{Items: provider.FilterAlbums(p.albums, req.Years)}, nil
+}
+
+func TestYearFilter(t *testing.T) {
+	prov := &yearsProvider{testProvider: newTestProvider()}
+	m := createTestModel(t)
+	m = initializeModel(m, prov.testProvider)
+	m.provider = prov
+	m.screen = screenLibrary
+	m.focusedPane = paneContent
+
+	// From the artists list, y opens the albums of the first decade chip
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	if m.yearFilter != provider.Decade(1950) || cmd == nil {
+		t.Fatalf("expected the 1950s chip and a reload, got %v", m.yearFilter)
+	}
+	m, cmd = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'y'}})
+	m, _ = updateModel(m, cmd())
+	if m.yearFilter != provider.Decade(1960) || prov.albumsReq.Years != m.yearFilter {
+		t.Fatalf("expected the 1960s passed to the provider, got %v / %v", m.yearFilter, prov.albumsReq.Years)
+	}
+	if len(m.albums) != 1 || m.albums[0].Title != "Abbey Road" {
+		t.Fatalf("expected only Abbey Road, got %+v", m.albums)
+	}
+	if view := m.renderLibrary(80, 20); !strings.Contains(view, "Years: 1960s") {
+		t.Errorf("expected the year chip in the header, got:\n%s", view)
+	}
+
+	// Y steps back; stepping back from all years wraps to the latest decade
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	if !m.yearFilter.IsZero() {
+		t.Fatalf("expected all years, got %v", m.yearFilter)
+	}
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'Y'}})
+	chips := yearChips()
+	if m.yearFilter != chips[len(chips)-1] {
+		t.Errorf("expected the latest decade, got %v", m.yearFilter)
+	}
+
+	// The palette takes any range
+	var c Command
+	for _, cmd := range m.commandRegistry.Commands() {
+		if cmd.ID == "nav.filter_years" {
+			c = cmd
+		}
+	}
+	m, _ = c.RunArg(&m, "1965-1975")
+	if m.yearFilter != (provider.YearRange{From: 1965, To: 1975}) {
+		t.Errorf("expected 1965-1975, got %v", m.yearFilter)
+	}
+	m, _ = c.RunArg(&m, "1970x")
+	if m.errorMsg == "" || m.yearFilter.IsZero() {
+		t.Errorf("expected an error keeping the filter, got %q %v", m.errorMsg, m.yearFilter)
+	}
+	m, _ = c.RunArg(&m, "all")
+	if !m.yearFilter.IsZero() {
+		t.Errorf("expected the filter cleared, got %v", m.yearFilter)
+	}
+}
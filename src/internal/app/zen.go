@@ -0,0 +1,138 @@
+package app
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/tunez/tunez/internal/artwork"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// zenIdleMsg checks whether the UI has been idle long enough to enter zen
+// mode.
+type zenIdleMsg struct{}
+
+// zenIdleCmd schedules the next idle check, when [ui] zen_idle_seconds is
+// set.
+func (m Model) zenIdleCmd() tea.Cmd {
+	if m.cfg.UI.ZenIdleSeconds <= 0 {
+		return nil
+	}
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return zenIdleMsg{}
+	})
+}
+
+// handleZenIdle enters zen mode once no key has been pressed for
+// zen_idle_seconds while a track plays and nothing is open on top.
+func (m Model) handleZenIdle() (Model, tea.Cmd) {
+	idle := time.Duration(m.cfg.UI.ZenIdleSeconds) * time.Second
+	overlay := m.showHelp || m.showPalette || m.showTrackInfo || m.showProfileSwitcher || m.showDiagnostics || m.showBookmarks || m.showChapters || m.showAbout || m.showSimilar || m.showQuickOpen || m.showHealth || m.confirmPrompt != nil
+	if !m.zen && !overlay && m.nowPlaying.ID != "" && !m.paused && time.Since(m.lastInput) >= idle {
+		m.logger.Debug("entering zen mode after idle", slog.Duration("idle", idle))
+		var cmd tea.Cmd
+		m, cmd = m.enterZen()
+		return m, tea.Batch(cmd, m.zenIdleCmd())
+	}
+	return m, m.zenIdleCmd()
+}
+
+// enterZen hides everything but the artwork, title, progress and
+// visualizer. Any key leaves.
+func (m Model) enterZen() (Model, tea.Cmd) {
+	m.zen = true
+	if !m.cfg.Artwork.Enabled || !m.provider.Capabilities()[provider.CapArtwork] || m.nowPlaying.ArtworkRef == "" || m.zenArtworkTrackID == m.nowPlaying.ID {
+		return m, nil
+	}
+	width, height := m.zenArtworkSize()
+	return m, m.renderArtworkCmd(m.nowPlaying, width, height, true)
+}
+
+// zenArtworkSize fills the height left over by the text, progress bar and
+// visualizer, kept square.
+func (m Model) zenArtworkSize() (width, height int) {
+	height = max(m.height-16, 8)
+	width = height * 2
+	if width > m.width-4 {
+		width = max(m.width-4, 16)
+		height = width / 2
+	}
+	return width, height
+}
+
+func (m Model) renderZen() string {
+	width := max(m.width-2, 20)
+	var lines []string
+
+	if m.cfg.Artwork.Enabled {
+		artWidth, artHeight := m.zenArtworkSize()
+		art := m.zenArtworkANSI
+		if m.zenArtworkTrackID != m.nowPlaying.ID {
+			// The large version is still loading
+			art = m.artworkANSI
+		}
+		if art == "" {
+			art = artwork.DefaultArtwork(artWidth, artHeight)
+		}
+		lines = append(lines, art, "")
+	}
+
+	if m.nowPlaying.Title == "" {
+		lines = append(lines, m.theme.Dim.Render("♪ "+m.msgs.T("status.nothing_playing")))
+	} else {
+		title := m.theme.Title.Render(strings.ToUpper(m.nowPlaying.Title)) + m.theme.Error.Render(m.loveIndicator(m.nowPlaying.ID))
+		lines = append(lines,
+			title,
+			m.theme.Text.Render(m.nowPlaying.ArtistName)+m.theme.Dim.Render(" — "+m.nowPlaying.AlbumTitle),
+			"",
+		)
+		barWidth := max(width-20, 10)
+		timeStr := formatPosition(m.timePos) + " / " + formatPosition(m.duration)
+		lines = append(lines, m.progressBar(barWidth)+"  "+m.theme.Dim.Render(timeStr), "")
+		if m.visualizer != nil && m.visualizer.Running() {
+			lines = append(lines, m.visualizer.RenderStyle(m.vizStyle, barWidth+len(timeStr), 6, m.theme.VisualizerGradient(m.vizStyle)))
+		}
+	}
+
+	content := lipgloss.JoinVertical(lipgloss.Center, lines...)
+	hint := m.theme.Dim.Render("press any key to return")
+	return lipgloss.Place(width, max(m.height-1, 1), lipgloss.Center, lipgloss.Center, content) + "\n" +
+		lipgloss.PlaceHorizontal(width, lipgloss.Center, hint)
+}
+
+// progressBar draws the playback position barWidth cells wide.
+func (m Model) progressBar(barWidth int) string {
+	pct := 0.0
+	if m.duration > 0 {
+		pct = m.timePos / m.duration
+	}
+	filled := min(max(int(float64(barWidth)*pct), 0), barWidth)
+	ticks := m.bookmarkTicks(barWidth)
+	if len(ticks) == 0 {
+		return m.theme.Highlight.Render(strings.Repeat("▓", filled)) +
+			m.theme.Dim.Render(strings.Repeat("░", barWidth-filled))
+	}
+	// Bookmarks show as tick marks, drawing each run between them in one go
+	var b strings.Builder
+	for start := 0; start < barWidth; {
+		if ticks[start] {
+			b.WriteString(m.theme.Accent.Render("┃"))
+			start++
+			continue
+		}
+		end := start + 1
+		for end < barWidth && !ticks[end] && (end < filled) == (start < filled) {
+			end++
+		}
+		if start < filled {
+			b.WriteString(m.theme.Highlight.Render(strings.Repeat("▓", end-start)))
+		} else {
+			b.WriteString(m.theme.Dim.Render(strings.Repeat("░", end-start)))
+		}
+		start = end
+	}
+	return b.String()
+}
@@ -0,0 +1,76 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestZenMode(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.screen = screenQueue
+	m.nowPlaying = provider.Track{ID: "t1", Title: "Time", ArtistName: "Pink Floyd", AlbumTitle: "The Dark Side of the Moon"}
+	m.duration = 413
+
+	m, _ = updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'z'}})
+	if !m.zen {
+		t.Fatal("expected z to enter zen mode")
+	}
+	view := m.View()
+	if !strings.Contains(view, "TIME") || !strings.Contains(view, "Pink Floyd") {
+		t.Errorf("expected the big title and artist, got:\n%s", view)
+	}
+	if strings.Contains(view, "Queue") {
+		t.Errorf("expected navigation and lists hidden, got:\n%s", view)
+	}
+
+	// Any key only leaves zen mode
+	m, cmd := updateModel(m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	if m.zen {
+		t.Error("expected a key press to leave zen mode")
+	}
+	if cmd != nil {
+		t.Error("expected the key that leaves zen mode to do nothing else")
+	}
+	if m.screen != screenQueue {
+		t.Errorf("expected to return to the queue, got screen %d", m.screen)
+	}
+}
+
+func TestZenModeAfterIdle(t *testing.T) {
+	m := createTestModel(t)
+	m = initializeModel(m, newTestProvider())
+	m.cfg.UI.ZenIdleSeconds = 60
+	m.nowPlaying = provider.Track{ID: "t1", Title: "Time"}
+
+	m.lastInput = time.Now().Add(-30 * time.Second)
+	m, cmd := updateModel(m, zenIdleMsg{})
+	if m.zen {
+		t.Fatal("expected no zen mode before the idle time")
+	}
+	if cmd == nil {
+		t.Error("expected the idle check to be rescheduled")
+	}
+
+	m.paused = true
+	m.lastInput = time.Now().Add(-2 * time.Minute)
+	m, _ = updateModel(m, zenIdleMsg{})
+	if m.zen {
+		t.Fatal("expected no zen mode while paused")
+	}
+
+	m.paused = false
+	m, _ = updateModel(m, zenIdleMsg{})
+	if !m.zen {
+		t.Fatal("expected zen mode after the idle time")
+	}
+
+	m.cfg.UI.ZenIdleSeconds = 0
+	if m.zenIdleCmd() != nil {
+		t.Error("expected no idle checks when zen_idle_seconds is 0")
+	}
+}
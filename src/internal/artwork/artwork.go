@@ -1,7 +1,6 @@
 package artwork
 
 import (
-	"bytes"
 	"context"
 	"crypto/sha256"
 	_ "embed"
@@ -14,8 +13,10 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	lru "github.com/hashicorp/golang-lru/v2"
@@ -36,10 +37,35 @@ var (
 )
 
 // Cache provides caching for converted ANSI artwork.
+//
+// Entries are kept by when they were last shown: each hit renews one, those
+// unshown for cacheDays expire, and once the cache grows past its size cap
+// the least recently shown go first. Pruning runs in the background on
+// startup and whenever a write takes the cache over the cap.
 type Cache struct {
 	baseDir   string
-	cacheDays int
-	maxSize   int64
+	cacheDays atomic.Int64
+	maxSize   atomic.Int64
+
+	// mu guards the running totals, kept so writes needn't list the
+	// directory, and pruning, set while a background prune runs
+	mu      sync.Mutex
+	entries int
+	size    int64
+	evicted int
+	pruning bool
+
+	// pruneMu runs one prune at a time
+	pruneMu sync.Mutex
+}
+
+// CacheStats describes what the cache holds.
+type CacheStats struct {
+	Entries  int
+	Bytes    int64
+	MaxBytes int64
+	// Evicted counts entries dropped to stay under the size cap
+	Evicted int
 }
 
 // NewCache creates a new artwork cache.
@@ -51,30 +77,53 @@ func NewCache(baseDir string, cacheDays int, maxSizeMB int) (*Cache, error) {
 			return nil, fmt.Errorf("resolve cache dir: %w", err)
 		}
 	}
-	if cacheDays <= 0 {
-		cacheDays = 30
-	}
-	if maxSizeMB <= 0 {
-		maxSizeMB = 500 // Default 500MB
-	}
 
 	// Ensure cache directory exists
 	if err := os.MkdirAll(baseDir, 0o755); err != nil {
 		return nil, fmt.Errorf("create cache dir: %w", err)
 	}
 
-	c := &Cache{
-		baseDir:   baseDir,
-		cacheDays: cacheDays,
-		maxSize:   int64(maxSizeMB) * 1024 * 1024,
-	}
+	c := &Cache{baseDir: baseDir}
+	c.setLimits(cacheDays, maxSizeMB)
 
-	// Prune on startup to enforce limits
-	go c.Prune()
+	// Count what's there, dropping expired entries and enforcing the cap
+	c.startPrune()
 
 	return c, nil
 }
 
+// SetLimits changes how long artwork is kept and how large the cache may
+// grow, pruning to the new size in the background. Zero keeps the default
+// of 30 days or 500MB.
+func (c *Cache) SetLimits(cacheDays int, maxSizeMB int) {
+	c.setLimits(cacheDays, maxSizeMB)
+	c.startPrune()
+}
+
+func (c *Cache) setLimits(cacheDays int, maxSizeMB int) {
+	if cacheDays <= 0 {
+		cacheDays = 30
+	}
+	if maxSizeMB <= 0 {
+		maxSizeMB = 500 // Default 500MB
+	}
+	c.cacheDays.Store(int64(cacheDays))
+	c.maxSize.Store(int64(maxSizeMB) * 1024 * 1024)
+}
+
+// Limits returns how many days artwork is kept and the most bytes the
+// cache may take.
+func (c *Cache) Limits() (cacheDays int, maxSize int64) {
+	return int(c.cacheDays.Load()), c.maxSize.Load()
+}
+
+// Stats returns what the cache holds, as of its last write or prune.
+func (c *Cache) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Entries: c.entries, Bytes: c.size, MaxBytes: c.maxSize.Load(), Evicted: c.evicted}
+}
+
 func defaultCacheDir() (string, error) {
 	var base string
 	switch runtime.GOOS {
@@ -108,7 +157,14 @@ func cacheKey(ref string, width int, height int, quality QualityLevel, scaleMode
 	return hex.EncodeToString(h.Sum(nil))[:16]
 }
 
-// Get retrieves cached ANSI artwork if available and not expired.
+// expired reports whether an entry last shown at mtime has gone unshown
+// for longer than the cache keeps artwork.
+func (c *Cache) expired(mtime time.Time) bool {
+	return time.Since(mtime) > time.Duration(c.cacheDays.Load())*24*time.Hour
+}
+
+// Get retrieves cached ANSI artwork if available and not expired, marking
+// it as just shown.
 func (c *Cache) Get(ref string, width int, height int, quality QualityLevel, scaleMode ScaleMode) (string, bool) {
 	key := cacheKey(ref, width, height, quality, scaleMode)
 	path := filepath.Join(c.baseDir, key+".ansi")
@@ -119,8 +175,13 @@ func (c *Cache) Get(ref string, width int, height int, quality QualityLevel, sca
 	}
 
 	// Check expiration
-	if time.Since(info.ModTime()) > time.Duration(c.cacheDays)*24*time.Hour {
-		os.Remove(path)
+	if c.expired(info.ModTime()) {
+		if os.Remove(path) == nil {
+			c.mu.Lock()
+			c.entries--
+			c.size -= info.Size()
+			c.mu.Unlock()
+		}
 		return "", false
 	}
 
@@ -129,6 +190,10 @@ func (c *Cache) Get(ref string, width int, height int, quality QualityLevel, sca
 		return "", false
 	}
 
+	// The modification time doubles as the last use, for eviction
+	now := time.Now()
+	_ = os.Chtimes(path, now, now)
+
 	return string(data), true
 }
 
@@ -136,20 +201,48 @@ func (c *Cache) Get(ref string, width int, height int, quality QualityLevel, sca
 func (c *Cache) Set(ref string, width int, height int, quality QualityLevel, scaleMode ScaleMode, ansi string) error {
 	key := cacheKey(ref, width, height, quality, scaleMode)
 	path := filepath.Join(c.baseDir, key+".ansi")
+	old, statErr := os.Stat(path)
 	if err := os.WriteFile(path, []byte(ansi), 0o644); err != nil {
 		return err
 	}
-	// Check size occasionally or just fire forget prune
-	go c.Prune()
+
+	c.mu.Lock()
+	if statErr == nil {
+		c.size -= old.Size()
+	} else {
+		c.entries++
+	}
+	c.size += int64(len(ansi))
+	over := c.size > c.maxSize.Load()
+	c.mu.Unlock()
+	if over {
+		c.startPrune()
+	}
 	return nil
 }
 
-// Prune removes old files if cache exceeds max size.
-func (c *Cache) Prune() {
-	size, err := c.Size()
-	if err != nil || size <= c.maxSize {
+// startPrune prunes in the background unless a prune is already running.
+func (c *Cache) startPrune() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.pruning {
 		return
 	}
+	c.pruning = true
+	go func() {
+		c.Prune()
+		c.mu.Lock()
+		c.pruning = false
+		c.mu.Unlock()
+	}()
+}
+
+// Prune removes expired artwork and, if the cache is over its size cap,
+// the least recently shown until it is down to nine tenths of the cap, so
+// the next few writes don't each set off another prune.
+func (c *Cache) Prune() {
+	c.pruneMu.Lock()
+	defer c.pruneMu.Unlock()
 
 	entries, err := os.ReadDir(c.baseDir)
 	if err != nil {
@@ -162,60 +255,43 @@ func (c *Cache) Prune() {
 		size  int64
 	}
 	var files []fileInfo
-
+	var size int64
 	for _, e := range entries {
 		if !strings.HasSuffix(e.Name(), ".ansi") {
 			continue
 		}
 		info, err := e.Info()
-		if err == nil {
-			files = append(files, fileInfo{
-				path:  filepath.Join(c.baseDir, e.Name()),
-				mtime: info.ModTime(),
-				size:  info.Size(),
-			})
+		if err != nil {
+			continue
 		}
-	}
-
-	// Sort by oldest modified
-	// We want to delete oldest files until we are under limit
-	// Bubble sort or slice sort? Slice sort is fine.
-	// We'll just define the sort logic here manually or use sort.Slice if imported (it's not).
-	// Let's implement a simple selection to find oldest or just use loop if we don't care about perfect ordering.
-	// Actually we should sort.
-	// Since 'sort' package is not imported, let's just delete files older than split cacheDays/2 if desperate?
-	// No, let's do it properly. I'll stick to a simple strategy: delete files > cacheDays first (already done by Get).
-	// If still over size, delete oldest.
-	// Since I can't import 'sort' without adding it to imports (which complicates replace tool),
-	// I'll just iterate and find oldest one by one until size is OK? slightly inefficient but fine for background.
-	// actually I can assume 'time' is imported.
-
-	// For simplicity without adding imports: just delete ANY file until size is OK?
-	// No, that's bad.
-	// I will check if 'sort' is imported. It is NOT.
-	// I will add 'sort' to imports in a separate chunk.
-
-	// Wait, I can try to find the oldest in O(N) and delete it, repeat.
-	// If 1000 files, O(N*K) where K is number of files to delete.
-
-	// Implementation:
-	for size > c.maxSize && len(files) > 0 {
-		oldestIdx := 0
-		for i := 1; i < len(files); i++ {
-			if files[i].mtime.Before(files[oldestIdx].mtime) {
-				oldestIdx = i
+		path := filepath.Join(c.baseDir, e.Name())
+		if c.expired(info.ModTime()) {
+			if os.Remove(path) == nil {
+				continue
 			}
 		}
-
-		target := files[oldestIdx]
-		if err := os.Remove(target.path); err == nil {
-			size -= target.size
+		files = append(files, fileInfo{path: path, mtime: info.ModTime(), size: info.Size()})
+		size += info.Size()
+	}
+
+	evicted := 0
+	if maxSize := c.maxSize.Load(); size > maxSize {
+		slices.SortFunc(files, func(a, b fileInfo) int { return a.mtime.Compare(b.mtime) })
+		target := maxSize / 10 * 9
+		for len(files) > 0 && size > target {
+			if err := os.Remove(files[0].path); err == nil || os.IsNotExist(err) {
+				size -= files[0].size
+				evicted++
+			}
+			files = files[1:]
 		}
-
-		// Remove from slice
-		files[oldestIdx] = files[len(files)-1]
-		files = files[:len(files)-1]
 	}
+
+	c.mu.Lock()
+	c.entries = len(files)
+	c.size = size
+	c.evicted += evicted
+	c.mu.Unlock()
 }
 
 // Clear removes all cached artwork.
@@ -232,6 +308,9 @@ func (c *Cache) Clear() error {
 			os.Remove(filepath.Join(c.baseDir, e.Name()))
 		}
 	}
+	c.mu.Lock()
+	c.entries, c.size = 0, 0
+	c.mu.Unlock()
 	return nil
 }
 
@@ -279,6 +358,14 @@ const (
 // quality: "low" (nearest neighbor), "medium" (bilinear), "high" (bicubic + anti-aliasing)
 // scaleMode: "fit", "fill", or "stretch"
 func ConvertToANSI(ctx context.Context, data []byte, width, height int, quality QualityLevel, scaleMode ScaleMode) (string, error) {
+	img, err := Decode(data)
+	if err != nil {
+		return "", err
+	}
+	return imageToANSI(ctx, img, width, height, quality, scaleMode)
+}
+
+func imageToANSI(ctx context.Context, img image.Image, width, height int, quality QualityLevel, scaleMode ScaleMode) (string, error) {
 	if width <= 0 {
 		width = 20
 	}
@@ -292,10 +379,13 @@ func ConvertToANSI(ctx context.Context, data []byte, width, height int, quality
 		scaleMode = ScaleFit
 	}
 
-	// Decode image
-	img, _, err := image.Decode(bytes.NewReader(data))
-	if err != nil {
-		return "", fmt.Errorf("decode image: %w", err)
+	return halfBlocks(ctx, img, width, height, quality, scaleMode)
+}
+
+// halfBlocks draws img in width x height cells of half blocks.
+func halfBlocks(ctx context.Context, img image.Image, width, height int, quality QualityLevel, scaleMode ScaleMode) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
 	}
 
 	// Get image bounds
@@ -370,6 +460,9 @@ func ConvertToANSI(ctx context.Context, data []byte, width, height int, quality
 	// ▀ = upper half block (fg = top pixel, bg = bottom pixel)
 	var result strings.Builder
 	for row := 0; row < targetHeight; row++ {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
 		for x := 0; x < targetWidth; x++ {
 			// Calculate sample positions with anti-aliasing
 			var topR, topG, topB, topA uint32
@@ -515,7 +608,7 @@ func DefaultArtwork(width, height int) string {
 		height = 10
 	}
 
-	key := fmt.Sprintf("%d:%d", width, height)
+	key := fmt.Sprintf("%s:%d:%d", DetectProtocol(), width, height)
 
 	// Check cache
 	defaultArtworkCacheOnce.Do(func() {
@@ -532,7 +625,8 @@ func DefaultArtwork(width, height int) string {
 	// Convert embedded PNG using best available protocol (auto-detects kitty/sixel/ansi)
 	result, err := Render(context.Background(), defaultArtworkPNG, width, height, QualityMedium, ScaleFit)
 	if err != nil {
-		// Fallback to text placeholder if conversion fails
+		// Fallback to text placeholder if conversion fails or the
+		// terminal cannot draw images
 		return Placeholder(width, height)
 	}
 
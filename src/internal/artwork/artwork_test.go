@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestCacheGetSet(t *testing.T) {
@@ -95,6 +96,94 @@ func TestCacheSize(t *testing.T) {
 	}
 }
 
+func TestCacheLimits(t *testing.T) {
+	cache, err := NewCache(t.TempDir(), 0, 0)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	if days, size := cache.Limits(); days != 30 || size != 500<<20 {
+		t.Errorf("expected the 30 day, 500MB defaults, got %d days %d bytes", days, size)
+	}
+	cache.SetLimits(7, 100)
+	if days, size := cache.Limits(); days != 7 || size != 100<<20 {
+		t.Errorf("expected 7 days and 100MB, got %d days %d bytes", days, size)
+	}
+}
+
+// waitPruned waits for a background prune to finish.
+func waitPruned(c *Cache) {
+	for {
+		c.mu.Lock()
+		pruning := c.pruning
+		c.mu.Unlock()
+		if !pruning {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestCacheEvictsLeastRecentlyShown(t *testing.T) {
+	dir := t.TempDir()
+	cache, err := NewCache(dir, 30, 1)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	waitPruned(cache)
+
+	// Three covers fill most of the 1MB cap, a shown longest ago
+	art := strings.Repeat("x", 300<<10)
+	for i, ref := range []string{"a", "b", "c"} {
+		if err := cache.Set(ref, 20, 10, QualityMedium, ScaleFit, art); err != nil {
+			t.Fatalf("Set: %v", err)
+		}
+		shown := time.Now().Add(time.Duration(i-3) * time.Hour)
+		os.Chtimes(filepath.Join(dir, cacheKey(ref, 20, 10, QualityMedium, ScaleFit)+".ansi"), shown, shown)
+	}
+	waitPruned(cache)
+	if st := cache.Stats(); st.Entries != 3 || st.Bytes != 900<<10 || st.Evicted != 0 {
+		t.Fatalf("expected three entries under the cap, got %+v", st)
+	}
+
+	// Showing a again makes b the least recently shown, and the fourth
+	// cover takes the cache over the cap
+	if _, ok := cache.Get("a", 20, 10, QualityMedium, ScaleFit); !ok {
+		t.Fatal("expected a hit for a")
+	}
+	cache.Set("d", 20, 10, QualityMedium, ScaleFit, art)
+	waitPruned(cache)
+
+	for ref, want := range map[string]bool{"a": true, "b": false, "c": true, "d": true} {
+		if _, ok := cache.Get(ref, 20, 10, QualityMedium, ScaleFit); ok != want {
+			t.Errorf("expected %s cached=%v", ref, want)
+		}
+	}
+	if st := cache.Stats(); st.Entries != 3 || st.Bytes != 900<<10 || st.Evicted != 1 || st.MaxBytes != 1<<20 {
+		t.Errorf("expected b evicted, got %+v", st)
+	}
+}
+
+func TestCachePruneExpired(t *testing.T) {
+	dir := t.TempDir()
+	old := filepath.Join(dir, "0123456789abcdef.ansi")
+	os.WriteFile(old, []byte("old"), 0o644)
+	unshown := time.Now().AddDate(0, 0, -31)
+	os.Chtimes(old, unshown, unshown)
+	os.WriteFile(filepath.Join(dir, "fedcba9876543210.ansi"), []byte("new"), 0o644)
+
+	cache, err := NewCache(dir, 30, 0)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+	waitPruned(cache)
+	if _, err := os.Stat(old); !os.IsNotExist(err) {
+		t.Errorf("expected artwork unshown for 31 days pruned on startup, got %v", err)
+	}
+	if st := cache.Stats(); st.Entries != 1 || st.Bytes != 3 || st.Evicted != 0 {
+		t.Errorf("expected the fresh entry counted, got %+v", st)
+	}
+}
+
 func TestConvertToANSI(t *testing.T) {
 	// Create a simple test image
 	img := image.NewRGBA(image.Rect(0, 0, 10, 10))
@@ -0,0 +1,110 @@
+package artwork
+
+import (
+	"context"
+	"image"
+	"image/color"
+)
+
+// blurGrid is how many averaged colors a side of a Blurred cover blends
+// between.
+const blurGrid = 4
+
+// Blurred draws a blurred stand-in for img, in the manner of a blurhash:
+// a few averaged colors blended across the cover, drawn in half blocks at
+// the size ConvertToANSI would use, so the layout doesn't move when the
+// full conversion replaces it. It is quick to make, for showing while
+// that runs; pass the same decoded image on to RenderImage.
+func Blurred(ctx context.Context, img image.Image, width, height int, scaleMode ScaleMode) (string, error) {
+	b := img.Bounds()
+	if b.Dx() == 0 || b.Dy() == 0 {
+		return "", ErrInvalid
+	}
+	if scaleMode == "" {
+		scaleMode = ScaleFit
+	}
+	return halfBlocks(ctx, newBlurImage(img), width, height, QualityLow, scaleMode)
+}
+
+// blurImage is an image of the same bounds as the one it stands in for,
+// whose colors blend smoothly between a grid of that image's averages.
+type blurImage struct {
+	bounds image.Rectangle
+	grid   [blurGrid][blurGrid][3]float64
+}
+
+// newBlurImage averages img over a blurGrid square grid, sampling at
+// most about 16x16 pixels a square.
+func newBlurImage(img image.Image) *blurImage {
+	bl := &blurImage{bounds: img.Bounds()}
+	b := bl.bounds
+	for gy := range blurGrid {
+		y0, y1 := b.Min.Y+gy*b.Dy()/blurGrid, b.Min.Y+(gy+1)*b.Dy()/blurGrid
+		for gx := range blurGrid {
+			x0, x1 := b.Min.X+gx*b.Dx()/blurGrid, b.Min.X+(gx+1)*b.Dx()/blurGrid
+			stepX, stepY := max((x1-x0)/16, 1), max((y1-y0)/16, 1)
+			var sum [3]float64
+			n := 0
+			for y := y0; y < y1; y += stepY {
+				for x := x0; x < x1; x += stepX {
+					cr, cg, cb, _ := img.At(x, y).RGBA()
+					sum[0] += float64(cr >> 8)
+					sum[1] += float64(cg >> 8)
+					sum[2] += float64(cb >> 8)
+					n++
+				}
+			}
+			if n == 0 {
+				// An image narrower than the grid: borrow the square before
+				if gx > 0 {
+					bl.grid[gy][gx] = bl.grid[gy][gx-1]
+				} else if gy > 0 {
+					bl.grid[gy][gx] = bl.grid[gy-1][gx]
+				}
+				continue
+			}
+			for c := range sum {
+				bl.grid[gy][gx][c] = sum[c] / float64(n)
+			}
+		}
+	}
+	return bl
+}
+
+func (bl *blurImage) ColorModel() color.Model { return color.RGBAModel }
+
+func (bl *blurImage) Bounds() image.Rectangle { return bl.bounds }
+
+// At blends the four grid colors nearest (x, y), each taken as sitting at
+// the middle of its square.
+func (bl *blurImage) At(x, y int) color.Color {
+	fx := (float64(x-bl.bounds.Min.X)+0.5)/float64(bl.bounds.Dx())*blurGrid - 0.5
+	fy := (float64(y-bl.bounds.Min.Y)+0.5)/float64(bl.bounds.Dy())*blurGrid - 0.5
+	x0, y0 := gridIndex(fx), gridIndex(fy)
+	x1, y1 := gridIndex(fx+1), gridIndex(fy+1)
+	tx, ty := clamp01(fx-float64(x0)), clamp01(fy-float64(y0))
+
+	var out [3]uint8
+	for c := range out {
+		top := bl.grid[y0][x0][c]*(1-tx) + bl.grid[y0][x1][c]*tx
+		bottom := bl.grid[y1][x0][c]*(1-tx) + bl.grid[y1][x1][c]*tx
+		out[c] = uint8(top*(1-ty) + bottom*ty + 0.5)
+	}
+	return color.RGBA{R: out[0], G: out[1], B: out[2], A: 255}
+}
+
+// gridIndex is the grid square at or before position f.
+func gridIndex(f float64) int {
+	i := int(f)
+	if i < 0 {
+		return 0
+	}
+	if i > blurGrid-1 {
+		return blurGrid - 1
+	}
+	return i
+}
+
+func clamp01(f float64) float64 {
+	return min(max(f, 0), 1)
+}
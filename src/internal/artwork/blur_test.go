@@ -0,0 +1,63 @@
+package artwork
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func TestBlurred(t *testing.T) {
+	// Left half red, right half blue, twice as wide as tall
+	img := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for y := range 32 {
+		for x := range 64 {
+			c := color.RGBA{255, 0, 0, 255}
+			if x >= 32 {
+				c = color.RGBA{0, 0, 255, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	decoded, err := Decode(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := Blurred(ctx, decoded, 20, 10, ScaleFit)
+	if err != nil {
+		t.Fatalf("Blurred: %v", err)
+	}
+	full, err := ConvertToANSI(ctx, buf.Bytes(), 20, 10, QualityHigh, ScaleFit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(got, "\n")
+	if len(lines) != len(strings.Split(full, "\n")) {
+		t.Errorf("expected the blurred cover as tall as the full conversion, got %d lines for %d", len(lines), len(strings.Split(full, "\n")))
+	}
+
+	// Pure at the edges, blended in between
+	row := lines[len(lines)/2]
+	if !strings.HasPrefix(row, "\x1b[38;2;255;0;0m") {
+		t.Errorf("expected the left edge red, got %q", row[:40])
+	}
+	if !strings.HasSuffix(row, "\x1b[38;2;0;0;255m\x1b[48;2;0;0;255m▀\x1b[0m") {
+		t.Errorf("expected the right edge blue, got %q", row[max(len(row)-40, 0):])
+	}
+	if !strings.Contains(row, "\x1b[38;2;120;0;135m") {
+		t.Errorf("expected a blend of the two in the middle, got %q", row)
+	}
+
+	if _, err := Decode([]byte("not an image")); err == nil {
+		t.Error("expected an error for data that isn't an image")
+	}
+}
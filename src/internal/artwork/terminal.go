@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"image"
 	"image/png"
@@ -19,8 +20,14 @@ const (
 	ProtocolANSI  Protocol = "ansi"  // Half-block Unicode characters (universal fallback)
 	ProtocolSixel Protocol = "sixel" // Sixel graphics (iTerm2, WezTerm, foot, mlterm, xterm)
 	ProtocolKitty Protocol = "kitty" // Kitty graphics protocol
+	// ProtocolText draws no images; artwork is shown as a Tile of initials.
+	// Used on dumb terminals and when NO_COLOR is set.
+	ProtocolText Protocol = "text"
 )
 
+// ErrNoGraphics is returned when rendering with ProtocolText.
+var ErrNoGraphics = errors.New("terminal cannot draw images")
+
 var (
 	detectedProtocol     Protocol
 	detectedProtocolOnce sync.Once
@@ -48,6 +55,10 @@ func ForceProtocol(p Protocol) {
 }
 
 func detectProtocolImpl() Protocol {
+	if os.Getenv("TERM") == "dumb" || os.Getenv("NO_COLOR") != "" {
+		return ProtocolText
+	}
+
 	// Check for Kitty terminal first (best quality)
 	if isKittyTerminal() {
 		return ProtocolKitty
@@ -135,13 +146,41 @@ func Render(ctx context.Context, data []byte, width, height int, quality Quality
 
 // RenderWithProtocol converts image data using a specific protocol.
 func RenderWithProtocol(ctx context.Context, data []byte, width, height int, quality QualityLevel, scaleMode ScaleMode, protocol Protocol) (string, error) {
+	if protocol == ProtocolText {
+		return "", ErrNoGraphics
+	}
+	img, err := Decode(data)
+	if err != nil {
+		return "", err
+	}
+	return renderImage(ctx, img, width, height, quality, scaleMode, protocol)
+}
+
+// Decode decodes image data once, for callers that draw it more than one
+// way, such as a Blurred preview followed by RenderImage.
+func Decode(data []byte) (image.Image, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("decode image: %w", err)
+	}
+	return img, nil
+}
+
+// RenderImage is Render for an image already decoded.
+func RenderImage(ctx context.Context, img image.Image, width, height int, quality QualityLevel, scaleMode ScaleMode) (string, error) {
+	return renderImage(ctx, img, width, height, quality, scaleMode, DetectProtocol())
+}
+
+func renderImage(ctx context.Context, img image.Image, width, height int, quality QualityLevel, scaleMode ScaleMode, protocol Protocol) (string, error) {
 	switch protocol {
 	case ProtocolKitty:
-		return ConvertToKitty(ctx, data, width, height)
+		return imageToKitty(ctx, img, width, height)
 	case ProtocolSixel:
-		return ConvertToSixel(ctx, data, width, height)
+		return imageToSixel(ctx, img, width, height)
+	case ProtocolText:
+		return "", ErrNoGraphics
 	default:
-		return ConvertToANSI(ctx, data, width, height, quality, scaleMode)
+		return imageToANSI(ctx, img, width, height, quality, scaleMode)
 	}
 }
 
@@ -152,10 +191,16 @@ func RenderWithProtocol(ctx context.Context, data []byte, width, height int, qua
 // For TUI compatibility, this outputs placeholder lines after the escape sequence
 // so that lipgloss can correctly measure the height for layout purposes.
 func ConvertToKitty(ctx context.Context, data []byte, widthCells, heightCells int) (string, error) {
-	// Decode image
-	img, _, err := image.Decode(bytes.NewReader(data))
+	img, err := Decode(data)
 	if err != nil {
-		return "", fmt.Errorf("decode image: %w", err)
+		return "", err
+	}
+	return imageToKitty(ctx, img, widthCells, heightCells)
+}
+
+func imageToKitty(ctx context.Context, img image.Image, widthCells, heightCells int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
 	}
 
 	// Calculate actual output height using same logic as ANSI converter
@@ -259,10 +304,16 @@ func ConvertToKitty(ctx context.Context, data []byte, widthCells, heightCells in
 // For TUI compatibility, this outputs placeholder lines after the escape sequence
 // so that lipgloss can correctly measure the height for layout purposes.
 func ConvertToSixel(ctx context.Context, data []byte, widthCells, heightCells int) (string, error) {
-	// Decode image
-	img, _, err := image.Decode(bytes.NewReader(data))
+	img, err := Decode(data)
 	if err != nil {
-		return "", fmt.Errorf("decode image: %w", err)
+		return "", err
+	}
+	return imageToSixel(ctx, img, widthCells, heightCells)
+}
+
+func imageToSixel(ctx context.Context, img image.Image, widthCells, heightCells int) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
 	}
 
 	// Calculate actual output height using same logic as ANSI converter
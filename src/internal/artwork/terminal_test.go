@@ -18,6 +18,7 @@ func TestDetectProtocol(t *testing.T) {
 		os.Unsetenv("WEZTERM_EXECUTABLE")
 		os.Unsetenv("XTERM_VERSION")
 		os.Unsetenv("CONTOUR_SESSION_ID")
+		os.Unsetenv("NO_COLOR")
 	}
 
 	tests := []struct {
@@ -75,6 +76,21 @@ func TestDetectProtocol(t *testing.T) {
 			},
 			expected: ProtocolSixel,
 		},
+		{
+			name: "Text on a dumb terminal",
+			setup: func() {
+				os.Setenv("TERM", "dumb")
+			},
+			expected: ProtocolText,
+		},
+		{
+			name: "Text with NO_COLOR",
+			setup: func() {
+				os.Setenv("TERM", "xterm-kitty")
+				os.Setenv("NO_COLOR", "1")
+			},
+			expected: ProtocolText,
+		},
 		{
 			name: "Fallback to ANSI",
 			setup: func() {
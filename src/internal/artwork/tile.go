@@ -0,0 +1,127 @@
+package artwork
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"strings"
+	"unicode"
+
+	"github.com/tunez/tunez/internal/ui"
+)
+
+// AverageColor returns the mean color of img, sampling at most about
+// 64x64 pixels.
+func AverageColor(img image.Image) (color.RGBA, error) {
+	b := img.Bounds()
+	stepX := max(b.Dx()/64, 1)
+	stepY := max(b.Dy()/64, 1)
+	var r, g, bl, n uint64
+	for y := b.Min.Y; y < b.Max.Y; y += stepY {
+		for x := b.Min.X; x < b.Max.X; x += stepX {
+			cr, cg, cb, _ := img.At(x, y).RGBA()
+			r += uint64(cr >> 8)
+			g += uint64(cg >> 8)
+			bl += uint64(cb >> 8)
+			n++
+		}
+	}
+	if n == 0 {
+		return color.RGBA{}, ErrInvalid
+	}
+	return color.RGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: 255}, nil
+}
+
+// Initials returns up to two uppercase initials of name, ignoring a
+// leading "The", e.g. "The Dark Side of the Moon" is "DS".
+func Initials(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) > 1 && strings.EqualFold(words[0], "the") {
+		words = words[1:]
+	}
+	var out []rune
+	for _, w := range words {
+		if len(out) == 2 {
+			break
+		}
+		out = append(out, unicode.ToUpper([]rune(w)[0]))
+	}
+	return string(out)
+}
+
+// shades fill a colorless tile from dark to light covers.
+var shades = []string{" ", "░", "▒", "▓"}
+
+// Tile stands in for artwork that cannot be drawn as an image: the album
+// and artist initials centered in a width x height block. With useColor the
+// block is filled with bg in true color and the text picks black or white
+// for contrast. Without it the block is framed and shaded by bg's
+// brightness, so a dark cover still looks different from a light one.
+func Tile(width, height int, bg color.RGBA, album, artist string, useColor bool) string {
+	if width <= 0 {
+		width = 20
+	}
+	if height <= 0 {
+		height = 10
+	}
+	lines := []string{Initials(album), Initials(artist)}
+	if lines[0] == "" && lines[1] == "" {
+		lines = []string{"♪"}
+	} else if lines[1] == "" || lines[1] == lines[0] {
+		lines = lines[:1]
+	}
+	top := (height - len(lines)) / 2
+
+	// Relative luminance, 0-255
+	lum := (299*int(bg.R) + 587*int(bg.G) + 114*int(bg.B)) / 1000
+
+	var b strings.Builder
+	if useColor {
+		fg := "255;255;255"
+		if lum > 140 {
+			fg = "0;0;0"
+		}
+		for y := 0; y < height; y++ {
+			fmt.Fprintf(&b, "\x1b[48;2;%d;%d;%dm\x1b[38;2;%sm", bg.R, bg.G, bg.B, fg)
+			b.WriteString(centerText(lineAt(lines, y-top), width, " "))
+			b.WriteString("\x1b[0m")
+			if y < height-1 {
+				b.WriteByte('\n')
+			}
+		}
+		return b.String()
+	}
+
+	fill := shades[lum*len(shades)/256]
+	inner := max(width-2, 0)
+	b.WriteString("┌" + strings.Repeat("─", inner) + "┐\n")
+	for y := 1; y < height-1; y++ {
+		b.WriteString("│")
+		text := lineAt(lines, y-top)
+		if text != "" {
+			// Clear a gap around the text so it stays readable on a shaded fill
+			text = " " + text + " "
+		}
+		b.WriteString(centerText(text, inner, fill))
+		b.WriteString("│\n")
+	}
+	b.WriteString("└" + strings.Repeat("─", inner) + "┘")
+	return b.String()
+}
+
+func lineAt(lines []string, i int) string {
+	if i < 0 || i >= len(lines) {
+		return ""
+	}
+	return lines[i]
+}
+
+// centerText pads text to width cells with fill on both sides.
+func centerText(text string, width int, fill string) string {
+	text = ui.Truncate(text, width)
+	n := ui.Width(text)
+	left := (width - n) / 2
+	return strings.Repeat(fill, left) + text + strings.Repeat(fill, width-n-left)
+}
@@ -0,0 +1,79 @@
+package artwork
+
+import (
+	"image"
+	"image/color"
+	"strings"
+	"testing"
+)
+
+func TestAverageColor(t *testing.T) {
+	// Left half red, right half blue
+	img := image.NewRGBA(image.Rect(0, 0, 100, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 100; x++ {
+			c := color.RGBA{255, 0, 0, 255}
+			if x >= 50 {
+				c = color.RGBA{0, 0, 255, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	got, err := AverageColor(img)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.R < 120 || got.R > 135 || got.G != 0 || got.B < 120 || got.B > 135 {
+		t.Errorf("expected an even purple, got %+v", got)
+	}
+	if _, err := AverageColor(image.NewRGBA(image.Rectangle{})); err == nil {
+		t.Error("expected an error for an empty image")
+	}
+}
+
+func TestInitials(t *testing.T) {
+	for name, want := range map[string]string{
+		"The Dark Side of the Moon": "DS",
+		"The The":                   "T",
+		"abbey road":                "AR",
+		"Blue":                      "B",
+		"¡Uno!":                     "U",
+		"":                          "",
+	} {
+		if got := Initials(name); got != want {
+			t.Errorf("Initials(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestTile(t *testing.T) {
+	dark := color.RGBA{20, 20, 60, 255}
+
+	plain := Tile(20, 8, dark, "Abbey Road", "The Beatles", false)
+	if strings.Contains(plain, "\x1b[") {
+		t.Error("expected no escape codes without color")
+	}
+	lines := strings.Split(plain, "\n")
+	if len(lines) != 8 {
+		t.Fatalf("expected 8 lines, got %d", len(lines))
+	}
+	for i, line := range lines {
+		if n := len([]rune(line)); n != 20 {
+			t.Errorf("line %d is %d cells wide, want 20: %q", i, n, line)
+		}
+	}
+	if !strings.Contains(plain, " AR ") || !strings.Contains(plain, " B ") {
+		t.Errorf("expected album and artist initials:\n%s", plain)
+	}
+	if light := Tile(20, 8, color.RGBA{240, 240, 240, 255}, "Abbey Road", "", false); light == plain || !strings.Contains(light, "▓") {
+		t.Errorf("expected a light cover to be shaded differently:\n%s", light)
+	}
+
+	colored := Tile(10, 4, dark, "Abbey Road", "The Beatles", true)
+	if !strings.Contains(colored, "\x1b[48;2;20;20;60m") || !strings.Contains(colored, "38;2;255;255;255m") {
+		t.Errorf("expected the average color behind white text, got %q", colored)
+	}
+	if got := Tile(10, 4, dark, "", "", false); !strings.Contains(got, "♪") {
+		t.Errorf("expected a note without names, got:\n%s", got)
+	}
+}
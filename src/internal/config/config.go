@@ -7,29 +7,216 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"reflect"
+	"regexp"
 	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/pelletier/go-toml/v2"
+	"github.com/tunez/tunez/internal/i18n"
+	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/visualizer"
 )
 
 // Config holds Tunez runtime configuration loaded from TOML.
 type Config struct {
-	ConfigVersion int              `toml:"config_version"`
-	ActiveProfile string           `toml:"active_profile"`
-	UI            UIConfig         `toml:"ui"`
-	Player        PlayerConfig     `toml:"player"`
-	Queue         QueueConfig      `toml:"queue"`
-	Artwork       ArtworkConfig    `toml:"artwork"`
-	Scrobble      ScrobbleConfig   `toml:"scrobble"`
-	Keybindings   KeybindConfig    `toml:"keybindings"`
-	Profiles      []Profile        `toml:"profiles"`
-	Scrobblers    []ScrobblerEntry `toml:"scrobblers"`
+	ConfigVersion int    `toml:"config_version"`
+	ActiveProfile string `toml:"active_profile"`
+	// ActiveProfiles lists profiles to browse together as one merged
+	// library. When set, ActiveProfile defaults to its first entry.
+	ActiveProfiles []string `toml:"active_profiles"`
+	// MultipleInstances lets more than one Tunez run at once. By default a
+	// second launch forwards its playback flags to the running one.
+	MultipleInstances bool             `toml:"multiple_instances"`
+	UI                UIConfig         `toml:"ui"`
+	Player            PlayerConfig     `toml:"player"`
+	Queue             QueueConfig      `toml:"queue"`
+	Artwork           ArtworkConfig    `toml:"artwork"`
+	Scrobble          ScrobbleConfig   `toml:"scrobble"`
+	Keybindings       KeybindConfig    `toml:"keybindings"`
+	Profiles          []Profile        `toml:"profiles"`
+	Scrobblers        []ScrobblerEntry `toml:"scrobblers"`
+	Commands          []UserCommand    `toml:"commands"`
+	Plugins           PluginsConfig    `toml:"plugins"`
+	Hooks             HooksConfig      `toml:"hooks"`
+	Export            ExportConfig     `toml:"export"`
+	Logging           LoggingConfig    `toml:"logging"`
+	Metrics           MetricsConfig    `toml:"metrics"`
+	Visualizer        VisualizerConfig `toml:"visualizer"`
+	About             AboutConfig      `toml:"about"`
+	Audiobooks        AudiobooksConfig `toml:"audiobooks"`
+	Library           LibraryConfig    `toml:"library"`
+
+	// Path is the file the config was loaded from. Not part of the file.
+	Path string `toml:"-"`
+	// Migration is set when Load upgraded the file to CurrentVersion.
+	Migration *Migration `toml:"-"`
+
+	// base holds the top-level [ui] and [keybindings], before any profile's
+	// overrides were applied.
+	base *profileBase
+}
+
+type profileBase struct {
+	UI          UIConfig
+	Keybindings KeybindConfig
+	Player      ProfilePlayerConfig
+}
+
+// UserCommand is a command palette entry defined in the config file that
+// runs built-in palette commands in order.
+type UserCommand struct {
+	Name        string `toml:"name"`
+	Description string `toml:"description"`
+	// Steps are palette command IDs, each optionally followed by a space and
+	// the argument of a command that takes one ("playback.set_volume 40").
+	Steps []string `toml:"steps"`
+}
+
+// PluginsConfig controls loading of Lua plugin scripts.
+type PluginsConfig struct {
+	Enabled bool `toml:"enabled"`
+	// Dir holds the *.lua scripts; empty means "plugins" next to the
+	// config file.
+	Dir       string `toml:"dir"`
+	TimeoutMs int    `toml:"timeout_ms"` // per hook or command call
+}
+
+// PluginsDir returns the directory plugins are loaded from.
+func (c Config) PluginsDir() string {
+	if c.Plugins.Dir != "" {
+		return c.Plugins.Dir
+	}
+	return filepath.Join(filepath.Dir(c.Path), "plugins")
+}
+
+// HooksConfig holds shell commands run on playback events. Track details
+// are passed in TUNEZ_* environment variables.
+type HooksConfig struct {
+	TrackChange  string `toml:"track_change"`
+	Pause        string `toml:"pause"`
+	Resume       string `toml:"resume"`
+	QueueEmpty   string `toml:"queue_empty"` // the last track in the queue finished
+	AppStart     string `toml:"app_start"`
+	AppStop      string `toml:"app_stop"`
+	ArtworkSaved string `toml:"artwork_saved"` // Save Artwork wrote an image, e.g. to set it as wallpaper
+	TimeoutMs    int    `toml:"timeout_ms"`
+}
+
+// ExportConfig controls writing the current track to files on every
+// change, for streaming overlays and status bars.
+type ExportConfig struct {
+	Path     string `toml:"path"`      // text file rendered from Template
+	Template string `toml:"template"`  // Go text/template; default "{{.Artist}} - {{.Title}}"
+	JSONPath string `toml:"json_path"` // optional JSON file with all fields
+}
+
+// LoggingConfig controls the log file.
+type LoggingConfig struct {
+	Level string `toml:"level"` // debug, info, warn or error
+	// Path is the log file; empty means tunez-<date>.log in the state dir.
+	Path       string `toml:"path"`
+	Format     string `toml:"format"`      // text or json
+	MaxSizeMB  int    `toml:"max_size_mb"` // rotate past this size; 0 never rotates
+	MaxBackups int    `toml:"max_backups"` // rotated files kept
+}
+
+// MetricsConfig controls the Prometheus /metrics endpoint.
+type MetricsConfig struct {
+	Enabled bool   `toml:"enabled"`
+	Listen  string `toml:"listen"` // host:port, default 127.0.0.1:9464
+}
+
+// VisualizerConfig selects where the spectrum comes from and how cava
+// captures audio.
+type VisualizerConfig struct {
+	// Backend is cava, builtin or off. cava falls back to builtin when
+	// it is not installed.
+	Backend string `toml:"backend"`
+	Style   string `toml:"style"`  // bars, mirrored, waveform or vu; v cycles on Now Playing
+	Input   string `toml:"input"`  // auto, pulse, pipewire, alsa, fifo, ...
+	Source  string `toml:"source"` // device, monitor or FIFO path; "" is cava's default
+}
+
+// AboutConfig controls the About panel's artist bios and album reviews.
+// Nothing is fetched from the internet unless Enabled is set.
+type AboutConfig struct {
+	Enabled   bool   `toml:"enabled"`
+	Source    string `toml:"source"`     // lastfm or wikipedia; "" is lastfm when a Last.fm api_key is set
+	Language  string `toml:"language"`   // Wikipedia edition, default en
+	CacheDays int    `toml:"cache_days"` // default 30
+}
+
+// AudiobooksConfig picks out audiobook tracks, which are never scrobbled,
+// resume where they were left, play at Speed and keep their file order.
+type AudiobooksConfig struct {
+	// Folders are globs matched against each folder a track's file is in,
+	// e.g. "~/Audiobooks" or "/mnt/*/Books".
+	Folders []string `toml:"folders"`
+	// Genres match a track's genre tag case-insensitively; default
+	// Audiobook, Audiobooks and Spoken Word.
+	Genres []string `toml:"genres"`
+	// Speed is the playback speed of audiobooks, e.g. 1.25; default 1.
+	Speed float64 `toml:"speed"`
+}
+
+// LibraryConfig controls the actions that change files in local
+// libraries.
+type LibraryConfig struct {
+	// OrganizePattern is where --organize moves files under their library
+	// folder: a Go text/template without the extension, with "/" between
+	// folders. "" is Artist/Album/NN - Title.
+	OrganizePattern string `toml:"organize_pattern"`
+	// AllowDelete enables the Delete File command, which removes the
+	// selected track's file after asking twice.
+	AllowDelete bool `toml:"allow_delete"`
+	// DeletePermanently removes deleted files for good instead of moving
+	// them to the OS trash.
+	DeletePermanently bool `toml:"delete_permanently"`
 }
 
 // QueueConfig holds queue persistence settings.
 type QueueConfig struct {
 	Persist bool `toml:"persist"`
+	// PlayFromHere makes Enter on an album track also queue the tracks
+	// after it.
+	PlayFromHere bool `toml:"play_from_here"`
+	// ExportRelativePaths writes file paths in exported playlist files
+	// relative to the playlist file instead of absolute.
+	ExportRelativePaths bool `toml:"export_relative_paths"`
+	// MaxSize is the most tracks the queue holds; tracks added beyond it
+	// are left out. Negative means no limit.
+	MaxSize int `toml:"max_size"`
+	// Skip holds the rules for tracks passed over when the queue advances.
+	Skip SkipConfig `toml:"skip"`
+	// Shuffle picks how shuffling orders the queue.
+	Shuffle ShuffleConfig `toml:"shuffle"`
+}
+
+// ShuffleModes are the values of [queue.shuffle] mode.
+var ShuffleModes = []string{"random", "weighted"}
+
+// ShuffleConfig is [queue.shuffle]. In weighted mode each track starts
+// with a weight of 1 and the weights below are added; heavier tracks tend
+// to come up sooner. When none of the weights is set, loved 2, plays 0.5
+// and recency 1 are used.
+type ShuffleConfig struct {
+	Mode          string  `toml:"mode"`           // random or weighted
+	LovedWeight   float64 `toml:"loved_weight"`   // added for loved tracks
+	PlaysWeight   float64 `toml:"plays_weight"`   // times log2(1+plays); negative favors rarely played tracks
+	RecencyWeight float64 `toml:"recency_weight"` // times how long since the last play, up to 30 days
+}
+
+// SkipConfig is [queue.skip]. Each rule is on when set; a track matching
+// any of them is skipped when the queue moves on to it.
+type SkipConfig struct {
+	ShorterThanSeconds int      `toml:"shorter_than_seconds"` // tracks shorter than this
+	TitlePatterns      []string `toml:"title_patterns"`       // case-insensitive regular expressions matched against titles
+	PlayedToday        bool     `toml:"played_today"`         // tracks already played since midnight
 }
 
 // ArtworkConfig holds artwork display settings.
@@ -40,6 +227,11 @@ type ArtworkConfig struct {
 	Quality   string `toml:"quality"`    // low, medium, high
 	ScaleMode string `toml:"scale_mode"` // fit, fill, stretch
 	CacheDays int    `toml:"cache_days"`
+	// CacheMaxMB caps the converted artwork kept on disk; the least
+	// recently shown goes first.
+	CacheMaxMB int `toml:"cache_max_mb"`
+	// ExportDir is where Save Artwork writes images; "" is ~/Pictures.
+	ExportDir string `toml:"export_dir"`
 }
 
 // ScrobbleConfig holds global scrobbling settings.
@@ -56,38 +248,173 @@ type ScrobblerEntry struct {
 }
 
 type UIConfig struct {
-	PageSize int    `toml:"page_size"`
-	NoEmoji  bool   `toml:"no_emoji"`
-	Theme    string `toml:"theme"`
+	PageSize int        `toml:"page_size"`
+	NoEmoji  bool       `toml:"no_emoji"`
+	Theme    string     `toml:"theme"`
+	Sort     SortConfig `toml:"sort"`
+	// ZenIdleSeconds enters zen mode after this long without a key
+	// press while playing; 0 never does.
+	ZenIdleSeconds int `toml:"zen_idle_seconds"`
+	// NowPlayingLayout is "default" or "lyrics", which puts synced lyrics
+	// beside the artwork.
+	NowPlayingLayout string `toml:"now_playing_layout"`
+	// NoConfirm runs destructive actions such as clearing the queue
+	// without asking first.
+	NoConfirm bool `toml:"no_confirm"`
+	// SelectionMarkers marks the selected row with ">>" and bold text
+	// rather than by color alone.
+	SelectionMarkers bool `toml:"selection_markers"`
+	// Versions picks which of several versions of a song (remasters, other
+	// formats, other profiles) Search and Library track lists show, the
+	// rest folded beneath it: one of VersionPreferences, or "off" to list
+	// every version.
+	Versions string `toml:"versions"`
+	// TrackColumns lists the columns of Library, Search and Queue track
+	// lists in order, each one of TrackColumnNames with an optional
+	// ":width", as in "album:24".
+	TrackColumns []string `toml:"track_columns"`
+	// QualityBadges marks track rows Hi-Res, Lossless or Lossy, and Mono.
+	QualityBadges bool `toml:"quality_badges"`
+	// Language is the language of the interface, one of i18n.Languages.
+	Language string `toml:"language"`
+	// Clock shows times of day on a "24h" or "12h" clock.
+	Clock string `toml:"clock"`
+	// Dates is "relative" to show how long ago things happened, as in
+	// "3 days ago", or "absolute" to show their dates.
+	Dates string `toml:"dates"`
+}
+
+// VersionPreferences are the values of [ui] versions.
+var VersionPreferences = []string{"lossless", "bitrate", "compact", "off"}
+
+// TrackColumnNames are the columns [ui] track_columns can list.
+var TrackColumnNames = []string{"track", "title", "artist", "album", "year", "duration", "codec", "bitrate", "rating", "plays"}
+
+// TrackColumn is a column of the track lists. A zero Width fits the
+// column's values, or for title, artist and album shares the room the
+// other columns leave.
+type TrackColumn struct {
+	Name  string
+	Width int
+}
+
+// ParseTrackColumns reads [ui] track_columns.
+func ParseTrackColumns(specs []string) ([]TrackColumn, error) {
+	cols := make([]TrackColumn, 0, len(specs))
+	for _, spec := range specs {
+		name, width, hasWidth := strings.Cut(strings.TrimSpace(spec), ":")
+		col := TrackColumn{Name: strings.ToLower(name)}
+		if !slices.Contains(TrackColumnNames, col.Name) {
+			return nil, fmt.Errorf("unknown column %q (use %s)", name, strings.Join(TrackColumnNames, ", "))
+		}
+		if slices.ContainsFunc(cols, func(c TrackColumn) bool { return c.Name == col.Name }) {
+			return nil, fmt.Errorf("column %q listed twice", name)
+		}
+		if hasWidth {
+			n, err := strconv.Atoi(width)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid width %q of column %q", width, name)
+			}
+			col.Width = n
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// SortConfig holds the sort order of each Library view, using the
+// provider.Sort* modes. Empty keeps the provider's default order.
+type SortConfig struct {
+	Artists string `toml:"artists"`
+	Albums  string `toml:"albums"`
+	Tracks  string `toml:"tracks"`
 }
 
 type PlayerConfig struct {
-	MPVPath         string `toml:"mpv_path"`
-	IPC             string `toml:"ipc"`
-	InitialVolume   int    `toml:"initial_volume"`
-	CacheSeconds    int    `toml:"cache_secs"`
-	NetworkTimeout  int    `toml:"network_timeout_ms"`
-	SeekSmall       int    `toml:"seek_small_seconds"`
-	SeekLarge       int    `toml:"seek_large_seconds"`
-	VolumeStep      int    `toml:"volume_step"`
+	MPVPath        string `toml:"mpv_path"`
+	IPC            string `toml:"ipc"`
+	InitialVolume  int    `toml:"initial_volume"`
+	CacheSeconds   int    `toml:"cache_secs"`
+	NetworkTimeout int    `toml:"network_timeout_ms"`
+	SeekSmall      int    `toml:"seek_small_seconds"`
+	SeekLarge      int    `toml:"seek_large_seconds"`
+	VolumeStep     int    `toml:"volume_step"`
+	// VolumeCurve is how the 0-100 volume maps to mpv's: "linear", or
+	// "cubic", which leaves finer steps at the quiet end. RestoreVolume
+	// starts at the volume Tunez last quit with instead of InitialVolume.
+	VolumeCurve     string `toml:"volume_curve"`
+	RestoreVolume   bool   `toml:"restore_volume"`
 	EnableAutostart bool   `toml:"autostart"`
+	AudioExclusive  bool   `toml:"audio_exclusive"` // bit-perfect output via mpv --audio-exclusive
+	// TrimSilence trims leading and trailing silence quieter than
+	// SilenceThresholdDB so tracks run into each other without gaps.
+	TrimSilence        bool    `toml:"trim_silence"`
+	SilenceThresholdDB float64 `toml:"silence_threshold_db"`
+	// Normalize evens out loudness across tracks: "off", "track" or
+	// "album" (stored ReplayGain tags), or "loudnorm" (EBU R128, measured
+	// while playing). TargetLUFS is the loudness aimed for.
+	Normalize  string  `toml:"normalize"`
+	TargetLUFS float64 `toml:"target_lufs"`
+	// StreamQuality is what remote providers are asked to transcode
+	// streams to ("raw", "mp3-320", "opus-128", ...); LowBandwidthQuality
+	// replaces it while low-bandwidth mode is on. LowBandwidth starts in
+	// that mode.
+	StreamQuality       string `toml:"stream_quality"`
+	LowBandwidthQuality string `toml:"low_bandwidth_quality"`
+	LowBandwidth        bool   `toml:"low_bandwidth"`
+	// PrefetchSeconds is how long before a track ends the next remote
+	// track is fetched ahead; a negative value turns prefetching off.
+	PrefetchSeconds int `toml:"prefetch_seconds"`
+	// FadeMs fades the volume out before pausing or skipping a track, and
+	// in after; 0 = no fade.
+	FadeMs int `toml:"fade_ms"`
+	// AutoPause pauses playback when the system is about to sleep or an
+	// audio output goes away.
+	AutoPause AutoPauseConfig `toml:"auto_pause"`
 }
 
+// AutoPauseConfig is [player.auto_pause]. Playback stays paused until it
+// is resumed by hand.
+type AutoPauseConfig struct {
+	Suspend       bool `toml:"suspend"`        // before the system suspends
+	DeviceRemoved bool `toml:"device_removed"` // when an audio output disappears, e.g. headphones
+}
+
+// VolumeCurves lists the accepted player.volume_curve values.
+var VolumeCurves = []string{"linear", "cubic"}
+
+// NormalizeModes lists the accepted player.normalize values.
+var NormalizeModes = []string{"off", "track", "album", "loudnorm"}
+
 // KeybindConfig allows customizing keybindings.
 type KeybindConfig struct {
-	PlayPause    string `toml:"play_pause"`
-	NextTrack    string `toml:"next_track"`
-	PrevTrack    string `toml:"prev_track"`
-	SeekForward  string `toml:"seek_forward"`
-	SeekBackward string `toml:"seek_backward"`
-	VolumeUp     string `toml:"volume_up"`
-	VolumeDown   string `toml:"volume_down"`
-	Mute         string `toml:"mute"`
-	Shuffle      string `toml:"shuffle"`
-	Repeat       string `toml:"repeat"`
-	Search       string `toml:"search"`
-	Help         string `toml:"help"`
-	Quit         string `toml:"quit"`
+	PlayPause     string `toml:"play_pause"`
+	NextTrack     string `toml:"next_track"`
+	PrevTrack     string `toml:"prev_track"`
+	SeekForward   string `toml:"seek_forward"`
+	SeekBackward  string `toml:"seek_backward"`
+	VolumeUp      string `toml:"volume_up"`
+	VolumeDown    string `toml:"volume_down"`
+	Mute          string `toml:"mute"`
+	Shuffle       string `toml:"shuffle"`
+	Repeat        string `toml:"repeat"`
+	Search        string `toml:"search"`
+	Love          string `toml:"love"`
+	ProfileSwitch string `toml:"profile_switch"`
+	QuickOpen     string `toml:"quick_open"`
+	Help          string `toml:"help"`
+	Zen           string `toml:"zen"`
+	Bookmark      string `toml:"bookmark"`
+	Bookmarks     string `toml:"bookmarks"`
+	NextChapter   string `toml:"next_chapter"`
+	PrevChapter   string `toml:"prev_chapter"`
+	Chapters      string `toml:"chapters"`
+	TrackInfo     string `toml:"track_info"`
+	GoToAlbum     string `toml:"go_to_album"`
+	GoToArtist    string `toml:"go_to_artist"`
+	NavBack       string `toml:"nav_back"`
+	NavForward    string `toml:"nav_forward"`
+	Quit          string `toml:"quit"`
 }
 
 type Profile struct {
@@ -96,6 +423,27 @@ type Profile struct {
 	Provider string         `toml:"provider"`
 	Enabled  bool           `toml:"enabled"`
 	Settings map[string]any `toml:"settings"`
+	// UI and Keybindings override the top-level tables while this profile
+	// is active. Keys left unset keep the top-level value.
+	UI          ProfileUIConfig     `toml:"ui"`
+	Keybindings KeybindConfig       `toml:"keybindings"`
+	Player      ProfilePlayerConfig `toml:"player"`
+}
+
+// ProfileUIConfig is the part of [ui] a profile can override.
+type ProfileUIConfig struct {
+	PageSize int        `toml:"page_size"`
+	Theme    string     `toml:"theme"`
+	Sort     SortConfig `toml:"sort"`
+}
+
+// ProfilePlayerConfig is the part of [player] a profile can override.
+type ProfilePlayerConfig struct {
+	InitialVolume       int     `toml:"initial_volume"`
+	Normalize           string  `toml:"normalize"`
+	TargetLUFS          float64 `toml:"target_lufs"`
+	StreamQuality       string  `toml:"stream_quality"`
+	LowBandwidthQuality string  `toml:"low_bandwidth_quality"`
 }
 
 // Load reads configuration from disk. If path is empty, a default OS-specific
@@ -110,23 +458,29 @@ func Load(path string) (*Config, string, error) {
 		}
 	}
 
-	data, err := os.ReadFile(cfgPath)
+	cfg, err := ReadFile(cfgPath)
 	if err != nil {
-		return nil, cfgPath, fmt.Errorf("read config: %w", err)
+		return nil, cfgPath, err
 	}
-
-	var cfg Config
-	if err := toml.Unmarshal(data, &cfg); err != nil {
-		return nil, cfgPath, fmt.Errorf("parse config: %w", err)
+	migration, err := migrateFile(cfgPath, cfg.ConfigVersion)
+	if err != nil {
+		return nil, cfgPath, err
+	}
+	if migration != nil {
+		if cfg, err = ReadFile(cfgPath); err != nil {
+			return nil, cfgPath, err
+		}
+		cfg.Migration = migration
 	}
 
-	applyDefaults(&cfg)
+	applyDefaults(cfg)
+	cfg.ApplyProfile(cfg.ActiveProfile)
 
-	if err := Validate(cfg); err != nil {
+	if err := Validate(*cfg); err != nil {
 		return nil, cfgPath, err
 	}
 
-	return &cfg, cfgPath, nil
+	return cfg, cfgPath, nil
 }
 
 func defaultPath() (string, error) {
@@ -158,18 +512,66 @@ func defaultPath() (string, error) {
 }
 
 func applyDefaults(cfg *Config) {
+	if cfg.ActiveProfile == "" && len(cfg.ActiveProfiles) > 0 {
+		cfg.ActiveProfile = cfg.ActiveProfiles[0]
+	}
 	if cfg.UI.PageSize == 0 {
 		cfg.UI.PageSize = 100
 	}
 	if cfg.UI.Theme == "" {
 		cfg.UI.Theme = "rainbow"
 	}
+	if cfg.UI.NowPlayingLayout == "" {
+		cfg.UI.NowPlayingLayout = "default"
+	}
+	if cfg.UI.Versions == "" {
+		cfg.UI.Versions = "lossless"
+	}
+	if cfg.UI.Language == "" {
+		cfg.UI.Language = i18n.Fallback
+	}
+	if cfg.UI.Clock == "" {
+		cfg.UI.Clock = "24h"
+	}
+	if cfg.UI.Dates == "" {
+		cfg.UI.Dates = "relative"
+	}
+	if len(cfg.UI.TrackColumns) == 0 {
+		cfg.UI.TrackColumns = []string{"track", "artist", "title", "duration"}
+	}
 	if cfg.Player.MPVPath == "" {
 		cfg.Player.MPVPath = "mpv"
 	}
+	if cfg.Queue.Shuffle.Mode == "" {
+		cfg.Queue.Shuffle.Mode = "random"
+	}
+	if sh := &cfg.Queue.Shuffle; sh.LovedWeight == 0 && sh.PlaysWeight == 0 && sh.RecencyWeight == 0 {
+		sh.LovedWeight, sh.PlaysWeight, sh.RecencyWeight = 2, 0.5, 1
+	}
 	if cfg.Player.InitialVolume == 0 {
 		cfg.Player.InitialVolume = 70
 	}
+	if cfg.Player.VolumeCurve == "" {
+		cfg.Player.VolumeCurve = "linear"
+	}
+	if cfg.Player.Normalize == "" {
+		cfg.Player.Normalize = "off"
+	}
+	if cfg.Player.TargetLUFS == 0 {
+		cfg.Player.TargetLUFS = -18
+	}
+	if cfg.Player.StreamQuality == "" {
+		cfg.Player.StreamQuality = "raw"
+	}
+	if cfg.Player.LowBandwidthQuality == "" {
+		cfg.Player.LowBandwidthQuality = "opus-64"
+	}
+	if cfg.Player.PrefetchSeconds == 0 {
+		cfg.Player.PrefetchSeconds = 15
+	}
+	if cfg.Player.SilenceThresholdDB == 0 {
+		cfg.Player.SilenceThresholdDB = -50
+	}
 	if cfg.Player.SeekSmall == 0 {
 		cfg.Player.SeekSmall = 5
 	}
@@ -216,6 +618,48 @@ func applyDefaults(cfg *Config) {
 	if cfg.Keybindings.Search == "" {
 		cfg.Keybindings.Search = "/"
 	}
+	if cfg.Keybindings.Love == "" {
+		cfg.Keybindings.Love = "F"
+	}
+	if cfg.Keybindings.ProfileSwitch == "" {
+		cfg.Keybindings.ProfileSwitch = "ctrl+o"
+	}
+	if cfg.Keybindings.QuickOpen == "" {
+		cfg.Keybindings.QuickOpen = "ctrl+k"
+	}
+	if cfg.Keybindings.Zen == "" {
+		cfg.Keybindings.Zen = "z"
+	}
+	if cfg.Keybindings.Bookmark == "" {
+		cfg.Keybindings.Bookmark = "b"
+	}
+	if cfg.Keybindings.Bookmarks == "" {
+		cfg.Keybindings.Bookmarks = "B"
+	}
+	if cfg.Keybindings.NextChapter == "" {
+		cfg.Keybindings.NextChapter = "]"
+	}
+	if cfg.Keybindings.PrevChapter == "" {
+		cfg.Keybindings.PrevChapter = "["
+	}
+	if cfg.Keybindings.Chapters == "" {
+		cfg.Keybindings.Chapters = "E"
+	}
+	if cfg.Keybindings.TrackInfo == "" {
+		cfg.Keybindings.TrackInfo = "i"
+	}
+	if cfg.Keybindings.GoToAlbum == "" {
+		cfg.Keybindings.GoToAlbum = "ctrl+a"
+	}
+	if cfg.Keybindings.GoToArtist == "" {
+		cfg.Keybindings.GoToArtist = "ctrl+r"
+	}
+	if cfg.Keybindings.NavBack == "" {
+		cfg.Keybindings.NavBack = "alt+left"
+	}
+	if cfg.Keybindings.NavForward == "" {
+		cfg.Keybindings.NavForward = "alt+right"
+	}
 	if cfg.Keybindings.Help == "" {
 		cfg.Keybindings.Help = "?"
 	}
@@ -228,6 +672,9 @@ func applyDefaults(cfg *Config) {
 		// Note: TOML will parse missing as false, so we treat missing as "use default"
 		cfg.Queue.Persist = true
 	}
+	if cfg.Queue.MaxSize == 0 {
+		cfg.Queue.MaxSize = 100000
+	}
 	// Artwork defaults - enabled by default
 	if !cfg.Artwork.Enabled {
 		cfg.Artwork.Enabled = true
@@ -247,6 +694,45 @@ func applyDefaults(cfg *Config) {
 	if cfg.Artwork.CacheDays == 0 {
 		cfg.Artwork.CacheDays = 30
 	}
+	if cfg.Artwork.CacheMaxMB == 0 {
+		cfg.Artwork.CacheMaxMB = 500
+	}
+	if cfg.Metrics.Listen == "" {
+		cfg.Metrics.Listen = "127.0.0.1:9464"
+	}
+	if cfg.About.Language == "" {
+		cfg.About.Language = "en"
+	}
+	if cfg.About.CacheDays == 0 {
+		cfg.About.CacheDays = 30
+	}
+	if cfg.Audiobooks.Genres == nil {
+		cfg.Audiobooks.Genres = []string{"Audiobook", "Audiobooks", "Spoken Word"}
+	}
+	if cfg.Audiobooks.Speed == 0 {
+		cfg.Audiobooks.Speed = 1
+	}
+	if cfg.Visualizer.Backend == "" {
+		cfg.Visualizer.Backend = "cava"
+	}
+	if cfg.Visualizer.Style == "" {
+		cfg.Visualizer.Style = visualizer.StyleBars
+	}
+	if cfg.Visualizer.Input == "" {
+		cfg.Visualizer.Input = "auto"
+	}
+	if cfg.Logging.Level == "" {
+		cfg.Logging.Level = "debug"
+	}
+	if cfg.Logging.Format == "" {
+		cfg.Logging.Format = "text"
+	}
+	if cfg.Logging.MaxSizeMB == 0 {
+		cfg.Logging.MaxSizeMB = 10
+	}
+	if cfg.Logging.MaxBackups == 0 {
+		cfg.Logging.MaxBackups = 3
+	}
 }
 
 // Validate performs semantic validation of config according to docs/CONFIG.md.
@@ -261,9 +747,145 @@ func Validate(cfg Config) error {
 	if !profile.Enabled {
 		return fmt.Errorf("active_profile %q is disabled", cfg.ActiveProfile)
 	}
+	for _, id := range cfg.ActiveProfiles {
+		p, ok := cfg.ProfileByID(id)
+		if !ok {
+			return fmt.Errorf("active_profiles: %q not found", id)
+		}
+		if !p.Enabled {
+			return fmt.Errorf("active_profiles: %q is disabled", id)
+		}
+		if err := validateProvider(p); err != nil {
+			return err
+		}
+	}
+	if err := validateSort("ui.sort", cfg.UI.Sort); err != nil {
+		return err
+	}
+	for _, p := range cfg.Profiles {
+		if p.UI.PageSize < 0 {
+			return fmt.Errorf("profile %q: ui.page_size must not be negative", p.ID)
+		}
+		if err := validateSort(fmt.Sprintf("profile %q: ui.sort", p.ID), p.UI.Sort); err != nil {
+			return err
+		}
+		if err := validatePlayer(fmt.Sprintf("profile %q: player", p.ID), p.Player); err != nil {
+			return err
+		}
+	}
+	seen := make(map[string]bool)
+	for i, c := range cfg.Commands {
+		name := strings.TrimSpace(c.Name)
+		if name == "" {
+			return fmt.Errorf("commands[%d]: name is required", i)
+		}
+		if seen[strings.ToLower(name)] {
+			return fmt.Errorf("commands[%d]: duplicate name %q", i, name)
+		}
+		seen[strings.ToLower(name)] = true
+		if len(c.Steps) == 0 {
+			return fmt.Errorf("commands[%d] (%s): steps are required", i, name)
+		}
+	}
+	if cfg.Export.Template != "" {
+		if _, err := template.New("export").Parse(cfg.Export.Template); err != nil {
+			return fmt.Errorf("export.template: %w", err)
+		}
+	}
+	if cfg.Library.OrganizePattern != "" {
+		if _, err := template.New("organize").Parse(cfg.Library.OrganizePattern); err != nil {
+			return fmt.Errorf("library.organize_pattern: %w", err)
+		}
+	}
+	if err := validatePlayer("player", cfg.Player.profile()); err != nil {
+		return err
+	}
+	if cfg.Player.SilenceThresholdDB > 0 {
+		return fmt.Errorf("player.silence_threshold_db must be negative, got %g", cfg.Player.SilenceThresholdDB)
+	}
+	if cfg.UI.ZenIdleSeconds < 0 {
+		return errors.New("ui.zen_idle_seconds must not be negative")
+	}
+	switch cfg.UI.NowPlayingLayout {
+	case "", "default", "lyrics":
+	default:
+		return fmt.Errorf("ui.now_playing_layout must be default or lyrics, got %q", cfg.UI.NowPlayingLayout)
+	}
+	if v := cfg.UI.Versions; v != "" && !slices.Contains(VersionPreferences, v) {
+		return fmt.Errorf("ui.versions must be one of %v, got %q", VersionPreferences, v)
+	}
+	if _, err := ParseTrackColumns(cfg.UI.TrackColumns); err != nil {
+		return fmt.Errorf("ui.track_columns: %w", err)
+	}
+	if l := cfg.UI.Language; l != "" && !i18n.Valid(l) {
+		return fmt.Errorf("ui.language must be one of %v, got %q", i18n.Languages(), l)
+	}
+	switch cfg.UI.Clock {
+	case "", "24h", "12h":
+	default:
+		return fmt.Errorf("ui.clock must be 24h or 12h, got %q", cfg.UI.Clock)
+	}
+	switch cfg.UI.Dates {
+	case "", "relative", "absolute":
+	default:
+		return fmt.Errorf("ui.dates must be relative or absolute, got %q", cfg.UI.Dates)
+	}
+	if s := cfg.About.Source; s != "" && s != "lastfm" && s != "wikipedia" {
+		return fmt.Errorf("about.source must be lastfm or wikipedia, got %q", s)
+	}
+	if sp := cfg.Audiobooks.Speed; sp != 0 && (sp < 0.25 || sp > 4) {
+		return fmt.Errorf("audiobooks.speed must be between 0.25 and 4, got %g", sp)
+	}
+	if cfg.Hooks.TimeoutMs < 0 {
+		return errors.New("hooks.timeout_ms must not be negative")
+	}
+	if cfg.Plugins.TimeoutMs < 0 {
+		return errors.New("plugins.timeout_ms must not be negative")
+	}
+	if l := strings.ToLower(cfg.Logging.Level); l != "" && !slices.Contains([]string{"debug", "info", "warn", "error"}, l) {
+		return fmt.Errorf("logging.level must be debug, info, warn or error, got %q", cfg.Logging.Level)
+	}
+	if f := cfg.Logging.Format; f != "" && f != "text" && f != "json" {
+		return fmt.Errorf("logging.format must be text or json, got %q", f)
+	}
+	if cfg.Logging.MaxSizeMB < 0 || cfg.Logging.MaxBackups < 0 {
+		return errors.New("logging.max_size_mb and logging.max_backups must not be negative")
+	}
+	if b := cfg.Visualizer.Backend; b != "" && b != visualizer.BackendCava && b != visualizer.BackendBuiltin && b != visualizer.BackendOff {
+		return fmt.Errorf("visualizer.backend must be cava, builtin or off, got %q", b)
+	}
+	if st := cfg.Visualizer.Style; st != "" && !slices.Contains(visualizer.AllStyles, st) {
+		return fmt.Errorf("visualizer.style must be one of %s, got %q", strings.Join(visualizer.AllStyles, ", "), st)
+	}
+	if in := cfg.Visualizer.Input; in != "" && !slices.Contains(visualizer.Inputs, in) {
+		return fmt.Errorf("visualizer.input must be one of %s, got %q", strings.Join(visualizer.Inputs, ", "), in)
+	}
+	if cfg.Visualizer.Input == "fifo" && cfg.Visualizer.Source == "" {
+		return errors.New("visualizer.source is required with input = \"fifo\"")
+	}
+	if cfg.Queue.Skip.ShorterThanSeconds < 0 {
+		return errors.New("queue.skip.shorter_than_seconds must not be negative")
+	}
+	for _, p := range cfg.Queue.Skip.TitlePatterns {
+		if _, err := regexp.Compile("(?i)" + p); err != nil {
+			return fmt.Errorf("queue.skip.title_patterns: %w", err)
+		}
+	}
+	if mode := cfg.Queue.Shuffle.Mode; mode != "" && !slices.Contains(ShuffleModes, mode) {
+		return fmt.Errorf("queue.shuffle.mode must be one of %v, got %q", ShuffleModes, mode)
+	}
+	if cfg.Queue.Shuffle.LovedWeight < 0 || cfg.Queue.Shuffle.RecencyWeight < 0 {
+		return errors.New("queue.shuffle.loved_weight and recency_weight must not be negative")
+	}
 	if cfg.Player.InitialVolume < 0 || cfg.Player.InitialVolume > 100 {
 		return fmt.Errorf("player.initial_volume must be 0-100")
 	}
+	if cfg.Player.FadeMs < 0 || cfg.Player.FadeMs > 5000 {
+		return fmt.Errorf("player.fade_ms must be 0-5000, got %d", cfg.Player.FadeMs)
+	}
+	if c := cfg.Player.VolumeCurve; c != "" && !slices.Contains(VolumeCurves, c) {
+		return fmt.Errorf("player.volume_curve must be one of %v, got %q", VolumeCurves, c)
+	}
 	if _, err := os.Stat(cfg.Player.MPVPath); err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			if _, lookErr := execLookPath(cfg.Player.MPVPath); lookErr != nil {
@@ -272,19 +894,66 @@ func Validate(cfg Config) error {
 		}
 	}
 
+	return validateProvider(profile)
+}
+
+func validateSort(prefix string, sort SortConfig) error {
+	for _, v := range []struct {
+		name, mode string
+		allowed    []string
+	}{
+		{"artists", sort.Artists, provider.ArtistSorts},
+		{"albums", sort.Albums, provider.AlbumSorts},
+		{"tracks", sort.Tracks, provider.TrackSorts},
+	} {
+		if v.mode != "" && !slices.Contains(v.allowed, v.mode) {
+			return fmt.Errorf("%s.%s must be one of %v", prefix, v.name, v.allowed)
+		}
+	}
+	return nil
+}
+
+// validatePlayer checks normalize, target_lufs and the stream qualities
+// under prefix; unset values are allowed.
+func validatePlayer(prefix string, p ProfilePlayerConfig) error {
+	if p.InitialVolume < 0 || p.InitialVolume > 100 {
+		return fmt.Errorf("%s.initial_volume must be 0-100", prefix)
+	}
+	if p.Normalize != "" && !slices.Contains(NormalizeModes, p.Normalize) {
+		return fmt.Errorf("%s.normalize must be one of %v", prefix, NormalizeModes)
+	}
+	if p.TargetLUFS != 0 && (p.TargetLUFS < -70 || p.TargetLUFS > -5) {
+		return fmt.Errorf("%s.target_lufs must be between -70 and -5, got %g", prefix, p.TargetLUFS)
+	}
+	if _, err := provider.ParseStreamQuality(p.StreamQuality); err != nil {
+		return fmt.Errorf("%s.stream_quality: %w", prefix, err)
+	}
+	if _, err := provider.ParseStreamQuality(p.LowBandwidthQuality); err != nil {
+		return fmt.Errorf("%s.low_bandwidth_quality: %w", prefix, err)
+	}
+	return nil
+}
+
+// profile returns the part of [player] a profile can override.
+func (p PlayerConfig) profile() ProfilePlayerConfig {
+	return ProfilePlayerConfig{
+		InitialVolume:       p.InitialVolume,
+		Normalize:           p.Normalize,
+		TargetLUFS:          p.TargetLUFS,
+		StreamQuality:       p.StreamQuality,
+		LowBandwidthQuality: p.LowBandwidthQuality,
+	}
+}
+
+func validateProvider(profile Profile) error {
 	switch profile.Provider {
 	case "filesystem":
-		if err := validateFilesystem(profile.Settings); err != nil {
-			return err
-		}
+		return validateFilesystem(profile.Settings)
 	case "melodee":
-		if err := validateMelodee(profile.Settings); err != nil {
-			return err
-		}
+		return validateMelodee(profile.Settings)
 	default:
 		return fmt.Errorf("unknown provider: %s", profile.Provider)
 	}
-	return nil
 }
 
 func validateFilesystem(settings map[string]any) error {
@@ -301,6 +970,17 @@ func validateFilesystem(settings map[string]any) error {
 			return fmt.Errorf("filesystem root %s: %w", s, err)
 		}
 	}
+	if v, ok := settings["drop_folder"]; ok {
+		s, _ := v.(string)
+		if s == "" {
+			return errors.New("filesystem.drop_folder must be a directory path")
+		}
+		if info, err := os.Stat(s); err != nil {
+			return fmt.Errorf("filesystem drop_folder %s: %w", s, err)
+		} else if !info.IsDir() {
+			return fmt.Errorf("filesystem drop_folder %s: not a directory", s)
+		}
+	}
 	return nil
 }
 
@@ -312,6 +992,22 @@ func validateMelodee(settings map[string]any) error {
 	return nil
 }
 
+// ReadFile parses the config file as written, without defaults or
+// validation. Tools that rewrite the file use it to see the raw values.
+func ReadFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+
+	var cfg Config
+	if err := toml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parse config: %w", err)
+	}
+	cfg.Path = path
+	return &cfg, nil
+}
+
 // ProfileByID returns profile and true when found.
 func (c Config) ProfileByID(id string) (Profile, bool) {
 	for _, p := range c.Profiles {
@@ -322,6 +1018,105 @@ func (c Config) ProfileByID(id string) (Profile, bool) {
 	return Profile{}, false
 }
 
+// ApplyProfile sets UI and Keybindings to the top-level [ui] and
+// [keybindings] with the overrides of profile id on top. Load applies the
+// active profile; switching profiles applies the new one.
+func (c *Config) ApplyProfile(id string) {
+	if c.base == nil {
+		c.base = &profileBase{
+			UI:          c.UI,
+			Keybindings: c.Keybindings,
+			Player:      c.Player.profile(),
+		}
+	}
+	c.UI = c.base.UI
+	c.Keybindings = c.base.Keybindings
+	c.Player.InitialVolume = c.base.Player.InitialVolume
+	c.Player.Normalize, c.Player.TargetLUFS = c.base.Player.Normalize, c.base.Player.TargetLUFS
+	c.Player.StreamQuality, c.Player.LowBandwidthQuality = c.base.Player.StreamQuality, c.base.Player.LowBandwidthQuality
+	p, ok := c.ProfileByID(id)
+	if !ok {
+		return
+	}
+	if p.UI.PageSize > 0 {
+		c.UI.PageSize = p.UI.PageSize
+	}
+	if p.UI.Theme != "" {
+		c.UI.Theme = p.UI.Theme
+	}
+	c.UI.Sort = mergeStrings(c.UI.Sort, p.UI.Sort)
+	c.Keybindings = mergeStrings(c.Keybindings, p.Keybindings)
+	if p.Player.InitialVolume > 0 {
+		c.Player.InitialVolume = p.Player.InitialVolume
+	}
+	if p.Player.Normalize != "" {
+		c.Player.Normalize = p.Player.Normalize
+	}
+	if p.Player.TargetLUFS != 0 {
+		c.Player.TargetLUFS = p.Player.TargetLUFS
+	}
+	if p.Player.StreamQuality != "" {
+		c.Player.StreamQuality = p.Player.StreamQuality
+	}
+	if p.Player.LowBandwidthQuality != "" {
+		c.Player.LowBandwidthQuality = p.Player.LowBandwidthQuality
+	}
+}
+
+// SetSort changes the sort mode of a Library view ("artists", "albums" or
+// "tracks") for every profile, as saving it to [ui.sort] does.
+func (c *Config) SetSort(view, mode string) {
+	for _, ui := range []*UIConfig{&c.UI, c.baseUI()} {
+		switch view {
+		case "tracks":
+			ui.Sort.Tracks = mode
+		case "albums":
+			ui.Sort.Albums = mode
+		default:
+			ui.Sort.Artists = mode
+		}
+	}
+}
+
+func (c *Config) baseUI() *UIConfig {
+	if c.base == nil {
+		return &UIConfig{}
+	}
+	return &c.base.UI
+}
+
+// mergeStrings returns base with every non-empty string field of override
+// copied over it. T must be a struct of strings.
+func mergeStrings[T any](base, override T) T {
+	dst := reflect.ValueOf(&base).Elem()
+	src := reflect.ValueOf(override)
+	for i := range src.NumField() {
+		if v := src.Field(i).String(); v != "" {
+			dst.Field(i).SetString(v)
+		}
+	}
+	return base
+}
+
+// NoColor reports whether the UI should be drawn without color: NO_COLOR is
+// set or ui.no_emoji is on.
+func (c Config) NoColor() bool {
+	return os.Getenv("NO_COLOR") != "" || c.UI.NoEmoji
+}
+
+// Merged reports whether several profiles are browsed as one library.
+func (c Config) Merged() bool { return len(c.ActiveProfiles) > 1 }
+
+// LastfmScrobbler returns the first scrobbler entry of type "lastfm".
+func (c Config) LastfmScrobbler() (ScrobblerEntry, bool) {
+	for _, s := range c.Scrobblers {
+		if s.Type == "lastfm" {
+			return s, true
+		}
+	}
+	return ScrobblerEntry{}, false
+}
+
 // DeadlineContext returns a context with default timeout based on player network timeout.
 func (c Config) DeadlineContext() (context.Context, context.CancelFunc) {
 	d := time.Duration(c.Player.NetworkTimeout) * time.Millisecond
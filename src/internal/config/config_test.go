@@ -2,6 +2,8 @@ package config
 
 import (
 	"os"
+	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -62,6 +64,281 @@ func TestValidate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "merged profiles",
+			cfg: Config{
+				ActiveProfile:  "local",
+				ActiveProfiles: []string{"local", "server"},
+				Player:         PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+					{ID: "server", Enabled: true, Provider: "melodee", Settings: map[string]any{"base_url": "https://music.example.com"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "merged profile disabled",
+			cfg: Config{
+				ActiveProfile:  "local",
+				ActiveProfiles: []string{"local", "server"},
+				Player:         PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+					{ID: "server", Enabled: false, Provider: "melodee", Settings: map[string]any{"base_url": "https://music.example.com"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "user command without steps",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Commands: []UserCommand{{Name: "Evening chill"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "duplicate user command",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Commands: []UserCommand{
+					{Name: "Evening chill", Steps: []string{"queue.clear"}},
+					{Name: "evening chill", Steps: []string{"playback.play"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative plugin timeout",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Plugins: PluginsConfig{Enabled: true, TimeoutMs: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "audiobook speed out of range",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Audiobooks: AudiobooksConfig{Speed: 10},
+			},
+			wantErr: true,
+		},
+		{
+			name: "bad organize pattern",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Library: LibraryConfig{OrganizePattern: "{{.Artist"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "negative hook timeout",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Hooks: HooksConfig{TrackChange: "true", TimeoutMs: -1},
+			},
+			wantErr: true,
+		},
+		{
+			name: "malformed export template",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Export: ExportConfig{Path: "now.txt", Template: "{{.Title"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown visualizer input",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Visualizer: VisualizerConfig{Input: "coreaudio"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fifo visualizer input without source",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+				Visualizer: VisualizerConfig{Input: "fifo"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "positive silence threshold",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath, TrimSilence: true, SilenceThresholdDB: 3},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown normalize mode",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath, Normalize: "r128"},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown volume curve",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath, VolumeCurve: "log"},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid skip title pattern",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Queue:         QueueConfig{Skip: SkipConfig{TitlePatterns: []string{"intro", "(skit"}}},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown shuffle mode",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Queue:         QueueConfig{Shuffle: ShuffleConfig{Mode: "smart"}},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown version preference",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				UI:            UIConfig{Versions: "newest"},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown track column",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				UI:            UIConfig{TrackColumns: []string{"title", "composer:20"}},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown language",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				UI:            UIConfig{Language: "fr"},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid clock",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				UI:            UIConfig{Clock: "13h"},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid profile stream quality",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath, StreamQuality: "opus-128"},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings, Player: ProfilePlayerConfig{StreamQuality: "flac-900"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "profile target lufs out of range",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings, Player: ProfilePlayerConfig{TargetLUFS: 3}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid profile sort",
+			cfg: Config{
+				ActiveProfile: "local",
+				Player:        PlayerConfig{MPVPath: mpvPath},
+				Profiles: []Profile{
+					{ID: "local", Enabled: true, Provider: "filesystem", Settings: validSettings, UI: ProfileUIConfig{Sort: SortConfig{Albums: "bogus"}}},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid mpv path",
 			cfg: Config{
@@ -84,3 +361,127 @@ func TestValidate(t *testing.T) {
 		})
 	}
 }
+
+func TestApplyProfile(t *testing.T) {
+	cfg := &Config{
+		UI:          UIConfig{PageSize: 100, Theme: "rainbow", Sort: SortConfig{Artists: "name"}},
+		Keybindings: KeybindConfig{PlayPause: "space", NextTrack: "n"},
+		Profiles: []Profile{
+			{ID: "home"},
+			{ID: "server", UI: ProfileUIConfig{PageSize: 25, Theme: "nord", Sort: SortConfig{Albums: "year"}}, Keybindings: KeybindConfig{NextTrack: "N"}},
+		},
+	}
+
+	cfg.ApplyProfile("server")
+	want := UIConfig{PageSize: 25, Theme: "nord", Sort: SortConfig{Artists: "name", Albums: "year"}}
+	if !reflect.DeepEqual(cfg.UI, want) {
+		t.Errorf("expected server overrides %+v, got %+v", want, cfg.UI)
+	}
+	if cfg.Keybindings.NextTrack != "N" || cfg.Keybindings.PlayPause != "space" {
+		t.Errorf("expected only next_track overridden, got %+v", cfg.Keybindings)
+	}
+
+	// Sort changes made while on one profile carry over to the others
+	cfg.SetSort("tracks", "duration")
+
+	// Switching back restores the top-level values
+	cfg.ApplyProfile("home")
+	want = UIConfig{PageSize: 100, Theme: "rainbow", Sort: SortConfig{Artists: "name", Tracks: "duration"}}
+	if !reflect.DeepEqual(cfg.UI, want) {
+		t.Errorf("expected top-level ui %+v, got %+v", want, cfg.UI)
+	}
+	if cfg.Keybindings.NextTrack != "n" {
+		t.Errorf("expected top-level next_track, got %q", cfg.Keybindings.NextTrack)
+	}
+}
+
+func TestApplyProfileNormalize(t *testing.T) {
+	cfg := &Config{
+		Player: PlayerConfig{Normalize: "off", TargetLUFS: -18, TrimSilence: true},
+		Profiles: []Profile{
+			{ID: "home"},
+			{ID: "classical", Player: ProfilePlayerConfig{Normalize: "loudnorm", TargetLUFS: -23}},
+		},
+	}
+
+	cfg.ApplyProfile("classical")
+	if cfg.Player.Normalize != "loudnorm" || cfg.Player.TargetLUFS != -23 {
+		t.Errorf("expected the profile's normalization, got %q at %g", cfg.Player.Normalize, cfg.Player.TargetLUFS)
+	}
+
+	// Other player settings, such as runtime toggles, are left alone
+	cfg.Player.TrimSilence = false
+	cfg.ApplyProfile("home")
+	if cfg.Player.Normalize != "off" || cfg.Player.TargetLUFS != -18 {
+		t.Errorf("expected the top-level normalization, got %q at %g", cfg.Player.Normalize, cfg.Player.TargetLUFS)
+	}
+	if cfg.Player.TrimSilence {
+		t.Error("expected switching profiles to keep trim_silence as toggled")
+	}
+}
+
+func TestApplyProfileStreamQuality(t *testing.T) {
+	cfg := &Config{
+		Player: PlayerConfig{StreamQuality: "raw", LowBandwidthQuality: "opus-64"},
+		Profiles: []Profile{
+			{ID: "home"},
+			{ID: "travel", Player: ProfilePlayerConfig{StreamQuality: "mp3-320"}},
+		},
+	}
+	cfg.ApplyProfile("travel")
+	if cfg.Player.StreamQuality != "mp3-320" || cfg.Player.LowBandwidthQuality != "opus-64" {
+		t.Errorf("expected the profile's stream quality, got %+v", cfg.Player)
+	}
+	cfg.ApplyProfile("home")
+	if cfg.Player.StreamQuality != "raw" {
+		t.Errorf("expected the top-level stream quality, got %q", cfg.Player.StreamQuality)
+	}
+}
+
+func TestApplyProfileVolume(t *testing.T) {
+	cfg := &Config{
+		Player: PlayerConfig{InitialVolume: 70},
+		Profiles: []Profile{
+			{ID: "home"},
+			{ID: "office", Player: ProfilePlayerConfig{InitialVolume: 25}},
+		},
+	}
+	cfg.ApplyProfile("office")
+	if cfg.Player.InitialVolume != 25 {
+		t.Errorf("expected the profile's volume, got %d", cfg.Player.InitialVolume)
+	}
+	cfg.ApplyProfile("home")
+	if cfg.Player.InitialVolume != 70 {
+		t.Errorf("expected the top-level volume, got %d", cfg.Player.InitialVolume)
+	}
+}
+
+func TestRedacted(t *testing.T) {
+	cfg := &Config{
+		ActiveProfile: "home",
+		Profiles: []Profile{{ID: "home", Provider: "melodee", Settings: map[string]any{
+			"base_url": "https://music.example.com",
+			"username": "ada",
+			"password": "hunter2",
+		}}},
+		Scrobblers: []ScrobblerEntry{{ID: "lastfm", Type: "lastfm", Settings: map[string]any{
+			"api_key":     "abc123",
+			"api_secret":  "def456",
+			"session_key": "",
+		}}},
+	}
+	out, err := cfg.Redacted()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"hunter2", "abc123", "def456"} {
+		if strings.Contains(out, secret) {
+			t.Errorf("expected %q to be redacted:\n%s", secret, out)
+		}
+	}
+	for _, want := range []string{"https://music.example.com", "ada", "REDACTED", "session_key = ''"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in:\n%s", want, out)
+		}
+	}
+}
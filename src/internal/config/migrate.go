@@ -0,0 +1,316 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// CurrentVersion is the config_version this build reads. Older files are
+// migrated on load; newer ones are refused.
+const CurrentVersion = 1
+
+// migration upgrades a config from version from to from+1. apply makes the
+// change on the decoded document and reports whether it changed anything;
+// edit makes the same change to the file's lines, so comments and layout
+// are kept.
+type migration struct {
+	from     int
+	describe string
+	apply    func(doc map[string]any) bool
+	edit     func(lines []string) []string
+}
+
+// migrations run in order. Every migration with from at or above the file's
+// version is applied.
+var migrations = []migration{
+	{from: 0, describe: "rename [player] keys to their unit-suffixed names", apply: renamePlayerKeys, edit: renamePlayerLines},
+	{from: 0, describe: "move [scrobble.<type>] tables to [[scrobblers]]", apply: moveScrobblerTables, edit: moveScrobblerLines},
+}
+
+// ErrNewerVersion is returned by Load for a config written by a newer Tunez.
+var ErrNewerVersion = errors.New("config is newer than this tunez supports")
+
+// Migration describes an upgrade Load applied to the config file.
+type Migration struct {
+	From, To int
+	Steps    []string // what changed, one entry per migration that did something
+	Backup   string   // copy of the file before it was rewritten
+	// Reformatted is set when the file had to be re-encoded, losing its
+	// comments and layout, because the changes couldn't be made in place.
+	Reformatted bool
+}
+
+// migrateFile upgrades the config at path to CurrentVersion. The original
+// is copied to <path>.v<N>.bak before being rewritten. Files whose layout
+// needed no changes are left alone. It returns nil when nothing was
+// rewritten.
+func migrateFile(path string, version int) (*Migration, error) {
+	if version > CurrentVersion {
+		return nil, fmt.Errorf("%w: config_version %d, supported %d; upgrade tunez or restore an older config", ErrNewerVersion, version, CurrentVersion)
+	}
+	if version == CurrentVersion {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config: %w", err)
+	}
+	updated, steps, reformatted, err := migrate(data, version)
+	if err != nil || len(steps) == 0 {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("stat config: %w", err)
+	}
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if err := os.WriteFile(backup, data, info.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("back up config: %w", err)
+	}
+	if err := writeFileAtomic(path, updated, info.Mode().Perm()); err != nil {
+		return nil, err
+	}
+	return &Migration{From: version, To: CurrentVersion, Steps: steps, Backup: backup, Reformatted: reformatted}, nil
+}
+
+// migrate applies every migration from version up and returns the upgraded
+// file and the steps that changed something. The file is edited line by
+// line like SetSetting does, so comments are kept; when that doesn't give
+// the same document as the decoded upgrade, e.g. for keys written as inline
+// tables, it is re-encoded instead and reformatted is true.
+func migrate(data []byte, version int) (out []byte, steps []string, reformatted bool, err error) {
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return nil, nil, false, fmt.Errorf("parse config: %w", err)
+	}
+	lines := strings.Split(string(data), "\n")
+	for _, m := range migrations {
+		if m.from >= version && m.apply(doc) {
+			steps = append(steps, m.describe)
+			lines = m.edit(lines)
+		}
+	}
+	doc["config_version"] = int64(CurrentVersion)
+	lines = setTopLevel(lines, "config_version", strconv.Itoa(CurrentVersion))
+
+	out = []byte(strings.Join(lines, "\n"))
+	var edited map[string]any
+	if err := toml.Unmarshal(out, &edited); err != nil || !sameDoc(edited, doc) {
+		if out, err = toml.Marshal(doc); err != nil {
+			return nil, nil, false, fmt.Errorf("encode config: %w", err)
+		}
+		reformatted = true
+	}
+	// Refuse to write something we can no longer parse
+	var check Config
+	if err := toml.Unmarshal(out, &check); err != nil {
+		return nil, nil, false, fmt.Errorf("migrated config is invalid: %w", err)
+	}
+	return out, steps, reformatted, nil
+}
+
+// sameDoc reports whether two decoded configs are equal. [[scrobblers]]
+// entries are compared by id, since moved tables keep their place in the
+// file rather than going after the existing entries.
+func sameDoc(a, b map[string]any) bool {
+	for _, doc := range []map[string]any{a, b} {
+		if entries, ok := doc["scrobblers"].([]any); ok {
+			slices.SortStableFunc(entries, func(x, y any) int {
+				xm, _ := x.(map[string]any)
+				ym, _ := y.(map[string]any)
+				xid, _ := xm["id"].(string)
+				yid, _ := ym["id"].(string)
+				return strings.Compare(xid, yid)
+			})
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// setTopLevel writes key = literal among the keys before the first table,
+// above the first of them so it sits under any opening comments.
+func setTopLevel(lines []string, key, literal string) []string {
+	entry := key + " = " + literal
+	at := len(lines)
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "[") {
+			if at < len(lines) {
+				break
+			}
+			// No top-level keys: go above the table and any comment heading it
+			for at = i; at > 0 && strings.HasPrefix(strings.TrimSpace(lines[at-1]), "#"); at-- {
+			}
+			return slices.Insert(lines, at, entry, "")
+		}
+		if k, _, ok := keyValue(trimmed); ok && k == key {
+			lines[i] = entry
+			return lines
+		}
+		if at == len(lines) {
+			at = i
+		}
+	}
+	return slices.Insert(lines, at, entry)
+}
+
+// tableEnd returns the line after the body of the table whose header is at
+// start.
+func tableEnd(lines []string, start int) int {
+	for i := start + 1; i < len(lines); i++ {
+		if strings.HasPrefix(strings.TrimSpace(lines[i]), "[") {
+			return i
+		}
+	}
+	return len(lines)
+}
+
+// renamePlayerKeys renames the [player] keys of pre-versioned configs:
+// network_timeout, seek_small and seek_large gained unit suffixes.
+func renamePlayerKeys(doc map[string]any) bool {
+	player, ok := doc["player"].(map[string]any)
+	if !ok {
+		return false
+	}
+	changed := false
+	for old, key := range map[string]string{
+		"network_timeout": "network_timeout_ms",
+		"seek_small":      "seek_small_seconds",
+		"seek_large":      "seek_large_seconds",
+	} {
+		v, ok := player[old]
+		if !ok {
+			continue
+		}
+		delete(player, old)
+		if _, exists := player[key]; !exists {
+			player[key] = v
+		}
+		changed = true
+	}
+	return changed
+}
+
+// renamePlayerLines is renamePlayerKeys for the file's lines.
+func renamePlayerLines(lines []string) []string {
+	renames := map[string]string{
+		"network_timeout": "network_timeout_ms",
+		"seek_small":      "seek_small_seconds",
+		"seek_large":      "seek_large_seconds",
+	}
+	start := slices.IndexFunc(lines, func(line string) bool {
+		return tableHeader(strings.TrimSpace(line)) == "[player]"
+	})
+	if start < 0 {
+		return lines
+	}
+	end := tableEnd(lines, start)
+	present := map[string]bool{}
+	for _, line := range lines[start+1 : end] {
+		if k, _, ok := keyValue(strings.TrimSpace(line)); ok {
+			present[k] = true
+		}
+	}
+	out := slices.Clone(lines[:start+1])
+	for _, line := range lines[start+1 : end] {
+		k, _, ok := keyValue(strings.TrimSpace(line))
+		key, rename := renames[k]
+		switch {
+		case !ok || !rename:
+		case present[key]:
+			continue // the new key wins, as in renamePlayerKeys
+		default:
+			indent := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+			line = indent + key + strings.TrimPrefix(line[len(indent):], k)
+		}
+		out = append(out, line)
+	}
+	return append(out, lines[end:]...)
+}
+
+// moveScrobblerTables turns the per-type [scrobble.lastfm] and
+// [scrobble.melodee] tables of pre-versioned configs into [[scrobblers]]
+// entries. [scrobble] keeps only its enabled switch.
+func moveScrobblerTables(doc map[string]any) bool {
+	scrobble, ok := doc["scrobble"].(map[string]any)
+	if !ok {
+		return false
+	}
+	var types []string
+	for k, v := range scrobble {
+		if _, ok := v.(map[string]any); ok {
+			types = append(types, k)
+		}
+	}
+	if len(types) == 0 {
+		return false
+	}
+	slices.Sort(types)
+
+	entries, _ := doc["scrobblers"].([]any)
+	for _, typ := range types {
+		table := scrobble[typ].(map[string]any)
+		delete(scrobble, typ)
+
+		enabled := true
+		if v, ok := table["enabled"].(bool); ok {
+			enabled = v
+			delete(table, "enabled")
+		}
+		entries = append(entries, map[string]any{
+			"id":       typ,
+			"type":     typ,
+			"enabled":  enabled,
+			"settings": table,
+		})
+	}
+	doc["scrobblers"] = entries
+	return true
+}
+
+// moveScrobblerLines is moveScrobblerTables for the file's lines: each
+// [scrobble.<type>] table becomes a [[scrobblers]] entry in its place.
+func moveScrobblerLines(lines []string) []string {
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		typ, ok := strings.CutPrefix(tableHeader(strings.TrimSpace(lines[i])), "[scrobble.")
+		typ, closed := strings.CutSuffix(typ, "]")
+		if !ok || !closed || typ == "" || strings.ContainsAny(typ, ".[") {
+			out = append(out, lines[i])
+			continue
+		}
+		end := tableEnd(lines, i)
+		enabled := "true"
+		var settings []string
+		for _, line := range lines[i+1 : end] {
+			if k, v, ok := keyValue(strings.TrimSpace(line)); ok && k == "enabled" {
+				enabled = v
+				continue
+			}
+			settings = append(settings, line)
+		}
+		out = append(out,
+			"[[scrobblers]]",
+			"id = "+strconv.Quote(typ),
+			"type = "+strconv.Quote(typ),
+			"enabled = "+enabled,
+			"",
+			"[scrobblers.settings]",
+		)
+		out = append(out, settings...)
+		i = end - 1
+	}
+	return out
+}
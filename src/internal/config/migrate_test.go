@@ -0,0 +1,113 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMigrateLegacyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	legacy := `# My tunez setup
+active_profile = "home"
+
+[player]
+network_timeout = 9000 # slow NAS
+seek_small = 10
+
+[scrobble]
+enabled = true
+
+# Last.fm account
+[scrobble.lastfm]
+enabled = false
+api_key = "key"
+api_secret = "secret"
+
+[[profiles]]
+id = "home"
+provider = "filesystem"
+enabled = true
+`
+	if err := os.WriteFile(path, []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	m, err := migrateFile(path, 0)
+	if err != nil {
+		t.Fatalf("migrateFile: %v", err)
+	}
+	if m == nil || m.To != CurrentVersion || len(m.Steps) != 2 || m.Reformatted {
+		t.Fatalf("expected two migration steps made in place, got %+v", m)
+	}
+	migrated, _ := os.ReadFile(path)
+	for _, want := range []string{"# My tunez setup\nconfig_version = 1\n", "network_timeout_ms = 9000 # slow NAS", "# Last.fm account\n[[scrobblers]]\nid = \"lastfm\""} {
+		if !strings.Contains(string(migrated), want) {
+			t.Errorf("expected %q kept in the migrated file:\n%s", want, migrated)
+		}
+	}
+	if backup, err := os.ReadFile(m.Backup); err != nil || string(backup) != legacy {
+		t.Errorf("expected the original to be backed up, got %q %v", backup, err)
+	}
+
+	cfg, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if cfg.ConfigVersion != CurrentVersion || cfg.Player.NetworkTimeout != 9000 || cfg.Player.SeekSmall != 10 {
+		t.Errorf("expected renamed player keys at version %d, got %+v (version %d)", CurrentVersion, cfg.Player, cfg.ConfigVersion)
+	}
+	if !cfg.Scrobble.Enabled {
+		t.Error("expected [scrobble] enabled to be kept")
+	}
+	s, ok := cfg.LastfmScrobbler()
+	if !ok || s.ID != "lastfm" || s.Enabled || s.Settings["api_key"] != "key" {
+		t.Errorf("expected [scrobble.lastfm] moved to [[scrobblers]], got %+v", cfg.Scrobblers)
+	}
+
+	// Already current: nothing to do
+	if m, err := migrateFile(path, cfg.ConfigVersion); m != nil || err != nil {
+		t.Errorf("expected no migration, got %+v %v", m, err)
+	}
+}
+
+func TestMigrateLeavesModernLayoutAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "# no config_version, but nothing to upgrade\nactive_profile = \"home\"\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if m, err := migrateFile(path, 0); m != nil || err != nil {
+		t.Fatalf("expected no rewrite, got %+v %v", m, err)
+	}
+	if got, _ := os.ReadFile(path); string(got) != data {
+		t.Errorf("expected file untouched, got %q", got)
+	}
+}
+
+func TestMigrateReformatsWhatLinesCantReach(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	data := "# comment\nscrobble = { lastfm = { api_key = \"key\" } }\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m, err := migrateFile(path, 0)
+	if err != nil || m == nil || !m.Reformatted {
+		t.Fatalf("expected the inline table to force a reformat, got %+v %v", m, err)
+	}
+	cfg, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if s, ok := cfg.LastfmScrobbler(); !ok || s.Settings["api_key"] != "key" {
+		t.Errorf("expected the inline table moved to [[scrobblers]], got %+v", cfg.Scrobblers)
+	}
+}
+
+func TestMigrateRejectsNewerVersion(t *testing.T) {
+	if _, err := migrateFile("unused", CurrentVersion+1); !errors.Is(err, ErrNewerVersion) {
+		t.Errorf("expected ErrNewerVersion, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package config
+
+import (
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// secretKeys are substrings of setting names whose values are never shown,
+// e.g. password, api_secret, session_key.
+var secretKeys = []string{"password", "secret", "token", "_key", "apikey"}
+
+// Redacted renders the config as TOML with passwords, API keys and tokens
+// replaced, for crash reports and bug reports.
+func (c *Config) Redacted() (string, error) {
+	data, err := toml.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		return "", err
+	}
+	redact(doc)
+	out, err := toml.Marshal(doc)
+	if err != nil {
+		return "", err
+	}
+	return string(out), nil
+}
+
+func redact(v any) {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			if isSecretKey(k) {
+				if s, ok := val.(string); !ok || s != "" {
+					t[k] = "REDACTED"
+				}
+				continue
+			}
+			redact(val)
+		}
+	case []any:
+		for _, item := range t {
+			redact(item)
+		}
+	}
+}
+
+func isSecretKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range secretKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
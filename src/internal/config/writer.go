@@ -0,0 +1,282 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// Sections of the config file that hold per-entry settings tables.
+const (
+	SectionProfiles   = "profiles"
+	SectionScrobblers = "scrobblers"
+)
+
+// SetScrobblerSetting writes key = value into the [scrobblers.settings]
+// table of the [[scrobblers]] entry with the given id.
+func SetScrobblerSetting(path, scrobblerID, key, value string) error {
+	return SetSetting(path, SectionScrobblers, scrobblerID, key, value)
+}
+
+// SetProfileSetting writes key = value into the [profiles.settings] table of
+// the [[profiles]] entry with the given id.
+func SetProfileSetting(path, profileID, key, value string) error {
+	return SetSetting(path, SectionProfiles, profileID, key, value)
+}
+
+// SetSetting writes key = value into the settings table of the entry with the
+// given id in section ("profiles" or "scrobblers"). The file is edited line by
+// line so comments and formatting elsewhere are preserved, and it is written
+// with owner-only permissions since settings often hold credentials.
+func SetSetting(path, section, id, key, value string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	updated, err := setSetting(string(data), section, id, key, value)
+	if err != nil {
+		return err
+	}
+	// Refuse to write something we can no longer parse
+	var check Config
+	if err := toml.Unmarshal([]byte(updated), &check); err != nil {
+		return fmt.Errorf("updated config is invalid: %w", err)
+	}
+	return writeFileAtomic(path, []byte(updated), 0o600)
+}
+
+// SetValue writes key = value into the plain table (e.g. "ui.sort"),
+// adding the table at the end of the file if it doesn't exist yet.
+func SetValue(path, table, key, value string) error {
+	return setEntryInFile(path, table, key, strconv.Quote(value))
+}
+
+// SetInt is SetValue for a number.
+func SetInt(path, table, key string, value int) error {
+	return setEntryInFile(path, table, key, strconv.Itoa(value))
+}
+
+func setEntryInFile(path, table, key, literal string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+	updated := setEntry(string(data), table, key, literal)
+	var check Config
+	if err := toml.Unmarshal([]byte(updated), &check); err != nil {
+		return fmt.Errorf("updated config is invalid: %w", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("stat config: %w", err)
+	}
+	return writeFileAtomic(path, []byte(updated), info.Mode().Perm())
+}
+
+func setValue(doc, table, key, value string) string {
+	return setEntry(doc, table, key, strconv.Quote(value))
+}
+
+// setEntry writes key = literal, a value already in TOML form.
+func setEntry(doc, table, key, literal string) string {
+	lines := strings.Split(doc, "\n")
+	header := "[" + table + "]"
+	entry := key + " = " + literal
+
+	start := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "[") {
+			continue
+		}
+		if start >= 0 {
+			break
+		}
+		if tableHeader(trimmed) == header {
+			start = i
+		}
+	}
+	if start < 0 {
+		for len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) == "" {
+			lines = lines[:len(lines)-1]
+		}
+		return strings.Join(append(lines, "", header, entry), "\n") + "\n"
+	}
+
+	insertAt := start + 1
+	for i := start + 1; i < len(lines); i++ {
+		trimmed := strings.TrimSpace(lines[i])
+		if strings.HasPrefix(trimmed, "[") {
+			break
+		}
+		if k, _, ok := keyValue(trimmed); ok && k == key {
+			lines[i] = entry
+			return strings.Join(lines, "\n")
+		}
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+			insertAt = i + 1
+		}
+	}
+	return joinInsert(lines, insertAt, entry)
+}
+
+// Setting returns the raw value of key in the settings of the entry with the
+// given id in section.
+func (c Config) Setting(section, id, key string) (any, bool) {
+	switch section {
+	case SectionProfiles:
+		if p, ok := c.ProfileByID(id); ok {
+			v, ok := p.Settings[key]
+			return v, ok
+		}
+	case SectionScrobblers:
+		for _, s := range c.Scrobblers {
+			if s.ID == id {
+				v, ok := s.Settings[key]
+				return v, ok
+			}
+		}
+	}
+	return nil, false
+}
+
+// tomlBlock is an array-of-tables entry located by line index.
+type tomlBlock struct {
+	start    int // line of [[section]]
+	end      int // first line after the entry
+	settings int // line of [section.settings], or -1
+	id       string
+}
+
+func setSetting(doc, section, id, key, value string) (string, error) {
+	lines := strings.Split(doc, "\n")
+	arrayHeader := "[[" + section + "]]"
+	settingsHeader := "[" + section + ".settings]"
+
+	var blocks []tomlBlock
+	cur := -1
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "[") {
+			header := tableHeader(trimmed)
+			switch {
+			case header == arrayHeader:
+				if cur >= 0 {
+					blocks[cur].end = i
+				}
+				blocks = append(blocks, tomlBlock{start: i, end: len(lines), settings: -1})
+				cur = len(blocks) - 1
+			case header == settingsHeader && cur >= 0:
+				blocks[cur].settings = i
+			default:
+				if cur >= 0 {
+					blocks[cur].end = i
+					cur = -1
+				}
+			}
+			continue
+		}
+		if cur >= 0 && blocks[cur].settings < 0 {
+			if k, v, ok := keyValue(trimmed); ok && k == "id" {
+				blocks[cur].id = v
+			}
+		}
+	}
+
+	entry := fmt.Sprintf("%s = %s", key, strconv.Quote(value))
+	for _, b := range blocks {
+		if b.id != id {
+			continue
+		}
+		if b.settings < 0 {
+			// No settings table yet: add one at the end of the entry
+			insertAt := b.end
+			for insertAt > b.start+1 && strings.TrimSpace(lines[insertAt-1]) == "" {
+				insertAt--
+			}
+			return joinInsert(lines, insertAt, settingsHeader, entry), nil
+		}
+		insertAt := b.settings + 1
+		for i := b.settings + 1; i < b.end; i++ {
+			trimmed := strings.TrimSpace(lines[i])
+			if k, _, ok := keyValue(trimmed); ok && k == key {
+				indent := lines[i][:len(lines[i])-len(strings.TrimLeft(lines[i], " \t"))]
+				lines[i] = indent + entry
+				return strings.Join(lines, "\n"), nil
+			}
+			if trimmed != "" {
+				insertAt = i + 1
+			}
+		}
+		return joinInsert(lines, insertAt, entry), nil
+	}
+	return "", fmt.Errorf("%s entry %q not found in config", section, id)
+}
+
+// tableHeader strips a trailing comment from a table header line.
+func tableHeader(line string) string {
+	if i := strings.Index(line, "#"); i >= 0 {
+		line = line[:i]
+	}
+	return strings.ReplaceAll(strings.TrimSpace(line), " ", "")
+}
+
+// keyValue parses a simple `key = "value"` line. Values that are not plain
+// strings are returned unquoted as written.
+func keyValue(line string) (string, string, bool) {
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", false
+	}
+	k, v, ok := strings.Cut(line, "=")
+	if !ok {
+		return "", "", false
+	}
+	k = strings.TrimSpace(k)
+	v = strings.TrimSpace(v)
+	if i := strings.Index(v, "#"); i > 0 && (v[0] != '"' || strings.LastIndex(v[:i], "\"") > 0) {
+		v = strings.TrimSpace(v[:i])
+	}
+	if unq, err := strconv.Unquote(v); err == nil {
+		v = unq
+	} else {
+		v = strings.Trim(v, "'")
+	}
+	return k, v, true
+}
+
+func joinInsert(lines []string, at int, insert ...string) string {
+	out := make([]string, 0, len(lines)+len(insert))
+	out = append(out, lines[:at]...)
+	out = append(out, insert...)
+	out = append(out, lines[at:]...)
+	return strings.Join(out, "\n")
+}
+
+// writeFileAtomic writes data to a temp file in the same directory and
+// renames it over path.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tunez-config-*")
+	if err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		tmp.Close()
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("write config: %w", err)
+	}
+	return nil
+}
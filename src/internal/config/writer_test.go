@@ -0,0 +1,201 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSetScrobblerSetting(t *testing.T) {
+	tests := []struct {
+		name    string
+		doc     string
+		id      string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "replace existing key",
+			doc: `[[scrobblers]]
+id = "lastfm"
+type = "lastfm"
+
+[scrobblers.settings]
+api_key = "k"
+session_key = "" # filled by --lastfm-auth
+`,
+			id:   "lastfm",
+			want: []string{`api_key = "k"`, `session_key = "sess"`},
+		},
+		{
+			name: "append to settings table",
+			doc: `[[scrobblers]]
+id = "lastfm"
+type = "lastfm"
+
+[scrobblers.settings]
+api_key = "k"
+
+[ui]
+theme = "rainbow"
+`,
+			id:   "lastfm",
+			want: []string{"api_key = \"k\"\nsession_key = \"sess\"\n\n[ui]"},
+		},
+		{
+			name: "create settings table",
+			doc: `[[scrobblers]]
+id = "lastfm"
+type = "lastfm"
+
+[ui]
+theme = "rainbow"
+`,
+			id:   "lastfm",
+			want: []string{"type = \"lastfm\"\n[scrobblers.settings]\nsession_key = \"sess\"\n\n[ui]"},
+		},
+		{
+			name: "second entry only",
+			doc: `[[scrobblers]]
+id = "other"
+type = "lastfm"
+[scrobblers.settings]
+session_key = "keep"
+
+[[scrobblers]]
+id = "lastfm"
+type = "lastfm"
+[scrobblers.settings]
+session_key = "old"
+`,
+			id:   "lastfm",
+			want: []string{`session_key = "keep"`, `session_key = "sess"`},
+		},
+		{
+			name:    "missing scrobbler",
+			doc:     "[ui]\ntheme = \"rainbow\"\n",
+			id:      "lastfm",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setSetting(tt.doc, SectionScrobblers, tt.id, "session_key", "sess")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("setScrobblerSetting() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			for _, w := range tt.want {
+				if !strings.Contains(got, w) {
+					t.Errorf("expected output to contain %q, got:\n%s", w, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSetProfileSetting(t *testing.T) {
+	doc := `[[profiles]]
+id = "home"
+provider = "melodee"
+[profiles.settings]
+password = "hunter2"
+
+[[scrobblers]]
+id = "home"
+type = "melodee"
+[scrobblers.settings]
+token = "t"
+`
+	got, err := setSetting(doc, SectionProfiles, "home", "password", "keyring:x")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(got, `password = "keyring:x"`) || !strings.Contains(got, `token = "t"`) {
+		t.Errorf("expected only the profile entry to change, got:\n%s", got)
+	}
+}
+
+func TestSetScrobblerSettingWritesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	doc := "# my config\n[[scrobblers]]\nid = \"lastfm\"\ntype = \"lastfm\"\n[scrobblers.settings]\napi_key = \"k\"\n"
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetScrobblerSetting(path, "lastfm", "session_key", "sess"); err != nil {
+		t.Fatalf("SetScrobblerSetting() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# my config\n") {
+		t.Error("expected comments to be preserved")
+	}
+	if !strings.Contains(string(data), `session_key = "sess"`) {
+		t.Errorf("expected session key to be written, got:\n%s", data)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0o600 {
+		t.Errorf("expected mode 0600, got %o", perm)
+	}
+}
+
+func TestSetValue(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "replace existing key",
+			doc:  "[ui.sort]\nalbums = \"title\" # old\n\n[queue]\npersist = true\n",
+			want: "[ui.sort]\nalbums = \"year\"\n\n[queue]\npersist = true\n",
+		},
+		{
+			name: "append to table",
+			doc:  "[ui.sort]\nartists = \"name\"\n\n[queue]\npersist = true\n",
+			want: "[ui.sort]\nartists = \"name\"\nalbums = \"year\"\n\n[queue]\npersist = true\n",
+		},
+		{
+			name: "create table",
+			doc:  "[ui]\ntheme = \"rainbow\"\n\n",
+			want: "[ui]\ntheme = \"rainbow\"\n\n[ui.sort]\nalbums = \"year\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := setValue(tt.doc, "ui.sort", "albums", "year"); got != tt.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetInt(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	doc := "[artwork]\ncache_days = 30 # keep a month\nwidth = 40\n"
+	if err := os.WriteFile(path, []byte(doc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetInt(path, "artwork", "cache_days", 90); err != nil {
+		t.Fatalf("SetInt() error = %v", err)
+	}
+	if err := SetInt(path, "artwork", "cache_max_mb", 1000); err != nil {
+		t.Fatalf("SetInt() error = %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "[artwork]\ncache_days = 90\nwidth = 40\ncache_max_mb = 1000\n"; string(data) != want {
+		t.Errorf("got:\n%s\nwant:\n%s", data, want)
+	}
+}
@@ -0,0 +1,279 @@
+// Package control lets CLI subcommands like "tunez status" talk to a
+// running Tunez over a local socket.
+//
+// The protocol is one JSON Request per connection, answered by one JSON
+// Response.
+package control
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tunez/tunez/internal/i18n"
+	"github.com/tunez/tunez/internal/nowplaying"
+)
+
+// Commands a Request can carry.
+const (
+	CmdStatus = "status"
+	CmdPlay   = "play"
+	CmdPause  = "pause"
+	CmdToggle = "toggle"
+	CmdNext   = "next"
+	CmdPrev   = "prev"
+	CmdAdd    = "add" // Arg is a search query; matching tracks are queued
+	// CmdEnqueue carries the queueing flags of "tunez --enqueue" in
+	// Request.Enqueue.
+	CmdEnqueue = "enqueue"
+)
+
+// ErrNotRunning means no Tunez instance is listening on the socket.
+var ErrNotRunning = errors.New("tunez is not running")
+
+// ErrRunning means another instance already owns the socket.
+var ErrRunning = errors.New("another tunez instance is running")
+
+// replyTimeout bounds how long a client waits for the TUI to answer.
+const replyTimeout = 10 * time.Second
+
+// Request is sent by a client.
+type Request struct {
+	Cmd     string   `json:"cmd"`
+	Arg     string   `json:"arg,omitempty"`
+	Enqueue *Enqueue `json:"enqueue,omitempty"`
+}
+
+// Enqueue holds the CLI flags that pick tracks to queue.
+type Enqueue struct {
+	Artist   string `json:"artist,omitempty"`
+	Album    string `json:"album,omitempty"`
+	Track    string `json:"track,omitempty"`
+	Playlist string `json:"playlist,omitempty"`
+	Random   bool   `json:"random,omitempty"`
+	// RandomAlbum and RandomArtist queue every track of a random album,
+	// or of each album of a random artist
+	RandomAlbum  bool `json:"random_album,omitempty"`
+	RandomArtist bool `json:"random_artist,omitempty"`
+	// Genre and Years narrow Random, as "Jazz" and "1970s"
+	Genre   string `json:"genre,omitempty"`
+	Years   string `json:"years,omitempty"`
+	Play    bool   `json:"play,omitempty"`
+	Clear   bool   `json:"clear,omitempty"`
+	Shuffle bool   `json:"shuffle,omitempty"`
+	Repeat  string `json:"repeat,omitempty"`
+}
+
+// Response answers a Request.
+type Response struct {
+	OK      bool    `json:"ok"`
+	Error   string  `json:"error,omitempty"`
+	Message string  `json:"message,omitempty"`
+	Status  *Status `json:"status,omitempty"`
+}
+
+// Status describes the running player.
+type Status struct {
+	nowplaying.Info
+	PositionSec int    `json:"position_sec"`
+	Volume      int    `json:"volume"`
+	Muted       bool   `json:"muted"`
+	Shuffle     bool   `json:"shuffle"`
+	Repeat      string `json:"repeat"`
+	QueueIndex  int    `json:"queue_index"` // -1 when nothing is selected
+	QueueLength int    `json:"queue_length"`
+}
+
+// DefaultFormat is the Status.Format layout of "tunez nowplaying".
+const DefaultFormat = "{artist} - {title}"
+
+// Format fills in the placeholders of layout: {artist}, {title},
+// {album}, {year}, {status} (playing or paused), {icon} (▶ or ⏸),
+// {position}, {duration}, {remaining}, {volume}, {index}, {length},
+// {shuffle} and {repeat}. Others are left as they are.
+func (s Status) Format(layout string) string {
+	icon := "▶"
+	if s.Status == nowplaying.StatusPaused {
+		icon = "⏸"
+	}
+	year := ""
+	if s.Year > 0 {
+		year = strconv.Itoa(s.Year)
+	}
+	shuffle := "off"
+	if s.Shuffle {
+		shuffle = "on"
+	}
+	sec := func(n int) string { return i18n.Duration(time.Duration(n) * time.Second) }
+	return strings.NewReplacer(
+		"{artist}", s.Artist,
+		"{title}", s.Title,
+		"{album}", s.Album,
+		"{year}", year,
+		"{status}", s.Status,
+		"{icon}", icon,
+		"{position}", sec(s.PositionSec),
+		"{duration}", s.Duration,
+		"{remaining}", sec(max(s.DurationSec-s.PositionSec, 0)),
+		"{volume}", strconv.Itoa(s.Volume),
+		"{index}", strconv.Itoa(s.QueueIndex+1),
+		"{length}", strconv.Itoa(s.QueueLength),
+		"{shuffle}", shuffle,
+		"{repeat}", s.Repeat,
+	).Replace(layout)
+}
+
+// Call is a Request waiting for the TUI to answer it.
+type Call struct {
+	Request
+	reply chan Response
+}
+
+// Reply answers the call. Only the first reply is used.
+func (c *Call) Reply(r Response) {
+	select {
+	case c.reply <- r:
+	default:
+	}
+}
+
+// SocketPath returns where the control socket lives: the user's runtime
+// dir when there is one, otherwise the temp dir.
+func SocketPath() string {
+	dir := os.Getenv("XDG_RUNTIME_DIR")
+	if dir == "" {
+		dir = os.TempDir()
+	}
+	name := "tunez.sock"
+	if uid := os.Getuid(); uid >= 0 {
+		name = "tunez-" + strconv.Itoa(uid) + ".sock"
+	}
+	return filepath.Join(dir, name)
+}
+
+// Server accepts control connections and hands their requests to the TUI.
+type Server struct {
+	path     string
+	ln       net.Listener
+	calls    chan *Call
+	done     chan struct{}
+	closeOne sync.Once
+}
+
+// Listen creates the socket at path. A socket left behind by a crashed
+// instance is replaced; one a live instance answers on gives ErrRunning.
+func Listen(path string) (*Server, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, ErrRunning
+		}
+		os.Remove(path)
+	}
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		// Another instance may have bound it since the check above
+		if conn, dialErr := net.DialTimeout("unix", path, time.Second); dialErr == nil {
+			conn.Close()
+			return nil, ErrRunning
+		}
+		return nil, err
+	}
+	os.Chmod(path, 0o600)
+	s := &Server{path: path, ln: ln, calls: make(chan *Call), done: make(chan struct{})}
+	go s.accept()
+	return s, nil
+}
+
+// Calls delivers requests to answer. It is closed by Close.
+func (s *Server) Calls() <-chan *Call {
+	return s.calls
+}
+
+func (s *Server) accept() {
+	var wg sync.WaitGroup
+	defer func() {
+		wg.Wait()
+		close(s.calls)
+	}()
+	for {
+		conn, err := s.ln.Accept()
+		if err != nil {
+			return
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.serve(conn)
+		}()
+	}
+}
+
+func (s *Server) serve(conn net.Conn) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(replyTimeout))
+	var req Request
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		json.NewEncoder(conn).Encode(Response{Error: "bad request: " + err.Error()})
+		return
+	}
+	call := &Call{Request: req, reply: make(chan Response, 1)}
+	var resp Response
+	select {
+	case s.calls <- call:
+		select {
+		case resp = <-call.reply:
+		case <-time.After(replyTimeout):
+			resp = Response{Error: "timed out waiting for tunez"}
+		}
+	case <-s.done:
+		resp = Response{Error: "tunez is shutting down"}
+	}
+	json.NewEncoder(conn).Encode(resp)
+}
+
+// Close stops listening and removes the socket.
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	var err error
+	s.closeOne.Do(func() {
+		close(s.done)
+		err = s.ln.Close()
+		os.Remove(s.path)
+	})
+	return err
+}
+
+// Send delivers req to the instance listening on path and returns its
+// answer. A Response with OK false carries the instance's error.
+func Send(path string, req Request) (Response, error) {
+	return SendTimeout(path, req, replyTimeout+time.Second)
+}
+
+// SendTimeout is Send giving up after timeout, for callers such as status
+// lines that would rather show nothing than wait.
+func SendTimeout(path string, req Request, timeout time.Duration) (Response, error) {
+	conn, err := net.DialTimeout("unix", path, min(timeout, time.Second))
+	if err != nil {
+		return Response{}, ErrNotRunning
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(timeout))
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return Response{}, err
+	}
+	var resp Response
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
+		return Response{}, fmt.Errorf("read response: %w", err)
+	}
+	return resp, nil
+}
@@ -0,0 +1,96 @@
+package control
+
+import (
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/nowplaying"
+)
+
+func TestSendAndReply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tunez.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	go func() {
+		for call := range s.Calls() {
+			if call.Cmd == CmdAdd {
+				call.Reply(Response{OK: true, Message: "queued " + call.Arg})
+			} else {
+				call.Reply(Response{Error: "nope"})
+			}
+		}
+	}()
+
+	resp, err := Send(path, Request{Cmd: CmdAdd, Arg: "Queen"})
+	if err != nil || !resp.OK || resp.Message != "queued Queen" {
+		t.Errorf("expected add to be answered, got %+v %v", resp, err)
+	}
+	resp, err = Send(path, Request{Cmd: CmdNext})
+	if err != nil || resp.OK || resp.Error != "nope" {
+		t.Errorf("expected error response, got %+v %v", resp, err)
+	}
+
+	// A second instance can't take over a live socket
+	if _, err := Listen(path); !errors.Is(err, ErrRunning) {
+		t.Errorf("expected ErrRunning, got %v", err)
+	}
+}
+
+func TestStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tunez.sock")
+	if _, err := Send(path, Request{Cmd: CmdStatus}); !errors.Is(err, ErrNotRunning) {
+		t.Fatalf("expected ErrNotRunning, got %v", err)
+	}
+
+	// A socket file nobody listens on is left by a crash
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected stale socket file: %v", err)
+	}
+
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatalf("expected stale socket to be replaced, got %v", err)
+	}
+	s.Close()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("expected Close to remove the socket, got %v", err)
+	}
+}
+
+func TestStatusFormat(t *testing.T) {
+	st := Status{
+		Info: nowplaying.Info{
+			Status:      nowplaying.StatusPaused,
+			Title:       "Money",
+			Artist:      "Pink Floyd",
+			Album:       "The Dark Side of the Moon",
+			Year:        1973,
+			Duration:    "6:22",
+			DurationSec: 382,
+		},
+		PositionSec: 75,
+		Volume:      80,
+		Repeat:      "all",
+		QueueIndex:  2,
+		QueueLength: 10,
+	}
+	if got := st.Format(DefaultFormat); got != "Pink Floyd - Money" {
+		t.Errorf("unexpected default line %q", got)
+	}
+	got := st.Format("{icon} {artist} - {title} {position}/{duration} (-{remaining}) {year} {index}/{length} {repeat} {shuffle} {bogus}")
+	if want := "⏸ Pink Floyd - Money 1:15/6:22 (-5:07) 1973 3/10 all off {bogus}"; got != want {
+		t.Errorf("expected %q, got %q", want, got)
+	}
+}
@@ -0,0 +1,163 @@
+// Package crash catches panics in the TUI so the terminal is restored
+// through a normal shutdown, and writes a report to attach to bug reports.
+package crash
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Crash is a recovered panic.
+type Crash struct {
+	Value any
+	Stack []byte
+	At    time.Time
+}
+
+// Recorder keeps the first panic recovered by a Guard.
+type Recorder struct {
+	mu    sync.Mutex
+	crash *Crash
+	quit  func()
+}
+
+// Crash returns the recorded panic, or nil.
+func (r *Recorder) Crash() *Crash {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.crash
+}
+
+// Attach lets a panic in View, which cannot return a command, stop p.
+func (r *Recorder) Attach(p *tea.Program) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.quit = p.Quit
+}
+
+func (r *Recorder) record(v any) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.crash == nil {
+		r.crash = &Crash{Value: v, Stack: debug.Stack(), At: time.Now()}
+	}
+}
+
+// crashedMsg is returned by a command that panicked.
+type crashedMsg struct{}
+
+// Guard wraps m so a panic in Init, Update, View or a command they return
+// is recorded in r and quits the program, instead of leaving the terminal
+// in raw mode with the stack trace painted over the alt screen.
+func Guard(m tea.Model, r *Recorder) tea.Model {
+	return guard{Model: m, rec: r}
+}
+
+type guard struct {
+	tea.Model
+	rec *Recorder
+}
+
+func (g guard) Init() (cmd tea.Cmd) {
+	defer func() {
+		if v := recover(); v != nil {
+			g.rec.record(v)
+			cmd = tea.Quit
+		}
+	}()
+	return g.wrap(g.Model.Init())
+}
+
+func (g guard) Update(msg tea.Msg) (model tea.Model, cmd tea.Cmd) {
+	if _, ok := msg.(crashedMsg); ok {
+		return g, tea.Quit
+	}
+	defer func() {
+		if v := recover(); v != nil {
+			g.rec.record(v)
+			model, cmd = g, tea.Quit
+		}
+	}()
+	next, cmd := g.Model.Update(msg)
+	return guard{Model: next, rec: g.rec}, g.wrap(cmd)
+}
+
+func (g guard) View() (view string) {
+	defer func() {
+		if v := recover(); v != nil {
+			g.rec.record(v)
+			g.rec.mu.Lock()
+			quit := g.rec.quit
+			g.rec.mu.Unlock()
+			if quit != nil {
+				go quit()
+			}
+			view = ""
+		}
+	}()
+	return g.Model.View()
+}
+
+// wrap guards cmd, and the commands of a batch it returns.
+func (g guard) wrap(cmd tea.Cmd) tea.Cmd {
+	if cmd == nil {
+		return nil
+	}
+	return func() (msg tea.Msg) {
+		defer func() {
+			if v := recover(); v != nil {
+				g.rec.record(v)
+				msg = crashedMsg{}
+			}
+		}()
+		msg = cmd()
+		if batch, ok := msg.(tea.BatchMsg); ok {
+			wrapped := make(tea.BatchMsg, len(batch))
+			for i, c := range batch {
+				wrapped[i] = g.wrap(c)
+			}
+			return wrapped
+		}
+		return msg
+	}
+}
+
+// Info is the context written alongside the stack.
+type Info struct {
+	Version string
+	Config  string   // the config with secrets redacted
+	Logs    []string // the last log lines
+}
+
+// WriteReport writes c to crash-<time>.txt in dir and returns its path.
+func WriteReport(dir string, c *Crash, info Info) (string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("create crash dir: %w", err)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "tunez crash report\n\n")
+	fmt.Fprintf(&b, "Time:    %s\n", c.At.Format(time.RFC3339))
+	fmt.Fprintf(&b, "Version: %s (%s %s/%s)\n", info.Version, runtime.Version(), runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Panic:   %v\n\n", c.Value)
+	fmt.Fprintf(&b, "Stack:\n%s\n", c.Stack)
+	fmt.Fprintf(&b, "Config (secrets redacted):\n%s\n", info.Config)
+	fmt.Fprintf(&b, "Last %d log lines:\n", len(info.Logs))
+	for _, line := range info.Logs {
+		b.WriteString(line)
+		b.WriteByte('\n')
+	}
+
+	path := filepath.Join(dir, "crash-"+c.At.Format("20060102-150405")+".txt")
+	if err := os.WriteFile(path, []byte(b.String()), 0o600); err != nil {
+		return "", fmt.Errorf("write crash report: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,103 @@
+package crash
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type panicky struct{ in string }
+
+func (p panicky) Init() tea.Cmd { return nil }
+
+func (p panicky) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg {
+	case "update":
+		panic("boom in update")
+	case "cmd":
+		return p, tea.Batch(func() tea.Msg { return "fine" }, func() tea.Msg { panic("boom in cmd") })
+	}
+	return p, nil
+}
+
+func (p panicky) View() string {
+	if p.in == "view" {
+		panic("boom in view")
+	}
+	return "ok"
+}
+
+func TestGuardUpdate(t *testing.T) {
+	rec := &Recorder{}
+	g := Guard(panicky{}, rec)
+
+	_, cmd := g.Update("update")
+	if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected a panic in Update to quit")
+	}
+	c := rec.Crash()
+	if c == nil || c.Value != "boom in update" || !strings.Contains(string(c.Stack), "panicky.Update") {
+		t.Fatalf("expected the update panic with its stack, got %+v", c)
+	}
+}
+
+func TestGuardCommand(t *testing.T) {
+	rec := &Recorder{}
+	g := Guard(panicky{}, rec)
+
+	_, cmd := g.Update("cmd")
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("expected a batch")
+	}
+	if msg := batch[0](); msg != "fine" {
+		t.Errorf("expected the first command to run normally, got %#v", msg)
+	}
+	msg := batch[1]()
+	if _, ok := msg.(crashedMsg); !ok || rec.Crash() == nil {
+		t.Fatalf("expected the batched command's panic to be recorded, got %#v", msg)
+	}
+	if _, cmd := g.Update(msg); cmd == nil {
+		t.Error("expected the crash to quit")
+	} else if _, ok := cmd().(tea.QuitMsg); !ok {
+		t.Error("expected the crash to quit")
+	}
+}
+
+func TestGuardView(t *testing.T) {
+	rec := &Recorder{}
+	quit := make(chan struct{})
+	rec.quit = func() { close(quit) }
+
+	if view := Guard(panicky{in: "view"}, rec).View(); view != "" {
+		t.Errorf("expected an empty view, got %q", view)
+	}
+	<-quit
+	if c := rec.Crash(); c == nil || c.Value != "boom in view" {
+		t.Errorf("expected the view panic, got %+v", c)
+	}
+}
+
+func TestWriteReport(t *testing.T) {
+	rec := &Recorder{}
+	rec.record("boom")
+	path, err := WriteReport(t.TempDir(), rec.Crash(), Info{
+		Version: "1.2.3",
+		Config:  "active_profile = 'home'\n",
+		Logs:    []string{"level=INFO msg=one", "level=WARN msg=two"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"Panic:   boom", "Version: 1.2.3", "Stack:", "active_profile = 'home'", "Last 2 log lines:", "msg=two"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in the report:\n%s", want, data)
+		}
+	}
+}
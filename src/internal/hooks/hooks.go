@@ -0,0 +1,144 @@
+// Package hooks runs the shell commands configured under [hooks] when
+// playback events happen.
+package hooks
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// Events a hook can be configured for.
+const (
+	EventTrackChange = "track_change"
+	EventPause       = "pause"
+	EventResume      = "resume"
+	EventQueueEmpty  = "queue_empty"
+	EventAppStart    = "app_start"
+	EventAppStop     = "app_stop"
+	// EventArtworkSaved follows Save Artwork, with the image's path in
+	// TUNEZ_ARTWORK_PATH
+	EventArtworkSaved = "artwork_saved"
+)
+
+// DefaultTimeout bounds each hook command.
+const DefaultTimeout = 5 * time.Second
+
+// Runner starts hook commands. A nil Runner does nothing.
+type Runner struct {
+	commands map[string]string
+	timeout  time.Duration
+	logger   *slog.Logger
+	wg       sync.WaitGroup
+}
+
+// New returns a Runner for cfg, or nil when no hooks are configured.
+func New(cfg config.HooksConfig, logger *slog.Logger) *Runner {
+	commands := make(map[string]string)
+	for event, cmd := range map[string]string{
+		EventTrackChange:  cfg.TrackChange,
+		EventPause:        cfg.Pause,
+		EventResume:       cfg.Resume,
+		EventQueueEmpty:   cfg.QueueEmpty,
+		EventAppStart:     cfg.AppStart,
+		EventAppStop:      cfg.AppStop,
+		EventArtworkSaved: cfg.ArtworkSaved,
+	} {
+		if cmd != "" {
+			commands[event] = cmd
+		}
+	}
+	if len(commands) == 0 {
+		return nil
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+	timeout := time.Duration(cfg.TimeoutMs) * time.Millisecond
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Runner{commands: commands, timeout: timeout, logger: logger}
+}
+
+// Fire starts the command configured for event in the background, with t
+// described in TUNEZ_* environment variables. t may be empty for events
+// that aren't about a track. extra holds more KEY=value variables for the
+// event.
+func (r *Runner) Fire(event string, t provider.Track, extra ...string) {
+	if r == nil {
+		return
+	}
+	command, ok := r.commands[event]
+	if !ok {
+		return
+	}
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), r.timeout)
+		defer cancel()
+		cmd := shell(ctx, command)
+		cmd.Env = append(append(os.Environ(), env(event, t)...), extra...)
+		// Children of the shell may hold the output pipe open after it is
+		// killed; stop waiting for them shortly after.
+		cmd.WaitDelay = time.Second
+		start := time.Now()
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			if ctx.Err() != nil {
+				err = ctx.Err()
+			}
+			r.logger.Warn("hook failed", slog.String("event", event), slog.Any("err", err), slog.String("output", string(out)))
+			return
+		}
+		r.logger.Debug("hook ran", slog.String("event", event), slog.Duration("took", time.Since(start)), slog.String("output", string(out)))
+	}()
+}
+
+// Wait blocks until running hooks finish or ctx is done.
+func (r *Runner) Wait(ctx context.Context) error {
+	if r == nil {
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func shell(ctx context.Context, command string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", command)
+	}
+	return exec.CommandContext(ctx, "sh", "-c", command)
+}
+
+// env describes the event and track for the hook command.
+func env(event string, t provider.Track) []string {
+	return []string{
+		"TUNEZ_EVENT=" + event,
+		"TUNEZ_TRACK_ID=" + t.ID,
+		"TUNEZ_TITLE=" + t.Title,
+		"TUNEZ_ARTIST=" + t.ArtistName,
+		"TUNEZ_ALBUM=" + t.AlbumTitle,
+		"TUNEZ_YEAR=" + strconv.Itoa(t.Year),
+		"TUNEZ_DURATION=" + strconv.Itoa(t.DurationMs/1000),
+		"TUNEZ_PATH=" + t.FilePath,
+	}
+}
@@ -0,0 +1,56 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestFire(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	out := filepath.Join(t.TempDir(), "out")
+	r := New(config.HooksConfig{
+		TrackChange: `printf '%s|%s|%s|%s' "$TUNEZ_EVENT" "$TUNEZ_ARTIST" "$TUNEZ_TITLE" "$TUNEZ_DURATION" > ` + out,
+	}, nil)
+
+	r.Fire(EventPause, provider.Track{}) // not configured
+	r.Fire(EventTrackChange, provider.Track{Title: "Breathe", ArtistName: "Pink Floyd", DurationMs: 163000})
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(out)
+	if err != nil || string(got) != "track_change|Pink Floyd|Breathe|163" {
+		t.Errorf("expected track env in hook, got %q %v", got, err)
+	}
+}
+
+func TestFireTimeout(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	r := New(config.HooksConfig{AppStop: "sleep 5", TimeoutMs: 50}, nil)
+	start := time.Now()
+	r.Fire(EventAppStop, provider.Track{})
+	if err := r.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 2*time.Second {
+		t.Errorf("expected hook to be killed at the timeout, took %v", time.Since(start))
+	}
+}
+
+func TestNewWithoutHooks(t *testing.T) {
+	r := New(config.HooksConfig{TimeoutMs: 100}, nil)
+	if r != nil {
+		t.Fatal("expected no runner without commands")
+	}
+	r.Fire(EventAppStart, provider.Track{}) // nil-safe
+}
@@ -0,0 +1,341 @@
+package i18n
+
+func init() {
+	Register("de", OneOther, German)
+}
+
+// German is the catalog for "de".
+var German = Messages{
+	"screen.loading":     "Laden",
+	"screen.now_playing": "Läuft gerade",
+	"screen.search":      "Suche",
+	"screen.library":     "Bibliothek",
+	"screen.queue":       "Warteschlange",
+	"screen.playlists":   "Playlists",
+	"screen.lyrics":      "Liedtexte",
+	"screen.scrobbles":   "Scrobbles",
+	"screen.config":      "Einstellungen",
+
+	"library.artists":    "Interpreten",
+	"library.composers":  "Komponisten",
+	"library.albums":     "Alben",
+	"library.works":      "Werke",
+	"library.top_tracks": "Top-Titel",
+	"library.movements":  "Sätze",
+	"library.tracks":     "Titel",
+	"library.sort":       "Sortierung: %s",
+
+	"nav.back":       "Zurück: %s",
+	"nav.forward":    "Vorwärts: %s",
+	"nav.no_back":    "Kein Bereich zum Zurückgehen",
+	"nav.no_forward": "Kein Bereich zum Vorwärtsgehen",
+	"nav.search":     "Suche %s",
+
+	"status.loading":          "Lade %s...",
+	"status.playing_next":     "Als Nächstes: %s (%s)",
+	"status.queued":           "%s zur Warteschlange hinzugefügt (%s)",
+	"status.playing":          "Spiele %s",
+	"status.added":            "Zur Warteschlange hinzugefügt: %s",
+	"status.next":             "Als Nächstes: %s",
+	"status.restored":         "%d Titel wiederhergestellt",
+	"status.shuffled":         "%d kommende Titel gemischt",
+	"status.queue_full":       "Warteschlange ist voll (höchstens %d Titel): %d von %d hinzugefügt",
+	"status.queue_empty":      "Die Warteschlange ist schon leer",
+	"status.queue_cleared":    "Warteschlange geleert",
+	"status.artists_loaded":   "Interpreten geladen (%d)",
+	"status.albums_loaded":    "Alben geladen (%d)",
+	"status.tracks_loaded":    "Titel geladen (%d)",
+	"status.playlists_loaded": "Playlists geladen (%d)",
+	"status.more_results":     "Weitere Ergebnisse geladen",
+	"status.enter_query":      "Suchbegriff eingeben",
+	"status.jump":             "Springen zu: A-Z drücken, oder # für Zahlen und Zeichen",
+	"status.switching":        "Wechsle das Profil...",
+	"status.switched":         "Gewechselt zu %s",
+	"status.approve":          "Tunez im Browser freigeben: %s",
+	"status.mpv_restarting":   "mpv wurde unerwartet beendet, starte neu...",
+	"status.mpv_restarted":    "mpv neu gestartet",
+	"status.ready":            "Bereit",
+	"status.init_failed":      "Start fehlgeschlagen",
+
+	"confirm.clear_queue":        "Warteschlange leeren",
+	"confirm.clear_queue.detail": "Alle %d Titel aus der Warteschlange entfernen?",
+
+	"help.title":             "Hilfe / Tastenbelegung",
+	"help.close":             "? oder Esc schließt",
+	"help.global":            "Allgemein",
+	"help.player":            "Wiedergabe",
+	"help.navigation":        "Navigation",
+	"help.search":            "Suche",
+	"help.queue":             "Warteschlange",
+	"help.library":           "Bibliothek",
+	"help.switch_pane":       "Bereich wechseln (Navigation ↔ Inhalt)",
+	"help.toggle_help":       "Hilfe ein/aus",
+	"help.switch_profile":    "Profil wechseln",
+	"help.quick_open":        "Schnell öffnen (zu allem springen)",
+	"help.quit":              "Beenden",
+	"help.play_pause":        "Wiedergabe/Pause",
+	"help.next_prev":         "Nächster / Vorheriger Titel",
+	"help.seek":              "Spulen -%ds / +%ds",
+	"help.volume":            "Lautstärke leiser / lauter",
+	"help.mute":              "Stumm",
+	"help.shuffle":           "Zufallswiedergabe ein/aus",
+	"help.repeat":            "Wiederholen wechseln (aus/alle/einen)",
+	"help.love":              "Titel lieben / nicht mehr lieben",
+	"help.zen":               "Zen-Modus (jede Taste kehrt zurück)",
+	"help.bookmark":          "Position merken / Lesezeichen anzeigen",
+	"help.chapter":           "Vorheriges / Nächstes Kapitel",
+	"help.chapters":          "Kapitelauswahl",
+	"help.go_to":             "Zum Album / Interpreten des Titels",
+	"help.visualizer":        "Visualisierung wechseln (Läuft gerade)",
+	"help.key.move":          "↑/↓ oder j/k",
+	"help.move":              "Hoch/runter (je nach Bereich)",
+	"help.select":            "Auswählen / Abspielen / Öffnen",
+	"help.back":              "Zurück (Bibliothek)",
+	"help.nav_back":          "Zur vorherigen Ansicht",
+	"help.nav_forward":       "Wieder vorwärts",
+	"help.search_key":        "Suche (Filter in Bibliothek/Warteschlange)",
+	"help.filter":            "Filter wechseln (Titel/Alben/Interpreten)",
+	"help.versions":          "Andere Versionen eines Lieds zeigen / ausblenden",
+	"help.remove":            "Eintrag entfernen",
+	"help.move_item":         "Eintrag nach oben / unten",
+	"help.clear_queue":       "Warteschlange leeren",
+	"help.shuffle_remaining": "Rest mischen",
+	"help.play_next":         "Als Nächstes (nach dem aktuellen einfügen)",
+	"help.add":               "Zur Warteschlange hinzufügen",
+	"help.play_next_all":     "Als Nächstes (auch Alben, Playlists)",
+	"help.sort":              "Sortierung wechseln",
+	"help.decade":            "Nach Jahrzehnt filtern",
+	"help.classical":         "Klassik-Modus (Komponisten)",
+	"help.key.jump":          "' + Buchstabe",
+	"help.jump":              "Zu Interpreten mit diesem Anfangsbuchstaben",
+	"help.track_info":        "Titelinfos (ausgewählt oder laufend)",
+	"help.about":             "Über den Interpreten oder das Album",
+	"help.similar":           "Ähnliche Interpreten",
+	"help.top_tracks":        "Top-Titel des Interpreten",
+
+	"config.sections":          "Bereiche",
+	"config.details":           "Details",
+	"config.section.providers": "Anbieter & Profile",
+	"config.section.theme":     "Design & ANSI",
+	"config.section.keys":      "Tastenbelegung",
+	"config.section.cache":     "Cache / Offline",
+	"config.section.logging":   "Protokoll & Diagnose",
+	"config.section.hidden":    "Ausgeblendetes",
+	"config.active_provider":   "Aktiver Anbieter: %s",
+	"config.profile":           "Profil: %s",
+	"config.profiles":          "Profile insgesamt: %d",
+	"config.capabilities":      "Fähigkeiten: %s",
+	"config.artwork":           "Cover",
+	"config.theme":             "Design: %s",
+	"config.no_emoji":          "Ohne Emoji: %s",
+	"config.page_size":         "Seitengröße: %d",
+	"config.keys.help":         "Hilfe",
+	"config.mpv_path":          "MPV-Pfad: %s",
+	"config.seek_small":        "Kurz spulen: %ds",
+	"config.seek_large":        "Weit spulen: %ds",
+	"config.volume_step":       "Lautstärkeschritt: %d%%",
+	"config.exclusive":         "Exklusive Ausgabe: %s",
+	"config.trim_silence":      "Stille kürzen: %s",
+	"config.trim_below":        "An (unter %gdB)",
+	"config.normalize":         "Normalisieren: %s",
+	"config.yes":               "Ja",
+	"config.no":                "Nein",
+	"config.on":                "An",
+	"config.off":               "Aus",
+	"config.file":              "Konfigurationsdatei: %s",
+	"config.hint":              "[Enter]Bereich öffnen  [Esc]Zurück",
+	"config.hint.cache":        "[Enter]Leeren / Grenze ändern  [Esc]Zurück",
+	"config.hint.hidden":       "[Enter]Einblenden  [Esc]Zurück",
+
+	"cache.artwork":            "Cover",
+	"cache.responses":          "Bibliotheksantworten",
+	"cache.audio":              "Offline-Audio",
+	"cache.state":              "Warteschlange & Zustand",
+	"cache.artwork_limits":     "Cover: bis %d MB, %d Tage behalten",
+	"cache.responses_status":   "Bibliotheksantworten: %s",
+	"cache.audio_status":       "Offline-Audio: %s",
+	"cache.prefetch":           "nächster Titel, %ds vor dem Ende",
+	"cache.open":               "Enter zeigt die Größen und leert sie",
+	"cache.measuring":          "wird gemessen...",
+	"cache.unknown":            "unbekannt",
+	"cache.size_limit":         "Cover-Größengrenze",
+	"cache.kept_for":           "Cover behalten",
+	"cache.mb":                 "%d MB",
+	"cache.days":               "%d Tage",
+	"cache.limit_set":          "Cover-Cache-Grenze: %d MB",
+	"cache.days_set":           "Cover werden %d Tage behalten",
+	"cache.clear":              "%s leeren?",
+	"cache.detail.artwork":     "Cover werden beim Anzeigen neu umgewandelt.",
+	"cache.detail.responses":   "Die Bibliothek wird beim Stöbern neu vom Server geladen,\nund offline ist nichts durchsuchbar, bis es geladen wurde.",
+	"cache.detail.audio":       "Ein vorab geladener nächster Titel wird stattdessen gestreamt.",
+	"cache.clearing.artwork":   "Leere Cover...",
+	"cache.clearing.responses": "Leere Bibliotheksantworten...",
+	"cache.clearing.audio":     "Leere Offline-Audio...",
+	"cache.clearing.state":     "Verdichte die Zustandsdatenbank...",
+	"cache.cleared.artwork":    "Cover geleert",
+	"cache.cleared.responses":  "Bibliotheksantworten geleert",
+	"cache.cleared.audio":      "Offline-Audio geleert",
+	"cache.cleared.state":      "Zustandsdatenbank verdichtet",
+
+	"hidden.none":        "Nichts ausgeblendet. Mit Ausblenden in der Befehlspalette\nverschwinden Interpreten, Alben und Titel aus Bibliothek und Suche.",
+	"hidden.count":       "Ausgeblendet: %d Einträge\nEnter zeigt sie zum Einblenden",
+	"hidden.select":      "Zuerst einen Interpreten, ein Album oder einen Titel auswählen",
+	"hidden.needs_store": "Ausblenden braucht die Zustandsdatenbank",
+	"hidden.hidden":      "Ausgeblendet: %s",
+	"hidden.unhidden":    "Eingeblendet: %s",
+	"hidden.kind.artist": "Interpret",
+	"hidden.kind.album":  "Album",
+	"hidden.kind.track":  "Titel",
+
+	"status.starting":                    "Lade…",
+	"status.nothing_playing":             "Es läuft nichts",
+	"status.nothing_in":                  "In %s gibt es nichts abzuspielen",
+	"status.no_track_selected":           "Kein Titel ausgewählt",
+	"status.select_track":                "Wähle zuerst einen Titel",
+	"status.select_artist":               "Wähle einen Interpreten",
+	"status.select_artist_album":         "Wähle einen Interpreten oder ein Album",
+	"status.select_album_track":          "Wähle zuerst einen Titel in einem Album",
+	"status.select_track_album_playlist": "Wähle zuerst einen Titel, ein Album oder eine Playlist",
+	"status.select_playlist":             "Wähle zuerst eine Playlist",
+	"status.added_tracks":                "%d Titel zur Warteschlange hinzugefügt",
+	"status.queue_is_empty":              "Die Warteschlange ist leer",
+	"status.looking_up":                  "Suche %s...",
+	"status.seeking":                     "Springe zu %s",
+	"status.volume":                      "Lautstärke %d%%",
+	"status.no_artist":                   "Kein Interpret passt zu %s",
+	"status.no_tracks_by":                "Keine Titel von %s",
+	"status.ran":                         "%s ausgeführt",
+
+	"confirm.cancelled": "Abgebrochen",
+	"confirm.keys":      "[y]Ja  [n]Nein",
+
+	"about.off":             "Info ist aus; setze [about] enabled = true, um Biografien zu laden",
+	"audiobook.resuming":    "Weiter bei %s",
+	"autopause.sleep":       "Pausiert: das System ist in den Ruhezustand gegangen",
+	"autopause.device":      "Pausiert: Audiogerät entfernt (%s)",
+	"autoskip.skipped":      "%q übersprungen: %s",
+	"autoskip.skipped_many": "%d Titel übersprungen",
+
+	"bookmarks.needs_persist": "Lesezeichen brauchen [queue] persist = true",
+	"bookmarks.added":         "Lesezeichen bei %s gesetzt",
+	"bookmarks.none":          "Keine Lesezeichen in diesem Titel (%s setzt eins)",
+	"bookmarks.jumping":       "Springe zu %s",
+	"bookmarks.removed":       "Lesezeichen %s entfernt",
+	"chapters.chapter":        "Kapitel %d/%d: %s",
+	"chapters.none":           "Dieser Titel hat keine Kapitel",
+	"chapters.last":           "Schon im letzten Kapitel",
+	"classical.off":           "Bibliothek: Interpreten",
+	"classical.on":            "Bibliothek: Komponisten (Klassikmodus)",
+	"continue.resuming":       "Setze %s fort",
+
+	"player.exclusive_on":      "Exklusive Ausgabe an",
+	"player.exclusive_off":     "Exklusive Ausgabe aus",
+	"player.trim_on":           "Stille kürzen an",
+	"player.trim_off":          "Stille kürzen aus",
+	"player.normalization":     "Normalisierung: %s",
+	"quality.low_on":           "Streaming mit wenig Bandbreite an (%s) ab dem nächsten Titel",
+	"quality.low_off":          "Streaming mit wenig Bandbreite aus (%s) ab dem nächsten Titel",
+	"viz.off":                  "Der Visualizer ist aus",
+	"viz.style":                "Visualizer: %s",
+	"scrobble.off":             "Scrobbeln ist aus",
+	"scrobbles.sent":           "Scrobble schon gesendet",
+	"scrobbles.removed":        "%q aus der Warteschlange von %s entfernt",
+	"lastfm.requesting":        "Fordere Last.fm-Token an…",
+	"lastfm.connected":         "Last.fm verbunden als %s",
+	"lastfm.connected_restart": "Last.fm verbunden als %s; starte neu, um zu scrobbeln",
+	"love.unsupported":         "Kein Scrobbler kann Titel lieben",
+	"love.loved":               "Geliebt ♥",
+	"love.unloved":             "Nicht mehr geliebt",
+
+	"random.tracks":          "Wähle zufällige Titel...",
+	"random.album":           "Wähle ein zufälliges Album...",
+	"random.artist":          "Wähle einen zufälligen Interpreten...",
+	"random.what":            "zufällige Titel",
+	"random.what.filtered":   "zufällige Titel (%s)",
+	"random.what.album":      "Titel aus %s",
+	"random.what.artist":     "Titel von %s",
+	"startup.what":           "Titel",
+	"startup.added":          "%d %s zur Warteschlange hinzugefügt",
+	"startup.no_search":      "Keine Titel für die Startsuche gefunden",
+	"startup.no_random":      "Keine Titel für die Zufallswiedergabe gefunden",
+	"playfrom.playing":       "Spiele %s, %d weitere in der Warteschlange",
+	"dropfolder.added":       "Aus dem Ablageordner hinzugefügt: %s",
+	"dropfolder.added_many":  "%d Titel aus dem Ablageordner hinzugefügt",
+	"playlistfile.importing": "Importiere %s...",
+	"playlistfile.exporting": "Exportiere %s...",
+	"playlistfile.exported":  "%d Titel nach %s exportiert",
+	"playlistfile.imported":  "%d von %d Titeln aus %s importiert",
+
+	"delete.off":               "Löschen ist aus; setze [library] allow_delete = true, um es einzuschalten",
+	"delete.not_local":         "Nur Dateien einer lokalen Bibliothek können gelöscht werden",
+	"delete.playing":           "Der laufende Titel kann nicht gelöscht werden; überspringe ihn zuerst",
+	"delete.title":             "Datei löschen",
+	"delete.confirm.trash":     "%s von %s in den Papierkorb verschieben?",
+	"delete.confirm.permanent": "%s von %s endgültig löschen?",
+	"delete.restorable":        "Sie lässt sich aus dem Papierkorb wiederherstellen.",
+	"delete.final":             "Das lässt sich nicht rückgängig machen.",
+	"delete.really":            "Wirklich löschen?",
+	"delete.really.detail":     "%s wird aus der Bibliothek und der Warteschlange entfernt. %s\nDrücke y zum Löschen.",
+	"delete.deleting":          "Lösche %s...",
+	"delete.deleted":           "Gelöscht: %s",
+	"delete.trashed":           "In den Papierkorb verschoben: %s",
+
+	"goto.missing":       "%s ist nicht in der Bibliothek",
+	"goto.missing_it":    "Nicht in der Bibliothek gefunden",
+	"goto.artist":        "Interpret: %s",
+	"goto.album":         "Album: %s",
+	"jump.none":          "Keine Interpreten unter %s",
+	"jump.jumping":       "Springe zu %s...",
+	"location.not_local": "Nur lokale Dateien haben einen Ordner zum Öffnen",
+	"location.opened":    "%s geöffnet",
+	"location.none":      "Kein Pfad und keine URL für %s",
+	"location.copied":    "%s kopiert",
+	"health.offline":     "Anbieter nicht erreichbar: zeige zwischengespeicherte Daten",
+	"health.online":      "Anbieter wieder erreichbar",
+	"profiles.none":      "Keine anderen Profile zum Wechseln",
+
+	"radio.select":           "Wähle zuerst einen Titel oder Interpreten",
+	"radio.tuning":           "Stelle %s ein...",
+	"radio.empty":            "Radio: keine Titel wie %s",
+	"radio.station":          "Radio: %s",
+	"radio.off":              "Das Radio ist aus",
+	"radio.stopped":          "Radio gestoppt",
+	"artwork.none":           "Kein Cover für %s",
+	"artwork.saving":         "Speichere das Cover...",
+	"artwork.saved":          "Cover unter %s gespeichert",
+	"search.failed":          "Suche: %s",
+	"search.searching":       "Suche... bisher %d Treffer",
+	"search.found":           "%d Treffer gefunden",
+	"similar.needs_key":      "Ähnliche Interpreten brauchen einen Last.fm-api_key in [[scrobblers]]",
+	"similar.not_in_library": "%s ist nicht in deiner Bibliothek",
+	"toptracks.loading":      "Lade die Top-Titel von %s...",
+	"toptracks.by_plays":     "Top-Titel von %s nach Wiedergaben",
+	"toptracks.no_plays":     "Noch keine Wiedergaben von %s; zeige die ersten Titel",
+	"sort.sorted":            "%s sortiert nach %s",
+	"versions.none":          "Von diesem Titel gibt es keine anderen Versionen",
+	"years.showing":          "Zeige %s",
+	"commands.stopped":       "%s hielt bei %s an",
+
+	"count.album.one":      "%d Album",
+	"count.album.other":    "%d Alben",
+	"count.work.one":       "%d Werk",
+	"count.work.other":     "%d Werke",
+	"count.movement.one":   "%d Satz",
+	"count.movement.other": "%d Sätze",
+	"count.track.one":      "%d Titel",
+	"count.track.other":    "%d Titel",
+
+	"time.just_now":          "gerade eben",
+	"time.minutes_ago.one":   "vor %d Minute",
+	"time.minutes_ago.other": "vor %d Minuten",
+	"time.hours_ago.one":     "vor %d Stunde",
+	"time.hours_ago.other":   "vor %d Stunden",
+	"time.yesterday":         "gestern",
+	"time.yesterday_at":      "gestern %s",
+	"time.days_ago.one":      "vor %d Tag",
+	"time.days_ago.other":    "vor %d Tagen",
+	"time.weeks_ago.one":     "vor %d Woche",
+	"time.weeks_ago.other":   "vor %d Wochen",
+	"time.am":                "%s vorm.",
+	"time.pm":                "%s nachm.",
+}
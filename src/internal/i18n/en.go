@@ -0,0 +1,342 @@
+package i18n
+
+func init() {
+	Register("en", OneOther, English)
+}
+
+// English is the catalog every message has a text in, and the one others
+// fall back to.
+var English = Messages{
+	"screen.loading":     "Loading",
+	"screen.now_playing": "Now Playing",
+	"screen.search":      "Search",
+	"screen.library":     "Library",
+	"screen.queue":       "Queue",
+	"screen.playlists":   "Playlists",
+	"screen.lyrics":      "Lyrics",
+	"screen.scrobbles":   "Scrobbles",
+	"screen.config":      "Config",
+
+	"library.artists":    "Artists",
+	"library.composers":  "Composers",
+	"library.albums":     "Albums",
+	"library.works":      "Works",
+	"library.top_tracks": "Top Tracks",
+	"library.movements":  "Movements",
+	"library.tracks":     "Tracks",
+	"library.sort":       "Sort: %s",
+
+	"nav.back":       "Back: %s",
+	"nav.forward":    "Forward: %s",
+	"nav.no_back":    "Nothing to go back to",
+	"nav.no_forward": "Nothing to go forward to",
+	"nav.search":     "Search %s",
+
+	"status.loading":          "Loading %s...",
+	"status.playing_next":     "Playing next: %s (%s)",
+	"status.queued":           "Added %s to queue (%s)",
+	"status.playing":          "Playing %s",
+	"status.added":            "Added to queue: %s",
+	"status.next":             "Playing next: %s",
+	"status.restored":         "Restored %d tracks",
+	"status.shuffled":         "Shuffled %d upcoming tracks",
+	"status.queue_full":       "Queue is full (%d tracks max): added %d of %d",
+	"status.queue_empty":      "Queue is already empty",
+	"status.queue_cleared":    "Queue cleared",
+	"status.artists_loaded":   "Artists loaded (%d)",
+	"status.albums_loaded":    "Albums loaded (%d)",
+	"status.tracks_loaded":    "Tracks loaded (%d)",
+	"status.playlists_loaded": "Playlists loaded (%d)",
+	"status.more_results":     "Loaded more results",
+	"status.enter_query":      "Enter search query",
+	"status.jump":             "Jump to: press A-Z, or # for numbers and symbols",
+	"status.switching":        "Switching profile...",
+	"status.switched":         "Switched to %s",
+	"status.approve":          "Approve Tunez in your browser: %s",
+	"status.mpv_restarting":   "mpv stopped unexpectedly, restarting...",
+	"status.mpv_restarted":    "mpv restarted",
+	"status.ready":            "Ready",
+	"status.init_failed":      "Init failed",
+
+	"confirm.clear_queue":        "Clear Queue",
+	"confirm.clear_queue.detail": "Remove all %d tracks from the queue?",
+
+	"help.title":             "Help / Keybindings",
+	"help.close":             "Press ? or Esc to close",
+	"help.global":            "Global",
+	"help.player":            "Player",
+	"help.navigation":        "Navigation",
+	"help.search":            "Search",
+	"help.queue":             "Queue",
+	"help.library":           "Library",
+	"help.switch_pane":       "Switch pane (nav ↔ content)",
+	"help.toggle_help":       "Toggle help",
+	"help.switch_profile":    "Switch profile",
+	"help.quick_open":        "Quick open (jump to anything)",
+	"help.quit":              "Quit",
+	"help.play_pause":        "Play/Pause",
+	"help.next_prev":         "Next / Previous track",
+	"help.seek":              "Seek -%ds / +%ds",
+	"help.volume":            "Volume Down / Up",
+	"help.mute":              "Mute",
+	"help.shuffle":           "Toggle Shuffle",
+	"help.repeat":            "Cycle Repeat (off/all/one)",
+	"help.love":              "Love / Unlove track",
+	"help.zen":               "Zen mode (any key returns)",
+	"help.bookmark":          "Bookmark position / List bookmarks",
+	"help.chapter":           "Previous / Next chapter",
+	"help.chapters":          "Chapter picker",
+	"help.go_to":             "Go to the track's album / artist",
+	"help.visualizer":        "Cycle visualizer style (Now Playing)",
+	"help.key.move":          "↑/↓ or j/k",
+	"help.move":              "Move up/down (context-aware)",
+	"help.select":            "Select / Play / Drill down",
+	"help.back":              "Go back (Library)",
+	"help.nav_back":          "Back to the previous view",
+	"help.nav_forward":       "Forward again",
+	"help.search_key":        "Search (filter in Library/Queue)",
+	"help.filter":            "Cycle filter (Tracks/Albums/Artists)",
+	"help.versions":          "Show / hide a song's other versions",
+	"help.remove":            "Remove item",
+	"help.move_item":         "Move item up / down",
+	"help.clear_queue":       "Clear queue",
+	"help.shuffle_remaining": "Shuffle remaining",
+	"help.play_next":         "Play next (add after current)",
+	"help.add":               "Add to queue",
+	"help.play_next_all":     "Play next (albums, playlists too)",
+	"help.sort":              "Cycle sort order",
+	"help.decade":            "Filter by decade",
+	"help.classical":         "Classical mode (composers)",
+	"help.key.jump":          "' + letter",
+	"help.jump":              "Jump to artists by initial",
+	"help.track_info":        "Track info (selected or playing)",
+	"help.about":             "About the artist or album",
+	"help.similar":           "Similar artists",
+	"help.top_tracks":        "Artist's top tracks",
+
+	"config.sections":          "Sections",
+	"config.details":           "Details",
+	"config.section.providers": "Providers & Profiles",
+	"config.section.theme":     "Theme & ANSI",
+	"config.section.keys":      "Keybindings",
+	"config.section.cache":     "Cache / Offline",
+	"config.section.logging":   "Logging & Diagnostics",
+	"config.section.hidden":    "Hidden Items",
+	"config.active_provider":   "Active Provider: %s",
+	"config.profile":           "Profile: %s",
+	"config.profiles":          "Total Profiles: %d",
+	"config.capabilities":      "Capabilities: %s",
+	"config.artwork":           "Artwork",
+	"config.theme":             "Theme: %s",
+	"config.no_emoji":          "No Emoji: %s",
+	"config.page_size":         "Page Size: %d",
+	"config.keys.help":         "Help",
+	"config.mpv_path":          "MPV Path: %s",
+	"config.seek_small":        "Seek Small: %ds",
+	"config.seek_large":        "Seek Large: %ds",
+	"config.volume_step":       "Volume Step: %d%%",
+	"config.exclusive":         "Exclusive Output: %s",
+	"config.trim_silence":      "Trim Silence: %s",
+	"config.trim_below":        "On (below %gdB)",
+	"config.normalize":         "Normalize: %s",
+	"config.yes":               "Yes",
+	"config.no":                "No",
+	"config.on":                "On",
+	"config.off":               "Off",
+	"config.file":              "Config file: %s",
+	"config.hint":              "[Enter]Open Section  [Esc]Back",
+	"config.hint.cache":        "[Enter]Clear / Change Limit  [Esc]Back",
+	"config.hint.hidden":       "[Enter]Unhide  [Esc]Back",
+
+	"cache.artwork":            "Artwork",
+	"cache.responses":          "Library responses",
+	"cache.audio":              "Offline audio",
+	"cache.state":              "Queue & state",
+	"cache.artwork_limits":     "Artwork: up to %d MB, kept %d days",
+	"cache.responses_status":   "Library responses: %s",
+	"cache.audio_status":       "Offline audio: %s",
+	"cache.prefetch":           "next track, %ds before the end",
+	"cache.open":               "Press Enter to see sizes and clear them",
+	"cache.measuring":          "measuring...",
+	"cache.unknown":            "unknown",
+	"cache.size_limit":         "Artwork size limit",
+	"cache.kept_for":           "Artwork kept for",
+	"cache.mb":                 "%d MB",
+	"cache.days":               "%d days",
+	"cache.limit_set":          "Artwork cache limit: %d MB",
+	"cache.days_set":           "Artwork kept for %d days",
+	"cache.clear":              "Clear %s?",
+	"cache.detail.artwork":     "Artwork is converted again as it's shown.",
+	"cache.detail.responses":   "The library is fetched from the server again as you browse,\nand nothing is browsable offline until it has been.",
+	"cache.detail.audio":       "A prefetched next track streams instead.",
+	"cache.clearing.artwork":   "Clearing artwork...",
+	"cache.clearing.responses": "Clearing library responses...",
+	"cache.clearing.audio":     "Clearing offline audio...",
+	"cache.clearing.state":     "Compacting the state database...",
+	"cache.cleared.artwork":    "Cleared artwork",
+	"cache.cleared.responses":  "Cleared library responses",
+	"cache.cleared.audio":      "Cleared offline audio",
+	"cache.cleared.state":      "Compacted the state database",
+
+	"hidden.none":        "Nothing hidden. Use Hide in the command palette on an\nartist, album or track in the Library or Search.",
+	"hidden.count":       "Hidden: %d items\nPress Enter to review and unhide them",
+	"hidden.select":      "Select an artist, album or track first",
+	"hidden.needs_store": "Hiding needs the state database",
+	"hidden.hidden":      "Hidden: %s",
+	"hidden.unhidden":    "Unhidden: %s",
+	"hidden.kind.artist": "artist",
+	"hidden.kind.album":  "album",
+	"hidden.kind.track":  "track",
+
+	"status.starting":                    "Loading…",
+	"status.nothing_playing":             "Nothing playing",
+	"status.nothing_in":                  "Nothing to play in %s",
+	"status.no_track_selected":           "No track selected",
+	"status.select_track":                "Select a track first",
+	"status.select_artist":               "Select an artist",
+	"status.select_artist_album":         "Select an artist or album",
+	"status.select_album_track":          "Select a track in an album first",
+	"status.select_track_album_playlist": "Select a track, album or playlist first",
+	"status.select_playlist":             "Select a playlist first",
+	"status.added_tracks":                "Added %d tracks to queue",
+	"status.queue_is_empty":              "Queue is empty",
+	"status.looking_up":                  "Looking up %s...",
+	"status.seeking":                     "Seeking to %s",
+	"status.volume":                      "Volume %d%%",
+	"status.no_artist":                   "No artist matching %s",
+	"status.no_tracks_by":                "No tracks by %s",
+	"status.ran":                         "Ran %s",
+
+	"confirm.cancelled": "Cancelled",
+	"confirm.keys":      "[y]Yes  [n]No",
+
+	"about.off":             "About is off; set [about] enabled = true to fetch bios",
+	"audiobook.resuming":    "Resuming at %s",
+	"autopause.sleep":       "Paused: system went to sleep",
+	"autopause.device":      "Paused: audio device removed (%s)",
+	"autoskip.skipped":      "Skipped %q: %s",
+	"autoskip.skipped_many": "Skipped %d tracks",
+
+	"bookmarks.needs_persist": "Bookmarks need [queue] persist = true",
+	"bookmarks.added":         "Bookmarked %s",
+	"bookmarks.none":          "No bookmarks in this track (%s adds one)",
+	"bookmarks.jumping":       "Jumping to %s",
+	"bookmarks.removed":       "Removed bookmark %s",
+	"chapters.chapter":        "Chapter %d/%d: %s",
+	"chapters.none":           "This track has no chapters",
+	"chapters.last":           "Already in the last chapter",
+	"classical.off":           "Library: artists",
+	"classical.on":            "Library: composers (Classical mode)",
+	"continue.resuming":       "Resuming %s",
+
+	"player.exclusive_on":      "Exclusive output on",
+	"player.exclusive_off":     "Exclusive output off",
+	"player.trim_on":           "Silence trimming on",
+	"player.trim_off":          "Silence trimming off",
+	"player.normalization":     "Normalization: %s",
+	"quality.low_on":           "Low-bandwidth streaming on (%s) from the next track",
+	"quality.low_off":          "Low-bandwidth streaming off (%s) from the next track",
+	"viz.off":                  "Visualizer is off",
+	"viz.style":                "Visualizer: %s",
+	"scrobble.off":             "Scrobbling is off",
+	"scrobbles.sent":           "Scrobble already sent",
+	"scrobbles.removed":        "Removed %q from %s queue",
+	"lastfm.requesting":        "Requesting Last.fm token…",
+	"lastfm.connected":         "Last.fm connected as %s",
+	"lastfm.connected_restart": "Last.fm connected as %s; restart to start scrobbling",
+	"love.unsupported":         "No scrobbler supports loving tracks",
+	"love.loved":               "Loved ♥",
+	"love.unloved":             "Unloved",
+
+	"random.tracks":          "Picking random tracks...",
+	"random.album":           "Picking a random album...",
+	"random.artist":          "Picking a random artist...",
+	"random.what":            "random tracks",
+	"random.what.filtered":   "random tracks (%s)",
+	"random.what.album":      "tracks from %s",
+	"random.what.artist":     "tracks by %s",
+	"startup.what":           "tracks",
+	"startup.added":          "Added %d %s to queue",
+	"startup.no_search":      "No tracks found for startup search",
+	"startup.no_random":      "No tracks found for random play",
+	"playfrom.playing":       "Playing %s, %d more queued",
+	"dropfolder.added":       "Added from drop folder: %s",
+	"dropfolder.added_many":  "Added %d tracks from drop folder",
+	"playlistfile.importing": "Importing %s...",
+	"playlistfile.exporting": "Exporting %s...",
+	"playlistfile.exported":  "Exported %d tracks to %s",
+	"playlistfile.imported":  "Imported %d of %d tracks from %s",
+
+	"delete.off":               "Deleting files is off; set [library] allow_delete = true to turn it on",
+	"delete.not_local":         "Only files in a local library can be deleted",
+	"delete.playing":           "Can't delete the playing track; skip it first",
+	"delete.title":             "Delete File",
+	"delete.confirm.trash":     "Delete %s by %s to the trash?",
+	"delete.confirm.permanent": "Delete %s by %s for good?",
+	"delete.restorable":        "It can be restored from the trash.",
+	"delete.final":             "This can't be undone.",
+	"delete.really":            "Really Delete?",
+	"delete.really.detail":     "%s will be removed from the library and the queue. %s\nPress y to delete it.",
+	"delete.deleting":          "Deleting %s...",
+	"delete.deleted":           "Deleted: %s",
+	"delete.trashed":           "Moved to the trash: %s",
+
+	"goto.missing":       "Couldn't find %s in the library",
+	"goto.missing_it":    "Couldn't find it in the library",
+	"goto.artist":        "Artist: %s",
+	"goto.album":         "Album: %s",
+	"jump.none":          "No artists under %s",
+	"jump.jumping":       "Jumping to %s...",
+	"location.not_local": "Only local files have a folder to open",
+	"location.opened":    "Opened %s",
+	"location.none":      "No path or URL for %s",
+	"location.copied":    "Copied %s",
+	"health.offline":     "Provider unreachable: showing cached data",
+	"health.online":      "Provider back online",
+	"profiles.none":      "No other profiles to switch to",
+
+	"radio.select":           "Select a track or artist first",
+	"radio.tuning":           "Tuning in to %s...",
+	"radio.empty":            "Radio: no tracks like %s",
+	"radio.station":          "Radio: %s",
+	"radio.off":              "Radio is off",
+	"radio.stopped":          "Radio stopped",
+	"artwork.none":           "No artwork for %s",
+	"artwork.saving":         "Saving artwork...",
+	"artwork.saved":          "Saved artwork to %s",
+	"search.failed":          "Search: %s",
+	"search.searching":       "Searching... %d results so far",
+	"search.found":           "Found %d results",
+	"similar.needs_key":      "Similar artists need a Last.fm api_key in [[scrobblers]]",
+	"similar.not_in_library": "%s is not in your library",
+	"toptracks.loading":      "Loading top tracks of %s...",
+	"toptracks.by_plays":     "Top tracks of %s by play count",
+	"toptracks.no_plays":     "No plays of %s yet; showing the first tracks",
+	"sort.sorted":            "Sorted %s by %s",
+	"versions.none":          "This track has no other versions",
+	"years.showing":          "Showing %s",
+	"commands.stopped":       "%s stopped at %s",
+
+	"count.album.one":      "%d album",
+	"count.album.other":    "%d albums",
+	"count.work.one":       "%d work",
+	"count.work.other":     "%d works",
+	"count.movement.one":   "%d movement",
+	"count.movement.other": "%d movements",
+	"count.track.one":      "%d track",
+	"count.track.other":    "%d tracks",
+
+	"time.just_now":          "just now",
+	"time.minutes_ago.one":   "%d minute ago",
+	"time.minutes_ago.other": "%d minutes ago",
+	"time.hours_ago.one":     "%d hour ago",
+	"time.hours_ago.other":   "%d hours ago",
+	"time.yesterday":         "yesterday",
+	"time.yesterday_at":      "yesterday %s",
+	"time.days_ago.one":      "%d day ago",
+	"time.days_ago.other":    "%d days ago",
+	"time.weeks_ago.one":     "%d week ago",
+	"time.weeks_ago.other":   "%d weeks ago",
+	"time.am":                "%s AM",
+	"time.pm":                "%s PM",
+}
@@ -0,0 +1,341 @@
+package i18n
+
+func init() {
+	Register("es", OneOther, Spanish)
+}
+
+// Spanish is the catalog for "es".
+var Spanish = Messages{
+	"screen.loading":     "Cargando",
+	"screen.now_playing": "Reproduciendo",
+	"screen.search":      "Buscar",
+	"screen.library":     "Biblioteca",
+	"screen.queue":       "Cola",
+	"screen.playlists":   "Listas",
+	"screen.lyrics":      "Letras",
+	"screen.scrobbles":   "Scrobbles",
+	"screen.config":      "Ajustes",
+
+	"library.artists":    "Artistas",
+	"library.composers":  "Compositores",
+	"library.albums":     "Álbumes",
+	"library.works":      "Obras",
+	"library.top_tracks": "Más escuchadas",
+	"library.movements":  "Movimientos",
+	"library.tracks":     "Pistas",
+	"library.sort":       "Orden: %s",
+
+	"nav.back":       "Atrás: %s",
+	"nav.forward":    "Adelante: %s",
+	"nav.no_back":    "No hay nada atrás",
+	"nav.no_forward": "No hay nada adelante",
+	"nav.search":     "Buscar %s",
+
+	"status.loading":          "Cargando %s...",
+	"status.playing_next":     "A continuación: %s (%s)",
+	"status.queued":           "%s añadido a la cola (%s)",
+	"status.playing":          "Reproduciendo %s",
+	"status.added":            "Añadido a la cola: %s",
+	"status.next":             "A continuación: %s",
+	"status.restored":         "%d pistas restauradas",
+	"status.shuffled":         "%d próximas pistas mezcladas",
+	"status.queue_full":       "La cola está llena (máximo %d pistas): añadidas %d de %d",
+	"status.queue_empty":      "La cola ya está vacía",
+	"status.queue_cleared":    "Cola vaciada",
+	"status.artists_loaded":   "Artistas cargados (%d)",
+	"status.albums_loaded":    "Álbumes cargados (%d)",
+	"status.tracks_loaded":    "Pistas cargadas (%d)",
+	"status.playlists_loaded": "Listas cargadas (%d)",
+	"status.more_results":     "Más resultados cargados",
+	"status.enter_query":      "Escribe lo que buscas",
+	"status.jump":             "Saltar a: pulsa A-Z, o # para números y símbolos",
+	"status.switching":        "Cambiando de perfil...",
+	"status.switched":         "Cambiado a %s",
+	"status.approve":          "Autoriza Tunez en tu navegador: %s",
+	"status.mpv_restarting":   "mpv se detuvo inesperadamente, reiniciando...",
+	"status.mpv_restarted":    "mpv reiniciado",
+	"status.ready":            "Listo",
+	"status.init_failed":      "Falló el inicio",
+
+	"confirm.clear_queue":        "Vaciar la cola",
+	"confirm.clear_queue.detail": "¿Quitar las %d pistas de la cola?",
+
+	"help.title":             "Ayuda / Atajos",
+	"help.close":             "Pulsa ? o Esc para cerrar",
+	"help.global":            "General",
+	"help.player":            "Reproductor",
+	"help.navigation":        "Navegación",
+	"help.search":            "Buscar",
+	"help.queue":             "Cola",
+	"help.library":           "Biblioteca",
+	"help.switch_pane":       "Cambiar de panel (navegación ↔ contenido)",
+	"help.toggle_help":       "Mostrar u ocultar la ayuda",
+	"help.switch_profile":    "Cambiar de perfil",
+	"help.quick_open":        "Apertura rápida (saltar a lo que sea)",
+	"help.quit":              "Salir",
+	"help.play_pause":        "Reproducir/Pausa",
+	"help.next_prev":         "Pista siguiente / anterior",
+	"help.seek":              "Saltar -%ds / +%ds",
+	"help.volume":            "Bajar / Subir volumen",
+	"help.mute":              "Silenciar",
+	"help.shuffle":           "Aleatorio sí/no",
+	"help.repeat":            "Cambiar repetición (no/todo/una)",
+	"help.love":              "Marcar / Desmarcar como favorita",
+	"help.zen":               "Modo zen (cualquier tecla vuelve)",
+	"help.bookmark":          "Marcar posición / Ver marcadores",
+	"help.chapter":           "Capítulo anterior / siguiente",
+	"help.chapters":          "Elegir capítulo",
+	"help.go_to":             "Ir al álbum / artista de la pista",
+	"help.visualizer":        "Cambiar visualizador (Reproduciendo)",
+	"help.key.move":          "↑/↓ o j/k",
+	"help.move":              "Subir/bajar (según el panel)",
+	"help.select":            "Elegir / Reproducir / Abrir",
+	"help.back":              "Volver (Biblioteca)",
+	"help.nav_back":          "Volver a la vista anterior",
+	"help.nav_forward":       "Ir adelante de nuevo",
+	"help.search_key":        "Buscar (filtra en Biblioteca/Cola)",
+	"help.filter":            "Cambiar filtro (Pistas/Álbumes/Artistas)",
+	"help.versions":          "Mostrar / ocultar otras versiones de una canción",
+	"help.remove":            "Quitar elemento",
+	"help.move_item":         "Subir / bajar elemento",
+	"help.clear_queue":       "Vaciar la cola",
+	"help.shuffle_remaining": "Mezclar lo que queda",
+	"help.play_next":         "A continuación (tras la actual)",
+	"help.add":               "Añadir a la cola",
+	"help.play_next_all":     "A continuación (también álbumes y listas)",
+	"help.sort":              "Cambiar el orden",
+	"help.decade":            "Filtrar por década",
+	"help.classical":         "Modo clásico (compositores)",
+	"help.key.jump":          "' + letra",
+	"help.jump":              "Saltar a artistas por inicial",
+	"help.track_info":        "Datos de la pista (elegida o en curso)",
+	"help.about":             "Sobre el artista o el álbum",
+	"help.similar":           "Artistas similares",
+	"help.top_tracks":        "Más escuchadas del artista",
+
+	"config.sections":          "Secciones",
+	"config.details":           "Detalles",
+	"config.section.providers": "Proveedores y perfiles",
+	"config.section.theme":     "Tema y ANSI",
+	"config.section.keys":      "Atajos",
+	"config.section.cache":     "Caché / Sin conexión",
+	"config.section.logging":   "Registro y diagnóstico",
+	"config.section.hidden":    "Elementos ocultos",
+	"config.active_provider":   "Proveedor activo: %s",
+	"config.profile":           "Perfil: %s",
+	"config.profiles":          "Perfiles en total: %d",
+	"config.capabilities":      "Funciones: %s",
+	"config.artwork":           "Portadas",
+	"config.theme":             "Tema: %s",
+	"config.no_emoji":          "Sin emoji: %s",
+	"config.page_size":         "Tamaño de página: %d",
+	"config.keys.help":         "Ayuda",
+	"config.mpv_path":          "Ruta de MPV: %s",
+	"config.seek_small":        "Salto corto: %ds",
+	"config.seek_large":        "Salto largo: %ds",
+	"config.volume_step":       "Paso de volumen: %d%%",
+	"config.exclusive":         "Salida exclusiva: %s",
+	"config.trim_silence":      "Recortar silencio: %s",
+	"config.trim_below":        "Sí (por debajo de %gdB)",
+	"config.normalize":         "Normalizar: %s",
+	"config.yes":               "Sí",
+	"config.no":                "No",
+	"config.on":                "Sí",
+	"config.off":               "No",
+	"config.file":              "Archivo de configuración: %s",
+	"config.hint":              "[Enter]Abrir sección  [Esc]Volver",
+	"config.hint.cache":        "[Enter]Vaciar / Cambiar límite  [Esc]Volver",
+	"config.hint.hidden":       "[Enter]Mostrar  [Esc]Volver",
+
+	"cache.artwork":            "Portadas",
+	"cache.responses":          "Respuestas de la biblioteca",
+	"cache.audio":              "Audio sin conexión",
+	"cache.state":              "Cola y estado",
+	"cache.artwork_limits":     "Portadas: hasta %d MB, %d días",
+	"cache.responses_status":   "Respuestas de la biblioteca: %s",
+	"cache.audio_status":       "Audio sin conexión: %s",
+	"cache.prefetch":           "siguiente pista, %ds antes del final",
+	"cache.open":               "Pulsa Enter para ver los tamaños y vaciarlos",
+	"cache.measuring":          "midiendo...",
+	"cache.unknown":            "desconocido",
+	"cache.size_limit":         "Límite de portadas",
+	"cache.kept_for":           "Portadas durante",
+	"cache.mb":                 "%d MB",
+	"cache.days":               "%d días",
+	"cache.limit_set":          "Límite de la caché de portadas: %d MB",
+	"cache.days_set":           "Portadas guardadas %d días",
+	"cache.clear":              "¿Vaciar %s?",
+	"cache.detail.artwork":     "Las portadas se convierten de nuevo al mostrarse.",
+	"cache.detail.responses":   "La biblioteca se vuelve a pedir al servidor mientras navegas,\ny nada se puede explorar sin conexión hasta entonces.",
+	"cache.detail.audio":       "La siguiente pista descargada se reproducirá en streaming.",
+	"cache.clearing.artwork":   "Vaciando portadas...",
+	"cache.clearing.responses": "Vaciando respuestas de la biblioteca...",
+	"cache.clearing.audio":     "Vaciando audio sin conexión...",
+	"cache.clearing.state":     "Compactando la base de datos de estado...",
+	"cache.cleared.artwork":    "Portadas vaciadas",
+	"cache.cleared.responses":  "Respuestas de la biblioteca vaciadas",
+	"cache.cleared.audio":      "Audio sin conexión vaciado",
+	"cache.cleared.state":      "Base de datos de estado compactada",
+
+	"hidden.none":        "Nada oculto. Usa Ocultar en la paleta de comandos sobre un\nartista, álbum o pista en la Biblioteca o en Buscar.",
+	"hidden.count":       "Ocultos: %d elementos\nPulsa Enter para revisarlos y mostrarlos",
+	"hidden.select":      "Elige antes un artista, álbum o pista",
+	"hidden.needs_store": "Ocultar necesita la base de datos de estado",
+	"hidden.hidden":      "Oculto: %s",
+	"hidden.unhidden":    "Visible de nuevo: %s",
+	"hidden.kind.artist": "artista",
+	"hidden.kind.album":  "álbum",
+	"hidden.kind.track":  "pista",
+
+	"status.starting":                    "Cargando…",
+	"status.nothing_playing":             "No suena nada",
+	"status.nothing_in":                  "No hay nada que reproducir en %s",
+	"status.no_track_selected":           "No hay ninguna pista seleccionada",
+	"status.select_track":                "Selecciona antes una pista",
+	"status.select_artist":               "Selecciona un artista",
+	"status.select_artist_album":         "Selecciona un artista o un álbum",
+	"status.select_album_track":          "Selecciona antes una pista de un álbum",
+	"status.select_track_album_playlist": "Selecciona antes una pista, un álbum o una lista",
+	"status.select_playlist":             "Selecciona antes una lista",
+	"status.added_tracks":                "%d pistas añadidas a la cola",
+	"status.queue_is_empty":              "La cola está vacía",
+	"status.looking_up":                  "Buscando %s...",
+	"status.seeking":                     "Saltando a %s",
+	"status.volume":                      "Volumen %d%%",
+	"status.no_artist":                   "Ningún artista coincide con %s",
+	"status.no_tracks_by":                "No hay pistas de %s",
+	"status.ran":                         "%s ejecutado",
+
+	"confirm.cancelled": "Cancelado",
+	"confirm.keys":      "[y]Sí  [n]No",
+
+	"about.off":             "La información está desactivada; pon [about] enabled = true para obtener biografías",
+	"audiobook.resuming":    "Reanudando en %s",
+	"autopause.sleep":       "En pausa: el sistema entró en reposo",
+	"autopause.device":      "En pausa: se quitó el dispositivo de audio (%s)",
+	"autoskip.skipped":      "Se saltó %q: %s",
+	"autoskip.skipped_many": "Se saltaron %d pistas",
+
+	"bookmarks.needs_persist": "Los marcadores necesitan [queue] persist = true",
+	"bookmarks.added":         "Marcador en %s",
+	"bookmarks.none":          "Esta pista no tiene marcadores (%s añade uno)",
+	"bookmarks.jumping":       "Saltando a %s",
+	"bookmarks.removed":       "Marcador %s eliminado",
+	"chapters.chapter":        "Capítulo %d/%d: %s",
+	"chapters.none":           "Esta pista no tiene capítulos",
+	"chapters.last":           "Ya estás en el último capítulo",
+	"classical.off":           "Biblioteca: artistas",
+	"classical.on":            "Biblioteca: compositores (modo clásico)",
+	"continue.resuming":       "Reanudando %s",
+
+	"player.exclusive_on":      "Salida exclusiva activada",
+	"player.exclusive_off":     "Salida exclusiva desactivada",
+	"player.trim_on":           "Recorte de silencios activado",
+	"player.trim_off":          "Recorte de silencios desactivado",
+	"player.normalization":     "Normalización: %s",
+	"quality.low_on":           "Streaming de bajo ancho de banda activado (%s) desde la próxima pista",
+	"quality.low_off":          "Streaming de bajo ancho de banda desactivado (%s) desde la próxima pista",
+	"viz.off":                  "El visualizador está desactivado",
+	"viz.style":                "Visualizador: %s",
+	"scrobble.off":             "El scrobbling está desactivado",
+	"scrobbles.sent":           "El scrobble ya se envió",
+	"scrobbles.removed":        "%q quitado de la cola de %s",
+	"lastfm.requesting":        "Pidiendo el token de Last.fm…",
+	"lastfm.connected":         "Last.fm conectado como %s",
+	"lastfm.connected_restart": "Last.fm conectado como %s; reinicia para empezar a hacer scrobbling",
+	"love.unsupported":         "Ningún scrobbler permite marcar pistas como favoritas",
+	"love.loved":               "Favorita ♥",
+	"love.unloved":             "Ya no es favorita",
+
+	"random.tracks":          "Eligiendo pistas al azar...",
+	"random.album":           "Eligiendo un álbum al azar...",
+	"random.artist":          "Eligiendo un artista al azar...",
+	"random.what":            "pistas al azar",
+	"random.what.filtered":   "pistas al azar (%s)",
+	"random.what.album":      "pistas de %s",
+	"random.what.artist":     "pistas de %s",
+	"startup.what":           "pistas",
+	"startup.added":          "%d %s añadidas a la cola",
+	"startup.no_search":      "No se encontraron pistas para la búsqueda de inicio",
+	"startup.no_random":      "No se encontraron pistas para la reproducción aleatoria",
+	"playfrom.playing":       "Reproduciendo %s, %d más en la cola",
+	"dropfolder.added":       "Añadido desde la carpeta de entrada: %s",
+	"dropfolder.added_many":  "%d pistas añadidas desde la carpeta de entrada",
+	"playlistfile.importing": "Importando %s...",
+	"playlistfile.exporting": "Exportando %s...",
+	"playlistfile.exported":  "%d pistas exportadas a %s",
+	"playlistfile.imported":  "%d de %d pistas importadas de %s",
+
+	"delete.off":               "Borrar archivos está desactivado; pon [library] allow_delete = true para activarlo",
+	"delete.not_local":         "Solo se pueden borrar archivos de una biblioteca local",
+	"delete.playing":           "No se puede borrar la pista que suena; sáltala antes",
+	"delete.title":             "Borrar archivo",
+	"delete.confirm.trash":     "¿Mover %s de %s a la papelera?",
+	"delete.confirm.permanent": "¿Borrar %s de %s para siempre?",
+	"delete.restorable":        "Se puede recuperar de la papelera.",
+	"delete.final":             "No se puede deshacer.",
+	"delete.really":            "¿Borrar de verdad?",
+	"delete.really.detail":     "%s se quitará de la biblioteca y de la cola. %s\nPulsa y para borrarlo.",
+	"delete.deleting":          "Borrando %s...",
+	"delete.deleted":           "Borrado: %s",
+	"delete.trashed":           "Movido a la papelera: %s",
+
+	"goto.missing":       "No se encontró %s en la biblioteca",
+	"goto.missing_it":    "No se encontró en la biblioteca",
+	"goto.artist":        "Artista: %s",
+	"goto.album":         "Álbum: %s",
+	"jump.none":          "No hay artistas en la %s",
+	"jump.jumping":       "Saltando a la %s...",
+	"location.not_local": "Solo los archivos locales tienen una carpeta que abrir",
+	"location.opened":    "%s abierto",
+	"location.none":      "No hay ruta ni URL para %s",
+	"location.copied":    "%s copiado",
+	"health.offline":     "Proveedor inaccesible: mostrando datos en caché",
+	"health.online":      "El proveedor vuelve a estar en línea",
+	"profiles.none":      "No hay otros perfiles a los que cambiar",
+
+	"radio.select":           "Selecciona antes una pista o un artista",
+	"radio.tuning":           "Sintonizando %s...",
+	"radio.empty":            "Radio: no hay pistas como %s",
+	"radio.station":          "Radio: %s",
+	"radio.off":              "La radio está apagada",
+	"radio.stopped":          "Radio detenida",
+	"artwork.none":           "No hay portada para %s",
+	"artwork.saving":         "Guardando la portada...",
+	"artwork.saved":          "Portada guardada en %s",
+	"search.failed":          "Búsqueda: %s",
+	"search.searching":       "Buscando... %d resultados hasta ahora",
+	"search.found":           "%d resultados encontrados",
+	"similar.needs_key":      "Los artistas similares necesitan una api_key de Last.fm en [[scrobblers]]",
+	"similar.not_in_library": "%s no está en tu biblioteca",
+	"toptracks.loading":      "Cargando las pistas más escuchadas de %s...",
+	"toptracks.by_plays":     "Pistas más escuchadas de %s por reproducciones",
+	"toptracks.no_plays":     "Aún no hay reproducciones de %s; mostrando las primeras pistas",
+	"sort.sorted":            "%s ordenados por %s",
+	"versions.none":          "Esta pista no tiene otras versiones",
+	"years.showing":          "Mostrando %s",
+	"commands.stopped":       "%s se detuvo en %s",
+
+	"count.album.one":      "%d álbum",
+	"count.album.other":    "%d álbumes",
+	"count.work.one":       "%d obra",
+	"count.work.other":     "%d obras",
+	"count.movement.one":   "%d movimiento",
+	"count.movement.other": "%d movimientos",
+	"count.track.one":      "%d pista",
+	"count.track.other":    "%d pistas",
+
+	"time.just_now":          "ahora mismo",
+	"time.minutes_ago.one":   "hace %d minuto",
+	"time.minutes_ago.other": "hace %d minutos",
+	"time.hours_ago.one":     "hace %d hora",
+	"time.hours_ago.other":   "hace %d horas",
+	"time.yesterday":         "ayer",
+	"time.yesterday_at":      "ayer %s",
+	"time.days_ago.one":      "hace %d día",
+	"time.days_ago.other":    "hace %d días",
+	"time.weeks_ago.one":     "hace %d semana",
+	"time.weeks_ago.other":   "hace %d semanas",
+	"time.am":                "%s a. m.",
+	"time.pm":                "%s p. m.",
+}
@@ -0,0 +1,83 @@
+package i18n
+
+import (
+	"fmt"
+	"time"
+)
+
+// Duration formats d as m:ss, or h:mm:ss from an hour.
+func Duration(d time.Duration) string {
+	s := int(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// Clock formats the time of day of t, as in "15:04", or "3:04 PM" on a
+// 12-hour clock.
+func (c Catalog) Clock(t time.Time) string {
+	if !c.Clock12 {
+		return t.Format("15:04")
+	}
+	if t.Hour() < 12 {
+		return c.T("time.am", t.Format("3:04"))
+	}
+	return c.T("time.pm", t.Format("3:04"))
+}
+
+// Date formats the day of t as 2006-01-02, which reads the same in every
+// language.
+func (c Catalog) Date(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+// Since says how long before now t was, as in "3 days ago". Times more
+// than a month back, and every time when dates are absolute, give their
+// date instead.
+func (c Catalog) Since(t, now time.Time) string {
+	if c.AbsoluteDates {
+		return c.Date(t)
+	}
+	d := now.Sub(t)
+	days := daysBetween(t, now)
+	switch {
+	case d < time.Minute:
+		return c.T("time.just_now")
+	case d < time.Hour:
+		return c.N("time.minutes_ago", int(d/time.Minute))
+	case days == 0 || d < 6*time.Hour:
+		return c.N("time.hours_ago", int(d/time.Hour))
+	case days == 1:
+		return c.T("time.yesterday")
+	case days < 7:
+		return c.N("time.days_ago", days)
+	case days < 35:
+		return c.N("time.weeks_ago", days/7)
+	}
+	return c.Date(t)
+}
+
+// Stamp formats t for a history list: its time of day when it was today,
+// with "yesterday" the day before, and with its date otherwise.
+func (c Catalog) Stamp(t, now time.Time) string {
+	switch days := daysBetween(t, now); {
+	case days == 0:
+		return c.Clock(t)
+	case days == 1 && !c.AbsoluteDates:
+		return c.T("time.yesterday_at", c.Clock(t))
+	}
+	return c.Date(t) + " " + c.Clock(t)
+}
+
+// daysBetween counts the calendar days from t to now in now's time zone.
+func daysBetween(t, now time.Time) int {
+	t = t.In(now.Location())
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := now.Date()
+	// Counting in UTC keeps days made 23 or 25 hours long by daylight
+	// saving from miscounting
+	from := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from) / (24 * time.Hour))
+}
@@ -0,0 +1,80 @@
+package i18n
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                                     "0:00",
+		59*time.Second + 900*time.Millisecond: "0:59",
+		413 * time.Second:                     "6:53",
+		time.Hour + 2*time.Minute + 33*time.Second: "1:02:33",
+		10 * time.Hour: "10:00:00",
+	} {
+		if got := Duration(d); got != want {
+			t.Errorf("%v: expected %q, got %q", d, want, got)
+		}
+	}
+}
+
+func TestClock(t *testing.T) {
+	at := time.Date(2026, 3, 14, 15, 4, 0, 0, time.UTC)
+	c := New("en")
+	if got := c.Clock(at); got != "15:04" {
+		t.Errorf("expected a 24-hour time, got %q", got)
+	}
+	c.Clock12 = true
+	if got := c.Clock(at); got != "3:04 PM" {
+		t.Errorf("expected a 12-hour time, got %q", got)
+	}
+	ja := New("ja")
+	ja.Clock12 = true
+	if got := ja.Clock(at.Add(-6 * time.Hour)); got != "午前 9:04" {
+		t.Errorf("expected a Japanese morning time, got %q", got)
+	}
+}
+
+func TestSince(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 4, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		ago  time.Duration
+		want string
+	}{
+		{20 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{45 * time.Minute, "45 minutes ago"},
+		{5 * time.Hour, "5 hours ago"},
+		{20 * time.Hour, "yesterday"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{15 * 24 * time.Hour, "2 weeks ago"},
+		{60 * 24 * time.Hour, "2026-01-13"},
+	} {
+		if got := New("en").Since(now.Add(-tc.ago), now); got != tc.want {
+			t.Errorf("%v ago: expected %q, got %q", tc.ago, tc.want, got)
+		}
+	}
+	if got := New("de").Since(now.Add(-3*24*time.Hour), now); got != "vor 3 Tagen" {
+		t.Errorf("expected German, got %q", got)
+	}
+	abs := New("en")
+	abs.AbsoluteDates = true
+	if got := abs.Since(now.Add(-3*24*time.Hour), now); got != "2026-03-11" {
+		t.Errorf("expected the date, got %q", got)
+	}
+}
+
+func TestStamp(t *testing.T) {
+	now := time.Date(2026, 3, 14, 15, 4, 0, 0, time.UTC)
+	c := New("en")
+	for ago, want := range map[time.Duration]string{
+		2 * time.Hour:      "13:04",
+		20 * time.Hour:     "yesterday 19:04",
+		3 * 24 * time.Hour: "2026-03-11 15:04",
+	} {
+		if got := c.Stamp(now.Add(-ago), now); got != want {
+			t.Errorf("%v ago: expected %q, got %q", ago, want, got)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Package i18n holds the message catalogs the Tunez TUI's text comes from,
+// one per language, and picks plural forms for counts.
+package i18n
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Fallback is the language messages missing from a catalog come from.
+const Fallback = "en"
+
+// Messages maps message keys to their text, a format for fmt.Sprintf.
+// Counted messages have a key per plural form, as in "count.album.one" and
+// "count.album.other".
+type Messages map[string]string
+
+// PluralFunc returns the plural form, "one" or "other", a language uses
+// for n.
+type PluralFunc func(n int) string
+
+// language is a registered catalog.
+type language struct {
+	plural   PluralFunc
+	messages Messages
+}
+
+// registry maps language codes to their catalogs.
+var registry = make(map[string]language)
+
+// Register adds a language's catalog.
+func Register(lang string, plural PluralFunc, messages Messages) {
+	registry[lang] = language{plural: plural, messages: messages}
+}
+
+// Valid reports whether lang has a catalog.
+func Valid(lang string) bool {
+	_, ok := registry[lang]
+	return ok
+}
+
+// Languages returns the codes of the languages with a catalog.
+func Languages() []string {
+	langs := make([]string, 0, len(registry))
+	for lang := range registry {
+		langs = append(langs, lang)
+	}
+	slices.Sort(langs)
+	return langs
+}
+
+// OneOther is the plural rule of languages that set one thing apart from
+// any other count, such as English, German and Spanish.
+func OneOther(n int) string {
+	if n == 1 {
+		return "one"
+	}
+	return "other"
+}
+
+// OtherOnly is the plural rule of languages without plural forms, such as
+// Japanese.
+func OtherOnly(int) string {
+	return "other"
+}
+
+// Catalog looks up messages in one language, falling back to English,
+// and formats times the way the interface is set to.
+type Catalog struct {
+	lang string
+	// Clock12 shows times of day on a 12-hour clock.
+	Clock12 bool
+	// AbsoluteDates shows dates rather than how long ago they were.
+	AbsoluteDates bool
+}
+
+// New returns the catalog for lang, or the English one when lang has
+// none.
+func New(lang string) Catalog {
+	if !Valid(lang) {
+		lang = Fallback
+	}
+	return Catalog{lang: lang}
+}
+
+// Language returns the code of the catalog's language.
+func (c Catalog) Language() string {
+	if c.lang == "" {
+		return Fallback
+	}
+	return c.lang
+}
+
+// T returns the message for key formatted with args. Keys the catalog
+// lacks come from English, or are returned as they are.
+func (c Catalog) T(key string, args ...any) string {
+	msg, ok := c.lookup(key)
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+// N returns the counted message for key in the plural form the language
+// uses for n, as in "1 album" or "2 albums". Counts the catalog lacks come
+// from English.
+func (c Catalog) N(key string, n int) string {
+	for _, lang := range []string{c.Language(), Fallback} {
+		l, ok := registry[lang]
+		if !ok {
+			continue
+		}
+		for _, k := range []string{key + "." + l.plural(n), key + ".other"} {
+			if msg, ok := l.messages[k]; ok {
+				return fmt.Sprintf(msg, n)
+			}
+		}
+	}
+	return fmt.Sprintf("%d %s", n, key)
+}
+
+// lookup finds key in the catalog's language, then in English.
+func (c Catalog) lookup(key string) (string, bool) {
+	if msg, ok := registry[c.Language()].messages[key]; ok {
+		return msg, true
+	}
+	msg, ok := registry[Fallback].messages[key]
+	return msg, ok
+}
@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCatalogs(t *testing.T) {
+	for _, lang := range Languages() {
+		l := registry[lang]
+		for key, msg := range l.messages {
+			en, ok := English[key]
+			if !ok {
+				t.Errorf("%s: %q has no English text", lang, key)
+				continue
+			}
+			// Translations take the same arguments
+			if strings.Count(msg, "%") != strings.Count(en, "%") {
+				t.Errorf("%s: %q has arguments %q, English %q", lang, key, msg, en)
+			}
+		}
+		// Every message is translated, but for plural forms the language
+		// doesn't use
+		for key := range English {
+			unused := strings.HasSuffix(key, ".one") && l.plural(1) != "one"
+			if _, ok := l.messages[key]; !ok && !unused {
+				t.Errorf("%s: %q is missing", lang, key)
+			}
+		}
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	de := New("de")
+	if got := de.T("nav.back", "Alben"); got != "Zurück: Alben" {
+		t.Errorf("expected the German message, got %q", got)
+	}
+	if got := New("xx").T("screen.queue"); got != "Queue" {
+		t.Errorf("expected unknown languages to use English, got %q", got)
+	}
+	if got := de.T("no.such.key"); got != "no.such.key" {
+		t.Errorf("expected an unknown key back, got %q", got)
+	}
+
+	Register("test", OneOther, Messages{"count.album.other": "%d Platten"})
+	defer delete(registry, "test")
+	if got := New("test").T("screen.search"); got != "Search" {
+		t.Errorf("expected a missing message from English, got %q", got)
+	}
+	if got := New("test").N("count.album", 1); got != "1 Platten" {
+		t.Errorf("expected a missing form from the language's other, got %q", got)
+	}
+}
+
+func TestPlural(t *testing.T) {
+	for _, tc := range []struct {
+		lang string
+		n    int
+		want string
+	}{
+		{"en", 0, "0 albums"},
+		{"en", 1, "1 album"},
+		{"en", 2, "2 albums"},
+		{"de", 1, "1 Album"},
+		{"de", 3, "3 Alben"},
+		{"es", 1, "1 álbum"},
+		{"es", 12, "12 álbumes"},
+		{"ja", 1, "1 枚"},
+		{"ja", 5, "5 枚"},
+	} {
+		if got := New(tc.lang).N("count.album", tc.n); got != tc.want {
+			t.Errorf("%s %d: expected %q, got %q", tc.lang, tc.n, tc.want, got)
+		}
+	}
+	if got := New("en").N("count.song", 2); got != "2 count.song" {
+		t.Errorf("expected an unknown count with its key, got %q", got)
+	}
+}
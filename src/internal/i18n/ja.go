@@ -0,0 +1,334 @@
+package i18n
+
+func init() {
+	Register("ja", OtherOnly, Japanese)
+}
+
+// Japanese is the catalog for "ja". Counts take a counter word rather
+// than a plural.
+var Japanese = Messages{
+	"screen.loading":     "読み込み中",
+	"screen.now_playing": "再生中",
+	"screen.search":      "検索",
+	"screen.library":     "ライブラリ",
+	"screen.queue":       "キュー",
+	"screen.playlists":   "プレイリスト",
+	"screen.lyrics":      "歌詞",
+	"screen.scrobbles":   "Scrobble",
+	"screen.config":      "設定",
+
+	"library.artists":    "アーティスト",
+	"library.composers":  "作曲家",
+	"library.albums":     "アルバム",
+	"library.works":      "作品",
+	"library.top_tracks": "人気曲",
+	"library.movements":  "楽章",
+	"library.tracks":     "曲",
+	"library.sort":       "並び順: %s",
+
+	"nav.back":       "戻る: %s",
+	"nav.forward":    "進む: %s",
+	"nav.no_back":    "戻る先がありません",
+	"nav.no_forward": "進む先がありません",
+	"nav.search":     "検索 %s",
+
+	"status.loading":          "%s を読み込み中...",
+	"status.playing_next":     "次に再生: %s (%s)",
+	"status.queued":           "%s をキューに追加しました (%s)",
+	"status.playing":          "%s を再生中",
+	"status.added":            "キューに追加しました: %s",
+	"status.next":             "次に再生: %s",
+	"status.restored":         "%d 曲を復元しました",
+	"status.shuffled":         "この先の %d 曲をシャッフルしました",
+	"status.queue_full":       "キューがいっぱいです (最大 %d 曲): %d / %d 曲を追加しました",
+	"status.queue_empty":      "キューはすでに空です",
+	"status.queue_cleared":    "キューをクリアしました",
+	"status.artists_loaded":   "アーティストを読み込みました (%d)",
+	"status.albums_loaded":    "アルバムを読み込みました (%d)",
+	"status.tracks_loaded":    "曲を読み込みました (%d)",
+	"status.playlists_loaded": "プレイリストを読み込みました (%d)",
+	"status.more_results":     "続きの結果を読み込みました",
+	"status.enter_query":      "検索語を入力してください",
+	"status.jump":             "移動: A-Z を押すか、数字と記号は #",
+	"status.switching":        "プロフィールを切り替え中...",
+	"status.switched":         "%s に切り替えました",
+	"status.approve":          "ブラウザで Tunez を承認してください: %s",
+	"status.mpv_restarting":   "mpv が予期せず停止しました。再起動中...",
+	"status.mpv_restarted":    "mpv を再起動しました",
+	"status.ready":            "準備完了",
+	"status.init_failed":      "初期化に失敗しました",
+
+	"confirm.clear_queue":        "キューをクリア",
+	"confirm.clear_queue.detail": "キューから %d 曲すべてを削除しますか?",
+
+	"help.title":             "ヘルプ / キー操作",
+	"help.close":             "? か Esc で閉じる",
+	"help.global":            "全体",
+	"help.player":            "プレーヤー",
+	"help.navigation":        "移動",
+	"help.search":            "検索",
+	"help.queue":             "キュー",
+	"help.library":           "ライブラリ",
+	"help.switch_pane":       "ペイン切り替え (ナビ ↔ 内容)",
+	"help.toggle_help":       "ヘルプの表示切り替え",
+	"help.switch_profile":    "プロフィール切り替え",
+	"help.quick_open":        "クイックオープン (どこへでも移動)",
+	"help.quit":              "終了",
+	"help.play_pause":        "再生/一時停止",
+	"help.next_prev":         "次 / 前の曲",
+	"help.seek":              "シーク -%ds / +%ds",
+	"help.volume":            "音量を下げる / 上げる",
+	"help.mute":              "ミュート",
+	"help.shuffle":           "シャッフル切り替え",
+	"help.repeat":            "リピート切り替え (オフ/全曲/1曲)",
+	"help.love":              "お気に入りに追加 / 解除",
+	"help.zen":               "Zen モード (任意のキーで戻る)",
+	"help.bookmark":          "位置をブックマーク / ブックマーク一覧",
+	"help.chapter":           "前 / 次のチャプター",
+	"help.chapters":          "チャプター選択",
+	"help.go_to":             "曲のアルバム / アーティストへ移動",
+	"help.visualizer":        "ビジュアライザー切り替え (再生中)",
+	"help.key.move":          "↑/↓ か j/k",
+	"help.move":              "上下に移動 (画面に応じて)",
+	"help.select":            "選択 / 再生 / 開く",
+	"help.back":              "戻る (ライブラリ)",
+	"help.nav_back":          "前の表示に戻る",
+	"help.nav_forward":       "もう一度進む",
+	"help.search_key":        "検索 (ライブラリ/キューでは絞り込み)",
+	"help.filter":            "絞り込み切り替え (曲/アルバム/アーティスト)",
+	"help.versions":          "曲の別バージョンを表示 / 非表示",
+	"help.remove":            "項目を削除",
+	"help.move_item":         "項目を上 / 下へ",
+	"help.clear_queue":       "キューをクリア",
+	"help.shuffle_remaining": "残りをシャッフル",
+	"help.play_next":         "次に再生 (再生中の後に追加)",
+	"help.add":               "キューに追加",
+	"help.play_next_all":     "次に再生 (アルバム、プレイリストも)",
+	"help.sort":              "並び順を切り替え",
+	"help.decade":            "年代で絞り込み",
+	"help.classical":         "クラシックモード (作曲家)",
+	"help.key.jump":          "' + 文字",
+	"help.jump":              "頭文字でアーティストへ移動",
+	"help.track_info":        "曲の情報 (選択中か再生中)",
+	"help.about":             "アーティストやアルバムについて",
+	"help.similar":           "似たアーティスト",
+	"help.top_tracks":        "アーティストの人気曲",
+
+	"config.sections":          "項目",
+	"config.details":           "詳細",
+	"config.section.providers": "プロバイダーとプロフィール",
+	"config.section.theme":     "テーマと ANSI",
+	"config.section.keys":      "キー操作",
+	"config.section.cache":     "キャッシュ / オフライン",
+	"config.section.logging":   "ログと診断",
+	"config.section.hidden":    "非表示の項目",
+	"config.active_provider":   "使用中のプロバイダー: %s",
+	"config.profile":           "プロフィール: %s",
+	"config.profiles":          "プロフィール数: %d",
+	"config.capabilities":      "機能: %s",
+	"config.artwork":           "アートワーク",
+	"config.theme":             "テーマ: %s",
+	"config.no_emoji":          "絵文字なし: %s",
+	"config.page_size":         "ページサイズ: %d",
+	"config.keys.help":         "ヘルプ",
+	"config.mpv_path":          "MPV のパス: %s",
+	"config.seek_small":        "小シーク: %ds",
+	"config.seek_large":        "大シーク: %ds",
+	"config.volume_step":       "音量の刻み: %d%%",
+	"config.exclusive":         "排他出力: %s",
+	"config.trim_silence":      "無音のカット: %s",
+	"config.trim_below":        "オン (%gdB 未満)",
+	"config.normalize":         "ノーマライズ: %s",
+	"config.yes":               "はい",
+	"config.no":                "いいえ",
+	"config.on":                "オン",
+	"config.off":               "オフ",
+	"config.file":              "設定ファイル: %s",
+	"config.hint":              "[Enter]項目を開く  [Esc]戻る",
+	"config.hint.cache":        "[Enter]クリア / 上限を変更  [Esc]戻る",
+	"config.hint.hidden":       "[Enter]再表示  [Esc]戻る",
+
+	"cache.artwork":            "アートワーク",
+	"cache.responses":          "ライブラリの応答",
+	"cache.audio":              "オフライン音声",
+	"cache.state":              "キューと状態",
+	"cache.artwork_limits":     "アートワーク: 最大 %d MB、%d 日間保存",
+	"cache.responses_status":   "ライブラリの応答: %s",
+	"cache.audio_status":       "オフライン音声: %s",
+	"cache.prefetch":           "次の曲、終了の %ds 前",
+	"cache.open":               "Enter でサイズを表示してクリア",
+	"cache.measuring":          "計測中...",
+	"cache.unknown":            "不明",
+	"cache.size_limit":         "アートワークの上限",
+	"cache.kept_for":           "アートワーク保存期間",
+	"cache.mb":                 "%d MB",
+	"cache.days":               "%d 日",
+	"cache.limit_set":          "アートワークキャッシュの上限: %d MB",
+	"cache.days_set":           "アートワークを %d 日間保存",
+	"cache.clear":              "%s をクリアしますか?",
+	"cache.detail.artwork":     "アートワークは表示するときに再変換されます。",
+	"cache.detail.responses":   "ライブラリは閲覧するたびにサーバーから再取得され、\nそれまではオフラインで閲覧できません。",
+	"cache.detail.audio":       "先読みした次の曲は代わりにストリーミングされます。",
+	"cache.clearing.artwork":   "アートワークをクリア中...",
+	"cache.clearing.responses": "ライブラリの応答をクリア中...",
+	"cache.clearing.audio":     "オフライン音声をクリア中...",
+	"cache.clearing.state":     "状態データベースを最適化中...",
+	"cache.cleared.artwork":    "アートワークをクリアしました",
+	"cache.cleared.responses":  "ライブラリの応答をクリアしました",
+	"cache.cleared.audio":      "オフライン音声をクリアしました",
+	"cache.cleared.state":      "状態データベースを最適化しました",
+
+	"hidden.none":        "非表示の項目はありません。ライブラリや検索で、アーティスト、\nアルバム、曲にコマンドパレットの「非表示」を使います。",
+	"hidden.count":       "非表示: %d 件\nEnter で確認して再表示",
+	"hidden.select":      "先にアーティスト、アルバム、曲を選択してください",
+	"hidden.needs_store": "非表示には状態データベースが必要です",
+	"hidden.hidden":      "非表示にしました: %s",
+	"hidden.unhidden":    "再表示しました: %s",
+	"hidden.kind.artist": "アーティスト",
+	"hidden.kind.album":  "アルバム",
+	"hidden.kind.track":  "曲",
+
+	"status.starting":                    "読み込み中…",
+	"status.nothing_playing":             "再生中の曲はありません",
+	"status.nothing_in":                  "%s に再生できる曲がありません",
+	"status.no_track_selected":           "曲が選択されていません",
+	"status.select_track":                "先に曲を選択してください",
+	"status.select_artist":               "アーティストを選択してください",
+	"status.select_artist_album":         "アーティストかアルバムを選択してください",
+	"status.select_album_track":          "先にアルバム内の曲を選択してください",
+	"status.select_track_album_playlist": "先に曲、アルバム、プレイリストのいずれかを選択してください",
+	"status.select_playlist":             "先にプレイリストを選択してください",
+	"status.added_tracks":                "%d 曲をキューに追加しました",
+	"status.queue_is_empty":              "キューは空です",
+	"status.looking_up":                  "%s を検索中...",
+	"status.seeking":                     "%s へ移動",
+	"status.volume":                      "音量 %d%%",
+	"status.no_artist":                   "%s に一致するアーティストはありません",
+	"status.no_tracks_by":                "%s の曲はありません",
+	"status.ran":                         "%s を実行しました",
+
+	"confirm.cancelled": "キャンセルしました",
+	"confirm.keys":      "[y]はい  [n]いいえ",
+
+	"about.off":             "情報表示はオフです。経歴を取得するには [about] enabled = true を設定してください",
+	"audiobook.resuming":    "%s から再開",
+	"autopause.sleep":       "一時停止: システムがスリープしました",
+	"autopause.device":      "一時停止: オーディオデバイスが外されました (%s)",
+	"autoskip.skipped":      "%q をスキップしました: %s",
+	"autoskip.skipped_many": "%d 曲をスキップしました",
+
+	"bookmarks.needs_persist": "ブックマークには [queue] persist = true が必要です",
+	"bookmarks.added":         "%s にブックマークしました",
+	"bookmarks.none":          "この曲にブックマークはありません (%s で追加)",
+	"bookmarks.jumping":       "%s へジャンプ",
+	"bookmarks.removed":       "ブックマーク %s を削除しました",
+	"chapters.chapter":        "チャプター %d/%d: %s",
+	"chapters.none":           "この曲にはチャプターがありません",
+	"chapters.last":           "すでに最後のチャプターです",
+	"classical.off":           "ライブラリ: アーティスト",
+	"classical.on":            "ライブラリ: 作曲家 (クラシックモード)",
+	"continue.resuming":       "%s を再開",
+
+	"player.exclusive_on":      "排他出力オン",
+	"player.exclusive_off":     "排他出力オフ",
+	"player.trim_on":           "無音カットオン",
+	"player.trim_off":          "無音カットオフ",
+	"player.normalization":     "ノーマライズ: %s",
+	"quality.low_on":           "次の曲から低帯域ストリーミングをオン (%s)",
+	"quality.low_off":          "次の曲から低帯域ストリーミングをオフ (%s)",
+	"viz.off":                  "ビジュアライザーはオフです",
+	"viz.style":                "ビジュアライザー: %s",
+	"scrobble.off":             "Scrobble はオフです",
+	"scrobbles.sent":           "Scrobble は送信済みです",
+	"scrobbles.removed":        "%q を %s のキューから削除しました",
+	"lastfm.requesting":        "Last.fm のトークンを要求中…",
+	"lastfm.connected":         "Last.fm に %s として接続しました",
+	"lastfm.connected_restart": "Last.fm に %s として接続しました。Scrobble を始めるには再起動してください",
+	"love.unsupported":         "お気に入りに対応した Scrobbler がありません",
+	"love.loved":               "お気に入り ♥",
+	"love.unloved":             "お気に入りを解除しました",
+
+	"random.tracks":          "ランダムに曲を選んでいます...",
+	"random.album":           "ランダムにアルバムを選んでいます...",
+	"random.artist":          "ランダムにアーティストを選んでいます...",
+	"random.what":            "ランダムな曲",
+	"random.what.filtered":   "ランダムな曲 (%s)",
+	"random.what.album":      "%s の曲",
+	"random.what.artist":     "%s の曲",
+	"startup.what":           "曲",
+	"startup.added":          "%d 件の%sをキューに追加しました",
+	"startup.no_search":      "起動時の検索で曲が見つかりません",
+	"startup.no_random":      "ランダム再生する曲が見つかりません",
+	"playfrom.playing":       "%s を再生中、ほか %d 曲をキューに追加",
+	"dropfolder.added":       "ドロップフォルダから追加: %s",
+	"dropfolder.added_many":  "ドロップフォルダから %d 曲を追加しました",
+	"playlistfile.importing": "%s をインポート中...",
+	"playlistfile.exporting": "%s をエクスポート中...",
+	"playlistfile.exported":  "%d 曲を %s にエクスポートしました",
+	"playlistfile.imported":  "%[3]s から %[2]d 曲中 %[1]d 曲をインポートしました",
+
+	"delete.off":               "ファイルの削除はオフです。有効にするには [library] allow_delete = true を設定してください",
+	"delete.not_local":         "削除できるのはローカルライブラリのファイルだけです",
+	"delete.playing":           "再生中の曲は削除できません。先にスキップしてください",
+	"delete.title":             "ファイルを削除",
+	"delete.confirm.trash":     "%[2]s の %[1]s をゴミ箱に移動しますか?",
+	"delete.confirm.permanent": "%[2]s の %[1]s を完全に削除しますか?",
+	"delete.restorable":        "ゴミ箱から復元できます。",
+	"delete.final":             "元に戻せません。",
+	"delete.really":            "本当に削除しますか?",
+	"delete.really.detail":     "%s はライブラリとキューから削除されます。%s\ny キーで削除します。",
+	"delete.deleting":          "%s を削除中...",
+	"delete.deleted":           "削除しました: %s",
+	"delete.trashed":           "ゴミ箱に移動しました: %s",
+
+	"goto.missing":       "ライブラリに %s が見つかりません",
+	"goto.missing_it":    "ライブラリに見つかりません",
+	"goto.artist":        "アーティスト: %s",
+	"goto.album":         "アルバム: %s",
+	"jump.none":          "%s のアーティストはいません",
+	"jump.jumping":       "%s へジャンプ中...",
+	"location.not_local": "開けるフォルダがあるのはローカルファイルだけです",
+	"location.opened":    "%s を開きました",
+	"location.none":      "%s のパスや URL がありません",
+	"location.copied":    "%s をコピーしました",
+	"health.offline":     "プロバイダーに接続できません: キャッシュを表示しています",
+	"health.online":      "プロバイダーに再接続しました",
+	"profiles.none":      "切り替えられる他のプロファイルはありません",
+
+	"radio.select":           "先に曲かアーティストを選択してください",
+	"radio.tuning":           "%s を選局中...",
+	"radio.empty":            "ラジオ: %s に似た曲はありません",
+	"radio.station":          "ラジオ: %s",
+	"radio.off":              "ラジオはオフです",
+	"radio.stopped":          "ラジオを停止しました",
+	"artwork.none":           "%s のアートワークはありません",
+	"artwork.saving":         "アートワークを保存中...",
+	"artwork.saved":          "アートワークを %s に保存しました",
+	"search.failed":          "検索: %s",
+	"search.searching":       "検索中... これまでに %d 件",
+	"search.found":           "%d 件見つかりました",
+	"similar.needs_key":      "似たアーティストには [[scrobblers]] に Last.fm の api_key が必要です",
+	"similar.not_in_library": "%s はライブラリにありません",
+	"toptracks.loading":      "%s の人気曲を読み込み中...",
+	"toptracks.by_plays":     "%s の人気曲 (再生回数順)",
+	"toptracks.no_plays":     "%s の再生履歴はまだありません。最初の曲を表示しています",
+	"sort.sorted":            "%s を%sで並べ替えました",
+	"versions.none":          "この曲に他のバージョンはありません",
+	"years.showing":          "%s を表示中",
+	"commands.stopped":       "%s は %s で停止しました",
+
+	"count.album.other":    "%d 枚",
+	"count.work.other":     "%d 作品",
+	"count.movement.other": "%d 楽章",
+	"count.track.other":    "%d 曲",
+
+	"time.just_now":          "たった今",
+	"time.minutes_ago.other": "%d 分前",
+	"time.hours_ago.other":   "%d 時間前",
+	"time.yesterday":         "昨日",
+	"time.yesterday_at":      "昨日 %s",
+	"time.days_ago.other":    "%d 日前",
+	"time.weeks_ago.other":   "%d 週間前",
+	"time.am":                "午前 %s",
+	"time.pm":                "午後 %s",
+}
@@ -0,0 +1,179 @@
+// Package libexport dumps a provider's library of artists, albums and
+// tracks as JSON or CSV, for backups, spreadsheets and other tools.
+package libexport
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// Formats lists the supported output formats.
+var Formats = []string{"csv", "json"}
+
+// Library is everything exported from a provider.
+type Library struct {
+	Provider   string    `json:"provider"`
+	Profile    string    `json:"profile"`
+	ExportedAt time.Time `json:"exported_at"`
+	Artists    []Artist  `json:"artists"`
+}
+
+// Artist is an artist and its albums.
+type Artist struct {
+	ID     string  `json:"id"`
+	Name   string  `json:"name"`
+	Albums []Album `json:"albums"`
+}
+
+// Album is an album and its tracks.
+type Album struct {
+	ID     string  `json:"id"`
+	Title  string  `json:"title"`
+	Year   int     `json:"year,omitempty"`
+	Tracks []Track `json:"tracks"`
+}
+
+// Track holds the metadata exported for a track.
+type Track struct {
+	ID          string `json:"id"`
+	Title       string `json:"title"`
+	Artist      string `json:"artist"`
+	Disc        int    `json:"disc,omitempty"`
+	Number      int    `json:"number,omitempty"`
+	DurationMs  int    `json:"duration_ms"`
+	Codec       string `json:"codec,omitempty"`
+	BitrateKbps int    `json:"bitrate_kbps,omitempty"`
+	SampleRate  int    `json:"sample_rate_hz,omitempty"`
+	BitDepth    int    `json:"bit_depth,omitempty"`
+	Path        string `json:"path,omitempty"`
+}
+
+// Options tunes Collect.
+type Options struct {
+	PageSize int
+	// Progress, when set, is called after each artist with the artists
+	// and tracks collected so far.
+	Progress func(artists, tracks int)
+}
+
+// Collect pages through every artist, album and track of prov.
+func Collect(ctx context.Context, prov provider.Provider, opts Options) (Library, error) {
+	lib := Library{Provider: prov.ID(), ExportedAt: time.Now().UTC()}
+	req := provider.ListReq{PageSize: opts.PageSize}
+	artists, err := pageAll(ctx, func(cursor string) (provider.Page[provider.Artist], error) {
+		req.Cursor = cursor
+		return prov.ListArtists(ctx, req)
+	})
+	if err != nil {
+		return lib, fmt.Errorf("list artists: %w", err)
+	}
+	tracks := 0
+	for _, a := range artists {
+		artist := Artist{ID: a.ID, Name: a.Name}
+		albums, err := pageAll(ctx, func(cursor string) (provider.Page[provider.Album], error) {
+			return prov.ListAlbums(ctx, a.ID, provider.ListReq{PageSize: opts.PageSize, Cursor: cursor})
+		})
+		if err != nil {
+			return lib, fmt.Errorf("list albums of %s: %w", a.Name, err)
+		}
+		for _, al := range albums {
+			album := Album{ID: al.ID, Title: al.Title, Year: al.Year}
+			items, err := pageAll(ctx, func(cursor string) (provider.Page[provider.Track], error) {
+				return prov.ListTracks(ctx, al.ID, "", "", provider.ListReq{PageSize: opts.PageSize, Cursor: cursor})
+			})
+			if err != nil {
+				return lib, fmt.Errorf("list tracks of %s: %w", al.Title, err)
+			}
+			for _, t := range items {
+				album.Tracks = append(album.Tracks, Track{
+					ID: t.ID, Title: t.Title, Artist: t.ArtistName, Disc: t.DiscNo, Number: t.TrackNo,
+					DurationMs: t.DurationMs, Codec: t.Codec, BitrateKbps: t.BitrateKbps,
+					SampleRate: t.SampleRateHz, BitDepth: t.BitDepth, Path: t.FilePath,
+				})
+			}
+			tracks += len(items)
+			artist.Albums = append(artist.Albums, album)
+		}
+		lib.Artists = append(lib.Artists, artist)
+		if opts.Progress != nil {
+			opts.Progress(len(lib.Artists), tracks)
+		}
+	}
+	return lib, nil
+}
+
+// pageAll fetches pages until the cursor runs out.
+func pageAll[T any](ctx context.Context, fetch func(cursor string) (provider.Page[T], error)) ([]T, error) {
+	var out []T
+	cursor := ""
+	for {
+		if err := ctx.Err(); err != nil {
+			return out, err
+		}
+		page, err := fetch(cursor)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, page.Items...)
+		if page.NextCursor == "" || page.NextCursor == cursor {
+			return out, nil
+		}
+		cursor = page.NextCursor
+	}
+}
+
+// Write writes lib in format, "json" or "csv".
+func Write(w io.Writer, lib Library, format string) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(lib)
+	case "csv":
+		return writeCSV(w, lib)
+	}
+	return fmt.Errorf("unknown export format %q (use csv or json)", format)
+}
+
+// csvHeader names the columns of the CSV export, one row per track.
+var csvHeader = []string{
+	"artist_id", "album_artist", "album_id", "album", "year", "track_id", "disc", "number",
+	"title", "artist", "duration_ms", "codec", "bitrate_kbps", "sample_rate_hz", "bit_depth", "path",
+}
+
+// writeCSV writes one row per track, with its album and artist repeated
+// so the file sorts and filters well in a spreadsheet.
+func writeCSV(w io.Writer, lib Library) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return err
+	}
+	num := func(n int) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.Itoa(n)
+	}
+	for _, a := range lib.Artists {
+		for _, al := range a.Albums {
+			for _, t := range al.Tracks {
+				row := []string{
+					a.ID, a.Name, al.ID, al.Title, num(al.Year), t.ID, num(t.Disc), num(t.Number),
+					t.Title, t.Artist, strconv.Itoa(t.DurationMs), t.Codec, num(t.BitrateKbps), num(t.SampleRate), num(t.BitDepth), t.Path,
+				}
+				if err := cw.Write(row); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
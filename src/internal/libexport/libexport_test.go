@@ -0,0 +1,86 @@
+package libexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// pagedProvider serves a small library one item per page.
+type pagedProvider struct {
+	provider.Provider
+}
+
+func (pagedProvider) ID() string { return "fake" }
+
+func onePerPage[T any](items []T, cursor string) provider.Page[T] {
+	i := 0
+	if cursor != "" {
+		i = int(cursor[0] - '0')
+	}
+	page := provider.Page[T]{Items: items[i : i+1]}
+	if i+1 < len(items) {
+		page.NextCursor = string(rune('0' + i + 1))
+	}
+	return page
+}
+
+func (pagedProvider) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	return onePerPage([]provider.Artist{{ID: "a1", Name: "Abba"}, {ID: "a2", Name: "Queen"}}, req.Cursor), nil
+}
+
+func (pagedProvider) ListAlbums(ctx context.Context, artistID string, req provider.ListReq) (provider.Page[provider.Album], error) {
+	return onePerPage([]provider.Album{{ID: artistID + "-al", Title: "Best of " + artistID, Year: 1980}}, req.Cursor), nil
+}
+
+func (pagedProvider) ListTracks(ctx context.Context, albumID, artistID, playlistID string, req provider.ListReq) (provider.Page[provider.Track], error) {
+	return onePerPage([]provider.Track{
+		{ID: albumID + "-1", Title: "One, Two", ArtistName: "Someone", TrackNo: 1, DurationMs: 1000, FilePath: "/m/1.flac"},
+		{ID: albumID + "-2", Title: "Two", TrackNo: 2, DurationMs: 2000},
+	}, req.Cursor), nil
+}
+
+func TestCollectAndWrite(t *testing.T) {
+	var progress [][2]int
+	lib, err := Collect(context.Background(), pagedProvider{}, Options{PageSize: 1, Progress: func(artists, tracks int) {
+		progress = append(progress, [2]int{artists, tracks})
+	}})
+	if err != nil {
+		t.Fatalf("Collect: %v", err)
+	}
+	if len(lib.Artists) != 2 || len(lib.Artists[1].Albums) != 1 || len(lib.Artists[1].Albums[0].Tracks) != 2 {
+		t.Fatalf("expected every page collected, got %+v", lib)
+	}
+	if len(progress) != 2 || progress[1] != [2]int{2, 4} {
+		t.Errorf("unexpected progress %v", progress)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, lib, "csv"); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil || len(rows) != 5 {
+		t.Fatalf("expected a header and 4 tracks, got %d rows (%v)", len(rows), err)
+	}
+	if rows[1][1] != "Abba" || rows[1][8] != "One, Two" || rows[1][15] != "/m/1.flac" || rows[2][15] != "" {
+		t.Errorf("unexpected row %q", rows[1])
+	}
+
+	buf.Reset()
+	if err := Write(&buf, lib, "json"); err != nil {
+		t.Fatalf("json: %v", err)
+	}
+	var back Library
+	if err := json.Unmarshal(buf.Bytes(), &back); err != nil || back.Provider != "fake" || back.Artists[0].Albums[0].Tracks[0].Path != "/m/1.flac" {
+		t.Errorf("expected the JSON to round trip, got %+v %v", back, err)
+	}
+
+	if err := Write(&buf, lib, "xml"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -2,31 +2,64 @@ package logging
 
 import (
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
-// Setup creates a slog.Logger that writes to a rotating log file in the user
-// state directory. The caller is responsible for closing the file.
-func Setup() (*slog.Logger, *os.File, error) {
-	stateDir, err := StateDir()
-	if err != nil {
-		return nil, nil, fmt.Errorf("state dir: %w", err)
+// Options configures Setup.
+type Options struct {
+	Level slog.Level
+	// Path is the log file; empty means tunez-<date>.log in the state dir.
+	Path string
+	JSON bool // JSON lines instead of key=value text
+	// MaxSize rotates the file once it would grow past this many bytes;
+	// zero never rotates.
+	MaxSize    int64
+	MaxBackups int // rotated files kept as <path>.1 ... <path>.N
+}
+
+// Setup creates a slog.Logger that writes to a rotating log file, by
+// default in the user state directory, and keeps the last lines for
+// Recent. The caller is responsible for closing the returned file.
+func Setup(opts Options) (*slog.Logger, io.Closer, error) {
+	path := opts.Path
+	if path == "" {
+		stateDir, err := StateDir()
+		if err != nil {
+			return nil, nil, fmt.Errorf("state dir: %w", err)
+		}
+		path = filepath.Join(stateDir, fmt.Sprintf("tunez-%s.log", time.Now().Format("20060102")))
 	}
-	if err := os.MkdirAll(stateDir, 0o755); err != nil {
-		return nil, nil, fmt.Errorf("create state dir: %w", err)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, nil, fmt.Errorf("create log dir: %w", err)
 	}
-	path := filepath.Join(stateDir, fmt.Sprintf("tunez-%s.log", time.Now().Format("20060102")))
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	f, err := openRotating(path, opts.MaxSize, opts.MaxBackups)
 	if err != nil {
 		return nil, nil, fmt.Errorf("open log file: %w", err)
 	}
-	handler := slog.NewTextHandler(f, &slog.HandlerOptions{Level: slog.LevelDebug})
+	w := io.MultiWriter(f, recent)
+	handlerOpts := &slog.HandlerOptions{Level: opts.Level}
+	var handler slog.Handler = slog.NewTextHandler(w, handlerOpts)
+	if opts.JSON {
+		handler = slog.NewJSONHandler(w, handlerOpts)
+	}
 	return slog.New(handler), f, nil
 }
 
+// ParseLevel parses debug, info, warn or error, ignoring case.
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	switch strings.ToLower(s) {
+	case "debug", "info", "warn", "error":
+		return l, l.UnmarshalText([]byte(s))
+	}
+	return l, fmt.Errorf("unknown log level %q (want debug, info, warn or error)", s)
+}
+
 // StateDir returns the path to the tunez state directory (~/.config/tunez/state)
 func StateDir() (string, error) {
 	dir, err := os.UserConfigDir()
@@ -0,0 +1,62 @@
+package logging
+
+import (
+	"strings"
+	"sync"
+)
+
+// recentLines is how many log lines Recent keeps.
+const recentLines = 100
+
+var recent = newRing(recentLines)
+
+// Recent returns the last log lines written by loggers from Setup, oldest
+// first. Crash reports include them.
+func Recent() []string {
+	return recent.lines()
+}
+
+// ring keeps the last n lines written to it.
+type ring struct {
+	mu      sync.Mutex
+	buf     []string
+	next    int
+	partial string
+}
+
+func newRing(n int) *ring {
+	return &ring{buf: make([]string, 0, n)}
+}
+
+func (r *ring) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	s := r.partial + string(p)
+	for {
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			break
+		}
+		r.add(s[:i])
+		s = s[i+1:]
+	}
+	r.partial = s
+	return len(p), nil
+}
+
+func (r *ring) add(line string) {
+	if len(r.buf) < cap(r.buf) {
+		r.buf = append(r.buf, line)
+		return
+	}
+	r.buf[r.next] = line
+	r.next = (r.next + 1) % len(r.buf)
+}
+
+func (r *ring) lines() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := make([]string, 0, len(r.buf))
+	out = append(out, r.buf[r.next:]...)
+	return append(out, r.buf[:r.next]...)
+}
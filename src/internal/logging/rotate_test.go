@@ -0,0 +1,58 @@
+package logging
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tunez.log")
+	r, err := openRotating(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer r.Close()
+
+	for _, line := range []string{"first\n", "second\n", "third\n", "fourth\n"} {
+		if _, err := r.Write([]byte(line)); err != nil {
+			t.Fatalf("write %q: %v", line, err)
+		}
+	}
+
+	for name, want := range map[string]string{
+		path:        "fourth\n",
+		path + ".1": "third\n",
+		path + ".2": "second\n",
+	} {
+		got, err := os.ReadFile(name)
+		if err != nil || string(got) != want {
+			t.Errorf("%s: expected %q, got %q %v", filepath.Base(name), want, got, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Errorf("expected only two backups, got %v", err)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, err := ParseLevel("WARN"); err != nil || l.String() != "WARN" {
+		t.Errorf("expected WARN, got %v %v", l, err)
+	}
+	if _, err := ParseLevel("verbose"); err == nil || !strings.Contains(err.Error(), "verbose") {
+		t.Errorf("expected an error for an unknown level, got %v", err)
+	}
+}
+
+func TestRing(t *testing.T) {
+	r := newRing(3)
+	r.Write([]byte("one\ntwo\nthr"))
+	if got := r.lines(); strings.Join(got, ",") != "one,two" {
+		t.Errorf("expected complete lines only, got %v", got)
+	}
+	r.Write([]byte("ee\nfour\nfive\n"))
+	if got := r.lines(); strings.Join(got, ",") != "three,four,five" {
+		t.Errorf("expected the last three lines, got %v", got)
+	}
+}
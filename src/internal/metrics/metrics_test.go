@@ -0,0 +1,61 @@
+package metrics
+
+import (
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWriteText(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("tunez_scrobbles_total", "Scrobbles by result.", "scrobbler", "lastfm", "result", "sent").Inc()
+	r.Counter("tunez_scrobbles_total", "Scrobbles by result.", "scrobbler", "lastfm", "result", "sent").Inc()
+	r.Counter("tunez_scrobbles_total", "Scrobbles by result.", "scrobbler", "lastfm", "result", "failed").Inc()
+	h := r.Histogram("tunez_request_seconds", "Request latency.", []float64{0.1, 1})
+	h.Observe(50 * time.Millisecond)
+	h.Observe(time.Second)
+	h.Observe(3 * time.Second)
+
+	var b strings.Builder
+	if err := r.WriteText(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `# HELP tunez_request_seconds Request latency.
+# TYPE tunez_request_seconds histogram
+tunez_request_seconds_bucket{le="0.1"} 1
+tunez_request_seconds_bucket{le="1"} 2
+tunez_request_seconds_bucket{le="+Inf"} 3
+tunez_request_seconds_sum 4.05
+tunez_request_seconds_count 3
+# HELP tunez_scrobbles_total Scrobbles by result.
+# TYPE tunez_scrobbles_total counter
+tunez_scrobbles_total{scrobbler="lastfm",result="failed"} 1
+tunez_scrobbles_total{scrobbler="lastfm",result="sent"} 2
+`
+	if b.String() != want {
+		t.Errorf("unexpected exposition:\n%s\nwant:\n%s", b.String(), want)
+	}
+}
+
+func TestServe(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("tunez_tracks_played_total", "Tracks started.").Inc()
+	s, err := Serve("127.0.0.1:0", r, slog.New(slog.DiscardHandler))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	resp, err := http.Get("http://" + s.Addr() + "/metrics")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if !strings.Contains(string(body), "tunez_tracks_played_total 1") {
+		t.Errorf("expected the counter in the response, got:\n%s", body)
+	}
+}
@@ -0,0 +1,143 @@
+// Package nowplaying writes the current track to files that other programs
+// (OBS, waybar, polybar) can read.
+package nowplaying
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+	"time"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/i18n"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// DefaultTemplate is used when [export] sets a path but no template.
+const DefaultTemplate = "{{.Artist}} - {{.Title}}"
+
+// Playback states reported in Info.Status.
+const (
+	StatusPlaying = "playing"
+	StatusPaused  = "paused"
+	StatusStopped = "stopped"
+)
+
+// Info is what gets exported: the fields available to the template and the
+// JSON file.
+type Info struct {
+	Status      string `json:"status"`
+	TrackID     string `json:"track_id,omitempty"`
+	Title       string `json:"title"`
+	Artist      string `json:"artist"`
+	Album       string `json:"album"`
+	Year        int    `json:"year,omitempty"`
+	Duration    string `json:"duration"` // m:ss
+	DurationSec int    `json:"duration_sec"`
+}
+
+// FromTrack describes t in the given status. A stopped player exports
+// nothing but the status.
+func FromTrack(t provider.Track, status string) Info {
+	if status == StatusStopped {
+		return Info{Status: status}
+	}
+	secs := t.DurationMs / 1000
+	return Info{
+		Status:      status,
+		TrackID:     t.ID,
+		Title:       t.Title,
+		Artist:      t.ArtistName,
+		Album:       t.AlbumTitle,
+		Year:        t.Year,
+		Duration:    i18n.Duration(time.Duration(secs) * time.Second),
+		DurationSec: secs,
+	}
+}
+
+// Exporter writes Info to the configured files.
+type Exporter struct {
+	path     string
+	tmpl     *template.Template
+	jsonPath string
+}
+
+// ParseTemplate parses an export template, falling back to DefaultTemplate
+// when text is empty. Fields Info doesn't have are reported here rather than
+// on the first track change.
+func ParseTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultTemplate
+	}
+	tmpl, err := template.New("export").Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := tmpl.Execute(io.Discard, Info{}); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// New returns an Exporter for cfg, or nil when no export path is set.
+func New(cfg config.ExportConfig) (*Exporter, error) {
+	if cfg.Path == "" && cfg.JSONPath == "" {
+		return nil, nil
+	}
+	tmpl, err := ParseTemplate(cfg.Template)
+	if err != nil {
+		return nil, err
+	}
+	return &Exporter{path: cfg.Path, tmpl: tmpl, jsonPath: cfg.JSONPath}, nil
+}
+
+// Write renders info to the text file and the JSON file. A stopped player
+// empties the text file.
+func (e *Exporter) Write(info Info) error {
+	if e == nil {
+		return nil
+	}
+	if e.path != "" {
+		var buf bytes.Buffer
+		if info.Status != StatusStopped {
+			if err := e.tmpl.Execute(&buf, info); err != nil {
+				return fmt.Errorf("export template: %w", err)
+			}
+			buf.WriteByte('\n')
+		}
+		if err := writeFile(e.path, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	if e.jsonPath != "" {
+		data, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		if err := writeFile(e.jsonPath, append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeFile replaces path in one step so readers never see a partial file.
+func writeFile(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tunez-export-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,58 @@
+package nowplaying
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/config"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+	text := filepath.Join(dir, "now.txt")
+	js := filepath.Join(dir, "now.json")
+	e, err := New(config.ExportConfig{Path: text, JSONPath: js, Template: "{{.Title}} by {{.Artist}} [{{.Duration}}]"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	track := provider.Track{ID: "101", Title: "Breathe", ArtistName: "Pink Floyd", AlbumTitle: "The Dark Side of the Moon", DurationMs: 163000}
+	if err := e.Write(FromTrack(track, StatusPlaying)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(text); string(got) != "Breathe by Pink Floyd [2:43]\n" {
+		t.Errorf("unexpected text export %q", got)
+	}
+	var info Info
+	data, _ := os.ReadFile(js)
+	if err := json.Unmarshal(data, &info); err != nil || info.Status != StatusPlaying || info.Album != track.AlbumTitle || info.DurationSec != 163 {
+		t.Errorf("unexpected JSON export %s %v", data, err)
+	}
+
+	// Stopping empties the text file but keeps the status in JSON
+	if err := e.Write(FromTrack(track, StatusStopped)); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := os.ReadFile(text); len(got) != 0 {
+		t.Errorf("expected empty text export when stopped, got %q", got)
+	}
+	data, _ = os.ReadFile(js)
+	if string(data) != "{\"status\":\"stopped\",\"title\":\"\",\"artist\":\"\",\"album\":\"\",\"duration\":\"\",\"duration_sec\":0}\n" {
+		t.Errorf("unexpected stopped JSON %s", data)
+	}
+}
+
+func TestNewDisabledAndBadTemplate(t *testing.T) {
+	if e, err := New(config.ExportConfig{Template: "{{.Title}}"}); e != nil || err != nil {
+		t.Errorf("expected no exporter without paths, got %v %v", e, err)
+	}
+	if _, err := New(config.ExportConfig{Path: "x", Template: "{{.Title"}); err == nil {
+		t.Error("expected a parse error")
+	}
+	if _, err := New(config.ExportConfig{Path: "x", Template: "{{.Song}}"}); err == nil {
+		t.Error("expected an error for an unknown field")
+	}
+}
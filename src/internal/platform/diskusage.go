@@ -0,0 +1,32 @@
+package platform
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DiskUsage returns the bytes taken by files at the given paths, counting
+// everything under a directory. Paths that don't exist count as empty, as
+// do files removed while they are being counted.
+func DiskUsage(paths ...string) (int64, error) {
+	var total int64
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+			if err == nil && d.Type().IsRegular() {
+				var info fs.FileInfo
+				if info, err = d.Info(); err == nil {
+					total += info.Size()
+				}
+			}
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
@@ -0,0 +1,30 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDiskUsage(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]int{"a.db": 100, "a.db-wal": 20, "cache/x.ansi": 7, "cache/sub/y.ansi": 3}
+	for name, size := range files {
+		path := filepath.Join(dir, name)
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	db := filepath.Join(dir, "a.db")
+	if got, err := DiskUsage(db, db+"-wal", db+"-shm"); err != nil || got != 120 {
+		t.Errorf("expected 120 bytes for the database, got %d %v", got, err)
+	}
+	if got, err := DiskUsage(filepath.Join(dir, "cache")); err != nil || got != 10 {
+		t.Errorf("expected 10 bytes under the directory, got %d %v", got, err)
+	}
+	if got, err := DiskUsage(filepath.Join(dir, "missing")); err != nil || got != 0 {
+		t.Errorf("expected nothing for a missing path, got %d %v", got, err)
+	}
+}
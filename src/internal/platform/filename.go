@@ -0,0 +1,17 @@
+package platform
+
+import "strings"
+
+// FileName makes s safe to use as a file or folder name on any system:
+// path separators, characters Windows forbids and control characters
+// become "_", and the spaces and dots Windows drops from the ends go. It
+// returns "" when nothing is left.
+func FileName(s string) string {
+	s = strings.Map(func(r rune) rune {
+		if r < 32 || strings.ContainsRune(`/\:*?"<>|`, r) {
+			return '_'
+		}
+		return r
+	}, s)
+	return strings.TrimRight(strings.TrimSpace(s), ". ")
+}
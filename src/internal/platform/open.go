@@ -0,0 +1,28 @@
+// Package platform wraps the small bits of OS integration Tunez needs.
+package platform
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+)
+
+// Open hands a URL or path to the desktop's default handler (browser, file
+// manager). It returns once the handler has been started.
+func Open(target string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", target)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", target)
+	default:
+		cmd = exec.Command("xdg-open", target)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("open %s: %w", target, err)
+	}
+	// Reap the launcher in the background so it doesn't linger as a zombie
+	go cmd.Wait()
+	return nil
+}
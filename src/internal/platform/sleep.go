@@ -0,0 +1,92 @@
+package platform
+
+import (
+	"bufio"
+	"context"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// sleepPoll is how often the clock is checked for a suspend where logind
+// can't be watched.
+const sleepPoll = 2 * time.Second
+
+// WatchSleep reports on the returned channel each time the system goes to
+// sleep, until ctx is done. On Linux it listens for logind's PrepareForSleep
+// signal, which arrives just before the suspend. Elsewhere, or without
+// gdbus, it notices the jump in wall-clock time a suspend leaves behind,
+// which is only once the system wakes up.
+func WatchSleep(ctx context.Context) <-chan struct{} {
+	ch := make(chan struct{}, 1)
+	go func() {
+		if runtime.GOOS == "linux" {
+			if err := watchLogind(ctx, ch); err == nil || ctx.Err() != nil {
+				return
+			}
+		}
+		watchClock(ctx, ch)
+	}()
+	return ch
+}
+
+// watchLogind follows logind's signals through gdbus. It returns an error
+// when gdbus is missing or stops, so the caller can fall back to the clock.
+func watchLogind(ctx context.Context, ch chan<- struct{}) error {
+	cmd := exec.CommandContext(ctx, "gdbus", "monitor", "--system",
+		"--dest", "org.freedesktop.login1", "--object-path", "/org/freedesktop/login1")
+	out, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	scanner := bufio.NewScanner(out)
+	for scanner.Scan() {
+		if prepareForSleep(scanner.Text()) {
+			notify(ch)
+		}
+	}
+	return cmd.Wait()
+}
+
+// prepareForSleep reports whether a gdbus monitor line is logind announcing
+// a suspend (rather than the wake-up, which carries false).
+func prepareForSleep(line string) bool {
+	return strings.Contains(line, ".PrepareForSleep (true")
+}
+
+// watchClock compares the wall clock with the monotonic clock, which
+// stands still while the system sleeps.
+func watchClock(ctx context.Context, ch chan<- struct{}) {
+	ticker := time.NewTicker(sleepPoll)
+	defer ticker.Stop()
+	prev := time.Now()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if slept(now.Round(0).Sub(prev.Round(0)), now.Sub(prev)) {
+				notify(ch)
+			}
+			prev = now
+		}
+	}
+}
+
+// slept reports whether the wall clock moved on noticeably further than the
+// monotonic clock between two readings, as it does across a suspend.
+func slept(wall, monotonic time.Duration) bool {
+	return wall-monotonic > 3*sleepPoll
+}
+
+// notify sends on ch unless a notification is already waiting.
+func notify(ch chan<- struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
@@ -0,0 +1,24 @@
+package platform
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPrepareForSleep(t *testing.T) {
+	if !prepareForSleep("/org/freedesktop/login1: org.freedesktop.login1.Manager.PrepareForSleep (true,)") {
+		t.Error("expected the suspend signal to be recognized")
+	}
+	if prepareForSleep("/org/freedesktop/login1: org.freedesktop.login1.Manager.PrepareForSleep (false,)") {
+		t.Error("expected the wake-up signal to be ignored")
+	}
+}
+
+func TestSlept(t *testing.T) {
+	if slept(sleepPoll, sleepPoll) || slept(sleepPoll+time.Second, sleepPoll) {
+		t.Error("expected a regular tick, or a small clock adjustment, not to count as sleep")
+	}
+	if !slept(10*time.Minute, sleepPoll) {
+		t.Error("expected wall time passing without monotonic time to count as sleep")
+	}
+}
@@ -0,0 +1,138 @@
+package platform
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Trash moves a file to the desktop's trash, where it can be restored
+// from: the Finder's Trash on macOS, the Recycle Bin on Windows, and the
+// freedesktop.org home trash elsewhere.
+func Trash(path string) error {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	if _, err := os.Lstat(path); err != nil {
+		return err
+	}
+	switch runtime.GOOS {
+	case "darwin":
+		err = runTrash(exec.Command("osascript", "-e",
+			`on run argv`, "-e", `tell application "Finder" to delete POSIX file (item 1 of argv)`, "-e", `end run`, path))
+	case "windows":
+		err = runTrash(exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command",
+			`Add-Type -AssemblyName Microsoft.VisualBasic; [Microsoft.VisualBasic.FileIO.FileSystem]::DeleteFile($args[0], 'OnlyErrorDialogs', 'SendToRecycleBin')`, path))
+	default:
+		err = trashFreedesktop(path, time.Now())
+	}
+	if err != nil {
+		return fmt.Errorf("trash %s: %w", path, err)
+	}
+	return nil
+}
+
+func runTrash(cmd *exec.Cmd) error {
+	out, err := cmd.CombinedOutput()
+	if err != nil && len(out) > 0 {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(out)))
+	}
+	return err
+}
+
+// trashDir is the freedesktop.org home trash, $XDG_DATA_HOME/Trash.
+func trashDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "Trash"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "Trash"), nil
+}
+
+// trashFreedesktop follows the freedesktop.org trash spec: the file goes
+// to Trash/files under a free name, with a Trash/info/<name>.trashinfo
+// recording where it came from so file managers can restore it. Files on
+// another file system are copied over, then removed.
+func trashFreedesktop(path string, now time.Time) error {
+	dir, err := trashDir()
+	if err != nil {
+		return err
+	}
+	files, info := filepath.Join(dir, "files"), filepath.Join(dir, "info")
+	if err := os.MkdirAll(files, 0o700); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(info, 0o700); err != nil {
+		return err
+	}
+
+	// Claim a name by creating its info file, which fails if taken
+	base, ext := filepath.Base(path), filepath.Ext(path)
+	name := base
+	var f *os.File
+	for i := 2; ; i++ {
+		f, err = os.OpenFile(filepath.Join(info, name+".trashinfo"), os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if err == nil {
+			if _, statErr := os.Lstat(filepath.Join(files, name)); os.IsNotExist(statErr) {
+				break
+			}
+			f.Close()
+			os.Remove(f.Name())
+		} else if !os.IsExist(err) {
+			return err
+		}
+		name = strings.TrimSuffix(base, ext) + "." + strconv.Itoa(i) + ext
+	}
+	escaped := (&url.URL{Path: path}).EscapedPath()
+	_, err = fmt.Fprintf(f, "[Trash Info]\nPath=%s\nDeletionDate=%s\n", escaped, now.Format("2006-01-02T15:04:05"))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = moveFile(path, filepath.Join(files, name))
+	}
+	if err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// moveFile renames a file, copying it across file systems.
+func moveFile(from, to string) error {
+	err := os.Rename(from, to)
+	if !errors.Is(err, syscall.EXDEV) {
+		return err
+	}
+	src, err := os.Open(from)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+	dst, err := os.OpenFile(to, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		dst.Close()
+		os.Remove(to)
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		os.Remove(to)
+		return err
+	}
+	return os.Remove(from)
+}
@@ -0,0 +1,54 @@
+package platform
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTrashFreedesktop(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	now := time.Date(2024, 5, 1, 20, 30, 0, 0, time.Local)
+
+	// Two files of the same name get names of their own in the trash
+	var paths []string
+	for _, sub := range []string{"a", "b"} {
+		path := filepath.Join(dir, sub, "My Song.flac")
+		os.MkdirAll(filepath.Dir(path), 0o755)
+		if err := os.WriteFile(path, []byte(sub), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if err := trashFreedesktop(path, now); err != nil {
+			t.Fatalf("trash %s: %v", path, err)
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("expected %s gone, got %v", path, err)
+		}
+		paths = append(paths, path)
+	}
+
+	trash := filepath.Join(dir, "data", "Trash")
+	for i, name := range []string{"My Song.flac", "My Song.2.flac"} {
+		if data, err := os.ReadFile(filepath.Join(trash, "files", name)); err != nil || string(data) != []string{"a", "b"}[i] {
+			t.Errorf("expected %s in the trash, got %q %v", name, data, err)
+		}
+		info, err := os.ReadFile(filepath.Join(trash, "info", name+".trashinfo"))
+		if err != nil {
+			t.Fatalf("expected info for %s: %v", name, err)
+		}
+		want := "Path=" + strings.ReplaceAll(paths[i], " ", "%20") + "\nDeletionDate=2024-05-01T20:30:00\n"
+		if !strings.HasPrefix(string(info), "[Trash Info]\n") || !strings.HasSuffix(string(info), want) {
+			t.Errorf("unexpected trash info for %s:\n%s", name, info)
+		}
+	}
+
+	if err := trashFreedesktop(filepath.Join(dir, "missing.mp3"), now); err == nil {
+		t.Error("expected trashing a missing file to fail")
+	}
+	if _, err := os.Stat(filepath.Join(trash, "info", "missing.mp3.trashinfo")); !os.IsNotExist(err) {
+		t.Errorf("expected no info left for a failed trash, got %v", err)
+	}
+}
@@ -0,0 +1,159 @@
+package player
+
+import (
+	"log/slog"
+	"time"
+)
+
+// fadeStep is how often the volume moves during a fade.
+const fadeStep = 20 * time.Millisecond
+
+// fadeState tracks mpv's volume around fades. Guarded by Controller.mu.
+type fadeState struct {
+	volume  float64 // mpv volume the user set, which fades return to
+	down    bool    // the volume is held below it after a fade-out
+	fading  bool    // a fade is moving the volume right now
+	inOnRun bool    // fade in once the loaded file starts playing
+	// gen counts pause and resume requests; a fade stops, and a pause is
+	// dropped, once a newer one comes in
+	gen uint64
+}
+
+// FadePause pauses or resumes playback. With Options.Fade set the volume
+// fades out before pausing and in after resuming. Pauses and resumes run
+// one at a time, and a newer one cuts short the fade of the one before,
+// so the last request wins.
+func (c *Controller) FadePause(paused bool) error {
+	if c.opts.Fade <= 0 {
+		return c.TogglePause(paused)
+	}
+	c.mu.Lock()
+	c.fade.gen++
+	gen := c.fade.gen
+	c.mu.Unlock()
+	c.fadeMu.Lock()
+	defer c.fadeMu.Unlock()
+	if paused {
+		c.rampOut(gen)
+		if c.superseded(gen) {
+			c.opts.Logger.Debug("pause superseded during fade")
+			return nil
+		}
+		return c.TogglePause(true)
+	}
+	if err := c.TogglePause(false); err != nil {
+		return err
+	}
+	c.rampIn(gen)
+	return nil
+}
+
+// FadeOut fades the volume out ahead of a manual skip. The next file Play
+// loads fades back in once it starts.
+func (c *Controller) FadeOut() {
+	if c.opts.Fade <= 0 {
+		return
+	}
+	gen := c.currentGen()
+	c.fadeMu.Lock()
+	defer c.fadeMu.Unlock()
+	c.rampOut(gen)
+}
+
+// fadeIn brings the volume back up after FadeOut.
+func (c *Controller) fadeIn() {
+	gen := c.currentGen()
+	c.fadeMu.Lock()
+	defer c.fadeMu.Unlock()
+	c.rampIn(gen)
+}
+
+// rampOut fades the volume out, for request gen. Callers hold fadeMu.
+func (c *Controller) rampOut(gen uint64) {
+	c.mu.Lock()
+	if c.fade.down || c.fade.fading {
+		c.mu.Unlock()
+		return
+	}
+	c.fade.down, c.fade.fading = true, true
+	from := c.fade.volume
+	c.mu.Unlock()
+	c.opts.Logger.Debug("fading out", slog.Float64("volume", from))
+	c.ramp(from, 0, gen)
+	c.mu.Lock()
+	c.fade.fading = false
+	c.mu.Unlock()
+}
+
+// rampIn brings the volume back up after rampOut, for request gen.
+// Callers hold fadeMu.
+func (c *Controller) rampIn(gen uint64) {
+	c.mu.Lock()
+	if !c.fade.down || c.fade.fading {
+		c.mu.Unlock()
+		return
+	}
+	c.fade.fading, c.fade.inOnRun = true, false
+	to := c.fade.volume
+	c.mu.Unlock()
+	c.opts.Logger.Debug("fading in", slog.Float64("volume", to))
+	c.ramp(0, to, gen)
+	c.mu.Lock()
+	c.fade.fading = false
+	if c.fade.gen != gen {
+		// A pause cut the fade short; the volume stays down for it
+		c.mu.Unlock()
+		return
+	}
+	c.fade.down = false
+	// The user may have changed the volume meanwhile
+	if c.fade.volume != to {
+		to = c.fade.volume
+		c.mu.Unlock()
+		_ = c.send(map[string]any{"command": []any{"set_property", "volume", to}})
+		return
+	}
+	c.mu.Unlock()
+}
+
+// currentGen returns the generation of the latest pause or resume.
+func (c *Controller) currentGen() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.fade.gen
+}
+
+// superseded reports whether a pause or resume came in after gen.
+func (c *Controller) superseded(gen uint64) bool {
+	return c.currentGen() != gen
+}
+
+// ramp moves mpv's volume from one value to another over Options.Fade,
+// stopping early once a request newer than gen comes in.
+func (c *Controller) ramp(from, to float64, gen uint64) {
+	steps := max(int(c.opts.Fade/fadeStep), 1)
+	for i := 1; i <= steps; i++ {
+		if c.superseded(gen) {
+			return
+		}
+		v := from + (to-from)*float64(i)/float64(steps)
+		if err := c.send(map[string]any{"command": []any{"set_property", "volume", v}}); err != nil {
+			return
+		}
+		if i < steps {
+			time.Sleep(fadeStep)
+		}
+	}
+}
+
+// faded reports whether volume changes from mpv come from a fade rather
+// than the user, recording the user's otherwise.
+func (c *Controller) faded(volume float64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.fade.down || c.fade.fading {
+		return true
+	}
+	c.fade.volume = volume
+	return false
+}
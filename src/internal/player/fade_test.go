@@ -0,0 +1,170 @@
+package player
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// fakeMPV accepts the controller's connection and collects the commands it
+// sends.
+func fakeMPV(t *testing.T, opts Options) (*Controller, net.Conn, <-chan []any) {
+	t.Helper()
+	socketPath := filepath.Join(os.TempDir(), "tunez-player-fade-test.sock")
+	_ = os.Remove(socketPath)
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		conn, _ := ln.Accept()
+		accepted <- conn
+	}()
+
+	opts.IPCPath, opts.DisableProcess = socketPath, true
+	ctrl := New(opts)
+	if err := ctrl.Start(context.Background()); err != nil {
+		t.Fatalf("start controller: %v", err)
+	}
+	conn := <-accepted
+	t.Cleanup(func() { conn.Close() })
+	cmds := make(chan []any, 256)
+	go func() {
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			var msg struct{ Command []any }
+			if json.Unmarshal(scanner.Bytes(), &msg) == nil && len(msg.Command) > 0 && msg.Command[0] != "observe_property" {
+				cmds <- msg.Command
+			}
+		}
+	}()
+	return ctrl, conn, cmds
+}
+
+// drain returns the commands received until none arrive for a while.
+func drain(cmds <-chan []any) [][]any {
+	var got [][]any
+	for {
+		select {
+		case c := <-cmds:
+			got = append(got, c)
+		case <-time.After(100 * time.Millisecond):
+			return got
+		}
+	}
+}
+
+func TestFadePause(t *testing.T) {
+	ctrl, conn, cmds := fakeMPV(t, Options{Volume: 50, Fade: 60 * time.Millisecond})
+
+	if err := ctrl.FadePause(true); err != nil {
+		t.Fatal(err)
+	}
+	got := drain(cmds)
+	if len(got) < 3 {
+		t.Fatalf("expected a volume ramp then pause, got %v", got)
+	}
+	if last := got[len(got)-1]; last[1] != "pause" || last[2] != true {
+		t.Errorf("expected pause last, got %v", last)
+	}
+	if down := got[len(got)-2]; down[1] != "volume" || down[2] != 0.0 {
+		t.Errorf("expected the volume faded to 0 before pausing, got %v", down)
+	}
+
+	// mpv reporting the faded volume doesn't reach the app
+	conn.Write([]byte(`{"event":"property-change","name":"volume","data":0}` + "\n"))
+	select {
+	case evt := <-ctrl.Events():
+		if evt.Volume != nil {
+			t.Errorf("expected the fade's volume hidden, got %v", *evt.Volume)
+		}
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A volume change while paused lands when the fade in ends
+	if err := ctrl.SetVolume(80); err != nil {
+		t.Fatal(err)
+	}
+	if err := ctrl.FadePause(false); err != nil {
+		t.Fatal(err)
+	}
+	got = drain(cmds)
+	if len(got) < 3 || got[0][1] != "pause" || got[0][2] != false {
+		t.Fatalf("expected resume then a volume ramp, got %v", got)
+	}
+	if last := got[len(got)-1]; last[1] != "volume" || last[2] != 80.0 {
+		t.Errorf("expected the fade to end at the new volume, got %v", last)
+	}
+}
+
+func TestFadeOff(t *testing.T) {
+	ctrl, _, cmds := fakeMPV(t, Options{})
+	if err := ctrl.FadePause(true); err != nil {
+		t.Fatal(err)
+	}
+	ctrl.FadeOut()
+	if got := drain(cmds); len(got) != 1 || got[0][1] != "pause" {
+		t.Errorf("expected a plain pause without fade_ms, got %v", got)
+	}
+}
+
+func TestFadePauseToggledDuringFade(t *testing.T) {
+	ctrl, _, cmds := fakeMPV(t, Options{Volume: 50, Fade: 200 * time.Millisecond})
+
+	// Play/pause pressed twice within the fade: the resume wins
+	paused := make(chan error, 1)
+	go func() { paused <- ctrl.FadePause(true) }()
+	time.Sleep(50 * time.Millisecond)
+	if err := ctrl.FadePause(false); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-paused; err != nil {
+		t.Fatal(err)
+	}
+	got := drain(cmds)
+	for _, cmd := range got {
+		if cmd[1] == "pause" && cmd[2] == true {
+			t.Fatalf("expected the pause dropped, got %v", got)
+		}
+	}
+	if last := got[len(got)-1]; last[1] != "volume" || last[2] != 50.0 {
+		t.Errorf("expected the volume back up, got %v", last)
+	}
+	ctrl.mu.Lock()
+	down := ctrl.fade.down
+	ctrl.mu.Unlock()
+	if down {
+		t.Error("expected the volume no longer held down")
+	}
+
+	// Pausing during the fade in leaves it paused, the volume still down
+	if err := ctrl.FadePause(true); err != nil {
+		t.Fatal(err)
+	}
+	drain(cmds)
+	resumed := make(chan error, 1)
+	go func() { resumed <- ctrl.FadePause(false) }()
+	time.Sleep(50 * time.Millisecond)
+	if err := ctrl.FadePause(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := <-resumed; err != nil {
+		t.Fatal(err)
+	}
+	got = drain(cmds)
+	if last := got[len(got)-1]; last[1] != "pause" || last[2] != true {
+		t.Errorf("expected the pause last, got %v", got)
+	}
+	for _, cmd := range got {
+		if cmd[1] == "volume" && cmd[2] == 50.0 {
+			t.Errorf("expected the fade in cut short, got %v", got)
+		}
+	}
+}
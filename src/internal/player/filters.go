@@ -0,0 +1,110 @@
+package player
+
+import (
+	"fmt"
+	"log/slog"
+)
+
+// DefaultSilenceThresholdDB is the level below which audio counts as
+// silence when trimming.
+const DefaultSilenceThresholdDB = -50.0
+
+// trimLabel names the silence trimming filter in mpv's filter chain, so it
+// can be removed again at runtime.
+const trimLabel = "@trim"
+
+// silenceFilter builds the mpv audio filter that trims silence at track
+// boundaries: all of it before the first sound, and any run longer than two
+// seconds after that, cut down to half a second. Long pauses inside a track
+// are shortened too, which is why trimming is opt-in.
+func silenceFilter(thresholdDB float64) string {
+	if thresholdDB >= 0 {
+		thresholdDB = DefaultSilenceThresholdDB
+	}
+	return fmt.Sprintf("%s:lavfi=[silenceremove=start_periods=1:start_threshold=%gdB:start_silence=0.05"+
+		":stop_periods=-1:stop_duration=2:stop_threshold=%gdB:stop_silence=0.5:detection=peak]",
+		trimLabel, thresholdDB, thresholdDB)
+}
+
+// SetTrimSilence adds or removes the silence trimming filter. It applies
+// from the next track mpv loads, and to the rest of the current one.
+func (c *Controller) SetTrimSilence(enabled bool) error {
+	c.opts.Logger.Debug("setting silence trimming", slog.Bool("enabled", enabled))
+	c.opts.TrimSilence = enabled
+	// Removing a filter that isn't there fails harmlessly
+	_ = c.send(map[string]any{"command": []any{"af", "remove", trimLabel}})
+	if !enabled {
+		return nil
+	}
+	err := c.send(map[string]any{"command": []any{"af", "add", silenceFilter(c.opts.SilenceThresholdDB)}})
+	if err != nil {
+		c.opts.Logger.Error("failed to send silence trimming filter", slog.Any("err", err))
+	}
+	return err
+}
+
+// replayGainReference is the loudness ReplayGain tags bring tracks to; the
+// preamp moves it to the configured target.
+const replayGainReference = -18.0
+
+// normLabel names the loudnorm filter in mpv's filter chain.
+const normLabel = "@norm"
+
+// loudnormFilter builds the EBU R128 loudnorm filter aiming at targetLUFS.
+func loudnormFilter(targetLUFS float64) string {
+	return fmt.Sprintf("%s:lavfi=[loudnorm=I=%g:TP=-1.5:LRA=11]", normLabel, targetLUFS)
+}
+
+// normalizeArgs returns the mpv options for a normalize mode: "track" or
+// "album" use the files' ReplayGain tags, "loudnorm" measures as it plays,
+// anything else leaves the volume alone.
+func normalizeArgs(mode string, targetLUFS float64) []string {
+	switch mode {
+	case "track", "album":
+		return []string{"--replaygain=" + mode, fmt.Sprintf("--replaygain-preamp=%g", targetLUFS-replayGainReference)}
+	case "loudnorm":
+		return []string{"--af-append=" + loudnormFilter(targetLUFS)}
+	}
+	return nil
+}
+
+// SetGainFallback sets the ReplayGain, in dB, that mpv applies to the files
+// that follow when they have no gain tags of their own, such as a gain
+// measured by the library's loudness analysis. 0 leaves them as they are.
+func (c *Controller) SetGainFallback(db float64) error {
+	c.mu.Lock()
+	changed := db != c.gainFallback
+	c.gainFallback = db
+	c.mu.Unlock()
+	if !changed {
+		return nil
+	}
+	return c.send(map[string]any{"command": []any{"set_property", "replaygain-fallback", db}})
+}
+
+// SetNormalization switches loudness normalization, e.g. after changing to
+// a profile with different settings. ReplayGain applies from the next
+// track; loudnorm straight away.
+func (c *Controller) SetNormalization(mode string, targetLUFS float64) error {
+	c.opts.Logger.Debug("setting normalization", slog.String("mode", mode), slog.Float64("target_lufs", targetLUFS))
+	c.opts.Normalize, c.opts.TargetLUFS = mode, targetLUFS
+	replayGain := "no"
+	if mode == "track" || mode == "album" {
+		replayGain = mode
+	}
+	if err := c.send(map[string]any{"command": []any{"set_property", "replaygain", replayGain}}); err != nil {
+		c.opts.Logger.Error("failed to send replaygain command", slog.Any("err", err))
+		return err
+	}
+	_ = c.send(map[string]any{"command": []any{"set_property", "replaygain-preamp", targetLUFS - replayGainReference}})
+	// Removing a filter that isn't there fails harmlessly
+	_ = c.send(map[string]any{"command": []any{"af", "remove", normLabel}})
+	if mode != "loudnorm" {
+		return nil
+	}
+	err := c.send(map[string]any{"command": []any{"af", "add", loudnormFilter(targetLUFS)}})
+	if err != nil {
+		c.opts.Logger.Error("failed to send loudnorm filter", slog.Any("err", err))
+	}
+	return err
+}
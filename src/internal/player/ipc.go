@@ -0,0 +1,64 @@
+package player
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// IPCPath returns the socket mpv listens on for the [player] ipc setting:
+// "auto" (or ""), "unix" and "pipe" pick the usual place, and anything else
+// is taken as a path. With perProcess the name carries this process's ID,
+// so instances running side by side don't share one mpv socket.
+func IPCPath(ipc string, perProcess bool) string {
+	var path string
+	switch ipc {
+	case "", "auto", "unix", "pipe":
+		if runtime.GOOS == "windows" && ipc != "unix" {
+			path = `\\.\pipe\tunez-mpv`
+		} else {
+			path = filepath.Join(os.TempDir(), "tunez-mpv.sock")
+		}
+	default:
+		path = ipc
+	}
+	if perProcess {
+		path = processPath(path, os.Getpid())
+	}
+	return path
+}
+
+// processPath inserts pid before path's extension: tunez-mpv.sock becomes
+// tunez-mpv-1234.sock.
+func processPath(path string, pid int) string {
+	ext := filepath.Ext(path)
+	if strings.HasPrefix(path, `\\.\pipe\`) {
+		ext = ""
+	}
+	return fmt.Sprintf("%s-%d%s", strings.TrimSuffix(path, ext), pid, ext)
+}
+
+// RemoveStaleSockets deletes the per-process sockets derived from path
+// that no mpv answers on, left behind by instances that crashed. Named
+// pipes go away with their process and are left alone.
+func RemoveStaleSockets(path string) {
+	if strings.HasPrefix(path, `\\.\pipe\`) {
+		return
+	}
+	ext := filepath.Ext(path)
+	matches, _ := filepath.Glob(strings.TrimSuffix(path, ext) + "-*" + ext)
+	for _, m := range matches {
+		if fi, err := os.Lstat(m); err != nil || fi.Mode()&os.ModeSocket == 0 {
+			continue
+		}
+		if conn, err := net.DialTimeout("unix", m, 200*time.Millisecond); err == nil {
+			conn.Close()
+			continue
+		}
+		os.Remove(m)
+	}
+}
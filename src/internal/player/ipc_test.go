@@ -0,0 +1,58 @@
+package player
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestIPCPathPerProcess(t *testing.T) {
+	if got := processPath("/run/tunez/mpv.sock", 42); got != "/run/tunez/mpv-42.sock" {
+		t.Errorf("processPath = %q", got)
+	}
+	if got := processPath(`\\.\pipe\tunez-mpv`, 42); got != `\\.\pipe\tunez-mpv-42` {
+		t.Errorf("processPath(pipe) = %q", got)
+	}
+	if got := IPCPath("/run/tunez/mpv.sock", false); got != "/run/tunez/mpv.sock" {
+		t.Errorf("expected a configured path to be used as is, got %q", got)
+	}
+	if IPCPath("auto", true) == IPCPath("auto", false) {
+		t.Error("expected a per-process path to differ from the shared one")
+	}
+}
+
+func TestRemoveStaleSockets(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets")
+	}
+	dir, err := os.MkdirTemp("", "tz")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	base := filepath.Join(dir, "mpv.sock")
+
+	live := processPath(base, 1)
+	ln, err := net.Listen("unix", live)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	stale := processPath(base, 2)
+	dead, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dead.(*net.UnixListener).SetUnlinkOnClose(false)
+	dead.Close()
+
+	RemoveStaleSockets(base)
+	if _, err := os.Stat(live); err != nil {
+		t.Errorf("expected the live socket kept, got %v", err)
+	}
+	if _, err := os.Stat(stale); !os.IsNotExist(err) {
+		t.Errorf("expected the stale socket removed, got %v", err)
+	}
+}
@@ -4,14 +4,12 @@ import (
 	"bufio"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"math/rand"
 	"net"
-	"os"
 	"os/exec"
-	"path/filepath"
-	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -19,16 +17,30 @@ import (
 
 // Event describes playback state updates emitted by mpv.
 type Event struct {
-	TimePos   *float64
-	Duration  *float64
-	Paused    *bool
-	Volume    *float64
-	Muted     *bool
-	Ended     bool   // true when track ended naturally (eof)
-	EndReason string // "eof", "stop", "quit", "error", "redirect"
-	Err       error
+	TimePos  *float64
+	Duration *float64
+	Paused   *bool
+	Volume   *float64
+	Muted    *bool
+	Chapters *[]Chapter // the loaded file's chapters; empty when it has none
+	// AudioDevices names the audio outputs mpv can see, when they change
+	AudioDevices *[]string
+	Ended        bool   // true when track ended naturally (eof)
+	EndReason    string // "eof", "stop", "quit", "error", "redirect"
+	Err          error
 }
 
+// Chapter is a chapter embedded in the loaded file, e.g. in an audiobook or
+// a long mix.
+type Chapter struct {
+	Title string
+	Start float64 // seconds
+}
+
+// ErrDisconnected is sent as an Event error when the IPC connection drops
+// without Stop being called, typically because mpv crashed or was killed.
+var ErrDisconnected = errors.New("mpv connection lost")
+
 // Options configures the Controller.
 type Options struct {
 	MPVPath        string
@@ -37,16 +49,45 @@ type Options struct {
 	DisableProcess bool
 	Dial           func(ctx context.Context, network, addr string) (net.Conn, error)
 	ExtraArgs      []string
+	AudioExclusive bool // request exclusive (bit-perfect) access to the audio device
+	// TrimSilence trims silence at track boundaries, below
+	// SilenceThresholdDB (0 = DefaultSilenceThresholdDB)
+	TrimSilence        bool
+	SilenceThresholdDB float64
+	// Normalize is "track" or "album" (ReplayGain) or "loudnorm", aiming
+	// at TargetLUFS; anything else is off
+	Normalize  string
+	TargetLUFS float64
+	// Volume is the 0-100 volume mpv starts at (0 = mpv's default), on
+	// VolumeCurve: "linear", or "cubic" for finer steps when quiet
+	Volume      float64
+	VolumeCurve string
+	// Fade is how long the volume takes to fade out before pausing or a
+	// manual skip and back in after; 0 turns fading off
+	Fade time.Duration
+}
+
+// NetworkOptions are the network settings mpv uses for the streams that
+// follow, from the provider the stream came from.
+type NetworkOptions struct {
+	Timeout            time.Duration // 0 = mpv's default of 60s
+	CAFile             string
+	InsecureSkipVerify bool
 }
 
 // Controller manages the mpv process and IPC connection.
 type Controller struct {
-	opts   Options
-	cmd    *exec.Cmd
-	conn   net.Conn
-	mu     sync.Mutex
-	events chan Event
-	done   chan struct{}
+	opts    Options
+	cmd     *exec.Cmd
+	conn    net.Conn
+	mu      sync.Mutex
+	fadeMu  sync.Mutex // one fade at a time
+	events  chan Event
+	done    chan struct{}
+	network NetworkOptions
+	// gainFallback is the replaygain-fallback last sent to mpv
+	gainFallback float64
+	fade         fadeState
 }
 
 func New(opts Options) *Controller {
@@ -60,27 +101,30 @@ func New(opts Options) *Controller {
 	}
 }
 
-func defaultIPCPath() string {
-	if runtime.GOOS == "windows" {
-		return `\\.\pipe\tunez-mpv`
-	}
-	return filepath.Join(os.TempDir(), "tunez-mpv.sock")
-}
-
 // Start launches mpv (unless disabled) and connects to the IPC socket.
 func (c *Controller) Start(ctx context.Context) error {
 	c.opts.Logger.Debug("starting player controller", slog.String("ipc_path", c.opts.IPCPath), slog.Bool("disable_process", c.opts.DisableProcess))
 	c.mu.Lock()
-	// Reinitialize done channel if previously closed (for restarts)
+	// Reinitialize done channel if previously closed (for restarts). The
+	// previous read loop closes its own events channel, so each session
+	// gets a fresh one.
 	select {
 	case <-c.done:
 		c.done = make(chan struct{})
+		c.events = make(chan Event, 32)
 	default:
 	}
+	events, done := c.events, c.done
+	c.network = NetworkOptions{} // a new mpv starts from its own defaults
+	c.gainFallback = 0
+	c.fade = fadeState{volume: 100}
+	if c.opts.Volume > 0 {
+		c.fade.volume = mpvVolume(c.opts.Volume, c.opts.VolumeCurve)
+	}
 	c.mu.Unlock()
 
 	if c.opts.IPCPath == "" {
-		c.opts.IPCPath = defaultIPCPath()
+		c.opts.IPCPath = IPCPath("auto", false)
 		c.opts.Logger.Debug("using default ipc path", slog.String("ipc_path", c.opts.IPCPath))
 	}
 	if !c.opts.DisableProcess {
@@ -100,12 +144,13 @@ func (c *Controller) Start(ctx context.Context) error {
 		return err
 	}
 	c.opts.Logger.Debug("started observing mpv properties")
-	go c.readLoop()
+	go c.readLoop(c.conn, events, done)
 	c.opts.Logger.Debug("player controller started successfully")
 	return nil
 }
 
-func (c *Controller) spawnMPV(ctx context.Context) error {
+// mpvArgs builds the mpv command line from the controller options.
+func (c *Controller) mpvArgs() []string {
 	args := []string{
 		"--idle=yes",
 		"--force-window=no",
@@ -113,7 +158,21 @@ func (c *Controller) spawnMPV(ctx context.Context) error {
 		"--no-video",
 		"--input-ipc-server=" + c.opts.IPCPath,
 	}
-	args = append(args, c.opts.ExtraArgs...)
+	if c.opts.AudioExclusive {
+		args = append(args, "--audio-exclusive=yes")
+	}
+	if c.opts.TrimSilence {
+		args = append(args, "--af-append="+silenceFilter(c.opts.SilenceThresholdDB))
+	}
+	if c.opts.Volume > 0 {
+		args = append(args, fmt.Sprintf("--volume=%.2f", mpvVolume(c.opts.Volume, c.opts.VolumeCurve)))
+	}
+	args = append(args, normalizeArgs(c.opts.Normalize, c.opts.TargetLUFS)...)
+	return append(args, c.opts.ExtraArgs...)
+}
+
+func (c *Controller) spawnMPV(ctx context.Context) error {
+	args := c.mpvArgs()
 	c.opts.Logger.Debug("spawning mpv process", slog.String("mpv_path", c.opts.MPVPath), slog.Any("args", args))
 	c.cmd = exec.CommandContext(ctx, c.opts.MPVPath, args...)
 	if err := c.cmd.Start(); err != nil {
@@ -170,7 +229,7 @@ func networkForPath(path string) string {
 }
 
 func (c *Controller) observeProperties() error {
-	props := []string{"time-pos", "duration", "pause", "volume", "mute"}
+	props := []string{"time-pos", "duration", "pause", "volume", "mute", "chapter-list", "audio-device-list"}
 	for i, p := range props {
 		if err := c.send(map[string]any{
 			"command": []any{"observe_property", i + 1, p},
@@ -181,8 +240,13 @@ func (c *Controller) observeProperties() error {
 	return nil
 }
 
-// Events returns the event channel.
-func (c *Controller) Events() <-chan Event { return c.events }
+// Events returns the event channel of the current session. It is closed
+// when the connection ends; after a restart Events returns a new channel.
+func (c *Controller) Events() <-chan Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.events
+}
 
 func (c *Controller) send(cmd map[string]any) error {
 	c.mu.Lock()
@@ -198,6 +262,42 @@ func (c *Controller) send(cmd map[string]any) error {
 	return err
 }
 
+// SetNetwork sets mpv's network timeout and TLS settings for the streams
+// that follow. Only settings that changed since the last call are sent, so
+// mpv.conf's own values stand until a provider asks for something else.
+func (c *Controller) SetNetwork(o NetworkOptions) error {
+	c.mu.Lock()
+	prev := c.network
+	c.network = o
+	c.mu.Unlock()
+	for _, cmd := range networkCommands(prev, o) {
+		if err := c.send(map[string]any{"command": cmd}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// networkCommands returns the set_property commands that move mpv from the
+// prev network settings to next.
+func networkCommands(prev, next NetworkOptions) [][]any {
+	var cmds [][]any
+	if next.Timeout != prev.Timeout {
+		secs := next.Timeout.Seconds()
+		if secs <= 0 {
+			secs = 60
+		}
+		cmds = append(cmds, []any{"set_property", "network-timeout", secs})
+	}
+	if next.CAFile != prev.CAFile {
+		cmds = append(cmds, []any{"set_property", "tls-ca-file", next.CAFile})
+	}
+	if next.InsecureSkipVerify != prev.InsecureSkipVerify {
+		cmds = append(cmds, []any{"set_property", "tls-verify", !next.InsecureSkipVerify})
+	}
+	return cmds
+}
+
 // Play loads a URL into mpv.
 func (c *Controller) Play(url string, headers map[string]string) error {
 	c.opts.Logger.Debug("playing track", slog.String("url", url), slog.Int("header_count", len(headers)))
@@ -208,11 +308,15 @@ func (c *Controller) Play(url string, headers map[string]string) error {
 		}
 		_ = c.send(map[string]any{"command": []any{"set_property", "http-header-fields", strings.Join(headerLines, "\n")}})
 	}
+	c.mu.Lock()
+	c.fade.inOnRun = c.fade.down
+	c.mu.Unlock()
 	err := c.send(map[string]any{
 		"command": []any{"loadfile", url, "replace"},
 	})
 	if err != nil {
 		c.opts.Logger.Error("failed to send play command", slog.Any("err", err))
+		c.fadeIn()
 	} else {
 		c.opts.Logger.Debug("play command sent successfully")
 	}
@@ -245,7 +349,15 @@ func (c *Controller) SetVolume(vol float64) error {
 		vol = 100
 	}
 	c.opts.Logger.Debug("setting volume", slog.Float64("volume", vol))
-	err := c.send(map[string]any{"command": []any{"set_property", "volume", vol}})
+	c.mu.Lock()
+	c.fade.volume = mpvVolume(vol, c.opts.VolumeCurve)
+	held := c.fade.down || c.fade.fading
+	c.mu.Unlock()
+	if held {
+		// Applied when the fade in ends
+		return nil
+	}
+	err := c.send(map[string]any{"command": []any{"set_property", "volume", mpvVolume(vol, c.opts.VolumeCurve)}})
 	if err != nil {
 		c.opts.Logger.Error("failed to send volume command", slog.Any("err", err))
 	}
@@ -261,6 +373,27 @@ func (c *Controller) SetMute(mute bool) error {
 	return err
 }
 
+// SetSpeed sets the playback speed, 1 being normal. mpv keeps the pitch.
+func (c *Controller) SetSpeed(speed float64) error {
+	c.opts.Logger.Debug("setting speed", slog.Float64("speed", speed))
+	err := c.send(map[string]any{"command": []any{"set_property", "speed", speed}})
+	if err != nil {
+		c.opts.Logger.Error("failed to send speed command", slog.Any("err", err))
+	}
+	return err
+}
+
+// SetAudioExclusive toggles exclusive output mode. mpv reopens the audio
+// device, so there may be a short gap in playback.
+func (c *Controller) SetAudioExclusive(exclusive bool) error {
+	c.opts.Logger.Debug("setting audio exclusive", slog.Bool("exclusive", exclusive))
+	err := c.send(map[string]any{"command": []any{"set_property", "audio-exclusive", exclusive}})
+	if err != nil {
+		c.opts.Logger.Error("failed to send audio-exclusive command", slog.Any("err", err))
+	}
+	return err
+}
+
 func (c *Controller) Stop() error {
 	c.mu.Lock()
 	defer c.mu.Unlock()
@@ -288,30 +421,54 @@ func (c *Controller) Stop() error {
 	return nil
 }
 
-func (c *Controller) readLoop() {
-	defer close(c.events)
-	scanner := bufio.NewScanner(c.conn)
+func (c *Controller) readLoop(conn net.Conn, events chan<- Event, done <-chan struct{}) {
+	defer close(events)
+	scanner := bufio.NewScanner(conn)
 	for scanner.Scan() {
 		line := scanner.Bytes()
 		var msg ipcMessage
 		if err := json.Unmarshal(line, &msg); err != nil {
-			c.events <- Event{Err: fmt.Errorf("decode: %w", err)}
+			events <- Event{Err: fmt.Errorf("decode: %w", err)}
 			continue
 		}
 		switch msg.Event {
 		case "property-change":
-			c.handlePropertyChange(msg)
+			if evt, ok := propertyEvent(msg); ok {
+				if evt.Volume != nil {
+					if c.faded(*evt.Volume) {
+						break
+					}
+					v := userVolume(*evt.Volume, c.opts.VolumeCurve)
+					evt.Volume = &v
+				}
+				events <- evt
+			}
+		case "playback-restart":
+			c.mu.Lock()
+			fadeIn := c.fade.inOnRun
+			c.mu.Unlock()
+			if fadeIn {
+				go c.fadeIn()
+			}
 		case "end-file":
 			// Only set Ended=true for natural end (eof), not for stop/quit/error
 			// "stop" happens when we load a new file, "quit" when mpv exits
-			c.events <- Event{
+			events <- Event{
 				Ended:     msg.Reason == "eof",
 				EndReason: msg.Reason,
 			}
 		}
 	}
-	if err := scanner.Err(); err != nil {
-		c.events <- Event{Err: err}
+	select {
+	case <-done:
+		// Stopped on purpose
+	default:
+		err := ErrDisconnected
+		if serr := scanner.Err(); serr != nil {
+			err = fmt.Errorf("%w: %w", ErrDisconnected, serr)
+		}
+		c.opts.Logger.Warn("mpv ipc connection ended", slog.Any("err", err))
+		events <- Event{Err: err}
 	}
 }
 
@@ -322,29 +479,61 @@ type ipcMessage struct {
 	Reason string      `json:"reason"` // for end-file event: "eof", "stop", "quit", "error", "redirect"
 }
 
-func (c *Controller) handlePropertyChange(msg ipcMessage) {
+// propertyEvent converts an observed property change into an Event.
+func propertyEvent(msg ipcMessage) (Event, bool) {
 	switch msg.Name {
 	case "time-pos":
 		if v, ok := toFloat(msg.Data); ok {
-			c.events <- Event{TimePos: &v}
+			return Event{TimePos: &v}, true
 		}
 	case "duration":
 		if v, ok := toFloat(msg.Data); ok {
-			c.events <- Event{Duration: &v}
+			return Event{Duration: &v}, true
 		}
 	case "pause":
 		if b, ok := msg.Data.(bool); ok {
-			c.events <- Event{Paused: &b}
+			return Event{Paused: &b}, true
 		}
 	case "volume":
 		if v, ok := toFloat(msg.Data); ok {
-			c.events <- Event{Volume: &v}
+			return Event{Volume: &v}, true
 		}
 	case "mute":
 		if b, ok := msg.Data.(bool); ok {
-			c.events <- Event{Muted: &b}
+			return Event{Muted: &b}, true
+		}
+	case "chapter-list":
+		list, ok := msg.Data.([]interface{})
+		if !ok && msg.Data != nil {
+			break
+		}
+		chapters := make([]Chapter, 0, len(list))
+		for _, item := range list {
+			fields, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			start, _ := toFloat(fields["time"])
+			title, _ := fields["title"].(string)
+			chapters = append(chapters, Chapter{Title: title, Start: start})
+		}
+		return Event{Chapters: &chapters}, true
+	case "audio-device-list":
+		list, ok := msg.Data.([]interface{})
+		if !ok {
+			break
+		}
+		devices := make([]string, 0, len(list))
+		for _, item := range list {
+			if fields, ok := item.(map[string]interface{}); ok {
+				if name, _ := fields["name"].(string); name != "" {
+					devices = append(devices, name)
+				}
+			}
 		}
+		return Event{AudioDevices: &devices}, true
 	}
+	return Event{}, false
 }
 
 func toFloat(v interface{}) (float64, bool) {
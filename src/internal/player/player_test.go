@@ -3,9 +3,13 @@ package player
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -121,3 +125,207 @@ func TestEndFileStopReasonDoesNotTriggerEnded(t *testing.T) {
 		t.Fatal("timeout waiting for event")
 	}
 }
+
+func TestPropertyEventChapters(t *testing.T) {
+	var msg ipcMessage
+	line := `{"event":"property-change","name":"chapter-list","data":[{"title":"Intro","time":0},{"title":"Part One","time":95.5},{"time":1800}]}`
+	if err := json.Unmarshal([]byte(line), &msg); err != nil {
+		t.Fatal(err)
+	}
+	evt, ok := propertyEvent(msg)
+	if !ok || evt.Chapters == nil {
+		t.Fatalf("expected a chapters event, got %+v", evt)
+	}
+	want := []Chapter{{"Intro", 0}, {"Part One", 95.5}, {"", 1800}}
+	if len(*evt.Chapters) != len(want) {
+		t.Fatalf("expected %d chapters, got %+v", len(want), *evt.Chapters)
+	}
+	for i, c := range *evt.Chapters {
+		if c != want[i] {
+			t.Errorf("chapter %d: expected %+v, got %+v", i, want[i], c)
+		}
+	}
+
+	// A file without chapters clears them
+	evt, ok = propertyEvent(ipcMessage{Event: "property-change", Name: "chapter-list", Data: []interface{}{}})
+	if !ok || evt.Chapters == nil || len(*evt.Chapters) != 0 {
+		t.Errorf("expected an empty chapter list, got %+v", evt)
+	}
+}
+
+func TestPropertyEventAudioDevices(t *testing.T) {
+	var msg ipcMessage
+	line := `{"event":"property-change","name":"audio-device-list","data":[{"name":"auto","description":"Autoselect device"},{"name":"pulse/bluez_output.headphones","description":"Headphones"}]}`
+	if err := json.Unmarshal([]byte(line), &msg); err != nil {
+		t.Fatal(err)
+	}
+	evt, ok := propertyEvent(msg)
+	if !ok || evt.AudioDevices == nil || len(*evt.AudioDevices) != 2 || (*evt.AudioDevices)[1] != "pulse/bluez_output.headphones" {
+		t.Fatalf("expected the device names, got %+v", evt)
+	}
+}
+
+func TestMPVArgsAudioExclusive(t *testing.T) {
+	has := func(args []string, want string) bool {
+		for _, a := range args {
+			if a == want {
+				return true
+			}
+		}
+		return false
+	}
+
+	ctrl := New(Options{IPCPath: "/tmp/test.sock"})
+	if has(ctrl.mpvArgs(), "--audio-exclusive=yes") {
+		t.Error("expected no --audio-exclusive by default")
+	}
+
+	ctrl = New(Options{IPCPath: "/tmp/test.sock", AudioExclusive: true, ExtraArgs: []string{"--foo"}})
+	args := ctrl.mpvArgs()
+	if !has(args, "--audio-exclusive=yes") {
+		t.Errorf("expected --audio-exclusive=yes in %v", args)
+	}
+	if args[len(args)-1] != "--foo" {
+		t.Errorf("expected extra args last, got %v", args)
+	}
+}
+
+func TestVolumeCurve(t *testing.T) {
+	if v := mpvVolume(50, "linear"); v != 50 {
+		t.Errorf("linear volume 50 = %v", v)
+	}
+	if v := mpvVolume(50, "cubic"); v != 12.5 {
+		t.Errorf("cubic volume 50 = %v, want 12.5", v)
+	}
+	for _, v := range []float64{0, 5, 35, 70, 100} {
+		if got := userVolume(mpvVolume(v, "cubic"), "cubic"); got != v {
+			t.Errorf("cubic volume %v round-trips to %v", v, got)
+		}
+	}
+
+	ctrl := New(Options{IPCPath: "/tmp/test.sock", Volume: 40, VolumeCurve: "cubic"})
+	if args := ctrl.mpvArgs(); !slices.Contains(args, "--volume=6.40") {
+		t.Errorf("expected mpv to start at the curved volume, got %v", args)
+	}
+}
+
+func TestMPVArgsTrimSilence(t *testing.T) {
+	ctrl := New(Options{IPCPath: "/tmp/test.sock"})
+	for _, a := range ctrl.mpvArgs() {
+		if strings.HasPrefix(a, "--af") {
+			t.Errorf("expected no audio filter by default, got %s", a)
+		}
+	}
+
+	ctrl = New(Options{IPCPath: "/tmp/test.sock", TrimSilence: true, SilenceThresholdDB: -60})
+	want := "--af-append=@trim:lavfi=[silenceremove=start_periods=1:start_threshold=-60dB:start_silence=0.05" +
+		":stop_periods=-1:stop_duration=2:stop_threshold=-60dB:stop_silence=0.5:detection=peak]"
+	args := ctrl.mpvArgs()
+	if args[len(args)-1] != want {
+		t.Errorf("expected %s, got %v", want, args)
+	}
+
+	if f := silenceFilter(0); !strings.Contains(f, "start_threshold=-50dB") {
+		t.Errorf("expected the default threshold, got %s", f)
+	}
+}
+
+func TestNormalizeArgs(t *testing.T) {
+	tests := []struct {
+		mode string
+		want []string
+	}{
+		{"off", nil},
+		{"track", []string{"--replaygain=track", "--replaygain-preamp=2"}},
+		{"album", []string{"--replaygain=album", "--replaygain-preamp=2"}},
+		{"loudnorm", []string{"--af-append=@norm:lavfi=[loudnorm=I=-16:TP=-1.5:LRA=11]"}},
+	}
+	for _, tt := range tests {
+		got := normalizeArgs(tt.mode, -16)
+		if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+			t.Errorf("normalizeArgs(%q) = %v, want %v", tt.mode, got, tt.want)
+		}
+	}
+}
+
+func TestDisconnectAndRestart(t *testing.T) {
+	socketPath := filepath.Join(os.TempDir(), "tunez-player-restart-test.sock")
+	_ = os.Remove(socketPath)
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- conn
+		}
+	}()
+
+	ctrl := New(Options{
+		MPVPath:        "mpv",
+		IPCPath:        socketPath,
+		DisableProcess: true,
+	})
+	if err := ctrl.Start(context.Background()); err != nil {
+		t.Fatalf("start controller: %v", err)
+	}
+	// mpv going away is reported once, then the channel closes
+	(<-accepted).Close()
+	evt, ok := <-ctrl.Events()
+	if !ok || !errors.Is(evt.Err, ErrDisconnected) {
+		t.Fatalf("expected ErrDisconnected, got %v (open=%v)", evt.Err, ok)
+	}
+	if _, ok := <-ctrl.Events(); ok {
+		t.Fatal("expected the events channel to close")
+	}
+
+	_ = ctrl.Stop()
+	if err := ctrl.Start(context.Background()); err != nil {
+		t.Fatalf("restart controller: %v", err)
+	}
+	conn := <-accepted
+	defer conn.Close()
+	b, _ := json.Marshal(map[string]any{"event": "property-change", "name": "volume", "data": 40.0})
+	conn.Write(append(b, '\n'))
+
+	select {
+	case evt := <-ctrl.Events():
+		if evt.Volume == nil || *evt.Volume != 40 {
+			t.Fatalf("expected a volume event after restart, got %+v", evt)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event after restart")
+	}
+
+	// A deliberate stop closes the channel without an error
+	_ = ctrl.Stop()
+	for evt := range ctrl.Events() {
+		if evt.Err != nil {
+			t.Fatalf("expected no error after Stop, got %v", evt.Err)
+		}
+	}
+}
+
+func TestNetworkCommands(t *testing.T) {
+	if cmds := networkCommands(NetworkOptions{}, NetworkOptions{}); len(cmds) != 0 {
+		t.Errorf("expected nothing sent while nothing changes, got %v", cmds)
+	}
+	homelab := NetworkOptions{Timeout: 30 * time.Second, CAFile: "/etc/ca.pem", InsecureSkipVerify: true}
+	got := fmt.Sprint(networkCommands(NetworkOptions{}, homelab))
+	want := "[[set_property network-timeout 30] [set_property tls-ca-file /etc/ca.pem] [set_property tls-verify false]]"
+	if got != want {
+		t.Errorf("networkCommands = %s, want %s", got, want)
+	}
+	got = fmt.Sprint(networkCommands(homelab, NetworkOptions{}))
+	want = "[[set_property network-timeout 60] [set_property tls-ca-file ] [set_property tls-verify true]]"
+	if got != want {
+		t.Errorf("networkCommands back to defaults = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,23 @@
+package player
+
+import "math"
+
+// mpvVolume maps a 0-100 volume on curve to mpv's volume property. The
+// cubic curve follows perceived loudness, so the low end isn't a few steps
+// between silent and loud.
+func mpvVolume(v float64, curve string) float64 {
+	if curve != "cubic" {
+		return v
+	}
+	return 100 * math.Pow(v/100, 3)
+}
+
+// userVolume is the inverse of mpvVolume, for the volume mpv reports.
+// Results are rounded to a hundredth so a step up and down lands back on
+// the same value.
+func userVolume(v float64, curve string) float64 {
+	if curve != "cubic" {
+		return v
+	}
+	return math.Round(100*math.Cbrt(max(v, 0)/100)*100) / 100
+}
@@ -0,0 +1,254 @@
+// Package playlistfile reads and writes M3U/M3U8 and XSPF playlist files.
+package playlistfile
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Entry is one track in a playlist file.
+type Entry struct {
+	Location   string // file path or stream URL; may be empty in XSPF
+	Title      string
+	Artist     string
+	Album      string
+	DurationMs int
+}
+
+// Format is a playlist file format, picked by file extension.
+type Format string
+
+const (
+	FormatM3U  Format = "m3u"
+	FormatXSPF Format = "xspf"
+)
+
+// FormatFor returns the format of a playlist file path.
+func FormatFor(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".m3u", ".m3u8":
+		return FormatM3U, nil
+	case ".xspf":
+		return FormatXSPF, nil
+	}
+	return "", fmt.Errorf("unsupported playlist file %q (use .m3u, .m3u8 or .xspf)", filepath.Base(path))
+}
+
+// Write saves entries to a playlist file, in the format its extension
+// names. With relative set, file paths are written relative to the
+// playlist's directory. M3U has no line for an entry without a location,
+// so those are left out; Write returns how many entries it wrote.
+func Write(path, title string, entries []Entry, relative bool) (int, error) {
+	format, err := FormatFor(path)
+	if err != nil {
+		return 0, err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return 0, err
+	}
+	dir := filepath.Dir(path)
+	out := make([]Entry, 0, len(entries))
+	for _, e := range entries {
+		if relative && filepath.IsAbs(e.Location) {
+			if rel, err := filepath.Rel(dir, e.Location); err == nil {
+				e.Location = rel
+			}
+		}
+		out = append(out, e)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	var n int
+	if format == FormatXSPF {
+		n, err = encodeXSPF(f, title, out)
+	} else {
+		n, err = encodeM3U(f, title, out)
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	return n, err
+}
+
+// Read loads the entries of a playlist file. Relative file paths are
+// resolved against the playlist's directory.
+func Read(path string) ([]Entry, error) {
+	format, err := FormatFor(path)
+	if err != nil {
+		return nil, err
+	}
+	path, err = filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []Entry
+	if format == FormatXSPF {
+		entries, err = decodeXSPF(f)
+	} else {
+		entries, err = decodeM3U(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", filepath.Base(path), err)
+	}
+	dir := filepath.Dir(path)
+	for i, e := range entries {
+		if e.Location != "" && !isURL(e.Location) && !filepath.IsAbs(e.Location) {
+			entries[i].Location = filepath.Join(dir, filepath.FromSlash(e.Location))
+		}
+	}
+	return entries, nil
+}
+
+// isURL reports whether a location is a URL rather than a file path.
+func isURL(loc string) bool {
+	u, err := url.Parse(loc)
+	return err == nil && len(u.Scheme) > 1
+}
+
+// encodeM3U writes extended M3U, UTF-8 as M3U8 expects.
+func encodeM3U(w io.Writer, title string, entries []Entry) (int, error) {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "#EXTM3U")
+	if title != "" {
+		fmt.Fprintf(bw, "#PLAYLIST:%s\n", title)
+	}
+	n := 0
+	for _, e := range entries {
+		if e.Location == "" {
+			continue
+		}
+		secs := -1
+		if e.DurationMs > 0 {
+			secs = e.DurationMs / 1000
+		}
+		name := e.Title
+		if e.Artist != "" {
+			name = e.Artist + " - " + e.Title
+		}
+		fmt.Fprintf(bw, "#EXTINF:%d,%s\n", secs, name)
+		if e.Album != "" {
+			fmt.Fprintf(bw, "#EXTALB:%s\n", e.Album)
+		}
+		fmt.Fprintln(bw, e.Location)
+		n++
+	}
+	return n, bw.Flush()
+}
+
+// decodeM3U reads plain or extended M3U. The artist and title come from
+// #EXTINF, split at the first " - ".
+func decodeM3U(r io.Reader) ([]Entry, error) {
+	var entries []Entry
+	var next Entry
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(sc.Text(), "\ufeff"))
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXTINF:"):
+			dur, name, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			// Attributes such as tvg-id may follow the duration
+			dur, _, _ = strings.Cut(dur, " ")
+			if secs, err := strconv.Atoi(dur); err == nil && secs > 0 {
+				next.DurationMs = secs * 1000
+			}
+			if artist, title, ok := strings.Cut(name, " - "); ok {
+				next.Artist, next.Title = strings.TrimSpace(artist), strings.TrimSpace(title)
+			} else {
+				next.Title = strings.TrimSpace(name)
+			}
+		case strings.HasPrefix(line, "#EXTALB:"):
+			next.Album = strings.TrimSpace(strings.TrimPrefix(line, "#EXTALB:"))
+		case strings.HasPrefix(line, "#"):
+		default:
+			next.Location = fileLocation(line)
+			entries = append(entries, next)
+			next = Entry{}
+		}
+	}
+	return entries, sc.Err()
+}
+
+// fileLocation turns a file:// URL into a path and leaves anything else
+// as it is.
+func fileLocation(loc string) string {
+	if u, err := url.Parse(loc); err == nil && u.Scheme == "file" {
+		return filepath.FromSlash(u.Path)
+	}
+	return loc
+}
+
+type xspfPlaylist struct {
+	XMLName xml.Name    `xml:"http://xspf.org/ns/0/ playlist"`
+	Version string      `xml:"version,attr"`
+	Title   string      `xml:"title,omitempty"`
+	Tracks  []xspfTrack `xml:"trackList>track"`
+}
+
+type xspfTrack struct {
+	Location string `xml:"location,omitempty"`
+	Title    string `xml:"title,omitempty"`
+	Creator  string `xml:"creator,omitempty"`
+	Album    string `xml:"album,omitempty"`
+	Duration int    `xml:"duration,omitempty"` // milliseconds
+}
+
+// encodeXSPF writes XSPF version 1. File paths become file:// URIs, or
+// relative URI references when relative.
+func encodeXSPF(w io.Writer, title string, entries []Entry) (int, error) {
+	pl := xspfPlaylist{Version: "1", Title: title}
+	for _, e := range entries {
+		loc := e.Location
+		if loc != "" && !isURL(loc) {
+			u := url.URL{Path: filepath.ToSlash(loc)}
+			if filepath.IsAbs(loc) {
+				u.Scheme = "file"
+			}
+			loc = u.String()
+		}
+		pl.Tracks = append(pl.Tracks, xspfTrack{Location: loc, Title: e.Title, Creator: e.Artist, Album: e.Album, Duration: e.DurationMs})
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return 0, err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(pl); err != nil {
+		return 0, err
+	}
+	_, err := io.WriteString(w, "\n")
+	return len(pl.Tracks), err
+}
+
+// decodeXSPF reads XSPF, turning file:// and relative URIs into paths.
+func decodeXSPF(r io.Reader) ([]Entry, error) {
+	var pl xspfPlaylist
+	if err := xml.NewDecoder(r).Decode(&pl); err != nil {
+		return nil, err
+	}
+	entries := make([]Entry, 0, len(pl.Tracks))
+	for _, t := range pl.Tracks {
+		loc := strings.TrimSpace(t.Location)
+		if u, err := url.Parse(loc); err == nil && (u.Scheme == "file" || u.Scheme == "") {
+			loc = filepath.FromSlash(u.Path)
+		}
+		entries = append(entries, Entry{Location: loc, Title: t.Title, Artist: t.Creator, Album: t.Album, DurationMs: t.Duration})
+	}
+	return entries, nil
+}
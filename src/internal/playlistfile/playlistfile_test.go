@@ -0,0 +1,67 @@
+package playlistfile
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	music := filepath.Join(dir, "Music", "Pink Floyd")
+	entries := []Entry{
+		{Location: filepath.Join(music, "Time & Money.flac"), Title: "Time", Artist: "Pink Floyd", Album: "The Dark Side of the Moon", DurationMs: 413000},
+		{Location: "https://radio.example/stream", Title: "Radio"},
+	}
+
+	for _, name := range []string{"mix.m3u8", "mix.xspf"} {
+		for _, relative := range []bool{false, true} {
+			path := filepath.Join(dir, "lists", name)
+			if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			n, err := Write(path, "Mix", entries, relative)
+			if err != nil || n != 2 {
+				t.Fatalf("%s relative=%v: Write = %d, %v", name, relative, n, err)
+			}
+			data, _ := os.ReadFile(path)
+			if relative != strings.Contains(string(data), "../Music") {
+				t.Errorf("%s relative=%v: unexpected paths in\n%s", name, relative, data)
+			}
+			got, err := Read(path)
+			if err != nil {
+				t.Fatalf("%s: Read: %v", name, err)
+			}
+			if !reflect.DeepEqual(got, entries) {
+				t.Errorf("%s relative=%v: round trip\n got %+v\nwant %+v", name, relative, got, entries)
+			}
+		}
+	}
+}
+
+func TestReadPlainM3U(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "old.m3u")
+	os.WriteFile(path, []byte("\ufeff# comment\r\nsongs/a.mp3\r\n\r\nfile:///srv/b.mp3\r\n"), 0o644)
+	got, err := Read(path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	want := []Entry{{Location: filepath.Join(dir, "songs", "a.mp3")}, {Location: filepath.FromSlash("/srv/b.mp3")}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestWriteM3USkipsEntriesWithoutLocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "q.m3u")
+	n, err := Write(path, "", []Entry{{Title: "Remote"}, {Location: "/a.mp3", Title: "Local"}}, false)
+	if err != nil || n != 1 {
+		t.Fatalf("Write = %d, %v", n, err)
+	}
+	if _, err := Write(filepath.Join(t.TempDir(), "q.pls"), "", nil, false); err == nil {
+		t.Error("expected an error for an unsupported extension")
+	}
+}
@@ -0,0 +1,241 @@
+// Package plugin runs user Lua scripts that react to playback events and add
+// entries to the command palette.
+//
+// A script registers its hooks through the global tunez table:
+//
+//	tunez.on("track_start", function(track) ... end)
+//	tunez.command("Lights off", function() return "Lights dimmed" end)
+//	tunez.log("loaded")
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	lua "github.com/yuin/gopher-lua"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// Events a script can hook with tunez.on.
+const (
+	EventTrackStart = "track_start"
+	EventTrackEnd   = "track_end"
+	EventScrobble   = "scrobble"
+)
+
+var events = []string{EventTrackStart, EventTrackEnd, EventScrobble}
+
+// DefaultTimeout bounds each call into a script.
+const DefaultTimeout = 2 * time.Second
+
+// Options configures Load.
+type Options struct {
+	// Timeout bounds each hook or command call; zero means DefaultTimeout.
+	Timeout time.Duration
+	Logger  *slog.Logger
+}
+
+// Command is a palette command added by a script.
+type Command struct {
+	Plugin string // script file name without .lua
+	Name   string
+	fn     *lua.LFunction
+	s      *script
+}
+
+// Manager holds the loaded scripts.
+type Manager struct {
+	scripts []*script
+	timeout time.Duration
+	logger  *slog.Logger
+}
+
+// script is one loaded Lua file. Lua states aren't safe for concurrent use,
+// so every call into it holds mu.
+type script struct {
+	name     string
+	mu       sync.Mutex
+	L        *lua.LState
+	hooks    map[string][]*lua.LFunction
+	commands []Command
+}
+
+// Load runs every *.lua file in dir, in name order. A missing dir loads
+// nothing; a script that fails to run is logged and skipped.
+func Load(dir string, opts Options) (*Manager, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+	m := &Manager{timeout: opts.Timeout, logger: opts.Logger}
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.lua"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(files)
+	for _, path := range files {
+		s, err := m.load(path)
+		if err != nil {
+			m.logger.Warn("plugin failed to load", slog.String("path", path), slog.Any("err", err))
+			continue
+		}
+		m.logger.Info("plugin loaded", slog.String("plugin", s.name), slog.Int("commands", len(s.commands)))
+		m.scripts = append(m.scripts, s)
+	}
+	return m, nil
+}
+
+func (m *Manager) load(path string) (*script, error) {
+	s := &script{
+		name:  strings.TrimSuffix(filepath.Base(path), ".lua"),
+		L:     lua.NewState(),
+		hooks: make(map[string][]*lua.LFunction),
+	}
+	api := s.L.NewTable()
+	s.L.SetFuncs(api, map[string]lua.LGFunction{
+		"on": func(L *lua.LState) int {
+			event := L.CheckString(1)
+			if !slices.Contains(events, event) {
+				L.ArgError(1, fmt.Sprintf("unknown event %q (want one of %s)", event, strings.Join(events, ", ")))
+			}
+			s.hooks[event] = append(s.hooks[event], L.CheckFunction(2))
+			return 0
+		},
+		"command": func(L *lua.LState) int {
+			s.commands = append(s.commands, Command{Plugin: s.name, Name: L.CheckString(1), fn: L.CheckFunction(2), s: s})
+			return 0
+		},
+		"log": func(L *lua.LState) int {
+			m.logger.Info(L.CheckString(1), slog.String("plugin", s.name))
+			return 0
+		},
+	})
+	s.L.SetGlobal("tunez", api)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	s.L.SetContext(ctx)
+	defer s.L.RemoveContext()
+	if err := s.L.DoFile(path); err != nil {
+		s.L.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Len returns how many scripts are loaded.
+func (m *Manager) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.scripts)
+}
+
+// Commands returns the palette commands added by all scripts.
+func (m *Manager) Commands() []Command {
+	if m == nil {
+		return nil
+	}
+	var out []Command
+	for _, s := range m.scripts {
+		out = append(out, s.commands...)
+	}
+	return out
+}
+
+// Emit calls every hook registered for event with t. It blocks until all
+// hooks return or time out; errors are logged.
+func (m *Manager) Emit(event string, t provider.Track) {
+	if m == nil {
+		return
+	}
+	for _, s := range m.scripts {
+		for _, fn := range s.hooks[event] {
+			if _, err := m.call(s, fn, func(L *lua.LState) []lua.LValue { return []lua.LValue{trackTable(L, t)} }); err != nil {
+				m.logger.Warn("plugin hook failed", slog.String("plugin", s.name), slog.String("event", event), slog.Any("err", err))
+			}
+		}
+	}
+}
+
+// Run calls a script's palette command and returns the text it returned,
+// if any, to show as status.
+func (m *Manager) Run(c Command) (string, error) {
+	ret, err := m.call(c.s, c.fn, nil)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", c.Plugin, err)
+	}
+	if ret == lua.LNil {
+		return "", nil
+	}
+	return ret.String(), nil
+}
+
+func (m *Manager) call(s *script, fn *lua.LFunction, args func(L *lua.LState) []lua.LValue) (lua.LValue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+	s.L.SetContext(ctx)
+	defer s.L.RemoveContext()
+
+	var argv []lua.LValue
+	if args != nil {
+		argv = args(s.L)
+	}
+	if err := s.L.CallByParam(lua.P{Fn: fn, NRet: 1, Protect: true}, argv...); err != nil {
+		return lua.LNil, err
+	}
+	ret := s.L.Get(-1)
+	s.L.Pop(1)
+	return ret, nil
+}
+
+// Close shuts down every script's Lua state.
+func (m *Manager) Close() {
+	if m == nil {
+		return
+	}
+	for _, s := range m.scripts {
+		s.mu.Lock()
+		s.L.Close()
+		s.mu.Unlock()
+	}
+	m.scripts = nil
+}
+
+// trackTable converts t to the table passed to hooks.
+func trackTable(L *lua.LState, t provider.Track) *lua.LTable {
+	tbl := L.NewTable()
+	for k, v := range map[string]string{
+		"id":        t.ID,
+		"title":     t.Title,
+		"artist":    t.ArtistName,
+		"artist_id": t.ArtistID,
+		"album":     t.AlbumTitle,
+		"album_id":  t.AlbumID,
+		"codec":     t.Codec,
+		"path":      t.FilePath,
+	} {
+		tbl.RawSetString(k, lua.LString(v))
+	}
+	for k, v := range map[string]int{
+		"year":        t.Year,
+		"duration_ms": t.DurationMs,
+		"track_no":    t.TrackNo,
+		"disc_no":     t.DiscNo,
+	} {
+		tbl.RawSetString(k, lua.LNumber(v))
+	}
+	return tbl
+}
@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func writeScript(t *testing.T, dir, name, src string) {
+	t.Helper()
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHooksAndCommands(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "lights.lua", `
+local last = "nothing"
+tunez.on("track_start", function(track)
+  last = track.artist .. " - " .. track.title .. " (" .. track.duration_ms .. ")"
+end)
+tunez.command("Last track", function() return last end)
+`)
+	writeScript(t, dir, "broken.lua", `tunez.on("track_begin", function() end)`)
+
+	m, err := Load(dir, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	if m.Len() != 1 {
+		t.Fatalf("expected the broken script to be skipped, got %d loaded", m.Len())
+	}
+
+	m.Emit(EventTrackStart, provider.Track{Title: "Breathe", ArtistName: "Pink Floyd", DurationMs: 163000})
+	cmds := m.Commands()
+	if len(cmds) != 1 || cmds[0].Name != "Last track" || cmds[0].Plugin != "lights" {
+		t.Fatalf("expected one command from lights, got %+v", cmds)
+	}
+	out, err := m.Run(cmds[0])
+	if err != nil || out != "Pink Floyd - Breathe (163000)" {
+		t.Errorf("expected hook state in command result, got %q %v", out, err)
+	}
+}
+
+func TestCallTimeout(t *testing.T) {
+	dir := t.TempDir()
+	writeScript(t, dir, "spin.lua", `tunez.command("Spin", function() while true do end end)`)
+
+	m, err := Load(dir, Options{Timeout: 50 * time.Millisecond})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer m.Close()
+	start := time.Now()
+	_, err = m.Run(m.Commands()[0])
+	if err == nil || !strings.Contains(err.Error(), "spin") {
+		t.Fatalf("expected a timeout error naming the plugin, got %v", err)
+	}
+	if time.Since(start) > time.Second {
+		t.Errorf("expected the call to be cut off, took %v", time.Since(start))
+	}
+}
+
+func TestLoadMissingDir(t *testing.T) {
+	m, err := Load(filepath.Join(t.TempDir(), "none"), Options{})
+	if err != nil || m.Len() != 0 {
+		t.Errorf("expected no plugins and no error, got %d %v", m.Len(), err)
+	}
+}
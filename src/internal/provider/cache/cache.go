@@ -0,0 +1,336 @@
+// Package cache provides a provider.Provider decorator that keeps list,
+// search and get responses in SQLite so remote libraries are instant to
+// revisit and stay browsable while the server is unreachable.
+package cache
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/tunez/tunez/internal/logging"
+	"github.com/tunez/tunez/internal/platform"
+	"github.com/tunez/tunez/internal/provider"
+	_ "modernc.org/sqlite"
+)
+
+// Default time-to-live for each kind of response.
+const (
+	DefaultListTTL   = time.Hour
+	DefaultItemTTL   = 24 * time.Hour
+	DefaultSearchTTL = 10 * time.Minute
+)
+
+// Options configures the cache.
+type Options struct {
+	// Path is the SQLite file. A bare file name is placed in the state dir;
+	// empty uses provider_cache.db there.
+	Path string
+	// Namespace keeps entries of different profiles apart, since two
+	// profiles can use the same provider type against different servers.
+	Namespace string
+
+	ListTTL   time.Duration
+	ItemTTL   time.Duration
+	SearchTTL time.Duration
+}
+
+// Provider wraps another provider and caches its read responses.
+//
+// Fresh entries are served without touching the network. Expired entries
+// are revalidated with ETag/If-Modified-Since when the wrapped provider
+// supports it (see provider.Validators), and served stale when the provider
+// is offline or failing temporarily. Streams and artwork are not cached.
+type Provider struct {
+	inner   provider.Provider
+	db      *sql.DB
+	opts    Options
+	now     func() time.Time
+	offline atomic.Bool
+}
+
+// New opens the cache database and wraps inner.
+func New(inner provider.Provider, opts Options) (*Provider, error) {
+	if opts.Path == "" {
+		opts.Path = "provider_cache.db"
+	}
+	if !filepath.IsAbs(opts.Path) && filepath.Base(opts.Path) == opts.Path {
+		dir, err := logging.StateDir()
+		if err != nil {
+			return nil, fmt.Errorf("resolve cache db path: %w", err)
+		}
+		opts.Path = filepath.Join(dir, opts.Path)
+	}
+	if opts.Namespace == "" {
+		opts.Namespace = inner.ID()
+	}
+	if opts.ListTTL == 0 {
+		opts.ListTTL = DefaultListTTL
+	}
+	if opts.ItemTTL == 0 {
+		opts.ItemTTL = DefaultItemTTL
+	}
+	if opts.SearchTTL == 0 {
+		opts.SearchTTL = DefaultSearchTTL
+	}
+
+	if err := os.MkdirAll(filepath.Dir(opts.Path), 0o755); err != nil {
+		return nil, fmt.Errorf("create cache dir: %w", err)
+	}
+	db, err := sql.Open("sqlite", opts.Path)
+	if err != nil {
+		return nil, fmt.Errorf("open cache db: %w", err)
+	}
+	if _, err := db.Exec("PRAGMA journal_mode=WAL"); err != nil {
+		slog.Warn("provider cache: set journal_mode", "err", err)
+	}
+	if _, err := db.Exec("PRAGMA synchronous=NORMAL"); err != nil {
+		slog.Warn("provider cache: set synchronous", "err", err)
+	}
+
+	c := &Provider{inner: inner, db: db, opts: opts, now: time.Now}
+	if err := c.ensureSchema(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Provider) ensureSchema(ctx context.Context) error {
+	_, err := c.db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS responses (
+		key TEXT PRIMARY KEY,
+		namespace TEXT NOT NULL,
+		value BLOB NOT NULL,
+		etag TEXT NOT NULL DEFAULT '',
+		last_modified TEXT NOT NULL DEFAULT '',
+		expires_at INTEGER NOT NULL
+	);`)
+	if err != nil {
+		return fmt.Errorf("migrate cache schema: %w", err)
+	}
+	return nil
+}
+
+// Unwrap returns the wrapped provider.
+func (c *Provider) Unwrap() provider.Provider { return c.inner }
+
+// SetOffline switches to serving saved responses only, however old, while
+// the wrapped provider is unreachable.
+func (c *Provider) SetOffline(offline bool) { c.offline.Store(offline) }
+
+// Offline reports whether only saved responses are being served.
+func (c *Provider) Offline() bool { return c.offline.Load() }
+
+// Close closes the cache database.
+func (c *Provider) Close() error { return c.db.Close() }
+
+// Clear drops every cached response for this namespace and gives the
+// space back to the file system.
+func (c *Provider) Clear(ctx context.Context) error {
+	if _, err := c.db.ExecContext(ctx, `DELETE FROM responses WHERE namespace = ?`, c.opts.Namespace); err != nil {
+		return err
+	}
+	if _, err := c.db.ExecContext(ctx, `VACUUM`); err != nil {
+		return err
+	}
+	_, err := c.db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`)
+	return err
+}
+
+// Size returns the bytes the cache database takes on disk, for every
+// namespace, with its write-ahead log.
+func (c *Provider) Size() (int64, error) {
+	return platform.DiskUsage(c.opts.Path, c.opts.Path+"-wal", c.opts.Path+"-shm")
+}
+
+func (c *Provider) ID() string   { return c.inner.ID() }
+func (c *Provider) Name() string { return c.inner.Name() }
+
+func (c *Provider) Capabilities() provider.Capabilities { return c.inner.Capabilities() }
+
+func (c *Provider) Initialize(ctx context.Context, profileCfg any) error {
+	return c.inner.Initialize(ctx, profileCfg)
+}
+
+func (c *Provider) Health(ctx context.Context) (bool, string) { return c.inner.Health(ctx) }
+
+func (c *Provider) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	return cached(ctx, c, c.opts.ListTTL, true, key("ListArtists", req), func(ctx context.Context) (provider.Page[provider.Artist], error) {
+		return c.inner.ListArtists(ctx, req)
+	})
+}
+
+func (c *Provider) GetArtist(ctx context.Context, id string) (provider.Artist, error) {
+	return cached(ctx, c, c.opts.ItemTTL, true, key("GetArtist", id), func(ctx context.Context) (provider.Artist, error) {
+		return c.inner.GetArtist(ctx, id)
+	})
+}
+
+func (c *Provider) ListAlbums(ctx context.Context, artistId string, req provider.ListReq) (provider.Page[provider.Album], error) {
+	return cached(ctx, c, c.opts.ListTTL, true, key("ListAlbums", artistId, req), func(ctx context.Context) (provider.Page[provider.Album], error) {
+		return c.inner.ListAlbums(ctx, artistId, req)
+	})
+}
+
+func (c *Provider) GetAlbum(ctx context.Context, id string) (provider.Album, error) {
+	return cached(ctx, c, c.opts.ItemTTL, true, key("GetAlbum", id), func(ctx context.Context) (provider.Album, error) {
+		return c.inner.GetAlbum(ctx, id)
+	})
+}
+
+func (c *Provider) ListTracks(ctx context.Context, albumId string, artistId string, playlistId string, req provider.ListReq) (provider.Page[provider.Track], error) {
+	return cached(ctx, c, c.opts.ListTTL, true, key("ListTracks", albumId, artistId, playlistId, req), func(ctx context.Context) (provider.Page[provider.Track], error) {
+		return c.inner.ListTracks(ctx, albumId, artistId, playlistId, req)
+	})
+}
+
+func (c *Provider) GetTrack(ctx context.Context, id string) (provider.Track, error) {
+	return cached(ctx, c, c.opts.ItemTTL, true, key("GetTrack", id), func(ctx context.Context) (provider.Track, error) {
+		return c.inner.GetTrack(ctx, id)
+	})
+}
+
+// Search fans out to several endpoints in some providers, so it is cached
+// by TTL only and never revalidated conditionally.
+func (c *Provider) Search(ctx context.Context, q string, req provider.ListReq) (provider.SearchResults, error) {
+	return cached(ctx, c, c.opts.SearchTTL, false, key("Search", q, req), func(ctx context.Context) (provider.SearchResults, error) {
+		return c.inner.Search(ctx, q, req)
+	})
+}
+
+func (c *Provider) ListPlaylists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Playlist], error) {
+	return cached(ctx, c, c.opts.ListTTL, true, key("ListPlaylists", req), func(ctx context.Context) (provider.Page[provider.Playlist], error) {
+		return c.inner.ListPlaylists(ctx, req)
+	})
+}
+
+func (c *Provider) GetPlaylist(ctx context.Context, id string) (provider.Playlist, error) {
+	return cached(ctx, c, c.opts.ItemTTL, true, key("GetPlaylist", id), func(ctx context.Context) (provider.Playlist, error) {
+		return c.inner.GetPlaylist(ctx, id)
+	})
+}
+
+func (c *Provider) GetLyrics(ctx context.Context, trackId string) (provider.Lyrics, error) {
+	return cached(ctx, c, c.opts.ItemTTL, true, key("GetLyrics", trackId), func(ctx context.Context) (provider.Lyrics, error) {
+		return c.inner.GetLyrics(ctx, trackId)
+	})
+}
+
+// GetStream is never cached: stream URLs are often signed and short-lived.
+func (c *Provider) GetStream(ctx context.Context, trackId string) (provider.StreamInfo, error) {
+	return c.inner.GetStream(ctx, trackId)
+}
+
+func (c *Provider) GetArtwork(ctx context.Context, ref string, sizePx int) (provider.Artwork, error) {
+	return c.inner.GetArtwork(ctx, ref, sizePx)
+}
+
+// key builds a cache key from the method name and its arguments.
+func key(method string, args ...any) string {
+	b, _ := json.Marshal(args)
+	return method + string(b)
+}
+
+type entry struct {
+	value      []byte
+	validators provider.Validators
+	expiresAt  time.Time
+}
+
+// cached serves key from the cache or calls fetch and stores the result.
+func cached[T any](ctx context.Context, c *Provider, ttl time.Duration, conditional bool, key string, fetch func(context.Context) (T, error)) (T, error) {
+	var zero T
+	now := c.now()
+	e, hit := c.load(ctx, key)
+	if hit && (now.Before(e.expiresAt) || c.offline.Load()) {
+		var v T
+		if err := json.Unmarshal(e.value, &v); err == nil {
+			return v, nil
+		}
+		hit = false // unreadable entry, refetch
+	}
+	if c.offline.Load() {
+		return zero, provider.ErrOffline
+	}
+
+	fetchCtx := ctx
+	var validators provider.Validators
+	if conditional {
+		if hit {
+			validators = e.validators
+		}
+		fetchCtx = provider.WithValidators(ctx, &validators)
+	}
+
+	v, err := fetch(fetchCtx)
+	switch {
+	case err == nil:
+		c.store(ctx, key, v, validators, now.Add(ttl))
+		return v, nil
+	case hit && errors.Is(err, provider.ErrNotModified):
+		c.touch(ctx, key, now.Add(ttl))
+	case hit && unavailable(err):
+		slog.Debug("provider cache: serving stale entry", "key", key, "err", err)
+	default:
+		return zero, err
+	}
+
+	var stale T
+	if jerr := json.Unmarshal(e.value, &stale); jerr != nil {
+		return zero, err
+	}
+	return stale, nil
+}
+
+// unavailable reports whether err means the provider couldn't be reached,
+// as opposed to a definitive answer like not found or unauthorized.
+func unavailable(err error) bool {
+	if errors.Is(err, provider.ErrOffline) || errors.Is(err, provider.ErrTemporary) || errors.Is(err, provider.ErrRateLimited) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
+
+func (c *Provider) load(ctx context.Context, key string) (entry, bool) {
+	var e entry
+	var expires int64
+	err := c.db.QueryRowContext(ctx, `SELECT value, etag, last_modified, expires_at FROM responses WHERE key = ?`,
+		c.opts.Namespace+"/"+key).Scan(&e.value, &e.validators.ETag, &e.validators.LastModified, &expires)
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			slog.Warn("provider cache: load", "key", key, "err", err)
+		}
+		return entry{}, false
+	}
+	e.expiresAt = time.Unix(expires, 0)
+	return e, true
+}
+
+func (c *Provider) store(ctx context.Context, key string, v any, validators provider.Validators, expiresAt time.Time) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	_, err = c.db.ExecContext(ctx, `INSERT OR REPLACE INTO responses (key, namespace, value, etag, last_modified, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		c.opts.Namespace+"/"+key, c.opts.Namespace, b, validators.ETag, validators.LastModified, expiresAt.Unix())
+	if err != nil {
+		slog.Warn("provider cache: store", "key", key, "err", err)
+	}
+}
+
+func (c *Provider) touch(ctx context.Context, key string, expiresAt time.Time) {
+	_, err := c.db.ExecContext(ctx, `UPDATE responses SET expires_at = ? WHERE key = ?`, expiresAt.Unix(), c.opts.Namespace+"/"+key)
+	if err != nil {
+		slog.Warn("provider cache: touch", "key", key, "err", err)
+	}
+}
@@ -0,0 +1,209 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// fakeProvider serves artists and albums and records what it was asked.
+type fakeProvider struct {
+	provider.Provider
+	calls      int
+	err        error
+	etag       string
+	gotETag    string
+	notChanged bool
+	name       string
+}
+
+func (f *fakeProvider) ID() string { return "fake" }
+
+func (f *fakeProvider) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	f.calls++
+	if f.err != nil {
+		return provider.Page[provider.Artist]{}, f.err
+	}
+	if v := provider.ClaimValidators(ctx); v != nil {
+		f.gotETag = v.ETag
+		if f.notChanged && v.ETag == f.etag {
+			return provider.Page[provider.Artist]{}, provider.ErrNotModified
+		}
+		v.ETag = f.etag
+	}
+	return provider.Page[provider.Artist]{Items: []provider.Artist{{ID: "1", Name: f.name}}, NextCursor: req.Cursor + "next"}, nil
+}
+
+func (f *fakeProvider) GetAlbum(ctx context.Context, id string) (provider.Album, error) {
+	f.calls++
+	if f.err != nil {
+		return provider.Album{}, f.err
+	}
+	return provider.Album{ID: id, Title: "Album " + id}, nil
+}
+
+func newTestCache(t *testing.T, inner provider.Provider) (*Provider, *time.Time) {
+	t.Helper()
+	c, err := New(inner, Options{Path: filepath.Join(t.TempDir(), "cache.db")})
+	if err != nil {
+		t.Fatalf("new cache: %v", err)
+	}
+	t.Cleanup(func() { c.Close() })
+	now := time.Now()
+	c.now = func() time.Time { return now }
+	return c, &now
+}
+
+func TestCacheServesFreshEntries(t *testing.T) {
+	inner := &fakeProvider{name: "Queen"}
+	c, _ := newTestCache(t, inner)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		page, err := c.ListArtists(ctx, provider.ListReq{Cursor: "a"})
+		if err != nil {
+			t.Fatalf("list artists: %v", err)
+		}
+		if len(page.Items) != 1 || page.Items[0].Name != "Queen" || page.NextCursor != "anext" {
+			t.Fatalf("unexpected page %+v", page)
+		}
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected 1 upstream call, got %d", inner.calls)
+	}
+
+	// A different cursor is a different entry
+	if _, err := c.ListArtists(ctx, provider.ListReq{Cursor: "b"}); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected 2 upstream calls, got %d", inner.calls)
+	}
+}
+
+func TestCacheRevalidatesWithETag(t *testing.T) {
+	inner := &fakeProvider{name: "Queen", etag: `"v1"`, notChanged: true}
+	c, now := newTestCache(t, inner)
+	ctx := context.Background()
+
+	if _, err := c.ListArtists(ctx, provider.ListReq{}); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(DefaultListTTL + time.Minute)
+	inner.name = "Changed" // would show if the 304 weren't honored
+
+	page, err := c.ListArtists(ctx, provider.ListReq{})
+	if err != nil {
+		t.Fatalf("revalidate: %v", err)
+	}
+	if inner.gotETag != `"v1"` {
+		t.Errorf("expected stored ETag to be sent, got %q", inner.gotETag)
+	}
+	if page.Items[0].Name != "Queen" {
+		t.Errorf("expected cached page on 304, got %+v", page.Items)
+	}
+
+	// The 304 refreshed the entry
+	if _, err := c.ListArtists(ctx, provider.ListReq{}); err != nil {
+		t.Fatal(err)
+	}
+	if inner.calls != 2 {
+		t.Errorf("expected 2 upstream calls, got %d", inner.calls)
+	}
+}
+
+func TestCacheServesStaleWhenOffline(t *testing.T) {
+	inner := &fakeProvider{}
+	c, now := newTestCache(t, inner)
+	ctx := context.Background()
+
+	if _, err := c.GetAlbum(ctx, "7"); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(DefaultItemTTL + time.Hour)
+	inner.err = provider.ErrTemporary
+
+	album, err := c.GetAlbum(ctx, "7")
+	if err != nil {
+		t.Fatalf("expected stale album, got %v", err)
+	}
+	if album.Title != "Album 7" {
+		t.Errorf("unexpected album %+v", album)
+	}
+
+	// Uncached items still fail, and definitive errors aren't masked
+	if _, err := c.GetAlbum(ctx, "8"); !errors.Is(err, provider.ErrTemporary) {
+		t.Errorf("expected temporary error for uncached album, got %v", err)
+	}
+	inner.err = provider.ErrNotFound
+	if _, err := c.GetAlbum(ctx, "7"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected not found to pass through, got %v", err)
+	}
+}
+
+func TestCacheOfflineSkipsNetwork(t *testing.T) {
+	inner := &fakeProvider{}
+	c, now := newTestCache(t, inner)
+	ctx := context.Background()
+
+	if _, err := c.GetAlbum(ctx, "7"); err != nil {
+		t.Fatal(err)
+	}
+	*now = now.Add(DefaultItemTTL + time.Hour)
+	if as, ok := provider.As[provider.OfflineServer](provider.Observe(c, func(string, time.Duration, error) {})); !ok || as != c {
+		t.Fatal("expected As to find the cache beneath another decorator")
+	}
+	c.SetOffline(true)
+
+	if album, err := c.GetAlbum(ctx, "7"); err != nil || album.Title != "Album 7" {
+		t.Fatalf("expected the expired album offline, got %+v %v", album, err)
+	}
+	if _, err := c.GetAlbum(ctx, "8"); !errors.Is(err, provider.ErrOffline) {
+		t.Errorf("expected uncached items to fail offline, got %v", err)
+	}
+	if inner.calls != 1 {
+		t.Errorf("expected no requests while offline, got %d", inner.calls-1)
+	}
+
+	c.SetOffline(false)
+	if _, err := c.GetAlbum(ctx, "7"); err != nil || inner.calls != 2 {
+		t.Errorf("expected the expired album refetched once back online, got %d calls %v", inner.calls, err)
+	}
+}
+
+func TestCacheClear(t *testing.T) {
+	inner := &fakeProvider{}
+	c, _ := newTestCache(t, inner)
+	ctx := context.Background()
+
+	c.GetAlbum(ctx, "1")
+	if size, err := c.Size(); err != nil || size == 0 {
+		t.Errorf("expected the cache to take space, got %d %v", size, err)
+	}
+	if err := c.Clear(ctx); err != nil {
+		t.Fatalf("clear: %v", err)
+	}
+	c.GetAlbum(ctx, "1")
+	if inner.calls != 2 {
+		t.Errorf("expected refetch after clear, got %d calls", inner.calls)
+	}
+	if provider.Unwrap(c) != provider.Provider(inner) {
+		t.Error("expected Unwrap to return the inner provider")
+	}
+}
+
+func TestCacheClosedThroughDecorators(t *testing.T) {
+	c, _ := newTestCache(t, &fakeProvider{})
+	observed := provider.Observe(c, func(string, time.Duration, error) {})
+
+	if err := provider.Close(observed); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+	if err := c.db.Ping(); err == nil {
+		t.Error("expected the cache database closed")
+	}
+}
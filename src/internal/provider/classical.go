@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"strconv"
+	"strings"
+)
+
+// romanNumerals lists movement numbers as they prefix movement titles.
+var romanNumerals = []string{"", "I", "II", "III", "IV", "V", "VI", "VII", "VIII", "IX", "X",
+	"XI", "XII", "XIII", "XIV", "XV", "XVI", "XVII", "XVIII", "XIX", "XX"}
+
+// Roman returns n as a Roman numeral for movement numbers 1 to 20, and in
+// digits otherwise.
+func Roman(n int) string {
+	if n > 0 && n < len(romanNumerals) {
+		return romanNumerals[n]
+	}
+	return strconv.Itoa(n)
+}
+
+// SplitWork splits a classical title such as "Symphony No. 5 in C minor,
+// Op. 67: I. Allegro con brio" into its work, movement and movement number.
+// The movement must start with a Roman or Arabic numeral and a period after
+// ": " or " - "; otherwise ok is false.
+func SplitWork(title string) (work, movement string, no int, ok bool) {
+	for _, sep := range []string{": ", " - "} {
+		i := strings.LastIndex(title, sep)
+		if i <= 0 {
+			continue
+		}
+		num, rest, found := strings.Cut(title[i+len(sep):], ". ")
+		if !found || strings.TrimSpace(rest) == "" {
+			continue
+		}
+		if n := parseMovementNo(num); n > 0 {
+			return strings.TrimSpace(title[:i]), strings.TrimSpace(rest), n, true
+		}
+	}
+	return "", "", 0, false
+}
+
+// parseMovementNo reads a Roman numeral up to XX or a plain number.
+func parseMovementNo(s string) int {
+	if n, err := strconv.Atoi(s); err == nil && n > 0 {
+		return n
+	}
+	for n, r := range romanNumerals {
+		if n > 0 && s == r {
+			return n
+		}
+	}
+	return 0
+}
+
+// MovementTitle returns the title of a track within its work, e.g.
+// "I. Allegro con brio", or its title when it has no movement.
+func (t Track) MovementTitle() string {
+	if t.Movement == "" {
+		return t.Title
+	}
+	if t.MovementNo > 0 {
+		return Roman(t.MovementNo) + ". " + t.Movement
+	}
+	return t.Movement
+}
@@ -0,0 +1,595 @@
+// Package composite merges several providers into one library. IDs are
+// namespaced with the owning profile ("home:42") so lookups, streams and
+// artwork are routed back to the provider an item came from.
+package composite
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// Member is one profile taking part in the merged library.
+type Member struct {
+	ProfileID string
+	Name      string
+	Provider  provider.Provider
+	Settings  any // passed to Provider.Initialize
+}
+
+// Provider fans list and search calls out to every member and merges the
+// pages. Single-item calls go to the member named in the ID.
+type Provider struct {
+	members []Member
+	byID    map[string]int
+}
+
+// New creates a composite over members, in display order.
+func New(members []Member) *Provider {
+	p := &Provider{members: members, byID: make(map[string]int, len(members))}
+	for i, mem := range members {
+		p.byID[mem.ProfileID] = i
+	}
+	return p
+}
+
+func (p *Provider) ID() string { return "composite" }
+
+func (p *Provider) Name() string {
+	names := make([]string, len(p.members))
+	for i, mem := range p.members {
+		names[i] = mem.Name
+	}
+	return strings.Join(names, " + ")
+}
+
+// Members returns the merged profiles.
+func (p *Provider) Members() []Member { return p.members }
+
+// Capabilities is the union of the members' capabilities.
+func (p *Provider) Capabilities() provider.Capabilities {
+	caps := provider.Capabilities{}
+	for _, mem := range p.members {
+		for c, ok := range mem.Provider.Capabilities() {
+			if ok {
+				caps[c] = true
+			}
+		}
+	}
+	return caps
+}
+
+// Initialize initializes every member with its own profile settings. Members
+// that fail are dropped so one unreachable server doesn't block the rest.
+func (p *Provider) Initialize(ctx context.Context, _ any) error {
+	errs := make([]error, len(p.members))
+	var wg sync.WaitGroup
+	for i, mem := range p.members {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = mem.Provider.Initialize(ctx, mem.Settings)
+		}()
+	}
+	wg.Wait()
+
+	var live []Member
+	for i, mem := range p.members {
+		if errs[i] != nil {
+			slog.Warn("composite: member failed to initialize", "profile", mem.ProfileID, "err", errs[i])
+			_ = provider.Close(mem.Provider)
+			continue
+		}
+		live = append(live, mem)
+	}
+	if len(live) == 0 {
+		return fmt.Errorf("no profile could be initialized: %w", errors.Join(errs...))
+	}
+	*p = *New(live)
+	return nil
+}
+
+// Health is ok only when every member is healthy.
+func (p *Provider) Health(ctx context.Context) (bool, string) {
+	allOK := true
+	var parts []string
+	for _, mem := range p.members {
+		ok, msg := mem.Provider.Health(ctx)
+		if !ok {
+			allOK = false
+		}
+		parts = append(parts, mem.ProfileID+": "+msg)
+	}
+	return allOK, strings.Join(parts, "; ")
+}
+
+// Close releases every member's resources.
+func (p *Provider) Close() error {
+	var errs []error
+	for _, mem := range p.members {
+		errs = append(errs, provider.Close(mem.Provider))
+	}
+	return errors.Join(errs...)
+}
+
+// Origin returns the member owning a namespaced ID and the ID within it.
+func (p *Provider) Origin(id string) (Member, string, bool) {
+	profileID, local, ok := strings.Cut(id, ":")
+	if !ok {
+		return Member{}, "", false
+	}
+	i, ok := p.byID[profileID]
+	if !ok {
+		return Member{}, "", false
+	}
+	return p.members[i], local, true
+}
+
+// route is Origin for callers that only need the provider.
+func (p *Provider) route(id string) (provider.Provider, string, error) {
+	mem, local, ok := p.Origin(id)
+	if !ok {
+		return nil, "", provider.ErrNotFound
+	}
+	return mem.Provider, local, nil
+}
+
+func (p *Provider) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	mode := req.Sort
+	if mode == "" {
+		mode = provider.SortName
+	}
+	return mergeSorted(ctx, p, req, provider.CompareArtists(mode), func(ctx context.Context, mem Member, cursor string) (provider.Page[provider.Artist], error) {
+		r := req
+		r.Cursor = cursor
+		pg, err := mem.Provider.ListArtists(ctx, r)
+		for i := range pg.Items {
+			namespaceArtist(mem.ProfileID, &pg.Items[i])
+		}
+		return pg, err
+	})
+}
+
+func (p *Provider) GetArtist(ctx context.Context, id string) (provider.Artist, error) {
+	mem, local, ok := p.Origin(id)
+	if !ok {
+		return provider.Artist{}, provider.ErrNotFound
+	}
+	a, err := mem.Provider.GetArtist(ctx, local)
+	namespaceArtist(mem.ProfileID, &a)
+	return a, err
+}
+
+func (p *Provider) ListAlbums(ctx context.Context, artistId string, req provider.ListReq) (provider.Page[provider.Album], error) {
+	if artistId != "" {
+		mem, local, ok := p.Origin(artistId)
+		if !ok {
+			return provider.Page[provider.Album]{}, provider.ErrNotFound
+		}
+		pg, err := mem.Provider.ListAlbums(ctx, local, req)
+		for i := range pg.Items {
+			namespaceAlbum(mem.ProfileID, &pg.Items[i])
+		}
+		return pg, err
+	}
+	return mergeSorted(ctx, p, req, provider.CompareAlbums(req.Sort), func(ctx context.Context, mem Member, cursor string) (provider.Page[provider.Album], error) {
+		r := req
+		r.Cursor = cursor
+		pg, err := mem.Provider.ListAlbums(ctx, "", r)
+		for i := range pg.Items {
+			namespaceAlbum(mem.ProfileID, &pg.Items[i])
+		}
+		return pg, err
+	})
+}
+
+func (p *Provider) GetAlbum(ctx context.Context, id string) (provider.Album, error) {
+	mem, local, ok := p.Origin(id)
+	if !ok {
+		return provider.Album{}, provider.ErrNotFound
+	}
+	a, err := mem.Provider.GetAlbum(ctx, local)
+	namespaceAlbum(mem.ProfileID, &a)
+	return a, err
+}
+
+func (p *Provider) ListTracks(ctx context.Context, albumId string, artistId string, playlistId string, req provider.ListReq) (provider.Page[provider.Track], error) {
+	// A scoped listing belongs to one member
+	for _, scope := range []string{albumId, artistId, playlistId} {
+		if scope == "" {
+			continue
+		}
+		mem, _, ok := p.Origin(scope)
+		if !ok {
+			return provider.Page[provider.Track]{}, provider.ErrNotFound
+		}
+		pg, err := mem.Provider.ListTracks(ctx, localID(albumId), localID(artistId), localID(playlistId), req)
+		for i := range pg.Items {
+			namespaceTrack(mem.ProfileID, &pg.Items[i])
+		}
+		return pg, err
+	}
+	return mergeSorted(ctx, p, req, provider.CompareTracks(req.Sort), func(ctx context.Context, mem Member, cursor string) (provider.Page[provider.Track], error) {
+		r := req
+		r.Cursor = cursor
+		pg, err := mem.Provider.ListTracks(ctx, "", "", "", r)
+		for i := range pg.Items {
+			namespaceTrack(mem.ProfileID, &pg.Items[i])
+		}
+		return pg, err
+	})
+}
+
+func (p *Provider) GetTrack(ctx context.Context, id string) (provider.Track, error) {
+	mem, local, ok := p.Origin(id)
+	if !ok {
+		return provider.Track{}, provider.ErrNotFound
+	}
+	t, err := mem.Provider.GetTrack(ctx, local)
+	namespaceTrack(mem.ProfileID, &t)
+	return t, err
+}
+
+// Search queries every member. Each result type keeps its own merged
+// cursor, built from the members' typed cursors, so continuing one type
+// only asks members that still have more of it.
+func (p *Provider) Search(ctx context.Context, q string, req provider.ListReq) (provider.SearchResults, error) {
+	cursors, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return provider.SearchResults{}, err
+	}
+	type result struct {
+		res provider.SearchResults
+		err error
+	}
+	results := make([]result, len(p.members))
+	var wg sync.WaitGroup
+	for i, mem := range p.members {
+		cursor, ok := cursors[mem.ProfileID]
+		if req.Cursor != "" && !ok {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r := req
+			r.Cursor = cursor
+			res, err := mem.Provider.Search(ctx, q, r)
+			results[i] = result{res: res, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var out provider.SearchResults
+	tracks, albums, artists, playlists := url.Values{}, url.Values{}, url.Values{}, url.Values{}
+	var errs []error
+	ok := 0
+	for i, mem := range p.members {
+		r := results[i]
+		if r.err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", mem.ProfileID, r.err))
+			continue
+		}
+		ok++
+		for j := range r.res.Tracks.Items {
+			namespaceTrack(mem.ProfileID, &r.res.Tracks.Items[j])
+		}
+		for j := range r.res.Albums.Items {
+			namespaceAlbum(mem.ProfileID, &r.res.Albums.Items[j])
+		}
+		for j := range r.res.Artists.Items {
+			namespaceArtist(mem.ProfileID, &r.res.Artists.Items[j])
+		}
+		for j := range r.res.Playlists.Items {
+			r.res.Playlists.Items[j].ID = namespace(mem.ProfileID, r.res.Playlists.Items[j].ID)
+		}
+		mergePage(&out.Tracks, r.res.Tracks, mem.ProfileID, tracks)
+		mergePage(&out.Albums, r.res.Albums, mem.ProfileID, albums)
+		mergePage(&out.Artists, r.res.Artists, mem.ProfileID, artists)
+		mergePage(&out.Playlists, r.res.Playlists, mem.ProfileID, playlists)
+	}
+	if ok == 0 && len(errs) > 0 {
+		return provider.SearchResults{}, errors.Join(errs...)
+	}
+	for _, err := range errs {
+		slog.Warn("composite: search failed for member", "err", err)
+	}
+	out.Tracks.NextCursor = tracks.Encode()
+	out.Albums.NextCursor = albums.Encode()
+	out.Artists.NextCursor = artists.Encode()
+	out.Playlists.NextCursor = playlists.Encode()
+	return out, nil
+}
+
+func (p *Provider) ListPlaylists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Playlist], error) {
+	byName := func(a, b provider.Playlist) int {
+		return strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	}
+	return mergeSorted(ctx, p, req, byName, func(ctx context.Context, mem Member, cursor string) (provider.Page[provider.Playlist], error) {
+		if !mem.Provider.Capabilities()[provider.CapPlaylists] {
+			return provider.Page[provider.Playlist]{}, nil
+		}
+		r := req
+		r.Cursor = cursor
+		pg, err := mem.Provider.ListPlaylists(ctx, r)
+		for i := range pg.Items {
+			pg.Items[i].ID = namespace(mem.ProfileID, pg.Items[i].ID)
+		}
+		return pg, err
+	})
+}
+
+func (p *Provider) GetPlaylist(ctx context.Context, id string) (provider.Playlist, error) {
+	mem, local, ok := p.Origin(id)
+	if !ok {
+		return provider.Playlist{}, provider.ErrNotFound
+	}
+	pl, err := mem.Provider.GetPlaylist(ctx, local)
+	pl.ID = namespace(mem.ProfileID, pl.ID)
+	return pl, err
+}
+
+func (p *Provider) GetStream(ctx context.Context, trackId string) (provider.StreamInfo, error) {
+	prov, local, err := p.route(trackId)
+	if err != nil {
+		return provider.StreamInfo{}, err
+	}
+	return prov.GetStream(ctx, local)
+}
+
+func (p *Provider) GetLyrics(ctx context.Context, trackId string) (provider.Lyrics, error) {
+	prov, local, err := p.route(trackId)
+	if err != nil {
+		return provider.Lyrics{}, err
+	}
+	return prov.GetLyrics(ctx, local)
+}
+
+func (p *Provider) GetArtwork(ctx context.Context, ref string, sizePx int) (provider.Artwork, error) {
+	prov, local, err := p.route(ref)
+	if err != nil {
+		return provider.Artwork{}, err
+	}
+	return prov.GetArtwork(ctx, local, sizePx)
+}
+
+// fanOut calls fetch on every member still in the cursor and merges the
+// pages in member order. Members that fail are skipped unless all fail.
+func fanOut[T any](ctx context.Context, p *Provider, cursor string, fetch func(context.Context, Member, string) (provider.Page[T], error)) (provider.Page[T], error) {
+	cursors, err := decodeCursor(cursor)
+	if err != nil {
+		return provider.Page[T]{}, err
+	}
+	pages := make([]provider.Page[T], len(p.members))
+	errs := make([]error, len(p.members))
+	asked := make([]bool, len(p.members))
+	var wg sync.WaitGroup
+	for i, mem := range p.members {
+		c, ok := cursors[mem.ProfileID]
+		if cursor != "" && !ok {
+			continue // this member is exhausted
+		}
+		asked[i] = true
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			pages[i], errs[i] = fetch(ctx, mem, c)
+		}()
+	}
+	wg.Wait()
+
+	var out provider.Page[T]
+	next := url.Values{}
+	var failed []error
+	ok := 0
+	for i, mem := range p.members {
+		if !asked[i] {
+			continue
+		}
+		if errs[i] != nil {
+			if !errors.Is(errs[i], provider.ErrNotSupported) {
+				failed = append(failed, fmt.Errorf("%s: %w", mem.ProfileID, errs[i]))
+			}
+			continue
+		}
+		ok++
+		mergePage(&out, pages[i], mem.ProfileID, next)
+	}
+	if ok == 0 && len(failed) > 0 {
+		return provider.Page[T]{}, errors.Join(failed...)
+	}
+	for _, err := range failed {
+		slog.Warn("composite: list failed for member", "err", err)
+	}
+	out.NextCursor = next.Encode()
+	return out, nil
+}
+
+// mergeSorted is fanOut for listings in a known order. The members' pages
+// are merged item by item, so the order holds across pages however unevenly
+// the members contribute. Each member's part of the cursor records the page
+// it was read from and how many of its items were already returned.
+// A nil compare keeps member order (see fanOut).
+func mergeSorted[T any](ctx context.Context, p *Provider, req provider.ListReq, compare func(a, b T) int, fetch func(context.Context, Member, string) (provider.Page[T], error)) (provider.Page[T], error) {
+	if compare == nil {
+		return fanOut(ctx, p, req.Cursor, fetch)
+	}
+	cursors, err := decodeCursor(req.Cursor)
+	if err != nil {
+		return provider.Page[T]{}, err
+	}
+	type source struct {
+		page   provider.Page[T]
+		cursor string // the member cursor page was read from
+		pos    int    // items of page already returned
+		asked  bool
+		err    error
+	}
+	sources := make([]source, len(p.members))
+	for i, mem := range p.members {
+		c, ok := cursors[mem.ProfileID]
+		if req.Cursor != "" && !ok {
+			continue // this member is exhausted
+		}
+		src := &sources[i]
+		if ok {
+			skip, cursor, _ := strings.Cut(c, ":")
+			if src.pos, err = strconv.Atoi(skip); err != nil {
+				return provider.Page[T]{}, fmt.Errorf("composite cursor: %w", err)
+			}
+			src.cursor = cursor
+		}
+		src.asked = true
+	}
+	var wg sync.WaitGroup
+	for i, mem := range p.members {
+		src := &sources[i]
+		if !src.asked {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			src.page, src.err = fetch(ctx, mem, src.cursor)
+		}()
+	}
+	wg.Wait()
+
+	var out provider.Page[T]
+	var failed []error
+	ok := 0
+	for i, mem := range p.members {
+		src := &sources[i]
+		if !src.asked {
+			continue
+		}
+		if src.err != nil {
+			src.asked = false
+			if !errors.Is(src.err, provider.ErrNotSupported) {
+				failed = append(failed, fmt.Errorf("%s: %w", mem.ProfileID, src.err))
+			}
+			continue
+		}
+		ok++
+		mergePage(&out, provider.Page[T]{TotalHint: src.page.TotalHint}, mem.ProfileID, nil)
+	}
+	if ok == 0 && len(failed) > 0 {
+		return provider.Page[T]{}, errors.Join(failed...)
+	}
+	for _, err := range failed {
+		slog.Warn("composite: list failed for member", "err", err)
+	}
+
+	for req.PageSize <= 0 || len(out.Items) < req.PageSize {
+		best := -1
+		for i, mem := range p.members {
+			src := &sources[i]
+			// A member's next page may sort before anything left, so read
+			// it before going on
+			for src.asked && src.pos >= len(src.page.Items) && src.page.NextCursor != "" && len(src.page.Items) > 0 {
+				src.cursor, src.pos = src.page.NextCursor, 0
+				if src.page, src.err = fetch(ctx, mem, src.cursor); src.err != nil {
+					slog.Warn("composite: list failed for member", "err", fmt.Errorf("%s: %w", mem.ProfileID, src.err))
+					src.asked = false
+				}
+			}
+			if !src.asked || src.pos >= len(src.page.Items) {
+				continue
+			}
+			if best < 0 || compare(src.page.Items[src.pos], sources[best].page.Items[sources[best].pos]) < 0 {
+				best = i
+			}
+		}
+		if best < 0 {
+			break
+		}
+		out.Items = append(out.Items, sources[best].page.Items[sources[best].pos])
+		sources[best].pos++
+	}
+
+	next := url.Values{}
+	for i, mem := range p.members {
+		src := &sources[i]
+		switch {
+		case !src.asked:
+		case src.pos < len(src.page.Items):
+			next.Set(mem.ProfileID, strconv.Itoa(src.pos)+":"+src.cursor)
+		case src.page.NextCursor != "":
+			next.Set(mem.ProfileID, "0:"+src.page.NextCursor)
+		}
+	}
+	out.NextCursor = next.Encode()
+	return out, nil
+}
+
+// mergePage appends pg to out and records the member's next cursor.
+func mergePage[T any](out *provider.Page[T], pg provider.Page[T], profileID string, next url.Values) {
+	out.Items = append(out.Items, pg.Items...)
+	switch {
+	case out.TotalHint < 0:
+	case pg.TotalHint < 0:
+		out.TotalHint = -1
+	default:
+		out.TotalHint += pg.TotalHint
+	}
+	if pg.NextCursor != "" && next != nil {
+		next.Set(profileID, pg.NextCursor)
+	}
+}
+
+// decodeCursor parses a merged cursor into per-member cursors. Members with
+// more results are listed; an empty cursor means "start every member".
+func decodeCursor(cursor string) (map[string]string, error) {
+	out := map[string]string{}
+	if cursor == "" {
+		return out, nil
+	}
+	v, err := url.ParseQuery(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("composite cursor: %w", err)
+	}
+	for k := range v {
+		out[k] = v.Get(k)
+	}
+	return out, nil
+}
+
+func namespace(profileID, id string) string {
+	if id == "" {
+		return ""
+	}
+	return profileID + ":" + id
+}
+
+// localID strips the profile namespace from an ID.
+func localID(id string) string {
+	_, local, ok := strings.Cut(id, ":")
+	if !ok {
+		return id
+	}
+	return local
+}
+
+func namespaceArtist(profileID string, a *provider.Artist) {
+	a.ID = namespace(profileID, a.ID)
+}
+
+func namespaceAlbum(profileID string, a *provider.Album) {
+	a.ID = namespace(profileID, a.ID)
+	a.ArtistID = namespace(profileID, a.ArtistID)
+	a.ArtworkRef = namespace(profileID, a.ArtworkRef)
+}
+
+func namespaceTrack(profileID string, t *provider.Track) {
+	t.ID = namespace(profileID, t.ID)
+	t.ArtistID = namespace(profileID, t.ArtistID)
+	t.AlbumID = namespace(profileID, t.AlbumID)
+	t.ArtworkRef = namespace(profileID, t.ArtworkRef)
+}
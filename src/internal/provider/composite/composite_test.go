@@ -0,0 +1,130 @@
+package composite
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// memProvider pages over a fixed artist list and serves tracks by ID.
+type memProvider struct {
+	provider.Provider
+	id      string
+	artists []provider.Artist
+	tracks  map[string]provider.Track
+	err     error
+}
+
+func (f *memProvider) ID() string                          { return f.id }
+func (f *memProvider) Capabilities() provider.Capabilities { return provider.Capabilities{} }
+
+func (f *memProvider) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	if f.err != nil {
+		return provider.Page[provider.Artist]{}, f.err
+	}
+	off, _ := strconv.Atoi(req.Cursor)
+	end := min(off+req.PageSize, len(f.artists))
+	next := ""
+	if end < len(f.artists) {
+		next = strconv.Itoa(end)
+	}
+	return provider.Page[provider.Artist]{Items: slices.Clone(f.artists[off:end]), NextCursor: next, TotalHint: len(f.artists)}, nil
+}
+
+func (f *memProvider) GetTrack(ctx context.Context, id string) (provider.Track, error) {
+	t, ok := f.tracks[id]
+	if !ok {
+		return provider.Track{}, provider.ErrNotFound
+	}
+	return t, nil
+}
+
+func (f *memProvider) GetStream(ctx context.Context, id string) (provider.StreamInfo, error) {
+	return provider.StreamInfo{URL: f.id + "://" + id}, nil
+}
+
+func newTestComposite() (*Provider, *memProvider, *memProvider) {
+	home := &memProvider{id: "filesystem", artists: []provider.Artist{{ID: "1", Name: "Abba"}, {ID: "2", Name: "Queen"}, {ID: "3", Name: "Yes"}}}
+	server := &memProvider{
+		id:      "melodee",
+		artists: []provider.Artist{{ID: "a", Name: "Blur"}, {ID: "z", Name: "Zappa"}},
+		tracks:  map[string]provider.Track{"t1": {ID: "t1", AlbumID: "al1", Title: "Song 2"}},
+	}
+	p := New([]Member{
+		{ProfileID: "home", Name: "Home", Provider: home},
+		{ProfileID: "server", Name: "Server", Provider: server},
+	})
+	return p, home, server
+}
+
+func TestListArtistsMergesPages(t *testing.T) {
+	p, _, _ := newTestComposite()
+	ctx := context.Background()
+
+	// Pages hold one order across members however unevenly they contribute
+	var pages [][]string
+	cursor := ""
+	for {
+		page, err := p.ListArtists(ctx, provider.ListReq{PageSize: 2, Cursor: cursor})
+		if err != nil {
+			t.Fatalf("list artists: %v", err)
+		}
+		if cursor == "" && page.TotalHint != 5 {
+			t.Errorf("expected total 5, got %d", page.TotalHint)
+		}
+		var ids []string
+		for _, a := range page.Items {
+			ids = append(ids, a.ID)
+		}
+		pages = append(pages, ids)
+		if cursor = page.NextCursor; cursor == "" || len(pages) > 5 {
+			break
+		}
+	}
+	want := [][]string{{"home:1", "server:a"}, {"home:2", "home:3"}, {"server:z"}}
+	if !slices.EqualFunc(pages, want, slices.Equal) {
+		t.Errorf("expected pages %v, got %v", want, pages)
+	}
+}
+
+func TestListArtistsSkipsFailingMember(t *testing.T) {
+	p, _, server := newTestComposite()
+	server.err = provider.ErrTemporary
+
+	page, err := p.ListArtists(context.Background(), provider.ListReq{PageSize: 10})
+	if err != nil {
+		t.Fatalf("expected partial results, got %v", err)
+	}
+	if len(page.Items) != 3 {
+		t.Errorf("expected the home artists, got %+v", page.Items)
+	}
+}
+
+func TestRoutingByNamespace(t *testing.T) {
+	p, _, _ := newTestComposite()
+	ctx := context.Background()
+
+	track, err := p.GetTrack(ctx, "server:t1")
+	if err != nil {
+		t.Fatalf("get track: %v", err)
+	}
+	if track.ID != "server:t1" || track.AlbumID != "server:al1" {
+		t.Errorf("expected namespaced IDs, got %+v", track)
+	}
+	stream, err := p.GetStream(ctx, track.ID)
+	if err != nil || stream.URL != "melodee://t1" {
+		t.Errorf("expected stream from the owning member, got %+v %v", stream, err)
+	}
+
+	mem, local, ok := p.Origin(track.ID)
+	if !ok || mem.Name != "Server" || local != "t1" {
+		t.Errorf("unexpected origin %+v %q %v", mem, local, ok)
+	}
+	if _, err := p.GetTrack(ctx, "elsewhere:t1"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected not found for unknown profile, got %v", err)
+	}
+}
@@ -1,6 +1,10 @@
 package provider
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	ErrNotSupported  = errors.New("provider: not supported")
@@ -18,3 +22,29 @@ func IsUnauthorized(err error) bool { return errors.Is(err, ErrUnauthorized) }
 func IsOffline(err error) bool      { return errors.Is(err, ErrOffline) }
 func IsRateLimited(err error) bool  { return errors.Is(err, ErrRateLimited) }
 func IsTemporary(err error) bool    { return errors.Is(err, ErrTemporary) }
+
+// RetryError wraps ErrRateLimited or ErrTemporary with how long the server
+// asked callers to wait before trying again.
+type RetryError struct {
+	Err   error
+	After time.Duration
+}
+
+func (e *RetryError) Error() string {
+	if e.After <= 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%v (retry in %s)", e.Err, e.After.Round(time.Second))
+}
+
+func (e *RetryError) Unwrap() error { return e.Err }
+
+// RetryAfter returns how long err asks callers to wait, or 0 when it does
+// not say.
+func RetryAfter(err error) time.Duration {
+	var re *RetryError
+	if errors.As(err, &re) {
+		return re.After
+	}
+	return 0
+}
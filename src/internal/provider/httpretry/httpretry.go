@@ -0,0 +1,224 @@
+// Package httpretry is an http.RoundTripper for remote providers that
+// retries transient failures with jittered backoff, waits out 429 responses
+// as the server's Retry-After asks, and stops calling a server that keeps
+// failing until it has had time to recover.
+package httpretry
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// Options tune a Transport. Zero fields take the defaults.
+type Options struct {
+	// MaxRetries is how many times a request is retried after the first
+	// attempt. Default 3.
+	MaxRetries int
+	// BaseDelay is the backoff before the first retry; it doubles with each
+	// attempt. Default 250ms.
+	BaseDelay time.Duration
+	// MaxDelay caps the backoff and the Retry-After wait the transport will
+	// sit out itself. A longer Retry-After returns the 429 to the caller.
+	// Default 5s.
+	MaxDelay time.Duration
+	// BreakAfter is how many failed requests in a row open the circuit.
+	// Default 5.
+	BreakAfter int
+	// Cooldown is how long an open circuit fails requests without calling
+	// the server before letting one through to probe it. Default 30s.
+	Cooldown time.Duration
+}
+
+func (o Options) withDefaults() Options {
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 3
+	}
+	if o.BaseDelay <= 0 {
+		o.BaseDelay = 250 * time.Millisecond
+	}
+	if o.MaxDelay <= 0 {
+		o.MaxDelay = 5 * time.Second
+	}
+	if o.BreakAfter <= 0 {
+		o.BreakAfter = 5
+	}
+	if o.Cooldown <= 0 {
+		o.Cooldown = 30 * time.Second
+	}
+	return o
+}
+
+// Transport retries idempotent requests on network errors and 5xx
+// responses and opens a circuit breaker after repeated failures. It is safe
+// for concurrent use.
+type Transport struct {
+	next http.RoundTripper
+	opts Options
+
+	now   func() time.Time
+	sleep func(ctx context.Context, d time.Duration) error
+
+	mu        sync.Mutex
+	failures  int
+	openUntil time.Time
+}
+
+// New wraps next, or http.DefaultTransport when next is nil.
+func New(next http.RoundTripper, opts Options) *Transport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &Transport{next: next, opts: opts.withDefaults(), now: time.Now, sleep: sleep}
+}
+
+// RoundTrip implements http.RoundTripper. While the circuit is open it
+// returns a *provider.RetryError wrapping provider.ErrTemporary without
+// calling the server.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if wait := t.open(); wait > 0 {
+		return nil, &provider.RetryError{Err: errCircuitOpen, After: wait}
+	}
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		resp, err := t.next.RoundTrip(req)
+		failed := transient(req, resp, err)
+		t.record(failed)
+
+		var wait time.Duration
+		switch {
+		case err == nil && resp.StatusCode == http.StatusTooManyRequests:
+			wait = RetryAfter(resp.Header)
+			if wait == 0 {
+				wait = t.backoff(attempt)
+			}
+			if wait > t.opts.MaxDelay {
+				return resp, nil
+			}
+		case failed:
+			wait = t.backoff(attempt)
+		default:
+			return resp, err
+		}
+		if attempt >= t.opts.MaxRetries || !replayable(req) || t.open() > 0 {
+			return resp, err
+		}
+		if resp != nil {
+			io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+			resp.Body.Close()
+		}
+		if err := t.sleep(req.Context(), wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// errCircuitOpen is what requests fail with while the breaker is open.
+var errCircuitOpen = fmt.Errorf("%w: server failing repeatedly, requests paused", provider.ErrTemporary)
+
+// open returns how much longer the circuit stays open, or 0 when requests
+// may go through. Once the cooldown passes one request is let through; if
+// it fails too the circuit opens again.
+func (t *Transport) open() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.openUntil.IsZero() {
+		return 0
+	}
+	if wait := t.openUntil.Sub(t.now()); wait > 0 {
+		return wait
+	}
+	t.openUntil = time.Time{}
+	t.failures = t.opts.BreakAfter - 1
+	return 0
+}
+
+// record counts a request towards the breaker, opening it after BreakAfter
+// failures in a row.
+func (t *Transport) record(failed bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !failed {
+		t.failures = 0
+		return
+	}
+	t.failures++
+	if t.failures >= t.opts.BreakAfter {
+		t.openUntil = t.now().Add(t.opts.Cooldown)
+	}
+}
+
+// backoff is the full-jitter delay before retry attempt+1.
+func (t *Transport) backoff(attempt int) time.Duration {
+	d := min(t.opts.BaseDelay<<attempt, t.opts.MaxDelay)
+	return time.Duration(rand.Int64N(int64(d)) + 1)
+}
+
+// transient reports whether a response or error is worth retrying: a
+// network error other than the caller giving up or a rejected certificate,
+// or a 5xx the server may recover from.
+func transient(req *http.Request, resp *http.Response, err error) bool {
+	if err != nil {
+		var certErr *tls.CertificateVerificationError
+		return req.Context().Err() == nil && !errors.As(err, &certErr)
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// replayable reports whether req can safely be sent again: its method is
+// idempotent and any body can be read a second time.
+func replayable(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	}
+	return false
+}
+
+// RetryAfter parses a Retry-After header given in seconds or as an HTTP
+// date. It returns 0 when the header is missing or unparsable.
+func RetryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return max(time.Duration(secs)*time.Second, 0)
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0)
+	}
+	return 0
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,162 @@
+package httpretry
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// newTestTransport returns a transport that records its sleeps instead of
+// waiting and runs on a clock the test moves.
+func newTestTransport(opts Options) (*Transport, *[]time.Duration, *time.Time) {
+	now := time.Unix(1700000000, 0)
+	var slept []time.Duration
+	tr := New(nil, opts)
+	tr.now = func() time.Time { return now }
+	tr.sleep = func(_ context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	return tr, &slept, &now
+}
+
+func TestRetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	tr, slept, _ := newTestTransport(Options{})
+	resp, err := (&http.Client{Transport: tr}).Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 3 || len(*slept) != 2 {
+		t.Fatalf("expected success on the third call after two backoffs, got %d after %d calls, slept %v", resp.StatusCode, calls.Load(), *slept)
+	}
+	for i, d := range *slept {
+		if d <= 0 || d > 250*time.Millisecond<<i {
+			t.Errorf("backoff %d = %s, want within (0, %s]", i, d, 250*time.Millisecond<<i)
+		}
+	}
+}
+
+func TestHonorsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if r.URL.Path == "/slow" {
+			w.Header().Set("Retry-After", "120")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}))
+	defer srv.Close()
+
+	tr, slept, _ := newTestTransport(Options{})
+	client := &http.Client{Transport: tr}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || len(*slept) != 1 || (*slept)[0] != 2*time.Second {
+		t.Fatalf("expected one 2s wait then success, got %d, slept %v", resp.StatusCode, *slept)
+	}
+
+	// A wait longer than MaxDelay is left to the caller
+	resp, err = client.Get(srv.URL + "/slow")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || RetryAfter(resp.Header) != 2*time.Minute || len(*slept) != 1 {
+		t.Fatalf("expected the 429 returned without waiting, got %d, slept %v", resp.StatusCode, *slept)
+	}
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer srv.Close()
+
+	tr, _, now := newTestTransport(Options{MaxRetries: 1, BreakAfter: 3, Cooldown: time.Minute})
+	client := &http.Client{Transport: tr}
+	for range 2 {
+		if resp, err := client.Get(srv.URL); err == nil {
+			resp.Body.Close()
+		}
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("expected the breaker to stop retries once open, got %d calls", calls.Load())
+	}
+
+	_, err := client.Get(srv.URL)
+	if !provider.IsTemporary(err) || provider.RetryAfter(err) != time.Minute || calls.Load() != 3 {
+		t.Fatalf("expected an open circuit to fail without calling the server, got %v after %d calls", err, calls.Load())
+	}
+
+	// After the cooldown one probe goes through and closes the circuit
+	*now = now.Add(time.Minute)
+	healthy.Store(true)
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatalf("expected the probe to go through, got %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls.Load() != 4 {
+		t.Fatalf("expected the probe to succeed, got %d after %d calls", resp.StatusCode, calls.Load())
+	}
+}
+
+func TestDoesNotRetryPost(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	tr, _, _ := newTestTransport(Options{})
+	resp, err := (&http.Client{Transport: tr}).Post(srv.URL, "application/json", strings.NewReader("{}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if calls.Load() != 1 {
+		t.Errorf("expected a POST to be sent once, got %d calls", calls.Load())
+	}
+}
+
+func TestRetryAfterDate(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", time.Now().Add(90*time.Second).UTC().Format(http.TimeFormat))
+	if d := RetryAfter(h); d < 80*time.Second || d > 90*time.Second {
+		t.Errorf("RetryAfter(date) = %s", d)
+	}
+	h.Set("Retry-After", "soon")
+	if d := RetryAfter(h); d != 0 {
+		t.Errorf("RetryAfter(garbage) = %s", d)
+	}
+}
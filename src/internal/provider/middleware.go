@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+)
+
+// ErrNotModified is returned by a provider when a conditional request
+// (see Validators) found the cached copy still current.
+var ErrNotModified = errors.New("provider: not modified")
+
+// Validators carry HTTP cache validators between a caching layer and a
+// remote provider. A provider that supports conditional requests sends them
+// as If-None-Match / If-Modified-Since, returns ErrNotModified on a 304, and
+// otherwise stores the response's validators back into the struct.
+type Validators struct {
+	ETag         string
+	LastModified string
+}
+
+type validatorsKey struct{}
+
+// validatorsSlot holds validators until one request claims them.
+type validatorsSlot struct {
+	v       *Validators
+	claimed atomic.Bool
+}
+
+// WithValidators attaches v to ctx for the next provider call. Only one
+// HTTP request of that call may use them (see ClaimValidators), so a call
+// that makes several requests can't apply one response's validators to
+// another.
+func WithValidators(ctx context.Context, v *Validators) context.Context {
+	return context.WithValue(ctx, validatorsKey{}, &validatorsSlot{v: v})
+}
+
+// ClaimValidators returns the validators attached to ctx to the first
+// request that asks for them, and nil to the rest or when there are none.
+func ClaimValidators(ctx context.Context) *Validators {
+	slot, _ := ctx.Value(validatorsKey{}).(*validatorsSlot)
+	if slot == nil || slot.claimed.Swap(true) {
+		return nil
+	}
+	return slot.v
+}
+
+// As returns the first provider in p's decorator chain, starting with p
+// itself, that implements T.
+func As[T any](p Provider) (T, bool) {
+	for {
+		if t, ok := p.(T); ok {
+			return t, true
+		}
+		u, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		p = u.Unwrap()
+	}
+}
+
+// Unwrap returns the innermost provider beneath any decorators that
+// implement Unwrap() Provider.
+func Unwrap(p Provider) Provider {
+	for {
+		u, ok := p.(interface{ Unwrap() Provider })
+		if !ok {
+			return p
+		}
+		p = u.Unwrap()
+	}
+}
+
+// Close releases what p's decorator chain holds open, such as the response
+// cache's database. It is a no-op for providers that hold nothing.
+func Close(p Provider) error {
+	if c, ok := As[io.Closer](p); ok {
+		return c.Close()
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package provider
+
+import (
+	"context"
+	"time"
+)
+
+// Observer is told the duration and outcome of each provider call. op is
+// the method name, e.g. "ListAlbums".
+type Observer func(op string, d time.Duration, err error)
+
+// Observed wraps a provider and reports every library, stream, lyrics and
+// artwork call to an Observer. Initialize is not reported since a first
+// scan can take minutes and would swamp request latencies.
+type Observed struct {
+	Provider
+	observe Observer
+}
+
+// Observe wraps p so each request is reported to fn.
+func Observe(p Provider, fn Observer) *Observed {
+	return &Observed{Provider: p, observe: fn}
+}
+
+// Unwrap returns the wrapped provider.
+func (o *Observed) Unwrap() Provider { return o.Provider }
+
+func (o *Observed) done(op string, start time.Time, err error) {
+	o.observe(op, time.Since(start), err)
+}
+
+func (o *Observed) Health(ctx context.Context) (bool, string) {
+	start := time.Now()
+	ok, details := o.Provider.Health(ctx)
+	o.done("Health", start, nil)
+	return ok, details
+}
+
+func (o *Observed) ListArtists(ctx context.Context, req ListReq) (Page[Artist], error) {
+	start := time.Now()
+	page, err := o.Provider.ListArtists(ctx, req)
+	o.done("ListArtists", start, err)
+	return page, err
+}
+
+func (o *Observed) GetArtist(ctx context.Context, id string) (Artist, error) {
+	start := time.Now()
+	a, err := o.Provider.GetArtist(ctx, id)
+	o.done("GetArtist", start, err)
+	return a, err
+}
+
+func (o *Observed) ListAlbums(ctx context.Context, artistID string, req ListReq) (Page[Album], error) {
+	start := time.Now()
+	page, err := o.Provider.ListAlbums(ctx, artistID, req)
+	o.done("ListAlbums", start, err)
+	return page, err
+}
+
+func (o *Observed) GetAlbum(ctx context.Context, id string) (Album, error) {
+	start := time.Now()
+	a, err := o.Provider.GetAlbum(ctx, id)
+	o.done("GetAlbum", start, err)
+	return a, err
+}
+
+func (o *Observed) ListTracks(ctx context.Context, albumID, artistID, playlistID string, req ListReq) (Page[Track], error) {
+	start := time.Now()
+	page, err := o.Provider.ListTracks(ctx, albumID, artistID, playlistID, req)
+	o.done("ListTracks", start, err)
+	return page, err
+}
+
+func (o *Observed) GetTrack(ctx context.Context, id string) (Track, error) {
+	start := time.Now()
+	t, err := o.Provider.GetTrack(ctx, id)
+	o.done("GetTrack", start, err)
+	return t, err
+}
+
+func (o *Observed) Search(ctx context.Context, q string, req ListReq) (SearchResults, error) {
+	start := time.Now()
+	res, err := o.Provider.Search(ctx, q, req)
+	o.done("Search", start, err)
+	return res, err
+}
+
+func (o *Observed) ListPlaylists(ctx context.Context, req ListReq) (Page[Playlist], error) {
+	start := time.Now()
+	page, err := o.Provider.ListPlaylists(ctx, req)
+	o.done("ListPlaylists", start, err)
+	return page, err
+}
+
+func (o *Observed) GetPlaylist(ctx context.Context, id string) (Playlist, error) {
+	start := time.Now()
+	pl, err := o.Provider.GetPlaylist(ctx, id)
+	o.done("GetPlaylist", start, err)
+	return pl, err
+}
+
+func (o *Observed) GetStream(ctx context.Context, trackID string) (StreamInfo, error) {
+	start := time.Now()
+	s, err := o.Provider.GetStream(ctx, trackID)
+	o.done("GetStream", start, err)
+	return s, err
+}
+
+func (o *Observed) GetLyrics(ctx context.Context, trackID string) (Lyrics, error) {
+	start := time.Now()
+	l, err := o.Provider.GetLyrics(ctx, trackID)
+	o.done("GetLyrics", start, err)
+	return l, err
+}
+
+func (o *Observed) GetArtwork(ctx context.Context, ref string, sizePx int) (Artwork, error) {
+	start := time.Now()
+	a, err := o.Provider.GetArtwork(ctx, ref, sizePx)
+	o.done("GetArtwork", start, err)
+	return a, err
+}
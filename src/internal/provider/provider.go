@@ -1,6 +1,9 @@
 package provider
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Capability string
 
@@ -16,8 +19,21 @@ type ListReq struct {
 	Cursor   string
 	PageSize int
 	Sort     string
+	// Years limits album and track lists to a range of release years.
+	Years YearRange
+	// SearchKind limits Search to one kind of result, SearchTracks,
+	// SearchAlbums or SearchArtists; "" searches every kind.
+	SearchKind string
 }
 
+// Kinds of Search result, as in ListReq.SearchKind and typed search
+// cursors ("tracks:200").
+const (
+	SearchTracks  = "tracks"
+	SearchAlbums  = "albums"
+	SearchArtists = "artists"
+)
+
 type Page[T any] struct {
 	Items      []T
 	NextCursor string
@@ -27,6 +43,24 @@ type Page[T any] struct {
 type StreamInfo struct {
 	URL     string
 	Headers map[string]string
+
+	// Timeout is how long the player waits on a stalled stream; 0 keeps
+	// the player's default.
+	Timeout time.Duration
+	// CAFile and InsecureSkipVerify carry the provider's TLS settings to
+	// the player, for servers with a private CA or self-signed certificate.
+	CAFile             string
+	InsecureSkipVerify bool
+
+	// Gain is the ReplayGain measured for a file without gain tags, which
+	// the player applies in its place; nil for tagged or unmeasured files.
+	Gain *Gain
+}
+
+// Gain is a track's measured ReplayGain, relative to -18 LUFS.
+type Gain struct {
+	TrackDB float64
+	AlbumDB float64
 }
 
 type Provider interface {
@@ -57,6 +91,98 @@ type Provider interface {
 	GetArtwork(ctx context.Context, ref string, sizePx int) (Artwork, error)
 }
 
+// ArtistIndexer is implemented by providers that can locate a name in the
+// artist list without paging through it.
+type ArtistIndexer interface {
+	// ArtistOffset returns how many artists sort before prefix in the default
+	// name order.
+	ArtistOffset(ctx context.Context, prefix string) (int, error)
+}
+
+// TrackResolver is implemented by providers with a local file index that
+// can match playlist file entries to tracks.
+type TrackResolver interface {
+	// ResolveTrack finds a track by file path, or failing that by title and
+	// artist. It returns ErrNotFound when nothing matches.
+	ResolveTrack(ctx context.Context, path, title, artist string) (Track, error)
+}
+
+// DropFolderWatcher is implemented by providers with a drop folder: a
+// directory whose new audio files are indexed and queued as they arrive.
+type DropFolderWatcher interface {
+	// DropFolder returns the watched directory, or "" when none is set.
+	DropFolder() string
+	// TakeDropped indexes the audio files that have finished arriving in
+	// the drop folder since the last call and returns them in name order.
+	TakeDropped(ctx context.Context) ([]Track, error)
+}
+
+// FileDeleter is implemented by providers whose tracks are files they can
+// delete, such as a local library.
+type FileDeleter interface {
+	// DeleteTrack moves a track's file to the OS trash, or removes it for
+	// good when permanent is set, and drops the track from the index.
+	DeleteTrack(ctx context.Context, trackID string, permanent bool) error
+}
+
+// SimilarArtistFinder is implemented by providers that know which artists
+// in their library sound alike.
+type SimilarArtistFinder interface {
+	// SimilarArtists returns up to limit library artists similar to the
+	// artist, closest first.
+	SimilarArtists(ctx context.Context, artistID string, limit int) ([]Artist, error)
+}
+
+// RandomPicker is implemented by providers that can pick a random album or
+// artist from their own index, without the library being listed. Both
+// return ErrNotFound when the library is empty.
+type RandomPicker interface {
+	RandomAlbum(ctx context.Context) (Album, error)
+	// RandomArtist picks among the artists with at least one album.
+	RandomArtist(ctx context.Context) (Artist, error)
+}
+
+// RandomTrackLister is implemented by providers that can sample random
+// tracks themselves, such as with a database query or a server endpoint.
+// Use RandomTracks, which falls back to shuffling a track list for the
+// others.
+type RandomTrackLister interface {
+	// ListRandomTracks returns up to n tracks picked at random among those
+	// passing filter.
+	ListRandomTracks(ctx context.Context, n int, filter RandomFilter) ([]Track, error)
+}
+
+// ComposerBrowser is implemented by providers that index composer and work
+// tags, backing the Library's Classical mode. Composers list as artists
+// with their work count in AlbumCount, works as albums with their movement
+// count in TrackCount, and ListTracks takes a work ID as the album ID to
+// list its movements in order, ignoring the artist ID (the composer's).
+type ComposerBrowser interface {
+	ListComposers(ctx context.Context, req ListReq) (Page[Artist], error)
+	// ListWorks lists the works of a composer, or of every composer when
+	// composerID is empty.
+	ListWorks(ctx context.Context, composerID string, req ListReq) (Page[Album], error)
+}
+
+// OfflineServer is implemented by decorators that keep responses, such as
+// the response cache. While offline, saved responses are served however old
+// they are and anything not saved fails with ErrOffline instead of waiting
+// on an unreachable server. Find it with As, since it sits above the
+// provider Unwrap returns.
+type OfflineServer interface {
+	SetOffline(offline bool)
+	Offline() bool
+}
+
+// ResponseCache is implemented by decorators that keep responses on disk,
+// such as the response cache. Find it with As.
+type ResponseCache interface {
+	// Size returns the bytes the cache takes on disk.
+	Size() (int64, error)
+	// Clear drops the profile's cached responses.
+	Clear(ctx context.Context) error
+}
+
 type SearchResults struct {
 	Tracks    Page[Track]
 	Albums    Page[Album]
@@ -80,6 +206,9 @@ type Album struct {
 	Year       int
 	TrackCount int
 	ArtworkRef string
+	// Type is the release type the server reports: "album", "single",
+	// "ep", "compilation", "live" and so on. Empty when it doesn't say.
+	Type string
 }
 
 type Track struct {
@@ -90,6 +219,7 @@ type Track struct {
 	AlbumID     string
 	AlbumTitle  string
 	Year        int
+	Genre       string
 	DurationMs  int
 	TrackNo     int
 	DiscNo      int
@@ -97,6 +227,27 @@ type Track struct {
 	BitrateKbps int
 	ArtworkRef  string
 	StreamURL   string
+
+	// Extended metadata. Providers fill these in from GetTrack where
+	// available; list endpoints may leave them zero.
+	SampleRateHz    int
+	BitDepth        int
+	Channels        int
+	FilePath        string
+	FileSize        int64
+	ReplayGainTrack string
+	ReplayGainAlbum string
+	Tags            map[string]string
+	ModifiedAt      time.Time
+	IndexedAt       time.Time
+
+	// Classical metadata from composer, work and movement tags. Providers
+	// without movement tags may split Work and Movement from the title
+	// with SplitWork.
+	Composer   string
+	Work       string
+	Movement   string
+	MovementNo int
 }
 
 type Playlist struct {
@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// StreamFormats are the transcode formats a StreamQuality can ask for.
+var StreamFormats = []string{"mp3", "opus", "aac", "ogg"}
+
+// StreamQuality asks a remote provider to transcode streams to Format at
+// BitrateKbps. The zero value streams the original file. Providers that
+// can't transcode ignore it.
+type StreamQuality struct {
+	Format      string
+	BitrateKbps int
+}
+
+// ParseStreamQuality parses "raw" (or "") and "<format>-<kbps>" such as
+// "mp3-320" or "opus-128".
+func ParseStreamQuality(s string) (StreamQuality, error) {
+	if s == "" || s == "raw" {
+		return StreamQuality{}, nil
+	}
+	format, kbps, ok := strings.Cut(strings.ToLower(s), "-")
+	if !ok || !slices.Contains(StreamFormats, format) {
+		return StreamQuality{}, fmt.Errorf("stream quality %q: want raw or <%s>-<kbps>", s, strings.Join(StreamFormats, "|"))
+	}
+	n, err := strconv.Atoi(kbps)
+	if err != nil || n < 8 || n > 640 {
+		return StreamQuality{}, fmt.Errorf("stream quality %q: bitrate must be 8-640 kbps", s)
+	}
+	return StreamQuality{Format: format, BitrateKbps: n}, nil
+}
+
+// IsRaw reports whether q streams the original file.
+func (q StreamQuality) IsRaw() bool { return q.Format == "" }
+
+func (q StreamQuality) String() string {
+	if q.IsRaw() {
+		return "raw"
+	}
+	return fmt.Sprintf("%s-%d", q.Format, q.BitrateKbps)
+}
+
+type streamQualityKey struct{}
+
+// WithStreamQuality attaches q to ctx for the next GetStream call.
+func WithStreamQuality(ctx context.Context, q StreamQuality) context.Context {
+	return context.WithValue(ctx, streamQualityKey{}, q)
+}
+
+// StreamQualityFrom returns the quality attached to ctx, or the zero
+// (raw) quality.
+func StreamQualityFrom(ctx context.Context) StreamQuality {
+	q, _ := ctx.Value(streamQualityKey{}).(StreamQuality)
+	return q
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query is a search with qualifiers, as typed in the search box:
+//
+//	artist:floyd album:"the wall" year:1979 genre:rock dur:>5m
+//
+// Words without a known qualifier make up Text, which providers search the
+// way they search a plain query. Qualifier values are matched as
+// substrings, ignoring case; quotes keep a value with spaces together.
+type Query struct {
+	Text     string
+	Artist   string
+	Album    string
+	Title    string
+	Genre    string
+	Years    YearRange
+	Duration DurationRange
+}
+
+// DurationRange limits tracks to lengths from Min to Max, inclusive. A zero
+// bound is open.
+type DurationRange struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// IsZero reports whether the range lets every length through.
+func (r DurationRange) IsZero() bool { return r.Min == 0 && r.Max == 0 }
+
+// Contains reports whether a track of ms milliseconds is in the range.
+// Tracks of unknown length (0) are only in the zero range.
+func (r DurationRange) Contains(ms int) bool {
+	if r.IsZero() {
+		return true
+	}
+	d := time.Duration(ms) * time.Millisecond
+	return ms > 0 && (r.Min == 0 || d >= r.Min) && (r.Max == 0 || d <= r.Max)
+}
+
+// IsPlain reports whether the query has no qualifiers.
+func (q Query) IsPlain() bool {
+	return q.Artist == "" && q.Album == "" && q.Title == "" && q.Genre == "" && q.Years.IsZero() && q.Duration.IsZero()
+}
+
+// Searches reports whether results of kind (SearchTracks, SearchAlbums or
+// SearchArtists) can match the query's qualifiers. Albums have no title or
+// length of their own, and artists only a name.
+func (q Query) Searches(kind string) bool {
+	switch kind {
+	case SearchAlbums:
+		return q.Title == "" && q.Duration.IsZero()
+	case SearchArtists:
+		return q.Album == "" && q.Title == "" && q.Genre == "" && q.Years.IsZero() && q.Duration.IsZero()
+	}
+	return true
+}
+
+// MatchesTrack reports whether t passes the qualifiers. Text is left to
+// the provider.
+func (q Query) MatchesTrack(t Track) bool {
+	return containsFold(t.ArtistName, q.Artist) && containsFold(t.AlbumTitle, q.Album) &&
+		containsFold(t.Title, q.Title) && containsFold(t.Genre, q.Genre) &&
+		q.Years.Contains(t.Year) && q.Duration.Contains(t.DurationMs)
+}
+
+// MatchesAlbum reports whether a passes the qualifiers an album carries;
+// albums don't carry a genre, so that's left to the provider.
+func (q Query) MatchesAlbum(a Album) bool {
+	return q.Searches(SearchAlbums) && containsFold(a.ArtistName, q.Artist) &&
+		containsFold(a.Title, q.Album) && q.Years.Contains(a.Year)
+}
+
+// MatchesArtist reports whether a passes the qualifiers.
+func (q Query) MatchesArtist(a Artist) bool {
+	return q.Searches(SearchArtists) && containsFold(a.Name, q.Artist)
+}
+
+func containsFold(s, sub string) bool {
+	return sub == "" || strings.Contains(strings.ToLower(s), strings.ToLower(sub))
+}
+
+// ParseQuery reads a search box query. Words before a colon that aren't a
+// known qualifier ("re:", "10:15") are left in Text.
+//
+//	artist:, album:, title:, genre:   a substring, quoted if it has spaces
+//	year:                             1979, 1970s, 1975-1985, 1990- or -1969
+//	dur: (or duration:)               >5m, <3:30 or 3m-5m
+func ParseQuery(s string) (Query, error) {
+	var q Query
+	var text []string
+	for _, word := range splitQuery(s) {
+		key, value, ok := strings.Cut(word, ":")
+		if !ok || value == "" {
+			text = append(text, word)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "artist":
+			q.Artist = value
+		case "album":
+			q.Album = value
+		case "title":
+			q.Title = value
+		case "genre":
+			q.Genre = value
+		case "year":
+			r, err := ParseYearRange(value)
+			if err != nil {
+				return Query{}, fmt.Errorf("%s: %w", word, err)
+			}
+			q.Years = r
+		case "dur", "duration":
+			r, err := parseDurationRange(value)
+			if err != nil {
+				return Query{}, fmt.Errorf("%s: %w", word, err)
+			}
+			q.Duration = r
+		default:
+			text = append(text, word)
+		}
+	}
+	q.Text = strings.Join(text, " ")
+	return q, nil
+}
+
+// splitQuery splits s into words at spaces outside double quotes, dropping
+// the quotes.
+func splitQuery(s string) []string {
+	var words []string
+	var b strings.Builder
+	quoted, inWord := false, false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			quoted, inWord = !quoted, true
+		case !quoted && (r == ' ' || r == '\t'):
+			if inWord {
+				words = append(words, b.String())
+				b.Reset()
+			}
+			inWord = false
+		default:
+			b.WriteRune(r)
+			inWord = true
+		}
+	}
+	if inWord {
+		words = append(words, b.String())
+	}
+	return words
+}
+
+// parseDurationRange reads ">5m", "<3:30" or "3m-5m". Lengths are Go
+// durations ("4m30s"), m:ss, or a bare number of minutes.
+func parseDurationRange(s string) (DurationRange, error) {
+	switch {
+	case strings.HasPrefix(s, ">"):
+		d, err := parseTrackLength(strings.TrimPrefix(strings.TrimPrefix(s, ">"), "="))
+		return DurationRange{Min: d}, err
+	case strings.HasPrefix(s, "<"):
+		d, err := parseTrackLength(strings.TrimPrefix(strings.TrimPrefix(s, "<"), "="))
+		return DurationRange{Max: d}, err
+	}
+	from, to, ok := strings.Cut(s, "-")
+	if !ok {
+		return DurationRange{}, fmt.Errorf("invalid length %q (use e.g. >5m, <3:30 or 3m-5m)", s)
+	}
+	lo, err := parseTrackLength(from)
+	if err != nil {
+		return DurationRange{}, err
+	}
+	hi, err := parseTrackLength(to)
+	if err != nil {
+		return DurationRange{}, err
+	}
+	if hi < lo {
+		return DurationRange{}, fmt.Errorf("invalid length range %q", s)
+	}
+	return DurationRange{Min: lo, Max: hi}, nil
+}
+
+func parseTrackLength(s string) (time.Duration, error) {
+	if n, err := strconv.ParseFloat(s, 64); err == nil && n > 0 {
+		return time.Duration(n * float64(time.Minute)), nil
+	}
+	if m, sec, ok := strings.Cut(s, ":"); ok {
+		mins, err1 := strconv.Atoi(m)
+		secs, err2 := strconv.Atoi(sec)
+		if err1 == nil && err2 == nil && mins >= 0 && secs >= 0 && secs < 60 && len(sec) == 2 {
+			return time.Duration(mins)*time.Minute + time.Duration(secs)*time.Second, nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, nil
+	}
+	return 0, fmt.Errorf("invalid length %q", s)
+}
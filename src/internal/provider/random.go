@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"context"
+	"math/rand/v2"
+	"strings"
+)
+
+// randomPoolFactor is how many tracks, per track wanted, RandomTracks lists
+// to shuffle when the provider can't sample itself.
+const randomPoolFactor = 10
+
+// RandomFilter narrows random track picks. The zero value picks from the
+// whole library.
+type RandomFilter struct {
+	Genre string // matched ignoring case
+	Years YearRange
+}
+
+// IsZero reports whether the filter picks from everything.
+func (f RandomFilter) IsZero() bool { return f.Genre == "" && f.Years.IsZero() }
+
+// Matches reports whether t passes the filter.
+func (f RandomFilter) Matches(t Track) bool {
+	return (f.Genre == "" || strings.EqualFold(t.Genre, f.Genre)) && f.Years.Contains(t.Year)
+}
+
+func (f RandomFilter) String() string {
+	var parts []string
+	if f.Genre != "" {
+		parts = append(parts, f.Genre)
+	}
+	if !f.Years.IsZero() {
+		parts = append(parts, f.Years.String())
+	}
+	return strings.Join(parts, ", ")
+}
+
+// ParseRandomFilter reads a genre and a year range, in either order, such
+// as "jazz 1970s" or "1990-1995 Trip Hop". Words that don't parse as years
+// make up the genre; "" and "all" pick from everything.
+func ParseRandomFilter(s string) RandomFilter {
+	var f RandomFilter
+	var genre []string
+	for _, word := range strings.Fields(s) {
+		if r, err := ParseYearRange(word); err == nil && !r.IsZero() && f.Years.IsZero() {
+			f.Years = r
+			continue
+		}
+		genre = append(genre, word)
+	}
+	if g := strings.Join(genre, " "); !strings.EqualFold(g, "all") {
+		f.Genre = g
+	}
+	return f
+}
+
+// RandomTracks returns up to n tracks picked at random among those passing
+// filter. Providers implementing RandomTrackLister sample themselves; for
+// the rest a list of n*10 tracks is filtered and shuffled.
+func RandomTracks(ctx context.Context, p Provider, n int, filter RandomFilter) ([]Track, error) {
+	if rl, ok := As[RandomTrackLister](p); ok {
+		return rl.ListRandomTracks(ctx, n, filter)
+	}
+	page, err := p.ListTracks(ctx, "", "", "", ListReq{PageSize: n * randomPoolFactor, Years: filter.Years})
+	if err != nil {
+		return nil, err
+	}
+	tracks := make([]Track, 0, len(page.Items))
+	for _, t := range page.Items {
+		if filter.Matches(t) {
+			tracks = append(tracks, t)
+		}
+	}
+	rand.Shuffle(len(tracks), func(i, j int) {
+		tracks[i], tracks[j] = tracks[j], tracks[i]
+	})
+	return tracks[:min(n, len(tracks))], nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+)
+
+// Sort modes for ListReq.Sort. An empty Sort, or a mode that doesn't apply
+// to the type being listed, keeps the provider's default order.
+const (
+	SortName       = "name"        // artists by name
+	SortAlbumCount = "album_count" // artists with the most albums first
+	SortYear       = "year"        // albums oldest first
+	SortTitle      = "title"       // albums and tracks by title
+	SortRecent     = "recent"      // albums most recently added first
+	SortTrackNo    = "track"       // tracks by disc and track number
+	SortDuration   = "duration"    // tracks shortest first
+)
+
+// ArtistSorts, AlbumSorts and TrackSorts are the modes each list supports,
+// in the order the UI cycles through them.
+var (
+	ArtistSorts = []string{SortName, SortAlbumCount}
+	AlbumSorts  = []string{SortYear, SortTitle, SortRecent}
+	TrackSorts  = []string{SortTrackNo, SortTitle, SortDuration}
+)
+
+// CompareArtists returns how mode orders artists, or nil when it keeps
+// the provider's order.
+func CompareArtists(mode string) func(a, b Artist) int {
+	switch mode {
+	case SortName:
+		return func(a, b Artist) int {
+			return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+		}
+	case SortAlbumCount:
+		return func(a, b Artist) int {
+			return cmp.Compare(b.AlbumCount, a.AlbumCount)
+		}
+	}
+	return nil
+}
+
+// CompareAlbums returns how mode orders albums, or nil when it keeps the
+// provider's order. SortRecent needs an added date albums don't carry, so
+// it is left to the provider.
+func CompareAlbums(mode string) func(a, b Album) int {
+	switch mode {
+	case SortYear:
+		return func(a, b Album) int {
+			return cmp.Compare(a.Year, b.Year)
+		}
+	case SortTitle:
+		return func(a, b Album) int {
+			return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+	}
+	return nil
+}
+
+// CompareTracks returns how mode orders tracks, or nil when it keeps the
+// provider's order.
+func CompareTracks(mode string) func(a, b Track) int {
+	switch mode {
+	case SortTrackNo:
+		return func(a, b Track) int {
+			return cmp.Or(cmp.Compare(a.DiscNo, b.DiscNo), cmp.Compare(a.TrackNo, b.TrackNo))
+		}
+	case SortTitle:
+		return func(a, b Track) int {
+			return cmp.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+		}
+	case SortDuration:
+		return func(a, b Track) int {
+			return cmp.Compare(a.DurationMs, b.DurationMs)
+		}
+	}
+	return nil
+}
+
+// SortArtists orders a page of artists in place, for providers that can't
+// sort server-side.
+func SortArtists(items []Artist, mode string) {
+	if compare := CompareArtists(mode); compare != nil {
+		slices.SortStableFunc(items, compare)
+	}
+}
+
+// SortAlbums orders a page of albums in place.
+func SortAlbums(items []Album, mode string) {
+	if compare := CompareAlbums(mode); compare != nil {
+		slices.SortStableFunc(items, compare)
+	}
+}
+
+// SortTracks orders a page of tracks in place.
+func SortTracks(items []Track, mode string) {
+	if compare := CompareTracks(mode); compare != nil {
+		slices.SortStableFunc(items, compare)
+	}
+}
@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// YearRange limits album and track lists to releases from From to To,
+// inclusive. A zero bound is open; the zero YearRange lists everything.
+type YearRange struct {
+	From int
+	To   int
+}
+
+// Decade returns the range of the decade starting at year.
+func Decade(year int) YearRange {
+	year -= year % 10
+	return YearRange{From: year, To: year + 9}
+}
+
+// IsZero reports whether the range lists everything.
+func (r YearRange) IsZero() bool { return r.From == 0 && r.To == 0 }
+
+// Contains reports whether year is in the range. Unknown years (0) are only
+// in the zero range.
+func (r YearRange) Contains(year int) bool {
+	if r.IsZero() {
+		return true
+	}
+	return year > 0 && (r.From == 0 || year >= r.From) && (r.To == 0 || year <= r.To)
+}
+
+// String describes the range as "1970s", "1984", "1975-1985", "1990-" or
+// "-1969".
+func (r YearRange) String() string {
+	switch {
+	case r.IsZero():
+		return "all years"
+	case r.From == r.To:
+		return strconv.Itoa(r.From)
+	case r.From%10 == 0 && r.To == r.From+9:
+		return strconv.Itoa(r.From) + "s"
+	case r.To == 0:
+		return strconv.Itoa(r.From) + "-"
+	case r.From == 0:
+		return "-" + strconv.Itoa(r.To)
+	}
+	return fmt.Sprintf("%d-%d", r.From, r.To)
+}
+
+// ParseYearRange reads a range written as String does; "" and "all" are
+// the zero range.
+func ParseYearRange(s string) (YearRange, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" || s == "all" || s == "all years" {
+		return YearRange{}, nil
+	}
+	year := func(v string) (int, error) {
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1000 || n > 9999 {
+			return 0, fmt.Errorf("invalid year %q", v)
+		}
+		return n, nil
+	}
+	if d, ok := strings.CutSuffix(s, "s"); ok {
+		n, err := year(d)
+		if err != nil || n%10 != 0 {
+			return YearRange{}, fmt.Errorf("invalid decade %q (use e.g. 1970s)", s)
+		}
+		return Decade(n), nil
+	}
+	from, to, isRange := strings.Cut(s, "-")
+	lo, err := year(strings.TrimSpace(from))
+	if err != nil {
+		return YearRange{}, err
+	}
+	if !isRange {
+		return YearRange{From: lo, To: lo}, nil
+	}
+	hi, err := year(strings.TrimSpace(to))
+	if err != nil {
+		return YearRange{}, err
+	}
+	if lo == 0 && hi == 0 || hi != 0 && hi < lo {
+		return YearRange{}, fmt.Errorf("invalid year range %q", s)
+	}
+	return YearRange{From: lo, To: hi}, nil
+}
+
+// FilterAlbums drops albums outside r, for providers that can't filter
+// server-side.
+func FilterAlbums(items []Album, r YearRange) []Album {
+	if r.IsZero() {
+		return items
+	}
+	out := items[:0]
+	for _, a := range items {
+		if r.Contains(a.Year) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// FilterTracks drops tracks outside r, for providers that can't filter
+// server-side.
+func FilterTracks(items []Track, r YearRange) []Track {
+	if r.IsZero() {
+		return items
+	}
+	out := items[:0]
+	for _, t := range items {
+		if r.Contains(t.Year) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package filesystem
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"sync"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// replayGainReference is the loudness ReplayGain brings tracks to, in LUFS.
+const replayGainReference = -18.0
+
+// AnalyzeOptions tune Analyze. Zero fields take the defaults.
+type AnalyzeOptions struct {
+	// FFmpeg is the ffmpeg binary. Default "ffmpeg" from PATH.
+	FFmpeg string
+	// Workers is how many files are measured at once. Default NumCPU.
+	Workers int
+	// Progress, if set, is called after each file.
+	Progress func(done, total int, path string)
+}
+
+// AnalyzeResult counts what Analyze did.
+type AnalyzeResult struct {
+	Pending  int `json:"pending"`  // tracks that needed measuring
+	Analyzed int `json:"analyzed"` // measured and stored
+	Failed   int `json:"failed"`   // ffmpeg couldn't measure, e.g. silent or unreadable files
+}
+
+// Analyze measures the loudness of tracks without ReplayGain tags that
+// haven't been measured yet, with ffmpeg's EBU R128 loudnorm filter, and
+// stores it in the index. GetStream turns it into the gain the player
+// falls back on for untagged files. Each result is stored as it comes in,
+// so cancelling ctx keeps the work done and a later run picks up the rest.
+func (p *Provider) Analyze(ctx context.Context, opts AnalyzeOptions) (AnalyzeResult, error) {
+	var r AnalyzeResult
+	if opts.FFmpeg == "" {
+		opts.FFmpeg = "ffmpeg"
+	}
+	ffmpeg, err := exec.LookPath(opts.FFmpeg)
+	if err != nil {
+		return r, fmt.Errorf("loudness analysis needs ffmpeg: %w", err)
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = runtime.NumCPU()
+	}
+
+	type job struct{ id, path string }
+	rows, err := p.db.QueryContext(ctx, `SELECT id, file_path FROM tracks
+		WHERE COALESCE(replay_gain_track,'') = '' AND loudness IS NULL ORDER BY album_id, disc_number, track_number`)
+	if err != nil {
+		return r, err
+	}
+	var jobs []job
+	for rows.Next() {
+		var j job
+		if err := rows.Scan(&j.id, &j.path); err != nil {
+			rows.Close()
+			return r, err
+		}
+		jobs = append(jobs, j)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return r, err
+	}
+	r.Pending = len(jobs)
+
+	work := make(chan job)
+	var mu sync.Mutex
+	var storeErr error
+	var wg sync.WaitGroup
+	for range min(opts.Workers, max(len(jobs), 1)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range work {
+				lufs, err := measureLoudness(ctx, ffmpeg, j.path)
+				if err == nil {
+					_, err = p.db.ExecContext(context.WithoutCancel(ctx), `UPDATE tracks SET loudness=? WHERE id=?`, lufs, j.id)
+					if err != nil {
+						mu.Lock()
+						storeErr = err
+						mu.Unlock()
+					}
+				}
+				mu.Lock()
+				if err == nil {
+					r.Analyzed++
+				} else if ctx.Err() == nil {
+					r.Failed++
+				}
+				done := r.Analyzed + r.Failed
+				mu.Unlock()
+				if opts.Progress != nil {
+					opts.Progress(done, len(jobs), j.path)
+				}
+			}
+		}()
+	}
+feed:
+	for _, j := range jobs {
+		select {
+		case work <- j:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(work)
+	wg.Wait()
+	if storeErr != nil {
+		return r, fmt.Errorf("store loudness: %w", storeErr)
+	}
+	return r, ctx.Err()
+}
+
+// measureLoudness runs ffmpeg's loudnorm filter over a file and returns its
+// integrated loudness in LUFS.
+func measureLoudness(ctx context.Context, ffmpeg, path string) (float64, error) {
+	cmd := exec.CommandContext(ctx, ffmpeg, "-hide_banner", "-nostats", "-nostdin", "-i", path,
+		"-map", "0:a:0", "-af", "loudnorm=print_format=json", "-f", "null", "-")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return 0, fmt.Errorf("ffmpeg %s: %w", path, err)
+	}
+	return parseLoudnorm(stderr.Bytes())
+}
+
+// parseLoudnorm reads the integrated loudness from the JSON block loudnorm
+// prints at the end of ffmpeg's output.
+func parseLoudnorm(out []byte) (float64, error) {
+	start := bytes.LastIndexByte(out, '{')
+	end := bytes.LastIndexByte(out, '}')
+	if start < 0 || end < start {
+		return 0, errors.New("loudnorm: no measurement in ffmpeg output")
+	}
+	var m struct {
+		InputI string `json:"input_i"`
+	}
+	if err := json.Unmarshal(out[start:end+1], &m); err != nil {
+		return 0, fmt.Errorf("loudnorm: %w", err)
+	}
+	lufs, err := strconv.ParseFloat(m.InputI, 64)
+	if err != nil || math.IsInf(lufs, 0) || math.IsNaN(lufs) {
+		// Silence measures as -inf
+		return 0, fmt.Errorf("loudnorm: unusable loudness %q", m.InputI)
+	}
+	return lufs, nil
+}
+
+// measuredGain returns the ReplayGain for a measured track: its own, and
+// its album's from the power mean of the album's measured tracks. It
+// returns nil for tracks that carry gain tags or haven't been measured.
+func (p *Provider) measuredGain(ctx context.Context, trackID string) (*provider.Gain, error) {
+	var tagged string
+	var albumID string
+	var lufs *float64
+	err := p.db.QueryRowContext(ctx, `SELECT COALESCE(replay_gain_track,''), album_id, loudness FROM tracks WHERE id=?`, trackID).
+		Scan(&tagged, &albumID, &lufs)
+	if err != nil || tagged != "" || lufs == nil {
+		return nil, err
+	}
+	rows, err := p.db.QueryContext(ctx, `SELECT loudness FROM tracks WHERE album_id=? AND loudness IS NOT NULL`, albumID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var power float64
+	n := 0
+	for rows.Next() {
+		var l float64
+		if err := rows.Scan(&l); err != nil {
+			return nil, err
+		}
+		power += math.Pow(10, l/10)
+		n++
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	album := *lufs
+	if n > 0 {
+		album = 10 * math.Log10(power/float64(n))
+	}
+	return &provider.Gain{TrackDB: replayGainReference - *lufs, AlbumDB: replayGainReference - album}, nil
+}
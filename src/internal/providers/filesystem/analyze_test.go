@@ -0,0 +1,92 @@
+package filesystem
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeFFmpeg writes a script that reports a loudness by file name: a.mp3
+// -10 LUFS, b.mp3 -20, anything else silence.
+func fakeFFmpeg(t *testing.T) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake ffmpeg is a shell script")
+	}
+	script := `#!/bin/sh
+case "$5" in
+*/a.mp3) i=-10.00 ;;
+*/b.mp3) i=-20.00 ;;
+*) i=-inf ;;
+esac
+printf '[Parsed_loudnorm_0 @ 0x1]\n{\n\t"input_i" : "%s",\n\t"input_tp" : "-1.00"\n}\n' "$i" >&2
+`
+	path := filepath.Join(t.TempDir(), "ffmpeg")
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAnalyzeStoresMeasuredGain(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for _, name := range []string{"a.mp3", "b.mp3", "silent.mp3"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("fake audio"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	r, err := p.Analyze(ctx, AnalyzeOptions{FFmpeg: fakeFFmpeg(t), Workers: 2})
+	if err != nil {
+		t.Fatalf("analyze: %v", err)
+	}
+	if r.Pending != 3 || r.Analyzed != 2 || r.Failed != 1 {
+		t.Fatalf("unexpected result %+v", r)
+	}
+
+	var id string
+	if err := p.db.QueryRow(`SELECT id FROM tracks WHERE file_path=?`, filepath.Join(dir, "a.mp3")).Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	stream, err := p.GetStream(ctx, id)
+	if err != nil {
+		t.Fatalf("get stream: %v", err)
+	}
+	// The album's loudness is the power mean of -10 and -20 LUFS
+	album := -18 - 10*math.Log10((math.Pow(10, -1)+math.Pow(10, -2))/2)
+	if g := stream.Gain; g == nil || g.TrackDB != -8 || math.Abs(g.AlbumDB-album) > 1e-9 {
+		t.Fatalf("expected track gain -8 and album gain %.2f, got %+v", album, stream.Gain)
+	}
+
+	// Measured tracks aren't measured again; the silent one is retried
+	r, _ = p.Analyze(ctx, AnalyzeOptions{FFmpeg: fakeFFmpeg(t)})
+	if r.Pending != 1 {
+		t.Errorf("expected only the unmeasured track pending, got %+v", r)
+	}
+
+	// Tagged files keep their own gain
+	if _, err := p.db.Exec(`UPDATE tracks SET replay_gain_track='-3.00 dB' WHERE id=?`, id); err != nil {
+		t.Fatal(err)
+	}
+	if stream, _ := p.GetStream(ctx, id); stream.Gain != nil {
+		t.Errorf("expected no measured gain for a tagged file, got %+v", stream.Gain)
+	}
+}
+
+func TestParseLoudnorm(t *testing.T) {
+	out := []byte("Input #0, mp3\n[Parsed_loudnorm_0 @ 0x1] \n{\n\t\"input_i\" : \"-14.52\",\n\t\"target_offset\" : \"0.00\"\n}\n")
+	if lufs, err := parseLoudnorm(out); err != nil || lufs != -14.52 {
+		t.Errorf("parseLoudnorm = %v, %v", lufs, err)
+	}
+	if _, err := parseLoudnorm([]byte("no json here")); err == nil {
+		t.Error("expected an error without a measurement")
+	}
+}
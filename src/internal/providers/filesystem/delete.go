@@ -0,0 +1,51 @@
+package filesystem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tunez/tunez/internal/platform"
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// DeleteTrack moves a track's file and its .lrc lyrics to the OS trash, or
+// removes them for good when permanent is set, and drops the track from
+// the index. The index keeps the track if the file can't be removed.
+func (p *Provider) DeleteTrack(ctx context.Context, id string, permanent bool) error {
+	var path string
+	if err := p.db.QueryRowContext(ctx, `SELECT file_path FROM tracks WHERE id=?`, id).Scan(&path); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return provider.ErrNotFound
+		}
+		return err
+	}
+	remove := platform.Trash
+	if permanent {
+		remove = os.Remove
+	}
+
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `DELETE FROM tracks WHERE id=?`, id); err != nil {
+		return err
+	}
+	if err := remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		// The file is gone already; the next scan drops the track
+		return err
+	}
+	lyrics := strings.TrimSuffix(path, filepath.Ext(path)) + ".lrc"
+	if _, err := os.Stat(lyrics); err == nil {
+		remove(lyrics)
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestDeleteTrack(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "music")
+	os.MkdirAll(root, 0o755)
+	for _, name := range []string{"keep.mp3", "trash.mp3", "trash.lrc", "gone.flac"} {
+		if err := os.WriteFile(filepath.Join(root, name), []byte(name), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{root}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+
+	// Deleting for good removes the file and the track
+	gone := hash(filepath.Join(root, "gone.flac"))
+	if err := p.DeleteTrack(ctx, gone, true); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "gone.flac")); !os.IsNotExist(err) {
+		t.Errorf("expected the file removed, got %v", err)
+	}
+	if _, err := p.GetTrack(ctx, gone); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected the track out of the index, got %v", err)
+	}
+	if err := p.DeleteTrack(ctx, gone, true); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected deleting it again to find nothing, got %v", err)
+	}
+
+	// The trash takes the lyrics along
+	if runtime.GOOS != "linux" {
+		return
+	}
+	t.Setenv("XDG_DATA_HOME", filepath.Join(dir, "data"))
+	if err := p.DeleteTrack(ctx, hash(filepath.Join(root, "trash.mp3")), false); err != nil {
+		t.Fatalf("trash: %v", err)
+	}
+	for _, name := range []string{"trash.mp3", "trash.lrc"} {
+		if _, err := os.Stat(filepath.Join(dir, "data", "Trash", "files", name)); err != nil {
+			t.Errorf("expected %s in the trash: %v", name, err)
+		}
+	}
+	entries, _ := os.ReadDir(root)
+	if len(entries) != 1 || entries[0].Name() != "keep.mp3" {
+		t.Errorf("expected only keep.mp3 left, got %v", entries)
+	}
+	if _, err := p.GetTrack(ctx, hash(filepath.Join(root, "keep.mp3"))); err != nil {
+		t.Errorf("expected the other track kept: %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+package filesystem
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// dropState is what the drop folder held at the last look.
+type dropState struct {
+	mu sync.Mutex
+	// known are files already there at startup or already queued
+	known map[string]bool
+	// arriving are new files and their size; a file is taken once its
+	// size holds between two looks, so half-copied downloads wait
+	arriving map[string]int64
+}
+
+// startDropFolder records what the drop folder already holds, so only
+// files added from now on are queued.
+func (p *Provider) startDropFolder() {
+	p.drop.mu.Lock()
+	defer p.drop.mu.Unlock()
+	p.drop.known = make(map[string]bool)
+	p.drop.arriving = make(map[string]int64)
+	if p.cfg.DropFolder == "" {
+		return
+	}
+	for path := range dropFiles(p.cfg.DropFolder) {
+		p.drop.known[path] = true
+	}
+}
+
+// DropFolder returns the drop_folder setting.
+func (p *Provider) DropFolder() string { return p.cfg.DropFolder }
+
+// TakeDropped indexes and returns the audio files that finished arriving in
+// the drop folder since the last call.
+func (p *Provider) TakeDropped(ctx context.Context) ([]provider.Track, error) {
+	if p.cfg.DropFolder == "" {
+		return nil, nil
+	}
+	p.drop.mu.Lock()
+	defer p.drop.mu.Unlock()
+
+	files := dropFiles(p.cfg.DropFolder)
+	var ready []string
+	for path, size := range files {
+		if p.drop.known[path] {
+			continue
+		}
+		if prev, ok := p.drop.arriving[path]; ok && prev == size && size > 0 {
+			ready = append(ready, path)
+			continue
+		}
+		p.drop.arriving[path] = size
+	}
+	for path := range p.drop.arriving {
+		if _, ok := files[path]; !ok {
+			delete(p.drop.arriving, path)
+		}
+	}
+	for path := range p.drop.known {
+		if _, ok := files[path]; !ok {
+			// Gone; if it comes back it is new again
+			delete(p.drop.known, path)
+		}
+	}
+	slices.Sort(ready)
+
+	var tracks []provider.Track
+	for _, path := range ready {
+		delete(p.drop.arriving, path)
+		p.drop.known[path] = true
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		ti, err := p.processFile(path, info)
+		if err != nil {
+			continue
+		}
+		if err := p.indexFile(ctx, ti); err != nil {
+			return tracks, err
+		}
+		t, err := p.GetTrack(ctx, hash(path))
+		if err != nil {
+			return tracks, err
+		}
+		tracks = append(tracks, t)
+	}
+	return tracks, nil
+}
+
+// dropFiles lists the audio files under dir with their sizes.
+func dropFiles(dir string) map[string]int64 {
+	files := make(map[string]int64)
+	filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !allowedExtensions[strings.ToLower(filepath.Ext(path))] {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			files[path] = info.Size()
+		}
+		return nil
+	})
+	return files
+}
+
+// indexFile writes one scanned file to the index, as a scan would.
+func (p *Provider) indexFile(ctx context.Context, ti *trackInfo) error {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	artistID := hash(strings.ToLower(ti.ArtistName))
+	albumID := hash(artistID, strings.ToLower(ti.AlbumTitle))
+	if _, err := tx.ExecContext(ctx, insertArtistSQL, artistID, ti.ArtistName, strings.ToLower(ti.ArtistName)); err != nil {
+		return fmt.Errorf("index %s: %w", ti.Path, err)
+	}
+	if _, err := tx.ExecContext(ctx, insertAlbumSQL, albumID, artistID, ti.AlbumTitle, ti.Year, ""); err != nil {
+		return fmt.Errorf("index %s: %w", ti.Path, err)
+	}
+	tagsJSON, _ := json.Marshal(ti.Tags)
+	composerID, workID := classicalIDs(ti.Composer, ti.Work)
+	if _, err := tx.ExecContext(ctx, insertTrackSQL, hash(ti.Path), albumID, artistID, ti.TrackTitle, ti.AlbumTitle, ti.ArtistName, ti.Year, ti.TrackNo, ti.DiscNo, ti.DurationMs, ti.Path, ti.Size, ti.Mtime, ti.Codec, ti.BitrateKbps, ti.SampleRate, ti.BitDepth, ti.Channels, ti.ReplayGainTrack, ti.ReplayGainAlbum, string(tagsJSON), time.Now().Unix(),
+		ti.Composer, composerID, ti.Work, workID, ti.Movement, ti.MovementNo, ti.Genre); err != nil {
+		return fmt.Errorf("index %s: %w", ti.Path, err)
+	}
+	return tx.Commit()
+}
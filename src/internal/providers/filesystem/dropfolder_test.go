@@ -0,0 +1,51 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDropFolder(t *testing.T) {
+	ctx := context.Background()
+	lib, drop := t.TempDir(), t.TempDir()
+	if err := os.WriteFile(filepath.Join(drop, "old.mp3"), []byte("fake audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := New()
+	settings := map[string]any{"roots": []any{lib}, "drop_folder": drop, "index_db": filepath.Join(lib, "index.sqlite")}
+	if err := p.Initialize(ctx, settings); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if tracks, err := p.TakeDropped(ctx); err != nil || len(tracks) != 0 {
+		t.Fatalf("expected files there at startup left alone, got %d (%v)", len(tracks), err)
+	}
+
+	if err := os.WriteFile(filepath.Join(drop, "new.mp3"), []byte("fake audio"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if tracks, _ := p.TakeDropped(ctx); len(tracks) != 0 {
+		t.Fatal("expected a new file to wait until its size settles")
+	}
+	tracks, err := p.TakeDropped(ctx)
+	if err != nil || len(tracks) != 1 || tracks[0].FilePath != filepath.Join(drop, "new.mp3") {
+		t.Fatalf("expected new.mp3 taken, got %+v (%v)", tracks, err)
+	}
+	if tracks, _ := p.TakeDropped(ctx); len(tracks) != 0 {
+		t.Error("expected a file taken once")
+	}
+	if _, err := p.GetStream(ctx, tracks[0].ID); err != nil {
+		t.Errorf("expected the dropped file playable, got %v", err)
+	}
+
+	// A rescan keeps the drop folder's files in the index
+	settings["scan_on_init"] = true
+	p2 := New()
+	if err := p2.Initialize(ctx, settings); err != nil {
+		t.Fatalf("rescan: %v", err)
+	}
+	if _, err := p2.GetTrack(ctx, tracks[0].ID); err != nil {
+		t.Errorf("expected the dropped file still indexed after a rescan, got %v", err)
+	}
+}
@@ -0,0 +1,267 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/tunez/tunez/internal/platform"
+)
+
+// DefaultOrganizePattern is where Organize puts files without a pattern:
+// Artist/Album/NN - Title, under their library folder.
+const DefaultOrganizePattern = `{{.Artist}}/{{.Album}}/{{printf "%02d" .TrackNo}} - {{.Title}}`
+
+// OrganizeOptions tune Organize. Zero fields take the defaults.
+type OrganizeOptions struct {
+	// Pattern is a Go text/template of the path of a file under its
+	// library folder, without the extension, with "/" between folders.
+	// It sees Artist, Album, Title, TrackNo, DiscNo, Year and Genre.
+	// Default DefaultOrganizePattern.
+	Pattern string
+	// DryRun lists the moves without making them.
+	DryRun bool
+	// Progress, if set, is called after each file.
+	Progress func(done, total int, path string)
+}
+
+// OrganizeFields are the tags an organize pattern sees, made safe to use
+// as file names.
+type OrganizeFields struct {
+	Artist  string
+	Album   string
+	Title   string
+	TrackNo int
+	DiscNo  int
+	Year    int
+	Genre   string
+}
+
+// Move is a file Organize moved or would move, or left where it was.
+type Move struct {
+	TrackID string `json:"track_id"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	// Skipped says why the file stayed, such as another file already
+	// being at To
+	Skipped string `json:"skipped,omitempty"`
+}
+
+// OrganizeResult lists what Organize did.
+type OrganizeResult struct {
+	Moves   []Move `json:"moves"`
+	Moved   int    `json:"moved"`    // moved, or to move on a dry run
+	Skipped int    `json:"skipped"`  // left where they were, see Move.Skipped
+	InPlace int    `json:"in_place"` // already where the pattern puts them
+}
+
+// Organize moves the library's files to where the pattern puts them under
+// the library folder they're in, taking .lrc lyrics along and copying
+// folder artwork to new folders. Each move updates the index in a
+// transaction, undone if the file can't be moved, so the index always
+// matches the disk; tracks keep their IDs. Files that would land on
+// another file, or on the same path as another moved file, stay put.
+// Folders left empty are removed. Cancelling ctx stops between files.
+func (p *Provider) Organize(ctx context.Context, opts OrganizeOptions) (OrganizeResult, error) {
+	var r OrganizeResult
+	if opts.Pattern == "" {
+		opts.Pattern = DefaultOrganizePattern
+	}
+	tmpl, err := template.New("organize").Parse(opts.Pattern)
+	if err != nil {
+		return r, fmt.Errorf("organize pattern: %w", err)
+	}
+
+	type track struct {
+		id, path string
+		fields   OrganizeFields
+	}
+	rows, err := p.db.QueryContext(ctx, `SELECT id, file_path, artist_name, album_title, title,
+		COALESCE(track_number,0), COALESCE(disc_number,0), COALESCE(year,0), COALESCE(genre,'')
+		FROM tracks ORDER BY file_path`)
+	if err != nil {
+		return r, err
+	}
+	var tracks []track
+	for rows.Next() {
+		var t track
+		f := &t.fields
+		if err := rows.Scan(&t.id, &t.path, &f.Artist, &f.Album, &f.Title, &f.TrackNo, &f.DiscNo, &f.Year, &f.Genre); err != nil {
+			rows.Close()
+			return r, err
+		}
+		tracks = append(tracks, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return r, err
+	}
+
+	taken := make(map[string]string) // target path → the file moving there
+	vacated := make(map[string]bool) // paths moved away from, so free on a dry run
+	emptied := make(map[string]string)
+	for i, t := range tracks {
+		if err := ctx.Err(); err != nil {
+			return r, err
+		}
+		if opts.Progress != nil {
+			opts.Progress(i+1, len(tracks), t.path)
+		}
+		move := Move{TrackID: t.id, From: t.path}
+		root := p.rootOf(t.path)
+		if root == "" {
+			// Such as files waiting in the drop folder
+			continue
+		}
+		rel, err := organizePath(tmpl, t.fields)
+		if err != nil {
+			return r, err
+		}
+		move.To = filepath.Join(root, rel) + filepath.Ext(t.path)
+		if move.To == t.path {
+			r.InPlace++
+			continue
+		}
+		switch other, ok := taken[strings.ToLower(move.To)]; {
+		case ok:
+			move.Skipped = "collides with " + other
+		case occupied(t.path, move.To) && !vacated[strings.ToLower(move.To)]:
+			move.Skipped = "a file is already there"
+		}
+		if move.Skipped == "" && !opts.DryRun {
+			if err := p.moveTrack(ctx, t.id, t.path, move.To); err != nil {
+				move.Skipped = err.Error()
+			}
+		}
+		if move.Skipped != "" {
+			r.Skipped++
+		} else {
+			r.Moved++
+			taken[strings.ToLower(move.To)] = t.path
+			vacated[strings.ToLower(t.path)] = true
+			emptied[filepath.Dir(t.path)] = root
+		}
+		r.Moves = append(r.Moves, move)
+	}
+	if !opts.DryRun {
+		for dir, root := range emptied {
+			removeEmptyDirs(dir, root)
+		}
+	}
+	return r, nil
+}
+
+// moveTrack moves a track's file and its lyrics, and points the index at
+// the new path. The index changes only if the file moved.
+func (p *Provider) moveTrack(ctx context.Context, id, from, to string) error {
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		return err
+	}
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	if _, err := tx.ExecContext(ctx, `UPDATE tracks SET file_path=? WHERE id=?`, to, id); err != nil {
+		return err
+	}
+	if err := os.Rename(from, to); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		// Put the file back so the index still finds it
+		if undo := os.Rename(to, from); undo != nil {
+			return errors.Join(err, undo)
+		}
+		return err
+	}
+	lyrics := func(path string) string { return strings.TrimSuffix(path, filepath.Ext(path)) + ".lrc" }
+	if _, err := os.Stat(lyrics(from)); err == nil && !occupied(lyrics(from), lyrics(to)) {
+		os.Rename(lyrics(from), lyrics(to))
+	}
+	copyFolderArtwork(filepath.Dir(from), filepath.Dir(to))
+	return nil
+}
+
+// rootOf returns the library folder path is in, or "" when it is in none.
+func (p *Provider) rootOf(path string) string {
+	best := ""
+	for _, root := range p.cfg.Roots {
+		if rel, err := filepath.Rel(root, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) && len(root) > len(best) {
+			best = root
+		}
+	}
+	return best
+}
+
+// organizePath renders the pattern for a track as a path relative to its
+// library folder.
+func organizePath(tmpl *template.Template, f OrganizeFields) (string, error) {
+	f.Artist = fileNamePart(f.Artist, "Unknown Artist")
+	f.Album = fileNamePart(f.Album, "Unknown Album")
+	f.Title = fileNamePart(f.Title, "Untitled")
+	f.Genre = fileNamePart(f.Genre, "Unknown Genre")
+	var b strings.Builder
+	if err := tmpl.Execute(&b, f); err != nil {
+		return "", fmt.Errorf("organize pattern: %w", err)
+	}
+	var parts []string
+	for _, part := range strings.Split(b.String(), "/") {
+		// Nothing the pattern makes can climb out of the library folder
+		if part = strings.TrimSpace(part); part != "" && part != "." && part != ".." {
+			parts = append(parts, part)
+		}
+	}
+	if len(parts) == 0 {
+		return "", errors.New("organize pattern: renders an empty path")
+	}
+	return filepath.Join(parts...), nil
+}
+
+// fileNamePart makes a tag safe to use as a file or folder name on any
+// system, or returns fallback for an empty one.
+func fileNamePart(s, fallback string) string {
+	if s = platform.FileName(s); s == "" {
+		return fallback
+	}
+	return s
+}
+
+// occupied reports whether another file is at to. On case-insensitive
+// file systems from itself may be, under another case.
+func occupied(from, to string) bool {
+	target, err := os.Stat(to)
+	if err != nil {
+		return false
+	}
+	source, err := os.Stat(from)
+	return err != nil || !os.SameFile(source, target)
+}
+
+// copyFolderArtwork copies the artwork image of folder from to folder to,
+// unless to has its own.
+func copyFolderArtwork(from, to string) {
+	for _, name := range folderArtworkNames {
+		if _, err := os.Stat(filepath.Join(to, name)); err == nil {
+			return
+		}
+	}
+	for _, name := range folderArtworkNames {
+		if data, err := os.ReadFile(filepath.Join(from, name)); err == nil {
+			os.WriteFile(filepath.Join(to, name), data, 0o644)
+			return
+		}
+	}
+}
+
+// removeEmptyDirs removes dir and the folders above it up to root while
+// they are empty.
+func removeEmptyDirs(dir, root string) {
+	for dir != root && strings.HasPrefix(dir, root) && os.Remove(dir) == nil {
+		dir = filepath.Dir(dir)
+	}
+}
@@ -0,0 +1,131 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestOrganize(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	root := filepath.Join(dir, "music")
+	files := map[string]string{
+		"incoming/a.mp3":     "a",
+		"incoming/a.lrc":     "[00:01.00]la",
+		"incoming/b.flac":    "b",
+		"incoming/cover.jpg": "jpeg",
+		"misc/dup.mp3":       "dup",
+		"misc/bells.mp3":     "bells",
+		"AC_DC/Back in Black/01 - Hells Bells.mp3": "in place",
+		"Queen/Jazz/03 - Jealousy.mp3":             "mistagged",
+		"taken/c.mp3":                              "c",
+	}
+	for name, data := range files {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{root}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	for name, tags := range map[string][]any{
+		"incoming/a.mp3":  {"Queen", "Jazz", "Mustapha", 1},
+		"incoming/b.flac": {"AC/DC", "Highway to Hell", "Night Prowler?", 10},
+		"misc/dup.mp3":    {"Queen", "Jazz", "Mustapha", 1},
+		"misc/bells.mp3":  {"AC/DC", "Back in Black", "Hells Bells", 1},
+		"AC_DC/Back in Black/01 - Hells Bells.mp3": {"AC/DC", "Back in Black", "Hells Bells", 1},
+		"Queen/Jazz/03 - Jealousy.mp3":             {"Someone", "Else", "Entirely", 1},
+		"taken/c.mp3":                              {"Queen", "Jazz", "Jealousy", 3},
+	} {
+		if _, err := p.db.Exec(`UPDATE tracks SET artist_name=?, album_title=?, title=?, track_number=? WHERE file_path=?`,
+			append(tags, filepath.Join(root, name))...); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A dry run lists the moves and changes nothing
+	preview, err := p.Organize(ctx, OrganizeOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry run: %v", err)
+	}
+	want := map[string]string{
+		"incoming/a.mp3":               "Queen/Jazz/01 - Mustapha.mp3",
+		"incoming/b.flac":              "AC_DC/Highway to Hell/10 - Night Prowler_.flac",
+		"misc/dup.mp3":                 "Queen/Jazz/01 - Mustapha.mp3",
+		"misc/bells.mp3":               "AC_DC/Back in Black/01 - Hells Bells.mp3",
+		"Queen/Jazz/03 - Jealousy.mp3": "Someone/Else/01 - Entirely.mp3",
+		"taken/c.mp3":                  "Queen/Jazz/03 - Jealousy.mp3",
+	}
+	if preview.Moved != 4 || preview.Skipped != 2 || preview.InPlace != 1 || len(preview.Moves) != len(want) {
+		t.Fatalf("unexpected preview %+v", preview)
+	}
+	skipped := map[string]bool{}
+	for _, m := range preview.Moves {
+		from, _ := filepath.Rel(root, m.From)
+		to, _ := filepath.Rel(root, m.To)
+		if want[from] != to {
+			t.Errorf("%s: expected it to go to %s, got %s", from, want[from], to)
+		}
+		if m.Skipped != "" {
+			skipped[from] = true
+		}
+	}
+	// A second file for the same path and one where a file stays are
+	// skipped; a path another file moves away from is free
+	if len(skipped) != 2 || !skipped["misc/dup.mp3"] || !skipped["misc/bells.mp3"] {
+		t.Errorf("expected the collisions skipped, got %+v", preview.Moves)
+	}
+	if _, err := os.Stat(filepath.Join(root, "incoming/a.mp3")); err != nil {
+		t.Fatalf("expected a dry run to leave the files, got %v", err)
+	}
+
+	r, err := p.Organize(ctx, OrganizeOptions{})
+	if err != nil {
+		t.Fatalf("organize: %v", err)
+	}
+	if r.Moved != preview.Moved || r.Skipped != preview.Skipped {
+		t.Fatalf("expected the dry run's moves, got %+v", r)
+	}
+	for name, data := range map[string]string{
+		"Queen/Jazz/01 - Mustapha.mp3":                   "a",
+		"Queen/Jazz/01 - Mustapha.lrc":                   "[00:01.00]la",
+		"Queen/Jazz/03 - Jealousy.mp3":                   "c",
+		"Queen/Jazz/cover.jpg":                           "jpeg",
+		"AC_DC/Highway to Hell/10 - Night Prowler_.flac": "b",
+		"Someone/Else/01 - Entirely.mp3":                 "mistagged",
+		"misc/dup.mp3":                                   "dup",
+		"incoming/cover.jpg":                             "jpeg",
+	} {
+		if got, err := os.ReadFile(filepath.Join(root, name)); err != nil || string(got) != data {
+			t.Errorf("expected %s to hold %q, got %q %v", name, data, got, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(root, "taken")); !os.IsNotExist(err) {
+		t.Errorf("expected the emptied folder removed, got %v", err)
+	}
+
+	// The index follows the files, keeping the track IDs
+	id := hash(filepath.Join(root, "incoming/a.mp3"))
+	if tr, err := p.GetTrack(ctx, id); err != nil || tr.FilePath != filepath.Join(root, "Queen/Jazz/01 - Mustapha.mp3") {
+		t.Errorf("expected the track at its new path, got %+v %v", tr, err)
+	}
+
+	// Running again finds everything in place
+	if r, err := p.Organize(ctx, OrganizeOptions{DryRun: true}); err != nil || r.Moved != 0 || r.InPlace != 5 {
+		t.Errorf("expected the moved files in place, got %+v %v", r, err)
+	}
+}
+
+func TestOrganizePattern(t *testing.T) {
+	p := New()
+	if _, err := p.Organize(context.Background(), OrganizeOptions{Pattern: "{{.Artist"}); err == nil {
+		t.Error("expected a bad pattern to fail")
+	}
+}
@@ -13,8 +13,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/dhowden/tag"
 	"github.com/tunez/tunez/internal/logging"
@@ -33,6 +36,7 @@ var allowedExtensions = map[string]bool{
 
 type Config struct {
 	Roots        []string
+	DropFolder   string // new audio files here are indexed and queued as they arrive
 	IndexDB      string
 	ScanOnInit   bool
 	PageSize     int
@@ -40,8 +44,9 @@ type Config struct {
 }
 
 type Provider struct {
-	cfg Config
-	db  *sql.DB
+	cfg  Config
+	db   *sql.DB
+	drop dropState
 }
 
 func New() *Provider {
@@ -105,6 +110,9 @@ func (p *Provider) Initialize(ctx context.Context, profileCfg any) error {
 			shouldScan = true
 		} else if count == 0 {
 			shouldScan = true
+		} else if err := p.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tracks WHERE file_mtime=0").Scan(&count); err == nil && count > 0 {
+			// Schema was upgraded; rescan to fill in the new columns
+			shouldScan = true
 		}
 	}
 
@@ -113,6 +121,7 @@ func (p *Provider) Initialize(ctx context.Context, profileCfg any) error {
 			return err
 		}
 	}
+	p.startDropFolder()
 	return nil
 }
 
@@ -128,6 +137,13 @@ func parseConfig(raw map[string]any) (Config, error) {
 	if v, ok := raw["index_db"].(string); ok && v != "" {
 		cfg.IndexDB = v
 	}
+	if v, ok := raw["drop_folder"].(string); ok && v != "" {
+		abs, err := filepath.Abs(v)
+		if err != nil {
+			return Config{}, err
+		}
+		cfg.DropFolder = abs
+	}
 	if v, ok := raw["scan_on_start"].(bool); ok {
 		cfg.ScanOnInit = v
 	}
@@ -179,6 +195,102 @@ func (p *Provider) ensureSchema(ctx context.Context) error {
 			return fmt.Errorf("migrate schema: %w", err)
 		}
 	}
+	if err := p.migrateTrackColumns(ctx); err != nil {
+		return err
+	}
+	for _, stmt := range classicalIndexes {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migrate schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// trackColumns are columns added to the tracks table after the initial schema.
+var trackColumns = []struct {
+	name string
+	def  string
+}{
+	{"sample_rate", "INTEGER"},
+	{"bit_depth", "INTEGER"},
+	{"channels", "INTEGER"},
+	{"replay_gain_track", "TEXT"},
+	{"replay_gain_album", "TEXT"},
+	{"tags_json", "TEXT"},
+	{"indexed_at", "INTEGER"},
+	{"composer", "TEXT"},
+	{"composer_id", "TEXT"},
+	{"work", "TEXT"},
+	{"work_id", "TEXT"},
+	{"movement", "TEXT"},
+	{"movement_number", "INTEGER"},
+	{"genre", "TEXT"},
+}
+
+// analysisColumns hold results of Analyze. Adding them needs no rescan.
+var analysisColumns = []struct {
+	name string
+	def  string
+}{
+	{"loudness", "REAL"}, // integrated loudness in LUFS, NULL until measured
+}
+
+// classicalIndexes cover the composer and work columns, which older indexes
+// only gain in migrateTrackColumns.
+var classicalIndexes = []string{
+	`CREATE INDEX IF NOT EXISTS idx_tracks_composer ON tracks(composer_id, work);`,
+	`CREATE INDEX IF NOT EXISTS idx_tracks_work ON tracks(work_id, movement_number);`,
+}
+
+// migrateTrackColumns adds any missing columns to the tracks table. When an
+// existing index is upgraded, file mtimes are reset so the next scan
+// re-reads every file and fills in the new columns.
+func (p *Provider) migrateTrackColumns(ctx context.Context) error {
+	rows, err := p.db.QueryContext(ctx, `PRAGMA table_info(tracks)`)
+	if err != nil {
+		return fmt.Errorf("migrate schema: %w", err)
+	}
+	have := make(map[string]bool)
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   int
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			rows.Close()
+			return fmt.Errorf("migrate schema: %w", err)
+		}
+		have[name] = true
+	}
+	rows.Close()
+
+	added := false
+	for _, col := range trackColumns {
+		if have[col.name] {
+			continue
+		}
+		if _, err := p.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE tracks ADD COLUMN %s %s", col.name, col.def)); err != nil {
+			return fmt.Errorf("migrate schema: add column %s: %w", col.name, err)
+		}
+		added = true
+	}
+	if added {
+		if _, err := p.db.ExecContext(ctx, `UPDATE tracks SET file_mtime=0`); err != nil {
+			return fmt.Errorf("migrate schema: %w", err)
+		}
+	}
+	for _, col := range analysisColumns {
+		if have[col.name] {
+			continue
+		}
+		if _, err := p.db.ExecContext(ctx, fmt.Sprintf("ALTER TABLE tracks ADD COLUMN %s %s", col.name, col.def)); err != nil {
+			return fmt.Errorf("migrate schema: add column %s: %w", col.name, err)
+		}
+	}
 	return nil
 }
 
@@ -267,20 +379,38 @@ func parseYearValue(v any) int {
 
 // trackInfo holds extracted metadata for a track
 type trackInfo struct {
-	Path        string
-	Size        int64
-	Mtime       int64
-	ArtistName  string
-	AlbumTitle  string
-	TrackTitle  string
-	TrackNo     int
-	DiscNo      int
-	Year        int
-	DurationMs  int
-	BitrateKbps int
-	Codec       string
+	Path            string
+	Size            int64
+	Mtime           int64
+	ArtistName      string
+	AlbumTitle      string
+	TrackTitle      string
+	TrackNo         int
+	DiscNo          int
+	Year            int
+	Genre           string
+	DurationMs      int
+	BitrateKbps     int
+	Codec           string
+	SampleRate      int
+	BitDepth        int
+	Channels        int
+	ReplayGainTrack string
+	ReplayGainAlbum string
+	Tags            map[string]string
+	Composer        string
+	Work            string
+	Movement        string
+	MovementNo      int
 }
 
+// Statements writing a scanned file to the index.
+const (
+	insertArtistSQL = `INSERT OR IGNORE INTO artists(id,name,sort_name) VALUES(?,?,?)`
+	insertAlbumSQL  = `INSERT OR IGNORE INTO albums(id,artist_id,title,year,artwork_path) VALUES(?,?,?,?,?)`
+	insertTrackSQL  = `INSERT OR REPLACE INTO tracks(id,album_id,artist_id,title,album_title,artist_name,year,track_number,disc_number,duration_ms,file_path,file_size,file_mtime,codec,bitrate,sample_rate,bit_depth,channels,replay_gain_track,replay_gain_album,tags_json,indexed_at,composer,composer_id,work,work_id,movement,movement_number,genre) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`
+)
+
 func (p *Provider) scan(ctx context.Context) error {
 	// 1. Load existing tracks for incremental scan
 	existing := make(map[string]struct {
@@ -345,12 +475,17 @@ func (p *Provider) scan(ctx context.Context) error {
 	}
 
 	// 3. Start collector (database writer)
+	scanCtx := ctx
 	errChan := make(chan error, 1)
 	doneChan := make(chan struct{})
 
 	go func() {
 		defer close(doneChan)
 
+		// Writes outlive ctx so that an interrupted scan still commits the
+		// tracks it has read, leaving a consistent index to resume from.
+		ctx := context.WithoutCancel(ctx)
+
 		// Cache known IDs to avoid redundant DB executions
 		knownArtists := make(map[string]bool)
 		knownAlbums := make(map[string]bool)
@@ -367,9 +502,9 @@ func (p *Provider) scan(ctx context.Context) error {
 		}
 		defer tx.Rollback()
 
-		insertArtist, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO artists(id,name,sort_name) VALUES(?,?,?)`)
-		insertAlbum, _ := tx.PrepareContext(ctx, `INSERT OR IGNORE INTO albums(id,artist_id,title,year,artwork_path) VALUES(?,?,?,?,?)`)
-		insertTrack, _ := tx.PrepareContext(ctx, `INSERT OR REPLACE INTO tracks(id,album_id,artist_id,title,album_title,artist_name,year,track_number,disc_number,duration_ms,file_path,file_size,file_mtime,codec,bitrate) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
+		insertArtist, _ := tx.PrepareContext(ctx, insertArtistSQL)
+		insertAlbum, _ := tx.PrepareContext(ctx, insertAlbumSQL)
+		insertTrack, _ := tx.PrepareContext(ctx, insertTrackSQL)
 
 		seenPaths := make(map[string]bool)
 		batchSize := 100
@@ -407,7 +542,10 @@ func (p *Provider) scan(ctx context.Context) error {
 				knownAlbums[albumID] = true
 			}
 
-			if _, err := insertTrack.ExecContext(ctx, trackID, albumID, artistID, ti.TrackTitle, ti.AlbumTitle, ti.ArtistName, ti.Year, ti.TrackNo, ti.DiscNo, ti.DurationMs, ti.Path, ti.Size, ti.Mtime, ti.Codec, ti.BitrateKbps); err != nil {
+			tagsJSON, _ := json.Marshal(ti.Tags)
+			composerID, workID := classicalIDs(ti.Composer, ti.Work)
+			if _, err := insertTrack.ExecContext(ctx, trackID, albumID, artistID, ti.TrackTitle, ti.AlbumTitle, ti.ArtistName, ti.Year, ti.TrackNo, ti.DiscNo, ti.DurationMs, ti.Path, ti.Size, ti.Mtime, ti.Codec, ti.BitrateKbps, ti.SampleRate, ti.BitDepth, ti.Channels, ti.ReplayGainTrack, ti.ReplayGainAlbum, string(tagsJSON), time.Now().Unix(),
+				ti.Composer, composerID, ti.Work, workID, ti.Movement, ti.MovementNo, ti.Genre); err != nil {
 				continue
 			}
 
@@ -424,18 +562,21 @@ func (p *Provider) scan(ctx context.Context) error {
 					return
 				}
 
-				insertArtist, _ = tx.PrepareContext(ctx, `INSERT OR IGNORE INTO artists(id,name,sort_name) VALUES(?,?,?)`)
-				insertAlbum, _ = tx.PrepareContext(ctx, `INSERT OR IGNORE INTO albums(id,artist_id,title,year,artwork_path) VALUES(?,?,?,?,?)`)
-				insertTrack, _ = tx.PrepareContext(ctx, `INSERT OR REPLACE INTO tracks(id,album_id,artist_id,title,album_title,artist_name,year,track_number,disc_number,duration_ms,file_path,file_size,file_mtime,codec,bitrate) VALUES(?,?,?,?,?,?,?,?,?,?,?,?,?,?,?)`)
+				insertArtist, _ = tx.PrepareContext(ctx, insertArtistSQL)
+				insertAlbum, _ = tx.PrepareContext(ctx, insertAlbumSQL)
+				insertTrack, _ = tx.PrepareContext(ctx, insertTrackSQL)
 				count = 0
 			}
 		}
 
-		// Cleanup deleted files
-		for path := range existing {
-			if !seenPaths[path] {
-				// File no longer exists or wasn't scanned
-				_, _ = tx.ExecContext(ctx, "DELETE FROM tracks WHERE file_path = ?", path)
+		// Cleanup deleted files, unless the scan was interrupted and
+		// unseen files may simply not have been reached yet
+		if scanCtx.Err() == nil {
+			for path := range existing {
+				if !seenPaths[path] {
+					// File no longer exists or wasn't scanned
+					_, _ = tx.ExecContext(ctx, "DELETE FROM tracks WHERE file_path = ?", path)
+				}
 			}
 		}
 
@@ -446,8 +587,16 @@ func (p *Provider) scan(ctx context.Context) error {
 		errChan <- nil
 	}()
 
-	// 4. Walk directories and feed jobs
-	for _, root := range p.cfg.Roots {
+	// 4. Walk directories and feed jobs. Files queued from the drop folder
+	// stay indexed while they are there.
+	roots := p.cfg.Roots
+	if p.cfg.DropFolder != "" {
+		roots = append(slices.Clone(roots), p.cfg.DropFolder)
+	}
+	for _, root := range roots {
+		if ctx.Err() != nil {
+			break
+		}
 		filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
 			if err != nil || d.IsDir() {
 				return nil
@@ -471,6 +620,9 @@ func (p *Provider) scan(ctx context.Context) error {
 	if err := <-errChan; err != nil {
 		return err
 	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("scan interrupted: %w", err)
+	}
 
 	// Optimize DB after scan
 	if _, err := p.db.Exec("PRAGMA optimize"); err != nil {
@@ -501,6 +653,11 @@ func (p *Provider) processFile(path string, info os.FileInfo) (*trackInfo, error
 		ti.TrackNo, _ = meta.Track()
 		ti.DiscNo, _ = meta.Disc()
 		ti.Year = extractYear(meta)
+		ti.Tags = extractTags(meta)
+		ti.ReplayGainTrack = lookupTag(ti.Tags, "replaygain_track_gain")
+		ti.ReplayGainAlbum = lookupTag(ti.Tags, "replaygain_album_gain")
+		ti.Composer = strings.TrimSpace(meta.Composer())
+		ti.Genre = strings.TrimSpace(meta.Genre())
 	}
 
 	if ti.ArtistName == "" {
@@ -515,16 +672,122 @@ func (p *Provider) processFile(path string, info os.FileInfo) (*trackInfo, error
 	if ti.TrackTitle == "" {
 		ti.TrackTitle = strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
 	}
+	if ti.Composer != "" {
+		ti.Work, ti.Movement, ti.MovementNo = classicalTags(ti.Tags, ti.TrackTitle)
+	}
 
 	// Get audio metadata
 	audioInfo := getAudioInfo(path)
 	ti.DurationMs = audioInfo.DurationMs
 	ti.Codec = audioInfo.Codec
 	ti.BitrateKbps = audioInfo.BitrateKbps
+	ti.SampleRate = audioInfo.SampleRate
+	ti.BitDepth = audioInfo.BitDepth
+	ti.Channels = audioInfo.Channels
 
 	return ti, nil
 }
 
+// extractTags flattens the raw tag map into printable key/value pairs.
+// Binary frames (pictures) and lyrics are skipped; user-defined text frames
+// (TXXX) are keyed by their description.
+func extractTags(meta tag.Metadata) map[string]string {
+	raw := meta.Raw()
+	if raw == nil {
+		return nil
+	}
+	tags := make(map[string]string, len(raw))
+	for k, v := range raw {
+		switch val := v.(type) {
+		case string:
+			if val != "" {
+				tags[k] = val
+			}
+		case int:
+			tags[k] = strconv.Itoa(val)
+		case int64:
+			tags[k] = strconv.FormatInt(val, 10)
+		case *tag.Comm:
+			if strings.HasPrefix(k, "USLT") || strings.HasPrefix(k, "ULT") || val.Text == "" {
+				continue
+			}
+			if val.Description != "" && (strings.HasPrefix(k, "TXXX") || strings.HasPrefix(k, "TXX")) {
+				tags[val.Description] = val.Text
+				continue
+			}
+			tags[k] = val.Text
+		}
+	}
+	// Lyrics are served separately via GetLyrics
+	for k := range tags {
+		if strings.EqualFold(k, "lyrics") || strings.EqualFold(k, "unsyncedlyrics") {
+			delete(tags, k)
+		}
+	}
+	return tags
+}
+
+// Work and movement tags as Picard writes them: Vorbis comments, ID3 frames
+// (TXXX frames are keyed by description) and MP4 atoms.
+var (
+	workTags       = []string{"work", "©wrk"}
+	movementTags   = []string{"movementname", "MVNM", "©mvn"}
+	movementNoTags = []string{"movement", "movementnumber", "MVIN", "©mvi"}
+)
+
+// classicalTags returns the work, movement and movement number of a track
+// with a composer. Untagged works are split from titles like "Symphony No. 5:
+// I. Allegro", and a title that doesn't split is a work of its own.
+func classicalTags(tags map[string]string, title string) (work, movement string, no int) {
+	first := func(keys []string) string {
+		for _, k := range keys {
+			if v := strings.TrimSpace(lookupTag(tags, k)); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	work, movement = first(workTags), first(movementTags)
+	// MVIN holds "number/total"
+	no, _ = strconv.Atoi(strings.TrimSpace(strings.SplitN(first(movementNoTags), "/", 2)[0]))
+	if work != "" {
+		if movement == "" && title != work {
+			movement = title
+			if _, m, n, ok := provider.SplitWork(title); ok {
+				movement, no = m, n
+			}
+		}
+		return work, movement, no
+	}
+	if w, m, n, ok := provider.SplitWork(title); ok {
+		return w, m, n
+	}
+	return title, "", 0
+}
+
+// workIDPrefix marks the work IDs ListTracks accepts as album IDs.
+const workIDPrefix = "work-"
+
+// classicalIDs returns the composer and work IDs of a track, or empty IDs
+// when it has no composer.
+func classicalIDs(composer, work string) (string, string) {
+	if composer == "" {
+		return "", ""
+	}
+	composerID := hash("composer:", strings.ToLower(composer))
+	return composerID, workIDPrefix + hash(composerID, strings.ToLower(work))
+}
+
+// lookupTag returns the value for key, matching case-insensitively.
+func lookupTag(tags map[string]string, key string) string {
+	for k, v := range tags {
+		if strings.EqualFold(k, key) {
+			return v
+		}
+	}
+	return ""
+}
+
 func (p *Provider) Health(ctx context.Context) (bool, string) {
 	if p.db == nil {
 		return false, "db not initialized"
@@ -550,7 +813,7 @@ func (p *Provider) listArtists(ctx context.Context, req provider.ListReq) (provi
 		FROM artists a
 		LEFT JOIN albums al ON al.artist_id = a.id
 		GROUP BY a.id
-		ORDER BY a.sort_name
+		ORDER BY `+artistOrder(req.Sort)+`
 		LIMIT ? OFFSET ?`, pageSize+1, offset)
 	if err != nil {
 		return provider.Page[provider.Artist]{}, err
@@ -572,6 +835,57 @@ func (p *Provider) listArtists(ctx context.Context, req provider.ListReq) (provi
 	return provider.Page[provider.Artist]{Items: items, NextCursor: next, TotalHint: -1}, nil
 }
 
+// ArtistOffset counts the artists sorting before prefix using the sort_name
+// index, so the Library can jump to an initial without loading every page.
+func (p *Provider) ArtistOffset(ctx context.Context, prefix string) (int, error) {
+	var n int
+	err := p.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM artists WHERE sort_name < ?`, strings.ToLower(prefix)).Scan(&n)
+	return n, err
+}
+
+// Stats counts what the index holds.
+type Stats struct {
+	Artists int `json:"artists"`
+	Albums  int `json:"albums"`
+	Tracks  int `json:"tracks"`
+}
+
+// Stats returns the number of indexed artists, albums and tracks.
+func (p *Provider) Stats(ctx context.Context) (Stats, error) {
+	var s Stats
+	err := p.db.QueryRowContext(ctx, `SELECT (SELECT COUNT(*) FROM artists), (SELECT COUNT(*) FROM albums), (SELECT COUNT(*) FROM tracks)`).Scan(&s.Artists, &s.Albums, &s.Tracks)
+	return s, err
+}
+
+// artistOrder, albumOrder and trackOrder map a ListReq.Sort mode to an
+// ORDER BY clause. Each ends on the id so paging is stable.
+func artistOrder(mode string) string {
+	if mode == provider.SortAlbumCount {
+		return "album_count DESC, a.sort_name, a.id"
+	}
+	return "a.sort_name, a.id"
+}
+
+func albumOrder(mode string) string {
+	switch mode {
+	case provider.SortYear:
+		return "year, title, id"
+	case provider.SortRecent:
+		return "(SELECT MAX(COALESCE(t.indexed_at, t.file_mtime)) FROM tracks t WHERE t.album_id = albums.id) DESC, title, id"
+	}
+	return "title, id"
+}
+
+func trackOrder(mode string) string {
+	switch mode {
+	case provider.SortTitle:
+		return "title, id"
+	case provider.SortDuration:
+		return "duration_ms, title, id"
+	}
+	return "disc_number, track_number, title, id"
+}
+
 func (p *Provider) GetArtist(ctx context.Context, id string) (provider.Artist, error) {
 	var a provider.Artist
 	err := p.db.QueryRowContext(ctx, `
@@ -597,11 +911,16 @@ func (p *Provider) ListAlbums(ctx context.Context, artistId string, req provider
 	_, offset := parseCursor(req.Cursor)
 	query := `SELECT id,artist_id,title,year FROM albums `
 	var args []any
+	var clauses []string
 	if artistId != "" {
-		query += `WHERE artist_id=? `
+		clauses = append(clauses, "artist_id=?")
 		args = append(args, artistId)
 	}
-	query += `ORDER BY title LIMIT ? OFFSET ?`
+	clauses, args = yearClauses(req.Years, clauses, args)
+	if len(clauses) > 0 {
+		query += "WHERE " + strings.Join(clauses, " AND ") + " "
+	}
+	query += `ORDER BY ` + albumOrder(req.Sort) + ` LIMIT ? OFFSET ?`
 	args = append(args, pageSize+1, offset)
 	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -624,6 +943,24 @@ func (p *Provider) ListAlbums(ctx context.Context, artistId string, req provider
 	return provider.Page[provider.Album]{Items: items, NextCursor: next, TotalHint: -1}, nil
 }
 
+// yearClauses adds the WHERE conditions of a year range. Unknown years
+// (0) are left out of any range.
+func yearClauses(r provider.YearRange, clauses []string, args []any) ([]string, []any) {
+	if r.IsZero() {
+		return clauses, args
+	}
+	clauses = append(clauses, "year>0")
+	if r.From != 0 {
+		clauses = append(clauses, "year>=?")
+		args = append(args, r.From)
+	}
+	if r.To != 0 {
+		clauses = append(clauses, "year<=?")
+		args = append(args, r.To)
+	}
+	return clauses, args
+}
+
 func (p *Provider) GetAlbum(ctx context.Context, id string) (provider.Album, error) {
 	var a provider.Album
 	err := p.db.QueryRowContext(ctx, `SELECT id,artist_id,title,year FROM albums WHERE id=?`, id).Scan(&a.ID, &a.ArtistID, &a.Title, &a.Year)
@@ -636,6 +973,24 @@ func (p *Provider) GetAlbum(ctx context.Context, id string) (provider.Album, err
 	return a, nil
 }
 
+// trackListColumns are the columns track lists read, in scanTrackList's
+// order.
+const trackListColumns = `id,title,artist_id,artist_name,album_id,album_title,year,duration_ms,track_number,disc_number,codec,bitrate,file_path,COALESCE(sample_rate,0),COALESCE(bit_depth,0),COALESCE(channels,0),COALESCE(composer,''),COALESCE(work,''),COALESCE(movement,''),COALESCE(movement_number,0),COALESCE(genre,'')`
+
+// scanTrackList reads the tracks of a trackListColumns query.
+func scanTrackList(rows *sql.Rows) ([]provider.Track, error) {
+	var items []provider.Track
+	for rows.Next() {
+		var t provider.Track
+		if err := rows.Scan(&t.ID, &t.Title, &t.ArtistID, &t.ArtistName, &t.AlbumID, &t.AlbumTitle, &t.Year, &t.DurationMs, &t.TrackNo, &t.DiscNo, &t.Codec, &t.BitrateKbps, &t.FilePath, &t.SampleRateHz, &t.BitDepth, &t.Channels, &t.Composer, &t.Work, &t.Movement, &t.MovementNo, &t.Genre); err != nil {
+			return nil, err
+		}
+		t.ArtworkRef = t.FilePath // Use file path for artwork extraction
+		items = append(items, t)
+	}
+	return items, rows.Err()
+}
+
 func (p *Provider) ListTracks(ctx context.Context, albumId string, artistId string, playlistId string, req provider.ListReq) (provider.Page[provider.Track], error) {
 	if playlistId != "" {
 		return provider.Page[provider.Track]{}, provider.ErrNotSupported
@@ -645,10 +1000,19 @@ func (p *Provider) ListTracks(ctx context.Context, albumId string, artistId stri
 		pageSize = p.cfg.PageSize
 	}
 	_, offset := parseCursor(req.Cursor)
-	query := `SELECT id,title,artist_id,artist_name,album_id,album_title,year,duration_ms,track_number,disc_number,codec,bitrate,file_path FROM tracks `
+	query := `SELECT ` + trackListColumns + ` FROM tracks `
 	var args []any
 	var clauses []string
-	if albumId != "" {
+	order := trackOrder(req.Sort)
+	if strings.HasPrefix(albumId, workIDPrefix) {
+		clauses = append(clauses, "work_id=?")
+		args = append(args, albumId)
+		if req.Sort == "" || req.Sort == provider.SortTrackNo {
+			order = "movement_number, disc_number, track_number, id"
+		}
+		// A work's artist is its composer, not a performer
+		artistId = ""
+	} else if albumId != "" {
 		clauses = append(clauses, "album_id=?")
 		args = append(args, albumId)
 	}
@@ -656,25 +1020,20 @@ func (p *Provider) ListTracks(ctx context.Context, albumId string, artistId stri
 		clauses = append(clauses, "artist_id=?")
 		args = append(args, artistId)
 	}
+	clauses, args = yearClauses(req.Years, clauses, args)
 	if len(clauses) > 0 {
 		query += "WHERE " + strings.Join(clauses, " AND ") + " "
 	}
-	query += `ORDER BY disc_number, track_number, title LIMIT ? OFFSET ?`
+	query += `ORDER BY ` + order + ` LIMIT ? OFFSET ?`
 	args = append(args, pageSize+1, offset)
 	rows, err := p.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return provider.Page[provider.Track]{}, err
 	}
 	defer rows.Close()
-	var items []provider.Track
-	for rows.Next() {
-		var t provider.Track
-		var filePath string
-		if err := rows.Scan(&t.ID, &t.Title, &t.ArtistID, &t.ArtistName, &t.AlbumID, &t.AlbumTitle, &t.Year, &t.DurationMs, &t.TrackNo, &t.DiscNo, &t.Codec, &t.BitrateKbps, &filePath); err != nil {
-			return provider.Page[provider.Track]{}, err
-		}
-		t.ArtworkRef = filePath // Use file path for artwork extraction
-		items = append(items, t)
+	items, err := scanTrackList(rows)
+	if err != nil {
+		return provider.Page[provider.Track]{}, err
 	}
 	next := ""
 	if len(items) > pageSize {
@@ -686,44 +1045,67 @@ func (p *Provider) ListTracks(ctx context.Context, albumId string, artistId stri
 
 func (p *Provider) GetTrack(ctx context.Context, id string) (provider.Track, error) {
 	var t provider.Track
-	var filePath string
-	err := p.db.QueryRowContext(ctx, `SELECT id,title,artist_id,artist_name,album_id,album_title,year,duration_ms,track_number,disc_number,codec,bitrate,file_path FROM tracks WHERE id=?`, id).Scan(&t.ID, &t.Title, &t.ArtistID, &t.ArtistName, &t.AlbumID, &t.AlbumTitle, &t.Year, &t.DurationMs, &t.TrackNo, &t.DiscNo, &t.Codec, &t.BitrateKbps, &filePath)
+	var mtime, indexedAt int64
+	var tagsJSON string
+	err := p.db.QueryRowContext(ctx, `SELECT id,title,artist_id,artist_name,album_id,album_title,year,duration_ms,track_number,disc_number,codec,bitrate,file_path,
+		COALESCE(file_size,0),COALESCE(file_mtime,0),COALESCE(sample_rate,0),COALESCE(bit_depth,0),COALESCE(channels,0),COALESCE(replay_gain_track,''),COALESCE(replay_gain_album,''),COALESCE(tags_json,''),COALESCE(indexed_at,0),
+		COALESCE(composer,''),COALESCE(work,''),COALESCE(movement,''),COALESCE(movement_number,0),COALESCE(genre,'')
+		FROM tracks WHERE id=?`, id).Scan(&t.ID, &t.Title, &t.ArtistID, &t.ArtistName, &t.AlbumID, &t.AlbumTitle, &t.Year, &t.DurationMs, &t.TrackNo, &t.DiscNo, &t.Codec, &t.BitrateKbps, &t.FilePath,
+		&t.FileSize, &mtime, &t.SampleRateHz, &t.BitDepth, &t.Channels, &t.ReplayGainTrack, &t.ReplayGainAlbum, &tagsJSON, &indexedAt,
+		&t.Composer, &t.Work, &t.Movement, &t.MovementNo, &t.Genre)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return provider.Track{}, provider.ErrNotFound
 		}
 		return provider.Track{}, err
 	}
-	t.ArtworkRef = filePath // Use file path for artwork extraction
+	t.ArtworkRef = t.FilePath // Use file path for artwork extraction
+	if mtime > 0 {
+		t.ModifiedAt = time.Unix(mtime, 0)
+	}
+	if indexedAt > 0 {
+		t.IndexedAt = time.Unix(indexedAt, 0)
+	}
+	if tagsJSON != "" {
+		if err := json.Unmarshal([]byte(tagsJSON), &t.Tags); err != nil {
+			slog.Warn("Failed to decode stored tags", "track_id", id, "err", err)
+		}
+	}
 	return t, nil
 }
 
+// Search matches the words of q against track, album and artist names and
+// its qualifiers (artist:, year: and so on) against their columns.
 func (p *Provider) Search(ctx context.Context, q string, req provider.ListReq) (provider.SearchResults, error) {
+	query, err := provider.ParseQuery(q)
+	if err != nil {
+		return provider.SearchResults{}, err
+	}
 	pageSize := req.PageSize
 	if pageSize == 0 {
 		pageSize = p.cfg.PageSize
 	}
 	targetType, offset := parseCursor(req.Cursor)
-	pattern := "%" + strings.ToLower(q) + "%"
+	if req.SearchKind != "" {
+		targetType = req.SearchKind
+	}
+	searches := func(kind string) bool {
+		return (targetType == "" || targetType == kind) && query.Searches(kind)
+	}
 
 	var res provider.SearchResults
 
 	// Search Tracks
-	if targetType == "" || targetType == "tracks" {
-		rows, err := p.db.QueryContext(ctx, `SELECT id,title,artist_id,artist_name,album_id,album_title,year,duration_ms,track_number,disc_number,codec,bitrate,file_path FROM tracks WHERE lower(title) LIKE ? OR lower(artist_name) LIKE ? OR lower(album_title) LIKE ? ORDER BY artist_name LIMIT ? OFFSET ?`, pattern, pattern, pattern, pageSize+1, offset)
+	if searches(provider.SearchTracks) {
+		clauses, args := trackQueryClauses(query)
+		rows, err := p.db.QueryContext(ctx, `SELECT `+trackListColumns+` FROM tracks `+where(clauses)+`ORDER BY artist_name LIMIT ? OFFSET ?`, append(args, pageSize+1, offset)...)
 		if err != nil {
 			return provider.SearchResults{}, err
 		}
 		defer rows.Close()
-		var tracks []provider.Track
-		for rows.Next() {
-			var t provider.Track
-			var filePath string
-			if err := rows.Scan(&t.ID, &t.Title, &t.ArtistID, &t.ArtistName, &t.AlbumID, &t.AlbumTitle, &t.Year, &t.DurationMs, &t.TrackNo, &t.DiscNo, &t.Codec, &t.BitrateKbps, &filePath); err != nil {
-				return provider.SearchResults{}, err
-			}
-			t.ArtworkRef = filePath // Use file path for artwork extraction
-			tracks = append(tracks, t)
+		tracks, err := scanTrackList(rows)
+		if err != nil {
+			return provider.SearchResults{}, err
 		}
 		next := ""
 		if len(tracks) > pageSize {
@@ -734,8 +1116,9 @@ func (p *Provider) Search(ctx context.Context, q string, req provider.ListReq) (
 	}
 
 	// Search Albums
-	if targetType == "" || targetType == "albums" {
-		rows, err := p.db.QueryContext(ctx, `SELECT id,artist_id,title,year FROM albums WHERE lower(title) LIKE ? ORDER BY title LIMIT ? OFFSET ?`, pattern, pageSize+1, offset)
+	if searches(provider.SearchAlbums) {
+		clauses, args := albumQueryClauses(query)
+		rows, err := p.db.QueryContext(ctx, `SELECT id,artist_id,title,year FROM albums `+where(clauses)+`ORDER BY title LIMIT ? OFFSET ?`, append(args, pageSize+1, offset)...)
 		if err != nil {
 			return provider.SearchResults{}, err
 		}
@@ -757,15 +1140,23 @@ func (p *Provider) Search(ctx context.Context, q string, req provider.ListReq) (
 	}
 
 	// Search Artists
-	if targetType == "" || targetType == "artists" {
+	if searches(provider.SearchArtists) {
+		var clauses []string
+		var args []any
+		for _, s := range []string{query.Text, query.Artist} {
+			if s != "" {
+				clauses = append(clauses, "lower(a.name) LIKE ?")
+				args = append(args, likePattern(s))
+			}
+		}
 		rows, err := p.db.QueryContext(ctx, `
 			SELECT a.id, a.name, a.sort_name, COUNT(al.id) as album_count
 			FROM artists a
 			LEFT JOIN albums al ON al.artist_id = a.id
-			WHERE lower(a.name) LIKE ?
+			`+where(clauses)+`
 			GROUP BY a.id
 			ORDER BY a.sort_name
-			LIMIT ? OFFSET ?`, pattern, pageSize+1, offset)
+			LIMIT ? OFFSET ?`, append(args, pageSize+1, offset)...)
 		if err != nil {
 			return provider.SearchResults{}, err
 		}
@@ -789,6 +1180,166 @@ func (p *Provider) Search(ctx context.Context, q string, req provider.ListReq) (
 	return res, nil
 }
 
+// trackQueryClauses returns the WHERE conditions of a search on tracks.
+func trackQueryClauses(q provider.Query) (clauses []string, args []any) {
+	if q.Text != "" {
+		pattern := likePattern(q.Text)
+		clauses = append(clauses, "(lower(title) LIKE ? OR lower(artist_name) LIKE ? OR lower(album_title) LIKE ? OR lower(composer) LIKE ?)")
+		args = append(args, pattern, pattern, pattern, pattern)
+	}
+	for _, f := range []struct{ column, s string }{{"artist_name", q.Artist}, {"album_title", q.Album}, {"title", q.Title}, {"genre", q.Genre}} {
+		if f.s != "" {
+			clauses = append(clauses, "lower("+f.column+") LIKE ?")
+			args = append(args, likePattern(f.s))
+		}
+	}
+	clauses, args = yearClauses(q.Years, clauses, args)
+	if q.Duration.Min != 0 {
+		clauses = append(clauses, "duration_ms>=?")
+		args = append(args, q.Duration.Min.Milliseconds())
+	}
+	if q.Duration.Max != 0 {
+		clauses = append(clauses, "duration_ms>0", "duration_ms<=?")
+		args = append(args, q.Duration.Max.Milliseconds())
+	}
+	return clauses, args
+}
+
+// albumQueryClauses returns the WHERE conditions of a search on albums.
+// The artist and genre qualifiers look through the artists and tracks
+// tables, as albums keep neither.
+func albumQueryClauses(q provider.Query) (clauses []string, args []any) {
+	for _, s := range []string{q.Text, q.Album} {
+		if s != "" {
+			clauses = append(clauses, "lower(title) LIKE ?")
+			args = append(args, likePattern(s))
+		}
+	}
+	if q.Artist != "" {
+		clauses = append(clauses, "artist_id IN (SELECT id FROM artists WHERE lower(name) LIKE ?)")
+		args = append(args, likePattern(q.Artist))
+	}
+	if q.Genre != "" {
+		clauses = append(clauses, "id IN (SELECT album_id FROM tracks WHERE lower(genre) LIKE ?)")
+		args = append(args, likePattern(q.Genre))
+	}
+	return yearClauses(q.Years, clauses, args)
+}
+
+// likePattern matches s anywhere in a lowercased column.
+func likePattern(s string) string {
+	return "%" + strings.ToLower(s) + "%"
+}
+
+// where joins clauses into a WHERE clause, or "" when there are none.
+func where(clauses []string) string {
+	if len(clauses) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(clauses, " AND ") + " "
+}
+
+// ListComposers lists the composers of tagged tracks by name, with their
+// work count as AlbumCount.
+func (p *Provider) ListComposers(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = p.cfg.PageSize
+	}
+	_, offset := parseCursor(req.Cursor)
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT composer_id, MIN(composer), COUNT(DISTINCT work_id)
+		FROM tracks
+		WHERE composer_id <> ''
+		GROUP BY composer_id
+		ORDER BY lower(MIN(composer)), composer_id
+		LIMIT ? OFFSET ?`, pageSize+1, offset)
+	if err != nil {
+		return provider.Page[provider.Artist]{}, err
+	}
+	defer rows.Close()
+	var items []provider.Artist
+	for rows.Next() {
+		var a provider.Artist
+		if err := rows.Scan(&a.ID, &a.Name, &a.AlbumCount); err != nil {
+			return provider.Page[provider.Artist]{}, err
+		}
+		a.SortName = strings.ToLower(a.Name)
+		items = append(items, a)
+	}
+	next := ""
+	if len(items) > pageSize {
+		next = fmt.Sprintf("%d", offset+pageSize)
+		items = items[:pageSize]
+	}
+	return provider.Page[provider.Artist]{Items: items, NextCursor: next, TotalHint: -1}, nil
+}
+
+// ListWorks lists the works of a composer by title, with their movement
+// count as TrackCount and the year of their earliest recording.
+func (p *Provider) ListWorks(ctx context.Context, composerID string, req provider.ListReq) (provider.Page[provider.Album], error) {
+	pageSize := req.PageSize
+	if pageSize == 0 {
+		pageSize = p.cfg.PageSize
+	}
+	_, offset := parseCursor(req.Cursor)
+	clauses := []string{"work_id <> ''"}
+	var args []any
+	if composerID != "" {
+		clauses = append(clauses, "composer_id=?")
+		args = append(args, composerID)
+	}
+	args = append(args, pageSize+1, offset)
+	rows, err := p.db.QueryContext(ctx, `
+		SELECT work_id, MIN(work), MIN(composer_id), MIN(composer), COALESCE(MIN(NULLIF(year,0)),0), COUNT(*), MIN(file_path)
+		FROM tracks
+		WHERE `+strings.Join(clauses, " AND ")+`
+		GROUP BY work_id
+		ORDER BY lower(MIN(work)), work_id
+		LIMIT ? OFFSET ?`, args...)
+	if err != nil {
+		return provider.Page[provider.Album]{}, err
+	}
+	defer rows.Close()
+	var items []provider.Album
+	for rows.Next() {
+		var a provider.Album
+		if err := rows.Scan(&a.ID, &a.Title, &a.ArtistID, &a.ArtistName, &a.Year, &a.TrackCount, &a.ArtworkRef); err != nil {
+			return provider.Page[provider.Album]{}, err
+		}
+		items = append(items, a)
+	}
+	next := ""
+	if len(items) > pageSize {
+		next = fmt.Sprintf("%d", offset+pageSize)
+		items = items[:pageSize]
+	}
+	return provider.Page[provider.Album]{Items: items, NextCursor: next, TotalHint: -1}, nil
+}
+
+// ResolveTrack finds an indexed track by file path, or failing that by
+// title and artist, for importing playlist files.
+func (p *Provider) ResolveTrack(ctx context.Context, path, title, artist string) (provider.Track, error) {
+	var id string
+	if path != "" {
+		err := p.db.QueryRowContext(ctx, `SELECT id FROM tracks WHERE file_path=?`, filepath.Clean(path)).Scan(&id)
+		if err != nil && err != sql.ErrNoRows {
+			return provider.Track{}, err
+		}
+	}
+	if id == "" && title != "" {
+		err := p.db.QueryRowContext(ctx, `SELECT id FROM tracks WHERE title=? COLLATE NOCASE AND (?='' OR artist_name=? COLLATE NOCASE)
+			ORDER BY album_title, disc_number, track_number LIMIT 1`, title, artist, artist).Scan(&id)
+		if err != nil && err != sql.ErrNoRows {
+			return provider.Track{}, err
+		}
+	}
+	if id == "" {
+		return provider.Track{}, provider.ErrNotFound
+	}
+	return p.GetTrack(ctx, id)
+}
+
 func (p *Provider) ListPlaylists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Playlist], error) {
 	return provider.Page[provider.Playlist]{}, provider.ErrNotSupported
 }
@@ -809,8 +1360,12 @@ func (p *Provider) GetStream(ctx context.Context, trackId string) (provider.Stre
 	if _, err := os.Stat(path); err != nil {
 		return provider.StreamInfo{}, fmt.Errorf("track missing: %w", err)
 	}
+	gain, err := p.measuredGain(ctx, trackId)
+	if err != nil {
+		slog.Warn("read measured loudness", "track", trackId, "err", err)
+	}
 	u := url.URL{Scheme: "file", Path: path}
-	return provider.StreamInfo{URL: u.String()}, nil
+	return provider.StreamInfo{URL: u.String(), Gain: gain}, nil
 }
 
 func (p *Provider) GetLyrics(ctx context.Context, trackId string) (provider.Lyrics, error) {
@@ -931,12 +1486,15 @@ func (p *Provider) GetArtwork(ctx context.Context, ref string, sizePx int) (prov
 	}, nil
 }
 
+// folderArtworkNames are the image files an album folder's artwork is
+// looked for in, in order.
+var folderArtworkNames = []string{"folder.jpg", "cover.jpg", "album.jpg", "front.jpg", "folder.png", "cover.png", "album.png", "front.png"}
+
 // getFolderArtwork looks for folder.jpg, cover.jpg, etc. in the same directory
 func (p *Provider) getFolderArtwork(trackPath string) (provider.Artwork, error) {
 	dir := filepath.Dir(trackPath)
-	coverNames := []string{"folder.jpg", "cover.jpg", "album.jpg", "front.jpg", "folder.png", "cover.png", "album.png", "front.png"}
 
-	for _, name := range coverNames {
+	for _, name := range folderArtworkNames {
 		coverPath := filepath.Join(dir, name)
 		data, err := os.ReadFile(coverPath)
 		if err == nil && len(data) > 0 {
@@ -980,6 +1538,9 @@ type audioInfo struct {
 	DurationMs  int
 	Codec       string
 	BitrateKbps int
+	SampleRate  int
+	BitDepth    int
+	Channels    int
 }
 
 // getAudioInfo uses ffprobe to extract audio metadata
@@ -996,8 +1557,12 @@ func getAudioInfo(path string) audioInfo {
 			BitRate  string `json:"bit_rate"`
 		} `json:"format"`
 		Streams []struct {
-			CodecName string `json:"codec_name"`
-			CodecType string `json:"codec_type"`
+			CodecName        string `json:"codec_name"`
+			CodecType        string `json:"codec_type"`
+			SampleRate       string `json:"sample_rate"`
+			Channels         int    `json:"channels"`
+			BitsPerSample    int    `json:"bits_per_sample"`
+			BitsPerRawSample string `json:"bits_per_raw_sample"`
 		} `json:"streams"`
 	}
 
@@ -1008,26 +1573,40 @@ func getAudioInfo(path string) audioInfo {
 	info := audioInfo{}
 
 	// Duration
-	if result.Format.Duration != "" {
-		var secs float64
-		fmt.Sscanf(result.Format.Duration, "%f", &secs)
+	if secs, err := strconv.ParseFloat(result.Format.Duration, 64); err == nil {
 		info.DurationMs = int(secs * 1000)
 	}
 
 	// Bitrate (convert from bps to kbps)
-	if result.Format.BitRate != "" {
-		var bps int
-		fmt.Sscanf(result.Format.BitRate, "%d", &bps)
+	if bps, err := strconv.Atoi(result.Format.BitRate); err == nil {
 		info.BitrateKbps = bps / 1000
 	}
 
-	// Codec - find the audio stream
+	// Codec, sample rate, bit depth and channels - find the audio stream
 	for _, s := range result.Streams {
 		if s.CodecType == "audio" && s.CodecName != "" {
 			info.Codec = s.CodecName
+			if rate, err := strconv.Atoi(s.SampleRate); err == nil {
+				info.SampleRate = rate
+			}
+			info.Channels = s.Channels
+			info.BitDepth = bitDepth(s.CodecName, s.BitsPerRawSample, s.BitsPerSample)
 			break
 		}
 	}
 
 	return info
 }
+
+// bitDepth picks the stored sample size for an audio stream. ffprobe reports
+// bits_per_raw_sample for lossless codecs (FLAC, ALAC) and bits_per_sample for
+// PCM; lossy codecs have no meaningful bit depth and return 0.
+func bitDepth(codec, rawSample string, sample int) int {
+	if depth, err := strconv.Atoi(rawSample); err == nil && depth > 0 {
+		return depth
+	}
+	if strings.HasPrefix(codec, "pcm_") {
+		return sample
+	}
+	return 0
+}
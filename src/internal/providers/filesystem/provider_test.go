@@ -2,9 +2,11 @@ package filesystem
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
@@ -336,6 +338,108 @@ func TestParseYearValue(t *testing.T) {
 	}
 }
 
+// TestExtractTags tests flattening of raw tag frames for the track info view
+func TestExtractTags(t *testing.T) {
+	meta := &mockMetadata{raw: map[string]any{
+		"TPE1":   "Artist",
+		"TRCK":   7,
+		"TXXX":   &tag.Comm{Description: "REPLAYGAIN_TRACK_GAIN", Text: "-6.20 dB"},
+		"TXXX_0": &tag.Comm{Description: "REPLAYGAIN_ALBUM_GAIN", Text: "-5.80 dB"},
+		"COMM":   &tag.Comm{Language: "eng", Text: "A comment"},
+		"USLT":   &tag.Comm{Language: "eng", Text: "la la la"},
+		"APIC":   &tag.Picture{MIMEType: "image/jpeg"},
+		"TCOM":   "",
+	}}
+
+	tags := extractTags(meta)
+	want := map[string]string{
+		"TPE1":                  "Artist",
+		"TRCK":                  "7",
+		"REPLAYGAIN_TRACK_GAIN": "-6.20 dB",
+		"REPLAYGAIN_ALBUM_GAIN": "-5.80 dB",
+		"COMM":                  "A comment",
+	}
+	if len(tags) != len(want) {
+		t.Errorf("expected %d tags, got %d: %v", len(want), len(tags), tags)
+	}
+	for k, v := range want {
+		if tags[k] != v {
+			t.Errorf("tag %s = %q, want %q", k, tags[k], v)
+		}
+	}
+	if got := lookupTag(tags, "replaygain_track_gain"); got != "-6.20 dB" {
+		t.Errorf("lookupTag() = %q, want %q", got, "-6.20 dB")
+	}
+}
+
+// TestGetTrackExtendedMetadata verifies GetTrack returns file details stored at scan time
+func TestGetTrackExtendedMetadata(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	song := filepath.Join(dir, "track.mp3")
+	if err := os.WriteFile(song, []byte("fake audio"), 0o644); err != nil {
+		t.Fatalf("write file: %v", err)
+	}
+
+	p := New()
+	settings := map[string]any{
+		"roots":    []any{dir},
+		"index_db": filepath.Join(dir, "index.sqlite"),
+	}
+	if err := p.Initialize(ctx, settings); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	page, err := p.ListTracks(ctx, "", "", "", provider.ListReq{PageSize: 10})
+	if err != nil || len(page.Items) != 1 {
+		t.Fatalf("list tracks: %v (%d items)", err, len(page.Items))
+	}
+
+	track, err := p.GetTrack(ctx, page.Items[0].ID)
+	if err != nil {
+		t.Fatalf("get track: %v", err)
+	}
+	if track.FilePath != song {
+		t.Errorf("FilePath = %q, want %q", track.FilePath, song)
+	}
+	if track.FileSize != int64(len("fake audio")) {
+		t.Errorf("FileSize = %d, want %d", track.FileSize, len("fake audio"))
+	}
+	if track.ModifiedAt.IsZero() {
+		t.Error("expected ModifiedAt to be set")
+	}
+	if track.IndexedAt.IsZero() {
+		t.Error("expected IndexedAt to be set")
+	}
+
+	if _, err := p.GetTrack(ctx, "missing"); !provider.IsNotFound(err) {
+		t.Errorf("expected ErrNotFound for missing track, got %v", err)
+	}
+}
+
+// TestBitDepth tests bit depth selection from ffprobe stream fields
+func TestBitDepth(t *testing.T) {
+	tests := []struct {
+		name      string
+		codec     string
+		rawSample string
+		sample    int
+		want      int
+	}{
+		{"flac 24-bit", "flac", "24", 0, 24},
+		{"alac 16-bit", "alac", "16", 0, 16},
+		{"pcm wav", "pcm_s16le", "", 16, 16},
+		{"mp3 has no depth", "mp3", "", 0, 0},
+		{"lossy ignores sample size", "aac", "", 32, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := bitDepth(tt.codec, tt.rawSample, tt.sample); got != tt.want {
+				t.Errorf("bitDepth() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
 // mockMetadata implements tag.Metadata for testing
 type mockMetadata struct {
 	raw map[string]any
@@ -401,3 +505,385 @@ func (m *mockMetadata) Comment() string {
 func (m *mockMetadata) Raw() map[string]any {
 	return m.raw
 }
+
+func TestListSortModes(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	stmts := []string{
+		`INSERT INTO artists VALUES ('a1', 'Abba', 'abba'), ('a2', 'Queen', 'queen')`,
+		`INSERT INTO albums (id, artist_id, title, year) VALUES ('al1', 'a2', 'Innuendo', 1991), ('al2', 'a2', 'Jazz', 1978), ('al3', 'a1', 'Arrival', 1976)`,
+		`INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, indexed_at) VALUES
+			('t1', 'al2', 'a2', 'Mustapha', 'Jazz', 'Queen', 0, 1, 1, 180000, '', 0, '/m/1', 100),
+			('t2', 'al2', 'a2', 'Fat Bottomed Girls', 'Jazz', 'Queen', 0, 2, 1, 250000, '', 0, '/m/2', 100),
+			('t3', 'al1', 'a2', 'Innuendo', 'Innuendo', 'Queen', 0, 1, 1, 390000, '', 0, '/m/3', 300),
+			('t4', 'al3', 'a1', 'Dancing Queen', 'Arrival', 'Abba', 0, 1, 1, 230000, '', 0, '/m/4', 200)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	artists, err := p.ListArtists(ctx, provider.ListReq{PageSize: 10, Sort: provider.SortAlbumCount})
+	if err != nil || len(artists.Items) != 2 || artists.Items[0].Name != "Queen" {
+		t.Errorf("expected Queen first by album count, got %+v %v", artists.Items, err)
+	}
+
+	albumTitles := func(sort string) []string {
+		page, err := p.ListAlbums(ctx, "", provider.ListReq{PageSize: 10, Sort: sort})
+		if err != nil {
+			t.Fatalf("list albums (%s): %v", sort, err)
+		}
+		var out []string
+		for _, a := range page.Items {
+			out = append(out, a.Title)
+		}
+		return out
+	}
+	for sort, want := range map[string][]string{
+		"":                  {"Arrival", "Innuendo", "Jazz"},
+		provider.SortYear:   {"Arrival", "Jazz", "Innuendo"},
+		provider.SortRecent: {"Innuendo", "Arrival", "Jazz"},
+	} {
+		if got := albumTitles(sort); !slices.Equal(got, want) {
+			t.Errorf("albums by %q: expected %v, got %v", sort, want, got)
+		}
+	}
+
+	tracks, err := p.ListTracks(ctx, "al2", "", "", provider.ListReq{PageSize: 10, Sort: provider.SortTitle})
+	if err != nil || len(tracks.Items) != 2 || tracks.Items[0].Title != "Fat Bottomed Girls" {
+		t.Errorf("expected tracks by title, got %+v %v", tracks.Items, err)
+	}
+	tracks, err = p.ListTracks(ctx, "", "", "", provider.ListReq{PageSize: 10, Sort: provider.SortDuration})
+	if err != nil || len(tracks.Items) != 4 || tracks.Items[0].ID != "t1" || tracks.Items[3].ID != "t3" {
+		t.Errorf("expected tracks by duration, got %+v %v", tracks.Items, err)
+	}
+}
+
+func TestArtistOffset(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := p.db.ExecContext(ctx, `INSERT INTO artists VALUES ('a1', 'Abba', 'abba'), ('a2', 'Blur', 'blur'), ('a3', 'Queen', 'queen')`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	for prefix, want := range map[string]int{"a": 0, "B": 1, "m": 2, "z": 3} {
+		got, err := p.ArtistOffset(ctx, prefix)
+		if err != nil || got != want {
+			t.Errorf("ArtistOffset(%q) = %d, %v; want %d", prefix, got, err, want)
+		}
+	}
+
+	if st, err := p.Stats(ctx); err != nil || st != (Stats{Artists: 3}) {
+		t.Errorf("expected 3 artists and nothing else, got %+v %v", st, err)
+	}
+
+	// The offset is where the initial's page starts
+	page, err := p.ListArtists(ctx, provider.ListReq{PageSize: 1, Cursor: "2"})
+	if err != nil || len(page.Items) != 1 || page.Items[0].Name != "Queen" {
+		t.Errorf("expected Queen at offset 2, got %+v %v", page.Items, err)
+	}
+}
+
+func TestScanInterruptedKeepsIndex(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	for i := range 30 {
+		if err := os.WriteFile(filepath.Join(dir, fmt.Sprintf("%02d - Song.mp3", i)), []byte("fake mp3 content"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := p.db.ExecContext(ctx, `INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, file_size, file_mtime, indexed_at) VALUES ('t1', 'al1', 'a1', 'Gone', 'Album', 'Artist', 0, 1, 1, 1000, '', 0, '/m/gone.mp3', 2048, 100, 100)`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+	indexed := func(path string) bool {
+		var n int
+		if err := p.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tracks WHERE file_path = ?", path).Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n > 0
+	}
+	countTracks := func() int {
+		var n int
+		if err := p.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM tracks").Scan(&n); err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// Interrupt as soon as the first progress update arrives
+	p.cfg.Roots = []string{dir}
+	scanCtx, cancel := context.WithCancel(ctx)
+	p.cfg.ScanProgress = func(int, string) { cancel() }
+	if err := p.scan(scanCtx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the scan to report the interruption, got %v", err)
+	}
+	// What was read is committed, and nothing is pruned since the scan
+	// did not see every file
+	if n := countTracks(); n < 11 {
+		t.Errorf("expected the tracks read before the interrupt to be committed, got %d", n)
+	}
+	if !indexed("/m/gone.mp3") {
+		t.Error("expected an interrupted scan not to prune unseen files")
+	}
+
+	p.cfg.ScanProgress = nil
+	if err := p.scan(ctx); err != nil {
+		t.Fatalf("scan: %v", err)
+	}
+	if n := countTracks(); n != 30 || indexed("/m/gone.mp3") {
+		t.Errorf("expected a complete scan to index 30 files and prune the missing one, got %d", n)
+	}
+}
+
+func TestResolveTrack(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	stmts := []string{
+		`INSERT INTO artists VALUES ('a1', 'Abba', 'abba'), ('a2', 'Queen', 'queen')`,
+		`INSERT INTO albums (id, artist_id, title, year) VALUES ('al1', 'a2', 'Jazz', 1978), ('al2', 'a1', 'Arrival', 1976)`,
+		`INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, indexed_at) VALUES
+			('t1', 'al1', 'a2', 'Mustapha', 'Jazz', 'Queen', 0, 1, 1, 180000, '', 0, '/m/jazz/01.flac', 100),
+			('t2', 'al2', 'a1', 'Dancing Queen', 'Arrival', 'Abba', 0, 1, 1, 230000, '', 0, '/m/arrival/01.flac', 100)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	if tr, err := p.ResolveTrack(ctx, "/m/jazz/01.flac", "", ""); err != nil || tr.ID != "t1" || tr.FilePath != "/m/jazz/01.flac" {
+		t.Errorf("expected t1 by path, got %+v %v", tr, err)
+	}
+	// A path from another machine falls back to the tags
+	if tr, err := p.ResolveTrack(ctx, "/home/x/Music/dq.mp3", "dancing queen", "ABBA"); err != nil || tr.ID != "t2" {
+		t.Errorf("expected t2 by title and artist, got %+v %v", tr, err)
+	}
+	if _, err := p.ResolveTrack(ctx, "", "Dancing Queen", "Queen"); !errors.Is(err, provider.ErrNotFound) {
+		t.Errorf("expected no match for the wrong artist, got %v", err)
+	}
+
+	page, err := p.ListTracks(ctx, "al1", "", "", provider.ListReq{PageSize: 10})
+	if err != nil || len(page.Items) != 1 || page.Items[0].FilePath != "/m/jazz/01.flac" {
+		t.Errorf("expected listed tracks to carry their file path, got %+v %v", page.Items, err)
+	}
+}
+
+func TestListYearRange(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	stmts := []string{
+		`INSERT INTO artists VALUES ('a1', 'Abba', 'abba'), ('a2', 'Queen', 'queen')`,
+		`INSERT INTO albums (id, artist_id, title, year) VALUES ('al1', 'a2', 'Innuendo', 1991), ('al2', 'a2', 'Jazz', 1978), ('al3', 'a1', 'Arrival', 1976), ('al4', 'a1', 'Bootleg', 0)`,
+		`INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, indexed_at) VALUES
+			('t1', 'al2', 'a2', 'Mustapha', 'Jazz', 'Queen', 1978, 1, 1, 180000, '', 0, '/m/1', 100),
+			('t3', 'al1', 'a2', 'Innuendo', 'Innuendo', 'Queen', 1991, 1, 1, 390000, '', 0, '/m/3', 300)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	albumTitles := func(artistID string, years provider.YearRange) []string {
+		page, err := p.ListAlbums(ctx, artistID, provider.ListReq{PageSize: 10, Sort: provider.SortYear, Years: years})
+		if err != nil {
+			t.Fatalf("list albums (%v): %v", years, err)
+		}
+		var out []string
+		for _, a := range page.Items {
+			out = append(out, a.Title)
+		}
+		return out
+	}
+	if got := albumTitles("", provider.Decade(1970)); !slices.Equal(got, []string{"Arrival", "Jazz"}) {
+		t.Errorf("expected the 1970s albums, got %v", got)
+	}
+	if got := albumTitles("a2", provider.YearRange{From: 1977}); !slices.Equal(got, []string{"Jazz", "Innuendo"}) {
+		t.Errorf("expected Queen from 1977 on, got %v", got)
+	}
+	if got := albumTitles("a1", provider.YearRange{}); len(got) != 2 {
+		t.Errorf("expected no filter to list unknown years too, got %v", got)
+	}
+
+	tracks, err := p.ListTracks(ctx, "", "a2", "", provider.ListReq{PageSize: 10, Years: provider.YearRange{From: 1990, To: 1995}})
+	if err != nil || len(tracks.Items) != 1 || tracks.Items[0].ID != "t3" {
+		t.Errorf("expected the 1991 track, got %+v %v", tracks.Items, err)
+	}
+}
+
+func TestSearchQualifiers(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	stmts := []string{
+		`INSERT INTO artists VALUES ('a1', 'Pink Floyd', 'pink floyd'), ('a2', 'Queen', 'queen')`,
+		`INSERT INTO albums (id, artist_id, title, year) VALUES ('al1', 'a1', 'The Wall', 1979), ('al2', 'a1', 'Animals', 1977), ('al3', 'a2', 'Jazz', 1978)`,
+		`INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, indexed_at, genre) VALUES
+			('t1', 'al1', 'a1', 'Comfortably Numb', 'The Wall', 'Pink Floyd', 1979, 6, 2, 383000, '', 0, '/m/1', 1, 'Progressive Rock'),
+			('t2', 'al1', 'a1', 'Mother', 'The Wall', 'Pink Floyd', 1979, 4, 1, 332000, '', 0, '/m/2', 1, 'Progressive Rock'),
+			('t3', 'al1', 'a1', 'Hey You', 'The Wall', 'Pink Floyd', 1979, 1, 2, 280000, '', 0, '/m/3', 1, 'Progressive Rock'),
+			('t4', 'al2', 'a1', 'Dogs', 'Animals', 'Pink Floyd', 1977, 2, 1, 1024000, '', 0, '/m/4', 1, 'Progressive Rock'),
+			('t5', 'al3', 'a2', 'Mustapha', 'Jazz', 'Queen', 1978, 1, 1, 179000, '', 0, '/m/5', 1, 'Rock')`,
+	}
+	for _, stmt := range stmts {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	search := func(q string) provider.SearchResults {
+		res, err := p.Search(ctx, q, provider.ListReq{PageSize: 10})
+		if err != nil {
+			t.Fatalf("search %q: %v", q, err)
+		}
+		return res
+	}
+	trackIDs := func(res provider.SearchResults) []string {
+		var ids []string
+		for _, tr := range res.Tracks.Items {
+			ids = append(ids, tr.ID)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	res := search(`artist:floyd album:"the wall" year:1979 genre:rock dur:>5m`)
+	if got := trackIDs(res); !slices.Equal(got, []string{"t1", "t2"}) {
+		t.Errorf("expected the long tracks of The Wall, got %v", got)
+	}
+	// Albums have no length, so a dur: search finds only tracks
+	if len(res.Albums.Items) != 0 || len(res.Artists.Items) != 0 {
+		t.Errorf("expected only tracks, got %+v %+v", res.Albums.Items, res.Artists.Items)
+	}
+
+	// Free words still search every name alongside the qualifiers
+	if got := trackIDs(search("numb year:1970s")); !slices.Equal(got, []string{"t1"}) {
+		t.Errorf("expected Comfortably Numb, got %v", got)
+	}
+	if got := trackIDs(search("dur:3m-6m")); !slices.Equal(got, []string{"t2", "t3"}) {
+		t.Errorf("expected the 3 to 6 minute tracks, got %v", got)
+	}
+
+	res = search("artist:floyd genre:progressive")
+	if len(res.Albums.Items) != 2 || res.Albums.Items[0].Title != "Animals" {
+		t.Errorf("expected both Pink Floyd albums, got %+v", res.Albums.Items)
+	}
+	if len(res.Artists.Items) != 0 {
+		t.Errorf("expected no artists for a genre search, got %+v", res.Artists.Items)
+	}
+	res = search("artist:queen")
+	if len(res.Artists.Items) != 1 || res.Artists.Items[0].Name != "Queen" || len(res.Albums.Items) != 1 {
+		t.Errorf("expected Queen and their album, got %+v %+v", res.Artists.Items, res.Albums.Items)
+	}
+
+	if _, err := p.Search(ctx, "year:19", provider.ListReq{}); err == nil {
+		t.Error("expected an invalid year to fail")
+	}
+}
+
+func TestClassicalTags(t *testing.T) {
+	tests := []struct {
+		name     string
+		tags     map[string]string
+		title    string
+		work     string
+		movement string
+		no       int
+	}{
+		{"work tags", map[string]string{"WORK": "Symphony No. 5", "MOVEMENTNAME": "Andante con moto", "MOVEMENT": "2"}, "Andante", "Symphony No. 5", "Andante con moto", 2},
+		{"id3 movement of", map[string]string{"work": "Goldberg Variations", "MVNM": "Aria", "MVIN": "1/32"}, "Aria", "Goldberg Variations", "Aria", 1},
+		{"split title", nil, "Symphony No. 5 in C minor, Op. 67: I. Allegro con brio", "Symphony No. 5 in C minor, Op. 67", "Allegro con brio", 1},
+		{"work tag, numbered title", map[string]string{"WORK": "The Four Seasons"}, "Spring - 3. Allegro", "The Four Seasons", "Allegro", 3},
+		{"work tag, plain title", map[string]string{"WORK": "Carmen"}, "Habanera", "Carmen", "Habanera", 0},
+		{"standalone piece", nil, "Clair de lune", "Clair de lune", "", 0},
+		{"colon without movement", nil, "Prelude: Nocturne", "Prelude: Nocturne", "", 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			work, movement, no := classicalTags(tt.tags, tt.title)
+			if work != tt.work || movement != tt.movement || no != tt.no {
+				t.Errorf("got %q / %q / %d, want %q / %q / %d", work, movement, no, tt.work, tt.movement, tt.no)
+			}
+		})
+	}
+}
+
+func TestListComposersAndWorks(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	beethoven, symphony := classicalIDs("Ludwig van Beethoven", "Symphony No. 5")
+	_, sonata := classicalIDs("Ludwig van Beethoven", "Moonlight Sonata")
+	bach, goldberg := classicalIDs("Johann Sebastian Bach", "Goldberg Variations")
+	insert := `INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, composer, composer_id, work, work_id, movement, movement_number) VALUES (?, 'al1', 'a1', ?, 'Album', 'Orchestra', 1990, ?, 1, 1000, '', 0, ?, ?, ?, ?, ?, ?, ?)`
+	rows := [][]any{
+		// Movements out of track order, as on a compilation
+		{"t1", "Allegro", 2, "/m/1", "Ludwig van Beethoven", beethoven, "Symphony No. 5", symphony, "Allegro con brio", 1},
+		{"t2", "Andante", 1, "/m/2", "Ludwig van Beethoven", beethoven, "Symphony No. 5", symphony, "Andante con moto", 2},
+		{"t3", "Moonlight Sonata", 3, "/m/3", "Ludwig van Beethoven", beethoven, "Moonlight Sonata", sonata, "", 0},
+		{"t4", "Aria", 4, "/m/4", "Johann Sebastian Bach", bach, "Goldberg Variations", goldberg, "Aria", 1},
+		{"t5", "Pop Song", 5, "/m/5", "", "", "", "", "", 0},
+	}
+	for _, r := range rows {
+		if _, err := p.db.ExecContext(ctx, insert, r...); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	composers, err := p.ListComposers(ctx, provider.ListReq{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(composers.Items) != 2 || composers.Items[0].Name != "Johann Sebastian Bach" || composers.Items[1].AlbumCount != 2 {
+		t.Fatalf("expected Bach then Beethoven with 2 works, got %+v", composers.Items)
+	}
+
+	works, err := p.ListWorks(ctx, beethoven, provider.ListReq{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(works.Items) != 2 || works.Items[0].Title != "Moonlight Sonata" || works.Items[1].ID != symphony || works.Items[1].TrackCount != 2 {
+		t.Fatalf("unexpected works %+v", works.Items)
+	}
+	if works.Items[1].ArtistName != "Ludwig van Beethoven" || works.Items[1].Year != 1990 {
+		t.Errorf("expected the composer and year on the work, got %+v", works.Items[1])
+	}
+
+	movements, err := p.ListTracks(ctx, symphony, beethoven, "", provider.ListReq{PageSize: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(movements.Items) != 2 || movements.Items[0].ID != "t1" || movements.Items[0].MovementTitle() != "I. Allegro con brio" {
+		t.Fatalf("expected movements in order, got %+v", movements.Items)
+	}
+	if tr, err := p.GetTrack(ctx, "t2"); err != nil || tr.Composer != "Ludwig van Beethoven" || tr.Work != "Symphony No. 5" {
+		t.Errorf("expected classical metadata from GetTrack, got %+v %v", tr, err)
+	}
+}
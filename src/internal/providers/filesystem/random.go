@@ -0,0 +1,80 @@
+package filesystem
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// RandomAlbum picks an album uniformly at random: a count of the albums,
+// then the row at a random offset, so nothing but the one album is read
+// out of the index.
+func (p *Provider) RandomAlbum(ctx context.Context) (provider.Album, error) {
+	var n int
+	if err := p.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM albums`).Scan(&n); err != nil {
+		return provider.Album{}, err
+	}
+	if n == 0 {
+		return provider.Album{}, provider.ErrNotFound
+	}
+	var a provider.Album
+	err := p.db.QueryRowContext(ctx, `
+		SELECT al.id, al.artist_id, COALESCE(ar.name, ''), al.title, COALESCE(al.year, 0)
+		FROM albums al LEFT JOIN artists ar ON ar.id = al.artist_id
+		ORDER BY al.id LIMIT 1 OFFSET ?`, rand.IntN(n)).Scan(&a.ID, &a.ArtistID, &a.ArtistName, &a.Title, &a.Year)
+	if errors.Is(err, sql.ErrNoRows) {
+		// Albums went away in a rescan between the two queries
+		return provider.Album{}, provider.ErrNotFound
+	}
+	return a, err
+}
+
+// RandomArtist picks an artist with albums uniformly at random, the same
+// way as RandomAlbum.
+func (p *Provider) RandomArtist(ctx context.Context) (provider.Artist, error) {
+	var n int
+	if err := p.db.QueryRowContext(ctx, `SELECT COUNT(DISTINCT artist_id) FROM albums`).Scan(&n); err != nil {
+		return provider.Artist{}, err
+	}
+	if n == 0 {
+		return provider.Artist{}, provider.ErrNotFound
+	}
+	var a provider.Artist
+	err := p.db.QueryRowContext(ctx, `
+		SELECT a.id, a.name, a.sort_name, COUNT(al.id)
+		FROM artists a JOIN albums al ON al.artist_id = a.id
+		GROUP BY a.id
+		ORDER BY a.id LIMIT 1 OFFSET ?`, rand.IntN(n)).Scan(&a.ID, &a.Name, &a.SortName, &a.AlbumCount)
+	if errors.Is(err, sql.ErrNoRows) {
+		return provider.Artist{}, provider.ErrNotFound
+	}
+	return a, err
+}
+
+// ListRandomTracks samples tracks with ORDER BY RANDOM(), which draws
+// uniformly from every track passing the filter rather than from a page of
+// the track list.
+func (p *Provider) ListRandomTracks(ctx context.Context, n int, filter provider.RandomFilter) ([]provider.Track, error) {
+	var clauses []string
+	var args []any
+	if filter.Genre != "" {
+		clauses = append(clauses, "genre = ? COLLATE NOCASE")
+		args = append(args, filter.Genre)
+	}
+	clauses, args = yearClauses(filter.Years, clauses, args)
+	query := `SELECT ` + trackListColumns + ` FROM tracks `
+	if len(clauses) > 0 {
+		query += "WHERE " + strings.Join(clauses, " AND ") + " "
+	}
+	query += `ORDER BY RANDOM() LIMIT ?`
+	rows, err := p.db.QueryContext(ctx, query, append(args, n)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanTrackList(rows)
+}
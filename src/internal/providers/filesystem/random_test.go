@@ -0,0 +1,105 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestRandomAlbumAndArtist(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := p.RandomAlbum(ctx); !errors.Is(err, provider.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from an empty library, got %v", err)
+	}
+	if _, err := p.RandomArtist(ctx); !errors.Is(err, provider.ErrNotFound) {
+		t.Fatalf("expected ErrNotFound from an empty library, got %v", err)
+	}
+
+	stmts := []string{
+		`INSERT INTO artists VALUES ('a1', 'Abba', 'abba'), ('a2', 'Queen', 'queen'), ('a3', 'Nobody', 'nobody')`,
+		`INSERT INTO albums (id, artist_id, title, year) VALUES ('al1', 'a2', 'Innuendo', 1991), ('al2', 'a2', 'Jazz', 1978), ('al3', 'a1', 'Arrival', 1976)`,
+	}
+	for _, stmt := range stmts {
+		if _, err := p.db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	albums, artists := map[string]int{}, map[string]int{}
+	for range 300 {
+		al, err := p.RandomAlbum(ctx)
+		if err != nil {
+			t.Fatalf("RandomAlbum: %v", err)
+		}
+		albums[al.Title]++
+		if al.Title == "Arrival" && (al.ArtistName != "Abba" || al.Year != 1976) {
+			t.Fatalf("expected the album's artist and year, got %+v", al)
+		}
+		ar, err := p.RandomArtist(ctx)
+		if err != nil {
+			t.Fatalf("RandomArtist: %v", err)
+		}
+		artists[ar.Name]++
+	}
+	// Every album comes up; artists without albums never do
+	if len(albums) != 3 {
+		t.Errorf("expected all three albums picked, got %v", albums)
+	}
+	if len(artists) != 2 || artists["Nobody"] != 0 {
+		t.Errorf("expected only artists with albums picked, got %v", artists)
+	}
+}
+
+func TestListRandomTracks(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"roots": []any{dir}, "index_db": filepath.Join(dir, "index.sqlite")}); err != nil {
+		t.Fatalf("init: %v", err)
+	}
+	if _, err := p.db.ExecContext(ctx, `INSERT INTO tracks (id, album_id, artist_id, title, album_title, artist_name, year, track_number, disc_number, duration_ms, codec, bitrate, file_path, indexed_at, genre) VALUES
+		('t1', 'al1', 'a1', 'So What', 'Kind of Blue', 'Miles Davis', 1959, 1, 1, 1000, 'flac', 0, '/m/1.flac', 1, 'Jazz'),
+		('t2', 'al2', 'a2', 'Spain', 'Light as a Feather', 'Chick Corea', 1973, 1, 1, 1000, 'flac', 0, '/m/2.flac', 1, 'jazz'),
+		('t3', 'al3', 'a3', 'Chameleon', 'Head Hunters', 'Herbie Hancock', 1973, 1, 1, 1000, 'flac', 0, '/m/3.flac', 1, 'Jazz'),
+		('t4', 'al4', 'a4', 'Money', 'The Dark Side of the Moon', 'Pink Floyd', 1973, 1, 1, 1000, 'flac', 0, '/m/4.flac', 1, 'Rock')`); err != nil {
+		t.Fatalf("seed: %v", err)
+	}
+
+	seen := map[string]int{}
+	for range 200 {
+		tracks, err := p.ListRandomTracks(ctx, 2, provider.RandomFilter{})
+		if err != nil {
+			t.Fatalf("ListRandomTracks: %v", err)
+		}
+		if len(tracks) != 2 || tracks[0].ID == tracks[1].ID {
+			t.Fatalf("expected two different tracks, got %+v", tracks)
+		}
+		for _, tr := range tracks {
+			seen[tr.ID]++
+		}
+	}
+	if len(seen) != 4 {
+		t.Errorf("expected every track sampled, got %v", seen)
+	}
+
+	// Genre matches ignoring case; years narrow it further
+	tracks, err := p.ListRandomTracks(ctx, 10, provider.RandomFilter{Genre: "JAZZ", Years: provider.Decade(1970)})
+	if err != nil {
+		t.Fatalf("ListRandomTracks: %v", err)
+	}
+	got := map[string]bool{}
+	for _, tr := range tracks {
+		got[tr.ID] = true
+	}
+	if len(tracks) != 2 || !got["t2"] || !got["t3"] {
+		t.Errorf("expected t2 and t3 for 1970s jazz, got %+v", tracks)
+	}
+}
@@ -3,16 +3,22 @@ package melodee
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/tunez/tunez/internal/provider"
+	"github.com/tunez/tunez/internal/provider/httpretry"
 )
 
 type Config struct {
@@ -22,13 +28,28 @@ type Config struct {
 	PageSize   int
 	CacheDB    string
 	HTTPClient *http.Client
+
+	// Timeout bounds each API request; StreamTimeout is how long the player
+	// waits on a stalled stream.
+	Timeout       time.Duration
+	StreamTimeout time.Duration
+	// MaxConns caps the connections kept open to the server.
+	MaxConns int
+	// CAFile adds a PEM CA bundle to the system roots, for servers with a
+	// private CA; InsecureSkipVerify accepts any certificate.
+	CAFile             string
+	InsecureSkipVerify bool
 }
 
 type Provider struct {
 	cfg    Config
 	client *http.Client
-	token  string
 	caps   provider.Capabilities
+
+	mu    sync.RWMutex
+	token string // guarded by mu
+	// authMu lets one request at a time sign in again after a 401
+	authMu sync.Mutex
 }
 
 func New() *Provider {
@@ -46,7 +67,11 @@ func (p *Provider) Name() string { return "Melodee" }
 
 // Token returns the current auth token for use by scrobblers.
 // Implements melodee.TokenProvider interface.
-func (p *Provider) Token() string { return p.token }
+func (p *Provider) Token() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.token
+}
 
 // BaseURL returns the configured base URL for the Melodee API.
 func (p *Provider) BaseURL() string { return p.cfg.BaseURL }
@@ -66,7 +91,14 @@ func (p *Provider) Initialize(ctx context.Context, profileCfg any) error {
 	if p.cfg.HTTPClient != nil {
 		p.client = p.cfg.HTTPClient
 	} else {
-		p.client = &http.Client{Timeout: 8 * time.Second}
+		tr, err := newTransport(p.cfg)
+		if err != nil {
+			return err
+		}
+		p.client = &http.Client{
+			Timeout:   p.cfg.Timeout,
+			Transport: httpretry.New(tr, httpretry.Options{}),
+		}
 	}
 	if err := p.authenticate(ctx); err != nil {
 		return fmt.Errorf("authenticate: %w", err)
@@ -74,8 +106,40 @@ func (p *Provider) Initialize(ctx context.Context, profileCfg any) error {
 	return nil
 }
 
+// newTransport returns the connection pool for API requests: keep-alive
+// connections shared across requests, HTTP/2 where the server offers it,
+// gzip negotiated by the transport, and the profile's TLS settings.
+func newTransport(cfg Config) (*http.Transport, error) {
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.MaxIdleConns = cfg.MaxConns
+	tr.MaxIdleConnsPerHost = cfg.MaxConns
+	tr.MaxConnsPerHost = cfg.MaxConns
+	tr.IdleConnTimeout = 90 * time.Second
+	tr.ResponseHeaderTimeout = cfg.Timeout
+	tr.ForceAttemptHTTP2 = true
+	tr.TLSClientConfig = &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tls_ca_file: %v", provider.ErrInvalidConfig, err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%w: tls_ca_file: no certificates in %s", provider.ErrInvalidConfig, cfg.CAFile)
+		}
+		tr.TLSClientConfig.RootCAs = roots
+	}
+	return tr, nil
+}
+
 func parseConfig(raw map[string]any) (Config, error) {
-	cfg := Config{PageSize: 100}
+	cfg := Config{PageSize: 100, Timeout: 8 * time.Second, StreamTimeout: 30 * time.Second, MaxConns: 8}
 	if v, ok := raw["base_url"].(string); ok {
 		cfg.BaseURL = v
 	}
@@ -91,6 +155,21 @@ func parseConfig(raw map[string]any) (Config, error) {
 	if v, ok := raw["page_size"].(int64); ok && v > 0 {
 		cfg.PageSize = int(v)
 	}
+	if v, ok := raw["timeout_seconds"].(int64); ok && v > 0 {
+		cfg.Timeout = time.Duration(v) * time.Second
+	}
+	if v, ok := raw["stream_timeout_seconds"].(int64); ok && v > 0 {
+		cfg.StreamTimeout = time.Duration(v) * time.Second
+	}
+	if v, ok := raw["max_connections"].(int64); ok && v > 0 {
+		cfg.MaxConns = int(v)
+	}
+	if v, ok := raw["tls_ca_file"].(string); ok {
+		cfg.CAFile = v
+	}
+	if v, ok := raw["tls_insecure_skip_verify"].(bool); ok {
+		cfg.InsecureSkipVerify = v
+	}
 	if cfg.BaseURL == "" {
 		return Config{}, provider.ErrInvalidConfig
 	}
@@ -135,18 +214,35 @@ func (p *Provider) authenticate(ctx context.Context) error {
 	if r.AccessToken == "" {
 		return errors.New("empty token")
 	}
+	p.mu.Lock()
 	p.token = r.AccessToken
+	p.mu.Unlock()
 	return nil
 }
 
-func (p *Provider) authHeader(req *http.Request) {
-	if p.token != "" {
-		req.Header.Set("Authorization", "Bearer "+p.token)
+// reauthenticate signs in again after a request sent with token was
+// refused. Requests refused together sign in once: the others find the
+// token already replaced and retry with the new one.
+func (p *Provider) reauthenticate(ctx context.Context, token string) error {
+	p.authMu.Lock()
+	defer p.authMu.Unlock()
+	if p.Token() != token {
+		return nil
+	}
+	return p.authenticate(ctx)
+}
+
+// authHeader sets the request's bearer token, returning the token sent.
+func (p *Provider) authHeader(req *http.Request) string {
+	token := p.Token()
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
 	}
+	return token
 }
 
 func (p *Provider) doRequest(req *http.Request) (*http.Response, error) {
-	p.authHeader(req)
+	sent := p.authHeader(req)
 	resp, err := p.client.Do(req)
 	if err != nil {
 		return nil, err
@@ -154,7 +250,7 @@ func (p *Provider) doRequest(req *http.Request) (*http.Response, error) {
 	if resp.StatusCode == http.StatusUnauthorized {
 		resp.Body.Close()
 		// Re-authenticate
-		if err := p.authenticate(req.Context()); err != nil {
+		if err := p.reauthenticate(req.Context(), sent); err != nil {
 			return nil, err // Return auth error
 		}
 		// Retry once
@@ -165,7 +261,7 @@ func (p *Provider) doRequest(req *http.Request) (*http.Response, error) {
 }
 
 func (p *Provider) ListArtists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Artist], error) {
-	page, err := getPaged[provider.Artist](ctx, p, "/api/v1/artists", req)
+	page, err := getPaged[provider.Artist](ctx, p, "/api/v1/artists", orderParams(req.Sort), req)
 	if err != nil {
 		return provider.Page[provider.Artist]{}, err
 	}
@@ -181,74 +277,221 @@ func (p *Provider) ListAlbums(ctx context.Context, artistId string, req provider
 	if artistId != "" {
 		path = "/api/v1/artists/" + url.PathEscape(artistId) + "/albums"
 	}
-	return getPaged[provider.Album](ctx, p, path, req)
+	page, err := getPaged[provider.Album](ctx, p, path, withYears(orderParams(req.Sort), req.Years), req)
+	page.Items = provider.FilterAlbums(page.Items, req.Years)
+	return page, err
+}
+
+// withYears adds the fromYear/toYear params of a year range. Pages are
+// filtered again locally for servers that ignore them.
+func withYears(params url.Values, r provider.YearRange) url.Values {
+	if r.IsZero() {
+		return params
+	}
+	if params == nil {
+		params = url.Values{}
+	}
+	if r.From != 0 {
+		params.Set("fromYear", strconv.Itoa(r.From))
+	}
+	if r.To != 0 {
+		params.Set("toYear", strconv.Itoa(r.To))
+	}
+	return params
 }
 
 func (p *Provider) GetAlbum(ctx context.Context, id string) (provider.Album, error) {
 	return getOne[provider.Album](ctx, p, "/api/v1/albums/"+id)
 }
 
+// ListTracks lists songs of a playlist, album or artist. The song endpoints
+// take no order or year parameters, so req.Sort and req.Years are applied
+// to each page locally.
 func (p *Provider) ListTracks(ctx context.Context, albumId string, artistId string, playlistId string, req provider.ListReq) (provider.Page[provider.Track], error) {
+	page, err := p.listTracks(ctx, albumId, artistId, playlistId, req)
+	page.Items = provider.FilterTracks(page.Items, req.Years)
+	provider.SortTracks(page.Items, req.Sort)
+	return page, err
+}
+
+func (p *Provider) listTracks(ctx context.Context, albumId string, artistId string, playlistId string, req provider.ListReq) (provider.Page[provider.Track], error) {
 	switch {
 	case playlistId != "":
-		return getPaged[provider.Track](ctx, p, "/api/v1/playlists/"+url.PathEscape(playlistId)+"/songs", req)
+		return getPaged[provider.Track](ctx, p, "/api/v1/playlists/"+url.PathEscape(playlistId)+"/songs", nil, req)
 	case albumId != "":
-		return getPaged[provider.Track](ctx, p, "/api/v1/albums/"+url.PathEscape(albumId)+"/songs", req)
+		return getPaged[provider.Track](ctx, p, "/api/v1/albums/"+url.PathEscape(albumId)+"/songs", nil, req)
 	case artistId != "":
-		// fallback: search songs by artist
-		res, err := p.Search(ctx, "artist:"+artistId, req)
-		return res.Tracks, err
+		return getPaged[provider.Track](ctx, p, "/api/v1/artists/"+url.PathEscape(artistId)+"/songs", nil, req)
 	default:
-		return getPaged[provider.Track](ctx, p, "/api/v1/search/songs", req)
+		return getPaged[provider.Track](ctx, p, "/api/v1/search/songs", nil, req)
 	}
 }
 
+// GetTrack fetches a song with the details the song endpoint adds over
+// the listings: bitrate, genre, play count, rating and when the server
+// added and last updated it.
 func (p *Provider) GetTrack(ctx context.Context, id string) (provider.Track, error) {
-	return getOne[provider.Track](ctx, p, "/api/v1/songs/"+url.PathEscape(id))
+	s, err := getOne[songDetail](ctx, p, "/api/v1/songs/"+url.PathEscape(id))
+	if err != nil {
+		return provider.Track{}, err
+	}
+	return s.track(), nil
 }
 
+// songDetail is a song as /api/v1/songs/{id} returns it.
+type songDetail struct {
+	provider.Track
+	Bitrate    int    `json:"bitrate"`
+	Genre      string `json:"genre"`
+	PlayCount  int    `json:"playCount"`
+	UserRating int    `json:"userRating"`
+	CreatedAt  string `json:"createdAt"`
+	UpdatedAt  string `json:"updatedAt"`
+}
+
+func (s songDetail) track() provider.Track {
+	t := s.Track
+	if t.BitrateKbps == 0 {
+		t.BitrateKbps = s.Bitrate
+	}
+	if s.Genre != "" {
+		t.Genre = s.Genre
+	}
+	if at, err := time.Parse(time.RFC3339, s.CreatedAt); err == nil {
+		t.IndexedAt = at
+	}
+	if at, err := time.Parse(time.RFC3339, s.UpdatedAt); err == nil {
+		t.ModifiedAt = at
+	}
+	t.Tags = map[string]string{}
+	if s.Genre != "" {
+		t.Tags["GENRE"] = s.Genre
+	}
+	if s.PlayCount > 0 {
+		t.Tags["PLAYCOUNT"] = strconv.Itoa(s.PlayCount)
+	}
+	if s.UserRating > 0 {
+		t.Tags["RATING"] = strconv.Itoa(s.UserRating)
+	}
+	return t
+}
+
+// ListRandomTracks samples songs with the server's random endpoint. The
+// filter is applied again locally for servers that ignore it.
+func (p *Provider) ListRandomTracks(ctx context.Context, n int, filter provider.RandomFilter) ([]provider.Track, error) {
+	params := withYears(url.Values{"count": {strconv.Itoa(n)}}, filter.Years)
+	if filter.Genre != "" {
+		params.Set("genre", filter.Genre)
+	}
+	tracks, err := getOne[[]provider.Track](ctx, p, "/api/v1/songs/random?"+params.Encode())
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(tracks, func(t provider.Track) bool { return !filter.Matches(t) }), nil
+}
+
+// Search queries the song, album and artist search endpoints in parallel.
+// A typed cursor ("tracks:200") continues a single result type, and
+// req.SearchKind asks for one. Qualifiers (artist:, year: and so on) are
+// sent as query parameters and checked again on the results, for servers
+// that ignore them.
 func (p *Provider) Search(ctx context.Context, q string, req provider.ListReq) (provider.SearchResults, error) {
+	query, err := provider.ParseQuery(q)
+	if err != nil {
+		return provider.SearchResults{}, err
+	}
 	pageSize := req.PageSize
 	if pageSize == 0 {
 		pageSize = p.cfg.PageSize
 	}
-	offset := parseCursor(req.Cursor)
-	u, _ := url.Parse(p.cfg.BaseURL + "/api/v1/search/songs")
-	qp := u.Query()
-	qp.Set("q", q)
-	qp.Set("page", strconv.Itoa(offset/pageSize+1))
-	qp.Set("pageSize", strconv.Itoa(pageSize))
-	u.RawQuery = qp.Encode()
-	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
-	resp, err := p.doRequest(httpReq)
-	if err != nil {
-		return provider.SearchResults{}, mapHTTPError(err)
+	targetType, offset := parseSearchCursor(req.Cursor)
+	if req.SearchKind != "" {
+		targetType = req.SearchKind
+	}
+	searches := func(kind string) bool {
+		return (targetType == "" || targetType == kind) && query.Searches(kind)
+	}
+	params := queryParams(query)
+
+	var (
+		res                             provider.SearchResults
+		tracksErr, albumsErr, artistErr error
+		wg                              sync.WaitGroup
+	)
+	if searches(provider.SearchTracks) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.Tracks, tracksErr = searchPage[provider.Track](ctx, p, "/api/v1/search/songs", "tracks", params, offset, pageSize)
+		}()
+	}
+	if searches(provider.SearchAlbums) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.Albums, albumsErr = searchPage[provider.Album](ctx, p, "/api/v1/search/albums", "albums", params, offset, pageSize)
+		}()
+	}
+	if searches(provider.SearchArtists) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res.Artists, artistErr = searchPage[provider.Artist](ctx, p, "/api/v1/search/artists", "artists", params, offset, pageSize)
+		}()
+	}
+	wg.Wait()
+
+	if tracksErr != nil {
+		return provider.SearchResults{}, tracksErr
+	}
+	// Servers without album/artist search answer 404; treat it as no results
+	for _, err := range []error{albumsErr, artistErr} {
+		if err != nil && !errors.Is(err, provider.ErrNotFound) {
+			return provider.SearchResults{}, err
+		}
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusUnauthorized {
-		return provider.SearchResults{}, provider.ErrUnauthorized
+	if !query.IsPlain() {
+		res.Tracks.Items = slices.DeleteFunc(res.Tracks.Items, func(t provider.Track) bool { return !query.MatchesTrack(t) })
+		res.Albums.Items = slices.DeleteFunc(res.Albums.Items, func(a provider.Album) bool { return !query.MatchesAlbum(a) })
+		res.Artists.Items = slices.DeleteFunc(res.Artists.Items, func(a provider.Artist) bool { return !query.MatchesArtist(a) })
 	}
-	if resp.StatusCode == http.StatusNotFound {
-		return provider.SearchResults{}, provider.ErrNotFound
+	return res, nil
+}
+
+// queryParams turns a search query into search endpoint parameters: its
+// words as q, its qualifiers by name, and lengths in seconds.
+func queryParams(query provider.Query) url.Values {
+	params := url.Values{"q": {query.Text}}
+	for name, v := range map[string]string{"artist": query.Artist, "album": query.Album, "title": query.Title, "genre": query.Genre} {
+		if v != "" {
+			params.Set(name, v)
+		}
 	}
-	if resp.StatusCode >= 500 {
-		return provider.SearchResults{}, provider.ErrTemporary
+	if d := query.Duration.Min; d != 0 {
+		params.Set("minDuration", strconv.Itoa(int(d.Seconds())))
 	}
-	var data pagedResponse[provider.Track]
-	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return provider.SearchResults{}, err
+	if d := query.Duration.Max; d != 0 {
+		params.Set("maxDuration", strconv.Itoa(int(d.Seconds())))
+	}
+	return withYears(params, query.Years)
+}
+
+// searchPage fetches one page of a search endpoint and tags its next cursor
+// with the result type.
+func searchPage[T any](ctx context.Context, p *Provider, path, kind string, params url.Values, offset, pageSize int) (provider.Page[T], error) {
+	data, err := fetchPage[T](ctx, p, path, params, offset, pageSize)
+	if err != nil {
+		return provider.Page[T]{}, err
 	}
 	next := ""
 	if data.HasMore {
-		next = fmt.Sprintf("%d", offset+pageSize)
+		next = fmt.Sprintf("%s:%d", kind, offset+pageSize)
 	}
-	return provider.SearchResults{
-		Tracks: provider.Page[provider.Track]{Items: data.Items, NextCursor: next, TotalHint: data.Total},
-	}, nil
+	return provider.Page[T]{Items: data.Items, NextCursor: next, TotalHint: data.Total}, nil
 }
 
 func (p *Provider) ListPlaylists(ctx context.Context, req provider.ListReq) (provider.Page[provider.Playlist], error) {
-	return getPaged[provider.Playlist](ctx, p, "/api/v1/user/playlists", req)
+	return getPaged[provider.Playlist](ctx, p, "/api/v1/user/playlists", nil, req)
 }
 
 func (p *Provider) GetPlaylist(ctx context.Context, id string) (provider.Playlist, error) {
@@ -263,7 +506,31 @@ func (p *Provider) GetStream(ctx context.Context, trackId string) (provider.Stre
 	if track.StreamURL == "" {
 		return provider.StreamInfo{}, provider.ErrNotFound
 	}
-	return provider.StreamInfo{URL: track.StreamURL, Headers: map[string]string{"Authorization": "Bearer " + p.token}}, nil
+	return provider.StreamInfo{
+		URL:                transcodeURL(track.StreamURL, provider.StreamQualityFrom(ctx)),
+		Headers:            map[string]string{"Authorization": "Bearer " + p.Token()},
+		Timeout:            p.cfg.StreamTimeout,
+		CAFile:             p.cfg.CAFile,
+		InsecureSkipVerify: p.cfg.InsecureSkipVerify,
+	}, nil
+}
+
+// transcodeURL asks the server to transcode a stream, with the Subsonic
+// format and maxBitRate parameters. Servers without transcoding ignore
+// them and send the original file.
+func transcodeURL(stream string, q provider.StreamQuality) string {
+	if q.IsRaw() {
+		return stream
+	}
+	u, err := url.Parse(stream)
+	if err != nil {
+		return stream
+	}
+	v := u.Query()
+	v.Set("format", q.Format)
+	v.Set("maxBitRate", strconv.Itoa(q.BitrateKbps))
+	u.RawQuery = v.Encode()
+	return u.String()
 }
 
 func (p *Provider) GetLyrics(ctx context.Context, trackId string) (provider.Lyrics, error) {
@@ -286,6 +553,9 @@ func (p *Provider) GetLyrics(ctx context.Context, trackId string) (provider.Lyri
 	if resp.StatusCode == http.StatusNotFound {
 		return provider.Lyrics{}, provider.ErrNotFound
 	}
+	if err := retryStatus(resp); err != nil {
+		return provider.Lyrics{}, err
+	}
 	if resp.StatusCode >= 400 {
 		return provider.Lyrics{}, fmt.Errorf("http status %d", resp.StatusCode)
 	}
@@ -317,63 +587,98 @@ type pagedResponse[T any] struct {
 	Total   int  `json:"total"`
 }
 
-func getPaged[T any](ctx context.Context, p *Provider, path string, req provider.ListReq) (provider.Page[T], error) {
+func getPaged[T any](ctx context.Context, p *Provider, path string, params url.Values, req provider.ListReq) (provider.Page[T], error) {
 	pageSize := req.PageSize
 	if pageSize == 0 {
 		pageSize = p.cfg.PageSize
 	}
 	offset := parseCursor(req.Cursor)
+	data, err := fetchPage[T](ctx, p, path, params, offset, pageSize)
+	if err != nil {
+		return provider.Page[T]{}, err
+	}
+	next := ""
+	if data.HasMore {
+		next = fmt.Sprintf("%d", offset+pageSize)
+	}
+	return provider.Page[T]{Items: data.Items, NextCursor: next, TotalHint: data.Total}, nil
+}
+
+// orderParams maps a ListReq.Sort mode to the orderBy/orderDirection params
+// of the artist and album list endpoints.
+func orderParams(mode string) url.Values {
+	var by, dir string
+	switch mode {
+	case provider.SortName, provider.SortTitle:
+		by, dir = "name", "asc"
+	case provider.SortAlbumCount:
+		by, dir = "albumCount", "desc"
+	case provider.SortYear:
+		by, dir = "releaseYear", "asc"
+	case provider.SortRecent:
+		by, dir = "createdAt", "desc"
+	default:
+		return nil
+	}
+	return url.Values{"orderBy": {by}, "orderDirection": {dir}}
+}
+
+// fetchPage requests the page containing offset, with any extra query params.
+func fetchPage[T any](ctx context.Context, p *Provider, path string, params url.Values, offset, pageSize int) (pagedResponse[T], error) {
 	u, _ := url.Parse(p.cfg.BaseURL + path)
 	q := u.Query()
+	for k, v := range params {
+		q[k] = v
+	}
 	q.Set("page", strconv.Itoa(offset/pageSize+1))
 	q.Set("pageSize", strconv.Itoa(pageSize))
 	u.RawQuery = q.Encode()
 	httpReq, _ := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	validators := setConditional(httpReq)
 	resp, err := p.doRequest(httpReq)
 	if err != nil {
-		return provider.Page[T]{}, mapHTTPError(err)
+		return pagedResponse[T]{}, mapHTTPError(err)
 	}
 	defer resp.Body.Close()
 	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return pagedResponse[T]{}, provider.ErrNotModified
 	case http.StatusUnauthorized:
-		return provider.Page[T]{}, provider.ErrUnauthorized
+		return pagedResponse[T]{}, provider.ErrUnauthorized
 	case http.StatusNotFound:
-		return provider.Page[T]{}, provider.ErrNotFound
+		return pagedResponse[T]{}, provider.ErrNotFound
 	}
-	if resp.StatusCode == http.StatusTooManyRequests {
-		return provider.Page[T]{}, provider.ErrRateLimited
-	}
-	if resp.StatusCode >= 500 {
-		return provider.Page[T]{}, provider.ErrTemporary
+	if err := retryStatus(resp); err != nil {
+		return pagedResponse[T]{}, err
 	}
 	var data pagedResponse[T]
 	if err := json.NewDecoder(resp.Body).Decode(&data); err != nil {
-		return provider.Page[T]{}, err
+		return pagedResponse[T]{}, err
 	}
-	next := ""
-	if data.HasMore {
-		next = fmt.Sprintf("%d", offset+pageSize)
-	}
-	return provider.Page[T]{Items: data.Items, NextCursor: next, TotalHint: data.Total}, nil
+	recordValidators(validators, resp)
+	return data, nil
 }
 
 func getOne[T any](ctx context.Context, p *Provider, path string) (T, error) {
 	var zero T
 	u := p.cfg.BaseURL + path
 	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	validators := setConditional(req)
 	resp, err := p.doRequest(req)
 	if err != nil {
 		return zero, mapHTTPError(err)
 	}
 	defer resp.Body.Close()
 	switch resp.StatusCode {
+	case http.StatusNotModified:
+		return zero, provider.ErrNotModified
 	case http.StatusUnauthorized:
 		return zero, provider.ErrUnauthorized
 	case http.StatusNotFound:
 		return zero, provider.ErrNotFound
 	}
-	if resp.StatusCode >= 500 {
-		return zero, provider.ErrTemporary
+	if err := retryStatus(resp); err != nil {
+		return zero, err
 	}
 	if resp.StatusCode >= 400 {
 		return zero, fmt.Errorf("http status %d", resp.StatusCode)
@@ -381,6 +686,7 @@ func getOne[T any](ctx context.Context, p *Provider, path string) (T, error) {
 	if err := json.NewDecoder(resp.Body).Decode(&zero); err != nil {
 		return zero, err
 	}
+	recordValidators(validators, resp)
 	return zero, nil
 }
 
@@ -393,6 +699,56 @@ func parseCursor(cur string) int {
 	return off
 }
 
+// setConditional turns validators from the caching layer into conditional
+// request headers, claiming them for req. It returns them, or nil when
+// there are none or another request of the call has them.
+func setConditional(req *http.Request) *provider.Validators {
+	v := provider.ClaimValidators(req.Context())
+	if v == nil {
+		return nil
+	}
+	if v.ETag != "" {
+		req.Header.Set("If-None-Match", v.ETag)
+	}
+	if v.LastModified != "" {
+		req.Header.Set("If-Modified-Since", v.LastModified)
+	}
+	return v
+}
+
+// recordValidators hands the response's validators back to the caching
+// layer through v, the validators its request claimed.
+func recordValidators(v *provider.Validators, resp *http.Response) {
+	if v == nil {
+		return
+	}
+	v.ETag = resp.Header.Get("ETag")
+	v.LastModified = resp.Header.Get("Last-Modified")
+}
+
+// parseSearchCursor splits a "type:offset" search cursor. A bare offset
+// continues every result type.
+func parseSearchCursor(cur string) (string, int) {
+	kind, off, ok := strings.Cut(cur, ":")
+	if !ok {
+		return "", parseCursor(cur)
+	}
+	return kind, parseCursor(off)
+}
+
+// retryStatus maps a 429 to ErrRateLimited and a 5xx to ErrTemporary,
+// carrying the server's Retry-After, once the transport has given up
+// retrying.
+func retryStatus(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return &provider.RetryError{Err: provider.ErrRateLimited, After: httpretry.RetryAfter(resp.Header)}
+	case resp.StatusCode >= 500:
+		return &provider.RetryError{Err: provider.ErrTemporary, After: httpretry.RetryAfter(resp.Header)}
+	}
+	return nil
+}
+
 func mapHTTPError(err error) error {
 	if errors.Is(err, context.DeadlineExceeded) {
 		return provider.ErrTemporary
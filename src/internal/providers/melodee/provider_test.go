@@ -3,9 +3,18 @@ package melodee
 import (
 	"context"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/tunez/tunez/internal/provider"
 )
@@ -59,3 +68,536 @@ func TestProvider_Search(t *testing.T) {
 		t.Errorf("Expected 'Test Song', got %s", res.Tracks.Items[0].Title)
 	}
 }
+
+func TestProvider_SearchAllTypes(t *testing.T) {
+	var mu sync.Mutex
+	hits := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+			return
+		}
+		mu.Lock()
+		hits[r.URL.Path]++
+		mu.Unlock()
+		if r.URL.Query().Get("q") != "bowie" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		page := r.URL.Query().Get("page")
+		switch r.URL.Path {
+		case "/api/v1/search/songs":
+			json.NewEncoder(w).Encode(map[string]any{
+				"items":   []map[string]any{{"id": "s" + page, "title": "Heroes"}},
+				"total":   2,
+				"hasMore": page == "1",
+			})
+		case "/api/v1/search/albums":
+			json.NewEncoder(w).Encode(map[string]any{
+				"items":   []map[string]any{{"id": "al1", "title": "Low"}},
+				"total":   1,
+				"hasMore": false,
+			})
+		case "/api/v1/search/artists":
+			json.NewEncoder(w).Encode(map[string]any{
+				"items":   []map[string]any{{"id": "ar1", "name": "David Bowie"}},
+				"total":   1,
+				"hasMore": false,
+			})
+		default:
+			w.WriteHeader(404)
+		}
+	}))
+	defer server.Close()
+
+	p := New()
+	if err := p.Initialize(context.Background(), map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	res, err := p.Search(context.Background(), "bowie", provider.ListReq{PageSize: 1})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(res.Tracks.Items) != 1 || len(res.Albums.Items) != 1 || len(res.Artists.Items) != 1 {
+		t.Fatalf("expected one result per type, got %+v", res)
+	}
+	if res.Albums.Items[0].Title != "Low" || res.Artists.Items[0].Name != "David Bowie" {
+		t.Errorf("unexpected album/artist results %+v %+v", res.Albums.Items, res.Artists.Items)
+	}
+	if res.Tracks.NextCursor != "tracks:1" {
+		t.Errorf("expected typed cursor, got %q", res.Tracks.NextCursor)
+	}
+	if res.Albums.NextCursor != "" || res.Artists.NextCursor != "" {
+		t.Errorf("expected no more albums/artists")
+	}
+
+	// A typed cursor only continues that result type
+	more, err := p.Search(context.Background(), "bowie", provider.ListReq{PageSize: 1, Cursor: res.Tracks.NextCursor})
+	if err != nil {
+		t.Fatalf("Search page 2 failed: %v", err)
+	}
+	if len(more.Tracks.Items) != 1 || more.Tracks.Items[0].ID != "s2" {
+		t.Errorf("expected second track page, got %+v", more.Tracks.Items)
+	}
+	if len(more.Albums.Items) != 0 || len(more.Artists.Items) != 0 {
+		t.Errorf("expected only tracks on continuation, got %+v", more)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if hits["/api/v1/search/albums"] != 1 || hits["/api/v1/search/artists"] != 1 || hits["/api/v1/search/songs"] != 2 {
+		t.Errorf("unexpected request counts %v", hits)
+	}
+}
+
+func TestProvider_SearchReauthenticatesOnce(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		logins int
+	)
+	// The three searches are refused together, once all have been sent
+	refused := make(chan struct{})
+	var arrived sync.WaitGroup
+	arrived.Add(3)
+	go func() { arrived.Wait(); close(refused) }()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			mu.Lock()
+			logins++
+			token := "token-" + strconv.Itoa(logins)
+			mu.Unlock()
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": token})
+			return
+		}
+		if r.Header.Get("Authorization") == "Bearer token-1" {
+			arrived.Done()
+			select {
+			case <-refused:
+			case <-time.After(5 * time.Second):
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{{"id": "1", "title": "Heroes", "name": "David Bowie"}}, "total": 1})
+	}))
+	defer server.Close()
+
+	p := New()
+	if err := p.Initialize(context.Background(), map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	res, err := p.Search(context.Background(), "bowie", provider.ListReq{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(res.Tracks.Items) != 1 || len(res.Albums.Items) != 1 || len(res.Artists.Items) != 1 {
+		t.Errorf("expected every search retried with the new token, got %+v", res)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if logins != 2 {
+		t.Errorf("expected one sign-in after the 401s, got %d", logins-1)
+	}
+	if p.Token() != "token-2" {
+		t.Errorf("expected the new token, got %q", p.Token())
+	}
+}
+
+func TestProvider_SearchQualifiers(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+			return
+		}
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		q := r.URL.Query()
+		if q.Get("q") != "numb" || q.Get("artist") != "floyd" || q.Get("album") != "the wall" ||
+			q.Get("fromYear") != "1979" || q.Get("toYear") != "1979" || q.Get("minDuration") != "300" {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		// A server that ignores the qualifiers
+		json.NewEncoder(w).Encode(map[string]any{
+			"items": []map[string]any{
+				{"id": "s1", "title": "Comfortably Numb", "artistName": "Pink Floyd", "albumTitle": "The Wall", "year": 1979, "durationMs": 383000},
+				{"id": "s2", "title": "Numb", "artistName": "Linkin Park", "albumTitle": "Meteora", "year": 2003, "durationMs": 185000},
+			},
+			"total":   2,
+			"hasMore": false,
+		})
+	}))
+	defer server.Close()
+
+	p := New()
+	if err := p.Initialize(context.Background(), map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	res, err := p.Search(context.Background(), `numb artist:floyd album:"the wall" year:1979 dur:>5m`, provider.ListReq{})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(res.Tracks.Items) != 1 || res.Tracks.Items[0].ID != "s1" {
+		t.Errorf("expected only the matching track, got %+v", res.Tracks.Items)
+	}
+	// Albums and artists can't match a length, so they aren't searched
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 1 || paths[0] != "/api/v1/search/songs" {
+		t.Errorf("expected only the songs endpoint, got %v", paths)
+	}
+
+	if _, err := p.Search(context.Background(), "dur:long", provider.ListReq{}); err == nil {
+		t.Error("expected an invalid length to fail")
+	}
+}
+
+func TestProvider_SearchKind(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+			return
+		}
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{
+			"items":   []map[string]any{{"id": "al1", "title": "Low"}},
+			"total":   1,
+			"hasMore": false,
+		})
+	}))
+	defer server.Close()
+
+	p := New()
+	if err := p.Initialize(context.Background(), map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	res, err := p.Search(context.Background(), "bowie", provider.ListReq{SearchKind: provider.SearchAlbums})
+	if err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(res.Albums.Items) != 1 || len(res.Tracks.Items) != 0 || len(res.Artists.Items) != 0 {
+		t.Errorf("expected only albums, got %+v", res)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(paths) != 1 || paths[0] != "/api/v1/search/albums" {
+		t.Errorf("expected only the albums endpoint, got %v", paths)
+	}
+}
+
+func TestProvider_ConditionalRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		json.NewEncoder(w).Encode(map[string]any{"id": "al1", "title": "Low"})
+	}))
+	defer server.Close()
+
+	p := New()
+	if err := p.Initialize(context.Background(), map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	var v provider.Validators
+	ctx := provider.WithValidators(context.Background(), &v)
+	album, err := p.GetAlbum(ctx, "al1")
+	if err != nil || album.Title != "Low" {
+		t.Fatalf("GetAlbum: %+v %v", album, err)
+	}
+	if v.ETag != `"v1"` {
+		t.Errorf("expected ETag to be recorded, got %q", v.ETag)
+	}
+	// Validators are for one request; the next call brings them again
+	if _, err := p.GetAlbum(ctx, "al1"); err != nil {
+		t.Errorf("expected an unconditional request, got %v", err)
+	}
+	if _, err := p.GetAlbum(provider.WithValidators(context.Background(), &v), "al1"); !errors.Is(err, provider.ErrNotModified) {
+		t.Errorf("expected ErrNotModified, got %v", err)
+	}
+}
+
+func TestProvider_RateLimited(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+			return
+		}
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	p := New()
+	if err := p.Initialize(context.Background(), map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	_, err := p.GetAlbum(context.Background(), "al1")
+	if !provider.IsRateLimited(err) || provider.RetryAfter(err) != time.Minute {
+		t.Errorf("expected ErrRateLimited with the server's Retry-After, got %v", err)
+	}
+}
+
+func TestProvider_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	if err := New().Initialize(ctx, map[string]any{"base_url": server.URL}); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected by default")
+	}
+	if err := New().Initialize(ctx, map[string]any{"base_url": server.URL, "tls_insecure_skip_verify": true}); err != nil {
+		t.Fatalf("expected tls_insecure_skip_verify to accept it, got %v", err)
+	}
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	pemBytes := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	if err := os.WriteFile(caFile, pemBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := New()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL, "tls_ca_file": caFile, "stream_timeout_seconds": int64(45)}); err != nil {
+		t.Fatalf("expected tls_ca_file to trust the server, got %v", err)
+	}
+	if p.cfg.StreamTimeout != 45*time.Second || p.cfg.Timeout != 8*time.Second {
+		t.Errorf("unexpected timeouts %+v", p.cfg)
+	}
+
+	err := New().Initialize(ctx, map[string]any{"base_url": server.URL, "tls_ca_file": filepath.Join(t.TempDir(), "missing.pem")})
+	if !errors.Is(err, provider.ErrInvalidConfig) {
+		t.Errorf("expected a missing CA file to be a config error, got %v", err)
+	}
+}
+
+func TestProvider_ArtistSongs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/authenticate":
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+		case "/api/v1/artists/ar1/songs":
+			json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{{"id": "s1", "title": "Non-Album Single"}}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := New()
+	ctx := context.Background()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	page, err := p.ListTracks(ctx, "", "ar1", "", provider.ListReq{})
+	if err != nil || len(page.Items) != 1 || page.Items[0].ID != "s1" {
+		t.Fatalf("expected the artist's songs, got %+v %v", page.Items, err)
+	}
+}
+
+func TestProvider_StreamQuality(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v1/auth/authenticate" {
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]any{"id": "s1", "streamUrl": server.URL + "/song/stream/k/u/t"})
+	}))
+	defer server.Close()
+
+	p := New()
+	ctx := context.Background()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	raw, err := p.GetStream(ctx, "s1")
+	if err != nil || raw.URL != server.URL+"/song/stream/k/u/t" {
+		t.Fatalf("expected the original stream, got %q %v", raw.URL, err)
+	}
+	q, _ := provider.ParseStreamQuality("opus-128")
+	low, err := p.GetStream(provider.WithStreamQuality(ctx, q), "s1")
+	if err != nil || low.URL != server.URL+"/song/stream/k/u/t?format=opus&maxBitRate=128" {
+		t.Errorf("expected transcode params, got %q %v", low.URL, err)
+	}
+}
+
+func TestProvider_ListSort(t *testing.T) {
+	var gotOrder []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/authenticate":
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+		case "/api/v1/albums/al1/songs":
+			gotOrder = append(gotOrder, r.URL.Query().Get("orderBy"))
+			json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"id": "s1", "title": "Long", "durationMs": 300000},
+				{"id": "s2", "title": "Short", "durationMs": 90000},
+			}})
+		default:
+			gotOrder = append(gotOrder, r.URL.Query().Get("orderBy")+" "+r.URL.Query().Get("orderDirection"))
+			json.NewEncoder(w).Encode(map[string]any{"items": []any{}})
+		}
+	}))
+	defer server.Close()
+
+	p := New()
+	ctx := context.Background()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	if _, err := p.ListArtists(ctx, provider.ListReq{Sort: provider.SortAlbumCount}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ListAlbums(ctx, "ar1", provider.ListReq{Sort: provider.SortRecent}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.ListAlbums(ctx, "", provider.ListReq{}); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"albumCount desc", "createdAt desc", " "}
+	if !slices.Equal(gotOrder, want) {
+		t.Errorf("expected order params %q, got %q", want, gotOrder)
+	}
+
+	// Album songs take no order params and are sorted locally
+	page, err := p.ListTracks(ctx, "al1", "", "", provider.ListReq{Sort: provider.SortDuration})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page.Items) != 2 || page.Items[0].ID != "s2" {
+		t.Errorf("expected shortest song first, got %+v", page.Items)
+	}
+	if gotOrder[len(gotOrder)-1] != "" {
+		t.Errorf("expected no orderBy on album songs, got %q", gotOrder[len(gotOrder)-1])
+	}
+}
+
+func TestProvider_ListYears(t *testing.T) {
+	var gotQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/authenticate":
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+		default:
+			gotQuery = r.URL.Query()
+			// A server that ignores the year params
+			json.NewEncoder(w).Encode(map[string]any{"items": []map[string]any{
+				{"id": "al1", "title": "Jazz", "year": 1978},
+				{"id": "al2", "title": "Innuendo", "year": 1991},
+				{"id": "al3", "title": "Unknown"},
+			}})
+		}
+	}))
+	defer server.Close()
+
+	p := New()
+	ctx := context.Background()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+
+	page, err := p.ListAlbums(ctx, "", provider.ListReq{Sort: provider.SortYear, Years: provider.Decade(1970)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotQuery.Get("fromYear") != "1970" || gotQuery.Get("toYear") != "1979" || gotQuery.Get("orderBy") != "releaseYear" {
+		t.Errorf("unexpected query %v", gotQuery)
+	}
+	if len(page.Items) != 1 || page.Items[0].ID != "al1" {
+		t.Errorf("expected only the 1970s album, got %+v", page.Items)
+	}
+
+	if _, err := p.ListAlbums(ctx, "ar1", provider.ListReq{}); err != nil {
+		t.Fatal(err)
+	}
+	if gotQuery.Has("fromYear") || gotQuery.Has("toYear") {
+		t.Errorf("expected no year params without a range, got %v", gotQuery)
+	}
+}
+
+func TestProvider_ListRandomTracks(t *testing.T) {
+	var gotQuery url.Values
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/authenticate":
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+		case "/api/v1/songs/random":
+			gotQuery = r.URL.Query()
+			// A server that ignores the genre
+			json.NewEncoder(w).Encode([]map[string]any{
+				{"id": "s1", "title": "Spain", "genre": "Jazz", "year": 1973},
+				{"id": "s2", "title": "Money", "genre": "Rock", "year": 1973},
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := New()
+	ctx := context.Background()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	tracks, err := p.ListRandomTracks(ctx, 25, provider.RandomFilter{Genre: "jazz", Years: provider.Decade(1970)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotQuery.Get("count") != "25" || gotQuery.Get("genre") != "jazz" || gotQuery.Get("fromYear") != "1970" || gotQuery.Get("toYear") != "1979" {
+		t.Errorf("unexpected query %v", gotQuery)
+	}
+	if len(tracks) != 1 || tracks[0].ID != "s1" {
+		t.Errorf("expected only the jazz song, got %+v", tracks)
+	}
+}
+
+func TestProvider_GetTrackDetails(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/auth/authenticate":
+			json.NewEncoder(w).Encode(map[string]string{"accessToken": "fake-token"})
+		case "/api/v1/songs/s1":
+			json.NewEncoder(w).Encode(map[string]any{
+				"id": "s1", "title": "Money", "durationMs": 382000, "bitrate": 320, "genre": "Rock",
+				"playCount": 12, "userRating": 4, "createdAt": "2025-01-02T03:04:05Z", "updatedAt": "2025-02-03T04:05:06Z",
+			})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	p := New()
+	ctx := context.Background()
+	if err := p.Initialize(ctx, map[string]any{"base_url": server.URL}); err != nil {
+		t.Fatalf("Initialize failed: %v", err)
+	}
+	tr, err := p.GetTrack(ctx, "s1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tr.Title != "Money" || tr.DurationMs != 382000 || tr.BitrateKbps != 320 || tr.Genre != "Rock" {
+		t.Errorf("unexpected track %+v", tr)
+	}
+	if tr.Tags["PLAYCOUNT"] != "12" || tr.Tags["RATING"] != "4" {
+		t.Errorf("expected play count and rating tags, got %v", tr.Tags)
+	}
+	if !tr.IndexedAt.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) || !tr.ModifiedAt.Equal(time.Date(2025, 2, 3, 4, 5, 6, 0, time.UTC)) {
+		t.Errorf("unexpected dates %v %v", tr.IndexedAt, tr.ModifiedAt)
+	}
+}
@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Bookmark is a position saved within a track.
+type Bookmark struct {
+	ProfileID string
+	TrackID   string
+	Position  float64 // seconds into TrackID
+	CreatedAt time.Time
+}
+
+// AddBookmark saves a position within a track. Bookmarking the same
+// position twice keeps one.
+func (s *PersistenceStore) AddBookmark(ctx context.Context, b Bookmark) error {
+	if b.CreatedAt.IsZero() {
+		b.CreatedAt = time.Now()
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO bookmarks (profile_id, track_id, position, created_at)
+		VALUES (?, ?, ?, ?)`, b.ProfileID, b.TrackID, b.Position, b.CreatedAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("add bookmark: %w", err)
+	}
+	return nil
+}
+
+// DeleteBookmark removes the bookmark at position in a track.
+func (s *PersistenceStore) DeleteBookmark(ctx context.Context, profileID, trackID string, position float64) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM bookmarks WHERE profile_id = ? AND track_id = ? AND position = ?`,
+		profileID, trackID, position)
+	if err != nil {
+		return fmt.Errorf("delete bookmark: %w", err)
+	}
+	return nil
+}
+
+// Bookmarks returns the bookmarks of a track in order of position.
+func (s *PersistenceStore) Bookmarks(ctx context.Context, profileID, trackID string) ([]Bookmark, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT position, created_at FROM bookmarks
+		WHERE profile_id = ? AND track_id = ? ORDER BY position`, profileID, trackID)
+	if err != nil {
+		return nil, fmt.Errorf("query bookmarks: %w", err)
+	}
+	defer rows.Close()
+
+	var out []Bookmark
+	for rows.Next() {
+		b := Bookmark{ProfileID: profileID, TrackID: trackID}
+		var created int64
+		if err := rows.Scan(&b.Position, &created); err != nil {
+			return nil, fmt.Errorf("scan bookmark: %w", err)
+		}
+		b.CreatedAt = time.UnixMilli(created)
+		out = append(out, b)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,47 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestBookmarks(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	for _, b := range []Bookmark{
+		{ProfileID: "home", TrackID: "mix", Position: 1800},
+		{ProfileID: "home", TrackID: "mix", Position: 95.5},
+		{ProfileID: "home", TrackID: "mix", Position: 95.5},
+		{ProfileID: "home", TrackID: "other", Position: 10},
+		{ProfileID: "server", TrackID: "mix", Position: 20},
+	} {
+		if err := store.AddBookmark(ctx, b); err != nil {
+			t.Fatalf("AddBookmark: %v", err)
+		}
+	}
+
+	got, err := store.Bookmarks(ctx, "home", "mix")
+	if err != nil {
+		t.Fatalf("Bookmarks: %v", err)
+	}
+	if len(got) != 2 || got[0].Position != 95.5 || got[1].Position != 1800 {
+		t.Fatalf("expected the two positions in order, got %+v", got)
+	}
+
+	if err := store.DeleteBookmark(ctx, "home", "mix", 95.5); err != nil {
+		t.Fatalf("DeleteBookmark: %v", err)
+	}
+	got, err = store.Bookmarks(ctx, "home", "mix")
+	if err != nil {
+		t.Fatalf("Bookmarks: %v", err)
+	}
+	if len(got) != 1 || got[0].Position != 1800 {
+		t.Errorf("expected one bookmark left, got %+v", got)
+	}
+}
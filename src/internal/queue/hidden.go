@@ -0,0 +1,73 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// HiddenKind is what sort of library item is hidden.
+type HiddenKind string
+
+const (
+	HiddenArtist HiddenKind = "artist"
+	HiddenAlbum  HiddenKind = "album"
+	HiddenTrack  HiddenKind = "track"
+)
+
+// HiddenItem is an artist, album or track kept out of the library, search
+// and random play.
+type HiddenItem struct {
+	Kind     HiddenKind
+	ID       string
+	Name     string // shown in the list of hidden items, e.g. "Album by Artist"
+	HiddenAt time.Time
+}
+
+// Hide hides an item for a profile. Hiding it again keeps the first time.
+func (s *PersistenceStore) Hide(ctx context.Context, profileID string, item HiddenItem) error {
+	if item.HiddenAt.IsZero() {
+		item.HiddenAt = time.Now()
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR IGNORE INTO hidden_items (profile_id, kind, item_id, name, hidden_at)
+		VALUES (?, ?, ?, ?, ?)`, profileID, string(item.Kind), item.ID, item.Name, item.HiddenAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("hide item: %w", err)
+	}
+	return nil
+}
+
+// Unhide shows a hidden item again.
+func (s *PersistenceStore) Unhide(ctx context.Context, profileID string, kind HiddenKind, id string) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM hidden_items WHERE profile_id = ? AND kind = ? AND item_id = ?`,
+		profileID, string(kind), id)
+	if err != nil {
+		return fmt.Errorf("unhide item: %w", err)
+	}
+	return nil
+}
+
+// HiddenItems returns a profile's hidden items, most recently hidden
+// first.
+func (s *PersistenceStore) HiddenItems(ctx context.Context, profileID string) ([]HiddenItem, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT kind, item_id, name, hidden_at FROM hidden_items
+		WHERE profile_id = ? ORDER BY hidden_at DESC, name`, profileID)
+	if err != nil {
+		return nil, fmt.Errorf("query hidden items: %w", err)
+	}
+	defer rows.Close()
+
+	var out []HiddenItem
+	for rows.Next() {
+		var item HiddenItem
+		var kind string
+		var hidden int64
+		if err := rows.Scan(&kind, &item.ID, &item.Name, &hidden); err != nil {
+			return nil, fmt.Errorf("scan hidden item: %w", err)
+		}
+		item.Kind = HiddenKind(kind)
+		item.HiddenAt = time.UnixMilli(hidden)
+		out = append(out, item)
+	}
+	return out, rows.Err()
+}
@@ -0,0 +1,52 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHiddenItems(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	now := time.Now()
+	for _, h := range []struct {
+		profile string
+		item    HiddenItem
+	}{
+		{"home", HiddenItem{Kind: HiddenArtist, ID: "7", Name: "Mariah Carey", HiddenAt: now.Add(-time.Hour)}},
+		{"home", HiddenItem{Kind: HiddenAlbum, ID: "7", Name: "Kidz Bop 12", HiddenAt: now}},
+		{"home", HiddenItem{Kind: HiddenAlbum, ID: "7", Name: "Kidz Bop 12 again", HiddenAt: now.Add(time.Hour)}},
+		{"server", HiddenItem{Kind: HiddenTrack, ID: "7", Name: "Jingle Bells"}},
+	} {
+		if err := store.Hide(ctx, h.profile, h.item); err != nil {
+			t.Fatalf("Hide: %v", err)
+		}
+	}
+
+	got, err := store.HiddenItems(ctx, "home")
+	if err != nil {
+		t.Fatalf("HiddenItems: %v", err)
+	}
+	// The same ID of another kind is a separate item; hiding twice keeps the first
+	if len(got) != 2 || got[0].Kind != HiddenAlbum || got[0].Name != "Kidz Bop 12" || got[1].Kind != HiddenArtist {
+		t.Fatalf("expected the album then the artist, got %+v", got)
+	}
+
+	if err := store.Unhide(ctx, "home", HiddenAlbum, "7"); err != nil {
+		t.Fatalf("Unhide: %v", err)
+	}
+	got, _ = store.HiddenItems(ctx, "home")
+	if len(got) != 1 || got[0].Kind != HiddenArtist {
+		t.Errorf("expected only the artist left hidden, got %+v", got)
+	}
+	if got, _ := store.HiddenItems(ctx, "server"); len(got) != 1 || got[0].Name != "Jingle Bells" {
+		t.Errorf("expected the other profile's item kept, got %+v", got)
+	}
+}
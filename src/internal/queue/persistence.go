@@ -9,14 +9,30 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sync"
+	"time"
 
+	"github.com/tunez/tunez/internal/platform"
 	"github.com/tunez/tunez/internal/provider"
 	_ "modernc.org/sqlite"
 )
 
 // PersistenceStore handles queue state persistence to SQLite.
 type PersistenceStore struct {
-	db *sql.DB
+	db   *sql.DB
+	path string
+
+	// mu guards saved, what the last successful Save or Sync committed.
+	// Sync writes only the difference from it.
+	mu    sync.Mutex
+	saved *savedQueue
+}
+
+// savedQueue is the queue as last committed to queue_items.
+type savedQueue struct {
+	providerID string
+	profileID  string
+	ids        []string
 }
 
 // NewPersistenceStore creates a new persistence store at the given path.
@@ -57,7 +73,7 @@ func NewPersistenceStore(dbPath string) (*PersistenceStore, error) {
 		slog.Warn("queue persistence: set mmap_size", "err", err)
 	}
 
-	store := &PersistenceStore{db: db}
+	store := &PersistenceStore{db: db, path: dbPath}
 	if err := store.ensureSchema(context.Background()); err != nil {
 		db.Close()
 		return nil, err
@@ -107,6 +123,78 @@ func (s *PersistenceStore) ensureSchema(ctx context.Context) error {
 			repeat_mode INTEGER NOT NULL DEFAULT 0,
 			profile_id TEXT NOT NULL DEFAULT ''
 		);`,
+		// Last saved queue of each profile, restored when switching back
+		`CREATE TABLE IF NOT EXISTS queue_snapshots (
+			profile_id TEXT PRIMARY KEY,
+			provider_id TEXT NOT NULL,
+			tracks_json TEXT NOT NULL,
+			current_index INTEGER NOT NULL DEFAULT -1,
+			shuffle_enabled INTEGER NOT NULL DEFAULT 0,
+			repeat_mode INTEGER NOT NULL DEFAULT 0,
+			saved_at INTEGER NOT NULL
+		);`,
+		// Last position reached in each album/playlist, for Continue Listening
+		`CREATE TABLE IF NOT EXISTS listening_contexts (
+			profile_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			context_id TEXT NOT NULL,
+			title TEXT NOT NULL,
+			subtitle TEXT NOT NULL DEFAULT '',
+			track_id TEXT NOT NULL,
+			track_index INTEGER NOT NULL DEFAULT 0,
+			position REAL NOT NULL DEFAULT 0,
+			finished INTEGER NOT NULL DEFAULT 0,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (profile_id, kind, context_id)
+		);`,
+		// Positions bookmarked within tracks, e.g. in mixes and audiobooks
+		`CREATE TABLE IF NOT EXISTS bookmarks (
+			profile_id TEXT NOT NULL,
+			track_id TEXT NOT NULL,
+			position REAL NOT NULL,
+			created_at INTEGER NOT NULL,
+			PRIMARY KEY (profile_id, track_id, position)
+		);`,
+		// Where each audiobook track was left, to resume it from there
+		`CREATE TABLE IF NOT EXISTS track_positions (
+			profile_id TEXT NOT NULL,
+			track_id TEXT NOT NULL,
+			position REAL NOT NULL,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (profile_id, track_id)
+		);`,
+		// Play counts and loved marks by artist and title, so they carry
+		// across providers and can be imported from Last.fm
+		`CREATE TABLE IF NOT EXISTS track_stats (
+			artist_key TEXT NOT NULL,
+			title_key TEXT NOT NULL,
+			plays INTEGER NOT NULL DEFAULT 0,
+			loved INTEGER NOT NULL DEFAULT 0,
+			updated_at INTEGER NOT NULL,
+			PRIMARY KEY (artist_key, title_key)
+		);`,
+		// When each track was last played, for [queue.skip] played_today
+		`CREATE TABLE IF NOT EXISTS last_plays (
+			artist_key TEXT NOT NULL,
+			title_key TEXT NOT NULL,
+			played_at INTEGER NOT NULL,
+			PRIMARY KEY (artist_key, title_key)
+		);`,
+		// Artists, albums and tracks each profile keeps out of sight
+		`CREATE TABLE IF NOT EXISTS hidden_items (
+			profile_id TEXT NOT NULL,
+			kind TEXT NOT NULL,
+			item_id TEXT NOT NULL,
+			name TEXT NOT NULL DEFAULT '',
+			hidden_at INTEGER NOT NULL,
+			PRIMARY KEY (profile_id, kind, item_id)
+		);`,
+		// Where each profile's UI was left at quit, as JSON
+		`CREATE TABLE IF NOT EXISTS ui_sessions (
+			profile_id TEXT PRIMARY KEY,
+			session_json TEXT NOT NULL,
+			saved_at INTEGER NOT NULL
+		);`,
 		// Ensure there's always exactly one state row
 		`INSERT OR IGNORE INTO queue_state (id, current_index, shuffle_enabled, repeat_mode, profile_id)
 		 VALUES (1, -1, 0, 0, '');`,
@@ -119,8 +207,13 @@ func (s *PersistenceStore) ensureSchema(ctx context.Context) error {
 	return nil
 }
 
-// Save persists the queue state to SQLite.
+// Save persists the queue state to SQLite. It also becomes profileID's
+// snapshot, returned by LoadProfile after switching away and back.
 func (s *PersistenceStore) Save(ctx context.Context, q *Queue, providerID, profileID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = nil
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("begin transaction: %w", err)
@@ -131,8 +224,118 @@ func (s *PersistenceStore) Save(ctx context.Context, q *Queue, providerID, profi
 	if _, err := tx.ExecContext(ctx, `DELETE FROM queue_items`); err != nil {
 		return fmt.Errorf("clear queue items: %w", err)
 	}
+	items := q.Items()
+	if err := insertItems(ctx, tx, providerID, 0, items); err != nil {
+		return err
+	}
+	if err := saveState(ctx, tx, q, profileID); err != nil {
+		return err
+	}
+
+	if profileID != "" {
+		tracksJSON, err := json.Marshal(items)
+		if err != nil {
+			return fmt.Errorf("marshal snapshot: %w", err)
+		}
+		_, err = tx.ExecContext(ctx,
+			`INSERT OR REPLACE INTO queue_snapshots (profile_id, provider_id, tracks_json, current_index, shuffle_enabled, repeat_mode, saved_at)
+			 VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			profileID, providerID, string(tracksJSON), q.CurrentIndex(), boolInt(q.IsShuffled()), int(q.RepeatMode()), time.Now().Unix())
+		if err != nil {
+			return fmt.Errorf("save queue snapshot: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	s.saved = &savedQueue{providerID: providerID, profileID: profileID, ids: trackIDs(items)}
+	return nil
+}
+
+// Sync persists the queue like Save, but writes only the items that changed
+// since the last Save or Sync: adding tracks inserts just those rows,
+// removing one deletes its row and renumbers the rest, and moving the
+// current track touches only the state row. Each call is one transaction,
+// so a crash leaves either the previous queue or the new one. Sync doesn't
+// update profileID's snapshot; when the stored queue belongs to another
+// profile, it is kept as that profile's snapshot before being replaced.
+func (s *PersistenceStore) Sync(ctx context.Context, q *Queue, providerID, profileID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	prev := s.saved
+	// Until this commits the table may not match what was last saved
+	s.saved = nil
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	items := q.Items()
+	ids := trackIDs(items)
+	if prev == nil || prev.providerID != providerID || prev.profileID != profileID {
+		if err := snapshotOtherProfile(ctx, tx, profileID); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM queue_items`); err != nil {
+			return fmt.Errorf("clear queue items: %w", err)
+		}
+		if err := insertItems(ctx, tx, providerID, 0, items); err != nil {
+			return err
+		}
+	} else if err := syncItems(ctx, tx, providerID, prev.ids, items); err != nil {
+		return err
+	}
+	if err := saveState(ctx, tx, q, profileID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("commit transaction: %w", err)
+	}
+	s.saved = &savedQueue{providerID: providerID, profileID: profileID, ids: ids}
+	return nil
+}
+
+// syncItems rewrites the rows between the part of the queue unchanged at the
+// start and the part unchanged at the end, shifting the rows after it when
+// the length changed.
+func syncItems(ctx context.Context, tx *sql.Tx, providerID string, old []string, items []provider.Track) error {
+	prefix := 0
+	for prefix < len(old) && prefix < len(items) && old[prefix] == items[prefix].ID {
+		prefix++
+	}
+	suffix := 0
+	for suffix < min(len(old), len(items))-prefix && old[len(old)-1-suffix] == items[len(items)-1-suffix].ID {
+		suffix++
+	}
+	oldEnd, newEnd := len(old)-suffix, len(items)-suffix
 
-	// Insert current items
+	if oldEnd > prefix {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM queue_items WHERE position >= ? AND position < ?`, prefix, oldEnd); err != nil {
+			return fmt.Errorf("delete queue items: %w", err)
+		}
+	}
+	if shift := newEnd - oldEnd; shift != 0 && suffix > 0 {
+		// Renumber through negative positions so no row collides with
+		// another's old position mid-update
+		if _, err := tx.ExecContext(ctx, `UPDATE queue_items SET position = -(position + ?) - 1 WHERE position >= ?`, shift, oldEnd); err != nil {
+			return fmt.Errorf("shift queue items: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `UPDATE queue_items SET position = -position - 1 WHERE position < 0`); err != nil {
+			return fmt.Errorf("shift queue items: %w", err)
+		}
+	}
+	return insertItems(ctx, tx, providerID, prefix, items[prefix:newEnd])
+}
+
+// insertItems inserts tracks at positions from start on.
+func insertItems(ctx context.Context, tx *sql.Tx, providerID string, start int, tracks []provider.Track) error {
+	if len(tracks) == 0 {
+		return nil
+	}
 	stmt, err := tx.PrepareContext(ctx, `INSERT INTO queue_items (position, track_id, provider_id, track_json, added_at)
 		VALUES (?, ?, ?, ?, ?)`)
 	if err != nil {
@@ -140,35 +343,69 @@ func (s *PersistenceStore) Save(ctx context.Context, q *Queue, providerID, profi
 	}
 	defer stmt.Close()
 
-	items := q.Items()
-	for i, track := range items {
+	for i, track := range tracks {
 		trackJSON, err := json.Marshal(track)
 		if err != nil {
 			return fmt.Errorf("marshal track %s: %w", track.ID, err)
 		}
-		if _, err := stmt.ExecContext(ctx, i, track.ID, providerID, string(trackJSON), 0); err != nil {
+		if _, err := stmt.ExecContext(ctx, start+i, track.ID, providerID, string(trackJSON), 0); err != nil {
 			return fmt.Errorf("insert track %s: %w", track.ID, err)
 		}
 	}
+	return nil
+}
 
-	// Update state
-	shuffleInt := 0
-	if q.IsShuffled() {
-		shuffleInt = 1
-	}
-	_, err = tx.ExecContext(ctx,
+// saveState writes the current index, modes and profile.
+func saveState(ctx context.Context, tx *sql.Tx, q *Queue, profileID string) error {
+	_, err := tx.ExecContext(ctx,
 		`UPDATE queue_state SET current_index = ?, shuffle_enabled = ?, repeat_mode = ?, profile_id = ? WHERE id = 1`,
-		q.CurrentIndex(), shuffleInt, int(q.RepeatMode()), profileID)
+		q.CurrentIndex(), boolInt(q.IsShuffled()), int(q.RepeatMode()), profileID)
 	if err != nil {
 		return fmt.Errorf("update queue state: %w", err)
 	}
+	return nil
+}
 
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("commit transaction: %w", err)
+// snapshotOtherProfile keeps the stored queue as its profile's snapshot
+// when it belongs to a profile other than profileID, as it is about to be
+// replaced.
+func snapshotOtherProfile(ctx context.Context, tx *sql.Tx, profileID string) error {
+	var owner string
+	var current, shuffled, repeat int
+	err := tx.QueryRowContext(ctx, `SELECT profile_id, current_index, shuffle_enabled, repeat_mode FROM queue_state WHERE id = 1`).
+		Scan(&owner, &current, &shuffled, &repeat)
+	if err != nil && err != sql.ErrNoRows {
+		return fmt.Errorf("load queue state: %w", err)
+	}
+	if owner == "" || owner == profileID {
+		return nil
+	}
+	_, err = tx.ExecContext(ctx,
+		`INSERT OR REPLACE INTO queue_snapshots (profile_id, provider_id, tracks_json, current_index, shuffle_enabled, repeat_mode, saved_at)
+		 SELECT ?, COALESCE(MAX(provider_id), ''), '[' || COALESCE(GROUP_CONCAT(track_json, ',' ORDER BY position), '') || ']', ?, ?, ?, ?
+		 FROM queue_items`,
+		owner, current, shuffled, repeat, time.Now().Unix())
+	if err != nil {
+		return fmt.Errorf("save queue snapshot: %w", err)
 	}
 	return nil
 }
 
+func trackIDs(tracks []provider.Track) []string {
+	ids := make([]string, len(tracks))
+	for i, t := range tracks {
+		ids[i] = t.ID
+	}
+	return ids
+}
+
+func boolInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 // LoadResult contains the result of loading a queue from persistence.
 type LoadResult struct {
 	Tracks       []provider.Track
@@ -229,24 +466,81 @@ func (s *PersistenceStore) Load(ctx context.Context) (LoadResult, error) {
 	return result, nil
 }
 
-// Clear removes all persisted queue data.
-func (s *PersistenceStore) Clear(ctx context.Context) error {
+// LoadProfile returns the queue last saved while profileID was active. An
+// unknown profile yields an empty result.
+func (s *PersistenceStore) LoadProfile(ctx context.Context, profileID string) (LoadResult, error) {
+	result := LoadResult{CurrentIndex: -1, ProfileID: profileID}
+
+	var tracksJSON string
+	var shuffleInt int
+	err := s.db.QueryRowContext(ctx,
+		`SELECT tracks_json, current_index, shuffle_enabled, repeat_mode FROM queue_snapshots WHERE profile_id = ?`, profileID).
+		Scan(&tracksJSON, &result.CurrentIndex, &shuffleInt, &result.Repeat)
+	if err == sql.ErrNoRows {
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("load queue snapshot: %w", err)
+	}
+	result.Shuffled = shuffleInt == 1
+	if err := json.Unmarshal([]byte(tracksJSON), &result.Tracks); err != nil {
+		return LoadResult{CurrentIndex: -1, ProfileID: profileID}, fmt.Errorf("decode queue snapshot: %w", err)
+	}
+
+	if result.CurrentIndex >= len(result.Tracks) {
+		result.CurrentIndex = len(result.Tracks) - 1
+	}
+	if result.CurrentIndex < 0 && len(result.Tracks) > 0 {
+		result.CurrentIndex = 0
+	}
+	return result, nil
+}
+
+// Clear removes the persisted queue and profileID's snapshot. Other
+// profiles keep theirs.
+func (s *PersistenceStore) Clear(ctx context.Context, profileID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.saved = nil
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	if err := snapshotOtherProfile(ctx, tx, profileID); err != nil {
+		return err
+	}
 	if _, err := tx.ExecContext(ctx, `DELETE FROM queue_items`); err != nil {
 		return err
 	}
 	if _, err := tx.ExecContext(ctx, `UPDATE queue_state SET current_index = -1, shuffle_enabled = 0, repeat_mode = 0 WHERE id = 1`); err != nil {
 		return err
 	}
+	if _, err := tx.ExecContext(ctx, `DELETE FROM queue_snapshots WHERE profile_id = ?`, profileID); err != nil {
+		return err
+	}
 
 	return tx.Commit()
 }
 
+// Size returns the bytes the state database takes on disk, with its
+// write-ahead log.
+func (s *PersistenceStore) Size() (int64, error) {
+	return platform.DiskUsage(s.path, s.path+"-wal", s.path+"-shm")
+}
+
+// Compact rebuilds the database without the space left by deleted rows.
+// It keeps everything saved.
+func (s *PersistenceStore) Compact(ctx context.Context) error {
+	if _, err := s.db.ExecContext(ctx, `VACUUM`); err != nil {
+		return err
+	}
+	_, err := s.db.ExecContext(ctx, `PRAGMA wal_checkpoint(TRUNCATE)`)
+	return err
+}
+
 // Close closes the database connection.
 func (s *PersistenceStore) Close() error {
 	if s.db != nil {
@@ -2,8 +2,10 @@ package queue
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/tunez/tunez/internal/provider"
@@ -111,7 +113,7 @@ func TestPersistenceClear(t *testing.T) {
 	}
 
 	// Clear
-	if err := store.Clear(ctx); err != nil {
+	if err := store.Clear(ctx, "test"); err != nil {
 		t.Fatalf("Clear: %v", err)
 	}
 
@@ -125,6 +127,42 @@ func TestPersistenceClear(t *testing.T) {
 	}
 }
 
+func TestPersistenceCompact(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	q := New()
+	for i := range 500 {
+		q.Add(provider.Track{ID: fmt.Sprintf("t%d", i), Title: strings.Repeat("x", 200)})
+	}
+	if err := store.Save(ctx, q, "filesystem", "test"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	full, err := store.Size()
+	if err != nil || full == 0 {
+		t.Fatalf("expected the saved queue to take space, got %d %v", full, err)
+	}
+
+	q.Clear()
+	q.Add(provider.Track{ID: "kept", Title: "Kept"})
+	if err := store.Save(ctx, q, "filesystem", "test"); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	if err := store.Compact(ctx); err != nil {
+		t.Fatalf("Compact: %v", err)
+	}
+	if size, err := store.Size(); err != nil || size >= full {
+		t.Errorf("expected compacting to shrink the database below %d, got %d %v", full, size, err)
+	}
+	if result, err := store.Load(ctx); err != nil || len(result.Tracks) != 1 || result.Tracks[0].ID != "kept" {
+		t.Errorf("expected the saved queue kept, got %+v %v", result.Tracks, err)
+	}
+}
+
 func TestPersistenceInvalidIndex(t *testing.T) {
 	dir := t.TempDir()
 	dbPath := filepath.Join(dir, "queue.db")
@@ -204,3 +242,196 @@ func TestPersistenceDefaultPath(t *testing.T) {
 		t.Errorf("expected queue.db, got %s", filepath.Base(path))
 	}
 }
+
+func TestPersistencePerProfileSnapshots(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	home := New()
+	home.Add(provider.Track{ID: "h1"}, provider.Track{ID: "h2"})
+	_ = home.SetCurrent(1)
+	if err := store.Save(ctx, home, "filesystem", "home"); err != nil {
+		t.Fatalf("Save home: %v", err)
+	}
+
+	server := New()
+	server.Add(provider.Track{ID: "s1"})
+	server.ToggleShuffle()
+	if err := store.Save(ctx, server, "melodee", "server"); err != nil {
+		t.Fatalf("Save server: %v", err)
+	}
+
+	// The current queue is the server one, but home's is kept aside
+	current, _ := store.Load(ctx)
+	if current.ProfileID != "server" || len(current.Tracks) != 1 {
+		t.Errorf("expected server queue as current, got %+v", current)
+	}
+	result, err := store.LoadProfile(ctx, "home")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if len(result.Tracks) != 2 || result.Tracks[1].ID != "h2" || result.CurrentIndex != 1 {
+		t.Errorf("unexpected home snapshot %+v", result)
+	}
+	result, _ = store.LoadProfile(ctx, "server")
+	if !result.Shuffled {
+		t.Error("expected shuffle to be kept in the server snapshot")
+	}
+	result, _ = store.LoadProfile(ctx, "unknown")
+	if len(result.Tracks) != 0 || result.CurrentIndex != -1 {
+		t.Errorf("expected empty result for unknown profile, got %+v", result)
+	}
+
+	if err := store.Clear(ctx, "server"); err != nil {
+		t.Fatalf("Clear: %v", err)
+	}
+	if result, _ := store.LoadProfile(ctx, "server"); len(result.Tracks) != 0 {
+		t.Error("expected Clear to drop the profile's snapshot")
+	}
+	if result, _ := store.LoadProfile(ctx, "home"); len(result.Tracks) != 2 {
+		t.Error("expected other profiles' snapshots kept")
+	}
+}
+
+// requireStored fails unless the store holds exactly q's tracks and index.
+func requireStored(t *testing.T, store *PersistenceStore, q *Queue) {
+	t.Helper()
+	result, err := store.Load(context.Background())
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	items := q.Items()
+	if len(result.Tracks) != len(items) || result.CurrentIndex != q.CurrentIndex() {
+		t.Fatalf("stored %d tracks at %d, want %d at %d", len(result.Tracks), result.CurrentIndex, len(items), q.CurrentIndex())
+	}
+	for i := range items {
+		if result.Tracks[i].ID != items[i].ID {
+			t.Fatalf("stored track %d is %s, want %s", i, result.Tracks[i].ID, items[i].ID)
+		}
+	}
+}
+
+func TestPersistenceSyncIncremental(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	q := New()
+	for i := range 20 {
+		q.Add(provider.Track{ID: fmt.Sprintf("t%d", i)})
+	}
+	_ = q.SetCurrent(5)
+	sync := func() {
+		t.Helper()
+		if err := store.Sync(ctx, q, "filesystem", "home"); err != nil {
+			t.Fatalf("Sync: %v", err)
+		}
+		requireStored(t, store, q)
+	}
+	sync()
+
+	// Mark the first row so a rewrite of it shows
+	if _, err := store.db.Exec(`UPDATE queue_items SET added_at = 1 WHERE position = 0`); err != nil {
+		t.Fatal(err)
+	}
+	ops := []func(){
+		func() { q.Add(provider.Track{ID: "a1"}, provider.Track{ID: "a2"}) },
+		func() { _ = q.Remove(10) },
+		func() { _ = q.Move(3, 15) },
+		func() { _ = q.Move(18, 2) },
+		func() { q.AddNext(provider.Track{ID: "n1"}) },
+		func() { _ = q.SetCurrent(9) },
+		func() { _ = q.Remove(q.Len() - 1) },
+		func() { q.ShuffleRemaining() },
+	}
+	for _, op := range ops {
+		op()
+		sync()
+	}
+	var marked int
+	if err := store.db.QueryRow(`SELECT added_at FROM queue_items WHERE position = 0`).Scan(&marked); err != nil || marked != 1 {
+		t.Errorf("expected the unchanged first row left alone, got %d (%v)", marked, err)
+	}
+
+	q.Clear()
+	sync()
+}
+
+func TestPersistenceSyncCrashConsistency(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "queue.db")
+	store, err := NewPersistenceStore(dbPath)
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	ctx := context.Background()
+
+	q := New()
+	q.Add(provider.Track{ID: "t1"}, provider.Track{ID: "t2"}, provider.Track{ID: "t3"})
+	_ = q.SetCurrent(0)
+	if err := store.Sync(ctx, q, "filesystem", "home"); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	// A sync that fails part way leaves the last committed queue
+	failed := New()
+	failed.Add(provider.Track{ID: "t1"}, provider.Track{ID: "x"}, provider.Track{ID: "t3"})
+	cancelled, cancel := context.WithCancel(ctx)
+	cancel()
+	if err := store.Sync(cancelled, failed, "filesystem", "home"); err == nil {
+		t.Fatal("expected a cancelled sync to fail")
+	}
+	requireStored(t, store, q)
+
+	// and the next sync still lands in full
+	_ = q.Remove(1)
+	if err := store.Sync(ctx, q, "filesystem", "home"); err != nil {
+		t.Fatalf("Sync: %v", err)
+	}
+
+	// Every committed sync survives the process going away
+	store.Close()
+	store, err = NewPersistenceStore(dbPath)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	requireStored(t, store, q)
+}
+
+func TestPersistenceSyncKeepsOtherProfile(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	home := New()
+	home.Add(provider.Track{ID: "h1", Title: "Home 1"}, provider.Track{ID: "h2"})
+	_ = home.SetCurrent(1)
+	if err := store.Sync(ctx, home, "filesystem", "home"); err != nil {
+		t.Fatalf("Sync home: %v", err)
+	}
+
+	// A later run on another profile replaces the stored queue
+	server := New()
+	server.Add(provider.Track{ID: "s1"})
+	if err := store.Sync(ctx, server, "melodee", "server"); err != nil {
+		t.Fatalf("Sync server: %v", err)
+	}
+	requireStored(t, store, server)
+	result, err := store.LoadProfile(ctx, "home")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+	if len(result.Tracks) != 2 || result.Tracks[0].Title != "Home 1" || result.CurrentIndex != 1 {
+		t.Errorf("expected home's queue kept as its snapshot, got %+v", result)
+	}
+}
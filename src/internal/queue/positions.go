@@ -0,0 +1,40 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// SaveTrackPosition records how far into a track playback got, replacing
+// the position saved before. A position of 0 forgets the track.
+func (s *PersistenceStore) SaveTrackPosition(ctx context.Context, profileID, trackID string, position float64) error {
+	var err error
+	if position <= 0 {
+		_, err = s.db.ExecContext(ctx, `DELETE FROM track_positions WHERE profile_id = ? AND track_id = ?`, profileID, trackID)
+	} else {
+		_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO track_positions (profile_id, track_id, position, updated_at)
+			VALUES (?, ?, ?, ?)`, profileID, trackID, position, time.Now().UnixMilli())
+	}
+	if err != nil {
+		return fmt.Errorf("save track position: %w", err)
+	}
+	return nil
+}
+
+// TrackPosition returns the saved position in a track, or 0 when there is
+// none.
+func (s *PersistenceStore) TrackPosition(ctx context.Context, profileID, trackID string) (float64, error) {
+	var pos float64
+	err := s.db.QueryRowContext(ctx, `SELECT position FROM track_positions WHERE profile_id = ? AND track_id = ?`,
+		profileID, trackID).Scan(&pos)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query track position: %w", err)
+	}
+	return pos, nil
+}
@@ -0,0 +1,41 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestTrackPositions(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if pos, err := store.TrackPosition(ctx, "home", "ch1"); err != nil || pos != 0 {
+		t.Fatalf("expected no position, got %v %v", pos, err)
+	}
+	for _, pos := range []float64{60, 754.5} {
+		if err := store.SaveTrackPosition(ctx, "home", "ch1", pos); err != nil {
+			t.Fatalf("SaveTrackPosition: %v", err)
+		}
+	}
+	if err := store.SaveTrackPosition(ctx, "server", "ch1", 10); err != nil {
+		t.Fatalf("SaveTrackPosition: %v", err)
+	}
+	if pos, err := store.TrackPosition(ctx, "home", "ch1"); err != nil || pos != 754.5 {
+		t.Fatalf("expected the latest position, got %v %v", pos, err)
+	}
+
+	if err := store.SaveTrackPosition(ctx, "home", "ch1", 0); err != nil {
+		t.Fatalf("SaveTrackPosition: %v", err)
+	}
+	if pos, _ := store.TrackPosition(ctx, "home", "ch1"); pos != 0 {
+		t.Errorf("expected the position forgotten, got %v", pos)
+	}
+	if pos, _ := store.TrackPosition(ctx, "server", "ch1"); pos != 10 {
+		t.Errorf("expected other profiles kept, got %v", pos)
+	}
+}
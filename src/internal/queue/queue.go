@@ -2,7 +2,11 @@ package queue
 
 import (
 	"errors"
+	"fmt"
 	"math/rand"
+	"slices"
+	"strings"
+	"unique"
 
 	"github.com/tunez/tunez/internal/provider"
 )
@@ -15,21 +19,89 @@ const (
 	RepeatOne
 )
 
+// ParseRepeatMode parses "off", "all" or "one".
+func ParseRepeatMode(s string) (RepeatMode, error) {
+	for _, r := range []RepeatMode{RepeatOff, RepeatAll, RepeatOne} {
+		if strings.EqualFold(s, r.String()) {
+			return r, nil
+		}
+	}
+	return RepeatOff, fmt.Errorf("repeat mode must be off, all or one, not %q", s)
+}
+
+func (r RepeatMode) String() string {
+	switch r {
+	case RepeatAll:
+		return "all"
+	case RepeatOne:
+		return "one"
+	}
+	return "off"
+}
+
 // Queue maintains an ordered list of tracks and the current position.
 type Queue struct {
 	items      []provider.Track
 	current    int
 	repeatMode RepeatMode
 	shuffled   bool
-	original   []provider.Track
+	// seq holds each item's position in queued order while shuffled (a
+	// permutation of 0..len-1), so unshuffling can restore it.
+	seq []int
+	// limit caps len(items); 0 means no limit.
+	limit int
+	// weight biases shuffling when set; see SetShuffleWeight.
+	weight func(provider.Track) float64
 }
 
 var ErrEmpty = errors.New("queue is empty")
 
+// SetLimit caps the queue at n tracks, or removes the cap when n <= 0.
+// Tracks already queued are kept; Add and AddNext leave out whatever
+// doesn't fit.
+func (q *Queue) SetLimit(n int) { q.limit = max(n, 0) }
+
+// Limit returns the most tracks the queue holds, or 0 for no limit.
+func (q *Queue) Limit() int { return q.limit }
+
+// fit interns tracks' shared strings and trims them to the room left under
+// the limit.
+func (q *Queue) fit(tracks []provider.Track) []provider.Track {
+	if q.limit > 0 {
+		tracks = tracks[:max(min(len(tracks), q.limit-len(q.items)), 0)]
+	}
+	out := make([]provider.Track, len(tracks))
+	for i, t := range tracks {
+		out[i] = intern(t)
+	}
+	return out
+}
+
+// intern replaces the strings a track shares with the rest of its album
+// and artist by a single canonical copy, so queueing a whole library
+// holds each artist and album name once rather than once per track.
+func intern(t provider.Track) provider.Track {
+	for _, s := range []*string{&t.ArtistID, &t.ArtistName, &t.AlbumID, &t.AlbumTitle, &t.Genre, &t.Codec, &t.ArtworkRef} {
+		if *s != "" {
+			*s = unique.Make(*s).Value()
+		}
+	}
+	return t
+}
+
 func New() *Queue {
 	return &Queue{items: []provider.Track{}, current: -1}
 }
 
+// Clone returns a copy of the queue that can be read while q changes, e.g.
+// to save it from another goroutine.
+func (q *Queue) Clone() *Queue {
+	c := *q
+	c.items = slices.Clone(q.items)
+	c.seq = slices.Clone(q.seq)
+	return &c
+}
+
 func (q *Queue) Items() []provider.Track {
 	out := make([]provider.Track, len(q.items))
 	copy(out, q.items)
@@ -38,6 +110,11 @@ func (q *Queue) Items() []provider.Track {
 
 func (q *Queue) Len() int { return len(q.items) }
 
+// At returns the track at position i without copying the queue, for
+// callers that walk it row by row. Like indexing Items, it panics when i is
+// out of range.
+func (q *Queue) At(i int) provider.Track { return q.items[i] }
+
 func (q *Queue) Current() (provider.Track, error) {
 	if q.current < 0 || q.current >= len(q.items) {
 		return provider.Track{}, ErrEmpty
@@ -49,21 +126,58 @@ func (q *Queue) CurrentIndex() int {
 	return q.current
 }
 
-func (q *Queue) Add(tracks ...provider.Track) {
+// Add appends tracks and returns how many fit under the limit.
+func (q *Queue) Add(tracks ...provider.Track) int {
+	tracks = q.fit(tracks)
+	if q.shuffled {
+		next := len(q.seq)
+		for i := range tracks {
+			q.seq = append(q.seq, next+i)
+		}
+	}
 	q.items = append(q.items, tracks...)
 	if q.current == -1 && len(q.items) > 0 {
 		q.current = 0
 	}
+	return len(tracks)
 }
 
-func (q *Queue) AddNext(track provider.Track) {
+// AddNext inserts tracks right after the current one, keeping their order,
+// so a whole album or playlist can be played next. It returns how many fit
+// under the limit.
+func (q *Queue) AddNext(tracks ...provider.Track) int {
+	tracks = q.fit(tracks)
+	if len(tracks) == 0 {
+		return 0
+	}
 	if q.current == -1 {
-		q.items = []provider.Track{track}
+		q.items = tracks
 		q.current = 0
-		return
+		if q.shuffled {
+			q.seq = make([]int, len(tracks))
+			for i := range q.seq {
+				q.seq[i] = i
+			}
+		}
+		return len(tracks)
 	}
 	idx := q.current + 1
-	q.items = append(q.items[:idx], append([]provider.Track{track}, q.items[idx:]...)...)
+	q.items = slices.Insert(q.items, idx, tracks...)
+	if q.shuffled {
+		// In queued order they also follow the current track
+		after := q.seq[q.current]
+		for i, s := range q.seq {
+			if s > after {
+				q.seq[i] = s + len(tracks)
+			}
+		}
+		inserted := make([]int, len(tracks))
+		for i := range inserted {
+			inserted[i] = after + 1 + i
+		}
+		q.seq = slices.Insert(q.seq, idx, inserted...)
+	}
+	return len(tracks)
 }
 
 func (q *Queue) Remove(idx int) error {
@@ -71,6 +185,15 @@ func (q *Queue) Remove(idx int) error {
 		return errors.New("index out of range")
 	}
 	q.items = append(q.items[:idx], q.items[idx+1:]...)
+	if q.shuffled {
+		removed := q.seq[idx]
+		q.seq = slices.Delete(q.seq, idx, idx+1)
+		for i, s := range q.seq {
+			if s > removed {
+				q.seq[i] = s - 1
+			}
+		}
+	}
 	if len(q.items) == 0 {
 		q.current = -1
 		return nil
@@ -97,6 +220,11 @@ func (q *Queue) Move(from, to int) error {
 		copy(q.items[to+1:], q.items[to:from])
 	}
 	q.items[to] = item
+	if q.shuffled {
+		// The track keeps its place in queued order
+		s := q.seq[from]
+		q.seq = slices.Insert(slices.Delete(q.seq, from, from+1), to, s)
+	}
 	if q.current == from {
 		q.current = to
 	} else if from < q.current && to >= q.current {
@@ -107,54 +235,72 @@ func (q *Queue) Move(from, to int) error {
 	return nil
 }
 
+// ToggleShuffle turns shuffle on, shuffling the tracks after the current
+// one, or off, restoring the order they were queued in.
 func (q *Queue) ToggleShuffle() {
-	q.shuffled = !q.shuffled
 	if q.shuffled {
-		// Save original order
-		q.original = make([]provider.Track, len(q.items))
-		copy(q.original, q.items)
+		q.Unshuffle()
+	} else {
+		q.ShuffleRemaining()
+	}
+}
 
-		currentTrack := provider.Track{}
-		if q.current >= 0 && q.current < len(q.items) {
-			currentTrack = q.items[q.current]
-		}
+// ShuffleRemaining randomizes the tracks after the current one, leaving the
+// current track and the ones already played where they are, and turns
+// shuffle on. Calling it again reshuffles what is left. The order is
+// weighted when SetShuffleWeight has been called.
+func (q *Queue) ShuffleRemaining() {
+	q.SetShuffled(true)
+	rest := q.current + 1
+	if q.weight != nil {
+		q.weightedShuffle(rest)
+		return
+	}
+	// Go 1.20+ auto-seeds; no need for rand.Seed
+	rand.Shuffle(len(q.items)-rest, func(i, j int) {
+		i, j = i+rest, j+rest
+		q.items[i], q.items[j] = q.items[j], q.items[i]
+		q.seq[i], q.seq[j] = q.seq[j], q.seq[i]
+	})
+}
 
-		// Go 1.20+ auto-seeds; no need for rand.Seed
-		rand.Shuffle(len(q.items), func(i, j int) {
-			q.items[i], q.items[j] = q.items[j], q.items[i]
-		})
-
-		// Find current track and update index
-		if currentTrack.ID != "" {
-			for i, t := range q.items {
-				if t.ID == currentTrack.ID {
-					q.current = i
-					break
-				}
-			}
+// Unshuffle turns shuffle off and puts the tracks back in the order they
+// were queued in, including ones added or removed while shuffled. The
+// current track stays current.
+func (q *Queue) Unshuffle() {
+	if !q.shuffled {
+		return
+	}
+	items := make([]provider.Track, len(q.items))
+	current := -1
+	for i, pos := range q.seq {
+		items[pos] = q.items[i]
+		if i == q.current {
+			current = pos
 		}
-	} else {
-		// Restore original order
-		if q.original != nil {
-			// We need to find where current track is in original
-			currentTrack := provider.Track{}
-			if q.current >= 0 && q.current < len(q.items) {
-				currentTrack = q.items[q.current]
-			}
-
-			q.items = q.original
-			q.original = nil
+	}
+	q.items = items
+	if current >= 0 {
+		q.current = current
+	}
+	q.shuffled = false
+	q.seq = nil
+}
 
-			if currentTrack.ID != "" {
-				for i, t := range q.items {
-					if t.ID == currentTrack.ID {
-						q.current = i
-						break
-					}
-				}
-			}
+// SetShuffled marks the queue shuffled or not without reordering it, taking
+// the present order as the original one. It is used to restore a saved
+// queue, whose tracks are already in play order.
+func (q *Queue) SetShuffled(on bool) {
+	if on && !q.shuffled {
+		q.seq = make([]int, len(q.items))
+		for i := range q.seq {
+			q.seq[i] = i
 		}
 	}
+	if !on {
+		q.seq = nil
+	}
+	q.shuffled = on
 }
 
 func (q *Queue) CycleRepeat() RepeatMode {
@@ -162,6 +308,11 @@ func (q *Queue) CycleRepeat() RepeatMode {
 	return q.repeatMode
 }
 
+// SetRepeat sets the repeat mode.
+func (q *Queue) SetRepeat(mode RepeatMode) {
+	q.repeatMode = mode
+}
+
 func (q *Queue) RepeatMode() RepeatMode {
 	return q.repeatMode
 }
@@ -225,4 +376,7 @@ func (q *Queue) SetCurrent(idx int) error {
 func (q *Queue) Clear() {
 	q.items = nil
 	q.current = -1
+	if q.shuffled {
+		q.seq = []int{}
+	}
 }
@@ -2,7 +2,9 @@ package queue
 
 import (
 	"fmt"
+	"slices"
 	"testing"
+	"unsafe"
 
 	"github.com/tunez/tunez/internal/provider"
 )
@@ -47,6 +49,27 @@ func TestQueueNextPrev(t *testing.T) {
 	}
 }
 
+func TestQueueClone(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(3)...)
+	q.ToggleShuffle()
+	c := q.Clone()
+	q.Clear()
+	if c.Len() != 3 || !c.IsShuffled() || c.CurrentIndex() != 0 {
+		t.Fatalf("expected the clone unchanged, got len %d shuffled %v current %d", c.Len(), c.IsShuffled(), c.CurrentIndex())
+	}
+}
+
+func TestQueueAt(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(3)...)
+	for i, want := range q.Items() {
+		if got := q.At(i); got.ID != want.ID {
+			t.Errorf("At(%d): expected %s, got %s", i, want.ID, got.ID)
+		}
+	}
+}
+
 func TestQueueRemove(t *testing.T) {
 	q := New()
 	q.Add(sampleTracks(3)...)
@@ -106,6 +129,135 @@ func TestQueueShuffle(t *testing.T) {
 	}
 }
 
+func queueIDs(q *Queue) []string {
+	var ids []string
+	for _, t := range q.Items() {
+		ids = append(ids, t.ID)
+	}
+	return ids
+}
+
+func TestShuffleRemainingKeepsHistory(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(20)...)
+	_ = q.SetCurrent(5)
+	before := queueIDs(q)
+
+	q.ShuffleRemaining()
+	after := queueIDs(q)
+	if !q.IsShuffled() {
+		t.Fatal("expected shuffle to be on")
+	}
+	if !slices.Equal(after[:6], before[:6]) {
+		t.Fatalf("expected played and current tracks untouched, got %v", after[:6])
+	}
+	if q.CurrentIndex() != 5 {
+		t.Fatalf("expected current to stay at 5, got %d", q.CurrentIndex())
+	}
+	rest := slices.Clone(after[6:])
+	slices.Sort(rest)
+	want := slices.Clone(before[6:])
+	slices.Sort(want)
+	if !slices.Equal(rest, want) {
+		t.Fatalf("expected the same upcoming tracks, got %v", after[6:])
+	}
+}
+
+func TestUnshuffleRestoresOrder(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(10)...)
+	_ = q.SetCurrent(2)
+	want := queueIDs(q)
+
+	q.ToggleShuffle()
+	q.ShuffleRemaining()
+	q.ToggleShuffle()
+	if got := queueIDs(q); !slices.Equal(got, want) {
+		t.Fatalf("expected original order %v, got %v", want, got)
+	}
+	if cur, _ := q.Current(); cur.ID != "t2" {
+		t.Fatalf("expected t2 to stay current, got %s", cur.ID)
+	}
+}
+
+func TestUnshuffleAfterEdits(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(6)...)
+	_ = q.SetCurrent(1)
+	q.ShuffleRemaining()
+
+	// Edits while shuffled land in queued order too
+	q.Add(provider.Track{ID: "end"})
+	q.AddNext(provider.Track{ID: "next"})
+	for i, track := range q.Items() {
+		if track.ID == "t4" {
+			_ = q.Remove(i)
+			break
+		}
+	}
+	_ = q.Move(q.Len()-1, 2)
+	_, _ = q.Next()
+	cur, _ := q.Current()
+
+	q.Unshuffle()
+	want := []string{"t0", "t1", "next", "t2", "t3", "t5", "end"}
+	if got := queueIDs(q); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if now, _ := q.Current(); now.ID != cur.ID {
+		t.Errorf("expected %s to stay current, got %s", cur.ID, now.ID)
+	}
+}
+
+func TestAddNextBatch(t *testing.T) {
+	album := []provider.Track{{ID: "a1"}, {ID: "a2"}, {ID: "a3"}}
+
+	q := New()
+	q.AddNext(album...)
+	if got := queueIDs(q); !slices.Equal(got, []string{"a1", "a2", "a3"}) || q.CurrentIndex() != 0 {
+		t.Fatalf("expected the album to fill an empty queue, got %v at %d", got, q.CurrentIndex())
+	}
+
+	q = New()
+	q.Add(sampleTracks(3)...)
+	_ = q.SetCurrent(1)
+	q.AddNext(album...)
+	want := []string{"t0", "t1", "a1", "a2", "a3", "t2"}
+	if got := queueIDs(q); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// While shuffled they follow the current track in queued order too
+	q = New()
+	q.Add(sampleTracks(4)...)
+	_ = q.SetCurrent(1)
+	q.ShuffleRemaining()
+	q.AddNext(album...)
+	items := queueIDs(q)
+	if !slices.Equal(items[2:5], []string{"a1", "a2", "a3"}) {
+		t.Fatalf("expected the album after the current track, got %v", items)
+	}
+	q.Unshuffle()
+	want = []string{"t0", "t1", "a1", "a2", "a3", "t2", "t3"}
+	if got := queueIDs(q); !slices.Equal(got, want) {
+		t.Fatalf("expected %v after unshuffling, got %v", want, got)
+	}
+}
+
+func TestSetShuffledKeepsOrder(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(4)...)
+	want := queueIDs(q)
+	q.SetShuffled(true)
+	if !q.IsShuffled() || !slices.Equal(queueIDs(q), want) {
+		t.Fatalf("expected shuffle flag without reordering, got %v", queueIDs(q))
+	}
+	q.Unshuffle()
+	if !slices.Equal(queueIDs(q), want) {
+		t.Fatalf("expected order kept, got %v", queueIDs(q))
+	}
+}
+
 func TestQueueRepeat(t *testing.T) {
 	q := New()
 	q.Add(sampleTracks(2)...)
@@ -157,3 +309,60 @@ func TestQueueRepeatOne(t *testing.T) {
 		t.Fatalf("expected same track in repeat one, got %s vs %s", track1.ID, track2.ID)
 	}
 }
+
+func TestParseRepeatMode(t *testing.T) {
+	for in, want := range map[string]RepeatMode{"off": RepeatOff, "ALL": RepeatAll, "one": RepeatOne} {
+		if got, err := ParseRepeatMode(in); err != nil || got != want {
+			t.Errorf("ParseRepeatMode(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseRepeatMode("twice"); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+}
+
+func TestQueueLimit(t *testing.T) {
+	q := New()
+	q.SetLimit(5)
+	if n := q.Add(sampleTracks(3)...); n != 3 {
+		t.Fatalf("expected all 3 added, got %d", n)
+	}
+	if n := q.AddNext(sampleTracks(4)...); n != 2 || q.Len() != 5 {
+		t.Fatalf("expected 2 of 4 to fit, got %d (len %d)", n, q.Len())
+	}
+	if items := q.Items(); items[1].ID != "t0" || items[2].ID != "t1" {
+		t.Errorf("expected the first tracks that fit queued next, got %v", items)
+	}
+	if n := q.Add(sampleTracks(1)...); n != 0 || q.Len() != 5 {
+		t.Errorf("expected a full queue to take nothing, got %d", n)
+	}
+
+	_ = q.Remove(0)
+	if n := q.Add(sampleTracks(2)...); n != 1 {
+		t.Errorf("expected room for 1 after a removal, got %d", n)
+	}
+	q.SetLimit(0)
+	if n := q.Add(sampleTracks(10)...); n != 10 {
+		t.Errorf("expected no limit, got %d added", n)
+	}
+}
+
+func TestQueueInternsSharedStrings(t *testing.T) {
+	// Each track gets its own copy, as if decoded from separate responses
+	track := func(id string) provider.Track {
+		return provider.Track{ID: id, ArtistName: string([]byte("Artist")), AlbumTitle: string([]byte("Album"))}
+	}
+	q := New()
+	q.Add(track("1"), track("2"))
+	q.AddNext(track("3"))
+	items := q.Items()
+	for _, it := range items[1:] {
+		if unsafe.StringData(it.ArtistName) != unsafe.StringData(items[0].ArtistName) ||
+			unsafe.StringData(it.AlbumTitle) != unsafe.StringData(items[0].AlbumTitle) {
+			t.Fatalf("expected track %s to share the artist and album strings", it.ID)
+		}
+	}
+	if items[0].ArtistName != "Artist" || items[2].AlbumTitle != "Album" {
+		t.Errorf("interning changed values: %+v", items)
+	}
+}
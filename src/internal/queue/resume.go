@@ -0,0 +1,75 @@
+package queue
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// ContextKind is what a listening context refers to.
+type ContextKind string
+
+const (
+	ContextAlbum    ContextKind = "album"
+	ContextPlaylist ContextKind = "playlist"
+)
+
+// ListeningContext is the last position reached in an album or playlist.
+type ListeningContext struct {
+	ProfileID  string
+	Kind       ContextKind
+	ID         string // album or playlist ID
+	Title      string
+	Subtitle   string // artist of an album
+	TrackID    string
+	TrackIndex int     // position of TrackID within the context
+	Position   float64 // seconds into TrackID
+	Finished   bool    // played through to the end
+	UpdatedAt  time.Time
+}
+
+// RecordContext stores the latest position in an album or playlist,
+// replacing what was recorded for it before.
+func (s *PersistenceStore) RecordContext(ctx context.Context, lc ListeningContext) error {
+	if lc.UpdatedAt.IsZero() {
+		lc.UpdatedAt = time.Now()
+	}
+	finished := 0
+	if lc.Finished {
+		finished = 1
+	}
+	_, err := s.db.ExecContext(ctx, `INSERT OR REPLACE INTO listening_contexts
+		(profile_id, kind, context_id, title, subtitle, track_id, track_index, position, finished, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		lc.ProfileID, string(lc.Kind), lc.ID, lc.Title, lc.Subtitle, lc.TrackID, lc.TrackIndex, lc.Position, finished, lc.UpdatedAt.UnixMilli())
+	if err != nil {
+		return fmt.Errorf("record listening context: %w", err)
+	}
+	return nil
+}
+
+// RecentContexts returns up to limit albums and playlists of profileID that
+// were left before the end, most recently played first.
+func (s *PersistenceStore) RecentContexts(ctx context.Context, profileID string, limit int) ([]ListeningContext, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT kind, context_id, title, subtitle, track_id, track_index, position, updated_at
+		FROM listening_contexts WHERE profile_id = ? AND finished = 0
+		ORDER BY updated_at DESC LIMIT ?`, profileID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("query listening contexts: %w", err)
+	}
+	defer rows.Close()
+
+	var out []ListeningContext
+	for rows.Next() {
+		lc := ListeningContext{ProfileID: profileID}
+		var kind string
+		var updated int64
+		if err := rows.Scan(&kind, &lc.ID, &lc.Title, &lc.Subtitle, &lc.TrackID, &lc.TrackIndex, &lc.Position, &updated); err != nil {
+			return nil, fmt.Errorf("scan listening context: %w", err)
+		}
+		lc.Kind = ContextKind(kind)
+		lc.UpdatedAt = time.UnixMilli(updated)
+		out = append(out, lc)
+	}
+	return out, rows.Err()
+}
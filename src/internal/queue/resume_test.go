@@ -0,0 +1,46 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentContexts(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	base := time.Now()
+	record := func(lc ListeningContext, ago time.Duration) {
+		t.Helper()
+		lc.UpdatedAt = base.Add(-ago)
+		if err := store.RecordContext(ctx, lc); err != nil {
+			t.Fatalf("RecordContext: %v", err)
+		}
+	}
+	record(ListeningContext{ProfileID: "home", Kind: ContextAlbum, ID: "al1", Title: "Old", TrackID: "t1"}, time.Hour)
+	record(ListeningContext{ProfileID: "home", Kind: ContextPlaylist, ID: "pl1", Title: "Mix", TrackID: "t9", TrackIndex: 4, Position: 42.5}, time.Minute)
+	record(ListeningContext{ProfileID: "home", Kind: ContextAlbum, ID: "al2", Title: "Done", TrackID: "t5", Finished: true}, 0)
+	record(ListeningContext{ProfileID: "server", Kind: ContextAlbum, ID: "al3", Title: "Elsewhere", TrackID: "t7"}, 0)
+	// A later position replaces the earlier one
+	record(ListeningContext{ProfileID: "home", Kind: ContextAlbum, ID: "al1", Title: "Old", TrackID: "t2", TrackIndex: 1, Position: 10}, 2*time.Minute)
+
+	got, err := store.RecentContexts(ctx, "home", 5)
+	if err != nil {
+		t.Fatalf("RecentContexts: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 unfinished contexts, got %+v", got)
+	}
+	if got[0].ID != "pl1" || got[0].Kind != ContextPlaylist || got[0].TrackIndex != 4 || got[0].Position != 42.5 {
+		t.Errorf("unexpected first context %+v", got[0])
+	}
+	if got[1].ID != "al1" || got[1].TrackID != "t2" || got[1].Position != 10 {
+		t.Errorf("unexpected second context %+v", got[1])
+	}
+}
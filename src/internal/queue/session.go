@@ -0,0 +1,57 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Session is where the UI was left at quit: the screen, the Library
+// artist and album, the search and the selected row.
+type Session struct {
+	Screen       string `json:"screen"`
+	Pane         string `json:"pane,omitempty"`
+	ArtistID     string `json:"artist_id,omitempty"`
+	AlbumID      string `json:"album_id,omitempty"`
+	SearchQuery  string `json:"search_query,omitempty"`
+	SearchFilter int    `json:"search_filter,omitempty"`
+	Selection    int    `json:"selection,omitempty"`
+	LyricsScroll int    `json:"lyrics_scroll,omitempty"`
+	// Volume is the 0-100 volume at quit, for [player] restore_volume
+	Volume float64 `json:"volume,omitempty"`
+}
+
+// SaveSession stores profileID's UI session, replacing the one saved
+// before.
+func (s *PersistenceStore) SaveSession(ctx context.Context, profileID string, sess Session) error {
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT OR REPLACE INTO ui_sessions (profile_id, session_json, saved_at)
+		VALUES (?, ?, ?)`, profileID, string(data), time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("save session: %w", err)
+	}
+	return nil
+}
+
+// Session returns profileID's saved UI session. ok is false when none was
+// saved.
+func (s *PersistenceStore) Session(ctx context.Context, profileID string) (sess Session, ok bool, err error) {
+	var data string
+	err = s.db.QueryRowContext(ctx, `SELECT session_json FROM ui_sessions WHERE profile_id = ?`, profileID).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Session{}, false, nil
+	}
+	if err != nil {
+		return Session{}, false, fmt.Errorf("query session: %w", err)
+	}
+	if err := json.Unmarshal([]byte(data), &sess); err != nil {
+		return Session{}, false, fmt.Errorf("decode session: %w", err)
+	}
+	return sess, true, nil
+}
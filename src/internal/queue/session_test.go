@@ -0,0 +1,44 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+)
+
+func TestSessions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue.db")
+	store, err := NewPersistenceStore(path)
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	ctx := context.Background()
+
+	if _, ok, err := store.Session(ctx, "home"); err != nil || ok {
+		t.Fatalf("expected no session, got %v %v", ok, err)
+	}
+	want := Session{Screen: "library", Pane: "content", ArtistID: "ar1", AlbumID: "al1", SearchQuery: "blue", SearchFilter: 2, Selection: 7}
+	if err := store.SaveSession(ctx, "home", Session{Screen: "queue"}); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	if err := store.SaveSession(ctx, "home", want); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	if err := store.SaveSession(ctx, "server", Session{Screen: "search"}); err != nil {
+		t.Fatalf("SaveSession: %v", err)
+	}
+	store.Close()
+
+	// Sessions outlive the process
+	store, err = NewPersistenceStore(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	defer store.Close()
+	if got, ok, err := store.Session(ctx, "home"); err != nil || !ok || got != want {
+		t.Errorf("expected the latest session, got %+v %v %v", got, ok, err)
+	}
+	if got, _, _ := store.Session(ctx, "server"); got.Screen != "search" {
+		t.Errorf("expected other profiles kept, got %+v", got)
+	}
+}
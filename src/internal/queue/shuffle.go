@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+// minShuffleWeight keeps every track in a weighted shuffle possible, however
+// the weights add up.
+const minShuffleWeight = 0.05
+
+// recencyWindow is how long after a play a track counts as fully rested
+// for the recency weight.
+const recencyWindow = 30 * 24 * time.Hour
+
+// ShuffleWeights bias a weighted shuffle. A track's weight starts at 1;
+// tracks with higher weights tend to come up sooner.
+type ShuffleWeights struct {
+	Loved   float64 // added for loved tracks
+	Plays   float64 // times log2(1+plays); negative favors rarely played tracks
+	Recency float64 // times how rested the track is, from 0 just played to 1 after 30 days or never
+}
+
+// TrackHistory is what a weighted shuffle knows of a track.
+type TrackHistory struct {
+	Plays      int
+	Loved      bool
+	LastPlayed time.Time // zero when never played
+}
+
+// Weight returns a track's shuffle weight at now.
+func (w ShuffleWeights) Weight(h TrackHistory, now time.Time) float64 {
+	weight := 1 + w.Plays*math.Log2(1+float64(h.Plays))
+	if h.Loved {
+		weight += w.Loved
+	}
+	rested := 1.0
+	if !h.LastPlayed.IsZero() {
+		rested = min(max(float64(now.Sub(h.LastPlayed))/float64(recencyWindow), 0), 1)
+	}
+	weight += w.Recency * rested
+	return max(weight, minShuffleWeight)
+}
+
+// PlayHistory holds the play counts, loved marks and last plays of tracks,
+// by artist and title.
+type PlayHistory map[string]TrackHistory
+
+// Get returns a track's history, zero when it has none.
+func (p PlayHistory) Get(artist, title string) TrackHistory {
+	return p[lastPlaysKey(artist, title)]
+}
+
+// AddPlay counts a play of the track at t.
+func (p PlayHistory) AddPlay(artist, title string, t time.Time) {
+	k := lastPlaysKey(artist, title)
+	h := p[k]
+	h.Plays++
+	h.LastPlayed = t
+	p[k] = h
+}
+
+// SetLoved notes whether the track is loved.
+func (p PlayHistory) SetLoved(artist, title string, loved bool) {
+	k := lastPlaysKey(artist, title)
+	h := p[k]
+	h.Loved = loved
+	p[k] = h
+}
+
+// PlayHistory returns the history of every track played or loved.
+func (s *PersistenceStore) PlayHistory(ctx context.Context) (PlayHistory, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT s.artist_key, s.title_key, s.plays, s.loved, COALESCE(l.played_at, 0)
+		FROM track_stats s LEFT JOIN last_plays l ON l.artist_key = s.artist_key AND l.title_key = s.title_key`)
+	if err != nil {
+		return nil, fmt.Errorf("query play history: %w", err)
+	}
+	defer rows.Close()
+	history := PlayHistory{}
+	for rows.Next() {
+		var artist, title string
+		var h TrackHistory
+		var loved int
+		var at int64
+		if err := rows.Scan(&artist, &title, &h.Plays, &loved, &at); err != nil {
+			return nil, fmt.Errorf("scan play history: %w", err)
+		}
+		h.Loved = loved != 0
+		if at > 0 {
+			h.LastPlayed = time.UnixMilli(at)
+		}
+		history[artist+"\x00"+title] = h
+	}
+	return history, rows.Err()
+}
+
+// SetShuffleWeight makes shuffling weighted: each track comes up next with
+// a chance in proportion to its weight. nil shuffles uniformly again.
+func (q *Queue) SetShuffleWeight(weight func(provider.Track) float64) {
+	q.weight = weight
+}
+
+// weightedShuffle orders items[from:] by weight, keeping seq in step. Each
+// track draws an exponential arrival time at a rate of its weight and
+// tracks play in order of arrival, so the first is picked in proportion to
+// its weight, the second likewise among the rest, and so on.
+func (q *Queue) weightedShuffle(from int) {
+	n := len(q.items) - from
+	keys := make([]float64, n)
+	order := make([]int, n)
+	for i := range n {
+		keys[i] = rand.ExpFloat64() / max(q.weight(q.items[from+i]), minShuffleWeight)
+		order[i] = i
+	}
+	slices.SortFunc(order, func(a, b int) int { return cmp.Compare(keys[a], keys[b]) })
+	items := make([]provider.Track, n)
+	seq := make([]int, n)
+	for i, j := range order {
+		items[i], seq[i] = q.items[from+j], q.seq[from+j]
+	}
+	copy(q.items[from:], items)
+	copy(q.seq[from:], seq)
+}
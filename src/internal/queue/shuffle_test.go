@@ -0,0 +1,132 @@
+package queue
+
+import (
+	"context"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/provider"
+)
+
+func TestShuffleWeights(t *testing.T) {
+	now := time.Now()
+	w := ShuffleWeights{Loved: 2, Plays: 0.5, Recency: 1}
+
+	never := w.Weight(TrackHistory{}, now)
+	if never != 2 {
+		t.Errorf("expected 1 plus a full recency weight for an unplayed track, got %v", never)
+	}
+	if loved := w.Weight(TrackHistory{Loved: true}, now); loved != never+2 {
+		t.Errorf("expected loved to add 2, got %v", loved)
+	}
+	// Plays raise the weight, ever more slowly
+	one := w.Weight(TrackHistory{Plays: 1, LastPlayed: now.Add(-60 * 24 * time.Hour)}, now)
+	many := w.Weight(TrackHistory{Plays: 15, LastPlayed: now.Add(-60 * 24 * time.Hour)}, now)
+	if one != 2.5 || many != 4 {
+		t.Errorf("expected 2.5 and 4 for 1 and 15 plays, got %v and %v", one, many)
+	}
+	// Recently played tracks weigh less, regaining weight over 30 days
+	just := w.Weight(TrackHistory{Plays: 1, LastPlayed: now}, now)
+	mid := w.Weight(TrackHistory{Plays: 1, LastPlayed: now.Add(-15 * 24 * time.Hour)}, now)
+	if just != 1.5 || math.Abs(mid-2) > 1e-9 {
+		t.Errorf("expected 1.5 just played and 2 after 15 days, got %v and %v", just, mid)
+	}
+	// Negative play weights favor rarely played tracks, but never rule
+	// any out
+	rare := ShuffleWeights{Plays: -1}
+	if got := rare.Weight(TrackHistory{Plays: 1000}, now); got != minShuffleWeight {
+		t.Errorf("expected the minimum weight, got %v", got)
+	}
+}
+
+func TestWeightedShuffleDistribution(t *testing.T) {
+	weights := map[string]float64{"t1": 1, "t2": 2, "t3": 7}
+	const runs = 20000
+	first := map[string]int{}
+	last := map[string]int{}
+	for range runs {
+		q := New()
+		q.Add(sampleTracks(4)...)
+		q.SetShuffleWeight(func(tr provider.Track) float64 { return weights[tr.ID] })
+		q.ShuffleRemaining()
+		items := q.Items()
+		if items[0].ID != "t0" {
+			t.Fatalf("expected the current track kept first, got %v", items)
+		}
+		first[items[1].ID]++
+		last[items[3].ID]++
+	}
+	// The next track is picked in proportion to its weight
+	for id, w := range weights {
+		want := w / 10
+		if got := float64(first[id]) / runs; math.Abs(got-want) > 0.02 {
+			t.Errorf("expected %s next %.2f of the time, got %.3f", id, want, got)
+		}
+	}
+	// The lightest track is most often left for last
+	if last["t1"] <= last["t2"] || last["t2"] <= last["t3"] {
+		t.Errorf("expected lighter tracks last more often, got %v", last)
+	}
+}
+
+func TestWeightedShuffleUnshuffle(t *testing.T) {
+	q := New()
+	q.Add(sampleTracks(6)...)
+	q.SetShuffleWeight(func(tr provider.Track) float64 {
+		if tr.ID == "t5" {
+			return 1000
+		}
+		return 1
+	})
+	q.ShuffleRemaining()
+	if items := q.Items(); items[1].ID != "t5" || len(items) != 6 {
+		t.Fatalf("expected the heavy track next, got %v", items)
+	}
+	q.Unshuffle()
+	for i, tr := range q.Items() {
+		if tr.ID != sampleTracks(6)[i].ID {
+			t.Fatalf("expected the queued order restored, got %v", q.Items())
+		}
+	}
+}
+
+func TestPlayHistory(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.RecordPlay(ctx, "Queen", "Innuendo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.RecordPlay(ctx, "queen", "innuendo"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetLoved(ctx, "Abba", "Arrival", true); err != nil {
+		t.Fatal(err)
+	}
+	history, err := store.PlayHistory(ctx)
+	if err != nil {
+		t.Fatalf("PlayHistory: %v", err)
+	}
+	if h := history.Get("QUEEN", "Innuendo"); h.Plays != 2 || h.Loved || time.Since(h.LastPlayed) > time.Minute {
+		t.Errorf("expected two recent plays, got %+v", h)
+	}
+	if h := history.Get("Abba", "Arrival"); h.Plays != 0 || !h.Loved || !h.LastPlayed.IsZero() {
+		t.Errorf("expected loved and never played, got %+v", h)
+	}
+
+	at := time.Now()
+	history.AddPlay("Abba", "Arrival", at)
+	history.SetLoved("Queen", "Innuendo", true)
+	if h := history.Get("Abba", "Arrival"); h.Plays != 1 || !h.LastPlayed.Equal(at) || !h.Loved {
+		t.Errorf("expected a play added, got %+v", h)
+	}
+	if h := history.Get("Queen", "Innuendo"); !h.Loved || h.Plays != 2 {
+		t.Errorf("expected loved, got %+v", h)
+	}
+}
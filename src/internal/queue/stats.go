@@ -0,0 +1,164 @@
+package queue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TrackStats is the play count and loved mark of a track, identified by
+// artist and title rather than a provider's track ID.
+type TrackStats struct {
+	Artist string
+	Title  string
+	Plays  int
+	Loved  bool
+}
+
+// statsKey normalizes an artist or title for matching: case, surrounding
+// and repeated spaces are ignored.
+func statsKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// TrackStats returns the stats recorded for a track, zero when there are
+// none.
+func (s *PersistenceStore) TrackStats(ctx context.Context, artist, title string) (TrackStats, error) {
+	st := TrackStats{Artist: artist, Title: title}
+	var loved int
+	err := s.db.QueryRowContext(ctx, `SELECT plays, loved FROM track_stats WHERE artist_key = ? AND title_key = ?`,
+		statsKey(artist), statsKey(title)).Scan(&st.Plays, &loved)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return st, fmt.Errorf("query track stats: %w", err)
+	}
+	st.Loved = loved != 0
+	return st, nil
+}
+
+// SetLoved records whether a track is loved.
+func (s *PersistenceStore) SetLoved(ctx context.Context, artist, title string, loved bool) error {
+	_, err := s.db.ExecContext(ctx, `INSERT INTO track_stats (artist_key, title_key, loved, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (artist_key, title_key) DO UPDATE SET loved = excluded.loved, updated_at = excluded.updated_at`,
+		statsKey(artist), statsKey(title), boolToInt(loved), time.Now().UnixMilli())
+	if err != nil {
+		return fmt.Errorf("set loved: %w", err)
+	}
+	return nil
+}
+
+// RecordPlay counts one play of a track and notes when it was played.
+func (s *PersistenceStore) RecordPlay(ctx context.Context, artist, title string) error {
+	now := time.Now().UnixMilli()
+	_, err := s.db.ExecContext(ctx, `INSERT INTO track_stats (artist_key, title_key, plays, updated_at) VALUES (?, ?, 1, ?)
+		ON CONFLICT (artist_key, title_key) DO UPDATE SET plays = plays + 1, updated_at = excluded.updated_at`,
+		statsKey(artist), statsKey(title), now)
+	if err != nil {
+		return fmt.Errorf("record play: %w", err)
+	}
+	_, err = s.db.ExecContext(ctx, `INSERT INTO last_plays (artist_key, title_key, played_at) VALUES (?, ?, ?)
+		ON CONFLICT (artist_key, title_key) DO UPDATE SET played_at = excluded.played_at`,
+		statsKey(artist), statsKey(title), now)
+	if err != nil {
+		return fmt.Errorf("record play time: %w", err)
+	}
+	return nil
+}
+
+// LastPlays holds when tracks were last played, by artist and title.
+type LastPlays map[string]time.Time
+
+func lastPlaysKey(artist, title string) string {
+	return statsKey(artist) + "\x00" + statsKey(title)
+}
+
+// PlayedSince reports whether the track was played at or after t.
+func (p LastPlays) PlayedSince(artist, title string, t time.Time) bool {
+	at, ok := p[lastPlaysKey(artist, title)]
+	return ok && !at.Before(t)
+}
+
+// Add notes a play of the track at t.
+func (p LastPlays) Add(artist, title string, t time.Time) {
+	p[lastPlaysKey(artist, title)] = t
+}
+
+// LastPlays returns the tracks last played at or after since.
+func (s *PersistenceStore) LastPlays(ctx context.Context, since time.Time) (LastPlays, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT artist_key, title_key, played_at FROM last_plays WHERE played_at >= ?`, since.UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("query last plays: %w", err)
+	}
+	defer rows.Close()
+	plays := LastPlays{}
+	for rows.Next() {
+		var artist, title string
+		var at int64
+		if err := rows.Scan(&artist, &title, &at); err != nil {
+			return nil, fmt.Errorf("scan last plays: %w", err)
+		}
+		plays[artist+"\x00"+title] = time.UnixMilli(at)
+	}
+	return plays, rows.Err()
+}
+
+// ArtistPlays holds the play counts of an artist's tracks.
+type ArtistPlays map[string]int
+
+// Plays returns the play count of the track titled title.
+func (p ArtistPlays) Plays(title string) int {
+	return p[statsKey(title)]
+}
+
+// ArtistPlays returns the play counts of every played track by artist.
+func (s *PersistenceStore) ArtistPlays(ctx context.Context, artist string) (ArtistPlays, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT title_key, plays FROM track_stats WHERE artist_key = ? AND plays > 0`, statsKey(artist))
+	if err != nil {
+		return nil, fmt.Errorf("query artist plays: %w", err)
+	}
+	defer rows.Close()
+	plays := ArtistPlays{}
+	for rows.Next() {
+		var title string
+		var n int
+		if err := rows.Scan(&title, &n); err != nil {
+			return nil, fmt.Errorf("scan artist plays: %w", err)
+		}
+		plays[title] = n
+	}
+	return plays, rows.Err()
+}
+
+// ImportStats merges stats from another service: the higher play count
+// wins and loved marks are added, so importing twice changes nothing.
+func (s *PersistenceStore) ImportStats(ctx context.Context, stats []TrackStats) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `INSERT INTO track_stats (artist_key, title_key, plays, loved, updated_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (artist_key, title_key) DO UPDATE SET plays = max(plays, excluded.plays), loved = max(loved, excluded.loved), updated_at = excluded.updated_at`)
+	if err != nil {
+		return fmt.Errorf("prepare import: %w", err)
+	}
+	defer stmt.Close()
+
+	now := time.Now().UnixMilli()
+	for _, st := range stats {
+		if _, err := stmt.ExecContext(ctx, statsKey(st.Artist), statsKey(st.Title), st.Plays, boolToInt(st.Loved), now); err != nil {
+			return fmt.Errorf("import stats for %s - %s: %w", st.Artist, st.Title, err)
+		}
+	}
+	return tx.Commit()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,110 @@
+package queue
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTrackStats(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if st, err := store.TrackStats(ctx, "Queen", "Innuendo"); err != nil || st.Plays != 0 || st.Loved {
+		t.Fatalf("expected no stats yet, got %+v %v", st, err)
+	}
+
+	imported := []TrackStats{
+		{Artist: "Queen", Title: "Innuendo", Plays: 12},
+		{Artist: "Abba", Title: "Dancing Queen", Plays: 3, Loved: true},
+	}
+	for range 2 {
+		if err := store.ImportStats(ctx, imported); err != nil {
+			t.Fatalf("ImportStats: %v", err)
+		}
+	}
+	// Matching ignores case and spacing; importing twice doesn't add up
+	if st, _ := store.TrackStats(ctx, "QUEEN", " innuendo "); st.Plays != 12 || st.Loved {
+		t.Errorf("expected 12 plays, not loved, got %+v", st)
+	}
+
+	if err := store.SetLoved(ctx, "Abba", "Dancing  Queen", false); err != nil {
+		t.Fatalf("SetLoved: %v", err)
+	}
+	// A lower imported count keeps the higher one; loved marks are added
+	if err := store.ImportStats(ctx, []TrackStats{{Artist: "Queen", Title: "Innuendo", Plays: 5, Loved: true}}); err != nil {
+		t.Fatalf("ImportStats: %v", err)
+	}
+	if st, _ := store.TrackStats(ctx, "Queen", "Innuendo"); st.Plays != 12 || !st.Loved {
+		t.Errorf("expected 12 plays and loved, got %+v", st)
+	}
+	if st, _ := store.TrackStats(ctx, "Abba", "Dancing Queen"); st.Plays != 3 || st.Loved {
+		t.Errorf("expected the unloved mark kept, got %+v", st)
+	}
+}
+
+func TestRecordPlay(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	if err := store.SetLoved(ctx, "Queen", "Innuendo", true); err != nil {
+		t.Fatalf("SetLoved: %v", err)
+	}
+	for _, title := range []string{"Innuendo", "innuendo", "Bicycle Race"} {
+		if err := store.RecordPlay(ctx, "Queen", title); err != nil {
+			t.Fatalf("RecordPlay: %v", err)
+		}
+	}
+	if err := store.RecordPlay(ctx, "Abba", "Waterloo"); err != nil {
+		t.Fatalf("RecordPlay: %v", err)
+	}
+
+	plays, err := store.ArtistPlays(ctx, "queen")
+	if err != nil {
+		t.Fatalf("ArtistPlays: %v", err)
+	}
+	if len(plays) != 2 || plays.Plays("INNUENDO") != 2 || plays.Plays("Bicycle Race") != 1 || plays.Plays("Waterloo") != 0 {
+		t.Errorf("unexpected plays %v", plays)
+	}
+	if st, _ := store.TrackStats(ctx, "Queen", "Innuendo"); !st.Loved {
+		t.Error("expected counting a play to keep the loved mark")
+	}
+}
+
+func TestLastPlays(t *testing.T) {
+	store, err := NewPersistenceStore(filepath.Join(t.TempDir(), "queue.db"))
+	if err != nil {
+		t.Fatalf("NewPersistenceStore: %v", err)
+	}
+	defer store.Close()
+	ctx := context.Background()
+
+	before := time.Now().Add(-time.Second)
+	if err := store.RecordPlay(ctx, "Queen", "Innuendo"); err != nil {
+		t.Fatalf("RecordPlay: %v", err)
+	}
+	plays, err := store.LastPlays(ctx, before)
+	if err != nil {
+		t.Fatalf("LastPlays: %v", err)
+	}
+	if !plays.PlayedSince("queen", " Innuendo", before) {
+		t.Errorf("expected Innuendo played since %v, got %v", before, plays)
+	}
+	if plays.PlayedSince("Queen", "Bicycle Race", before) {
+		t.Error("expected an unplayed track not to count")
+	}
+
+	// Plays before since are left out
+	if plays, _ := store.LastPlays(ctx, time.Now().Add(time.Hour)); len(plays) != 0 {
+		t.Errorf("expected no plays in the future, got %v", plays)
+	}
+}
@@ -0,0 +1,187 @@
+package scrobble
+
+import (
+	"context"
+	"time"
+)
+
+// Flush loop defaults.
+const (
+	DefaultFlushInterval = 30 * time.Second
+	DefaultMaxBackoff    = 30 * time.Minute
+)
+
+// FlushOptions configures the background flush loop.
+type FlushOptions struct {
+	// Interval is how often pending scrobbles and connectivity are checked,
+	// and the first retry delay after a failure.
+	Interval time.Duration
+	// MaxBackoff caps the exponential retry delay.
+	MaxBackoff time.Duration
+	// Online reports whether the network is reachable. When it returns false
+	// flushing is paused; the first check that succeeds again retries every
+	// backend immediately. Nil means always online.
+	Online func(ctx context.Context) bool
+	// OnFlush, if set, is called after every flush attempt.
+	OnFlush func(id string, err error)
+}
+
+// PendingStatus describes the offline queue of one scrobbler.
+type PendingStatus struct {
+	ID        string
+	Name      string
+	Enabled   bool
+	Pending   int
+	Failures  int       // consecutive failed flushes
+	LastError string    // most recent flush error, "" after a success
+	NextRetry time.Time // zero when no retry is scheduled
+	LastFlush time.Time // last successful flush
+}
+
+// flushState tracks retry backoff for one scrobbler.
+type flushState struct {
+	failures  int
+	lastErr   string
+	nextRetry time.Time
+	lastFlush time.Time
+}
+
+// StartFlushLoop submits pending scrobbles in the background until ctx is
+// canceled. Failed backends are retried with exponential backoff.
+func (m *Manager) StartFlushLoop(ctx context.Context, opts FlushOptions) {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultFlushInterval
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = DefaultMaxBackoff
+	}
+
+	go func() {
+		ticker := time.NewTicker(opts.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				m.flushDue(ctx, opts, time.Now())
+			}
+		}
+	}()
+}
+
+// flushDue runs one iteration of the flush loop.
+func (m *Manager) flushDue(ctx context.Context, opts FlushOptions, now time.Time) {
+	var due []Scrobbler
+	for _, s := range m.Scrobblers() {
+		if s.IsEnabled() && s.PendingCount() > 0 {
+			due = append(due, s)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+
+	if opts.Online != nil {
+		online := opts.Online(ctx)
+		m.mu.Lock()
+		wasOffline := m.offline
+		m.offline = !online
+		if online && wasOffline {
+			// Connectivity is back: don't sit out the rest of the backoff
+			for _, st := range m.flush {
+				st.nextRetry = time.Time{}
+			}
+		}
+		m.mu.Unlock()
+		if !online {
+			return
+		}
+	}
+
+	for _, s := range due {
+		m.mu.Lock()
+		st := m.flushStateLocked(s.ID())
+		ready := !now.Before(st.nextRetry)
+		m.mu.Unlock()
+		if !ready {
+			continue
+		}
+
+		err := s.FlushPending(ctx)
+
+		m.mu.Lock()
+		if err != nil {
+			st.failures++
+			st.lastErr = err.Error()
+			st.nextRetry = now.Add(backoff(opts.Interval, opts.MaxBackoff, st.failures))
+		} else {
+			st.failures = 0
+			st.lastErr = ""
+			st.nextRetry = time.Time{}
+			st.lastFlush = now
+			m.markFlushedLocked(s.ID())
+		}
+		m.mu.Unlock()
+
+		if opts.OnFlush != nil {
+			opts.OnFlush(s.ID(), err)
+		}
+	}
+}
+
+// flushStateLocked returns the backoff state for id. m.mu must be held.
+func (m *Manager) flushStateLocked(id string) *flushState {
+	if m.flush == nil {
+		m.flush = make(map[string]*flushState)
+	}
+	st, ok := m.flush[id]
+	if !ok {
+		st = &flushState{}
+		m.flush[id] = st
+	}
+	return st
+}
+
+// backoff returns base * 2^(failures-1), capped at limit.
+func backoff(base, limit time.Duration, failures int) time.Duration {
+	d := base
+	for i := 1; i < failures && d < limit; i++ {
+		d *= 2
+	}
+	if d > limit {
+		return limit
+	}
+	return d
+}
+
+// PendingStatus returns the offline queue state of every scrobbler.
+func (m *Manager) PendingStatus() []PendingStatus {
+	scrobblers := m.Scrobblers()
+	out := make([]PendingStatus, 0, len(scrobblers))
+	for _, s := range scrobblers {
+		ps := PendingStatus{
+			ID:      s.ID(),
+			Name:    s.Name(),
+			Enabled: s.IsEnabled(),
+			Pending: s.PendingCount(),
+		}
+		m.mu.RLock()
+		if st, ok := m.flush[s.ID()]; ok {
+			ps.Failures = st.failures
+			ps.LastError = st.lastErr
+			ps.NextRetry = st.nextRetry
+			ps.LastFlush = st.lastFlush
+		}
+		m.mu.RUnlock()
+		out = append(out, ps)
+	}
+	return out
+}
+
+// Offline reports whether the last connectivity check failed.
+func (m *Manager) Offline() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.offline
+}
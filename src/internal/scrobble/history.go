@@ -0,0 +1,165 @@
+package scrobble
+
+import (
+	"time"
+)
+
+// maxHistory is how many recent scrobbles the manager remembers.
+const maxHistory = 100
+
+// SubmitStatus is the outcome of a scrobble on one backend.
+type SubmitStatus string
+
+const (
+	StatusSending SubmitStatus = "sending"
+	StatusSent    SubmitStatus = "sent"
+	StatusQueued  SubmitStatus = "queued"  // backend not ready, waiting in its pending queue
+	StatusFailed  SubmitStatus = "failed"  // submit failed, queued for retry
+	StatusRemoved SubmitStatus = "removed" // deleted from the pending queue by the user
+	StatusSkipped SubmitStatus = "skipped" // backend can't scrobble this track
+)
+
+// BackendResult is the status of a scrobble on one backend.
+type BackendResult struct {
+	ID     string
+	Name   string
+	Status SubmitStatus
+	Error  string
+}
+
+// HistoryEntry is a scrobble submitted during this session.
+type HistoryEntry struct {
+	Track   Track
+	At      time.Time
+	Results []BackendResult
+}
+
+// PendingEditor is implemented by scrobblers whose offline queue can be
+// inspected and edited before it is flushed.
+type PendingEditor interface {
+	// PendingTracks returns the queued scrobbles, oldest first.
+	PendingTracks() []Track
+	// RemovePending drops the queued scrobble matching track.
+	RemovePending(track Track) bool
+}
+
+// PendingItem is a queued scrobble on a specific backend.
+type PendingItem struct {
+	ScrobblerID   string
+	ScrobblerName string
+	Track         Track
+}
+
+// SameAs reports whether two tracks describe the same play.
+func (t Track) SameAs(o Track) bool {
+	return t.Title == o.Title && t.Artist == o.Artist && t.StartedAt.Equal(o.StartedAt)
+}
+
+// recordSubmit adds a history entry for a scrobble fanned out to scrobblers
+// and returns it for the per-backend goroutines to fill in.
+func (m *Manager) recordSubmit(track Track, scrobblers []Scrobbler) *HistoryEntry {
+	entry := &HistoryEntry{Track: track, At: time.Now()}
+	for _, s := range scrobblers {
+		entry.Results = append(entry.Results, BackendResult{ID: s.ID(), Name: s.Name(), Status: StatusSending})
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.history = append(m.history, entry)
+	if len(m.history) > maxHistory {
+		m.history = m.history[len(m.history)-maxHistory:]
+	}
+	return entry
+}
+
+// setResult updates the status of one backend in a history entry.
+func (m *Manager) setResult(entry *HistoryEntry, id string, status SubmitStatus, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range entry.Results {
+		if entry.Results[i].ID != id {
+			continue
+		}
+		entry.Results[i].Status = status
+		entry.Results[i].Error = ""
+		if err != nil {
+			entry.Results[i].Error = err.Error()
+		}
+	}
+	if m.onResult != nil {
+		m.onResult(id, status)
+	}
+}
+
+// markFlushedLocked marks every queued or failed scrobble of a backend as sent
+// after its pending queue was flushed. m.mu must be held.
+func (m *Manager) markFlushedLocked(id string) {
+	for _, entry := range m.history {
+		for i := range entry.Results {
+			r := &entry.Results[i]
+			if r.ID == id && (r.Status == StatusQueued || r.Status == StatusFailed) {
+				r.Status = StatusSent
+				r.Error = ""
+				if m.onResult != nil {
+					m.onResult(id, StatusSent)
+				}
+			}
+		}
+	}
+}
+
+// History returns the scrobbles submitted this session, newest first.
+func (m *Manager) History() []HistoryEntry {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	out := make([]HistoryEntry, 0, len(m.history))
+	for i := len(m.history) - 1; i >= 0; i-- {
+		e := *m.history[i]
+		e.Results = append([]BackendResult(nil), e.Results...)
+		out = append(out, e)
+	}
+	return out
+}
+
+// PendingItems lists the queued scrobbles of every backend that exposes them.
+func (m *Manager) PendingItems() []PendingItem {
+	var items []PendingItem
+	for _, s := range m.Scrobblers() {
+		editor, ok := s.(PendingEditor)
+		if !ok {
+			continue
+		}
+		for _, t := range editor.PendingTracks() {
+			items = append(items, PendingItem{ScrobblerID: s.ID(), ScrobblerName: s.Name(), Track: t})
+		}
+	}
+	return items
+}
+
+// RemovePending deletes a queued scrobble from one backend so it is never
+// sent.
+func (m *Manager) RemovePending(scrobblerID string, track Track) bool {
+	for _, s := range m.Scrobblers() {
+		editor, ok := s.(PendingEditor)
+		if !ok || s.ID() != scrobblerID {
+			continue
+		}
+		if !editor.RemovePending(track) {
+			return false
+		}
+		m.mu.Lock()
+		for _, entry := range m.history {
+			if !entry.Track.SameAs(track) {
+				continue
+			}
+			for i := range entry.Results {
+				if entry.Results[i].ID == scrobblerID {
+					entry.Results[i].Status = StatusRemoved
+				}
+			}
+		}
+		m.mu.Unlock()
+		return true
+	}
+	return false
+}
@@ -0,0 +1,162 @@
+package lastfm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const authPageURL = "https://www.last.fm/api/auth/"
+
+var (
+	// ErrTokenNotAuthorized means the user has not approved the token yet.
+	ErrTokenNotAuthorized = errors.New("lastfm: token not yet authorized")
+	// ErrTokenExpired means the token timed out before it was approved.
+	ErrTokenExpired = errors.New("lastfm: token expired")
+)
+
+// Last.fm API error codes relevant to the auth flow.
+const (
+	errCodeInvalidToken  = 4
+	errCodeNotAuthorized = 14
+	errCodeTokenExpired  = 15
+)
+
+// Session is the result of a successful auth.getSession call.
+type Session struct {
+	Name string // Last.fm username
+	Key  string // session key for signed requests
+}
+
+// Auth implements the Last.fm desktop auth flow:
+// auth.getToken → user approves in browser → auth.getSession.
+type Auth struct {
+	apiKey    string
+	apiSecret string
+	endpoint  string
+	client    *http.Client
+}
+
+// NewAuth creates an auth client for the given API account.
+func NewAuth(apiKey, apiSecret string) *Auth {
+	return &Auth{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		endpoint:  apiURL,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// GetToken requests an unauthorized request token.
+func (a *Auth) GetToken(ctx context.Context) (string, error) {
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := a.call(ctx, map[string]string{"method": "auth.getToken"}, &resp); err != nil {
+		return "", fmt.Errorf("get token: %w", err)
+	}
+	if resp.Token == "" {
+		return "", errors.New("get token: empty token in response")
+	}
+	return resp.Token, nil
+}
+
+// AuthURL returns the page where the user approves the token.
+func (a *Auth) AuthURL(token string) string {
+	q := url.Values{}
+	q.Set("api_key", a.apiKey)
+	q.Set("token", token)
+	return authPageURL + "?" + q.Encode()
+}
+
+// GetSession exchanges an approved token for a session key. It returns
+// ErrTokenNotAuthorized while the user has not approved the token yet.
+func (a *Auth) GetSession(ctx context.Context, token string) (Session, error) {
+	var resp struct {
+		Session struct {
+			Name string `json:"name"`
+			Key  string `json:"key"`
+		} `json:"session"`
+	}
+	params := map[string]string{"method": "auth.getSession", "token": token}
+	if err := a.call(ctx, params, &resp); err != nil {
+		return Session{}, err
+	}
+	if resp.Session.Key == "" {
+		return Session{}, errors.New("get session: empty session key in response")
+	}
+	return Session{Name: resp.Session.Name, Key: resp.Session.Key}, nil
+}
+
+// WaitForSession polls GetSession every interval until the token is
+// approved, expires, or ctx is cancelled.
+func (a *Auth) WaitForSession(ctx context.Context, token string, interval time.Duration) (Session, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		session, err := a.GetSession(ctx, token)
+		if !errors.Is(err, ErrTokenNotAuthorized) {
+			return session, err
+		}
+		select {
+		case <-ctx.Done():
+			return Session{}, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *Auth) call(ctx context.Context, params map[string]string, out any) error {
+	params["api_key"] = a.apiKey
+	params["api_sig"] = signParams(params, a.apiSecret)
+	params["format"] = "json"
+
+	form := url.Values{}
+	for k, v := range params {
+		form.Set(k, v)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	var raw json.RawMessage
+	if err := json.NewDecoder(resp.Body).Decode(&raw); err != nil {
+		if resp.StatusCode >= 400 {
+			return fmt.Errorf("lastfm error: %s", resp.Status)
+		}
+		return fmt.Errorf("decode response: %w", err)
+	}
+
+	// Last.fm reports API errors in the body, sometimes with a 4xx status
+	var apiErr struct {
+		Error   int    `json:"error"`
+		Message string `json:"message"`
+	}
+	_ = json.Unmarshal(raw, &apiErr)
+	switch apiErr.Error {
+	case 0:
+	case errCodeNotAuthorized:
+		return ErrTokenNotAuthorized
+	case errCodeTokenExpired, errCodeInvalidToken:
+		return ErrTokenExpired
+	default:
+		return fmt.Errorf("lastfm error %d: %s", apiErr.Error, apiErr.Message)
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("lastfm error: %s", resp.Status)
+	}
+	return json.Unmarshal(raw, out)
+}
@@ -0,0 +1,94 @@
+package lastfm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestAuth(t *testing.T, handler http.HandlerFunc) *Auth {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	a := NewAuth("key", "secret")
+	a.endpoint = srv.URL
+	return a
+}
+
+func TestAuthGetToken(t *testing.T) {
+	a := newTestAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if r.Form.Get("method") != "auth.getToken" {
+			t.Errorf("unexpected method %q", r.Form.Get("method"))
+		}
+		if r.Form.Get("api_key") != "key" || r.Form.Get("api_sig") == "" {
+			t.Errorf("expected api_key and api_sig, got %v", r.Form)
+		}
+		w.Write([]byte(`{"token":"tok123"}`))
+	})
+
+	token, err := a.GetToken(context.Background())
+	if err != nil {
+		t.Fatalf("get token: %v", err)
+	}
+	if token != "tok123" {
+		t.Errorf("expected tok123, got %q", token)
+	}
+	if u := a.AuthURL(token); !strings.Contains(u, "api_key=key") || !strings.Contains(u, "token=tok123") {
+		t.Errorf("unexpected auth url %q", u)
+	}
+}
+
+func TestAuthWaitForSession(t *testing.T) {
+	var calls int32
+	a := newTestAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"error":14,"message":"Unauthorized Token"}`))
+			return
+		}
+		w.Write([]byte(`{"session":{"name":"listener","key":"sk-abc","subscriber":0}}`))
+	})
+
+	session, err := a.WaitForSession(context.Background(), "tok", time.Millisecond)
+	if err != nil {
+		t.Fatalf("wait for session: %v", err)
+	}
+	if session.Key != "sk-abc" || session.Name != "listener" {
+		t.Errorf("unexpected session %+v", session)
+	}
+	if got := atomic.LoadInt32(&calls); got != 3 {
+		t.Errorf("expected 3 polls, got %d", got)
+	}
+}
+
+func TestAuthTokenExpired(t *testing.T) {
+	a := newTestAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":15,"message":"This token has expired"}`))
+	})
+
+	_, err := a.WaitForSession(context.Background(), "tok", time.Millisecond)
+	if !errors.Is(err, ErrTokenExpired) {
+		t.Errorf("expected ErrTokenExpired, got %v", err)
+	}
+}
+
+func TestAuthWaitCancelled(t *testing.T) {
+	a := newTestAuth(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"error":14,"message":"Unauthorized Token"}`))
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := a.WaitForSession(ctx, "tok", 5*time.Millisecond)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
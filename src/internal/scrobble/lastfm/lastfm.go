@@ -6,6 +6,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"os"
@@ -36,6 +37,7 @@ type Scrobbler struct {
 	apiSecret    string
 	sessionKey   string
 	enabled      bool
+	endpoint     string
 	client       *http.Client
 	pending      []scrobbleEntry
 	nowPlaying   *scrobble.Track
@@ -59,6 +61,7 @@ func New(id string, cfg Config) *Scrobbler {
 		apiSecret:  cfg.APISecret,
 		sessionKey: cfg.SessionKey,
 		enabled:    cfg.APIKey != "" && cfg.APISecret != "",
+		endpoint:   apiURL,
 		client:     &http.Client{Timeout: 10 * time.Second},
 	}
 }
@@ -67,7 +70,7 @@ func (s *Scrobbler) ID() string   { return s.id }
 func (s *Scrobbler) Name() string { return "Last.fm" }
 
 func (s *Scrobbler) IsEnabled() bool {
-	return s.enabled && s.sessionKey != ""
+	return s.enabled && s.session() != ""
 }
 
 // SetSessionKey sets the session key for authenticated requests.
@@ -77,6 +80,13 @@ func (s *Scrobbler) SetSessionKey(key string) {
 	s.sessionKey = key
 }
 
+// session returns the session key; it can be set while requests run.
+func (s *Scrobbler) session() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sessionKey
+}
+
 func (s *Scrobbler) NowPlaying(ctx context.Context, track scrobble.Track) error {
 	s.mu.Lock()
 	s.nowPlaying = &track
@@ -94,7 +104,7 @@ func (s *Scrobbler) NowPlaying(ctx context.Context, track scrobble.Track) error
 		"artist":  track.Artist,
 		"album":   track.Album,
 		"api_key": s.apiKey,
-		"sk":      s.sessionKey,
+		"sk":      s.session(),
 	}
 	if track.DurationMs > 0 {
 		params["duration"] = fmt.Sprintf("%d", track.DurationMs/1000)
@@ -146,6 +156,15 @@ func (s *Scrobbler) Scrobble(ctx context.Context, track scrobble.Track) error {
 		return nil
 	}
 
+	if err := s.submit(ctx, track); err != nil {
+		s.queueScrobble(track)
+		return err
+	}
+	return nil
+}
+
+// submit sends a single track.scrobble without touching the pending queue.
+func (s *Scrobbler) submit(ctx context.Context, track scrobble.Track) error {
 	params := map[string]string{
 		"method":    "track.scrobble",
 		"track":     track.Title,
@@ -153,18 +172,44 @@ func (s *Scrobbler) Scrobble(ctx context.Context, track scrobble.Track) error {
 		"album":     track.Album,
 		"timestamp": fmt.Sprintf("%d", track.StartedAt.Unix()),
 		"api_key":   s.apiKey,
-		"sk":        s.sessionKey,
+		"sk":        s.session(),
 	}
 	if track.DurationMs > 0 {
 		params["duration"] = fmt.Sprintf("%d", track.DurationMs/1000)
 	}
 
-	err := s.signedPost(ctx, params)
-	if err != nil {
-		s.queueScrobble(track)
-		return err
+	return s.signedPost(ctx, params)
+}
+
+// Love marks a track as loved (track.love) or removes it (track.unlove).
+func (s *Scrobbler) Love(ctx context.Context, track scrobble.Track, love bool) error {
+	if !s.IsEnabled() {
+		return scrobble.ErrNotConfigured
 	}
-	return nil
+	method := "track.love"
+	if !love {
+		method = "track.unlove"
+	}
+	return s.signedPost(ctx, map[string]string{
+		"method":  method,
+		"track":   track.Title,
+		"artist":  track.Artist,
+		"api_key": s.apiKey,
+		"sk":      s.session(),
+	})
+}
+
+// Check verifies the session key by fetching the authorized user's profile
+// (user.getInfo).
+func (s *Scrobbler) Check(ctx context.Context) error {
+	if !s.IsEnabled() {
+		return scrobble.ErrNotConfigured
+	}
+	return s.signedPost(ctx, map[string]string{
+		"method":  "user.getInfo",
+		"api_key": s.apiKey,
+		"sk":      s.session(),
+	})
 }
 
 func (s *Scrobbler) PendingCount() int {
@@ -184,9 +229,11 @@ func (s *Scrobbler) FlushPending(ctx context.Context) error {
 	s.mu.Unlock()
 
 	var failed []scrobbleEntry
+	var lastErr error
 	for _, entry := range pending {
-		if err := s.Scrobble(ctx, entry.Track); err != nil {
+		if err := s.submit(ctx, entry.Track); err != nil {
 			failed = append(failed, entry)
+			lastErr = err
 		}
 	}
 
@@ -194,12 +241,36 @@ func (s *Scrobbler) FlushPending(ctx context.Context) error {
 		s.mu.Lock()
 		s.pending = append(failed, s.pending...)
 		s.mu.Unlock()
-		return fmt.Errorf("failed to scrobble %d tracks", len(failed))
+		return fmt.Errorf("failed to scrobble %d tracks: %w", len(failed), lastErr)
 	}
 
 	return nil
 }
 
+// PendingTracks returns the queued scrobbles, oldest first.
+func (s *Scrobbler) PendingTracks() []scrobble.Track {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tracks := make([]scrobble.Track, len(s.pending))
+	for i, e := range s.pending {
+		tracks[i] = e.Track
+	}
+	return tracks
+}
+
+// RemovePending drops a queued scrobble so it is never sent.
+func (s *Scrobbler) RemovePending(track scrobble.Track) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.pending {
+		if e.Track.SameAs(track) {
+			s.pending = append(s.pending[:i:i], s.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Scrobbler) queueScrobble(track scrobble.Track) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -216,6 +287,12 @@ func (s *Scrobbler) queueScrobble(track scrobble.Track) {
 }
 
 func (s *Scrobbler) signedPost(ctx context.Context, params map[string]string) error {
+	return s.signedCall(ctx, params, nil)
+}
+
+// signedCall posts a signed request and, when out is set, decodes the
+// response into it.
+func (s *Scrobbler) signedCall(ctx context.Context, params map[string]string, out any) error {
 	params["api_sig"] = s.sign(params)
 	params["format"] = "json"
 
@@ -224,7 +301,7 @@ func (s *Scrobbler) signedPost(ctx context.Context, params map[string]string) er
 		form.Set(k, v)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(form.Encode()))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, strings.NewReader(form.Encode()))
 	if err != nil {
 		return err
 	}
@@ -246,20 +323,35 @@ func (s *Scrobbler) signedPost(ctx context.Context, params map[string]string) er
 		return fmt.Errorf("lastfm error: %s", resp.Status)
 	}
 
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
 	var result struct {
 		Error   int    `json:"error"`
 		Message string `json:"message"`
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err == nil {
+	if err := json.Unmarshal(body, &result); err == nil {
 		if result.Error != 0 {
 			return fmt.Errorf("lastfm error %d: %s", result.Error, result.Message)
 		}
 	}
+	if out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
 
 	return nil
 }
 
 func (s *Scrobbler) sign(params map[string]string) string {
+	return signParams(params, s.apiSecret)
+}
+
+// signParams computes the Last.fm api_sig: md5 over the sorted key/value
+// pairs (excluding format) followed by the shared secret.
+func signParams(params map[string]string, secret string) string {
 	keys := make([]string, 0, len(params))
 	for k := range params {
 		if k != "format" {
@@ -273,7 +365,7 @@ func (s *Scrobbler) sign(params map[string]string) string {
 		sig.WriteString(k)
 		sig.WriteString(params[k])
 	}
-	sig.WriteString(s.apiSecret)
+	sig.WriteString(secret)
 
 	hash := md5.Sum([]byte(sig.String()))
 	return hex.EncodeToString(hash[:])
@@ -284,15 +376,19 @@ func (s *Scrobbler) SavePending() error {
 	pending := s.pending
 	s.mu.Unlock()
 
-	if len(pending) == 0 {
-		return nil
-	}
-
 	path, err := s.pendingPath()
 	if err != nil {
 		return err
 	}
 
+	// Nothing left: drop the file so flushed or deleted entries don't come back
+	if len(pending) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
@@ -1,6 +1,10 @@
 package lastfm
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -161,3 +165,66 @@ func TestSign(t *testing.T) {
 		t.Error("expected deterministic signature")
 	}
 }
+
+func TestLove(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		methods = append(methods, r.Form.Get("method"))
+		if r.Form.Get("track") != "Song" || r.Form.Get("artist") != "Band" || r.Form.Get("sk") != "session" {
+			t.Errorf("unexpected form %v", r.Form)
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	s := New("test", Config{APIKey: "key", APISecret: "secret", SessionKey: "session"})
+	s.endpoint = srv.URL
+	track := scrobble.Track{Title: "Song", Artist: "Band"}
+
+	if err := s.Love(context.Background(), track, true); err != nil {
+		t.Fatalf("love: %v", err)
+	}
+	if err := s.Love(context.Background(), track, false); err != nil {
+		t.Fatalf("unlove: %v", err)
+	}
+	if len(methods) != 2 || methods[0] != "track.love" || methods[1] != "track.unlove" {
+		t.Errorf("expected track.love then track.unlove, got %v", methods)
+	}
+
+	if err := New("off", Config{}).Love(context.Background(), track, true); err != scrobble.ErrNotConfigured {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		if r.Form.Get("method") != "user.getInfo" {
+			t.Errorf("unexpected method %q", r.Form.Get("method"))
+		}
+		if r.Form.Get("sk") != "session" {
+			w.Write([]byte(`{"error":9,"message":"Invalid session key"}`))
+			return
+		}
+		w.Write([]byte(`{"user":{"name":"someone"}}`))
+	}))
+	defer srv.Close()
+
+	s := New("test", Config{APIKey: "key", APISecret: "secret", SessionKey: "session"})
+	s.endpoint = srv.URL
+	if err := s.Check(context.Background()); err != nil {
+		t.Errorf("expected valid session, got %v", err)
+	}
+	s.SetSessionKey("stale")
+	if err := s.Check(context.Background()); err == nil || !strings.Contains(err.Error(), "Invalid session key") {
+		t.Errorf("expected invalid session error, got %v", err)
+	}
+	if err := New("off", Config{}).Check(context.Background()); err != scrobble.ErrNotConfigured {
+		t.Errorf("expected ErrNotConfigured, got %v", err)
+	}
+}
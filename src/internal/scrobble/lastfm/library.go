@@ -0,0 +1,120 @@
+package lastfm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strconv"
+
+	"github.com/tunez/tunez/internal/scrobble"
+)
+
+// importPageSize is the most tracks Last.fm returns per page.
+const importPageSize = 1000
+
+// UserTrack is a track from a Last.fm profile with its play count.
+type UserTrack struct {
+	Artist string
+	Title  string
+	Plays  int
+}
+
+// Username returns the name of the authorized user (user.getInfo).
+func (s *Scrobbler) Username(ctx context.Context) (string, error) {
+	if !s.IsEnabled() {
+		return "", scrobble.ErrNotConfigured
+	}
+	var resp struct {
+		User struct {
+			Name string `json:"name"`
+		} `json:"user"`
+	}
+	err := s.signedCall(ctx, map[string]string{
+		"method":  "user.getInfo",
+		"api_key": s.apiKey,
+		"sk":      s.session(),
+	}, &resp)
+	return resp.User.Name, err
+}
+
+// TopTracks returns every track user has played with its play count, most
+// played first (user.getTopTracks over all time).
+func (s *Scrobbler) TopTracks(ctx context.Context, user string) ([]UserTrack, error) {
+	return s.userTracks(ctx, "user.getTopTracks", "toptracks", user)
+}
+
+// LovedTracks returns every track user has loved (user.getLovedTracks).
+func (s *Scrobbler) LovedTracks(ctx context.Context, user string) ([]UserTrack, error) {
+	return s.userTracks(ctx, "user.getLovedTracks", "lovedtracks", user)
+}
+
+// userTracks pages through one of the user track lists.
+func (s *Scrobbler) userTracks(ctx context.Context, method, key, user string) ([]UserTrack, error) {
+	if !s.IsEnabled() {
+		return nil, scrobble.ErrNotConfigured
+	}
+	var out []UserTrack
+	for page := 1; ; page++ {
+		var resp map[string]struct {
+			Track json.RawMessage `json:"track"`
+			Attr  struct {
+				TotalPages string `json:"totalPages"`
+			} `json:"@attr"`
+		}
+		err := s.signedCall(ctx, map[string]string{
+			"method":  method,
+			"user":    user,
+			"period":  "overall",
+			"limit":   strconv.Itoa(importPageSize),
+			"page":    strconv.Itoa(page),
+			"api_key": s.apiKey,
+			"sk":      s.session(),
+		}, &resp)
+		if err != nil {
+			return out, err
+		}
+		list := resp[key]
+		tracks, err := decodeUserTracks(list.Track)
+		if err != nil {
+			return out, err
+		}
+		out = append(out, tracks...)
+		total, _ := strconv.Atoi(list.Attr.TotalPages)
+		if page >= total || len(tracks) == 0 {
+			return out, nil
+		}
+	}
+}
+
+// decodeUserTracks decodes a track list, which Last.fm sends as a single
+// object rather than an array when it holds one track. Numbers arrive as
+// strings.
+func decodeUserTracks(raw json.RawMessage) ([]UserTrack, error) {
+	type track struct {
+		Name      string `json:"name"`
+		Playcount string `json:"playcount"`
+		Artist    struct {
+			Name string `json:"name"`
+		} `json:"artist"`
+	}
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var items []track
+	if raw[0] == '{' {
+		var one track
+		if err := json.Unmarshal(raw, &one); err != nil {
+			return nil, err
+		}
+		items = []track{one}
+	} else if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, err
+	}
+	out := make([]UserTrack, 0, len(items))
+	for _, t := range items {
+		plays, _ := strconv.Atoi(t.Playcount)
+		out = append(out, UserTrack{Artist: t.Artist.Name, Title: t.Name, Plays: plays})
+	}
+	return out, nil
+}
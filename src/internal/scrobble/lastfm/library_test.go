@@ -0,0 +1,51 @@
+package lastfm
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestUserTracks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("parse form: %v", err)
+		}
+		switch r.Form.Get("method") + "/" + r.Form.Get("page") {
+		case "user.getInfo/":
+			w.Write([]byte(`{"user":{"name":"someone"}}`))
+		case "user.getTopTracks/1":
+			if r.Form.Get("user") != "someone" {
+				t.Errorf("unexpected user %q", r.Form.Get("user"))
+			}
+			w.Write([]byte(`{"toptracks":{"track":[{"name":"Innuendo","playcount":"12","artist":{"name":"Queen"}}],"@attr":{"totalPages":"2"}}}`))
+		case "user.getTopTracks/2":
+			w.Write([]byte(`{"toptracks":{"track":[{"name":"Jazz","playcount":"3","artist":{"name":"Queen"}}],"@attr":{"totalPages":"2"}}}`))
+		case "user.getLovedTracks/1":
+			// A single track comes as an object rather than an array
+			w.Write([]byte(`{"lovedtracks":{"track":{"name":"Dancing Queen","artist":{"name":"Abba"}},"@attr":{"totalPages":"1"}}}`))
+		default:
+			t.Errorf("unexpected request %v", r.Form)
+			w.Write([]byte(`{"error":6,"message":"Invalid parameters"}`))
+		}
+	}))
+	defer srv.Close()
+
+	s := New("test", Config{APIKey: "key", APISecret: "secret", SessionKey: "session"})
+	s.endpoint = srv.URL
+	ctx := context.Background()
+
+	user, err := s.Username(ctx)
+	if err != nil || user != "someone" {
+		t.Fatalf("Username = %q, %v", user, err)
+	}
+	top, err := s.TopTracks(ctx, user)
+	if err != nil || len(top) != 2 || top[0] != (UserTrack{Artist: "Queen", Title: "Innuendo", Plays: 12}) || top[1].Plays != 3 {
+		t.Errorf("TopTracks = %+v, %v", top, err)
+	}
+	loved, err := s.LovedTracks(ctx, user)
+	if err != nil || len(loved) != 1 || loved[0].Title != "Dancing Queen" || loved[0].Artist != "Abba" {
+		t.Errorf("LovedTracks = %+v, %v", loved, err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -27,6 +28,9 @@ type Config struct {
 	TokenProvider TokenProvider
 	// Alternative: direct token if not using provider
 	Token string
+	// Provider is the provider ID whose track IDs are Melodee song IDs.
+	// Tracks played from other providers are skipped. Defaults to "melodee".
+	Provider string
 }
 
 // Scrobbler implements scrobble.Scrobbler for Melodee API.
@@ -36,6 +40,7 @@ type Scrobbler struct {
 	baseURL       string
 	tokenProvider TokenProvider
 	staticToken   string
+	provider      string
 	client        *http.Client
 	pending       []scrobbleEntry
 	nowPlaying    *scrobble.Track
@@ -44,8 +49,9 @@ type Scrobbler struct {
 }
 
 type scrobbleEntry struct {
-	Track     scrobble.Track
-	Timestamp time.Time
+	Track         scrobble.Track
+	Timestamp     time.Time
+	PlayedSeconds int
 }
 
 // scrobbleRequest matches Melodee API ScrobbleRequest schema.
@@ -62,11 +68,16 @@ func New(id string, cfg Config) *Scrobbler {
 	if id == "" {
 		id = "melodee"
 	}
+	provider := cfg.Provider
+	if provider == "" {
+		provider = "melodee"
+	}
 	return &Scrobbler{
 		id:            id,
 		baseURL:       cfg.BaseURL,
 		tokenProvider: cfg.TokenProvider,
 		staticToken:   cfg.Token,
+		provider:      provider,
 		client:        &http.Client{Timeout: 10 * time.Second},
 	}
 }
@@ -95,10 +106,9 @@ func (s *Scrobbler) NowPlaying(ctx context.Context, track scrobble.Track) error
 	if !s.IsEnabled() {
 		return nil
 	}
-
-	// Track must have an ID for Melodee scrobbling
-	// The track ID should be passed through somehow - for now we skip if not available
-	// In practice, the app would need to pass the provider-specific track ID
+	if _, err := s.songID(track); err != nil {
+		return err
+	}
 	return s.sendScrobble(ctx, track, "NowPlaying", 0)
 }
 
@@ -140,6 +150,10 @@ func (s *Scrobbler) ShouldScrobble() bool {
 }
 
 func (s *Scrobbler) Scrobble(ctx context.Context, track scrobble.Track) error {
+	// Don't queue what the server could never accept
+	if _, err := s.songID(track); err != nil {
+		return err
+	}
 	if !s.IsEnabled() {
 		s.queueScrobble(track)
 		return nil
@@ -157,11 +171,20 @@ func (s *Scrobbler) Scrobble(ctx context.Context, track scrobble.Track) error {
 	return nil
 }
 
+// songID returns the Melodee song ID for track. Only tracks played from the
+// Melodee provider carry one.
+func (s *Scrobbler) songID(track scrobble.Track) (string, error) {
+	if track.Provider != s.provider || track.ProviderID == "" {
+		return "", scrobble.ErrUnsupportedTrack
+	}
+	return track.ProviderID, nil
+}
+
 func (s *Scrobbler) sendScrobble(ctx context.Context, track scrobble.Track, scrobbleType string, playedDuration int) error {
-	// Note: For Melodee, we need the song ID. This is a simplification -
-	// in practice, the Track struct may need to carry provider-specific IDs.
-	// For now, we'll use title as a fallback identifier.
-	songID := track.Title // This should be the actual Melodee song ID
+	songID, err := s.songID(track)
+	if err != nil {
+		return err
+	}
 
 	req := scrobbleRequest{
 		SongID:         songID,
@@ -202,6 +225,33 @@ func (s *Scrobbler) sendScrobble(ctx context.Context, track scrobble.Track, scro
 	return nil
 }
 
+// Check verifies the token by listing one of the user's playlists, which
+// needs an authenticated user.
+func (s *Scrobbler) Check(ctx context.Context) error {
+	if !s.IsEnabled() {
+		return scrobble.ErrNotConfigured
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.baseURL+"/api/v1/user/playlists?page=1&pageSize=1", nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.getToken())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return scrobble.ErrUnauthorized
+	}
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("melodee error: %s", resp.Status)
+	}
+	return nil
+}
+
 func (s *Scrobbler) PendingCount() int {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -219,9 +269,16 @@ func (s *Scrobbler) FlushPending(ctx context.Context) error {
 	s.mu.Unlock()
 
 	var failed []scrobbleEntry
+	var lastErr error
 	for _, entry := range pending {
-		if err := s.Scrobble(ctx, entry.Track); err != nil {
+		err := s.sendScrobble(ctx, entry.Track, "Scrobble", entry.PlayedSeconds)
+		if errors.Is(err, scrobble.ErrUnsupportedTrack) {
+			// Queued by older versions without a song ID; retrying can't help
+			continue
+		}
+		if err != nil {
 			failed = append(failed, entry)
+			lastErr = err
 		}
 	}
 
@@ -229,19 +286,44 @@ func (s *Scrobbler) FlushPending(ctx context.Context) error {
 		s.mu.Lock()
 		s.pending = append(failed, s.pending...)
 		s.mu.Unlock()
-		return fmt.Errorf("failed to scrobble %d tracks", len(failed))
+		return fmt.Errorf("failed to scrobble %d tracks: %w", len(failed), lastErr)
 	}
 
 	return nil
 }
 
+// PendingTracks returns the queued scrobbles, oldest first.
+func (s *Scrobbler) PendingTracks() []scrobble.Track {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tracks := make([]scrobble.Track, len(s.pending))
+	for i, e := range s.pending {
+		tracks[i] = e.Track
+	}
+	return tracks
+}
+
+// RemovePending drops a queued scrobble so it is never sent.
+func (s *Scrobbler) RemovePending(track scrobble.Track) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.pending {
+		if e.Track.SameAs(track) {
+			s.pending = append(s.pending[:i:i], s.pending[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
 func (s *Scrobbler) queueScrobble(track scrobble.Track) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
 	s.pending = append(s.pending, scrobbleEntry{
-		Track:     track,
-		Timestamp: track.StartedAt,
+		Track:         track,
+		Timestamp:     track.StartedAt,
+		PlayedSeconds: int(s.playDuration.Seconds()),
 	})
 
 	// Limit pending queue
@@ -255,15 +337,19 @@ func (s *Scrobbler) SavePending() error {
 	pending := s.pending
 	s.mu.Unlock()
 
-	if len(pending) == 0 {
-		return nil
-	}
-
 	path, err := s.pendingPath()
 	if err != nil {
 		return err
 	}
 
+	// Nothing left: drop the file so flushed or deleted entries don't come back
+	if len(pending) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+
 	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
 		return err
 	}
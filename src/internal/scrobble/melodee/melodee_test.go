@@ -0,0 +1,145 @@
+package melodee
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tunez/tunez/internal/scrobble"
+)
+
+// recorder captures scrobble requests sent to a fake Melodee server.
+type recorder struct {
+	mu       sync.Mutex
+	requests []scrobbleRequest
+}
+
+func (r *recorder) all() []scrobbleRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]scrobbleRequest(nil), r.requests...)
+}
+
+func newTestScrobbler(t *testing.T) (*Scrobbler, *recorder) {
+	t.Helper()
+	rec := &recorder{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/scrobble" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer tok" {
+			t.Errorf("unexpected auth header %q", got)
+		}
+		var req scrobbleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("decode body: %v", err)
+		}
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, req)
+		rec.mu.Unlock()
+	}))
+	t.Cleanup(srv.Close)
+	return New("melodee", Config{BaseURL: srv.URL, Token: "tok"}), rec
+}
+
+func TestScrobbleSendsSongID(t *testing.T) {
+	s, rec := newTestScrobbler(t)
+	started := time.Unix(1700000000, 0)
+	track := scrobble.Track{
+		Title:      "Song",
+		Artist:     "Band",
+		DurationMs: 200000,
+		StartedAt:  started,
+		Provider:   "melodee",
+		ProviderID: "song-42",
+	}
+
+	if err := s.NowPlaying(context.Background(), track); err != nil {
+		t.Fatalf("now playing: %v", err)
+	}
+	s.UpdatePosition(150*time.Second, false)
+	if err := s.Scrobble(context.Background(), track); err != nil {
+		t.Fatalf("scrobble: %v", err)
+	}
+
+	reqs := rec.all()
+	if len(reqs) != 2 {
+		t.Fatalf("expected 2 requests, got %d", len(reqs))
+	}
+	if reqs[0].SongID != "song-42" || reqs[0].ScrobbleType != "NowPlaying" {
+		t.Errorf("unexpected now playing request %+v", reqs[0])
+	}
+	want := scrobbleRequest{
+		SongID:         "song-42",
+		PlayerName:     "Tunez",
+		ScrobbleType:   "Scrobble",
+		Timestamp:      started.Unix(),
+		PlayedDuration: 150,
+	}
+	if reqs[1] != want {
+		t.Errorf("expected %+v, got %+v", want, reqs[1])
+	}
+}
+
+func TestScrobbleSkipsOtherProviders(t *testing.T) {
+	s, rec := newTestScrobbler(t)
+	local := scrobble.Track{Title: "Song", Artist: "Band", Provider: "filesystem", ProviderID: "/music/song.flac"}
+
+	if err := s.NowPlaying(context.Background(), local); !errors.Is(err, scrobble.ErrUnsupportedTrack) {
+		t.Errorf("expected ErrUnsupportedTrack, got %v", err)
+	}
+	if err := s.Scrobble(context.Background(), local); !errors.Is(err, scrobble.ErrUnsupportedTrack) {
+		t.Errorf("expected ErrUnsupportedTrack, got %v", err)
+	}
+	if n := len(rec.all()); n != 0 {
+		t.Errorf("expected no requests, got %d", n)
+	}
+	if n := s.PendingCount(); n != 0 {
+		t.Errorf("expected nothing queued, got %d", n)
+	}
+}
+
+func TestFlushPendingDropsEntriesWithoutSongID(t *testing.T) {
+	s, rec := newTestScrobbler(t)
+	s.pending = []scrobbleEntry{
+		{Track: scrobble.Track{Title: "Legacy"}},
+		{Track: scrobble.Track{Title: "Song", Provider: "melodee", ProviderID: "song-7"}, PlayedSeconds: 90},
+	}
+
+	if err := s.FlushPending(context.Background()); err != nil {
+		t.Fatalf("flush: %v", err)
+	}
+	reqs := rec.all()
+	if len(reqs) != 1 || reqs[0].SongID != "song-7" || reqs[0].PlayedDuration != 90 {
+		t.Errorf("expected only song-7 to be sent, got %+v", reqs)
+	}
+	if n := s.PendingCount(); n != 0 {
+		t.Errorf("expected queue to be empty, got %d", n)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/user/playlists" {
+			t.Errorf("unexpected path %q", r.URL.Path)
+		}
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	if err := New("melodee", Config{BaseURL: srv.URL, Token: "tok"}).Check(context.Background()); err != nil {
+		t.Errorf("expected valid token, got %v", err)
+	}
+	if err := New("melodee", Config{BaseURL: srv.URL, Token: "expired"}).Check(context.Background()); err != scrobble.ErrUnauthorized {
+		t.Errorf("expected ErrUnauthorized, got %v", err)
+	}
+}
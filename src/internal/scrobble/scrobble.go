@@ -9,6 +9,9 @@ var (
 	ErrNotConfigured = errors.New("scrobbling not configured")
 	ErrUnauthorized  = errors.New("unauthorized")
 	ErrRateLimited   = errors.New("rate limited")
+	// ErrUnsupportedTrack means the scrobbler can't identify the track, e.g.
+	// a server scrobbler given a track from a different provider.
+	ErrUnsupportedTrack = errors.New("track not supported by scrobbler")
 )
 
 // Track represents a track for scrobbling.
@@ -18,7 +21,11 @@ type Track struct {
 	Album      string
 	DurationMs int
 	StartedAt  time.Time
+	// Provider is the ID of the provider the track was played from
+	// (e.g., "melodee", "filesystem"). It scopes ProviderID.
+	Provider string
 	// ProviderID is the provider-specific track ID (e.g., Melodee song ID).
-	// Used by scrobblers that need provider-specific identifiers.
+	// Used by scrobblers that need provider-specific identifiers; only
+	// meaningful together with Provider.
 	ProviderID string
 }
@@ -1,6 +1,11 @@
 package scrobble_test
 
 import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -76,3 +81,219 @@ func TestManagerTotalPending(t *testing.T) {
 		t.Errorf("expected 2 total pending, got %d", mgr.TotalPendingCount())
 	}
 }
+
+// flakyScrobbler fails FlushPending until failures runs out.
+type flakyScrobbler struct {
+	mu       sync.Mutex
+	pending  int
+	failures int
+	attempts int
+}
+
+func (f *flakyScrobbler) ID() string      { return "flaky" }
+func (f *flakyScrobbler) Name() string    { return "Flaky" }
+func (f *flakyScrobbler) IsEnabled() bool { return true }
+func (f *flakyScrobbler) NowPlaying(context.Context, scrobble.Track) error {
+	return nil
+}
+func (f *flakyScrobbler) Scrobble(context.Context, scrobble.Track) error { return nil }
+func (f *flakyScrobbler) UpdatePosition(time.Duration, bool)             {}
+func (f *flakyScrobbler) ShouldScrobble() bool                           { return false }
+func (f *flakyScrobbler) SavePending() error                             { return nil }
+func (f *flakyScrobbler) LoadPending() error                             { return nil }
+
+func (f *flakyScrobbler) PendingCount() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.pending
+}
+
+func (f *flakyScrobbler) FlushPending(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.attempts++
+	if f.failures > 0 {
+		f.failures--
+		return errors.New("network down")
+	}
+	f.pending = 0
+	return nil
+}
+
+func (f *flakyScrobbler) Attempts() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.attempts
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("timed out waiting for condition")
+		}
+		time.Sleep(2 * time.Millisecond)
+	}
+}
+
+func TestManagerFlushLoopRetries(t *testing.T) {
+	mgr := scrobble.NewManager()
+	f := &flakyScrobbler{pending: 3, failures: 2}
+	mgr.Register(f)
+
+	var mu sync.Mutex
+	var errs []error
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr.StartFlushLoop(ctx, scrobble.FlushOptions{
+		Interval:   5 * time.Millisecond,
+		MaxBackoff: 20 * time.Millisecond,
+		OnFlush: func(id string, err error) {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		},
+	})
+
+	waitFor(t, func() bool { return f.PendingCount() == 0 })
+
+	if got := f.Attempts(); got != 3 {
+		t.Errorf("expected 3 flush attempts, got %d", got)
+	}
+	mu.Lock()
+	if len(errs) != 3 || errs[0] == nil || errs[2] != nil {
+		t.Errorf("expected two failures then success, got %v", errs)
+	}
+	mu.Unlock()
+
+	status := mgr.PendingStatus()
+	if len(status) != 1 {
+		t.Fatalf("expected 1 status, got %d", len(status))
+	}
+	if status[0].Failures != 0 || status[0].LastError != "" || status[0].LastFlush.IsZero() {
+		t.Errorf("expected clean status after success, got %+v", status[0])
+	}
+}
+
+func TestManagerFlushLoopWaitsForConnectivity(t *testing.T) {
+	mgr := scrobble.NewManager()
+	f := &flakyScrobbler{pending: 1}
+	mgr.Register(f)
+
+	var online atomic.Bool
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mgr.StartFlushLoop(ctx, scrobble.FlushOptions{
+		Interval: 5 * time.Millisecond,
+		Online:   func(context.Context) bool { return online.Load() },
+	})
+
+	waitFor(t, mgr.Offline)
+	time.Sleep(20 * time.Millisecond)
+	if f.Attempts() != 0 {
+		t.Errorf("expected no flush while offline, got %d attempts", f.Attempts())
+	}
+
+	online.Store(true)
+	waitFor(t, func() bool { return f.PendingCount() == 0 })
+	if mgr.Offline() {
+		t.Error("expected manager to report online after recovery")
+	}
+}
+
+// loverScrobbler records Love calls.
+type loverScrobbler struct {
+	flakyScrobbler
+	loved map[string]bool
+	err   error
+}
+
+func (l *loverScrobbler) Love(_ context.Context, track scrobble.Track, love bool) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.err != nil {
+		return l.err
+	}
+	l.loved[track.Title] = love
+	return nil
+}
+
+func TestManagerLove(t *testing.T) {
+	mgr := scrobble.NewManager()
+	plain := &flakyScrobbler{}
+	mgr.Register(plain)
+	if mgr.CanLove() {
+		t.Error("expected CanLove false without a Lover")
+	}
+
+	good := &loverScrobbler{loved: map[string]bool{}}
+	bad := &loverScrobbler{loved: map[string]bool{}, err: errors.New("boom")}
+	mgr.Register(good)
+	mgr.Register(bad)
+	if !mgr.CanLove() {
+		t.Error("expected CanLove true")
+	}
+
+	accepted, err := mgr.Love(context.Background(), scrobble.Track{Title: "Song"}, true)
+	if accepted != 1 {
+		t.Errorf("expected 1 backend to accept, got %d", accepted)
+	}
+	if err == nil || !strings.Contains(err.Error(), "boom") {
+		t.Errorf("expected joined error from failing backend, got %v", err)
+	}
+	if !good.loved["Song"] {
+		t.Error("expected track to be loved on working backend")
+	}
+}
+
+func TestManagerHistoryAndPendingEdit(t *testing.T) {
+	mgr := scrobble.NewManager()
+	mgr.Register(lastfm.New("lastfm1", lastfm.Config{})) // Disabled - will queue
+	mgr.Register(lastfm.New("lastfm2", lastfm.Config{}))
+	var mu sync.Mutex
+	outcomes := map[scrobble.SubmitStatus]int{}
+	mgr.OnResult(func(id string, status scrobble.SubmitStatus) {
+		mu.Lock()
+		defer mu.Unlock()
+		outcomes[status]++
+	})
+
+	track := scrobble.Track{Title: "Skipped", Artist: "Band", StartedAt: time.Now()}
+	mgr.Scrobble(context.Background(), track)
+	if err := mgr.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	if outcomes[scrobble.StatusQueued] != 2 || len(outcomes) != 1 {
+		t.Errorf("expected two queued outcomes to be reported, got %v", outcomes)
+	}
+	mu.Unlock()
+
+	history := mgr.History()
+	if len(history) != 1 || len(history[0].Results) != 2 {
+		t.Fatalf("expected 1 history entry with 2 results, got %+v", history)
+	}
+	for _, r := range history[0].Results {
+		if r.Status != scrobble.StatusQueued {
+			t.Errorf("expected %s queued, got %s", r.ID, r.Status)
+		}
+	}
+
+	items := mgr.PendingItems()
+	if len(items) != 2 {
+		t.Fatalf("expected 2 pending items, got %d", len(items))
+	}
+	if !mgr.RemovePending("lastfm1", items[0].Track) {
+		t.Fatal("expected pending scrobble to be removed")
+	}
+	if mgr.RemovePending("lastfm1", items[0].Track) {
+		t.Error("expected second removal to find nothing")
+	}
+	if got := mgr.TotalPendingCount(); got != 1 {
+		t.Errorf("expected 1 pending after removal, got %d", got)
+	}
+	if r := mgr.History()[0].Results[0]; r.ID != "lastfm1" || r.Status != scrobble.StatusRemoved {
+		t.Errorf("expected lastfm1 result to be marked removed, got %+v", r)
+	}
+}
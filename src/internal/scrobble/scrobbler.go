@@ -2,6 +2,8 @@ package scrobble
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -35,11 +37,36 @@ type Scrobbler interface {
 	FlushPending(ctx context.Context) error
 }
 
+// Lover is implemented by scrobblers that can mark tracks as loved
+// (Last.fm track.love, ListenBrainz feedback).
+type Lover interface {
+	// Love marks the track as loved, or removes the mark when love is false.
+	Love(ctx context.Context, track Track, love bool) error
+}
+
+// Checker is implemented by scrobblers that can verify their credentials
+// without submitting anything.
+type Checker interface {
+	// Check makes an authenticated request and returns ErrUnauthorized (or
+	// the backend's error) when the credentials are rejected.
+	Check(ctx context.Context) error
+}
+
 // Manager coordinates multiple scrobblers, fanning out events to all enabled backends.
 type Manager struct {
 	mu         sync.RWMutex
 	scrobblers []Scrobbler
 	wg         sync.WaitGroup
+
+	// Background flush state, see StartFlushLoop
+	flush   map[string]*flushState
+	offline bool
+
+	// Scrobbles submitted this session, oldest first
+	history []*HistoryEntry
+
+	// onResult is told every final per-backend outcome, see OnResult
+	onResult func(id string, status SubmitStatus)
 }
 
 // NewManager creates a new scrobbler manager.
@@ -54,6 +81,16 @@ func (m *Manager) Register(s Scrobbler) {
 	m.scrobblers = append(m.scrobblers, s)
 }
 
+// OnResult sets fn to be called with the outcome of every scrobble on each
+// backend, including queued scrobbles later sent by a flush. fn runs on the
+// submitting goroutine with the manager locked, so it must be quick and must
+// not call back into the manager.
+func (m *Manager) OnResult(fn func(id string, status SubmitStatus)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onResult = fn
+}
+
 // Scrobblers returns all registered scrobblers.
 func (m *Manager) Scrobblers() []Scrobbler {
 	m.mu.RLock()
@@ -101,16 +138,76 @@ func (m *Manager) Scrobble(ctx context.Context, track Track) {
 	scrobblers := m.scrobblers
 	m.mu.RUnlock()
 
+	entry := m.recordSubmit(track, scrobblers)
 	for _, s := range scrobblers {
 		// Call on all scrobblers - they handle queueing if not enabled
 		m.wg.Add(1)
 		go func(scrobbler Scrobbler) {
 			defer m.wg.Done()
-			_ = scrobbler.Scrobble(ctx, track)
+			enabled := scrobbler.IsEnabled()
+			err := scrobbler.Scrobble(ctx, track)
+			switch {
+			case errors.Is(err, ErrUnsupportedTrack):
+				m.setResult(entry, scrobbler.ID(), StatusSkipped, nil)
+			case err != nil:
+				m.setResult(entry, scrobbler.ID(), StatusFailed, err)
+			case !enabled:
+				m.setResult(entry, scrobbler.ID(), StatusQueued, nil)
+			default:
+				m.setResult(entry, scrobbler.ID(), StatusSent, nil)
+			}
 		}(s)
 	}
 }
 
+// CanLove reports whether any enabled scrobbler supports loving tracks.
+func (m *Manager) CanLove() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, s := range m.scrobblers {
+		if _, ok := s.(Lover); ok && s.IsEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+// Love loves or unloves a track on every enabled scrobbler that supports it.
+// It returns how many backends accepted the change and the joined errors of
+// those that failed.
+func (m *Manager) Love(ctx context.Context, track Track, love bool) (int, error) {
+	m.mu.RLock()
+	scrobblers := m.scrobblers
+	m.mu.RUnlock()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		errs     []error
+		accepted int
+	)
+	for _, s := range scrobblers {
+		lover, ok := s.(Lover)
+		if !ok || !s.IsEnabled() {
+			continue
+		}
+		wg.Add(1)
+		go func(id string, l Lover) {
+			defer wg.Done()
+			err := l.Love(ctx, track, love)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", id, err))
+			} else {
+				accepted++
+			}
+		}(s.ID(), lover)
+	}
+	wg.Wait()
+	return accepted, errors.Join(errs...)
+}
+
 // Wait blocks until all in-flight scrobble operations complete or the context is canceled.
 func (m *Manager) Wait(ctx context.Context) error {
 	done := make(chan struct{})
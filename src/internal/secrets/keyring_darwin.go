@@ -0,0 +1,66 @@
+//go:build darwin
+
+package secrets
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+const securityPath = "/usr/bin/security"
+
+// security(1) exits with this status when no item matches.
+const errSecItemNotFound = 44
+
+// Keyring returns the macOS login Keychain, accessed through security(1).
+func Keyring() Store {
+	return keychain{}
+}
+
+type keychain struct{}
+
+func (keychain) Get(name string) (string, error) {
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(securityPath, "find-generic-password", "-s", Service, "-a", name, "-w")
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("keychain lookup: %s", strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+func (keychain) Set(name, value string) error {
+	// Feed the command through interactive mode so the secret is not passed
+	// as an argument; -X takes the password hex-encoded.
+	script := fmt.Sprintf("add-generic-password -U -s %q -a %q -X %q\n", Service, name, hex.EncodeToString([]byte(value)))
+	var stderr bytes.Buffer
+	cmd := exec.Command(securityPath, "-i")
+	cmd.Stdin = strings.NewReader(script)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("keychain store: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (keychain) Delete(name string) error {
+	var stderr bytes.Buffer
+	cmd := exec.Command(securityPath, "delete-generic-password", "-s", Service, "-a", name)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == errSecItemNotFound {
+			return ErrNotFound
+		}
+		return fmt.Errorf("keychain delete: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+//go:build !darwin && !windows
+
+package secrets
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Keyring returns the Secret Service keyring (GNOME Keyring, KWallet),
+// accessed through libsecret's secret-tool.
+func Keyring() Store {
+	return secretTool{}
+}
+
+type secretTool struct{}
+
+func (secretTool) path() (string, error) {
+	p, err := exec.LookPath("secret-tool")
+	if err != nil {
+		return "", fmt.Errorf("%w: secret-tool not found (install libsecret-tools)", ErrUnavailable)
+	}
+	return p, nil
+}
+
+func (s secretTool) Get(name string) (string, error) {
+	tool, err := s.path()
+	if err != nil {
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(tool, "lookup", "service", Service, "account", name)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		// secret-tool exits 1 with no output when nothing matches
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && stderr.Len() == 0 {
+			return "", ErrNotFound
+		}
+		return "", fmt.Errorf("secret-tool lookup: %s", strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSuffix(stdout.String(), "\n"), nil
+}
+
+func (s secretTool) Set(name, value string) error {
+	tool, err := s.path()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	// The secret goes over stdin so it never shows up in the process list
+	cmd := exec.Command(tool, "store", "--label", "Tunez: "+name, "service", Service, "account", name)
+	cmd.Stdin = strings.NewReader(value)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secret-tool store: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+func (s secretTool) Delete(name string) error {
+	tool, err := s.path()
+	if err != nil {
+		return err
+	}
+	var stderr bytes.Buffer
+	cmd := exec.Command(tool, "clear", "service", Service, "account", name)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("secret-tool clear: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package secrets
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/tunez/tunez/internal/config"
+)
+
+// Migrated describes a plaintext secret moved into the keyring.
+type Migrated struct {
+	Section string
+	ID      string
+	Key     string
+	Name    string // keyring name now referenced from config
+}
+
+// Migrate moves plaintext credentials in the config file at path into the
+// keyring and rewrites each one as a keyring reference. Values that are
+// already references or empty are skipped. Each secret is stored before its
+// config line is rewritten, so an interrupted run never loses a credential.
+func Migrate(store Store, path string) ([]Migrated, error) {
+	raw, err := config.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		section, id string
+		settings    map[string]any
+	}
+	var entries []entry
+	for _, p := range raw.Profiles {
+		entries = append(entries, entry{config.SectionProfiles, p.ID, p.Settings})
+	}
+	for _, s := range raw.Scrobblers {
+		entries = append(entries, entry{config.SectionScrobblers, s.ID, s.Settings})
+	}
+
+	var moved []Migrated
+	for _, e := range entries {
+		keys := make([]string, 0, len(e.settings))
+		for k := range e.settings {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			value, ok := e.settings[key].(string)
+			if !ok || value == "" || !IsSecretKey(key) {
+				continue
+			}
+			if _, isRef := RefName(value); isRef {
+				continue
+			}
+			name := Name(e.section, e.id, key)
+			if err := store.Set(name, value); err != nil {
+				return moved, fmt.Errorf("store %s: %w", name, err)
+			}
+			if err := config.SetSetting(path, e.section, e.id, key, Ref(name)); err != nil {
+				return moved, fmt.Errorf("rewrite %s: %w", name, err)
+			}
+			moved = append(moved, Migrated{Section: e.section, ID: e.id, Key: key, Name: name})
+		}
+	}
+	return moved, nil
+}
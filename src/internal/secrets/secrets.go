@@ -0,0 +1,133 @@
+// Package secrets keeps passwords, tokens and API keys out of the plaintext
+// config. A setting whose value is "keyring:<name>" is looked up in the OS
+// keyring (Secret Service, macOS Keychain, Windows Credential Manager) under
+// the Tunez service.
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tunez/tunez/internal/config"
+)
+
+// Service is the keyring service/collection name secrets are stored under.
+const Service = "tunez"
+
+// RefPrefix marks a config value as a keyring reference.
+const RefPrefix = "keyring:"
+
+var (
+	// ErrNotFound means no secret is stored under the name.
+	ErrNotFound = errors.New("secret not found in keyring")
+	// ErrUnavailable means the OS keyring can't be reached (no Secret
+	// Service, missing tools, unsupported platform).
+	ErrUnavailable = errors.New("os keyring unavailable")
+)
+
+// Store is a named secret store.
+type Store interface {
+	Get(name string) (string, error)
+	Set(name, value string) error
+	Delete(name string) error
+}
+
+// secretKeys are settings that hold credentials and belong in the keyring.
+var secretKeys = map[string]bool{
+	"password":    true,
+	"token":       true,
+	"api_key":     true,
+	"api_secret":  true,
+	"session_key": true,
+}
+
+// IsSecretKey reports whether a settings key holds a credential.
+func IsSecretKey(key string) bool {
+	return secretKeys[key]
+}
+
+// Ref returns the config value that refers to the named secret.
+func Ref(name string) string {
+	return RefPrefix + name
+}
+
+// RefName returns the secret name when value is a keyring reference.
+func RefName(value string) (string, bool) {
+	if !strings.HasPrefix(value, RefPrefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(value, RefPrefix)
+	return name, name != ""
+}
+
+// Name returns the default keyring name for a setting, e.g.
+// "profiles/home/password".
+func Name(section, id, key string) string {
+	return section + "/" + id + "/" + key
+}
+
+// ResolveSettings returns a copy of settings with keyring references replaced
+// by the stored secrets. Other values are passed through untouched.
+func ResolveSettings(store Store, settings map[string]any) (map[string]any, error) {
+	if settings == nil {
+		return nil, nil
+	}
+	out := make(map[string]any, len(settings))
+	for k, v := range settings {
+		out[k] = v
+		s, ok := v.(string)
+		if !ok {
+			continue
+		}
+		name, ok := RefName(s)
+		if !ok {
+			continue
+		}
+		secret, err := store.Get(name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", k, name, err)
+		}
+		out[k] = secret
+	}
+	return out, nil
+}
+
+// ResolveConfig replaces keyring references in every profile and scrobbler
+// settings table with the stored secrets.
+func ResolveConfig(cfg *config.Config, store Store) error {
+	for i, p := range cfg.Profiles {
+		settings, err := ResolveSettings(store, p.Settings)
+		if err != nil {
+			return fmt.Errorf("profile %s: %w", p.ID, err)
+		}
+		cfg.Profiles[i].Settings = settings
+	}
+	for i, s := range cfg.Scrobblers {
+		settings, err := ResolveSettings(store, s.Settings)
+		if err != nil {
+			return fmt.Errorf("scrobbler %s: %w", s.ID, err)
+		}
+		cfg.Scrobblers[i].Settings = settings
+	}
+	return nil
+}
+
+// SaveSetting persists a setting that was obtained at runtime (e.g. a
+// Last.fm session key). If the config already refers to the keyring for
+// it, the keyring entry is updated and the file is left alone; otherwise the
+// value is written to the config file.
+func SaveSetting(store Store, cfgPath, section, id, key, value string) error {
+	raw, err := config.ReadFile(cfgPath)
+	if err != nil {
+		return err
+	}
+	if v, ok := raw.Setting(section, id, key); ok {
+		if s, ok := v.(string); ok {
+			if name, ok := RefName(s); ok {
+				return store.Set(name, value)
+			}
+		}
+	}
+	return config.SetSetting(cfgPath, section, id, key, value)
+}
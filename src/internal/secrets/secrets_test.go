@@ -0,0 +1,166 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// memStore is an in-memory Store for tests.
+type memStore map[string]string
+
+func (m memStore) Get(name string) (string, error) {
+	v, ok := m[name]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+func (m memStore) Set(name, value string) error {
+	m[name] = value
+	return nil
+}
+
+func (m memStore) Delete(name string) error {
+	delete(m, name)
+	return nil
+}
+
+func TestResolveSettings(t *testing.T) {
+	store := memStore{"profiles/home/password": "hunter2"}
+	in := map[string]any{
+		"base_url":  "https://music.example.com",
+		"password":  "keyring:profiles/home/password",
+		"page_size": int64(200),
+	}
+
+	out, err := ResolveSettings(store, in)
+	if err != nil {
+		t.Fatalf("ResolveSettings() error = %v", err)
+	}
+	if out["password"] != "hunter2" {
+		t.Errorf("expected password to resolve, got %v", out["password"])
+	}
+	if out["base_url"] != "https://music.example.com" || out["page_size"] != int64(200) {
+		t.Errorf("expected other settings to pass through, got %v", out)
+	}
+	if in["password"] != "keyring:profiles/home/password" {
+		t.Error("expected input map to be left untouched")
+	}
+
+	_, err = ResolveSettings(store, map[string]any{"token": "keyring:missing"})
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound for missing secret, got %v", err)
+	}
+}
+
+func TestRefName(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   string
+		wantOK bool
+	}{
+		{"keyring:scrobblers/lastfm/api_secret", "scrobblers/lastfm/api_secret", true},
+		{"keyring:", "", false},
+		{"plain", "", false},
+		{"", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := RefName(tt.value)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("RefName(%q) = %q, %v; want %q, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+const migrateDoc = `active_profile = "home"
+
+[[profiles]]
+id = "home"
+provider = "melodee"
+enabled = true
+[profiles.settings]
+base_url = "https://music.example.com"
+username = "steven"
+password = "hunter2"
+
+[[scrobblers]]
+id = "lastfm"
+type = "lastfm"
+[scrobblers.settings]
+api_key = "key"
+api_secret = "keyring:scrobblers/lastfm/api_secret"
+session_key = ""
+`
+
+func TestMigrate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(migrateDoc), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store := memStore{"scrobblers/lastfm/api_secret": "secret"}
+
+	moved, err := Migrate(store, path)
+	if err != nil {
+		t.Fatalf("Migrate() error = %v", err)
+	}
+	if len(moved) != 2 {
+		t.Fatalf("expected 2 migrated secrets, got %+v", moved)
+	}
+	if store["profiles/home/password"] != "hunter2" || store["scrobblers/lastfm/api_key"] != "key" {
+		t.Errorf("expected secrets in store, got %v", store)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc := string(data)
+	for _, want := range []string{
+		`password = "keyring:profiles/home/password"`,
+		`api_key = "keyring:scrobblers/lastfm/api_key"`,
+		`username = "steven"`,
+		`session_key = ""`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("expected config to contain %q, got:\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "hunter2") {
+		t.Error("expected plaintext password to be removed from config")
+	}
+
+	// Running again is a no-op
+	moved, err = Migrate(store, path)
+	if err != nil || len(moved) != 0 {
+		t.Errorf("expected second run to migrate nothing, got %+v, %v", moved, err)
+	}
+}
+
+func TestSaveSetting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	if err := os.WriteFile(path, []byte(migrateDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	store := memStore{}
+
+	// Referenced settings go to the keyring
+	if err := SaveSetting(store, path, "scrobblers", "lastfm", "api_secret", "rotated"); err != nil {
+		t.Fatal(err)
+	}
+	if store["scrobblers/lastfm/api_secret"] != "rotated" {
+		t.Errorf("expected keyring to be updated, got %v", store)
+	}
+
+	// Plain settings are written to the file
+	if err := SaveSetting(store, path, "scrobblers", "lastfm", "session_key", "sess"); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `session_key = "sess"`) {
+		t.Errorf("expected session key in config, got:\n%s", data)
+	}
+}
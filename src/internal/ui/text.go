@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// Ellipsis ends text cut to fit.
+const Ellipsis = "…"
+
+// cells measures text the way terminals lay it out: CJK and most emoji
+// take two cells, combining marks none. Characters of ambiguous width,
+// such as "—", take one, as lipgloss counts them, whatever the locale.
+var cells = &runewidth.Condition{EastAsianWidth: false, StrictEmojiNeutral: true}
+
+// Width returns how many terminal cells s takes, ignoring ANSI styling.
+func Width(s string) int {
+	if strings.Contains(s, "\x1b") {
+		s = ansi.Strip(s)
+	}
+	return cells.StringWidth(s)
+}
+
+// Truncate cuts s to at most width cells, ending it with an ellipsis when
+// cut. Wide characters are never split, and ANSI styling is kept.
+func Truncate(s string, width int) string {
+	if Width(s) <= width {
+		return s
+	}
+	if width < 1 {
+		return ""
+	}
+	if strings.Contains(s, "\x1b") {
+		return ansi.Truncate(s, width, Ellipsis)
+	}
+	return strings.TrimRight(cells.Truncate(s, width-1, ""), " ") + Ellipsis
+}
+
+// PadRight truncates s to width cells and fills it out to width with
+// spaces on the right.
+func PadRight(s string, width int) string {
+	s = Truncate(s, width)
+	return s + strings.Repeat(" ", max(width-Width(s), 0))
+}
+
+// PadLeft truncates s to width cells and fills it out to width with
+// spaces on the left.
+func PadLeft(s string, width int) string {
+	s = Truncate(s, width)
+	return strings.Repeat(" ", max(width-Width(s), 0)) + s
+}
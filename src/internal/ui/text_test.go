@@ -0,0 +1,62 @@
+package ui
+
+import "testing"
+
+func TestWidth(t *testing.T) {
+	tests := map[string]int{
+		"Abbey Road":              10,
+		"東京事変":                    8,
+		"Café":                    4,
+		"Café":                   4, // combining accent
+		"🎵 Music":                 8,
+		"The Beatles — Something": 23,
+		"\x1b[2m4:19\x1b[0m":      4,
+	}
+	for s, want := range tests {
+		if got := Width(s); got != want {
+			t.Errorf("Width(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"Come Together", 20, "Come Together"},
+		{"Come Together", 13, "Come Together"},
+		{"Come Together", 8, "Come To…"},
+		{"Here Comes the Sun", 11, "Here Comes…"},
+		// A wide character that doesn't fit whole is left out
+		{"東京事変 - 群青日和", 6, "東京…"},
+		{"東京事変 - 群青日和", 7, "東京事…"},
+		{"🎵🎶🎵", 4, "🎵…"},
+		{"abc", 1, "…"},
+		{"abc", 0, ""},
+		// Styling is kept
+		{"\x1b[2mlong dimmed text\x1b[0m", 6, "\x1b[2mlong …\x1b[0m"},
+	}
+	for _, tt := range tests {
+		got := Truncate(tt.s, tt.width)
+		if got != tt.want {
+			t.Errorf("Truncate(%q, %d) = %q, want %q", tt.s, tt.width, got, tt.want)
+		}
+		if Width(got) > tt.width {
+			t.Errorf("Truncate(%q, %d) is %d cells wide", tt.s, tt.width, Width(got))
+		}
+	}
+}
+
+func TestPad(t *testing.T) {
+	if got := PadRight("日本", 6); got != "日本  " {
+		t.Errorf("PadRight = %q", got)
+	}
+	if got := PadLeft("3:05", 5); got != " 3:05" {
+		t.Errorf("PadLeft = %q", got)
+	}
+	if got := PadRight("Something", 5); got != "Some…" {
+		t.Errorf("PadRight cut = %q", got)
+	}
+}
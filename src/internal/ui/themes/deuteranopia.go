@@ -0,0 +1,27 @@
+package themes
+
+import "github.com/charmbracelet/lipgloss"
+
+func init() {
+	Register("deuteranopia", Deuteranopia)
+}
+
+// Deuteranopia uses the Okabe-Ito palette, which stays distinct under
+// red-green color blindness: blue and orange in place of green and red.
+func Deuteranopia(noColor bool) Theme {
+	if noColor {
+		return NoColor(noColor)
+	}
+	return Theme{
+		Name:      "deuteranopia",
+		Accent:    lipgloss.NewStyle().Foreground(lipgloss.Color("#E69F00")).Bold(true),
+		Dim:       lipgloss.NewStyle().Foreground(lipgloss.Color("#999999")),
+		Text:      lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")),
+		Title:     lipgloss.NewStyle().Foreground(lipgloss.Color("#56B4E9")).Bold(true),
+		Error:     lipgloss.NewStyle().Foreground(lipgloss.Color("#D55E00")).Bold(true).Underline(true),
+		Success:   lipgloss.NewStyle().Foreground(lipgloss.Color("#0072B2")).Bold(true),
+		Warning:   lipgloss.NewStyle().Foreground(lipgloss.Color("#F0E442")).Bold(true),
+		Border:    lipgloss.NewStyle().Foreground(lipgloss.Color("#56B4E9")),
+		Highlight: lipgloss.NewStyle().Foreground(lipgloss.Color("#F0E442")).Bold(true).Underline(true),
+	}
+}
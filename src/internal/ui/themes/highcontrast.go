@@ -0,0 +1,27 @@
+package themes
+
+import "github.com/charmbracelet/lipgloss"
+
+func init() {
+	Register("highcontrast", HighContrast)
+}
+
+// HighContrast uses only pure white, yellow and cyan at full brightness,
+// with the selection drawn in reverse video, for low-vision users.
+func HighContrast(noColor bool) Theme {
+	if noColor {
+		return NoColor(noColor)
+	}
+	return Theme{
+		Name:      "highcontrast",
+		Accent:    lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true),
+		Dim:       lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")),
+		Text:      lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")),
+		Title:     lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF")).Bold(true),
+		Error:     lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Bold(true).Underline(true),
+		Success:   lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00")).Bold(true),
+		Warning:   lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true),
+		Border:    lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFFFF")),
+		Highlight: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFFF00")).Bold(true).Reverse(true),
+	}
+}
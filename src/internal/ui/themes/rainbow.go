@@ -22,5 +22,19 @@ func Rainbow(noColor bool) Theme {
 		Warning:   lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD166")).Bold(true),
 		Border:    lipgloss.NewStyle().Foreground(lipgloss.Color("#7C7CFF")),
 		Highlight: lipgloss.NewStyle().Foreground(lipgloss.Color("#FFA7C4")).Bold(true),
+		Visualizer: map[string][]lipgloss.Style{
+			"bars":     rainbowGradient(),
+			"mirrored": rainbowGradient(),
+		},
 	}
 }
+
+// rainbowGradient is the full spectrum, red to violet, in 256-color codes.
+func rainbowGradient() []lipgloss.Style {
+	codes := []string{"196", "202", "208", "214", "220", "226", "190", "154", "118", "82", "46", "47", "48", "49", "50", "51", "45", "39", "33", "27", "21", "57", "93", "129"}
+	styles := make([]lipgloss.Style, len(codes))
+	for i, c := range codes {
+		styles[i] = lipgloss.NewStyle().Foreground(lipgloss.Color(c))
+	}
+	return styles
+}
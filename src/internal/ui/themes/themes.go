@@ -15,6 +15,28 @@ type Theme struct {
 	Warning   lipgloss.Style
 	Border    lipgloss.Style
 	Highlight lipgloss.Style
+	// Visualizer holds color gradients for the visualizer styles, keyed
+	// by style name ("bars", "mirrored", "waveform", "vu") and spread
+	// left to right. Styles left out use VisualizerGradient's defaults.
+	Visualizer map[string][]lipgloss.Style
+}
+
+// VisualizerGradient returns the colors to draw visualizer style with: the
+// theme's own gradient, or Accent for the spectrum, Title for the
+// waveform and Success, Warning, Error for the meters.
+func (t Theme) VisualizerGradient(style string) []lipgloss.Style {
+	if g, ok := t.Visualizer[style]; ok {
+		return g
+	}
+	switch style {
+	case "waveform":
+		return []lipgloss.Style{t.Title}
+	case "vu":
+		// Green for most of the meter, then yellow and red near the top
+		return []lipgloss.Style{t.Success, t.Success, t.Success, t.Success, t.Success, t.Success, t.Warning, t.Warning, t.Error}
+	default:
+		return []lipgloss.Style{t.Accent}
+	}
 }
 
 // ThemeFunc is a constructor function for a theme.
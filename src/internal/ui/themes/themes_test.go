@@ -14,6 +14,9 @@ func TestGet(t *testing.T) {
 		{"mono", false, "mono"},
 		{"green", false, "green"},
 		{"nocolor", false, "nocolor"},
+		{"highcontrast", false, "highcontrast"},
+		{"deuteranopia", false, "deuteranopia"},
+		{"highcontrast", true, "nocolor"},
 		{"invalid", false, "rainbow"}, // falls back to rainbow
 		{"rainbow", true, "nocolor"},  // noColor overrides
 	}
@@ -70,3 +73,18 @@ func TestThemesNotPanic(t *testing.T) {
 		})
 	}
 }
+
+func TestVisualizerGradient(t *testing.T) {
+	rainbow := Get("rainbow", false)
+	if got := len(rainbow.VisualizerGradient("bars")); got != 24 {
+		t.Errorf("expected the rainbow theme's 24-color bars, got %d colors", got)
+	}
+	green := Get("green", false)
+	if g := green.VisualizerGradient("bars"); len(g) != 1 || g[0].GetForeground() != green.Accent.GetForeground() {
+		t.Errorf("expected bars to default to Accent, got %v", g)
+	}
+	vu := green.VisualizerGradient("vu")
+	if vu[0].GetForeground() != green.Success.GetForeground() || vu[len(vu)-1].GetForeground() != green.Error.GetForeground() {
+		t.Errorf("expected VU meters to run from Success to Error")
+	}
+}
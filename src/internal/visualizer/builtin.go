@@ -0,0 +1,233 @@
+package visualizer
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Backends selectable with [visualizer] backend.
+const (
+	BackendCava    = "cava"
+	BackendBuiltin = "builtin"
+	BackendOff     = "off"
+)
+
+// The builtin backend decodes the playing stream a second time with mpv,
+// as 16-bit stereo PCM on a pipe, and reads it at playback speed. Pipe
+// backpressure holds the decoder while tunez is paused.
+const (
+	sampleRate = 44100
+	frameRate  = 30
+	fftSize    = 2048
+	// Drift from the player's position that restarts the decoder, e.g.
+	// after a seek
+	maxDriftSeconds = 2.0
+)
+
+// Follow sets the stream the builtin backend decodes, from the start. The
+// cava backend captures system audio and ignores it.
+func (v *Visualizer) Follow(url string, headers map[string]string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.streamURL = url
+	v.streamHeaders = headers
+	if v.backend == BackendBuiltin && v.running {
+		v.startDecoder(0)
+	}
+}
+
+// Sync tells the builtin backend the player's position, so it can catch up
+// after a seek.
+func (v *Visualizer) Sync(pos float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.backend != BackendBuiltin || !v.running || v.streamURL == "" {
+		return
+	}
+	if math.Abs(v.decodedPos-pos) > maxDriftSeconds {
+		v.startDecoder(pos)
+	}
+}
+
+// SetPaused holds the builtin backend's decoder while playback is paused.
+func (v *Visualizer) SetPaused(paused bool) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.paused = paused
+}
+
+// startBuiltin is Start for the builtin backend; v.mu is held.
+func (v *Visualizer) startBuiltin(ctx context.Context) error {
+	if _, err := exec.LookPath(v.mpvPath); err != nil {
+		v.err = fmt.Errorf("builtin visualizer needs mpv: %w", err)
+		return v.err
+	}
+	v.base, v.cancel = context.WithCancel(ctx)
+	v.running = true
+	v.err = nil
+	if v.streamURL != "" {
+		v.startDecoder(0)
+	}
+	return nil
+}
+
+// startDecoder replaces the running decoder with one starting at pos
+// seconds; v.mu is held.
+func (v *Visualizer) startDecoder(pos float64) {
+	if v.stopDecoder != nil {
+		v.stopDecoder()
+	}
+	ctx, cancel := context.WithCancel(v.base)
+	v.stopDecoder = cancel
+	v.decoder++
+	v.decodedPos = pos
+	go v.decode(ctx, v.decoder, v.streamURL, v.streamHeaders, pos)
+}
+
+func (v *Visualizer) decode(ctx context.Context, id int, url string, headers map[string]string, pos float64) {
+	args := []string{
+		"--no-config", "--no-terminal", "--really-quiet", "--vid=no",
+		"--ao=pcm", "--ao-pcm-file=/dev/stdout", "--ao-pcm-waveheader=no",
+		"--audio-format=s16", "--audio-channels=stereo", "--audio-samplerate=" + strconv.Itoa(sampleRate),
+		"--start=" + strconv.FormatFloat(pos, 'f', 3, 64),
+	}
+	if len(headers) > 0 {
+		args = append(args, "--http-header-fields="+headerFields(headers))
+	}
+	args = append(args, "--", url)
+	cmd := exec.CommandContext(ctx, v.mpvPath, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		v.decoderFailed(ctx, id, fmt.Errorf("builtin visualizer: start mpv: %w", err))
+		return
+	}
+
+	window := make([]float64, fftSize)
+	buf := make([]byte, 4*sampleRate/frameRate)
+	ticker := time.NewTicker(time.Second / frameRate)
+	defer ticker.Stop()
+	for readErr := error(nil); readErr == nil; {
+		select {
+		case <-ctx.Done():
+			readErr = ctx.Err()
+			continue
+		case <-ticker.C:
+		}
+		v.mu.RLock()
+		paused := v.paused
+		v.mu.RUnlock()
+		if paused {
+			continue
+		}
+
+		var n int
+		n, readErr = io.ReadFull(stdout, buf)
+		samples := n / 4
+		var sumL, sumR float64
+		copy(window, window[samples:])
+		for i := range samples {
+			l := float64(int16(binary.LittleEndian.Uint16(buf[4*i:]))) / 32768
+			r := float64(int16(binary.LittleEndian.Uint16(buf[4*i+2:]))) / 32768
+			sumL += l * l
+			sumR += r * r
+			window[fftSize-samples+i] = (l + r) / 2
+		}
+		var levels [2]int
+		if samples > 0 {
+			levels = [2]int{v.level(math.Sqrt(sumL / float64(samples))), v.level(math.Sqrt(sumR / float64(samples)))}
+		}
+		v.setFrame(id, frame{
+			bars:   spectrum(window, sampleRate, v.barCount, v.maxValue),
+			levels: levels,
+			wave:   slices.Clone(window),
+		}, float64(samples)/sampleRate)
+	}
+
+	waitErr := cmd.Wait()
+	if ctx.Err() != nil {
+		return
+	}
+	if waitErr != nil {
+		v.decoderFailed(ctx, id, fmt.Errorf("builtin visualizer: mpv could not decode the stream: %s", lastLine(stderr.String(), waitErr)))
+		return
+	}
+	// End of the track: let the bars fall
+	v.setFrame(id, frame{bars: make([]int, v.barCount)}, 0)
+}
+
+// frame is what the decoder measured over one display frame.
+type frame struct {
+	bars   []int
+	levels [2]int    // left and right RMS, 0..maxValue
+	wave   []float64 // the latest mono samples, -1..1
+}
+
+// setFrame updates the display from decoder id, letting the bars and
+// meters fall back gradually rather than flicker.
+func (v *Visualizer) setFrame(id int, f frame, advance float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if id != v.decoder {
+		return
+	}
+	for i := range v.bars {
+		if i < len(f.bars) {
+			v.bars[i] = max(f.bars[i], v.bars[i]*85/100)
+		}
+	}
+	for i := range v.levels {
+		v.levels[i] = max(f.levels[i], v.levels[i]*85/100)
+	}
+	v.wave = f.wave
+	v.decodedPos += advance
+}
+
+// level scales an RMS amplitude to 0..maxValue on the same dB range as
+// the spectrum.
+func (v *Visualizer) level(rms float64) int {
+	l := (20*math.Log10(rms+1e-12) - floorDB) / -floorDB
+	return int(math.Max(0, math.Min(1, l)) * float64(v.maxValue))
+}
+
+func (v *Visualizer) decoderFailed(ctx context.Context, id int, err error) {
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if id != v.decoder {
+		return
+	}
+	v.err = err
+	v.running = false
+}
+
+// headerFields formats headers for mpv's --http-header-fields.
+func headerFields(headers map[string]string) string {
+	keys := make([]string, 0, len(headers))
+	for k := range headers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fields := make([]string, len(keys))
+	for i, k := range keys {
+		fields[i] = k + ": " + headers[k]
+	}
+	return strings.Join(fields, ",")
+}
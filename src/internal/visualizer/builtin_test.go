@@ -0,0 +1,122 @@
+package visualizer
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSpectrumPeak(t *testing.T) {
+	samples := make([]float64, fftSize)
+	for i := range samples {
+		samples[i] = math.Sin(2 * math.Pi * 1000 * float64(i) / sampleRate)
+	}
+	bars := spectrum(samples, sampleRate, 16, 1000)
+
+	peak := 0
+	for i, b := range bars {
+		if b > bars[peak] {
+			peak = i
+		}
+	}
+	// 1 kHz falls in band 8 (894-1283 Hz) of 16 log-spaced bands from 50 Hz
+	// to 16 kHz
+	if peak != 8 {
+		t.Errorf("expected the 1 kHz sine to peak in band 8, got band %d: %v", peak, bars)
+	}
+	if bars[peak] < 900 {
+		t.Errorf("expected a full-scale sine near the top, got %d", bars[peak])
+	}
+
+	silence := spectrum(make([]float64, fftSize), sampleRate, 16, 1000)
+	for i, b := range silence {
+		if b != 0 {
+			t.Errorf("band %d: expected silence to be 0, got %d", i, b)
+		}
+	}
+}
+
+// fakeMPV writes an mpv stand-in running script and returns its path.
+func fakeMPV(t *testing.T, script string) string {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as mpv")
+	}
+	path := filepath.Join(t.TempDir(), "mpv")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestBuiltinFollowsStream(t *testing.T) {
+	argsFile := filepath.Join(t.TempDir(), "args")
+	mpv := fakeMPV(t, `echo "$@" >> `+argsFile+`
+head -c 441000 /dev/zero
+`)
+	v := New(Config{Backend: BackendBuiltin, MPVPath: mpv})
+	v.Follow("http://music.example.com/stream/1", map[string]string{"Authorization": "Bearer x"})
+	if err := v.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	position := func() float64 {
+		v.mu.RLock()
+		defer v.mu.RUnlock()
+		return v.decodedPos
+	}
+	waitFor(t, "the decoder to advance", func() bool { return position() > 0.1 })
+
+	// A seek restarts the decoder at the player's position
+	v.Sync(90)
+	waitFor(t, "the decoder to restart", func() bool { return position() > 90 })
+
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	runs := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 decoder runs, got %q", runs)
+	}
+	for _, want := range []string{"--start=0.000", "--http-header-fields=Authorization: Bearer x", "-- http://music.example.com/stream/1"} {
+		if !strings.Contains(runs[0], want) {
+			t.Errorf("expected %q in %q", want, runs[0])
+		}
+	}
+	if !strings.Contains(runs[1], "--start=90.000") {
+		t.Errorf("expected the second run to start at 90s, got %q", runs[1])
+	}
+}
+
+func TestBuiltinDecodeFailure(t *testing.T) {
+	mpv := fakeMPV(t, "echo 'Failed to open http://music.example.com/stream/1.' >&2\nexit 2\n")
+	v := New(Config{Backend: BackendBuiltin, MPVPath: mpv})
+	v.Follow("http://music.example.com/stream/1", nil)
+	if err := v.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	waitFor(t, "the visualizer to stop", func() bool { return !v.Running() })
+	if err := v.Error(); err == nil || !strings.Contains(err.Error(), "Failed to open") {
+		t.Errorf("expected mpv's error, got %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package visualizer
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// fft transforms x in place. len(x) must be a power of two.
+func fft(x []complex128) {
+	n := len(x)
+	// Bit-reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	for size := 2; size <= n; size <<= 1 {
+		step := cmplx.Exp(complex(0, -2*math.Pi/float64(size)))
+		for start := 0; start < n; start += size {
+			w := complex(1, 0)
+			for k := 0; k < size/2; k++ {
+				a, b := x[start+k], x[start+k+size/2]*w
+				x[start+k], x[start+k+size/2] = a+b, a-b
+				w *= step
+			}
+		}
+	}
+}
+
+// Spectrum range and floor for the builtin backend's bars
+const (
+	lowHz   = 50.0
+	highHz  = 16000.0
+	floorDB = -60.0
+)
+
+// spectrum splits samples (mono, -1..1, a power of two long) into bands
+// log-spaced frequency bands and returns each band's peak level scaled to
+// 0..maxValue, where maxValue is a full-scale sine.
+func spectrum(samples []float64, sampleRate float64, bands, maxValue int) []int {
+	n := len(samples)
+	x := make([]complex128, n)
+	for i, s := range samples {
+		// Hann window
+		w := 0.5 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(n-1))
+		x[i] = complex(s*w, 0)
+	}
+	fft(x)
+
+	binHz := sampleRate / float64(n)
+	high := math.Min(highHz, sampleRate/2)
+	out := make([]int, bands)
+	for b := range out {
+		lo := lowHz * math.Pow(high/lowHz, float64(b)/float64(bands))
+		hi := lowHz * math.Pow(high/lowHz, float64(b+1)/float64(bands))
+		first := int(math.Ceil(lo / binHz))
+		last := max(int(math.Floor(hi/binHz)), first)
+		var peak float64
+		for k := first; k <= last && k < n/2; k++ {
+			// A Hann window halves a sine's amplitude
+			if a := cmplx.Abs(x[k]) * 4 / float64(n); a > peak {
+				peak = a
+			}
+		}
+		level := (20*math.Log10(peak+1e-12) - floorDB) / -floorDB
+		out[b] = int(math.Max(0, math.Min(1, level)) * float64(maxValue))
+	}
+	return out
+}
@@ -0,0 +1,52 @@
+package visualizer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+)
+
+// Inputs are the cava capture methods tunez accepts in [visualizer] input,
+// plus "auto".
+var Inputs = []string{"auto", "pulse", "pipewire", "alsa", "fifo", "portaudio", "sndio", "oss", "jack", "winscap"}
+
+// DetectInput picks the capture method for this system. PulseAudio is
+// preferred when its socket exists, since PipeWire's pulse shim serves it
+// too and every cava build supports it; then native PipeWire, then ALSA
+// (which needs the snd-aloop loopback device), then a FIFO.
+func DetectInput() string {
+	switch runtime.GOOS {
+	case "darwin":
+		return "portaudio"
+	case "windows":
+		return "winscap"
+	}
+	runtimeDir := os.Getenv("XDG_RUNTIME_DIR")
+	if runtimeDir == "" {
+		runtimeDir = filepath.Join("/run/user", strconv.Itoa(os.Getuid()))
+	}
+	pipewireDir := os.Getenv("PIPEWIRE_RUNTIME_DIR")
+	if pipewireDir == "" {
+		pipewireDir = runtimeDir
+	}
+	return detectInput(os.Getenv("PULSE_SERVER") != "", runtimeDir, pipewireDir, "/proc/asound")
+}
+
+func detectInput(pulseServer bool, runtimeDir, pipewireDir, asoundDir string) string {
+	switch {
+	case pulseServer || exists(filepath.Join(runtimeDir, "pulse", "native")):
+		return "pulse"
+	case exists(filepath.Join(pipewireDir, "pipewire-0")):
+		return "pipewire"
+	case exists(asoundDir):
+		return "alsa"
+	default:
+		return "fifo"
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,212 @@
+package visualizer
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Render styles, selectable with [visualizer] style and cycled on Now
+// Playing.
+const (
+	StyleBars     = "bars"
+	StyleMirrored = "mirrored"
+	StyleWaveform = "waveform"
+	StyleVU       = "vu"
+)
+
+// AllStyles lists every style in cycling order.
+var AllStyles = []string{StyleBars, StyleMirrored, StyleWaveform, StyleVU}
+
+// Styles lists the styles this backend can draw. The waveform needs the
+// samples themselves, which only the builtin backend has.
+func (v *Visualizer) Styles() []string {
+	if v.backend == BackendBuiltin {
+		return AllStyles
+	}
+	return []string{StyleBars, StyleMirrored, StyleVU}
+}
+
+// NextStyle returns the style after current, wrapping around.
+func (v *Visualizer) NextStyle(current string) string {
+	styles := v.Styles()
+	i := slices.Index(styles, current)
+	return styles[(i+1)%len(styles)]
+}
+
+var blocks = []rune{' ', '▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+
+// RenderStyle draws style in width columns (0 = bar count) and height rows
+// (0 = auto). Each column is colored from gradient, spread left to right;
+// an empty gradient draws without color. Styles the backend cannot draw
+// fall back to bars.
+func (v *Visualizer) RenderStyle(style string, width, height int, gradient []lipgloss.Style) string {
+	if !slices.Contains(v.Styles(), style) {
+		style = StyleBars
+	}
+	if width <= 0 {
+		width = v.barCount
+	}
+	if height <= 0 {
+		height = min(max((width+11)/12, 2), 6)
+	}
+
+	var rows [][]rune
+	switch style {
+	case StyleMirrored:
+		rows = v.mirroredRows(width, height)
+	case StyleWaveform:
+		rows = v.waveformRows(width, height)
+	case StyleVU:
+		return v.renderVU(width, gradient)
+	default:
+		rows = v.barRows(width, height)
+	}
+
+	lines := make([]string, len(rows))
+	for i, row := range rows {
+		lines[i] = "║" + paint(row, gradient) + "║"
+	}
+	return strings.Join(lines, "\n")
+}
+
+// columns stretches the bars to width columns scaled to 0..scale.
+func (v *Visualizer) columns(width, scale int) []int {
+	bars := v.Bars()
+	out := make([]int, width)
+	if len(bars) == 0 {
+		return out
+	}
+	for i := range out {
+		val := bars[min(i*len(bars)/width, len(bars)-1)]
+		out[i] = min(val*scale/v.maxValue, scale)
+	}
+	return out
+}
+
+// barRows draws bars growing up from the bottom, in eighths of a row.
+func (v *Visualizer) barRows(width, height int) [][]rune {
+	return barRowsFrom(v.columns(width, height*8), height)
+}
+
+// mirroredRows draws bars growing up from the middle with their reflection
+// below. Block characters only grow upward, so the reflection is drawn in
+// halves of a row.
+func (v *Visualizer) mirroredRows(width, height int) [][]rune {
+	half := max(height/2, 1)
+	cols := v.columns(width, half*8)
+	rows := barRowsFrom(cols, half)
+	for r := range half {
+		row := make([]rune, width)
+		for i, val := range cols {
+			switch rest := val - r*8; {
+			case rest >= 6:
+				row[i] = '█'
+			case rest >= 2:
+				row[i] = '▀'
+			default:
+				row[i] = ' '
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func barRowsFrom(cols []int, height int) [][]rune {
+	rows := make([][]rune, height)
+	for r := range rows {
+		rows[r] = make([]rune, len(cols))
+		threshold := (height - 1 - r) * 8
+		for i, val := range cols {
+			rows[r][i] = blocks[min(max(val-threshold, 0), 8)]
+		}
+	}
+	return rows
+}
+
+// waveformRows draws the latest samples as an oscilloscope trace, each
+// column spanning the lowest to highest sample it covers, in halves of a
+// row.
+func (v *Visualizer) waveformRows(width, height int) [][]rune {
+	v.mu.RLock()
+	wave := v.wave
+	v.mu.RUnlock()
+
+	sub := height * 2
+	toSub := func(s float64) int {
+		return min(max(int((1-s)/2*float64(sub-1)+0.5), 0), sub-1)
+	}
+	rows := make([][]rune, height)
+	for r := range rows {
+		rows[r] = []rune(strings.Repeat(" ", width))
+	}
+	for i := range width {
+		lo, hi := 0.0, 0.0
+		if len(wave) > 0 {
+			start := i * len(wave) / width
+			end := max((i+1)*len(wave)/width, start+1)
+			lo, hi = wave[start], wave[start]
+			for _, s := range wave[start:end] {
+				lo, hi = min(lo, s), max(hi, s)
+			}
+		}
+		top, bottom := toSub(hi), toSub(lo)
+		for r := range rows {
+			upper := 2*r >= top && 2*r <= bottom
+			lower := 2*r+1 >= top && 2*r+1 <= bottom
+			switch {
+			case upper && lower:
+				rows[r][i] = '█'
+			case upper:
+				rows[r][i] = '▀'
+			case lower:
+				rows[r][i] = '▄'
+			}
+		}
+	}
+	return rows
+}
+
+// eighths are left-aligned blocks from one to seven eighths wide.
+var eighths = []rune{'▏', '▎', '▍', '▌', '▋', '▊', '▉'}
+
+// renderVU draws left and right level meters, width columns long.
+func (v *Visualizer) renderVU(width int, gradient []lipgloss.Style) string {
+	v.mu.RLock()
+	levels := v.levels
+	v.mu.RUnlock()
+
+	lines := make([]string, 2)
+	for ch, label := range []string{"L ", "R "} {
+		fill := min(levels[ch]*width*8/v.maxValue, width*8)
+		row := []rune(strings.Repeat("█", fill/8))
+		if fill%8 > 0 {
+			row = append(row, eighths[fill%8-1])
+		}
+		row = append(row, []rune(strings.Repeat(" ", width-len(row)))...)
+		lines[ch] = label + paint(row, gradient)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// paint colors row from gradient by column, rendering runs of one color
+// together.
+func paint(row []rune, gradient []lipgloss.Style) string {
+	if len(gradient) == 0 {
+		return string(row)
+	}
+	var b strings.Builder
+	colorAt := func(i int) int { return i * len(gradient) / len(row) }
+	for start := 0; start < len(row); {
+		c := colorAt(start)
+		end := start + 1
+		for end < len(row) && colorAt(end) == c {
+			end++
+		}
+		b.WriteString(gradient[c].Render(string(row[start:end])))
+		start = end
+	}
+	return b.String()
+}
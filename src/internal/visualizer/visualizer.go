@@ -1,8 +1,10 @@
-// Package visualizer provides real-time audio spectrum visualization using CAVA.
+// Package visualizer provides real-time audio spectrum visualization using
+// CAVA, or an FFT of the playing stream when CAVA is not available.
 package visualizer
 
 import (
 	"bufio"
+	"bytes"
 	"context"
 	"fmt"
 	"os"
@@ -13,22 +15,45 @@ import (
 	"sync"
 )
 
-// Visualizer manages CAVA subprocess for audio spectrum analysis.
+// Visualizer manages the subprocess that feeds the audio spectrum.
 type Visualizer struct {
 	mu       sync.RWMutex
 	cmd      *exec.Cmd
 	cancel   context.CancelFunc
 	bars     []int
+	levels   [2]int    // left and right level for the VU style
+	wave     []float64 // latest samples for the waveform style; builtin only
 	barCount int
 	maxValue int
+	backend  string
+	input    string
+	source   string
 	running  bool
 	err      error
+
+	// Builtin backend state
+	mpvPath       string
+	base          context.Context
+	streamURL     string
+	streamHeaders map[string]string
+	paused        bool
+	stopDecoder   context.CancelFunc
+	decoder       int     // increments per decoder so stale ones are ignored
+	decodedPos    float64 // seconds of the stream consumed so far
 }
 
 // Config holds visualizer configuration.
 type Config struct {
-	BarCount int // Number of frequency bars (default: 24)
-	MaxValue int // Maximum bar value for scaling (default: 1000)
+	Backend  string // BackendCava (default) or BackendBuiltin
+	MPVPath  string // mpv binary for the builtin backend (default: "mpv")
+	BarCount int    // Number of frequency bars (default: 24)
+	MaxValue int    // Maximum bar value for scaling (default: 1000)
+	// Input is the cava capture method, e.g. "pipewire"; "" or "auto"
+	// uses DetectInput.
+	Input string
+	// Source is the capture device, sink monitor or FIFO path; "" leaves
+	// cava's default for the method.
+	Source string
 }
 
 // New creates a new Visualizer instance.
@@ -39,9 +64,22 @@ func New(cfg Config) *Visualizer {
 	if cfg.MaxValue <= 0 {
 		cfg.MaxValue = 1000
 	}
+	if cfg.Backend == "" {
+		cfg.Backend = BackendCava
+	}
+	if cfg.MPVPath == "" {
+		cfg.MPVPath = "mpv"
+	}
+	if cfg.Input == "" || cfg.Input == "auto" {
+		cfg.Input = DetectInput()
+	}
 	return &Visualizer{
 		barCount: cfg.BarCount,
 		maxValue: cfg.MaxValue,
+		backend:  cfg.Backend,
+		input:    cfg.Input,
+		source:   cfg.Source,
+		mpvPath:  cfg.MPVPath,
 		bars:     make([]int, cfg.BarCount),
 	}
 }
@@ -52,7 +90,8 @@ func Available() bool {
 	return err == nil
 }
 
-// Start begins the CAVA subprocess and starts reading spectrum data.
+// Start begins the CAVA subprocess and starts reading spectrum data. The
+// builtin backend decodes the stream set by Follow instead.
 func (v *Visualizer) Start(ctx context.Context) error {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -60,6 +99,9 @@ func (v *Visualizer) Start(ctx context.Context) error {
 	if v.running {
 		return nil
 	}
+	if v.backend == BackendBuiltin {
+		return v.startBuiltin(ctx)
+	}
 
 	if !Available() {
 		v.err = fmt.Errorf("cava not installed")
@@ -76,20 +118,25 @@ func (v *Visualizer) Start(ctx context.Context) error {
 	ctx, cancel := context.WithCancel(ctx)
 	v.cancel = cancel
 
-	v.cmd = exec.CommandContext(ctx, "cava", "-p", configPath)
-	v.cmd.Stderr = nil // Suppress stderr
+	cmd := exec.CommandContext(ctx, "cava", "-p", configPath)
+	// Kept to explain why capture failed if cava exits on its own
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
 
-	stdout, err := v.cmd.StdoutPipe()
+	stdout, err := cmd.StdoutPipe()
 	if err != nil {
+		cancel()
 		v.err = err
 		return err
 	}
 
-	if err := v.cmd.Start(); err != nil {
+	if err := cmd.Start(); err != nil {
+		cancel()
 		v.err = err
 		return err
 	}
 
+	v.cmd = cmd
 	v.running = true
 	v.err = nil
 
@@ -97,20 +144,24 @@ func (v *Visualizer) Start(ctx context.Context) error {
 	go func() {
 		scanner := bufio.NewScanner(stdout)
 		for scanner.Scan() {
-			select {
-			case <-ctx.Done():
-				v.mu.Lock()
-				v.running = false
-				v.mu.Unlock()
-				return
-			default:
-				line := scanner.Text()
-				v.parseLine(line)
+			if ctx.Err() != nil {
+				break
 			}
+			v.parseLine(scanner.Text())
 		}
+		err := cmd.Wait()
+
 		v.mu.Lock()
+		defer v.mu.Unlock()
+		if v.cmd != cmd {
+			// Stopped, and possibly restarted since
+			return
+		}
 		v.running = false
-		v.mu.Unlock()
+		v.cmd = nil
+		if ctx.Err() == nil {
+			v.err = captureError(v.input, stderr.String(), err)
+		}
 	}()
 
 	// Cleanup config file when done
@@ -122,7 +173,7 @@ func (v *Visualizer) Start(ctx context.Context) error {
 	return nil
 }
 
-// Stop terminates the CAVA subprocess.
+// Stop terminates the CAVA subprocess or the builtin decoder.
 func (v *Visualizer) Stop() {
 	v.mu.Lock()
 	defer v.mu.Unlock()
@@ -131,11 +182,13 @@ func (v *Visualizer) Stop() {
 		v.cancel()
 		v.cancel = nil
 	}
+	// The reader goroutine reaps the process
 	if v.cmd != nil && v.cmd.Process != nil {
 		v.cmd.Process.Kill()
-		v.cmd.Wait()
-		v.cmd = nil
 	}
+	v.cmd = nil
+	v.stopDecoder = nil
+	v.decoder++
 	v.running = false
 }
 
@@ -171,7 +224,18 @@ func (v *Visualizer) Running() bool {
 	return v.running
 }
 
-// Error returns any error that occurred.
+// Backend returns BackendCava or BackendBuiltin.
+func (v *Visualizer) Backend() string {
+	return v.backend
+}
+
+// Input returns the capture method cava is started with.
+func (v *Visualizer) Input() string {
+	return v.input
+}
+
+// Error returns any error that occurred, including cava exiting because it
+// could not capture audio with the configured input.
 func (v *Visualizer) Error() error {
 	v.mu.RLock()
 	defer v.mu.RUnlock()
@@ -184,11 +248,38 @@ func (v *Visualizer) parseLine(line string) {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
+	var sums [2]int
+	half := len(v.bars) / 2
 	for i := 0; i < len(v.bars) && i < len(parts); i++ {
 		if val, err := strconv.Atoi(strings.TrimSpace(parts[i])); err == nil {
 			v.bars[i] = val
 		}
+		// cava's default stereo output puts the left channel's bars in
+		// the left half and the right channel's in the right half
+		sums[min(i/max(half, 1), 1)] += v.bars[i]
+	}
+	if half > 0 {
+		v.levels = [2]int{sums[0] / half, sums[1] / (len(v.bars) - half)}
+	}
+}
+
+// captureError describes cava exiting while it should be running, using the
+// last line it printed to stderr when there is one.
+func captureError(input, stderr string, waitErr error) error {
+	return fmt.Errorf("audio capture with %s input failed: %s", input, lastLine(stderr, waitErr))
+}
+
+// lastLine returns the last line of a subprocess's stderr, or how it
+// exited when it printed nothing.
+func lastLine(stderr string, waitErr error) string {
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if last := strings.TrimSpace(lines[len(lines)-1]); last != "" {
+		return last
+	}
+	if waitErr != nil {
+		return waitErr.Error()
 	}
+	return "exited"
 }
 
 // writeConfig creates a temporary CAVA config file.
@@ -196,6 +287,11 @@ func (v *Visualizer) writeConfig() (string, error) {
 	tmpDir := os.TempDir()
 	configPath := filepath.Join(tmpDir, fmt.Sprintf("tunez-cava-%d.conf", os.Getpid()))
 
+	var sourceLine string
+	if v.source != "" {
+		sourceLine = fmt.Sprintf("source = %s\n", v.source)
+	}
+
 	config := fmt.Sprintf(`[general]
 bars = %d
 framerate = 30
@@ -204,9 +300,8 @@ autosens = 1
 overshoot = 20
 
 [input]
-method = pulse
-; Falls back to other methods automatically
-
+method = %s
+%s
 [output]
 method = raw
 data_format = ascii
@@ -222,7 +317,7 @@ gravity = 100
 
 [eq]
 ; Equal weight across bands
-`, v.barCount, v.maxValue)
+`, v.barCount, v.input, sourceLine, v.maxValue)
 
 	if err := os.WriteFile(configPath, []byte(config), 0644); err != nil {
 		return "", fmt.Errorf("write cava config: %w", err)
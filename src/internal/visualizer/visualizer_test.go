@@ -1,8 +1,14 @@
 package visualizer
 
 import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestNew(t *testing.T) {
@@ -142,3 +148,131 @@ func TestRenderRainbow(t *testing.T) {
 		t.Error("expected ANSI color codes in output")
 	}
 }
+
+func TestDetectInput(t *testing.T) {
+	dir := t.TempDir()
+	touch := func(path string) {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	asound := filepath.Join(dir, "asound")
+	runtimeDir := filepath.Join(dir, "run")
+
+	if got := detectInput(false, runtimeDir, runtimeDir, asound); got != "fifo" {
+		t.Errorf("nothing available: expected fifo, got %s", got)
+	}
+	touch(filepath.Join(asound, "cards"))
+	if got := detectInput(false, runtimeDir, runtimeDir, asound); got != "alsa" {
+		t.Errorf("ALSA only: expected alsa, got %s", got)
+	}
+	touch(filepath.Join(runtimeDir, "pipewire-0"))
+	if got := detectInput(false, runtimeDir, runtimeDir, asound); got != "pipewire" {
+		t.Errorf("pure PipeWire: expected pipewire, got %s", got)
+	}
+	if got := detectInput(true, runtimeDir, runtimeDir, asound); got != "pulse" {
+		t.Errorf("PULSE_SERVER set: expected pulse, got %s", got)
+	}
+	touch(filepath.Join(runtimeDir, "pulse", "native"))
+	if got := detectInput(false, runtimeDir, runtimeDir, asound); got != "pulse" {
+		t.Errorf("pipewire-pulse: expected pulse, got %s", got)
+	}
+}
+
+func TestWriteConfigInput(t *testing.T) {
+	v := New(Config{BarCount: 4, Input: "alsa", Source: "hw:Loopback,1"})
+	path, err := v.writeConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"method = alsa\n", "source = hw:Loopback,1\n"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in cava config:\n%s", want, data)
+		}
+	}
+}
+
+func TestCaptureFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses a shell script as cava")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\necho 'error connecting to pulseaudio server' >&2\nexit 1\n"
+	if err := os.WriteFile(filepath.Join(dir, "cava"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", dir)
+
+	v := New(Config{Input: "pulse"})
+	if err := v.Start(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	defer v.Stop()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for v.Running() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if v.Running() {
+		t.Fatal("expected the visualizer to stop when cava exits")
+	}
+	err := v.Error()
+	if err == nil || !strings.Contains(err.Error(), "pulse input") || !strings.Contains(err.Error(), "error connecting to pulseaudio server") {
+		t.Errorf("expected the capture error from cava's stderr, got %v", err)
+	}
+}
+
+func TestStyles(t *testing.T) {
+	cava := New(Config{Backend: BackendCava})
+	if got := cava.Styles(); slices.Contains(got, StyleWaveform) {
+		t.Errorf("expected no waveform without samples, got %v", got)
+	}
+	if got := cava.NextStyle(StyleMirrored); got != StyleVU {
+		t.Errorf("expected vu after mirrored with cava, got %s", got)
+	}
+	if got := cava.NextStyle(StyleVU); got != StyleBars {
+		t.Errorf("expected the cycle to wrap to bars, got %s", got)
+	}
+	builtin := New(Config{Backend: BackendBuiltin})
+	if got := builtin.NextStyle(StyleMirrored); got != StyleWaveform {
+		t.Errorf("expected waveform after mirrored with builtin, got %s", got)
+	}
+}
+
+func TestRenderStyles(t *testing.T) {
+	v := New(Config{Backend: BackendBuiltin, BarCount: 4, MaxValue: 100})
+	v.bars = []int{0, 50, 100, 25}
+	v.levels = [2]int{100, 50}
+	v.wave = []float64{1, 1, -1, -1}
+
+	mirrored := v.RenderStyle(StyleMirrored, 4, 4, nil)
+	want := "║  █ ║\n║ ██▄║\n║ ██▀║\n║  █ ║"
+	if mirrored != want {
+		t.Errorf("mirrored:\n%s\nwant:\n%s", mirrored, want)
+	}
+
+	vu := v.RenderStyle(StyleVU, 4, 0, nil)
+	if want := "L ████\nR ██  "; vu != want {
+		t.Errorf("vu:\n%q\nwant:\n%q", vu, want)
+	}
+
+	wave := v.RenderStyle(StyleWaveform, 4, 2, nil)
+	if want := "║▀▀  ║\n║  ▄▄║"; wave != want {
+		t.Errorf("waveform:\n%s\nwant:\n%s", wave, want)
+	}
+
+	// Without samples the cava backend falls back to bars
+	cava := New(Config{Backend: BackendCava, BarCount: 4, MaxValue: 100})
+	cava.bars = v.bars
+	if cava.RenderStyle(StyleWaveform, 4, 2, nil) != cava.RenderStyle(StyleBars, 4, 2, nil) {
+		t.Error("expected waveform to draw bars with cava")
+	}
+}